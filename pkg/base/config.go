@@ -167,6 +167,9 @@ type Config struct {
 	SSLCAKey string
 	// SSLCertsDir is the path to the certificate/key directory.
 	SSLCertsDir string
+	// CertRotationInterval, if non-zero, is the interval at which the
+	// server checks SSLCertsDir for changes and reloads the certificates.
+	CertRotationInterval time.Duration
 
 	// User running this process. It could be the user under which
 	// the server is running or the user passed in client calls.
@@ -266,6 +269,7 @@ func (cfg *Config) InitDefaults() {
 	cfg.SQLAddr = defaultSQLAddr
 	cfg.SQLAdvertiseAddr = cfg.SQLAddr
 	cfg.SSLCertsDir = DefaultCertsDirectory
+	cfg.CertRotationInterval = 0
 	cfg.RPCHeartbeatInterval = defaultRPCHeartbeatInterval
 	cfg.ClusterName = ""
 	cfg.DisableClusterNameVerification = false
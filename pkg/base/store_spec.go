@@ -185,6 +185,10 @@ type StoreSpec struct {
 	// Pebble OPTIONS file but treating any whitespace as a newline:
 	// (Eg, "[Options] delete_range_flush_delay=2s flush_split_bytes=4096")
 	PebbleOptions string
+	// Compression is the compression algorithm used for the sstables of the
+	// store. If left as pebble.DefaultCompression, the storage engine's default
+	// is used.
+	Compression pebble.Compression
 	// EncryptionOptions is a serialized protobuf set by Go CCL code and passed
 	// through to C CCL code to set up encryption-at-rest.  Must be set if and
 	// only if encryption is enabled, otherwise left empty.
@@ -225,6 +229,9 @@ func (ss StoreSpec) String() string {
 		}
 		fmt.Fprintf(&buffer, ",")
 	}
+	if ss.Compression != pebble.DefaultCompression {
+		fmt.Fprintf(&buffer, "compression=%s,", compressionNames[ss.Compression])
+	}
 	if len(ss.PebbleOptions) > 0 {
 		optsStr := strings.Replace(ss.PebbleOptions, "\n", " ", -1)
 		fmt.Fprint(&buffer, "pebble=")
@@ -243,6 +250,14 @@ func (ss StoreSpec) IsEncrypted() bool {
 	return len(ss.EncryptionOptions) > 0
 }
 
+// compressionNames maps the values accepted by the "compression" field of a
+// store spec to the compression algorithms.
+var compressionNames = map[pebble.Compression]string{
+	pebble.NoCompression:     "none",
+	pebble.SnappyCompression: "snappy",
+	pebble.ZstdCompression:   "zstd",
+}
+
 // fractionRegex is the regular expression that recognizes whether
 // the specified size is a fraction of the total available space.
 // Proportional sizes can be expressed as fractional numbers, either
@@ -273,6 +288,8 @@ var fractionRegex = regexp.MustCompile(`^([-]?([0-9]+\.[0-9]*|[0-9]*\.[0-9]+|[0-
 //   - 20%             -> 20% of the available space
 //   - 0.2             -> 20% of the available space
 // - attrs=xxx:yyy:zzz A colon separated list of optional attributes.
+// - compression=xxx The compression algorithm for the sstables of the store,
+//   one of zstd, snappy or none. Not allowed for in memory stores.
 // Note that commas are forbidden within any field name or value.
 func NewStoreSpec(value string) (StoreSpec, error) {
 	const pathField = "path"
@@ -361,6 +378,18 @@ func NewStoreSpec(value string) (StoreSpec, error) {
 			} else {
 				return StoreSpec{}, fmt.Errorf("%s is not a valid store type", value)
 			}
+		case "compression":
+			found := false
+			for c, name := range compressionNames {
+				if strings.ToLower(value) == name {
+					ss.Compression = c
+					found = true
+					break
+				}
+			}
+			if !found {
+				return StoreSpec{}, fmt.Errorf("%s is not a valid compression algorithm", value)
+			}
 		case "rocksdb":
 			ss.RocksDBOptions = value
 		case "pebble":
@@ -414,6 +443,9 @@ func NewStoreSpec(value string) (StoreSpec, error) {
 		if ss.BallastSize != nil {
 			return StoreSpec{}, fmt.Errorf("ballast-size specified for in memory store")
 		}
+		if ss.Compression != pebble.DefaultCompression {
+			return StoreSpec{}, fmt.Errorf("compression specified for in memory store")
+		}
 	} else if ss.Path == "" {
 		return StoreSpec{}, fmt.Errorf("no path specified")
 	}
@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble"
 	"github.com/stretchr/testify/require"
 )
 
@@ -160,6 +161,12 @@ target_file_size=2097152`
 		{fmt.Sprintf("path=/,pebble=%s", examplePebbleOptions), "", StoreSpec{Path: "/", PebbleOptions: examplePebbleOptions}},
 		{"path=/mnt/hda1,pebble=[Options] not_a_real_option=10", "pebble: unknown option: Options.not_a_real_option", StoreSpec{}},
 
+		// compression
+		{"path=/mnt/hda1,compression=zstd", "", StoreSpec{Path: "/mnt/hda1", Compression: pebble.ZstdCompression}},
+		{"path=/mnt/hda1,compression=Snappy", "", StoreSpec{Path: "/mnt/hda1", Compression: pebble.SnappyCompression}},
+		{"type=mem,size=20GiB,compression=none", "compression specified for in memory store", StoreSpec{}},
+		{"path=/mnt/hda1,compression=lz4", "lz4 is not a valid compression algorithm", StoreSpec{}},
+
 		// all together
 		{"path=/mnt/hda1,attrs=hdd:ssd,size=20GiB", "", StoreSpec{
 			Path:       "/mnt/hda1",
@@ -932,6 +932,16 @@ func createImportingDescriptors(
 				}
 			}
 
+			// The protected timestamp records of the tables with system versioning
+			// are not restored, and neither is their revision history, so system
+			// versioning is disabled on the restored tables. Similarly, the jobs
+			// that maintain the splits of the tables with a split policy are not
+			// restored, so the split policy is removed.
+			for _, table := range mutableTables {
+				table.SystemVersioning = nil
+				table.SplitPolicy = nil
+			}
+
 			// Write the new descriptors which are set in the OFFLINE state.
 			if err := ingesting.WriteDescriptors(
 				ctx, p.ExecCfg().Codec, txn, p.User(), descsCol, databases, writtenSchemas, tables, writtenTypes,
@@ -3690,6 +3690,12 @@ func TestChangefeedErrors(t *testing.T) {
 		t, `CHANGEFEED cannot target views: vw`,
 		`EXPERIMENTAL CHANGEFEED FOR vw`,
 	)
+	sqlDB.Exec(t, `CREATE TABLE rls (a INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `ALTER TABLE rls ENABLE ROW LEVEL SECURITY`)
+	sqlDB.ExpectErr(
+		t, `CHANGEFEED cannot target tables with row-level security enabled: rls`,
+		`EXPERIMENTAL CHANGEFEED FOR rls`,
+	)
 
 	sqlDB.ExpectErr(
 		t, `CHANGEFEED targets TABLE foo and TABLE foo are duplicates`,
@@ -47,6 +47,12 @@ func ValidateTable(
 		if tableDesc.IsSequence() {
 			return errors.Errorf(`CHANGEFEED cannot target sequences: %s`, tableDesc.GetName())
 		}
+		// The rows emitted by a changefeed don't go through the row-level
+		// security policies of the table.
+		if tableDesc.IsRowLevelSecurityEnabled() {
+			return errors.Errorf(
+				`CHANGEFEED cannot target tables with row-level security enabled: %s`, tableDesc.GetName())
+		}
 		switch t.Type {
 		case jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY:
 			if len(tableDesc.GetFamilies()) != 1 {
@@ -114,6 +114,12 @@ func fillEncryptionOptionsForStore(cfg *base.StorageConfig) error {
 	if err != nil {
 		return err
 	}
+	if opts == nil && len(storeEncryptionSpecs.Specs) > 0 {
+		// Opening the store without encryption would fail with a confusing
+		// corruption error.
+		return errors.Newf("no --%s flag matches the store directory %s",
+			cliflagsccl.EnterpriseEncryption.Name, cfg.Dir)
+	}
 
 	if opts != nil {
 		cfg.EncryptionOptions = opts
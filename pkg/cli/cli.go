@@ -66,15 +66,21 @@ func Main() {
 	err := doMain(cmd, cmdName)
 	errCode := exit.Success()
 	if err != nil {
-		// Display the error and its details/hints.
-		clierror.OutputError(stderr, err, true /*showSeverity*/, false /*verbose*/)
-
-		// Remind the user of which command was being run.
-		fmt.Fprintf(stderr, "Failed running %q\n", cmdName)
-
-		// Finally, extract the error code, as optionally specified
+		// Extract the error code, as optionally specified
 		// by the sub-command.
 		errCode = getExitCode(err)
+
+		if cliCtx.logFormat == logFormatJSON {
+			// Report the error, the command and the exit code as a
+			// single JSON object for automation.
+			clierror.OutputErrorJSON(stderr, err, cmdName, errCode)
+		} else {
+			// Display the error and its details/hints.
+			clierror.OutputError(stderr, err, true /*showSeverity*/, false /*verbose*/)
+
+			// Remind the user of which command was being run.
+			fmt.Fprintf(stderr, "Failed running %q\n", cmdName)
+		}
 	}
 
 	exit.WithCode(errCode)
@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/cli/cliflags"
 	"github.com/cockroachdb/cockroach/pkg/cli/clisqlcfg"
 	"github.com/cockroachdb/cockroach/pkg/security/clientsecopts"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/netutil/addr"
 	"github.com/spf13/cobra"
 )
@@ -32,7 +33,7 @@ func AddBaseFlags(
 
 	// --host/-h.
 	// Note that this flag is hidden for SQL commands.
-	cliflagcfg.VarFlagDepth(1, f, addr.NewAddrSetter(&clientOpts.ServerHost, &clientOpts.ServerPort), cliflags.ClientHost)
+	cliflagcfg.VarFlagDepth(1, f, addr.NewAddrListSetter(&clientOpts.ServerHost, &clientOpts.ServerPort, &clientOpts.AlternateServerAddrs), cliflags.ClientHost)
 
 	// The --port/-p flag is supported for backward compatibility with
 	// previous versions of CockroachDB. We also need the flag to be
@@ -88,6 +89,10 @@ func AddSQLFlags(
 		cliflagcfg.StringFlagDepth(1, f, &sqlCfg.InputFile, cliflags.File)
 		// --watch
 		cliflagcfg.DurationFlagDepth(1, f, &sqlCfg.ShellCtx.RepeatDelay, cliflags.Watch)
+		// --record-session
+		cliflagcfg.StringFlagDepth(1, f, &sqlCfg.ShellCtx.RecordSession, cliflags.RecordSession)
+		// --quiet
+		cliflagcfg.BoolFlagDepth(1, f, &sqlCfg.ShellCtx.Quiet, cliflags.Quiet)
 		// --safe-updates
 		cliflagcfg.VarFlagDepth(1, f, &sqlCfg.SafeUpdates, cliflags.SafeUpdates)
 		// The "safe-updates" flag is tri-valued (true, false, not-specified).
@@ -97,6 +102,8 @@ func AddSQLFlags(
 
 		// --read-only
 		cliflagcfg.BoolFlagDepth(1, f, &sqlCfg.ReadOnly, cliflags.ReadOnly)
+		// --max-result-memory
+		cliflagcfg.VarFlagDepth(1, f, humanizeutil.NewBytesValue(&sqlCfg.ExecCtx.MaxResultMemory), cliflags.MaxResultMemory)
 
 		// --debug-sql-cli
 		cliflagcfg.BoolFlagDepth(1, f, &sqlCfg.ConnCtx.DebugMode, cliflags.CliDebugMode)
@@ -186,6 +186,11 @@ func (u *urlParser) SetPort(port string) {
 	u.fl.Lookup(cliflags.ClientPort.Name).Changed = true
 }
 
+// SetAlternateServerAddrs implements the clientsecopts.CLIFlagInterfaceForClientURL interface.
+func (u *urlParser) SetAlternateServerAddrs(addrs []string) {
+	u.clientOpts.AlternateServerAddrs = addrs
+}
+
 // SetDatabase implements the clientsecopts.CLIFlagInterfaceForClientURL interface.
 func (u *urlParser) SetDatabase(db string) {
 	if f := u.fl.Lookup(cliflags.Database.Name); f == nil {
@@ -12,9 +12,11 @@ package clierror
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/cli/exit"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
@@ -22,6 +24,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestOutputError(t *testing.T) {
@@ -80,6 +83,33 @@ func TestOutputError(t *testing.T) {
 	}
 }
 
+func TestOutputErrorJSON(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	errBase := errors.New("woo")
+	testData := []struct {
+		err error
+		exp string
+	}{
+		{errBase, `{"command":"node ls","severity":"ERROR","message":"woo","exit_code":1}`},
+		{pgerror.WithCandidateCode(errors.WithHint(errors.WithDetail(errBase, "a"), "b"), pgcode.Syntax),
+			`{"command":"node ls","severity":"ERROR","message":"woo","sqlstate":"42601","detail":"a","hint":"b","exit_code":1}`},
+		{&pq.Error{Severity: "W", Message: "woo", Code: "23505", Constraint: "c"},
+			`{"command":"node ls","severity":"W","message":"woo","sqlstate":"23505","constraint":"c","exit_code":1}`},
+		{NewFormattedError(errBase, true, false), `{"command":"node ls","severity":"ERROR","message":"woo","exit_code":1}`},
+	}
+
+	tsRe := regexp.MustCompile(`^{"timestamp":"\d+\.\d{9}",`)
+	for _, tc := range testData {
+		var buf strings.Builder
+		OutputErrorJSON(&buf, tc.err, "node ls", exit.UnspecifiedError())
+		out := buf.String()
+		require.Regexp(t, tsRe, out)
+		assert.Equal(t, tc.exp+"\n", "{"+tsRe.ReplaceAllString(out, ""))
+	}
+}
+
 func TestFormatLocation(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -11,13 +11,16 @@
 package clierror
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"strconv"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/cli/exit"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq"
 )
@@ -38,6 +41,55 @@ func OutputError(w io.Writer, err error, showSeverity, verbose bool) {
 	fmt.Fprintln(w, f.Error())
 }
 
+// OutputErrorJSON prints out an error object on the given writer as
+// a single-line JSON object, for use by automation. The object
+// contains the timestamp at which the error is reported, in the same
+// format as the JSON logging format, the name of the command that
+// failed, the parts of the error displayed by OutputError and the
+// exit code of the command.
+func OutputErrorJSON(w io.Writer, err error, command string, exitCode exit.Code) {
+	// If we're applying recursively, report the original error.
+	if other := (*formattedError)(nil); errors.As(err, &other) {
+		err = other.err
+	}
+	fields := extractErrorFields(err)
+	now := timeutil.Now().UnixNano()
+	rec := jsonError{
+		Timestamp:  fmt.Sprintf("%d.%09d", now/1e9, now%1e9),
+		Command:    command,
+		Severity:   fields.severity,
+		Message:    fields.message,
+		Detail:     fields.detail,
+		Constraint: fields.constraintName,
+		Hint:       fields.hint,
+		ExitCode:   json.Number(exitCode.String()),
+	}
+	// As in OutputError, the uncategorized code is not reported.
+	if fields.code != pgcode.Uncategorized {
+		rec.SQLState = fields.code.String()
+	}
+	out, jerr := json.Marshal(rec)
+	if jerr != nil {
+		// Fall back to the human-readable output.
+		OutputError(w, err, true /* showSeverity */, false /* verbose */)
+		return
+	}
+	fmt.Fprintln(w, string(out))
+}
+
+// jsonError is the object printed by OutputErrorJSON.
+type jsonError struct {
+	Timestamp  string      `json:"timestamp"`
+	Command    string      `json:"command"`
+	Severity   string      `json:"severity"`
+	Message    string      `json:"message"`
+	SQLState   string      `json:"sqlstate,omitempty"`
+	Detail     string      `json:"detail,omitempty"`
+	Constraint string      `json:"constraint,omitempty"`
+	Hint       string      `json:"hint,omitempty"`
+	ExitCode   json.Number `json:"exit_code"`
+}
+
 // NewFormattedError wraps the error into another error object that displays
 // the details of the error when the error is formatted.
 // This constructor takes care of avoiding a wrap if the error is
@@ -71,31 +123,10 @@ func (f *formattedError) Error() string {
 	}
 	var buf strings.Builder
 
-	// If the severity is missing, we're going to assume it's an error.
-	severity := "ERROR"
-
-	// Extract the fields.
-	var message, hint, detail, location, constraintName string
-	var code pgcode.Code
-	if pqErr := (*pq.Error)(nil); errors.As(f.err, &pqErr) {
-		if pqErr.Severity != "" {
-			severity = pqErr.Severity
-		}
-		constraintName = pqErr.Constraint
-		message = pqErr.Message
-		code = pgcode.MakeCode(string(pqErr.Code))
-		hint, detail = pqErr.Hint, pqErr.Detail
-		location = formatLocation(pqErr.File, pqErr.Line, pqErr.Routine)
-	} else {
-		message = f.err.Error()
-		code = pgerror.GetPGCode(f.err)
-		// Extract the standard hint and details.
-		hint = errors.FlattenHints(f.err)
-		detail = errors.FlattenDetails(f.err)
-		if file, line, fn, ok := errors.GetOneLineSource(f.err); ok {
-			location = formatLocation(file, strconv.FormatInt(int64(line), 10), fn)
-		}
-	}
+	fields := extractErrorFields(f.err)
+	severity, message, code := fields.severity, fields.message, fields.code
+	hint, detail := fields.hint, fields.detail
+	location, constraintName := fields.location, fields.constraintName
 
 	// The order of the printing goes from most to less important.
 
@@ -141,6 +172,39 @@ func (f *formattedError) Error() string {
 	return strings.TrimRight(buf.String(), "\n")
 }
 
+// errorFields are the parts of an error reported to the user.
+type errorFields struct {
+	severity, message, hint, detail, location, constraintName string
+	code                                                      pgcode.Code
+}
+
+// extractErrorFields extracts the parts of an error to report.
+func extractErrorFields(err error) (f errorFields) {
+	// If the severity is missing, we're going to assume it's an error.
+	f.severity = "ERROR"
+
+	if pqErr := (*pq.Error)(nil); errors.As(err, &pqErr) {
+		if pqErr.Severity != "" {
+			f.severity = pqErr.Severity
+		}
+		f.constraintName = pqErr.Constraint
+		f.message = pqErr.Message
+		f.code = pgcode.MakeCode(string(pqErr.Code))
+		f.hint, f.detail = pqErr.Hint, pqErr.Detail
+		f.location = formatLocation(pqErr.File, pqErr.Line, pqErr.Routine)
+	} else {
+		f.message = err.Error()
+		f.code = pgerror.GetPGCode(err)
+		// Extract the standard hint and details.
+		f.hint = errors.FlattenHints(err)
+		f.detail = errors.FlattenDetails(err)
+		if file, line, fn, ok := errors.GetOneLineSource(err); ok {
+			f.location = formatLocation(file, strconv.FormatInt(int64(line), 10), fn)
+		}
+	}
+	return f
+}
+
 // formatLocation spells out the error's location in a format
 // similar to psql: routine then file:num. The routine part is
 // skipped if empty.
@@ -203,6 +203,15 @@ Display only the newly created cookie on the standard output
 without additional details and decoration.`,
 	}
 
+	ConvertURLCheckTLS = FlagInfo{
+		Name: "check-tls",
+		Description: `
+Connect to the server designated by the URL and check that the TLS
+parameters of the URL are compatible with the server: that TLS is
+enabled if and only if the server requires it, and that the server
+certificate can be verified as required by the sslmode parameter.`,
+	}
+
 	Cache = FlagInfo{
 		Name: "cache",
 		Description: `
@@ -221,7 +230,14 @@ This can be specified either as an address/hostname, or
 together with a port number as in -s myhost:26257.
 If the port number is left unspecified, it defaults to 26257.
 An IPv6 address can also be specified with the notation [...], for
-example [::1]:26257 or [fe80::f6f2:::]:26257.`,
+example [::1]:26257 or [fe80::f6f2:::]:26257.
+<PRE>
+
+</PRE>
+SQL client commands also accept a comma-separated list of nodes, as
+in --host=node1,node2:26258. The client connects to the first node
+that can be reached, and moves on to the next node in the list when
+its connection is lost.`,
 	}
 
 	ClientPort = FlagInfo{
@@ -290,6 +306,22 @@ with the specified period. The client will stop watching
 if an execution of the SQL statement(s) fail.`,
 	}
 
+	RecordSession = FlagInfo{
+		Name: "record-session",
+		Description: `
+Record every SQL statement executed by the shell, together with
+its latency and a fingerprint of its results, into the specified
+file. The recording can be re-executed against another cluster
+using 'cockroach sql replay', for example to validate an upgrade.`,
+	}
+
+	Quiet = FlagInfo{
+		Name: "quiet",
+		Description: `
+Suppress the informational messages of the shell, such as the progress
+reports of \copy.`,
+	}
+
 	EchoSQL = FlagInfo{
 		Name: "echo-sql",
 		Description: `
@@ -339,6 +371,15 @@ sql_safe_updates = FALSE.`,
 Set the session variable default_transaction_read_only to on.`,
 	}
 
+	MaxResultMemory = FlagInfo{
+		Name: "max-result-memory",
+		Description: `
+Maximum amount of memory used to buffer the result rows of a query
+when displaying them with --format=table. Beyond this amount, the
+rows are spilled to a temporary file before being displayed. The
+default, 0, means no limit.`,
+	}
+
 	Set = FlagInfo{
 		Name: "set",
 		Description: `
@@ -650,6 +691,15 @@ After the CockroachDB node has started up successfully, it will
 write its connection URL to the specified file.`,
 	}
 
+	ListeningHTTPURLFile = FlagInfo{
+		Name: "listening-http-url-file",
+		Description: `
+After the CockroachDB node has started up successfully, it will
+write the URL of its DB Console to the specified file. The URL uses
+the advertised HTTP address, and the file is only written once the
+HTTP listener has completed its TLS setup, if any.`,
+	}
+
 	PIDFile = FlagInfo{
 		Name: "pid-file",
 		Description: `
@@ -830,6 +880,16 @@ fields. It is permissible for the <cert-principal> string to contain colons.
 `,
 	}
 
+	CertRotationInterval = FlagInfo{
+		Name: "cert-rotation-interval",
+		Description: `
+If non-zero, the server checks the certificates directory for changes at
+the specified interval and reloads the certificates when they change,
+without requiring a restart or a SIGHUP signal. A reload is reported in
+the OPS logging channel by a certs_reload event.
+`,
+	}
+
 	CAKey = FlagInfo{
 		Name:        "ca-key",
 		EnvVar:      "COCKROACH_CA_KEY",
@@ -909,6 +969,15 @@ for example:
   --store=path=/mnt/ssd01,size=0.2             -> 20% of available space
   --store=path=/mnt/ssd01,size=.2              -> 20% of available space
 
+</PRE>
+The "compression" field sets the compression algorithm for the data files of the
+store, and can be one of "zstd", "snappy" (the default) or "none". Stores on the
+same node can use different algorithms, for example to trade CPU for I/O on
+slower devices (the field is not allowed for in-memory stores):
+<PRE>
+
+  --store=path=/mnt/ssd01 --store=path=/mnt/hda1,compression=zstd
+
 </PRE>
 For an in-memory store, the "type" and "size" fields are required, and the
 "path" field is forbidden. The "type" field must be set to "mem", and the
@@ -1021,6 +1090,12 @@ Connection URL, of the form:
 For example, postgresql://myuser@localhost:26257/mydb.
 <PRE>
 
+</PRE>
+The host part can also be a comma-separated list of nodes, as in
+postgresql://myuser@node1:26257,node2:26257/mydb. See --host for
+details.
+<PRE>
+
 </PRE>
 If left empty, the discrete connection flags are used: host, port,
 user, database, insecure, certs-dir.`,
@@ -1159,12 +1234,51 @@ in the history of the cluster.`,
 as target of the decommissioning or recommissioning command.`,
 	}
 
+	NodeDecommissionDryRun = FlagInfo{
+		Name: "dry-run",
+		Description: `Only run the decommission pre-checks and report the issues
+that would prevent the decommissioning from completing, without
+decommissioning the target nodes.`,
+	}
+
+	NodeDecommissionChecks = FlagInfo{
+		Name: "checks",
+		Description: `
+Specifies how the pre-checks run before decommissioning are handled. They
+verify that the remaining nodes are numerous enough to satisfy the
+replication factor and the constraints of the ranges of the target nodes, and
+that they have enough disk space to take over their data.
+Takes any of the following values:
+<PRE>
+
+  - enabled  runs the checks and reports the issues found as warnings before
+             decommissioning. This is the default.
+  - strict   runs the checks and aborts without decommissioning if any issue
+             is found.
+  - skip     does not run the checks.
+</PRE>`,
+	}
+
 	NodeDrainSelf = FlagInfo{
 		Name: "self",
 		Description: `Use the node ID of the node connected to via --host
 as target of the drain or quit command.`,
 	}
 
+	NodeDrainFormat = FlagInfo{
+		Name: "format",
+		Description: `
+Specifies the format of the drain progress reported every second while
+the node drains. Takes any of the following values:
+<PRE>
+
+  - text  prints human-readable progress lines to the standard error.
+          This is the default.
+  - json  prints one JSON object per line to the standard output,
+          for consumption by orchestration tooling.
+</PRE>`,
+	}
+
 	SQLFmtLen = FlagInfo{
 		Name: "print-width",
 		Description: `
@@ -1476,6 +1590,40 @@ confidential data or PII.
 `,
 	}
 
+	ZipRedactProfile = FlagInfo{
+		Name: "redact-profile",
+		Description: `
+Redact confidential data or PII from the contents of the zip file.
+Takes any of the following values:
+<PRE>
+
+  - none  does not redact anything beyond what --redact-logs and
+          --redact-rules request. This is the default.
+  - pii   redacts the log entries as per --redact-logs, and email and IP
+          addresses from all the text files. Profiles and goroutine dumps
+          are included as-is.
+  - full  redacts as per pii, and also SQL string literals from all the
+          text files. Profiles and goroutine dumps, which cannot be
+          redacted, are omitted.
+</PRE>`,
+	}
+
+	ZipRedactRules = FlagInfo{
+		Name: "redact-rules",
+		Description: `
+File of additional redaction rules, applied to each line of the text files of
+the zip file. Each line of the file is either empty, a comment starting with
+#, or a rule of the form:
+<PRE>
+
+  <regexp>
+  <regexp> => <replacement>
+</PRE>
+The matches of the regular expression are replaced by the replacement, in
+which $1 or ${name} refer to the submatches, or by ‹×› if there is
+no replacement.`,
+	}
+
 	ZipCPUProfileDuration = FlagInfo{
 		Name: "cpu-profile-duration",
 		Description: `
@@ -1492,6 +1640,27 @@ Can be set to 1 to ensure only one node is polled for data at a time.
 `,
 	}
 
+	HotRangesSortBy = FlagInfo{
+		Name: "sort-by",
+		Description: `
+The column to sort the hot ranges by, in descending order. One of
+qps, writes, reads, write-bytes or read-bytes.`,
+	}
+
+	HotRangesLimit = FlagInfo{
+		Name: "limit",
+		Description: `
+The maximum number of hot ranges to display. If set to 0, all the
+hot ranges reported by the nodes are displayed.`,
+	}
+
+	HotRangesWatch = FlagInfo{
+		Name: "watch",
+		Description: `
+Refresh the hot ranges report with the specified period, until
+interrupted.`,
+	}
+
 	StmtDiagDeleteAll = FlagInfo{
 		Name:        "all",
 		Description: `Delete all bundles.`,
@@ -1538,7 +1707,7 @@ that ignores the unsupported statements during an import.
 	ImportRowLimit = FlagInfo{
 		Name: "row-limit",
 		Description: `
-Specify the number of rows that will be imported for each table during a PGDUMP or MYSQLDUMP import.
+Specify the number of rows that will be imported for each table during an import.
 This can be used to check schema and data correctness without running the entire import.
 `,
 	}
@@ -1562,6 +1731,16 @@ This has the same effect as passing the content of the file via
 the --log flag.`,
 	}
 
+	LogFormat = FlagInfo{
+		Name: "log-format",
+		Description: `Format of the diagnostics printed to the standard error.
+Possible values: text, json. With json, the log entries printed to
+the standard error use the "json" logging format, and a command that
+fails reports its error as a single-line JSON object containing a
+timestamp, the name of the command, the error message, its SQLSTATE
+code if any and the process exit code.`,
+	}
+
 	DeprecatedStderrThreshold = FlagInfo{
 		Name:        "logtostderr",
 		Description: `Write log messages beyond the specified severity to stderr.`,
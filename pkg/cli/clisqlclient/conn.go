@@ -45,6 +45,11 @@ type sqlConn struct {
 	conn         DriverConn
 	reconnecting bool
 
+	// nextTarget is the index of the server that the next (re)connection
+	// is attempted to first: 0 for the server in url, or 1+i for the
+	// i-th server in connCtx.AlternateServerAddrs. See EnsureConn.
+	nextTarget int
+
 	// passwordMissing is true iff the url is missing a password.
 	passwordMissing bool
 
@@ -119,6 +124,7 @@ func (c *sqlConn) GetURL() string {
 // SetURL implements the Conn interface.
 func (c *sqlConn) SetURL(url string) {
 	c.url = url
+	c.nextTarget = 0
 }
 
 // GetDriverConn implements the Conn interface.
@@ -147,7 +153,35 @@ func (c *sqlConn) EnsureConn() error {
 		fmt.Fprintf(c.errw, "warning: connection lost!\n"+
 			"opening new connection: all session settings will be lost\n")
 	}
-	base, err := pq.NewConnector(c.url)
+	// Try the servers in turn, starting with the one following the
+	// server used by the previous connection.
+	numTargets := 1 + len(c.connCtx.AlternateServerAddrs)
+	var resErr error
+	for i := 0; i < numTargets; i++ {
+		target := (c.nextTarget + i) % numTargets
+		err := c.connect(ctx, target)
+		if err == nil {
+			c.nextTarget = (target + 1) % numTargets
+			c.reconnecting = false
+			return nil
+		}
+		if numTargets > 1 {
+			fmt.Fprintf(c.errw, "warning: unable to connect to %s: %v\n", c.targetAddr(target), err)
+		}
+		resErr = errors.CombineErrors(resErr, err)
+	}
+	return resErr
+}
+
+// connect establishes the connection to the target-th server: the
+// server in the connection URL for 0, or one of the alternate servers
+// otherwise.
+func (c *sqlConn) connect(ctx context.Context, target int) error {
+	connURL, err := c.targetURL(target)
+	if err != nil {
+		return wrapConnError(err)
+	}
+	base, err := pq.NewConnector(connURL)
 	if err != nil {
 		return wrapConnError(err)
 	}
@@ -177,7 +211,7 @@ func (c *sqlConn) EnsureConn() error {
 			// The recursion only occurs once because fillPassword()
 			// resets c.passwordMissing, so we cannot get into this
 			// conditional a second time.
-			return c.EnsureConn()
+			return c.connect(ctx, target)
 		}
 		// Not a password auth error, or password already set. Simply fail.
 		return wrapConnError(err)
@@ -190,14 +224,42 @@ func (c *sqlConn) EnsureConn() error {
 		}
 	}
 	c.conn = conn.(DriverConn)
+	// The server metadata query also serves as a health check: a server
+	// that cannot serve it is skipped in favor of the next one.
 	if err := c.checkServerMetadata(ctx); err != nil {
 		err = errors.CombineErrors(err, c.Close())
 		return wrapConnError(err)
 	}
-	c.reconnecting = false
 	return nil
 }
 
+// targetURL returns the connection URL for the target-th server. The
+// URL of an alternate server is the connection URL with its host part
+// replaced.
+func (c *sqlConn) targetURL(target int) (string, error) {
+	if target == 0 {
+		return c.url, nil
+	}
+	u, err := url.Parse(c.url)
+	if err != nil {
+		return "", err
+	}
+	u.Host = c.connCtx.AlternateServerAddrs[target-1]
+	return u.String(), nil
+}
+
+// targetAddr returns the address of the target-th server, for use in
+// messages.
+func (c *sqlConn) targetAddr(target int) string {
+	if target > 0 {
+		return c.connCtx.AlternateServerAddrs[target-1]
+	}
+	if u, err := url.Parse(c.url); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return "the server"
+}
+
 type showLastQueryStatsMode int
 
 const (
@@ -11,10 +11,13 @@
 package clisqlclient_test
 
 import (
+	"bytes"
 	"context"
 	"database/sql/driver"
 	"io/ioutil"
+	"net"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/cli"
@@ -102,6 +105,52 @@ func TestConnRecover(t *testing.T) {
 	}
 }
 
+func TestConnFailover(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := cli.NewCLITest(cli.TestCLIParams{T: t})
+	defer c.Cleanup()
+	ctx := context.Background()
+
+	// Find an address where no server is listening.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadAddr := ln.Addr().String()
+	if err := ln.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	url, cleanup := sqlutils.PGUrl(t, deadAddr, t.Name(), url.User(username.RootUser))
+	defer cleanup()
+
+	var warnings bytes.Buffer
+	sqlConnCtx := clisqlclient.Context{AlternateServerAddrs: []string{c.ServingSQLAddr()}}
+	conn := sqlConnCtx.MakeSQLConn(ioutil.Discard, &warnings, url.String())
+	defer func() {
+		if err := conn.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// The connection is established to the alternate server.
+	if err := conn.Exec(ctx, `SELECT 1`); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "warning: unable to connect to " + deadAddr; !strings.Contains(warnings.String(), expected) {
+		t.Fatalf("expected %q in warnings, got %q", expected, warnings.String())
+	}
+
+	// When no server can be reached, the errors of all the attempts
+	// are reported.
+	sqlConnCtx.AlternateServerAddrs = []string{deadAddr}
+	conn2 := sqlConnCtx.MakeSQLConn(ioutil.Discard, ioutil.Discard, url.String())
+	if err := conn2.EnsureConn(); err == nil {
+		t.Fatal("expected connection error")
+	}
+}
+
 // simulateServerRestart restarts the test server and reconfigures the connection
 // to use the new test server's port number. This is necessary because the port
 // number is selected randomly.
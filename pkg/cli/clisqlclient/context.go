@@ -36,6 +36,14 @@ type Context struct {
 	// EnableServerExecutionTimings determines whether to request (and
 	// display) server-side execution timings in the CLI.
 	EnableServerExecutionTimings bool
+
+	// AlternateServerAddrs are the host:port addresses of other servers
+	// of the cluster. When the server in the connection URL cannot be
+	// reached, the connection is established to these servers instead,
+	// in turn. Successive reconnections start with the server following
+	// the last one used, so that a lost connection fails over to another
+	// server.
+	AlternateServerAddrs []string
 }
 
 // IsInteractive returns true if the connection configuration
@@ -11,6 +11,7 @@
 package clisqlclient
 
 import (
+	"bufio"
 	"context"
 	"database/sql/driver"
 	"io"
@@ -114,3 +115,41 @@ func (c *CopyFromState) Commit(ctx context.Context, cleanupFunc func(), lines st
 		return rows, isMulti, err
 	}
 }
+
+// CommitFromReader completes a COPY FROM query by sending the lines read
+// from r to the database. If not nil, progressFn is called with the
+// number of lines sent so far after each line.
+func (c *CopyFromState) CommitFromReader(r io.Reader, progressFn func(numLines int)) QueryFn {
+	return func(ctx context.Context, conn Conn) (Rows, bool, error) {
+		rows, isMulti, err := func() (Rows, bool, error) {
+			br := bufio.NewReader(r)
+			for numLines := 1; ; numLines++ {
+				l, err := br.ReadString('\n')
+				if err != nil && err != io.EOF {
+					return nil, false, err
+				}
+				if l == "" && err == io.EOF {
+					break
+				}
+				if _, err := c.copyFromer.CopyData(ctx, strings.TrimSuffix(l, "\n")); err != nil {
+					return nil, false, err
+				}
+				if progressFn != nil {
+					progressFn(numLines)
+				}
+				if err == io.EOF {
+					break
+				}
+			}
+			res, err := c.copyFromer.Exec(nil)
+			if err != nil {
+				return nil, false, err
+			}
+			return copyFromRows{r: res}, false, c.Tx.Commit()
+		}()
+		if err != nil {
+			return rows, isMulti, errors.CombineErrors(err, errors.CombineErrors(c.copyFromer.Close(), c.Tx.Rollback()))
+		}
+		return rows, isMulti, err
+	}
+}
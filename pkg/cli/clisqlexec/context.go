@@ -45,6 +45,11 @@ type Context struct {
 
 	// VerboseTimings determines whether to show raw durations when reporting query latencies..
 	VerboseTimings bool
+
+	// MaxResultMemory, if positive, is the amount of result data that
+	// the 'table' display format buffers in memory before spilling the
+	// rows to a temporary file.
+	MaxResultMemory int64
 }
 
 // IsInteractive returns true if the connection configuration
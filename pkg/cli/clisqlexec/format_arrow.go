@@ -0,0 +1,209 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clisqlexec
+
+import (
+	"database/sql/driver"
+	"io"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/cockroachdb/errors"
+)
+
+// arrowRecordBatchSize is the maximum number of rows in a single record batch
+// of the Arrow stream.
+const arrowRecordBatchSize = 1024
+
+// arrowReporter reports the results as an Arrow IPC stream
+// (https://arrow.apache.org/docs/format/Columnar.html#ipc-streaming-format).
+//
+// When the rows come from a query result set, NULLs are reported as Arrow
+// nulls, and the integer, floating point, boolean and bytes columns use the
+// corresponding Arrow types. All other columns (e.g. decimals, timestamps or
+// arrays) are reported in their textual representation using the utf8 type.
+// When the rows are only available in their textual representation (e.g. the
+// output of the client-side commands), all columns use the utf8 type.
+type arrowReporter struct {
+	// valueIter, if set, provides the column types and the raw values of the
+	// rows.
+	valueIter rowValueIter
+	// arrowTypes are the Arrow types of the columns.
+	arrowTypes []arrow.DataType
+	builder    *array.RecordBuilder
+	writer     *ipc.Writer
+	// numBufferedRows is the number of rows in builder that haven't been
+	// written out yet.
+	numBufferedRows int
+}
+
+var _ valueRowReporter = &arrowReporter{}
+
+func (p *arrowReporter) setValueIter(iter rowValueIter) {
+	p.valueIter = iter
+}
+
+// arrowTypeForColumn returns the Arrow type used for a column with the given
+// database type name.
+func arrowTypeForColumn(colType string) arrow.DataType {
+	switch colType {
+	case "INT2":
+		return arrow.PrimitiveTypes.Int16
+	case "INT4":
+		return arrow.PrimitiveTypes.Int32
+	case "INT8":
+		return arrow.PrimitiveTypes.Int64
+	case "FLOAT4":
+		return arrow.PrimitiveTypes.Float32
+	case "FLOAT8":
+		return arrow.PrimitiveTypes.Float64
+	case "BOOL":
+		return arrow.FixedWidthTypes.Boolean
+	case "BYTEA":
+		return arrow.BinaryTypes.Binary
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+func (p *arrowReporter) describe(w io.Writer, cols []string) error {
+	var colTypes []string
+	if p.valueIter != nil {
+		colTypes = p.valueIter.columnTypeNames()
+	}
+	fields := make([]arrow.Field, len(cols))
+	p.arrowTypes = make([]arrow.DataType, len(cols))
+	for i, col := range cols {
+		p.arrowTypes[i] = arrow.BinaryTypes.String
+		if colTypes != nil {
+			p.arrowTypes[i] = arrowTypeForColumn(colTypes[i])
+		}
+		fields[i] = arrow.Field{Name: col, Type: p.arrowTypes[i], Nullable: true}
+	}
+	schema := arrow.NewSchema(fields, nil /* metadata */)
+	p.builder = array.NewRecordBuilder(memory.NewGoAllocator(), schema)
+	// Note that nothing is written until the first record batch is flushed
+	// or the writer is closed.
+	p.writer = ipc.NewWriter(w, ipc.WithSchema(schema))
+	return nil
+}
+
+func (p *arrowReporter) beforeFirstRow(_ io.Writer, _ RowStrIter) error { return nil }
+
+func (p *arrowReporter) iter(_, _ io.Writer, _ int, row []string) error {
+	var vals []driver.Value
+	if p.valueIter != nil {
+		vals = p.valueIter.values()
+	}
+	for i, val := range row {
+		if vals == nil {
+			p.builder.Field(i).(*array.StringBuilder).Append(val)
+			continue
+		}
+		if err := p.appendValue(i, vals[i], val); err != nil {
+			return err
+		}
+	}
+	p.numBufferedRows++
+	if p.numBufferedRows == arrowRecordBatchSize {
+		return p.flush()
+	}
+	return nil
+}
+
+// appendValue appends the value of the column with the given index to the
+// builder of the column. str is the textual representation of the value, used
+// for the utf8 columns.
+func (p *arrowReporter) appendValue(colIdx int, val driver.Value, str string) error {
+	fieldBuilder := p.builder.Field(colIdx)
+	if val == nil {
+		fieldBuilder.AppendNull()
+		return nil
+	}
+	switch b := fieldBuilder.(type) {
+	case *array.Int16Builder:
+		if v, ok := val.(int64); ok {
+			b.Append(int16(v))
+			return nil
+		}
+	case *array.Int32Builder:
+		if v, ok := val.(int64); ok {
+			b.Append(int32(v))
+			return nil
+		}
+	case *array.Int64Builder:
+		if v, ok := val.(int64); ok {
+			b.Append(v)
+			return nil
+		}
+	case *array.Float32Builder:
+		if v, ok := val.(float64); ok {
+			b.Append(float32(v))
+			return nil
+		}
+	case *array.Float64Builder:
+		if v, ok := val.(float64); ok {
+			b.Append(v)
+			return nil
+		}
+	case *array.BooleanBuilder:
+		if v, ok := val.(bool); ok {
+			b.Append(v)
+			return nil
+		}
+	case *array.BinaryBuilder:
+		if v, ok := val.([]byte); ok {
+			b.Append(v)
+			return nil
+		}
+	case *array.StringBuilder:
+		b.Append(str)
+		return nil
+	}
+	return errors.AssertionFailedf(
+		"unexpected value of type %T for arrow column of type %s", val, p.arrowTypes[colIdx],
+	)
+}
+
+// flush writes out the buffered rows as a single record batch.
+func (p *arrowReporter) flush() error {
+	rec := p.builder.NewRecord()
+	defer rec.Release()
+	p.numBufferedRows = 0
+	return p.writer.Write(rec)
+}
+
+func (p *arrowReporter) doneRows(w io.Writer, seenRows int) error {
+	if p.numBufferedRows > 0 {
+		if err := p.flush(); err != nil {
+			return err
+		}
+	}
+	// Closing the writer emits the schema if no record batches have been
+	// written as well as the end-of-stream marker.
+	return p.writer.Close()
+}
+
+func (p *arrowReporter) doneNoRows(_ io.Writer) error {
+	// The statement doesn't return rows, so we don't emit the stream at all.
+	return nil
+}
+
+// release releases the memory of the record builder. It must be called once
+// the reporter is no longer used, including when the rendering fails.
+func (p *arrowReporter) release() {
+	if p.builder != nil {
+		p.builder.Release()
+		p.builder = nil
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clisqlexec
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderArrow(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	cols := []string{"k", "v"}
+	for _, numRows := range []int{0, 1, arrowRecordBatchSize, 2*arrowRecordBatchSize + 1} {
+		t.Run(fmt.Sprintf("rows=%d", numRows), func(t *testing.T) {
+			rows := make([][]string, numRows)
+			for i := range rows {
+				rows[i] = []string{strconv.Itoa(i), fmt.Sprintf("v%d", i)}
+			}
+			var buf bytes.Buffer
+			reporter := &arrowReporter{}
+			defer reporter.release()
+			require.NoError(t, render(reporter, &buf, ioutil.Discard,
+				cols, NewRowSliceIter(rows, "rl"),
+				nil /* completedHook */, nil /* noRowsHook */))
+
+			r, err := ipc.NewReader(&buf)
+			require.NoError(t, err)
+			defer r.Release()
+			require.Len(t, r.Schema().Fields(), len(cols))
+			for i, col := range cols {
+				require.Equal(t, col, r.Schema().Field(i).Name)
+			}
+			var readRows int
+			for r.Next() {
+				rec := r.Record()
+				require.LessOrEqual(t, int(rec.NumRows()), arrowRecordBatchSize)
+				for i := 0; i < int(rec.NumRows()); i++ {
+					for j := range cols {
+						require.Equal(t, rows[readRows][j], rec.Column(j).(*array.String).Value(i))
+					}
+					readRows++
+				}
+			}
+			require.Equal(t, numRows, readRows)
+		})
+	}
+}
+
+// fakeRowValueIter is a rowValueIter over the given raw values.
+type fakeRowValueIter struct {
+	colTypes []string
+	rows     [][]driver.Value
+	idx      int
+}
+
+var _ rowValueIter = &fakeRowValueIter{}
+
+func (it *fakeRowValueIter) Next() ([]string, error) {
+	if it.idx >= len(it.rows) {
+		return nil, io.EOF
+	}
+	it.idx++
+	return formatRowValues(it.values(), it.colTypes, false /* showMoreChars */), nil
+}
+
+func (it *fakeRowValueIter) ToSlice() ([][]string, error) {
+	return nil, errors.New("unsupported")
+}
+
+func (it *fakeRowValueIter) Align() []int {
+	return nil
+}
+
+func (it *fakeRowValueIter) columnTypeNames() []string {
+	return it.colTypes
+}
+
+func (it *fakeRowValueIter) values() []driver.Value {
+	return it.rows[it.idx-1]
+}
+
+// TestRenderArrowTypes verifies that the values of a query result set are
+// reported with the Arrow types corresponding to their SQL types and that NULLs
+// are reported as Arrow nulls.
+func TestRenderArrowTypes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	cols := []string{"i2", "i4", "i8", "f4", "f8", "b", "by", "s", "d"}
+	iter := &fakeRowValueIter{
+		colTypes: []string{"INT2", "INT4", "INT8", "FLOAT4", "FLOAT8", "BOOL", "BYTEA", "TEXT", "NUMERIC"},
+		rows: [][]driver.Value{
+			{int64(1), int64(2), int64(3), 1.5, 2.5, true, []byte("ab"), []byte("NULL"), []byte("1.23")},
+			{nil, nil, nil, nil, nil, nil, nil, nil, nil},
+		},
+	}
+	var buf bytes.Buffer
+	reporter := &arrowReporter{}
+	defer reporter.release()
+	require.NoError(t, render(reporter, &buf, ioutil.Discard, cols, iter,
+		nil /* completedHook */, nil /* noRowsHook */))
+
+	r, err := ipc.NewReader(&buf)
+	require.NoError(t, err)
+	defer r.Release()
+	expectedTypes := []arrow.DataType{
+		arrow.PrimitiveTypes.Int16, arrow.PrimitiveTypes.Int32, arrow.PrimitiveTypes.Int64,
+		arrow.PrimitiveTypes.Float32, arrow.PrimitiveTypes.Float64, arrow.FixedWidthTypes.Boolean,
+		arrow.BinaryTypes.Binary, arrow.BinaryTypes.String, arrow.BinaryTypes.String,
+	}
+	for i := range cols {
+		require.Equal(t, expectedTypes[i], r.Schema().Field(i).Type)
+	}
+	require.True(t, r.Next())
+	rec := r.Record()
+	require.Equal(t, int64(2), rec.NumRows())
+	require.Equal(t, int16(1), rec.Column(0).(*array.Int16).Value(0))
+	require.Equal(t, int32(2), rec.Column(1).(*array.Int32).Value(0))
+	require.Equal(t, int64(3), rec.Column(2).(*array.Int64).Value(0))
+	require.Equal(t, float32(1.5), rec.Column(3).(*array.Float32).Value(0))
+	require.Equal(t, 2.5, rec.Column(4).(*array.Float64).Value(0))
+	require.True(t, rec.Column(5).(*array.Boolean).Value(0))
+	require.Equal(t, []byte("ab"), rec.Column(6).(*array.Binary).Value(0))
+	// The string 'NULL' is distinct from NULL.
+	require.Equal(t, "NULL", rec.Column(7).(*array.String).Value(0))
+	require.Equal(t, "1.23", rec.Column(8).(*array.String).Value(0))
+	for i := range cols {
+		require.False(t, rec.Column(i).IsNull(0))
+		require.True(t, rec.Column(i).IsNull(1))
+	}
+	require.False(t, r.Next())
+}
@@ -11,11 +11,15 @@
 package clisqlexec
 
 import (
+	"bufio"
 	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"html"
 	"io"
+	"os"
 	"reflect"
 	"strings"
 	"text/tabwriter"
@@ -26,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding/csv"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/errors"
 	"github.com/olekukonko/tablewriter"
@@ -101,17 +106,29 @@ type rowIter struct {
 	rows          clisqlclient.Rows
 	colTypes      []string
 	showMoreChars bool
+	// vals contains the raw values of the row most recently returned by Next.
+	vals []driver.Value
 }
 
+var _ rowValueIter = &rowIter{}
+
 func (iter *rowIter) Next() (row []string, err error) {
-	nextRowString, err := getNextRowStrings(iter.rows, iter.colTypes, iter.showMoreChars)
+	iter.vals, err = getNextRowValues(iter.rows)
 	if err != nil {
 		return nil, err
 	}
-	if nextRowString == nil {
+	if iter.vals == nil {
 		return nil, io.EOF
 	}
-	return nextRowString, nil
+	return formatRowValues(iter.vals, iter.colTypes, iter.showMoreChars), nil
+}
+
+func (iter *rowIter) columnTypeNames() []string {
+	return iter.colTypes
+}
+
+func (iter *rowIter) values() []driver.Value {
+	return iter.vals
 }
 
 func (iter *rowIter) ToSlice() ([][]string, error) {
@@ -148,6 +165,34 @@ func newRowIter(rows clisqlclient.Rows, showMoreChars bool) *rowIter {
 	}
 }
 
+// rowValueIter is a RowStrIter over a query result set that also provides the
+// column types and the raw values of the rows.
+type rowValueIter interface {
+	RowStrIter
+	// columnTypeNames returns the database type names of the columns.
+	columnTypeNames() []string
+	// values returns the raw values of the row most recently returned by
+	// Next.
+	values() []driver.Value
+}
+
+// asRowValueIter returns the rowValueIter that iter is or wraps, if any.
+func asRowValueIter(iter RowStrIter) (rowValueIter, bool) {
+	if fi, ok := iter.(fingerprintingRowIter); ok {
+		iter = fi.RowStrIter
+	}
+	vi, ok := iter.(rowValueIter)
+	return vi, ok
+}
+
+// valueRowReporter is a rowReporter that renders the raw values of the rows
+// (rather than only their textual representation) when they are available.
+// setValueIter is called before describe in such case.
+type valueRowReporter interface {
+	rowReporter
+	setValueIter(iter rowValueIter)
+}
+
 // rowReporter is used to render result sets.
 // - describe is called once in any case with the result column set.
 // - beforeFirstRow is called once upon the first row encountered.
@@ -182,6 +227,11 @@ func render(
 	completedHook func(),
 	noRowsHook func() (bool, error),
 ) (err error) {
+	if vr, ok := r.(valueRowReporter); ok {
+		if vi, ok := asRowValueIter(iter); ok {
+			vr.setValueIter(vi)
+		}
+	}
 	described := false
 	nRows := 0
 	defer func() {
@@ -254,15 +304,72 @@ type asciiTableReporter struct {
 	w     *tabwriter.Writer
 
 	tableBorderMode int
-}
 
-func newASCIITableReporter(tableBorderMode int) *asciiTableReporter {
-	n := &asciiTableReporter{tableBorderMode: tableBorderMode}
+	// maxMemory, if positive, is the amount of result data buffered in
+	// memory beyond which the rows are spilled to a temporary file.
+	maxMemory int64
+	// memUsed is the amount of result data buffered in memory so far.
+	memUsed int64
+	// colWidths is the display width of each column, computed over the
+	// header and all the rows. It is only maintained when maxMemory is
+	// set, so that the spilled rows can be rendered aligned with the
+	// rows buffered in memory.
+	colWidths []int
+	// align is the alignment of the columns.
+	align []int
+
+	// spill is the temporary file where the rows are written once
+	// maxMemory is exceeded.
+	spill       *os.File
+	spillW      *bufio.Writer
+	spilledRows int
+}
+
+func newASCIITableReporter(tableBorderMode int, maxMemory int64) *asciiTableReporter {
+	n := &asciiTableReporter{tableBorderMode: tableBorderMode, maxMemory: maxMemory}
 	// 4-wide columns, 1 character minimum width.
 	n.w = tabwriter.NewWriter(&n.buf, 4, 0, 1, ' ', 0)
 	return n
 }
 
+// borderMode returns whether to draw the outside borders and the lines
+// between rows.
+func (p *asciiTableReporter) borderMode() (outsideBorders, insideLines bool) {
+	// The following table border modes are taken from psql.
+	// https://www.postgresql.org/docs/12/app-psql.html
+	switch p.tableBorderMode {
+	case 0:
+		outsideBorders, insideLines = false, false
+	case 1:
+		outsideBorders, insideLines = false, true
+	case 2:
+		outsideBorders, insideLines = true, false
+	case 3:
+		outsideBorders, insideLines = true, true
+	}
+	return outsideBorders, insideLines
+}
+
+// newTable initializes a tablewriter with the configured formatting.
+func (p *asciiTableReporter) newTable(w io.Writer) *tablewriter.Table {
+	t := tablewriter.NewWriter(w)
+	t.SetAutoFormatHeaders(false)
+	t.SetAutoWrapText(false)
+	outsideBorders, insideLines := p.borderMode()
+	t.SetBorder(outsideBorders)
+	t.SetRowLine(insideLines)
+	t.SetReflowDuringAutoWrap(false)
+	t.SetTrimWhiteSpaceAtEOL(true)
+	// This width is sufficient to show a "standard text line width"
+	// on the screen when viewed as a single column on a 80-wide terminal.
+	//
+	// It's also wide enough for the output of SHOW CREATE on
+	// moderately long column definitions (e.g. including FK
+	// constraints).
+	t.SetColWidth(72)
+	return t
+}
+
 func (p *asciiTableReporter) describe(w io.Writer, cols []string) error {
 	if len(cols) > 0 {
 		// Ensure that tabs are converted to spaces and newlines are
@@ -276,44 +383,37 @@ func (p *asciiTableReporter) describe(w io.Writer, cols []string) error {
 		}
 
 		// Initialize tablewriter and set column names as the header row.
-		p.table = tablewriter.NewWriter(w)
-		p.table.SetAutoFormatHeaders(false)
-		p.table.SetAutoWrapText(false)
-		var outsideBorders, insideLines bool
-		// The following table border modes are taken from psql.
-		// https://www.postgresql.org/docs/12/app-psql.html
-		switch p.tableBorderMode {
-		case 0:
-			outsideBorders, insideLines = false, false
-		case 1:
-			outsideBorders, insideLines = false, true
-		case 2:
-			outsideBorders, insideLines = true, false
-		case 3:
-			outsideBorders, insideLines = true, true
-		}
-		p.table.SetBorder(outsideBorders)
-		p.table.SetRowLine(insideLines)
-		p.table.SetReflowDuringAutoWrap(false)
+		p.table = p.newTable(w)
 		p.table.SetHeader(expandedCols)
-		p.table.SetTrimWhiteSpaceAtEOL(true)
-		// This width is sufficient to show a "standard text line width"
-		// on the screen when viewed as a single column on a 80-wide terminal.
-		//
-		// It's also wide enough for the output of SHOW CREATE on
-		// moderately long column definitions (e.g. including FK
-		// constraints).
-		p.table.SetColWidth(72)
+		if p.maxMemory > 0 {
+			p.colWidths = make([]int, len(cols))
+			p.updateColWidths(expandedCols)
+		}
 	}
 	return nil
 }
 
+// updateColWidths widens the columns to fit the given row.
+func (p *asciiTableReporter) updateColWidths(row []string) {
+	for i, r := range row {
+		if i >= len(p.colWidths) {
+			break
+		}
+		for _, line := range strings.Split(r, "\n") {
+			if w := tablewriter.DisplayWidth(line); w > p.colWidths[i] {
+				p.colWidths[i] = w
+			}
+		}
+	}
+}
+
 func (p *asciiTableReporter) beforeFirstRow(w io.Writer, iter RowStrIter) error {
 	if p.table == nil {
 		return nil
 	}
 
-	p.table.SetColumnAlignment(iter.Align())
+	p.align = iter.Align()
+	p.table.SetColumnAlignment(p.align)
 	return nil
 }
 
@@ -326,7 +426,7 @@ func (p *asciiTableReporter) iter(_, ew io.Writer, _ int, row []string) error {
 		return nil
 	}
 
-	if p.rows == asciiTableWarnRows {
+	if p.rows == asciiTableWarnRows && p.spill == nil {
 		fmt.Fprintf(ew,
 			"warning: buffering more than %d result rows in client "+
 				"- RAM usage growing, consider another formatter instead\n",
@@ -339,15 +439,144 @@ func (p *asciiTableReporter) iter(_, ew io.Writer, _ int, row []string) error {
 		_ = p.w.Flush()
 		row[i] = p.buf.String()
 	}
-	p.table.Append(row)
 	p.rows++
+	if p.maxMemory <= 0 {
+		p.table.Append(row)
+		return nil
+	}
+
+	p.updateColWidths(row)
+	if p.spill != nil {
+		p.spilledRows++
+		return writeSpilledRow(p.spillW, row)
+	}
+	p.table.Append(row)
+	p.memUsed += rowMemSize(row)
+	if p.memUsed > p.maxMemory {
+		f, err := os.CreateTemp("", "cockroach-sql-results-")
+		if err != nil {
+			return errors.Wrap(err, "creating a temporary file for the result rows")
+		}
+		p.spill = f
+		p.spillW = bufio.NewWriter(f)
+		fmt.Fprintf(ew,
+			"note: result rows exceed %s of memory, spilling them to a temporary file\n",
+			humanizeutil.IBytes(p.maxMemory))
+	}
+	return nil
+}
+
+// rowMemSize estimates the memory used by a row buffered for display.
+func rowMemSize(row []string) int64 {
+	var n int64
+	for _, r := range row {
+		n += int64(len(r))
+	}
+	return n
+}
+
+// writeSpilledRow appends a row to the spill file. Each value is
+// encoded as its length followed by its bytes.
+func writeSpilledRow(w *bufio.Writer, row []string) error {
+	var lenBuf [binary.MaxVarintLen64]byte
+	for _, r := range row {
+		n := binary.PutUvarint(lenBuf[:], uint64(len(r)))
+		if _, err := w.Write(lenBuf[:n]); err != nil {
+			return errors.Wrap(err, "spilling result rows")
+		}
+		if _, err := w.WriteString(r); err != nil {
+			return errors.Wrap(err, "spilling result rows")
+		}
+	}
 	return nil
 }
 
+// readSpilledRow reads back a row written by writeSpilledRow.
+func readSpilledRow(r *bufio.Reader, numCols int) ([]string, error) {
+	row := make([]string, numCols)
+	for i := range row {
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		b := make([]byte, n)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+		row[i] = string(b)
+	}
+	return row, nil
+}
+
+// renderSpilled renders the rows buffered in memory, then the rows in the
+// spill file in batches that each fit in maxMemory. All the batches use
+// the same column widths and only the first one has a header and a top
+// border, so that the output looks like a single table.
+func (p *asciiTableReporter) renderSpilled(w io.Writer) error {
+	if err := p.spillW.Flush(); err != nil {
+		return errors.Wrap(err, "spilling result rows")
+	}
+	if _, err := p.spill.Seek(0, io.SeekStart); err != nil {
+		return errors.Wrap(err, "reading spilled result rows")
+	}
+	r := bufio.NewReader(p.spill)
+	outsideBorders, _ := p.borderMode()
+
+	t := p.table
+	for i, width := range p.colWidths {
+		t.SetColMinWidth(i, width)
+	}
+	t.SetBorders(tablewriter.Border{Left: outsideBorders, Right: outsideBorders, Top: outsideBorders})
+	t.Render()
+
+	for remaining := p.spilledRows; remaining > 0; {
+		t = p.newTable(w)
+		t.SetColumnAlignment(p.align)
+		for i, width := range p.colWidths {
+			t.SetColMinWidth(i, width)
+		}
+		var mem int64
+		for remaining > 0 && mem <= p.maxMemory {
+			row, err := readSpilledRow(r, len(p.colWidths))
+			if err != nil {
+				return errors.Wrap(err, "reading spilled result rows")
+			}
+			t.Append(row)
+			mem += rowMemSize(row)
+			remaining--
+		}
+		t.SetBorders(tablewriter.Border{
+			Left: outsideBorders, Right: outsideBorders, Bottom: outsideBorders && remaining == 0,
+		})
+		t.Render()
+	}
+	return nil
+}
+
+// closeSpill removes the spill file, if any.
+func (p *asciiTableReporter) closeSpill() {
+	if p.spill == nil {
+		return
+	}
+	_ = p.spill.Close()
+	_ = os.Remove(p.spill.Name())
+	p.spill = nil
+	p.spillW = nil
+}
+
 func (p *asciiTableReporter) doneRows(w io.Writer, seenRows int) error {
+	defer p.closeSpill()
 	if p.table != nil {
-		p.table.Render()
+		var err error
+		if p.spill != nil {
+			err = p.renderSpilled(w)
+		} else {
+			p.table.Render()
+		}
 		p.table = nil
+		if err != nil {
+			return err
+		}
 	} else {
 		// A simple delimiter, like in psql.
 		fmt.Fprintln(w, "--")
@@ -358,6 +587,7 @@ func (p *asciiTableReporter) doneRows(w io.Writer, seenRows int) error {
 }
 
 func (p *asciiTableReporter) doneNoRows(_ io.Writer) error {
+	p.closeSpill()
 	p.table = nil
 	return nil
 }
@@ -676,7 +906,7 @@ func (p *sqlReporter) doneRows(w io.Writer, seenRows int) error {
 func (sqlExecCtx *Context) makeReporter(w io.Writer) (rowReporter, func(), error) {
 	switch sqlExecCtx.TableDisplayFormat {
 	case TableDisplayTable:
-		return newASCIITableReporter(sqlExecCtx.TableBorderMode), nil, nil
+		return newASCIITableReporter(sqlExecCtx.TableBorderMode, sqlExecCtx.MaxResultMemory), nil, nil
 
 	case TableDisplayTSV:
 		fallthrough
@@ -702,6 +932,10 @@ func (sqlExecCtx *Context) makeReporter(w io.Writer) (rowReporter, func(), error
 	case TableDisplaySQL:
 		return &sqlReporter{}, nil, nil
 
+	case TableDisplayArrow:
+		reporter := &arrowReporter{}
+		return reporter, reporter.release, nil
+
 	default:
 		return nil, nil, errors.Errorf("unhandled display format: %d", sqlExecCtx.TableDisplayFormat)
 	}
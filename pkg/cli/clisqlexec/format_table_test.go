@@ -12,13 +12,18 @@ package clisqlexec_test
 
 import (
 	"bytes"
+	"fmt"
 	"strconv"
 	"strings"
+	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/cli"
 	"github.com/cockroachdb/cockroach/pkg/cli/clisqlexec"
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
 )
 
 func Example_sql_column_labels() {
@@ -54,6 +59,10 @@ thenshort`,
 	c.RunWithArgs([]string{"sql", "-e", "select * from t.u"})
 	c.RunWithArgs([]string{"sql", "--format=table", "-e", "show columns from t.u"})
 	for i := clisqlexec.TableDisplayFormat(0); i < clisqlexec.TableDisplayLastFormat; i++ {
+		if i == clisqlexec.TableDisplayArrow {
+			// The arrow format is binary (see TestRenderArrow).
+			continue
+		}
 		c.RunWithArgs([]string{"sql", "--format=" + i.String(), "-e", "select * from t.u"})
 	}
 
@@ -187,6 +196,10 @@ func Example_sql_empty_table() {
 		"create table t.nocols(); insert into t.nocols(rowid) values (1),(2),(3);"})
 	for _, table := range []string{"norows", "nocols", "nocolsnorows"} {
 		for format := clisqlexec.TableDisplayFormat(0); format < clisqlexec.TableDisplayLastFormat; format++ {
+			if format == clisqlexec.TableDisplayArrow {
+				// The arrow format is binary (see TestRenderArrow).
+				continue
+			}
 			c.RunWithArgs([]string{"sql", "--format=" + format.String(), "-e", "select * from t." + table})
 		}
 	}
@@ -484,6 +497,10 @@ func Example_sql_table() {
 	}
 	c.RunWithArgs([]string{"sql", "-e", "select * from t.t"})
 	for format := clisqlexec.TableDisplayFormat(0); format < clisqlexec.TableDisplayLastFormat; format++ {
+		if format == clisqlexec.TableDisplayArrow {
+			// The arrow format is binary (see TestRenderArrow).
+			continue
+		}
 		c.RunWithArgs([]string{"sql", "--format=" + format.String(), "-e", "select * from t.t"})
 	}
 
@@ -753,3 +770,54 @@ func Example_sql_table_border() {
 	// +---------+---------+
 	// (2 rows)
 }
+
+// TestTableSpill checks that the 'table' format renders identically
+// whether or not the rows are spilled to disk beyond --max-result-memory.
+func TestTableSpill(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	cols := []string{"a", "b"}
+	var rows [][]string
+	for i := 0; i < 50; i++ {
+		b := strings.Repeat("x", i%7)
+		if i%10 == 3 {
+			b += "\nfoo"
+		}
+		rows = append(rows, []string{strconv.Itoa(i), b})
+	}
+	rows = append(rows, []string{"123456789", "a very wide value at the very end"})
+
+	render := func(borderMode int, maxMemory int64) (string, string) {
+		var out, errOut bytes.Buffer
+		// Copy the rows since the formatter modifies them in place.
+		rowsCopy := make([][]string, len(rows))
+		for i := range rows {
+			rowsCopy[i] = append([]string(nil), rows[i]...)
+		}
+		sqlExecCtx := &clisqlexec.Context{
+			TableDisplayFormat: clisqlexec.TableDisplayTable,
+			TableBorderMode:    borderMode,
+			MaxResultMemory:    maxMemory,
+		}
+		require.NoError(t, sqlExecCtx.PrintQueryOutput(&out, &errOut, cols,
+			clisqlexec.NewRowSliceIter(rowsCopy, "rl")))
+		return out.String(), errOut.String()
+	}
+
+	for borderMode := 0; borderMode < 4; borderMode++ {
+		t.Run(fmt.Sprintf("border=%d", borderMode), func(t *testing.T) {
+			expected, errOut := render(borderMode, 0)
+			require.Empty(t, errOut)
+			for _, maxMemory := range []int64{1, 10, 100, 1 << 20} {
+				out, errOut := render(borderMode, maxMemory)
+				require.Equal(t, expected, out, "max memory %d", maxMemory)
+				if maxMemory < 1<<20 {
+					require.Contains(t, errOut, "spilling them to a temporary file")
+				} else {
+					require.Empty(t, errOut)
+				}
+			}
+		})
+	}
+}
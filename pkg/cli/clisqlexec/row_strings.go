@@ -39,11 +39,20 @@ func getAllRowStrings(
 func getNextRowStrings(
 	rows clisqlclient.Rows, colTypes []string, showMoreChars bool,
 ) ([]string, error) {
-	cols := rows.Columns()
-	var vals []driver.Value
-	if len(cols) > 0 {
-		vals = make([]driver.Value, len(cols))
+	vals, err := getNextRowValues(rows)
+	if vals == nil || err != nil {
+		return nil, err
 	}
+	return formatRowValues(vals, colTypes, showMoreChars), nil
+}
+
+// getNextRowValues returns the raw values of the next row, or nil if there
+// are no more rows.
+func getNextRowValues(rows clisqlclient.Rows) ([]driver.Value, error) {
+	cols := rows.Columns()
+	// Note that vals must be non-nil even if there are no columns, so that
+	// the caller can distinguish an empty row from the end of the rows.
+	vals := make([]driver.Value, len(cols))
 
 	err := rows.Next(vals)
 	if err == io.EOF {
@@ -52,10 +61,14 @@ func getNextRowStrings(
 	if err != nil {
 		return nil, err
 	}
+	return vals, nil
+}
 
-	rowStrings := make([]string, len(cols))
+// formatRowValues returns the textual representation of the given row.
+func formatRowValues(vals []driver.Value, colTypes []string, showMoreChars bool) []string {
+	rowStrings := make([]string, len(vals))
 	for i, v := range vals {
 		rowStrings[i] = FormatVal(v, colTypes[i], showMoreChars, showMoreChars)
 	}
-	return rowStrings, nil
+	return rowStrings
 }
@@ -43,6 +43,29 @@ func (sqlExecCtx *Context) RunQuery(
 // Errors and warnings, if any, are printed to 'ew'.
 func (sqlExecCtx *Context) RunQueryAndFormatResults(
 	ctx context.Context, conn clisqlclient.Conn, w, ew io.Writer, fn clisqlclient.QueryFn,
+) (err error) {
+	return sqlExecCtx.runQueryAndFormatResults(ctx, conn, w, ew, fn, nil /* fp */)
+}
+
+// RunQueryAndFingerprintResults is like RunQueryAndFormatResults but
+// additionally returns a fingerprint of all the result sets, for use
+// in session recordings. See SessionRecord.
+func (sqlExecCtx *Context) RunQueryAndFingerprintResults(
+	ctx context.Context, conn clisqlclient.Conn, w, ew io.Writer, fn clisqlclient.QueryFn,
+) (fingerprint string, err error) {
+	fp := makeResultFingerprinter()
+	if err := sqlExecCtx.runQueryAndFormatResults(ctx, conn, w, ew, fn, &fp); err != nil {
+		return "", err
+	}
+	return fp.String(), nil
+}
+
+func (sqlExecCtx *Context) runQueryAndFormatResults(
+	ctx context.Context,
+	conn clisqlclient.Conn,
+	w, ew io.Writer,
+	fn clisqlclient.QueryFn,
+	fp *resultFingerprinter,
 ) (err error) {
 	startTime := timeutil.Now()
 	rows, isMultiStatementQuery, err := fn(ctx, conn)
@@ -131,11 +154,25 @@ func (sqlExecCtx *Context) RunQueryAndFormatResults(
 			if cleanup != nil {
 				defer cleanup()
 			}
-			return render(reporter, w, ew, cols, newRowIter(rows, true), completedHook, noRowsHook)
+			var iter RowStrIter = newRowIter(rows, true)
+			if fp != nil {
+				fp.addStrings(cols)
+				iter = fingerprintingRowIter{RowStrIter: iter, f: fp}
+			}
+			return render(reporter, w, ew, cols, iter, completedHook, noRowsHook)
 		}(); err != nil {
 			return err
 		}
 
+		if fp != nil {
+			fp.addStrings([]string{rows.Tag()})
+			if ra, ok := rows.Result().(driver.RowsAffected); ok {
+				if nRows, err := ra.RowsAffected(); err == nil {
+					fp.addInt(nRows)
+				}
+			}
+		}
+
 		sqlExecCtx.maybeShowTimes(ctx, conn, w, ew, isMultiStatementQuery, startTime, queryCompleteTime)
 
 		if more, err := rows.NextResultSet(); err != nil {
@@ -0,0 +1,141 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clisqlexec
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"io"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// SessionRecord is a single statement captured by the SQL shell with
+// --record-session. Session recordings are sequences of JSON-encoded
+// SessionRecords, one per line.
+type SessionRecord struct {
+	// SQL is the text of the statement(s) as sent to the server.
+	SQL string `json:"sql"`
+	// Latency is the time from sending the statement until all of its
+	// results have been received.
+	Latency time.Duration `json:"latency_ns"`
+	// Fingerprint is a hash of all the result sets of the statement. It is
+	// empty if the statement failed.
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// Error is the error message, if the statement failed.
+	Error string `json:"error,omitempty"`
+}
+
+// SessionRecorder writes SessionRecords to a session recording.
+type SessionRecorder struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+// NewSessionRecorder creates a SessionRecorder that writes to w.
+func NewSessionRecorder(w io.Writer) *SessionRecorder {
+	bw := bufio.NewWriter(w)
+	return &SessionRecorder{w: bw, enc: json.NewEncoder(bw)}
+}
+
+// Record appends a record to the recording. The record is flushed to the
+// underlying writer right away so that the recording remains usable if the
+// shell is terminated abruptly.
+func (r *SessionRecorder) Record(rec SessionRecord) error {
+	if err := r.enc.Encode(rec); err != nil {
+		return err
+	}
+	return r.w.Flush()
+}
+
+// ReadSessionRecords reads all the records of a session recording.
+func ReadSessionRecords(r io.Reader) ([]SessionRecord, error) {
+	var recs []SessionRecord
+	dec := json.NewDecoder(r)
+	for {
+		var rec SessionRecord
+		if err := dec.Decode(&rec); err != nil {
+			if err == io.EOF {
+				return recs, nil
+			}
+			return nil, errors.Wrapf(err, "invalid record #%d", len(recs)+1)
+		}
+		recs = append(recs, rec)
+	}
+}
+
+// resultFingerprinter computes a hash over all the result sets of a query:
+// the column names, the rows (in their textual representation), the
+// statement tags and the number of rows affected. The rows are hashed in the
+// order they are received, so queries without an ORDER BY may produce
+// different fingerprints across executions.
+type resultFingerprinter struct {
+	h   hash.Hash64
+	buf [binary.MaxVarintLen64]byte
+}
+
+func makeResultFingerprinter() resultFingerprinter {
+	return resultFingerprinter{h: fnv.New64a()}
+}
+
+func (f *resultFingerprinter) addInt(v int64) {
+	n := binary.PutVarint(f.buf[:], v)
+	_, _ = f.h.Write(f.buf[:n])
+}
+
+// addStrings hashes the given strings. They are length-prefixed so that
+// e.g. {"ab", "c"} and {"a", "bc"} hash differently.
+func (f *resultFingerprinter) addStrings(strs []string) {
+	f.addInt(int64(len(strs)))
+	for _, s := range strs {
+		f.addInt(int64(len(s)))
+		_, _ = io.WriteString(f.h, s)
+	}
+}
+
+// String returns the fingerprint accumulated so far.
+func (f *resultFingerprinter) String() string {
+	return fmt.Sprintf("%016x", f.h.Sum64())
+}
+
+// fingerprintingRowIter is a RowStrIter that hashes the rows as they are
+// iterated over.
+type fingerprintingRowIter struct {
+	RowStrIter
+	f *resultFingerprinter
+}
+
+var _ RowStrIter = fingerprintingRowIter{}
+
+// Next implements the RowStrIter interface.
+func (it fingerprintingRowIter) Next() ([]string, error) {
+	row, err := it.RowStrIter.Next()
+	if err == nil {
+		it.f.addStrings(row)
+	}
+	return row, err
+}
+
+// ToSlice implements the RowStrIter interface.
+func (it fingerprintingRowIter) ToSlice() ([][]string, error) {
+	allRows, err := it.RowStrIter.ToSlice()
+	if err == nil {
+		for _, row := range allRows {
+			it.f.addStrings(row)
+		}
+	}
+	return allRows, err
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clisqlexec
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionRecordRoundTrip(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	recs := []SessionRecord{
+		{SQL: "SELECT 1", Latency: time.Millisecond, Fingerprint: "0123456789abcdef"},
+		{SQL: "SELECT\n\t'multi-line'", Latency: 2 * time.Second, Fingerprint: "fedcba9876543210"},
+		{SQL: "SELECT crdb_internal.force_error('', 'boom')", Error: "ERROR: boom"},
+	}
+	var buf bytes.Buffer
+	r := NewSessionRecorder(&buf)
+	for _, rec := range recs {
+		require.NoError(t, r.Record(rec))
+	}
+	// Each record is on a single line.
+	require.Equal(t, len(recs), strings.Count(buf.String(), "\n"))
+
+	read, err := ReadSessionRecords(&buf)
+	require.NoError(t, err)
+	require.Equal(t, recs, read)
+
+	_, err = ReadSessionRecords(strings.NewReader(`{"sql": "SELECT 1"}` + "\n" + `{"sql": `))
+	require.Error(t, err)
+}
+
+func TestResultFingerprint(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	fingerprint := func(cols []string, rows [][]string) string {
+		f := makeResultFingerprinter()
+		f.addStrings(cols)
+		it := fingerprintingRowIter{RowStrIter: NewRowSliceIter(rows, "l"), f: &f}
+		for {
+			if _, err := it.Next(); err == io.EOF {
+				break
+			} else {
+				require.NoError(t, err)
+			}
+		}
+		return f.String()
+	}
+
+	cols := []string{"a"}
+	base := fingerprint(cols, [][]string{{"ab"}, {"c"}})
+	require.Equal(t, base, fingerprint(cols, [][]string{{"ab"}, {"c"}}))
+	require.NotEqual(t, base, fingerprint(cols, [][]string{{"a"}, {"bc"}}))
+	require.NotEqual(t, base, fingerprint(cols, [][]string{{"c"}, {"ab"}}))
+	require.NotEqual(t, base, fingerprint([]string{"b"}, [][]string{{"ab"}, {"c"}}))
+	require.NotEqual(t, base, fingerprint(cols, [][]string{{"ab"}}))
+}
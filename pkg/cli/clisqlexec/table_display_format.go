@@ -50,6 +50,10 @@ const (
 	// TableDisplayRaw is a special format optimized to ensure that the
 	// values can be parsed accurately from the text output.
 	TableDisplayRaw
+	// TableDisplayArrow reports the results as an Arrow IPC stream
+	// (https://arrow.apache.org/docs/format/Columnar.html) so that they
+	// can be consumed directly by Arrow-aware tools.
+	TableDisplayArrow
 
 	// TableDisplayLastFormat is a marker for the end of the list of
 	// formats, for use in tests.
@@ -82,6 +86,8 @@ func (f *TableDisplayFormat) String() string {
 		return "rawhtml"
 	case TableDisplayRaw:
 		return "raw"
+	case TableDisplayArrow:
+		return "arrow"
 	}
 	return ""
 }
@@ -107,11 +113,13 @@ func (f *TableDisplayFormat) Set(s string) error {
 		*f = TableDisplayRawHTML
 	case "raw":
 		*f = TableDisplayRaw
+	case "arrow":
+		*f = TableDisplayArrow
 	default:
 		return errors.Newf("invalid table display format: %s "+
 			// Note: rawhtml is omitted intentionally. It is
 			// only supported for the 'gen settings-table' command.
-			"(possible values: tsv, csv, table, records, sql, html, raw)", s)
+			"(possible values: tsv, csv, table, records, sql, html, raw, arrow)", s)
 	}
 	return nil
 }
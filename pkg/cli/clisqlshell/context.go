@@ -33,6 +33,16 @@ type Context struct {
 	// the watch.
 	RepeatDelay time.Duration
 
+	// RecordSession, if non-empty, is the path of a file where every
+	// statement executed by the shell is recorded, together with its
+	// latency and a fingerprint of its results. The recording can be
+	// re-executed with `cockroach sql replay`.
+	RecordSession string
+
+	// Quiet suppresses the informational messages of the shell, like
+	// the progress reports of \copy.
+	Quiet bool
+
 	// DemoCluster is the interface to the in-memory cluster for the
 	// `demo` command, if that is the command being run.
 	DemoCluster democlusterapi.DemoCluster
@@ -0,0 +1,438 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clisqlshell
+
+import (
+	"bufio"
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/cli/clisqlexec"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// clientCopyCmd is a parsed \copy command. Its syntax is the same as
+// that of the COPY statement, except that the source or destination
+// is a file on the client machine:
+//
+//   \copy table [(col, ...)] FROM {'file' | STDIN} [options]
+//   \copy {table [(col, ...)] | (query)} TO {'file' | STDOUT} [options]
+type clientCopyCmd struct {
+	// target is the table, with an optional column list, or for
+	// a copy to a file, a parenthesized query.
+	target string
+	// from is true for \copy ... FROM.
+	from bool
+	// file is the name of the local file. It is empty for STDIN/STDOUT.
+	file string
+	// options is the text following the file name.
+	options string
+}
+
+// copyProgressInterval is the minimum time between two progress
+// messages of \copy.
+const copyProgressInterval = time.Second
+
+// parseClientCopy parses the arguments of a \copy command.
+func parseClientCopy(args string) (clientCopyCmd, error) {
+	var cmd clientCopyCmd
+	toks, err := splitCopyTokens(args, " \t\n")
+	if err != nil {
+		return cmd, err
+	}
+	dir := -1
+	for i := 1; i < len(toks); i++ {
+		if w := strings.ToLower(toks[i].text); w == "from" || w == "to" {
+			dir = i
+			break
+		}
+	}
+	if dir == -1 || dir == len(toks)-1 {
+		return cmd, errors.New(`expected \copy <table> FROM <file> or \copy <table> TO <file>`)
+	}
+	cmd.target = strings.TrimSpace(args[:toks[dir].start])
+	cmd.from = strings.ToLower(toks[dir].text) == "from"
+	dest := toks[dir+1]
+	switch w := strings.ToLower(dest.text); {
+	case cmd.from && w == "stdin", !cmd.from && w == "stdout":
+	case w == "stdin", w == "stdout":
+		return cmd, errors.Newf("cannot use %s with \\copy ... %s", dest.text, toks[dir].text)
+	case dest.text[0] == '\'':
+		cmd.file = strings.ReplaceAll(dest.text[1:len(dest.text)-1], "''", "'")
+	default:
+		cmd.file = dest.text
+	}
+	if cmd.file == "" && dest.text[0] == '\'' {
+		return cmd, errors.New("empty file name")
+	}
+	cmd.options = strings.TrimSpace(args[dest.end:])
+	if strings.HasPrefix(cmd.target, "(") && cmd.from {
+		return cmd, errors.New("cannot copy from a file into a query")
+	}
+	return cmd, nil
+}
+
+// copyToken is a token of a \copy command, as delimited by
+// splitCopyTokens.
+type copyToken struct {
+	text       string
+	start, end int
+}
+
+// splitCopyTokens splits the arguments of a \copy command into tokens
+// separated by any of the characters in seps. Quoted strings and
+// parenthesized expressions are kept as a single token.
+func splitCopyTokens(s string, seps string) ([]copyToken, error) {
+	var toks []copyToken
+	for i := 0; i < len(s); {
+		if strings.IndexByte(seps, s[i]) != -1 {
+			i++
+			continue
+		}
+		start := i
+		depth := 0
+		for i < len(s) {
+			ch := s[i]
+			if depth == 0 && strings.IndexByte(seps, ch) != -1 {
+				break
+			}
+			switch ch {
+			case '\'', '"':
+				// Skip to the closing quote. A doubled quote character is an
+				// escaped quote.
+				j := i + 1
+				for {
+					k := strings.IndexByte(s[j:], ch)
+					if k == -1 {
+						return nil, errors.Newf("unterminated quoted string in %q", s[start:])
+					}
+					j += k + 1
+					if j == len(s) || s[j] != ch {
+						break
+					}
+					j++
+				}
+				i = j
+				continue
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			i++
+		}
+		if depth != 0 {
+			return nil, errors.Newf("unbalanced parentheses in %q", s[start:])
+		}
+		toks = append(toks, copyToken{text: s[start:i], start: start, end: i})
+	}
+	return toks, nil
+}
+
+// copyOptions are the options of a \copy command.
+type copyOptions struct {
+	csv       bool
+	header    bool
+	delimiter byte
+	null      string
+}
+
+// parseCopyOptions parses the options of a \copy command. The supported
+// options are the format (text, the default, or CSV), DELIMITER, NULL
+// and HEADER, either in the parenthesized syntax or not.
+func parseCopyOptions(s string) (copyOptions, error) {
+	opts := copyOptions{delimiter: '\t', null: `\N`}
+	var delimiter, null *string
+	s = strings.TrimSpace(s)
+	if len(s) >= 4 && strings.EqualFold(s[:4], "with") && (len(s) == 4 || s[4] == ' ' || s[4] == '(') {
+		s = s[4:]
+	}
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		s = s[1 : len(s)-1]
+	}
+	toks, err := splitCopyTokens(s, " \t\n,")
+	if err != nil {
+		return opts, err
+	}
+	quoted := func(i int, opt string) (*string, error) {
+		if i >= len(toks) || toks[i].text[0] != '\'' {
+			return nil, errors.Newf("expected a quoted string after %s", opt)
+		}
+		v := strings.ReplaceAll(toks[i].text[1:len(toks[i].text)-1], "''", "'")
+		return &v, nil
+	}
+	for i := 0; i < len(toks); i++ {
+		switch opt := strings.ToLower(toks[i].text); opt {
+		case "csv":
+			opts.csv = true
+		case "binary":
+			return opts, errors.New("binary format is not supported by \\copy")
+		case "format":
+			i++
+			if i == len(toks) {
+				return opts, errors.New("expected a format after FORMAT")
+			}
+			switch f := strings.ToLower(toks[i].text); f {
+			case "csv":
+				opts.csv = true
+			case "text":
+				opts.csv = false
+			default:
+				return opts, errors.Newf("unsupported \\copy format %q", toks[i].text)
+			}
+		case "header":
+			opts.header = true
+			if i+1 < len(toks) {
+				switch strings.ToLower(toks[i+1].text) {
+				case "true":
+					i++
+				case "false":
+					opts.header = false
+					i++
+				}
+			}
+		case "delimiter":
+			i++
+			if delimiter, err = quoted(i, "DELIMITER"); err != nil {
+				return opts, err
+			}
+			if len(*delimiter) != 1 {
+				return opts, errors.New("delimiter must be a single character")
+			}
+		case "null":
+			i++
+			if null, err = quoted(i, "NULL"); err != nil {
+				return opts, err
+			}
+		default:
+			return opts, errors.Newf("unsupported \\copy option %q", toks[i].text)
+		}
+	}
+	if opts.csv {
+		opts.delimiter = ','
+		opts.null = ""
+	} else if opts.header {
+		return opts, errors.New("HEADER is only supported with the CSV format")
+	}
+	if delimiter != nil {
+		opts.delimiter = (*delimiter)[0]
+	}
+	if null != nil {
+		opts.null = *null
+	}
+	return opts, nil
+}
+
+// serverOptions returns the options of the COPY FROM statement that
+// loads the data described by opts. HEADER is not included as the
+// header line is skipped by the client.
+func (opts copyOptions) serverOptions() string {
+	var buf strings.Builder
+	defaults := copyOptions{delimiter: '\t', null: `\N`}
+	if opts.csv {
+		buf.WriteString(" CSV")
+		defaults = copyOptions{delimiter: ',', null: ""}
+	}
+	quote := func(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" }
+	if opts.delimiter != defaults.delimiter {
+		fmt.Fprintf(&buf, " DELIMITER %s", quote(string(opts.delimiter)))
+	}
+	if opts.null != defaults.null {
+		fmt.Fprintf(&buf, " NULL %s", quote(opts.null))
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// appendCopyField appends a field of a row exported by \copy ... TO,
+// escaped as per the COPY format.
+func (opts copyOptions) appendCopyField(buf []byte, val string, isNull bool) []byte {
+	if isNull {
+		return append(buf, opts.null...)
+	}
+	if opts.csv {
+		if val == opts.null || val == "" || strings.ContainsAny(val, "\"\r\n"+string(opts.delimiter)) {
+			buf = append(buf, '"')
+			buf = append(buf, strings.ReplaceAll(val, `"`, `""`)...)
+			return append(buf, '"')
+		}
+		return append(buf, val...)
+	}
+	for i := 0; i < len(val); i++ {
+		switch ch := val[i]; ch {
+		case '\\':
+			buf = append(buf, `\\`...)
+		case '\n':
+			buf = append(buf, `\n`...)
+		case '\r':
+			buf = append(buf, `\r`...)
+		case '\t':
+			buf = append(buf, `\t`...)
+		default:
+			if ch == opts.delimiter {
+				buf = append(buf, '\\')
+			}
+			buf = append(buf, ch)
+		}
+	}
+	return buf
+}
+
+// copyProgressFn returns a function that reports the progress of a
+// \copy every copyProgressInterval, unless the shell is configured to
+// be quiet.
+func (c *cliState) copyProgressFn(verb string) func(numRows int) {
+	if c.sqlCtx.Quiet {
+		return func(int) {}
+	}
+	lastReport := timeutil.Now()
+	return func(numRows int) {
+		if now := timeutil.Now(); now.Sub(lastReport) >= copyProgressInterval {
+			fmt.Fprintf(c.iCtx.stderr, "%s %d rows...\n", verb, numRows)
+			lastReport = now
+		}
+	}
+}
+
+// runClientCopy runs a \copy command.
+func (c *cliState) runClientCopy(ctx context.Context, cmd clientCopyCmd) error {
+	switch {
+	case cmd.from && cmd.file == "":
+		return c.beginCopyFrom(ctx, "COPY "+cmd.target+" FROM STDIN "+cmd.options)
+	case cmd.from:
+		return c.copyFromFile(ctx, cmd)
+	default:
+		return c.copyTo(ctx, cmd)
+	}
+}
+
+// copyFromFile runs a COPY FROM statement with the contents of a local
+// file. The header line of CSV files is skipped when the HEADER option
+// is specified.
+func (c *cliState) copyFromFile(ctx context.Context, cmd clientCopyCmd) error {
+	opts, err := parseCopyOptions(cmd.options)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(cmd.file)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	r := bufio.NewReader(f)
+	if opts.header {
+		if _, err := r.ReadString('\n'); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	copyFromState, err := c.startCopyFrom(ctx, "COPY "+cmd.target+" FROM STDIN "+opts.serverOptions())
+	if err != nil {
+		return err
+	}
+	return c.sqlExecCtx.RunQueryAndFormatResults(
+		ctx,
+		c.conn,
+		c.iCtx.stdout,
+		c.iCtx.stderr,
+		copyFromState.CommitFromReader(r, c.copyProgressFn("sent")),
+	)
+}
+
+// copyTo exports the rows of a table or query to a local file, or to
+// the standard output. The values are formatted as they are displayed
+// by the SQL shell, and then escaped as per the COPY format.
+func (c *cliState) copyTo(ctx context.Context, cmd clientCopyCmd) (resErr error) {
+	opts, err := parseCopyOptions(cmd.options)
+	if err != nil {
+		return err
+	}
+	query := cmd.target
+	if strings.HasPrefix(query, "(") {
+		query = strings.TrimSpace(query[1 : len(query)-1])
+	} else if i := strings.IndexByte(query, '('); i != -1 {
+		query = "SELECT " + strings.TrimSuffix(query[i+1:], ")") + " FROM " + query[:i]
+	} else {
+		query = "SELECT * FROM " + query
+	}
+
+	var w io.Writer = c.iCtx.stdout
+	if cmd.file != "" {
+		f, err := os.Create(cmd.file)
+		if err != nil {
+			return err
+		}
+		defer func() { resErr = errors.CombineErrors(resErr, f.Close()) }()
+		w = f
+	}
+	bw := bufio.NewWriter(w)
+
+	rows, err := c.conn.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer func() { resErr = errors.CombineErrors(resErr, rows.Close()) }()
+
+	cols := rows.Columns()
+	colTypes := rows.ColumnTypeNames()
+	var buf []byte
+	writeRow := func(vals []string, nulls []bool) error {
+		buf = buf[:0]
+		for i, v := range vals {
+			if i > 0 {
+				buf = append(buf, opts.delimiter)
+			}
+			buf = opts.appendCopyField(buf, v, nulls != nil && nulls[i])
+		}
+		buf = append(buf, '\n')
+		_, err := bw.Write(buf)
+		return err
+	}
+	if opts.header {
+		if err := writeRow(cols, nil /* nulls */); err != nil {
+			return err
+		}
+	}
+
+	progressFn := c.copyProgressFn("copied")
+	vals := make([]driver.Value, len(cols))
+	strs := make([]string, len(cols))
+	nulls := make([]bool, len(cols))
+	numRows := 0
+	for {
+		if err := rows.Next(vals); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		for i, v := range vals {
+			nulls[i] = v == nil
+			strs[i] = clisqlexec.FormatVal(v, colTypes[i], true /* showPrintableUnicode */, true /* showNewLinesAndTabs */)
+		}
+		if err := writeRow(strs, nulls); err != nil {
+			return err
+		}
+		numRows++
+		progressFn(numRows)
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	if cmd.file != "" {
+		fmt.Fprintf(c.iCtx.stdout, "COPY %d\n", numRows)
+	}
+	return nil
+}
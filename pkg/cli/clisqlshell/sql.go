@@ -42,6 +42,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlfsm"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 	readline "github.com/knz/go-libedit"
 )
@@ -75,6 +76,10 @@ Input/Output
   \echo [STRING]    write the provided string to standard output.
   \i                execute commands from the specified file.
   \ir               as \i, but relative to the location of the current script.
+  \copy TABLE FROM {'FILE' | STDIN} [OPTIONS]
+                    import the rows of a client-side file into a table.
+  \copy {TABLE | (QUERY)} TO {'FILE' | STDOUT} [OPTIONS]
+                    export the rows of a table or query to a client-side file.
 
 Informational
   \l                list all databases in the CockroachDB cluster.
@@ -165,6 +170,10 @@ type cliState struct {
 	// State of COPY FROM on the client.
 	copyFromState *clisqlclient.CopyFromState
 
+	// recorder, if set, records every statement executed by the shell.
+	// See Context.RecordSession.
+	recorder *clisqlexec.SessionRecorder
+
 	// State
 	//
 	// lastInputLine is the last valid line obtained from readline.
@@ -375,7 +384,7 @@ var options = map[string]struct {
 		display: func(c *cliState) string { return strconv.Itoa(c.sqlExecCtx.TableBorderMode) },
 	},
 	`display_format`: {
-		description:               "the output format for tabular data (table, csv, tsv, html, ndjson, sql, records, raw)",
+		description:               "the output format for tabular data (table, csv, tsv, html, ndjson, sql, records, raw, arrow)",
 		isBoolean:                 false,
 		validDuringMultilineEntry: true,
 		set: func(c *cliState, val string) error {
@@ -1235,13 +1244,19 @@ func (c *cliState) doHandleCliCmd(loopState, nextState cliStateEnum) cliStateEnu
 
 	case `\copy`:
 		c.exitErr = c.runWithInterruptableCtx(func(ctx context.Context) error {
-			return c.beginCopyFrom(ctx, c.concatLines)
+			copyCmd, err := parseClientCopy(line[len(cmd[0]):])
+			if err != nil {
+				return err
+			}
+			return c.runClientCopy(ctx, copyCmd)
 		})
-		if !c.singleStatement {
-			clierror.OutputError(c.iCtx.stderr, c.exitErr, true /*showSeverity*/, false /*verbose*/)
-		}
-		if c.exitErr != nil && c.iCtx.errExit {
-			return cliStop
+		if c.exitErr != nil {
+			if !c.singleStatement {
+				clierror.OutputError(c.iCtx.stderr, c.exitErr, true /*showSeverity*/, false /*verbose*/)
+			}
+			if c.iCtx.errExit {
+				return cliStop
+			}
 		}
 		return cliStartLine
 
@@ -1790,13 +1805,16 @@ func (c *cliState) doRunStatements(nextState cliStateEnum) cliStateEnum {
 				c.concatLines,
 			)
 		}
-		return c.sqlExecCtx.RunQueryAndFormatResults(
-			ctx,
-			c.conn,
-			c.iCtx.stdout,
-			c.iCtx.stderr,
-			q,
-		)
+		if c.recorder == nil || c.inCopy() {
+			return c.sqlExecCtx.RunQueryAndFormatResults(
+				ctx,
+				c.conn,
+				c.iCtx.stdout,
+				c.iCtx.stderr,
+				q,
+			)
+		}
+		return c.runAndRecordStatement(ctx, q)
 	})
 	if c.exitErr != nil {
 		if !c.singleStatement {
@@ -1853,15 +1871,24 @@ func (c *cliState) doRunStatements(nextState cliStateEnum) cliStateEnum {
 	return nextState
 }
 
-func (c *cliState) beginCopyFrom(ctx context.Context, sql string) error {
+// startCopyFrom starts the given COPY FROM query.
+func (c *cliState) startCopyFrom(
+	ctx context.Context, sql string,
+) (*clisqlclient.CopyFromState, error) {
 	c.refreshTransactionStatus()
 	if c.lastKnownTxnStatus != "" {
-		return unimplemented.Newf(
+		return nil, unimplemented.Newf(
 			"cli_copy_in_txn",
 			"cannot use COPY inside a transaction",
 		)
 	}
-	copyFromState, err := clisqlclient.BeginCopyFrom(ctx, c.conn, sql)
+	return clisqlclient.BeginCopyFrom(ctx, c.conn, sql)
+}
+
+// beginCopyFrom starts the given COPY FROM query and switches the shell
+// to reading the data to copy from its input.
+func (c *cliState) beginCopyFrom(ctx context.Context, sql string) error {
+	copyFromState, err := c.startCopyFrom(ctx, sql)
 	if err != nil {
 		return err
 	}
@@ -1983,6 +2010,32 @@ func (c *cliState) doRunShell(state cliStateEnum, cmdIn, cmdOut, cmdErr *os.File
 	return c.exitErr
 }
 
+// runAndRecordStatement runs the statement(s) in c.concatLines like
+// doRunStatements does, and additionally appends them to the session
+// recording.
+func (c *cliState) runAndRecordStatement(ctx context.Context, q clisqlclient.QueryFn) error {
+	startTime := timeutil.Now()
+	fingerprint, err := c.sqlExecCtx.RunQueryAndFingerprintResults(
+		ctx,
+		c.conn,
+		c.iCtx.stdout,
+		c.iCtx.stderr,
+		q,
+	)
+	rec := clisqlexec.SessionRecord{
+		SQL:         c.concatLines,
+		Latency:     timeutil.Since(startTime),
+		Fingerprint: fingerprint,
+	}
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	if recErr := c.recorder.Record(rec); recErr != nil {
+		fmt.Fprintf(c.iCtx.stderr, "warning: cannot record statement: %v\n", recErr)
+	}
+	return err
+}
+
 // configurePreShellDefaults should be called after command-line flags
 // have been loaded into the cliCtx/sqlCtx and .isInteractive /
 // .terminalOutput have been initialized, but before the SQL shell or
@@ -2097,6 +2150,21 @@ func (c *cliState) configurePreShellDefaults(
 		c.sqlCtx.ExecStmts = append(setStmts, c.sqlCtx.ExecStmts...)
 	}
 
+	if c.sqlCtx.RecordSession != "" {
+		f, err := os.Create(c.sqlCtx.RecordSession)
+		if err != nil {
+			return cleanupFn, errors.Wrap(err, "cannot create the session recording")
+		}
+		c.recorder = clisqlexec.NewSessionRecorder(f)
+		prevCleanupFn := cleanupFn
+		cleanupFn = func() {
+			if err := f.Close(); err != nil {
+				fmt.Fprintf(c.iCtx.stderr, "warning: cannot close the session recording: %v\n", err)
+			}
+			prevCleanupFn()
+		}
+	}
+
 	return cleanupFn, nil
 }
 
@@ -145,6 +145,130 @@ func TestHandleDemoNodeCommandsInvalidNodeName(t *testing.T) {
 	assert.Equal(t, errInvalidSyntax, c.exitErr)
 }
 
+func TestParseClientCopy(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		args   string
+		exp    clientCopyCmd
+		expErr string
+	}{
+		{`t FROM STDIN`, clientCopyCmd{target: "t", from: true}, ""},
+		{`t (a, b) from 'my file.csv' WITH CSV HEADER`,
+			clientCopyCmd{target: "t (a, b)", from: true, file: "my file.csv", options: "WITH CSV HEADER"}, ""},
+		{`t TO 'it''s.txt'`, clientCopyCmd{target: "t", file: "it's.txt"}, ""},
+		{`(SELECT 'from' FROM t) TO out.csv CSV`,
+			clientCopyCmd{target: "(SELECT 'from' FROM t)", file: "out.csv", options: "CSV"}, ""},
+		{`t TO STDOUT`, clientCopyCmd{target: "t"}, ""},
+		{`t`, clientCopyCmd{}, "expected"},
+		{`t FROM`, clientCopyCmd{}, "expected"},
+		{`t FROM STDOUT`, clientCopyCmd{}, "cannot use STDOUT"},
+		{`t TO ''`, clientCopyCmd{}, "empty file name"},
+		{`(SELECT 1) FROM 'f'`, clientCopyCmd{}, "into a query"},
+		{`t FROM 'f`, clientCopyCmd{}, "unterminated"},
+		{`t (a FROM 'f'`, clientCopyCmd{}, "unbalanced"},
+	}
+
+	for _, tc := range testData {
+		cmd, err := parseClientCopy(tc.args)
+		if tc.expErr != "" {
+			assert.Error(t, err, tc.args)
+			assert.Contains(t, err.Error(), tc.expErr, tc.args)
+			continue
+		}
+		assert.NoError(t, err, tc.args)
+		assert.Equal(t, tc.exp, cmd, tc.args)
+	}
+}
+
+func TestParseCopyOptions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		options string
+		exp     copyOptions
+		expErr  string
+	}{
+		{``, copyOptions{delimiter: '\t', null: `\N`}, ""},
+		{`CSV`, copyOptions{csv: true, delimiter: ',', null: ""}, ""},
+		{`WITH CSV HEADER DELIMITER '|' NULL 'nil'`,
+			copyOptions{csv: true, header: true, delimiter: '|', null: "nil"}, ""},
+		{`WITH (FORMAT csv, HEADER false, DELIMITER ',')`,
+			copyOptions{csv: true, delimiter: ',', null: ""}, ""},
+		{`delimiter ';'`, copyOptions{delimiter: ';', null: `\N`}, ""},
+		{`BINARY`, copyOptions{}, "binary format"},
+		{`HEADER`, copyOptions{}, "only supported with the CSV format"},
+		{`DELIMITER '||'`, copyOptions{}, "single character"},
+		{`NULL nil`, copyOptions{}, "expected a quoted string"},
+		{`FORMAT parquet`, copyOptions{}, "unsupported \\copy format"},
+		{`without`, copyOptions{}, "unsupported \\copy option"},
+	}
+
+	for _, tc := range testData {
+		opts, err := parseCopyOptions(tc.options)
+		if tc.expErr != "" {
+			assert.Error(t, err, tc.options)
+			assert.Contains(t, err.Error(), tc.expErr, tc.options)
+			continue
+		}
+		assert.NoError(t, err, tc.options)
+		assert.Equal(t, tc.exp, opts, tc.options)
+	}
+}
+
+func TestCopyServerOptions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		options string
+		exp     string
+	}{
+		{``, ``},
+		{"DELIMITER '\t'", ``},
+		{`WITH CSV HEADER`, `CSV`},
+		{`(FORMAT csv, DELIMITER '|', NULL 'it''s null')`, `CSV DELIMITER '|' NULL 'it''s null'`},
+		{`NULL ''`, `NULL ''`},
+	}
+
+	for _, tc := range testData {
+		opts, err := parseCopyOptions(tc.options)
+		assert.NoError(t, err, tc.options)
+		assert.Equal(t, tc.exp, opts.serverOptions(), tc.options)
+	}
+}
+
+func TestAppendCopyField(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	text := copyOptions{delimiter: '\t', null: `\N`}
+	csv := copyOptions{csv: true, delimiter: ',', null: ""}
+	testData := []struct {
+		opts   copyOptions
+		val    string
+		isNull bool
+		exp    string
+	}{
+		{text, "abc", false, "abc"},
+		{text, "", true, `\N`},
+		{text, "a\tb\nc\\d", false, `a\tb\nc\\d`},
+		{copyOptions{delimiter: '|'}, "a|b", false, `a\|b`},
+		{csv, "abc", false, "abc"},
+		{csv, "", true, ""},
+		{csv, "", false, `""`},
+		{csv, "a,b", false, `"a,b"`},
+		{csv, `say "hi"`, false, `"say ""hi"""`},
+		{csv, "a\nb", false, "\"a\nb\""},
+	}
+
+	for _, tc := range testData {
+		assert.Equal(t, tc.exp, string(tc.opts.appendCopyField(nil, tc.val, tc.isNull)), tc.val)
+	}
+}
+
 func setupTestCliState() *cliState {
 	cliCtx := &clicfg.Context{}
 	sqlConnCtx := &clisqlclient.Context{CliCtx: cliCtx}
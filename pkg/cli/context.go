@@ -71,6 +71,7 @@ func initCLIDefaults() {
 	setCertContextDefaults()
 	setDebugRecoverContextDefaults()
 	setDebugSendKVBatchContextDefaults()
+	setDebugHotRangesContextDefaults()
 
 	initPreFlagsDefaults()
 
@@ -181,6 +182,9 @@ type cliContext struct {
 	// logConfig is the resulting logging configuration after the input
 	// configuration has been parsed and validated.
 	logConfig logconfig.Config
+	// logFormat is the format of the diagnostics printed to the standard
+	// error, set via --log-format.
+	logFormat logFormat
 	// deprecatedLogOverrides is the legacy pre-v21.1 discrete flag
 	// overrides for the logging configuration.
 	// TODO(knz): Deprecated in v21.1. Remove this.
@@ -214,6 +218,7 @@ func setCliContextDefaults() {
 	cliCtx.cmdTimeout = 0 // no timeout
 	cliCtx.clientOpts.ServerHost = ""
 	cliCtx.clientOpts.ServerPort = base.DefaultPort
+	cliCtx.clientOpts.AlternateServerAddrs = nil
 	cliCtx.certPrincipalMap = nil
 	cliCtx.clientOpts.ExplicitURL = nil
 	cliCtx.clientOpts.User = username.RootUser
@@ -221,6 +226,7 @@ func setCliContextDefaults() {
 	cliCtx.allowUnencryptedClientPassword = false
 	cliCtx.logConfigInput = settableString{s: ""}
 	cliCtx.logConfig = logconfig.Config{}
+	cliCtx.logFormat = logFormatText
 	cliCtx.ambiguousLogDir = false
 	// TODO(knz): Deprecated in v21.1. Remove this.
 	cliCtx.deprecatedLogOverrides.reset()
@@ -241,6 +247,7 @@ func setSQLConnContextDefaults() {
 	sqlConnCtx.DebugMode = false
 	sqlConnCtx.Echo = false
 	sqlConnCtx.EnableServerExecutionTimings = false
+	sqlConnCtx.AlternateServerAddrs = nil
 }
 
 // certCtx captures the command-line parameters of the various `cert` commands.
@@ -299,6 +306,7 @@ func setSQLExecContextDefaults() {
 	}
 	sqlExecCtx.ShowTimes = false
 	sqlExecCtx.VerboseTimings = false
+	sqlExecCtx.MaxResultMemory = 0
 }
 
 var sqlCtx = func() *clisqlcfg.Context {
@@ -328,6 +336,13 @@ type zipContext struct {
 	// server-side during retrieval.
 	redactLogs bool
 
+	// redactProfile is the redaction applied to the contents of the zip
+	// file.
+	redactProfile zipRedactProfile
+
+	// redactRulesFile is the file of the additional redaction rules.
+	redactRulesFile string
+
 	// Duration (in seconds) to run CPU profile for.
 	cpuProfDuration time.Duration
 
@@ -346,6 +361,8 @@ func setZipContextDefaults() {
 	zipCtx.nodes = nodeSelection{}
 	zipCtx.files = fileSelection{}
 	zipCtx.redactLogs = false
+	zipCtx.redactProfile = zipRedactNone
+	zipCtx.redactRulesFile = ""
 	zipCtx.cpuProfDuration = 5 * time.Second
 	zipCtx.concurrency = 15
 
@@ -462,6 +479,10 @@ var startCtx struct {
 	// its listening URL when it is ready.
 	listeningURLFile string
 
+	// listeningHTTPURLFile indicates the file to which the server writes
+	// the URL of its DB Console when it is ready.
+	listeningHTTPURLFile string
+
 	// pidFile indicates the file to which the server writes its PID
 	// when it is ready.
 	pidFile string
@@ -485,6 +506,7 @@ func setStartContextDefaults() {
 	startCtx.tempDir = ""
 	startCtx.externalIODir = ""
 	startCtx.listeningURLFile = ""
+	startCtx.listeningHTTPURLFile = ""
 	startCtx.pidFile = ""
 	startCtx.inBackground = false
 	startCtx.geoLibsDir = "/usr/local/lib/cockroach"
@@ -500,6 +522,9 @@ var quitCtx struct {
 	// nodeDrainSelf indicates that the command should target
 	// the node we're connected to (this is the default behavior).
 	nodeDrainSelf bool
+	// nodeDrainFormat is the format of the progress reported by
+	// `node drain`.
+	nodeDrainFormat nodeDrainFormatType
 }
 
 // setQuitContextDefaults set the default values in quitCtx.  This
@@ -508,6 +533,7 @@ var quitCtx struct {
 func setQuitContextDefaults() {
 	quitCtx.drainWait = 10 * time.Minute
 	quitCtx.nodeDrainSelf = false
+	quitCtx.nodeDrainFormat = nodeDrainFormatText
 }
 
 // nodeCtx captures the command-line parameters of the `node` command.
@@ -515,6 +541,8 @@ func setQuitContextDefaults() {
 var nodeCtx struct {
 	nodeDecommissionWait   nodeDecommissionWaitType
 	nodeDecommissionSelf   bool
+	nodeDecommissionDryRun bool
+	nodeDecommissionChecks nodeDecommissionCheckMode
 	statusShowRanges       bool
 	statusShowStats        bool
 	statusShowDecommission bool
@@ -527,6 +555,8 @@ var nodeCtx struct {
 func setNodeContextDefaults() {
 	nodeCtx.nodeDecommissionWait = nodeDecommissionWaitAll
 	nodeCtx.nodeDecommissionSelf = false
+	nodeCtx.nodeDecommissionDryRun = false
+	nodeCtx.nodeDecommissionChecks = nodeDecommissionCheckEnabled
 	nodeCtx.statusShowRanges = false
 	nodeCtx.statusShowStats = false
 	nodeCtx.statusShowAll = false
@@ -559,6 +589,9 @@ func setSqlfmtContextDefaults() {
 
 var convertCtx struct {
 	url string
+	// checkTLS indicates whether to check the TLS parameters of the URL
+	// against the server.
+	checkTLS bool
 }
 
 // setConvContextDefaults set the default values in convertCtx.  This
@@ -566,6 +599,7 @@ var convertCtx struct {
 // test that exercises command-line parsing.
 func setConvContextDefaults() {
 	convertCtx.url = ""
+	convertCtx.checkTLS = false
 }
 
 // demoCtx captures the command-line parameters of the `demo` command.
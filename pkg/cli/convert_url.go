@@ -11,12 +11,22 @@
 package cli
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/cli/clierrorplus"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/server/pgurl"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
+	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/ttycolor"
 	"github.com/spf13/cobra"
 )
@@ -27,11 +37,25 @@ var convertURLCmd = &cobra.Command{
   convert-url --url postgres://root@localhost:26257/defaultdb
 
   convert-url "postgresql://example.com?sslcert=certs%2Fclient.root.crt&sslkey=certs%2Fclient.root.key&sslmode=verify-full&sslrootcert=certs%2Fca.crt"
+
+  convert-url --url "jdbc:postgresql://example.com:26257/defaultdb?user=root&sslmode=disable"
+
+  convert-url --url "host=example.com user=root dbname=defaultdb sslmode=verify-full sslrootcert=certs/ca.crt" --check-tls
 `,
 
 	Short: "convert a SQL connection string for use with various client drivers",
-	Args:  cobra.NoArgs,
-	RunE:  clierrorplus.MaybeDecorateError(runConvertURL),
+	Long: `
+Convert a SQL connection string for use with various client drivers.
+
+The connection string passed with --url can be a URL for libpq or JDBC,
+or a connection string in the key/value format of libpq.
+
+With --check-tls, the command also connects to the server to check
+that the TLS parameters of the connection string are compatible with
+the server.
+`,
+	Args: cobra.NoArgs,
+	RunE: clierrorplus.MaybeDecorateError(runConvertURL),
 }
 
 func runConvertURL(cmd *cobra.Command, _ []string) error {
@@ -43,7 +67,11 @@ func runConvertURL(cmd *cobra.Command, _ []string) error {
 		u = pgurl.New()
 	} else {
 		var err error
-		u, err = pgurl.Parse(convertCtx.url)
+		if strings.Contains(convertCtx.url, "://") {
+			u, err = pgurl.Parse(convertCtx.url)
+		} else {
+			u, err = pgurl.ParseDSN(convertCtx.url)
+		}
 		if err != nil {
 			return err
 		}
@@ -58,6 +86,17 @@ func runConvertURL(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	if convertCtx.checkTLS {
+		ctx, cancel := context.WithTimeout(context.Background(), checkTLSTimeout)
+		defer cancel()
+		msg, err := checkURLTLS(ctx, u)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("# %s\n", msg)
+		fmt.Println()
+	}
+
 	cp := ttycolor.StdoutProfile
 	yc := cp[ttycolor.Yellow]
 	rc := cp[ttycolor.Reset]
@@ -76,3 +115,116 @@ func runConvertURL(cmd *cobra.Command, _ []string) error {
 
 	return nil
 }
+
+// checkTLSTimeout is the maximum duration of the connection to the server
+// with --check-tls.
+const checkTLSTimeout = 10 * time.Second
+
+// checkURLTLS connects to the server designated by the URL and checks
+// that the TLS parameters of the URL are compatible with the server. It
+// returns a message describing the outcome of a successful check.
+func checkURLTLS(ctx context.Context, u *pgurl.URL) (string, error) {
+	proto, host, port := u.GetNetworking()
+	if proto != pgurl.ProtoTCP {
+		return "", errors.New("cannot check the TLS parameters of a URL that does not use TCP")
+	}
+	serverAddr := net.JoinHostPort(host, port)
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", serverAddr)
+	if err != nil {
+		return "", errors.Wrapf(err, "connecting to %s", serverAddr)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return "", err
+		}
+	}
+
+	// Ask the server whether it accepts TLS connections, as clients do
+	// at the beginning of the pgwire protocol: the server replies with
+	// 'S' if it does, and 'N' otherwise.
+	const sslRequestCode = 80877103
+	var req [8]byte
+	binary.BigEndian.PutUint32(req[0:], uint32(len(req)))
+	binary.BigEndian.PutUint32(req[4:], sslRequestCode)
+	if _, err := conn.Write(req[:]); err != nil {
+		return "", errors.Wrapf(err, "sending TLS request to %s", serverAddr)
+	}
+	var resp [1]byte
+	if _, err := io.ReadFull(conn, resp[:]); err != nil {
+		return "", errors.Wrapf(err, "reading TLS response from %s", serverAddr)
+	}
+
+	tlsUsed, mode, caCertPath := u.GetTLSOptions()
+	if mode == pgurl.TLSUnspecified {
+		// Most drivers default to sslmode=prefer.
+		mode = pgurl.TLSPrefer
+	}
+	switch resp[0] {
+	case 'N':
+		switch mode {
+		case pgurl.TLSRequire, pgurl.TLSVerifyCA, pgurl.TLSVerifyFull:
+			return "", errors.WithHint(
+				errors.Newf("the server at %s does not accept TLS connections, but the URL requires TLS (sslmode=%s)",
+					serverAddr, mode),
+				"Use sslmode=disable to connect to an insecure cluster.")
+		}
+		return fmt.Sprintf("The server at %s is insecure and does not use TLS.", serverAddr), nil
+	case 'S':
+	default:
+		return "", errors.Newf("unexpected response to TLS request from %s: %q", serverAddr, resp[0])
+	}
+
+	if !tlsUsed {
+		return "", errors.WithHint(
+			errors.Newf("the server at %s requires TLS connections, but the URL disables TLS (sslmode=disable)", serverAddr),
+			"Use sslmode=verify-full and set sslrootcert to the CA certificate of the cluster.")
+	}
+
+	tlsConf := &tls.Config{ServerName: host}
+	if caCertPath != "" {
+		caPEM, err := ioutil.ReadFile(caCertPath)
+		if err != nil {
+			return "", errors.Wrap(err, "reading the CA certificate (sslrootcert)")
+		}
+		tlsConf.RootCAs = x509.NewCertPool()
+		if !tlsConf.RootCAs.AppendCertsFromPEM(caPEM) {
+			return "", errors.Newf("no valid certificate found in %s (sslrootcert)", caCertPath)
+		}
+	}
+	if certEnabled, certPath, keyPath := u.GetAuthnCert(); certEnabled {
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return "", errors.Wrap(err, "loading the client certificate (sslcert, sslkey)")
+		}
+		tlsConf.Certificates = []tls.Certificate{cert}
+	}
+	switch mode {
+	case pgurl.TLSVerifyFull:
+	case pgurl.TLSVerifyCA:
+		// Verify the certificate chain, but not the host name.
+		tlsConf.InsecureSkipVerify = true
+		tlsConf.VerifyConnection = func(cs tls.ConnectionState) error {
+			opts := x509.VerifyOptions{Roots: tlsConf.RootCAs, Intermediates: x509.NewCertPool()}
+			for _, cert := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(cert)
+			}
+			_, err := cs.PeerCertificates[0].Verify(opts)
+			return err
+		}
+	default:
+		tlsConf.InsecureSkipVerify = true
+	}
+
+	tlsConn := tls.Client(conn, tlsConf)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return "", errors.Wrapf(err, "TLS handshake with %s", serverAddr)
+	}
+	if tlsConf.InsecureSkipVerify && tlsConf.VerifyConnection == nil {
+		return fmt.Sprintf("The server at %s uses TLS; "+
+			"note that its certificate is not verified with sslmode=%s.", serverAddr, mode), nil
+	}
+	return fmt.Sprintf("The server at %s uses TLS and its certificate was verified (sslmode=%s).",
+		serverAddr, mode), nil
+}
@@ -10,6 +10,18 @@
 
 package cli
 
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/server/pgurl"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
 func Example_convert_url() {
 	c := NewCLITest(TestCLIParams{
 		NoServer: true,
@@ -18,6 +30,7 @@ func Example_convert_url() {
 
 	c.RunWithArgs([]string{`convert-url`})
 	c.RunWithArgs([]string{`convert-url`, `--url`, `postgres://foo@bar`})
+	c.RunWithArgs([]string{`convert-url`, `--url`, `host=bar user=foo dbname=db sslmode=disable`})
 
 	// Output:
 	// convert-url
@@ -42,4 +55,67 @@ func Example_convert_url() {
 	// # Connection URL for JDBC (Java and JVM-based languages):
 	// jdbc:postgresql://bar:26257/defaultdb?user=foo
 	//
+	// convert-url --url host=bar user=foo dbname=db sslmode=disable
+	// # Connection URL for libpq (C/C++), psycopg (Python), lib/pq & pgx (Go), node-postgres (JS) and most pq-compatible drivers:
+	// postgresql://foo@bar:26257/db?sslmode=disable
+	//
+	// # Connection DSN (Data Source Name) for Postgres drivers that accept DSNs - most drivers and also ODBC:
+	// database=db user=foo host=bar port=26257 sslmode=disable
+	//
+	// # Connection URL for JDBC (Java and JVM-based languages):
+	// jdbc:postgresql://bar:26257/db?sslmode=disable&user=foo
+	//
+}
+
+func TestCheckURLTLS(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// serve starts a fake server which replies to the TLS request of the
+	// client with the given response, and returns its address.
+	serve := func(t *testing.T, response byte) string {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			var req [8]byte
+			if _, err := io.ReadFull(conn, req[:]); err != nil {
+				return
+			}
+			_, _ = conn.Write([]byte{response})
+		}()
+		t.Cleanup(func() {
+			_ = ln.Close()
+			<-done
+		})
+		return ln.Addr().String()
+	}
+
+	for _, tc := range []struct {
+		response byte
+		options  string
+		expected string
+	}{
+		{'N', "sslmode=disable", "is insecure and does not use TLS"},
+		{'N', "", "is insecure and does not use TLS"},
+		{'N', "sslmode=verify-full", `does not accept TLS connections, but the URL requires TLS \(sslmode=verify-full\)`},
+		{'S', "sslmode=disable", `requires TLS connections, but the URL disables TLS`},
+		{'X', "sslmode=disable", `unexpected response to TLS request`},
+	} {
+		t.Run(string(tc.response)+"/"+tc.options, func(t *testing.T) {
+			u, err := pgurl.Parse("postgresql://root@" + serve(t, tc.response) + "/?" + tc.options)
+			require.NoError(t, err)
+			msg, err := checkURLTLS(context.Background(), u)
+			if err != nil {
+				msg = err.Error()
+			}
+			require.Regexp(t, tc.expected, msg)
+		})
+	}
 }
@@ -32,6 +32,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/cli/clierrorplus"
+	"github.com/cockroachdb/cockroach/pkg/cli/cliflagcfg"
 	"github.com/cockroachdb/cockroach/pkg/cli/cliflags"
 	"github.com/cockroachdb/cockroach/pkg/cli/syncbench"
 	"github.com/cockroachdb/cockroach/pkg/config"
@@ -795,6 +796,15 @@ var DebugPebbleCmd = &cobra.Command{
 	Short: "run a Pebble introspection tool command",
 	Long: `
 Allows the use of pebble tools, such as to introspect manifests, SSTables, etc.
+
+The files of stores encrypted at rest can be inspected by specifying the
+encryption options of the store with --enterprise-encryption. Unless
+--store is specified, the store directory is the directory passed as
+first argument, or the directory containing the file passed as first
+argument, e.g.:
+
+  cockroach debug pebble sstable scan /data/000123.sst \
+    --enterprise-encryption=path=/data,key=/keys/aes-128.key,old-key=plain
 `,
 }
 
@@ -1590,6 +1600,7 @@ var debugCmds = []*cobra.Command{
 	debugResetQuorumCmd,
 	debugSendKVBatchCmd,
 	debugRecoverCmd,
+	debugHotRangesCmd,
 }
 
 // DebugCmd is the root of all debug commands. Exported to allow modification by CCL code.
@@ -1672,6 +1683,7 @@ func init() {
 	DebugCmd.AddCommand(debugDoctorCmd)
 
 	debugStatementBundleCmd.AddCommand(statementBundleRecreateCmd)
+	debugStatementBundleCmd.AddCommand(statementBundleReplayCmd)
 	DebugCmd.AddCommand(debugStatementBundleCmd)
 
 	DebugCmd.AddCommand(debugJobTraceFromClusterCmd)
@@ -1770,16 +1782,30 @@ func initPebbleCmds(cmd *cobra.Command) {
 					return err
 				}
 			}
-			return pebbleCryptoInitializer()
+			return pebbleCryptoInitializer(pebbleToolStoreDir(cmd, args))
 		}
 		initPebbleCmds(c)
 	}
 }
 
-func pebbleCryptoInitializer() error {
+// pebbleToolStoreDir returns the directory of the store inspected by a
+// debug pebble command. This is the --store directory if specified,
+// otherwise the directory passed as first argument or the directory
+// containing the file passed as first argument.
+func pebbleToolStoreDir(cmd *cobra.Command, args []string) string {
+	if cliflagcfg.FlagSetForCmd(cmd).Changed(cliflags.Store.Name) || len(args) == 0 {
+		return serverCfg.Stores.Specs[0].Path
+	}
+	if info, err := os.Stat(args[0]); err == nil && info.IsDir() {
+		return args[0]
+	}
+	return filepath.Dir(args[0])
+}
+
+func pebbleCryptoInitializer(storeDir string) error {
 	storageConfig := base.StorageConfig{
 		Settings: serverCfg.Settings,
-		Dir:      serverCfg.Stores.Specs[0].Path,
+		Dir:      storeDir,
 	}
 
 	if PopulateStorageConfigHook != nil {
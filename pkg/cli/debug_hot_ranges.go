@@ -0,0 +1,181 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/cli/clierrorplus"
+	"github.com/cockroachdb/cockroach/pkg/cli/clisqlexec"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+)
+
+// TODO(knz): this struct belongs elsewhere.
+// See: https://github.com/cockroachdb/cockroach/issues/49509
+var debugHotRangesContext = struct {
+	// The column to sort the ranges by, in descending order. See
+	// hotRangesSortKeys.
+	sortBy string
+	// The maximum number of ranges to display. Zero means all of them.
+	limit int
+	// If non-zero, the report is refreshed with the specified period.
+	watch time.Duration
+}{}
+
+func setDebugHotRangesContextDefaults() {
+	debugHotRangesContext.sortBy = "qps"
+	debugHotRangesContext.limit = 20
+	debugHotRangesContext.watch = 0
+}
+
+var debugHotRangesCmd = &cobra.Command{
+	Use:     "hot-ranges",
+	Aliases: []string{"hotranges"},
+	Short:   "list the hottest ranges in the cluster",
+	Long: `
+Retrieves the hottest ranges from every node in the cluster and displays
+them sorted by the column specified with --sort-by.
+
+The per-range statistics are those maintained by the stores for load-based
+rebalancing, so they are only reported by the leaseholder of each range.
+Use --watch to refresh the report periodically.
+`,
+	Args: cobra.NoArgs,
+	RunE: clierrorplus.MaybeDecorateError(runDebugHotRanges),
+}
+
+// hotRangesSortKeys are the columns that the hot ranges can be sorted by.
+var hotRangesSortKeys = map[string]func(r *serverpb.HotRangesResponseV2_HotRange) float64{
+	"qps":         func(r *serverpb.HotRangesResponseV2_HotRange) float64 { return r.QPS },
+	"writes":      func(r *serverpb.HotRangesResponseV2_HotRange) float64 { return r.WritesPerSecond },
+	"reads":       func(r *serverpb.HotRangesResponseV2_HotRange) float64 { return r.ReadsPerSecond },
+	"write-bytes": func(r *serverpb.HotRangesResponseV2_HotRange) float64 { return r.WriteBytesPerSecond },
+	"read-bytes":  func(r *serverpb.HotRangesResponseV2_HotRange) float64 { return r.ReadBytesPerSecond },
+}
+
+var hotRangesColumnHeaders = []string{
+	"range_id",
+	"qps",
+	"writes_per_second",
+	"reads_per_second",
+	"write_bytes_per_second",
+	"read_bytes_per_second",
+	"leaseholder_node_id",
+	"store_id",
+	"database_name",
+	"table_name",
+	"index_name",
+}
+
+func runDebugHotRanges(cmd *cobra.Command, _ []string) error {
+	sortBy := debugHotRangesContext.sortBy
+	if _, ok := hotRangesSortKeys[sortBy]; !ok {
+		keys := make([]string, 0, len(hotRangesSortKeys))
+		for k := range hotRangesSortKeys {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return errors.Newf("invalid --sort-by value %q, valid values: %s",
+			sortBy, strings.Join(keys, ", "))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conn, _, finish, err := getClientGRPCConn(ctx, serverCfg)
+	if err != nil {
+		return err
+	}
+	defer finish()
+	status := serverpb.NewStatusClient(conn)
+
+	// Stop watching, rather than dying mid-report, when interrupted.
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, drainSignals...)
+	defer signal.Stop(signalCh)
+	go func() {
+		select {
+		case <-signalCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var timer timeutil.Timer
+	defer timer.Stop()
+	for {
+		resp, err := status.HotRangesV2(ctx, &serverpb.HotRangesRequest{})
+		if err != nil {
+			return errors.Wrap(err, "failed to retrieve the hot ranges")
+		}
+		for nodeID, errMsg := range resp.ErrorsByNodeID {
+			fmt.Fprintf(stderr, "warning: cannot retrieve the hot ranges of n%d: %s\n", nodeID, errMsg)
+		}
+		rows := hotRangesToRows(resp.Ranges, sortBy, debugHotRangesContext.limit)
+		if err := sqlExecCtx.PrintQueryOutput(os.Stdout, stderr, hotRangesColumnHeaders,
+			clisqlexec.NewRowSliceIter(rows, "rrrrrrrrlll")); err != nil {
+			return err
+		}
+		if debugHotRangesContext.watch == 0 {
+			return nil
+		}
+		timer.Reset(debugHotRangesContext.watch)
+		select {
+		case <-timer.C:
+			timer.Read = true
+		case <-ctx.Done():
+			return nil
+		}
+		fmt.Println()
+	}
+}
+
+// hotRangesToRows sorts the hot ranges in descending order of the sortBy
+// column and formats the first limit of them.
+func hotRangesToRows(
+	ranges []*serverpb.HotRangesResponseV2_HotRange, sortBy string, limit int,
+) [][]string {
+	key := hotRangesSortKeys[sortBy]
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return key(ranges[i]) > key(ranges[j])
+	})
+	if limit > 0 && len(ranges) > limit {
+		ranges = ranges[:limit]
+	}
+	formatFloat := func(f float64) string { return strconv.FormatFloat(f, 'f', 2, 64) }
+	rows := make([][]string, len(ranges))
+	for i, r := range ranges {
+		rows[i] = []string{
+			strconv.FormatInt(int64(r.RangeID), 10),
+			formatFloat(r.QPS),
+			formatFloat(r.WritesPerSecond),
+			formatFloat(r.ReadsPerSecond),
+			formatFloat(r.WriteBytesPerSecond),
+			formatFloat(r.ReadBytesPerSecond),
+			strconv.FormatInt(int64(r.LeaseholderNodeID), 10),
+			strconv.FormatInt(int64(r.StoreID), 10),
+			r.DatabaseName,
+			r.TableName,
+			r.IndexName,
+		}
+	}
+	return rows
+}
@@ -0,0 +1,63 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHotRangesToRows(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ranges := []*serverpb.HotRangesResponseV2_HotRange{
+		{RangeID: 1, QPS: 10, WritesPerSecond: 3, TableName: "a"},
+		{RangeID: 2, QPS: 30, WritesPerSecond: 1, TableName: "b"},
+		{RangeID: 3, QPS: 20, WritesPerSecond: 2, TableName: "c"},
+	}
+	rangeIDs := func(rows [][]string) (ids []string) {
+		for _, row := range rows {
+			ids = append(ids, row[0])
+		}
+		return ids
+	}
+
+	require.Equal(t, []string{"2", "3", "1"}, rangeIDs(hotRangesToRows(ranges, "qps", 0)))
+	require.Equal(t, []string{"1", "3"}, rangeIDs(hotRangesToRows(ranges, "writes", 2)))
+
+	rows := hotRangesToRows(ranges, "qps", 1)
+	require.Equal(t, len(hotRangesColumnHeaders), len(rows[0]))
+	require.Equal(t, []string{"2", "30.00", "1.00", "0.00", "0.00", "0.00", "0", "0", "", "b", ""}, rows[0])
+}
+
+func TestDebugHotRanges(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	c := NewCLITest(TestCLIParams{T: t})
+	defer c.Cleanup()
+
+	output, err := c.RunWithCapture("debug hot-ranges --format=csv --limit=5")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	require.GreaterOrEqual(t, len(lines), 2)
+	require.Equal(t, strings.Join(hotRangesColumnHeaders, ","), lines[1])
+	require.LessOrEqual(t, len(lines), 2+5)
+
+	output, err = c.RunWithCapture("debug hotranges --sort-by=cpu")
+	require.NoError(t, err)
+	require.Contains(t, output, `ERROR: invalid --sort-by value "cpu"`)
+}
@@ -286,6 +286,7 @@ func init() {
 		// Logging configuration.
 		cliflagcfg.VarFlag(pf, &stringValue{settableString: &cliCtx.logConfigInput}, cliflags.Log)
 		cliflagcfg.VarFlag(pf, &fileContentsValue{settableString: &cliCtx.logConfigInput, fileName: "<unset>"}, cliflags.LogConfigFile)
+		cliflagcfg.VarFlag(pf, &cliCtx.logFormat, cliflags.LogFormat)
 
 		// Pre-v21.1 overrides. Deprecated.
 		// TODO(knz): Remove this.
@@ -423,6 +424,7 @@ func init() {
 		cliflagcfg.StringFlag(f, &serverCfg.ClockDevicePath, cliflags.ClockDevice)
 
 		cliflagcfg.StringFlag(f, &startCtx.listeningURLFile, cliflags.ListeningURLFile)
+		cliflagcfg.StringFlag(f, &startCtx.listeningHTTPURLFile, cliflags.ListeningHTTPURLFile)
 
 		cliflagcfg.StringFlag(f, &startCtx.pidFile, cliflags.PIDFile)
 		cliflagcfg.StringFlag(f, &startCtx.geoLibsDir, cliflags.GeoLibsDir)
@@ -441,6 +443,7 @@ func init() {
 
 		// Certificate principal map.
 		cliflagcfg.StringSliceFlag(f, &startCtx.serverCertPrincipalMap, cliflags.CertPrincipalMap)
+		cliflagcfg.DurationFlag(f, &baseCfg.CertRotationInterval, cliflags.CertRotationInterval)
 
 		// Cluster name verification.
 		cliflagcfg.VarFlag(f, clusterNameSetter{&baseCfg.ClusterName}, cliflags.ClusterName)
@@ -537,6 +540,7 @@ func init() {
 		debugZipCmd,
 		debugListFilesCmd,
 		debugSendKVBatchCmd,
+		debugHotRangesCmd,
 		doctorExamineClusterCmd,
 		doctorExamineFallbackClusterCmd,
 		doctorRecreateClusterCmd,
@@ -569,6 +573,7 @@ func init() {
 	{
 		f := convertURLCmd.PersistentFlags()
 		cliflagcfg.StringFlag(f, &convertCtx.url, cliflags.URL)
+		cliflagcfg.BoolFlag(f, &convertCtx.checkTLS, cliflags.ConvertURLCheckTLS)
 	}
 
 	// Auth commands.
@@ -608,6 +613,8 @@ func init() {
 	{
 		f := debugZipCmd.Flags()
 		cliflagcfg.BoolFlag(f, &zipCtx.redactLogs, cliflags.ZipRedactLogs)
+		cliflagcfg.VarFlag(f, &zipCtx.redactProfile, cliflags.ZipRedactProfile)
+		cliflagcfg.StringFlag(f, &zipCtx.redactRulesFile, cliflags.ZipRedactRules)
 		cliflagcfg.DurationFlag(f, &zipCtx.cpuProfDuration, cliflags.ZipCPUProfileDuration)
 		cliflagcfg.IntFlag(f, &zipCtx.concurrency, cliflags.ZipConcurrency)
 	}
@@ -622,8 +629,18 @@ func init() {
 		cliflagcfg.VarFlag(f, &zipCtx.files.endTimestamp, cliflags.ZipFilesUntil)
 	}
 
+	// Hot ranges command.
+	{
+		f := debugHotRangesCmd.Flags()
+		cliflagcfg.StringFlag(f, &debugHotRangesContext.sortBy, cliflags.HotRangesSortBy)
+		cliflagcfg.IntFlag(f, &debugHotRangesContext.limit, cliflags.HotRangesLimit)
+		cliflagcfg.DurationFlag(f, &debugHotRangesContext.watch, cliflags.HotRangesWatch)
+	}
+
 	// Decommission command.
 	cliflagcfg.VarFlag(decommissionNodeCmd.Flags(), &nodeCtx.nodeDecommissionWait, cliflags.Wait)
+	cliflagcfg.BoolFlag(decommissionNodeCmd.Flags(), &nodeCtx.nodeDecommissionDryRun, cliflags.NodeDecommissionDryRun)
+	cliflagcfg.VarFlag(decommissionNodeCmd.Flags(), &nodeCtx.nodeDecommissionChecks, cliflags.NodeDecommissionChecks)
 
 	// Decommission and recommission share --self.
 	for _, cmd := range []*cobra.Command{decommissionNodeCmd, recommissionNodeCmd} {
@@ -637,6 +654,7 @@ func init() {
 		cliflagcfg.DurationFlag(f, &quitCtx.drainWait, cliflags.DrainWait)
 		cliflagcfg.BoolFlag(f, &quitCtx.nodeDrainSelf, cliflags.NodeDrainSelf)
 	}
+	cliflagcfg.VarFlag(drainNodeCmd.Flags(), &quitCtx.nodeDrainFormat, cliflags.NodeDrainFormat)
 
 	// Commands that establish a SQL connection.
 	sqlCmds := []*cobra.Command{
@@ -647,6 +665,7 @@ func init() {
 		doctorExamineFallbackClusterCmd,
 		doctorRecreateClusterCmd,
 		statementBundleRecreateCmd,
+		statementBundleReplayCmd,
 		lsNodesCmd,
 		statusNodeCmd,
 	}
@@ -687,9 +706,15 @@ func init() {
 			statementBundleRecreateCmd,
 			debugListFilesCmd,
 			debugJobTraceFromClusterCmd,
+			debugHotRangesCmd,
 		},
 		demoCmd.Commands()...)
-	tableOutputCommands = append(tableOutputCommands, nodeCmds...)
+	for _, cmd := range nodeCmds {
+		// node drain has its own --format flag for the progress report.
+		if cmd != drainNodeCmd {
+			tableOutputCommands = append(tableOutputCommands, cmd)
+		}
+	}
 	tableOutputCommands = append(tableOutputCommands, authCmds...)
 
 	// By default, these commands print their output as pretty-formatted
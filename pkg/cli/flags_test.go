@@ -1275,6 +1275,13 @@ Flags:
                                   File name to read the logging configuration from. This has the same effect as
                                   passing the content of the file via the --log flag.
                                   (default <unset>)
+      --log-format <string>      
+                                  Format of the diagnostics printed to the standard error. Possible values:
+                                  text, json. With json, the log entries printed to the standard error use
+                                  the "json" logging format, and a command that fails reports its error as a
+                                  single-line JSON object containing a timestamp, the name of the command, the
+                                  error message, its SQLSTATE code if any and the process exit code.
+                                  (default text)
       --version                  version for cockroach
 
 Use "cockroach [command] --help" for more information about a command.
@@ -267,6 +267,87 @@ func (s *nodeDecommissionWaitType) Set(value string) error {
 	return nil
 }
 
+// nodeDecommissionCheckMode determines how the blockers found by the
+// decommission pre-check are handled.
+type nodeDecommissionCheckMode int
+
+const (
+	nodeDecommissionCheckEnabled nodeDecommissionCheckMode = iota
+	nodeDecommissionCheckStrict
+	nodeDecommissionCheckSkip
+)
+
+// Type implements the pflag.Value interface.
+func (s *nodeDecommissionCheckMode) Type() string { return "string" }
+
+// String implements the pflag.Value interface.
+func (s *nodeDecommissionCheckMode) String() string {
+	switch *s {
+	case nodeDecommissionCheckEnabled:
+		return "enabled"
+	case nodeDecommissionCheckStrict:
+		return "strict"
+	case nodeDecommissionCheckSkip:
+		return "skip"
+	default:
+		panic("unexpected node decommission check mode (possible values: enabled, strict, skip)")
+	}
+}
+
+// Set implements the pflag.Value interface.
+func (s *nodeDecommissionCheckMode) Set(value string) error {
+	switch value {
+	case "enabled":
+		*s = nodeDecommissionCheckEnabled
+	case "strict":
+		*s = nodeDecommissionCheckStrict
+	case "skip":
+		*s = nodeDecommissionCheckSkip
+	default:
+		return fmt.Errorf("invalid node decommission checks parameter: %s "+
+			"(possible values: enabled, strict, skip)", value)
+	}
+	return nil
+}
+
+// nodeDrainFormatType is the format of the drain progress reported by
+// `cockroach node drain`.
+type nodeDrainFormatType int
+
+const (
+	nodeDrainFormatText nodeDrainFormatType = iota
+	nodeDrainFormatJSON
+)
+
+// Type implements the pflag.Value interface.
+func (s *nodeDrainFormatType) Type() string { return "string" }
+
+// String implements the pflag.Value interface.
+func (s *nodeDrainFormatType) String() string {
+	switch *s {
+	case nodeDrainFormatText:
+		return "text"
+	case nodeDrainFormatJSON:
+		return "json"
+	default:
+		panic("unexpected node drain format (possible values: text, json)")
+	}
+}
+
+// Set implements the pflag.Value interface.
+func (s *nodeDrainFormatType) Set(value string) error {
+	switch value {
+	case "text":
+		*s = nodeDrainFormatText
+	case "json":
+		*s = nodeDrainFormatJSON
+	default:
+		return fmt.Errorf("invalid node drain format: %s "+
+			"(possible values: text, json)", value)
+	}
+	return nil
+}
+
 // bytesOrPercentageValue is a flag that accepts an integer value, an integer
 // plus a unit (e.g. 32GB or 32GiB) or a percentage (e.g. 32%). In all these
 // cases, it transforms the string flag input into an int64 value.
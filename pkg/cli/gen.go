@@ -11,11 +11,17 @@
 package cli
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"crypto/rand"
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"os/exec"
+	"sort"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/build"
@@ -26,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/startupmigrations"
+	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/errors/oserror"
 	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
@@ -192,14 +199,46 @@ The resulting key file will be 32 bytes (random key ID) + key_size in bytes.
 
 var includeReservedSettings bool
 var excludeSystemSettings bool
+var compareSettingsWith string
+
+// settingsListCols are the columns of the output of `gen settings-list`.
+var settingsListCols = []string{"Setting", "Type", "Default", "Description"}
+
+// settingInfo describes a cluster setting in the output of `gen
+// settings-list`.
+type settingInfo struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Default     string `json:"default"`
+	Description string `json:"description"`
+}
 
 var genSettingsListCmd = &cobra.Command{
 	Use:   "settings-list",
 	Short: "output a list of available cluster settings",
 	Long: `
 Output the list of cluster settings known to this binary.
+
+With --compare, output instead the cluster settings added, removed or
+changed since the version of the given binary, or since the list in
+the given file as output by --format=ndjson, as a JSON object.
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		infos := collectSettingsList()
+
+		if compareSettingsWith != "" {
+			old, err := loadSettingsList(context.Background(), compareSettingsWith)
+			if err != nil {
+				return err
+			}
+			out, err := json.MarshalIndent(diffSettingsLists(old, infos), "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+
 		wrapCode := func(s string) string {
 			if sqlExecCtx.TableDisplayFormat == clisqlexec.TableDisplayRawHTML {
 				return fmt.Sprintf("<code>%s</code>", s)
@@ -207,58 +246,213 @@ Output the list of cluster settings known to this binary.
 			return s
 		}
 
-		// Fill a Values struct with the defaults.
-		s := cluster.MakeTestingClusterSettings()
-		settings.NewUpdater(&s.SV).ResetRemaining(context.Background())
+		rows := make([][]string, len(infos))
+		for i, info := range infos {
+			rows[i] = []string{wrapCode(info.Name), info.Type, wrapCode(info.Default), info.Description}
+		}
 
-		var rows [][]string
-		for _, name := range settings.Keys(settings.ForSystemTenant) {
-			setting, ok := settings.Lookup(name, settings.LookupForLocalAccess, settings.ForSystemTenant)
-			if !ok {
-				panic(fmt.Sprintf("could not find setting %q", name))
-			}
+		sliceIter := clisqlexec.NewRowSliceIter(rows, "dddd")
+		return sqlExecCtx.PrintQueryOutput(os.Stdout, stderr, settingsListCols, sliceIter)
+	},
+}
 
-			if excludeSystemSettings && setting.Class() == settings.SystemOnly {
-				continue
-			}
+// collectSettingsList returns the cluster settings to list, sorted by
+// name.
+func collectSettingsList() []settingInfo {
+	// Fill a Values struct with the defaults.
+	s := cluster.MakeTestingClusterSettings()
+	settings.NewUpdater(&s.SV).ResetRemaining(context.Background())
+
+	var infos []settingInfo
+	for _, name := range settings.Keys(settings.ForSystemTenant) {
+		setting, ok := settings.Lookup(name, settings.LookupForLocalAccess, settings.ForSystemTenant)
+		if !ok {
+			panic(fmt.Sprintf("could not find setting %q", name))
+		}
 
-			if setting.Visibility() != settings.Public {
-				// We don't document non-public settings at this time.
-				continue
-			}
+		if excludeSystemSettings && setting.Class() == settings.SystemOnly {
+			continue
+		}
 
-			typ, ok := settings.ReadableTypes[setting.Typ()]
-			if !ok {
-				panic(fmt.Sprintf("unknown setting type %q", setting.Typ()))
-			}
-			var defaultVal string
-			if sm, ok := setting.(*settings.VersionSetting); ok {
-				defaultVal = sm.SettingsListDefault()
-			} else {
-				defaultVal = setting.String(&s.SV)
-				if override, ok := startupmigrations.SettingsDefaultOverrides[name]; ok {
-					defaultVal = override
-				}
+		if setting.Visibility() != settings.Public {
+			// We don't document non-public settings at this time.
+			continue
+		}
+
+		typ, ok := settings.ReadableTypes[setting.Typ()]
+		if !ok {
+			panic(fmt.Sprintf("unknown setting type %q", setting.Typ()))
+		}
+		var defaultVal string
+		if sm, ok := setting.(*settings.VersionSetting); ok {
+			defaultVal = sm.SettingsListDefault()
+		} else {
+			defaultVal = setting.String(&s.SV)
+			if override, ok := startupmigrations.SettingsDefaultOverrides[name]; ok {
+				defaultVal = override
 			}
+		}
 
-			settingDesc := setting.Description()
-			if strings.Contains(name, "sql.defaults") {
-				settingDesc = fmt.Sprintf(`%s
+		settingDesc := setting.Description()
+		if strings.Contains(name, "sql.defaults") {
+			settingDesc = fmt.Sprintf(`%s
 This cluster setting is being kept to preserve backwards-compatibility.
 This session variable default should now be configured using ALTER ROLE... SET: %s`,
-					setting.Description(),
-					"https://www.cockroachlabs.com/docs/stable/alter-role.html",
-				)
-			}
+				setting.Description(),
+				"https://www.cockroachlabs.com/docs/stable/alter-role.html",
+			)
+		}
+
+		infos = append(infos, settingInfo{
+			Name:        name,
+			Type:        typ,
+			Default:     defaultVal,
+			Description: settingDesc,
+		})
+	}
+	return infos
+}
+
+// loadSettingsList loads the settings list to compare with. If path
+// is a JSON file, it must contain the output of `gen settings-list
+// --format=ndjson`. Otherwise, path is a cockroach binary that is run
+// to produce this output.
+func loadSettingsList(ctx context.Context, path string) ([]settingInfo, error) {
+	isJSON, err := isJSONFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if isJSON {
+		data, err = ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		args := []string{"gen", "settings-list", "--format=ndjson"}
+		if excludeSystemSettings {
+			args = append(args, "--without-system-only")
+		}
+		c := exec.CommandContext(ctx, path, args...)
+		c.Stderr = stderr
+		data, err = c.Output()
+		if err != nil {
+			return nil, errors.Wrapf(err, "running %s gen settings-list", path)
+		}
+	}
 
-			row := []string{wrapCode(name), typ, wrapCode(defaultVal), settingDesc}
+	var infos []settingInfo
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		// The input is either a sequence of rows or an array of rows.
+		var v json.RawMessage
+		if err := dec.Decode(&v); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "parsing the settings list of %s", path)
+		}
+		var rows []map[string]string
+		if bytes.HasPrefix(bytes.TrimSpace(v), []byte("[")) {
+			err = json.Unmarshal(v, &rows)
+		} else {
+			var row map[string]string
+			err = json.Unmarshal(v, &row)
 			rows = append(rows, row)
 		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing the settings list of %s", path)
+		}
+		for _, row := range rows {
+			if row[settingsListCols[0]] == "" {
+				return nil, errors.Newf("parsing the settings list of %s: missing setting name", path)
+			}
+			infos = append(infos, settingInfo{
+				Name:        row[settingsListCols[0]],
+				Type:        row[settingsListCols[1]],
+				Default:     row[settingsListCols[2]],
+				Description: row[settingsListCols[3]],
+			})
+		}
+	}
+	return infos, nil
+}
 
-		sliceIter := clisqlexec.NewRowSliceIter(rows, "dddd")
-		cols := []string{"Setting", "Type", "Default", "Description"}
-		return sqlExecCtx.PrintQueryOutput(os.Stdout, stderr, cols, sliceIter)
-	},
+// isJSONFile returns whether the file at path starts with a JSON
+// object or array, as opposed to e.g. an executable.
+func isJSONFile(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+	for {
+		c, err := r.ReadByte()
+		if err == io.EOF {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '{', '[':
+			return true, nil
+		default:
+			return false, nil
+		}
+	}
+}
+
+// settingsDiff is the output of `gen settings-list --compare`.
+type settingsDiff struct {
+	Added   []settingInfo   `json:"added"`
+	Removed []settingInfo   `json:"removed"`
+	Changed []settingChange `json:"changed"`
+}
+
+// settingChange describes a setting whose type, default value or
+// description changed.
+type settingChange struct {
+	Name string      `json:"name"`
+	Old  settingInfo `json:"old"`
+	New  settingInfo `json:"new"`
+}
+
+// diffSettingsLists compares two settings lists. The settings in the
+// result are sorted by name.
+func diffSettingsLists(old, cur []settingInfo) settingsDiff {
+	oldByName := make(map[string]settingInfo, len(old))
+	for _, info := range old {
+		oldByName[info.Name] = info
+	}
+	curByName := make(map[string]settingInfo, len(cur))
+	for _, info := range cur {
+		curByName[info.Name] = info
+	}
+
+	diff := settingsDiff{
+		Added:   []settingInfo{},
+		Removed: []settingInfo{},
+		Changed: []settingChange{},
+	}
+	for _, info := range cur {
+		oldInfo, ok := oldByName[info.Name]
+		if !ok {
+			diff.Added = append(diff.Added, info)
+		} else if oldInfo != info {
+			diff.Changed = append(diff.Changed, settingChange{Name: info.Name, Old: oldInfo, New: info})
+		}
+	}
+	for _, info := range old {
+		if _, ok := curByName[info.Name]; !ok {
+			diff.Removed = append(diff.Removed, info)
+		}
+	}
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Name < diff.Added[j].Name })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Name < diff.Removed[j].Name })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].Name < diff.Changed[j].Name })
+	return diff
 }
 
 var genCmd = &cobra.Command{
@@ -293,6 +487,9 @@ func init() {
 		"include undocumented 'reserved' settings")
 	genSettingsListCmd.PersistentFlags().BoolVar(&excludeSystemSettings, "without-system-only", false,
 		"do not list settings only applicable to system tenant")
+	genSettingsListCmd.PersistentFlags().StringVar(&compareSettingsWith, "compare", "",
+		"output as JSON the settings added, removed or changed since the given cockroach binary "+
+			"or settings list output by --format=ndjson")
 
 	genCmd.AddCommand(genCmds...)
 }
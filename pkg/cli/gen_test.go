@@ -11,6 +11,8 @@
 package cli
 
 import (
+	"context"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -19,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGenMan(t *testing.T) {
@@ -94,3 +97,48 @@ func TestGenAutocomplete(t *testing.T) {
 		})
 	}
 }
+
+func TestGenSettingsListCompare(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	cur := collectSettingsList()
+	require.NotEmpty(t, cur)
+	require.Equal(t, settingsDiff{
+		Added: []settingInfo{}, Removed: []settingInfo{}, Changed: []settingChange{},
+	}, diffSettingsLists(cur, cur))
+
+	// The old list has a setting that was removed, one whose default
+	// changed and lacks all the other current settings.
+	const oldList = `{"Setting": "a.removed.setting", "Type": "boolean", "Default": "true", "Description": "gone"}
+{"Setting": "sql.defaults.distsql", "Type": "enumeration", "Default": "off", "Description": "old"}
+`
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "old.json")
+	require.NoError(t, ioutil.WriteFile(jsonPath, []byte(oldList), 0644))
+	// A fake binary that outputs the old list.
+	binPath := filepath.Join(dir, "cockroach")
+	require.NoError(t, ioutil.WriteFile(binPath,
+		[]byte("#!/bin/sh\ncat <<'EOF'\n"+oldList+"EOF\n"), 0755))
+
+	for _, path := range []string{jsonPath, binPath} {
+		old, err := loadSettingsList(context.Background(), path)
+		require.NoError(t, err)
+		require.Len(t, old, 2)
+
+		diff := diffSettingsLists(old, cur)
+		require.Len(t, diff.Added, len(cur)-1)
+		require.Equal(t, []settingInfo{old[0]}, diff.Removed)
+		require.Len(t, diff.Changed, 1)
+		require.Equal(t, "sql.defaults.distsql", diff.Changed[0].Name)
+		require.Equal(t, old[1], diff.Changed[0].Old)
+	}
+
+	// A JSON array is also accepted.
+	arrayPath := filepath.Join(dir, "old_array.json")
+	require.NoError(t, ioutil.WriteFile(arrayPath,
+		[]byte(`[{"Setting": "a", "Type": "b", "Default": "c", "Description": "d"}]`), 0644))
+	old, err := loadSettingsList(context.Background(), arrayPath)
+	require.NoError(t, err)
+	require.Equal(t, []settingInfo{{Name: "a", Type: "b", Default: "c", Description: "d"}}, old)
+}
@@ -13,14 +13,22 @@ package cli
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/url"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/cli/clierrorplus"
 	"github.com/cockroachdb/cockroach/pkg/cli/clisqlclient"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/cloud/userfile"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/server/pgurl"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
+	isatty "github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
 
@@ -29,6 +37,10 @@ var importDumpFileCmd = &cobra.Command{
 	Short: "import a pgdump or mysqldump file into CockroachDB",
 	Long: `
 Uploads and imports a local dump file into the cockroach cluster via userfile storage.
+
+If the import fails, the uploaded file is kept in userfile storage, so that
+running the same command again resumes from the uploaded file instead of
+uploading it again. The file is deleted once the import succeeds.
 `,
 	Args: cobra.MinimumNArgs(2),
 	RunE: clierrorplus.MaybeShoutError(runDumpFileImport),
@@ -36,9 +48,17 @@ Uploads and imports a local dump file into the cockroach cluster via userfile st
 
 var importDumpTableCmd = &cobra.Command{
 	Use:   "table <table> <format> <source>",
-	Short: "import a table from a pgdump or mysqldump file into CockroachDB",
+	Short: "import a table from a pgdump, mysqldump, csv or avro file into CockroachDB",
 	Long: `
 Uploads and imports a table from the local dump file into the cockroach cluster via userfile storage.
+
+With the pgdump and mysqldump formats, the table is created from the
+definition found in the dump file. With the csv and avro formats, the
+data is imported into the existing table.
+
+If the import fails, the uploaded file is kept in userfile storage, so that
+running the same command again resumes from the uploaded file instead of
+uploading it again. The file is deleted once the import succeeds.
 `,
 	Args: cobra.MinimumNArgs(3),
 	RunE: clierrorplus.MaybeShoutError(runDumpTableImport),
@@ -110,7 +130,18 @@ func runImport(
 	conn clisqlclient.Conn,
 	importFormat, source, tableName string,
 	mode importMode,
-) error {
+) (resErr error) {
+	switch importFormat {
+	case "pgdump", "mysqldump":
+	case "csv", "avro":
+		if mode != singleTable {
+			return errors.WithHint(errors.Newf("the %s format can only be imported into a table", importFormat),
+				"Use `cockroach import table`.")
+		}
+	default:
+		return errors.New("unsupported import format")
+	}
+
 	if err := conn.EnsureConn(); err != nil {
 		return err
 	}
@@ -132,15 +163,46 @@ func runImport(
 		return err
 	}
 
+	// If a previous run of the command failed after uploading the file, the
+	// uploaded file is reused. The file is deleted only once the import has
+	// succeeded, so that a failed import can be resumed.
+	uploaded := false
 	defer func() {
+		if !uploaded {
+			return
+		}
+		if resErr != nil {
+			fmt.Fprintf(stderr, "the uploaded file %s was kept to resume the import: "+
+				"run the same command again to retry the import, or delete the file with "+
+				"`cockroach userfile delete`\n", unescapedUserfileURL)
+			return
+		}
 		// Delete the file chunks which were written as part of this IMPORT.
 		_, _ = deleteUserFile(ctx, conn, unescapedUserfileURL)
 	}()
 
-	_, err = uploadUserFile(ctx, conn, source, userfileDestinationURI)
+	stat, err := os.Stat(source)
 	if err != nil {
-		return errors.Wrap(err, "failed to upload file to userfile before importing")
+		return err
+	}
+	if uploadedSize := uploadedUserFileSize(ctx, conn, unescapedUserfileURL); uploadedSize == stat.Size() {
+		fmt.Fprintf(stderr, "resuming the import from the previously uploaded file %s\n", unescapedUserfileURL)
+	} else {
+		var p *uploadProgress
+		var progress func(int)
+		if isatty.IsTerminal(os.Stderr.Fd()) {
+			p = &uploadProgress{w: os.Stderr, name: source, total: stat.Size()}
+			progress = p.add
+		}
+		_, err = uploadUserFile(ctx, conn, source, userfileDestinationURI, progress)
+		if p != nil {
+			p.finish()
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to upload file to userfile before importing")
+		}
 	}
+	uploaded = true
 
 	if importCLIKnobs.uploadComplete != nil {
 		importCLIKnobs.uploadComplete <- struct{}{}
@@ -185,6 +247,13 @@ func runImport(
 		case multiTable:
 			importQuery = fmt.Sprintf(`IMPORT PGDUMP '%s' %s`, unescapedUserfileURL, optionsClause)
 		}
+	case "csv", "avro":
+		var optionsClause string
+		if importCtx.rowLimit > 0 {
+			optionsClause = fmt.Sprintf(" WITH row_limit='%d'", importCtx.rowLimit)
+		}
+		importQuery = fmt.Sprintf(`IMPORT INTO %s %s DATA ('%s')%s`, tableName,
+			strings.ToUpper(importFormat), unescapedUserfileURL, optionsClause)
 	case "mysqldump":
 		var optionsClause string
 		if importCtx.skipForeignKeys {
@@ -201,8 +270,6 @@ func runImport(
 			importQuery = fmt.Sprintf(`IMPORT MYSQLDUMP '%s'%s`, unescapedUserfileURL,
 				optionsClause)
 		}
-	default:
-		return errors.New("unsupported import format")
 	}
 
 	purl, err := pgurl.Parse(conn.GetURL())
@@ -225,6 +292,76 @@ func runImport(
 	return nil
 }
 
+// uploadedUserFileSize returns the size of the file at the given userfile URI,
+// or -1 if the file cannot be read.
+func uploadedUserFileSize(ctx context.Context, conn clisqlclient.Conn, userfileURI string) int64 {
+	conf, err := getUserfileConf(ctx, conn, userfileURI)
+	if err != nil {
+		return -1
+	}
+	f, err := userfile.MakeSQLConnFileTableStorage(ctx, conf, conn.GetDriverConn().(cloud.SQLConnI))
+	if err != nil {
+		return -1
+	}
+	defer f.Close()
+	// The file, or even the tables backing userfile storage, may not exist,
+	// in which case the file simply needs to be uploaded: any other error
+	// will be reported by the upload.
+	reader, size, err := f.ReadFileAt(ctx, "", 0)
+	if err != nil {
+		return -1
+	}
+	_ = reader.Close(ctx)
+	return size
+}
+
+// uploadProgress displays a progress bar for the upload of a file.
+type uploadProgress struct {
+	w          io.Writer
+	name       string
+	total      int64
+	uploaded   int64
+	lastUpdate time.Time
+}
+
+// uploadProgressInterval is the minimum interval between two updates of
+// the progress bar.
+const uploadProgressInterval = 100 * time.Millisecond
+
+// add records that n more bytes were uploaded.
+func (p *uploadProgress) add(n int) {
+	p.uploaded += int64(n)
+	if now := timeutil.Now(); now.Sub(p.lastUpdate) >= uploadProgressInterval {
+		p.lastUpdate = now
+		fmt.Fprintf(p.w, "\r%s", p.String())
+	}
+}
+
+// finish displays the final state of the progress bar.
+func (p *uploadProgress) finish() {
+	fmt.Fprintf(p.w, "\r%s\n", p.String())
+}
+
+// String renders the progress bar, for example:
+//   uploading db.sql [==========>         ]  50% 1.0 MiB/2.0 MiB
+func (p *uploadProgress) String() string {
+	const width = 20
+	fraction := 1.0
+	if p.total > 0 {
+		fraction = float64(p.uploaded) / float64(p.total)
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	filled := int(fraction * width)
+	bar := strings.Repeat("=", filled)
+	if filled < width {
+		bar += ">" + strings.Repeat(" ", width-filled-1)
+	}
+	return fmt.Sprintf("uploading %s [%s] %3d%% %s/%s", p.name, bar, int(fraction*100),
+		humanizeutil.IBytes(p.uploaded), humanizeutil.IBytes(p.total))
+}
+
 var importCmds = []*cobra.Command{
 	importDumpTableCmd,
 	importDumpFileCmd,
@@ -232,8 +369,8 @@ var importCmds = []*cobra.Command{
 
 var importCmd = &cobra.Command{
 	Use:   "import [command]",
-	Short: "import a db or table from a local PGDUMP or MYSQLDUMP file",
-	Long:  "import a db or table from a local PGDUMP or MYSQLDUMP file",
+	Short: "import a db or table from a local PGDUMP, MYSQLDUMP, CSV or AVRO file",
+	Long:  "import a db or table from a local PGDUMP, MYSQLDUMP, CSV or AVRO file",
 	RunE:  UsageAndErr,
 }
 
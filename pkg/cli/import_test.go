@@ -185,3 +185,81 @@ func TestImportCLI(t *testing.T) {
 		})
 	}
 }
+
+func TestImportCLITableOnlyFormats(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := NewCLITest(TestCLIParams{T: t})
+	defer c.Cleanup()
+
+	ctx := context.Background()
+	dumpFilePath := testutils.TestDataPath(t, "import", "db.sql")
+
+	for _, tc := range []struct {
+		format              string
+		args                string
+		expectedImportQuery string
+	}{
+		{"CSV", "", "IMPORT INTO foo CSV DATA ('userfile://defaultdb.public.userfiles_root/db.sql')"},
+		{"csv", "--row-limit=10", "IMPORT INTO foo CSV DATA " +
+			"('userfile://defaultdb.public.userfiles_root/db.sql') WITH row_limit='10'"},
+		{"AVRO", "", "IMPORT INTO foo AVRO DATA ('userfile://defaultdb.public.userfiles_root/db.sql')"},
+	} {
+		t.Run(tc.format+tc.args, func(t *testing.T) {
+			importCLICmd := fmt.Sprintf("import table foo %s %s %s", tc.format, dumpFilePath, tc.args)
+			outputImportQuery, _ := runImportCLICommand(ctx, t, importCLICmd, dumpFilePath, c)
+			require.Equal(t, tc.expectedImportQuery, outputImportQuery)
+		})
+	}
+
+	// These formats need a target table.
+	out, err := c.RunWithCapture(fmt.Sprintf("import db csv %s", dumpFilePath))
+	require.NoError(t, err)
+	require.Contains(t, out, "the csv format can only be imported into a table")
+}
+
+func TestImportCLIResume(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := NewCLITest(TestCLIParams{T: t})
+	defer c.Cleanup()
+
+	ctx := context.Background()
+	dumpFilePath := testutils.TestDataPath(t, "import", "db.sql")
+
+	// Upload the file like a previous run of the import which failed after
+	// the upload would have.
+	out, err := c.RunWithCapture(fmt.Sprintf("userfile upload %s", dumpFilePath))
+	require.NoError(t, err)
+	require.Contains(t, out, "successfully uploaded to")
+
+	knobs, unsetImportCLIKnobs := setImportCLITestingKnobs()
+	defer unsetImportCLIKnobs()
+	knobs.pauseAfterUpload <- struct{}{}
+
+	out, err = c.RunWithCapture(fmt.Sprintf("import db pgdump %s", dumpFilePath))
+	require.NoError(t, err)
+	require.Contains(t, out, "resuming the import from the previously uploaded file "+
+		"userfile://defaultdb.public.userfiles_root/db.sql")
+	require.Contains(t, out, "IMPORT PGDUMP 'userfile://defaultdb.public.userfiles_root/db.sql'")
+
+	// The file is deleted once the import has succeeded.
+	userfileURI := constructUserfileDestinationURI(dumpFilePath, "", username.RootUserName())
+	store, err := c.ExecutorConfig().(sql.ExecutorConfig).DistSQLSrv.ExternalStorageFromURI(ctx,
+		userfileURI, username.RootUserName())
+	require.NoError(t, err)
+	_, err = store.ReadFile(ctx, "")
+	testutils.IsError(err, "file doesn't exist")
+}
+
+func TestUploadProgress(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	var buf strings.Builder
+	p := &uploadProgress{w: &buf, name: "db.sql", total: 4 << 20}
+	require.Equal(t, "uploading db.sql [>                   ]   0% 0 B/4.0 MiB", p.String())
+	p.add(1 << 20)
+	require.Equal(t, "\ruploading db.sql [=====>              ]  25% 1.0 MiB/4.0 MiB", buf.String())
+	p.uploaded = 4 << 20
+	require.Equal(t, "uploading db.sql [====================] 100% 4.0 MiB/4.0 MiB", p.String())
+}
@@ -80,6 +80,29 @@ eexpect root@
 
 end_test
 
+start_test "Check \\copy to and from a client-side file"
+
+send "\\copy t TO '/tmp/test_copy_out.csv' WITH CSV HEADER;\r"
+eexpect "COPY 4"
+eexpect root@
+
+send "TRUNCATE TABLE t;\r"
+eexpect root@
+
+send "\\copy t FROM '/tmp/test_copy_out.csv' WITH CSV HEADER;\r"
+eexpect "COPY 4"
+eexpect root@
+
+send "\\copy (SELECT id FROM t WHERE id > 2 ORDER BY id) TO STDOUT;\r"
+eexpect "3\r\n4\r\n"
+eexpect root@
+
+send "\\copy t FROM '/tmp/does_not_exist.csv';\r"
+eexpect "no such file or directory"
+eexpect root@
+
+end_test
+
 start_test "check CTRL+C during COPY exits the COPY mode as appropriate"
 
 send "COPY t FROM STDIN CSV;\r"
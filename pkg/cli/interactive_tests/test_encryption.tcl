@@ -110,4 +110,13 @@ eexpect "__level_____count____size___score______in__ingest(sz_cnt)____move(sz_cn
 # Try running without the encryption flag.
 send "$argv debug pebble db lsm $storedir --store=$storedir\r"
 eexpect "encryption was used on this store before, but no encryption flags specified."
+# The store directory defaults to the first argument.
+send "$argv debug pebble db lsm $storedir --enterprise-encryption=path=$storedir,key=$keydir/aes-256.key,old-key=$keydir/aes-256.key\r"
+eexpect "__level_____count____size___score______in__ingest(sz_cnt)____move(sz_cnt)___write(sz_cnt)____read___r-amp___w-amp\r"
+# Or to the directory containing the file passed as first argument.
+send "$argv debug pebble manifest dump `ls $storedir/MANIFEST-* | tail -n1` --enterprise-encryption=path=$storedir,key=$keydir/aes-256.key,old-key=$keydir/aes-256.key\r"
+eexpect "comparer:*cockroach_comparator"
+# Try running with encryption flags for another store.
+send "$argv debug pebble db lsm $storedir --enterprise-encryption=path=$keydir,key=$keydir/aes-256.key,old-key=$keydir/aes-256.key\r"
+eexpect "no --enterprise-encryption flag matches the store directory"
 end_test
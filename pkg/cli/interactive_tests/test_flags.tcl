@@ -54,6 +54,14 @@ interrupt
 eexpect ":/# "
 end_test
 
+start_test "Check that --listening-http-url-file gets created with the right data"
+send "$argv start-single-node --insecure --listening-http-url-file=foohttpurl\r"
+eexpect "node starting"
+system "grep -q 'http://.*:\[0-9\]\[0-9\]*' foohttpurl"
+interrupt
+eexpect ":/# "
+end_test
+
 start_test {Check that the "failed running SUBCOMMAND" message does not consider a flag the subcommand}
 send "$argv --vmodule=*=2 start --garbage\r"
 eexpect {Failed running "start"}
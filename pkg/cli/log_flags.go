@@ -108,6 +108,13 @@ func setupLogging(ctx context.Context, cmd *cobra.Command, isServerCmd, applyCon
 		commandSpecificDefaultLegacyStderrOverride = severity.WARNING
 	}
 
+	// With --log-format=json, the stderr sink uses the JSON format
+	// unless the configuration specified via --log chooses another.
+	if cliCtx.logFormat == logFormatJSON {
+		jsonFormat := "json"
+		h.Config.Sinks.Stderr.Format = &jsonFormat
+	}
+
 	// If some overrides were specified via the discrete flags,
 	// apply them.
 	//
@@ -216,6 +223,31 @@ func setupLogging(ctx context.Context, cmd *cobra.Command, isServerCmd, applyCon
 	return nil
 }
 
+// logFormat is the format of the diagnostics printed to the standard
+// error by all commands, as configured via --log-format.
+type logFormat string
+
+const (
+	logFormatText logFormat = "text"
+	logFormatJSON logFormat = "json"
+)
+
+// Type implements the pflag.Value interface.
+func (f *logFormat) Type() string { return "<string>" }
+
+// String implements the pflag.Value interface.
+func (f *logFormat) String() string { return string(*f) }
+
+// Set implements the pflag.Value interface.
+func (f *logFormat) Set(s string) error {
+	switch v := logFormat(s); v {
+	case logFormatText, logFormatJSON:
+		*f = v
+		return nil
+	}
+	return errors.Newf("invalid log format %q (possible values: %s, %s)", s, logFormatText, logFormatJSON)
+}
+
 // getDefaultLogDirFromStores derives a log directory path from the
 // configure first on-disk store. If more than one on-disk store is
 // defined, the ambiguousLogDirs return value is true.
@@ -12,11 +12,14 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/cli/clierrorplus"
@@ -335,6 +338,11 @@ func runDecommissionNode(cmd *cobra.Command, args []string) error {
 		return errors.New("no node ID specified")
 	}
 
+	if nodeCtx.nodeDecommissionDryRun && nodeCtx.nodeDecommissionChecks == nodeDecommissionCheckSkip {
+		return errors.Newf("--%s cannot be used with --%s=skip",
+			cliflags.NodeDecommissionDryRun.Name, cliflags.NodeDecommissionChecks.Name)
+	}
+
 	nodeIDs, err := parseNodeIDs(args)
 	if err != nil {
 		return err
@@ -363,6 +371,14 @@ func runDecommissionNode(cmd *cobra.Command, args []string) error {
 	}
 
 	c := serverpb.NewAdminClient(conn)
+	if nodeCtx.nodeDecommissionChecks != nodeDecommissionCheckSkip {
+		if err := runDecommissionPreCheck(ctx, c, nodeIDs); err != nil {
+			return err
+		}
+	}
+	if nodeCtx.nodeDecommissionDryRun {
+		return nil
+	}
 	if err := runDecommissionNodeImpl(ctx, c, nodeCtx.nodeDecommissionWait, nodeIDs, localNodeID); err != nil {
 		cause := errors.UnwrapAll(err)
 		if s, ok := status.FromError(cause); ok && s.Code() == codes.NotFound {
@@ -376,6 +392,53 @@ func runDecommissionNode(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runDecommissionPreCheck runs the decommission pre-checks for the
+// given nodes. The blockers found are reported as warnings, unless
+// --checks=strict or --dry-run was specified, in which case they are
+// returned as an error.
+func runDecommissionPreCheck(
+	ctx context.Context, c serverpb.AdminClient, nodeIDs []roachpb.NodeID,
+) error {
+	strict := nodeCtx.nodeDecommissionDryRun || nodeCtx.nodeDecommissionChecks == nodeDecommissionCheckStrict
+	resp, err := c.DecommissionPreCheck(ctx, &serverpb.DecommissionPreCheckRequest{NodeIDs: nodeIDs})
+	if err != nil {
+		cause := errors.UnwrapAll(err)
+		if s, ok := status.FromError(cause); ok && s.Code() == codes.Unimplemented && !strict {
+			// The server predates the pre-checks.
+			fmt.Fprintln(stderr, "warning: the server does not support the decommission pre-checks, skipping them")
+			return nil
+		}
+		return errors.Wrap(err, "while running the decommission pre-checks")
+	}
+
+	if len(resp.Blockers) == 0 {
+		if nodeCtx.nodeDecommissionDryRun {
+			fmt.Println("ok")
+		}
+		return nil
+	}
+	var buf strings.Builder
+	for i, b := range resp.Blockers {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		fmt.Fprintf(&buf, "%s: %s", b.Check, b.Message)
+	}
+	if !strict {
+		fmt.Fprintf(stderr, "warning: the decommission pre-checks found issues "+
+			"that may prevent the decommissioning from completing:\n%s\n", buf.String())
+		return nil
+	}
+	err = errors.WithDetail(
+		errors.Newf("the decommission pre-checks found %d blocker(s)", len(resp.Blockers)),
+		buf.String())
+	if !nodeCtx.nodeDecommissionDryRun {
+		err = errors.WithHintf(err, "Use --%s=enabled to decommission the nodes regardless.",
+			cliflags.NodeDecommissionChecks.Name)
+	}
+	return err
+}
+
 func getLocalNodeID(ctx context.Context, s serverpb.StatusClient) (roachpb.NodeID, error) {
 	var nodeID roachpb.NodeID
 	resp, err := s.Node(ctx, &serverpb.NodeRequest{NodeId: "local"})
@@ -712,6 +775,11 @@ After a successful drain, the server process is still running;
 use a service manager or orchestrator to terminate the process
 gracefully using e.g. a unix signal.
 
+While the node drains, the command reports every second the number
+of SQL connections and range leases that remain to be drained and the
+number of leases transferred so far. Use --format=json to report the
+progress as one JSON object per line on the standard output.
+
 If an argument is specified, the command affects the node
 whose ID is given. If --self is specified, the command
 affects the node that the command is connected to (via --host).
@@ -739,9 +807,10 @@ func runDrain(cmd *cobra.Command, args []string) (err error) {
 		targetNode = args[0]
 	}
 
-	// At the end, we'll report "ok" if there was no error.
+	// At the end, we'll report "ok" if there was no error. In JSON
+	// format, the end of the drain is reported in the progress instead.
 	defer func() {
-		if err == nil {
+		if err == nil && quitCtx.nodeDrainFormat == nodeDrainFormatText {
 			fmt.Println("ok")
 		}
 	}()
@@ -753,7 +822,84 @@ func runDrain(cmd *cobra.Command, args []string) (err error) {
 	}
 	defer finish()
 
+	// Report the progress of the drain while it proceeds.
+	progressCtx, cancelProgress := context.WithCancel(ctx)
+	defer cancelProgress()
+	lastProgress := make(chan *serverpb.DrainProgressResponse, 1)
+	go func() {
+		lastProgress <- reportDrainProgress(progressCtx, c, targetNode)
+	}()
+
 	_, _, err = doDrain(ctx, c, targetNode)
+
+	cancelProgress()
+	last := <-lastProgress
+	if err == nil && quitCtx.nodeDrainFormat == nodeDrainFormatJSON {
+		if last == nil {
+			last = &serverpb.DrainProgressResponse{}
+		}
+		return printDrainProgress(os.Stdout, quitCtx.nodeDrainFormat, last, true /* complete */)
+	}
+	return err
+}
+
+// reportDrainProgress streams the drain progress of the target node and
+// prints it in the format specified by --format, until ctx is canceled.
+// It returns the last progress received, if any.
+func reportDrainProgress(
+	ctx context.Context, c serverpb.AdminClient, targetNode string,
+) (last *serverpb.DrainProgressResponse) {
+	out := stderr
+	if quitCtx.nodeDrainFormat == nodeDrainFormatJSON {
+		out = os.Stdout
+	}
+	stream, err := c.DrainProgress(ctx, &serverpb.DrainProgressRequest{NodeId: targetNode})
+	for err == nil {
+		var resp *serverpb.DrainProgressResponse
+		if resp, err = stream.Recv(); err == nil {
+			last = resp
+			err = printDrainProgress(out, quitCtx.nodeDrainFormat, resp, false /* complete */)
+		}
+	}
+	if err != io.EOF && ctx.Err() == nil {
+		// Nodes running an older version do not implement DrainProgress;
+		// the drain proceeds without reporting its progress.
+		log.Infof(ctx, "cannot retrieve the drain progress: %v", err)
+	}
+	return last
+}
+
+// drainProgressJSON is the JSON representation of the drain progress
+// printed with --format=json.
+type drainProgressJSON struct {
+	IsDraining              bool  `json:"is_draining"`
+	SQLConnectionsRemaining int64 `json:"sql_connections_remaining"`
+	RangesRemaining         int64 `json:"ranges_remaining"`
+	LeasesTransferred       int64 `json:"leases_transferred"`
+	Complete                bool  `json:"complete"`
+}
+
+// printDrainProgress prints one drain progress report to w.
+func printDrainProgress(
+	w io.Writer, format nodeDrainFormatType, p *serverpb.DrainProgressResponse, complete bool,
+) error {
+	if format == nodeDrainFormatJSON {
+		b, err := json.Marshal(drainProgressJSON{
+			IsDraining:              p.IsDraining,
+			SQLConnectionsRemaining: p.SQLConnectionsRemaining,
+			RangesRemaining:         p.RangesRemaining,
+			LeasesTransferred:       p.LeasesTransferred,
+			Complete:                complete,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", b)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "drain progress: %d SQL connections remaining, "+
+		"%d ranges remaining, %d leases transferred\n",
+		p.SQLConnectionsRemaining, p.RangesRemaining, p.LeasesTransferred)
 	return err
 }
 
@@ -22,11 +22,13 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/build"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/testutils/skip"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
 )
 
 func Example_node() {
@@ -307,3 +309,27 @@ func extractFields(line string) ([]string, error) {
 	}
 	return r, nil
 }
+
+func TestPrintDrainProgress(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	p := &serverpb.DrainProgressResponse{
+		IsDraining:              true,
+		SQLConnectionsRemaining: 2,
+		RangesRemaining:         10,
+		LeasesTransferred:       5,
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, printDrainProgress(&buf, nodeDrainFormatText, p, false /* complete */))
+	require.Equal(t, "drain progress: 2 SQL connections remaining, "+
+		"10 ranges remaining, 5 leases transferred\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, printDrainProgress(&buf, nodeDrainFormatJSON, p, false /* complete */))
+	require.NoError(t, printDrainProgress(&buf, nodeDrainFormatJSON, p, true /* complete */))
+	require.Equal(t,
+		`{"is_draining":true,"sql_connections_remaining":2,"ranges_remaining":10,"leases_transferred":5,"complete":false}`+"\n"+
+			`{"is_draining":true,"sql_connections_remaining":2,"ranges_remaining":10,"leases_transferred":5,"complete":true}`+"\n",
+		buf.String())
+}
@@ -154,11 +154,6 @@ func doDrainNoTimeout(
 		verbose       = false
 	)
 	for ; ; prevRemaining = remaining {
-		// Tell the user we're starting to drain. This enables the user to
-		// mentally prepare for something to take some time, as opposed to
-		// wondering why nothing is happening.
-		fmt.Fprintf(stderr, "node is draining... ") // notice no final newline.
-
 		// Send a drain request with the drain bit set and the shutdown bit
 		// unset.
 		stream, err := c.Drain(ctx, &serverpb.DrainRequest{
@@ -168,7 +163,6 @@ func doDrainNoTimeout(
 			Verbose:  verbose,
 		})
 		if err != nil {
-			fmt.Fprintf(stderr, "\n") // finish the line started above.
 			return !grpcutil.IsTimeout(err), remaining > 0, errors.Wrap(err, "error sending drain request")
 		}
 		for {
@@ -179,7 +173,6 @@ func doDrainNoTimeout(
 			}
 			if err != nil {
 				// Unexpected error.
-				fmt.Fprintf(stderr, "\n") // finish the line started above.
 				log.Infof(ctx, "graceful drain failed: %v", err)
 				return false, remaining > 0, err
 			}
@@ -196,14 +189,16 @@ func doDrainNoTimeout(
 
 				// We use stderr so that 'cockroach quit''s stdout remains a
 				// simple 'ok' in case of success (for compatibility with
-				// scripts).
-				fmt.Fprintf(stderr, "remaining: %d%s\n", remaining, finalString)
+				// scripts). The line is printed in one piece so that it does
+				// not interleave with the drain progress reported by
+				// `node drain`.
+				fmt.Fprintf(stderr, "node is draining... remaining: %d%s\n", remaining, finalString)
 			} else {
 				// Either the server has decided it wanted to stop quitting; or
 				// we're running a pre-20.1 node which doesn't populate IsDraining.
 				// In either case, we need to stop sending drain requests.
 				remaining = 0
-				fmt.Fprintf(stderr, "done\n")
+				fmt.Fprintf(stderr, "node is draining... done\n")
 			}
 
 			if resp.DrainRemainingDescription != "" {
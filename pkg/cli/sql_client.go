@@ -15,6 +15,7 @@ import (
 	"strconv"
 
 	"github.com/cockroachdb/cockroach/pkg/cli/clisqlclient"
+	"github.com/cockroachdb/cockroach/pkg/security/clientsecopts"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
@@ -87,6 +88,11 @@ func makeSQLClient(appName string, defaultMode defaultSQLDb) (clisqlclient.Conn,
 		return nil, errors.Errorf("password authentication not enabled in insecure mode")
 	}
 
+	sqlConnCtx.AlternateServerAddrs, err = clientsecopts.ResolveAlternateServerAddrs(cliCtx.clientOpts)
+	if err != nil {
+		return nil, err
+	}
+
 	sqlURL := baseURL.ToPQ().String()
 
 	if log.V(2) {
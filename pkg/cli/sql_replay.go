@@ -0,0 +1,112 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/cli/clierrorplus"
+	"github.com/cockroachdb/cockroach/pkg/cli/clisqlclient"
+	"github.com/cockroachdb/cockroach/pkg/cli/clisqlexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+)
+
+// sqlReplayCmd re-executes a session recorded with `cockroach sql
+// --record-session`.
+var sqlReplayCmd = &cobra.Command{
+	Use:   "replay [options] <file>",
+	Short: "replay a recorded sql session",
+	Long: `
+Re-execute the statements of a session recorded with
+'cockroach sql --record-session' against a cockroach database, and
+report the statements whose results or errors differ from the
+recording.
+
+The results are compared using the fingerprints stored in the
+recording, so statements whose results are not deterministic (for
+example, queries without ORDER BY) may be reported as mismatches.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: clierrorplus.MaybeDecorateError(runSQLReplay),
+}
+
+func runSQLReplay(cmd *cobra.Command, args []string) (resErr error) {
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	recs, err := clisqlexec.ReadSessionRecords(f)
+	_ = f.Close()
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", args[0])
+	}
+
+	conn, err := makeSQLClient(catconstants.InternalSQLAppName, useDefaultDb)
+	if err != nil {
+		return err
+	}
+	defer func() { resErr = errors.CombineErrors(resErr, conn.Close()) }()
+
+	ctx := context.Background()
+	var numMismatches int
+	var recordedLatency, replayedLatency time.Duration
+	for i, rec := range recs {
+		startTime := timeutil.Now()
+		fingerprint, err := sqlExecCtx.RunQueryAndFingerprintResults(
+			ctx, conn, ioutil.Discard, ioutil.Discard, clisqlclient.MakeQuery(rec.SQL),
+		)
+		latency := timeutil.Since(startTime)
+		recordedLatency += rec.Latency
+		replayedLatency += latency
+
+		var errMsg string
+		if err != nil {
+			errMsg = err.Error()
+		}
+		if fingerprint == rec.Fingerprint && errMsg == rec.Error {
+			continue
+		}
+		numMismatches++
+		fmt.Printf("statement #%d differs from the recording:\n  %s\n", i+1, rec.SQL)
+		if errMsg != rec.Error {
+			fmt.Printf("  recorded error: %s\n  replayed error: %s\n",
+				orNone(rec.Error), orNone(errMsg))
+		} else {
+			fmt.Printf("  recorded fingerprint: %s\n  replayed fingerprint: %s\n",
+				rec.Fingerprint, fingerprint)
+		}
+	}
+
+	fmt.Printf("replayed %d statements, %d mismatches (recorded time: %s, replayed time: %s)\n",
+		len(recs), numMismatches, recordedLatency, replayedLatency)
+	if numMismatches > 0 {
+		return errors.Newf("%d statements differ from the recording", numMismatches)
+	}
+	return nil
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+func init() {
+	sqlShellCmd.AddCommand(sqlReplayCmd)
+}
@@ -140,6 +140,7 @@ var serverCmds = append(StartCmds, mtStartSQLCmd)
 // after other types of configuration.
 var customLoggingSetupCmds = append(
 	serverCmds, debugCheckLogConfigCmd, demoCmd, mtStartSQLProxyCmd, mtTestDirectorySvr, statementBundleRecreateCmd,
+	statementBundleReplayCmd,
 )
 
 func initBlockProfile() {
@@ -532,6 +533,16 @@ func runStart(cmd *cobra.Command, args []string, startSingleNode bool) (returnEr
 			}
 		}
 
+		// Ditto for the DB Console URL. The HTTP listener, including its
+		// TLS configuration, is set up before ReadyFn is called, so the
+		// URL is ready to be used by the time the file is written.
+		if startCtx.listeningHTTPURLFile != "" {
+			log.Ops.Infof(ctx, "listening HTTP URL file: %s", startCtx.listeningHTTPURLFile)
+			if err := ioutil.WriteFile(startCtx.listeningHTTPURLFile, []byte(fmt.Sprintf("%s\n", serverCfg.AdminURL())), 0644); err != nil {
+				log.Ops.Errorf(ctx, "failed writing the HTTP URL: %v", err)
+			}
+		}
+
 		if waitForInit {
 			log.Ops.Shout(ctx, severity.INFO,
 				"initial startup completed.\n"+
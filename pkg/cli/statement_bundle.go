@@ -11,6 +11,7 @@
 package cli
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
 	"database/sql/driver"
@@ -36,6 +37,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/errors"
@@ -62,8 +64,22 @@ and stats in an unzipped statement bundle directory.
 	Args: cobra.ExactArgs(1),
 }
 
+var statementBundleReplayCmd = &cobra.Command{
+	Use:   "replay <stmt bundle zip or zipdir>",
+	Short: "replay the statement from the statement bundle in a demo cluster",
+	Long: `
+Run the replay tool to populate a transient demo cluster with the environment,
+schema, and stats from a statement bundle (either the zip file itself or an
+unzipped directory), and then run the EXPLAIN commands specified with
+--explain-cmd (by default, EXPLAIN and EXPLAIN ANALYZE) on the bundle's
+statement. The placeholder values recorded in the bundle, if any, are used.
+`,
+	Args: cobra.ExactArgs(1),
+}
+
 var placeholderPairs []string
 var explainPrefix string
+var replayExplainCmds []string
 
 func init() {
 	statementBundleRecreateCmd.RunE = clierrorplus.MaybeDecorateError(func(cmd *cobra.Command, args []string) error {
@@ -76,6 +92,14 @@ func init() {
 	statementBundleRecreateCmd.Flags().StringVar(&explainPrefix, "explain-cmd", "EXPLAIN",
 		"set the EXPLAIN command used to produce the final output when displaying all optimal explain plans with"+
 			" --placeholder. Example: EXPLAIN(OPT)")
+
+	statementBundleReplayCmd.RunE = clierrorplus.MaybeDecorateError(func(cmd *cobra.Command, args []string) error {
+		return runBundleReplay(cmd, args)
+	})
+
+	statementBundleReplayCmd.Flags().StringArrayVar(&replayExplainCmds, "explain-cmd",
+		[]string{"EXPLAIN", "EXPLAIN ANALYZE"},
+		"set the EXPLAIN commands that are run on the statement, in order. Example: --explain-cmd='EXPLAIN(OPT)'")
 }
 
 type statementBundle struct {
@@ -123,19 +147,104 @@ func loadStatementBundle(zipdir string) (*statementBundle, error) {
 	})
 }
 
-func runBundleRecreate(cmd *cobra.Command, args []string) error {
-	zipdir := args[0]
-	bundle, err := loadStatementBundle(zipdir)
+// loadStatementBundleZip extracts the statement bundle zip file into a
+// temporary directory and loads it from there.
+func loadStatementBundleZip(zipPath string) (*statementBundle, error) {
+	r, err := zip.OpenReader(zipPath)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer r.Close()
+	dir, err := ioutil.TempDir("", "stmt-bundle")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		// The files in a statement bundle are all at the top level, so we
+		// only use the base name to make sure we don't write outside of the
+		// temporary directory.
+		if err := extractZipFile(f, filepath.Join(dir, filepath.Base(f.Name))); err != nil {
+			return nil, err
+		}
 	}
+	return loadStatementBundle(dir)
+}
 
-	closeFn, err := sqlCtx.Open(os.Stdin)
+func extractZipFile(f *zip.File, path string) error {
+	rc, err := f.Open()
 	if err != nil {
 		return err
 	}
-	defer closeFn()
-	ctx := context.Background()
+	defer rc.Close()
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, rc); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// parseBundleStatement splits the statement file of a statement bundle into
+// the statement itself and the values of its placeholders (if any), in the
+// order of placeholder indexes. The values are returned as query arguments:
+// NULL is returned as nil, and all other values are returned in the text
+// format used by pgwire (so that, for example, arrays are passed as '{1,2}').
+func parseBundleStatement(stmtFile []byte) (statement string, args []interface{}, _ error) {
+	stmtComponents := strings.SplitN(string(stmtFile), "Arguments:", 2)
+	statement = strings.TrimSpace(stmtComponents[0])
+	if len(stmtComponents) == 1 {
+		return statement, nil, nil
+	}
+	statement = strings.TrimSpace(strings.TrimSuffix(statement, "--"))
+	evalCtx := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+	semaCtx := tree.MakeSemaContext()
+	for _, line := range strings.Split(stmtComponents[1], "\n") {
+		matches := placeholderRe.FindStringSubmatch(line)
+		if len(matches) != 3 {
+			continue
+		}
+		n, err := strconv.Atoi(matches[1])
+		if err != nil {
+			return "", nil, err
+		}
+		if n != len(args)+1 {
+			return "", nil, errors.Errorf("unexpected placeholder $%d", n)
+		}
+		// The values are formatted as SQL expressions, so we need to evaluate
+		// them in order to pass them as arguments.
+		expr, err := parser.ParseExpr(strings.TrimSpace(matches[2]))
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "failed to parse value of placeholder $%d", n)
+		}
+		typedExpr, err := tree.TypeCheck(context.Background(), expr, &semaCtx, types.Any)
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "failed to type check value of placeholder $%d", n)
+		}
+		d, err := eval.Expr(&evalCtx, typedExpr)
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "failed to evaluate value of placeholder $%d", n)
+		}
+		if d == tree.DNull {
+			args = append(args, nil)
+			continue
+		}
+		args = append(args, tree.AsStringWithFlags(d, tree.FmtPgwireText))
+	}
+	return statement, args, nil
+}
+
+// openStatementBundleDemo starts a demo cluster and populates it with the
+// environment, schema, and stats from the statement bundle.
+func openStatementBundleDemo(
+	ctx context.Context, cmd *cobra.Command, bundle *statementBundle,
+) (democluster.DemoCluster, clisqlclient.Conn, error) {
 	c, err := democluster.NewDemoCluster(ctx, &demoCtx.Context,
 		log.Infof,
 		log.Warningf,
@@ -155,31 +264,55 @@ func runBundleRecreate(cmd *cobra.Command, args []string) error {
 	)
 	if err != nil {
 		c.Close(ctx)
-		return err
+		return nil, nil, err
 	}
-	defer c.Close(ctx)
 
 	initGEOS(ctx)
 
 	if err := c.Start(ctx, runInitialSQL); err != nil {
-		return clierrorplus.CheckAndMaybeShout(err)
+		c.Close(ctx)
+		return nil, nil, clierrorplus.CheckAndMaybeShout(err)
 	}
 	conn, err := sqlCtx.MakeConn(c.GetConnURL())
 	if err != nil {
-		return err
+		c.Close(ctx)
+		return nil, nil, err
 	}
 	// Disable autostats collection, which will override the injected stats.
 	if err := conn.Exec(ctx,
 		`SET CLUSTER SETTING sql.stats.automatic_collection.enabled = false`); err != nil {
-		return err
+		c.Close(ctx)
+		return nil, nil, err
 	}
 	var initStmts = [][]byte{bundle.env, bundle.schema}
 	initStmts = append(initStmts, bundle.stats...)
 	for _, a := range initStmts {
 		if err := conn.Exec(ctx, string(a)); err != nil {
-			return errors.Wrapf(err, "failed to run %s", a)
+			c.Close(ctx)
+			return nil, nil, errors.Wrapf(err, "failed to run %s", a)
 		}
 	}
+	return c, conn, nil
+}
+
+func runBundleRecreate(cmd *cobra.Command, args []string) error {
+	zipdir := args[0]
+	bundle, err := loadStatementBundle(zipdir)
+	if err != nil {
+		return err
+	}
+
+	closeFn, err := sqlCtx.Open(os.Stdin)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+	ctx := context.Background()
+	c, conn, err := openStatementBundleDemo(ctx, cmd, bundle)
+	if err != nil {
+		return err
+	}
+	defer c.Close(ctx)
 
 	cliCtx.PrintfUnlessEmbedded(`#
 # Statement bundle %s loaded.
@@ -218,12 +351,76 @@ func runBundleRecreate(cmd *cobra.Command, args []string) error {
 	return sqlCtx.Run(conn)
 }
 
+func runBundleReplay(cmd *cobra.Command, args []string) error {
+	bundlePath := args[0]
+	var bundle *statementBundle
+	var err error
+	if strings.HasSuffix(bundlePath, ".zip") {
+		bundle, err = loadStatementBundleZip(bundlePath)
+	} else {
+		bundle, err = loadStatementBundle(bundlePath)
+	}
+	if err != nil {
+		return err
+	}
+	statement, placeholders, err := parseBundleStatement(bundle.statement)
+	if err != nil {
+		return err
+	}
+
+	closeFn, err := sqlCtx.Open(os.Stdin)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+	ctx := context.Background()
+	c, conn, err := openStatementBundleDemo(ctx, cmd, bundle)
+	if err != nil {
+		return err
+	}
+	defer c.Close(ctx)
+
+	outputs, err := getExplainsOutputs(conn, replayExplainCmds, statement, placeholders)
+	if err != nil {
+		return err
+	}
+	for i, output := range outputs {
+		cliCtx.PrintfUnlessEmbedded("# %s:\n%s\n", replayExplainCmds[i], output)
+	}
+	return nil
+}
+
+// getExplainsOutputs runs each of the given explain styles on the statement
+// with the given placeholder values. The ith returned output corresponds to
+// the ith explain style.
+func getExplainsOutputs(
+	conn clisqlclient.Conn,
+	explainPrefixes []string,
+	statement string,
+	placeholders []interface{},
+) ([]string, error) {
+	outputs := make([]string, 0, len(explainPrefixes))
+	for _, prefix := range explainPrefixes {
+		output, err := getExplainOutput(conn, prefix, statement, placeholders)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to run %s", prefix)
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
 // placeholderRe matches the placeholder format at the bottom of statement.txt
 // in a statement bundle. It looks like this:
 //
-// $1: blah
-// $2: 1
-var placeholderRe = regexp.MustCompile(`\$(\d+): .*`)
+// -- Arguments:
+// --  $1: 'blah'
+// --  $2: 1
+//
+// (in 21.2 and prior releases, the lines were not prefixed with "--"). The
+// first matching group is the number of the placeholder, and the second one
+// is its value.
+var placeholderRe = regexp.MustCompile(`\$(\d+): (.*)`)
 
 var statsRe = regexp.MustCompile(`ALTER TABLE ([\w.]+) INJECT STATISTICS '`)
 
@@ -260,9 +457,9 @@ func getExplainCombinations(
 
 	var stmtPlaceholders []int
 	for _, line := range strings.Split(placeholders, "\n") {
-		// The placeholderRe has 1 matching group, so the length of the matches
-		// list will be 2 if we see a successful match.
-		if matches := placeholderRe.FindStringSubmatch(line); len(matches) == 2 {
+		// The placeholderRe has 2 matching groups, so the length of the matches
+		// list will be 3 if we see a successful match.
+		if matches := placeholderRe.FindStringSubmatch(line); len(matches) == 3 {
 			// The first matching group is the number of the placeholder. Extract it
 			// into an integer.
 			n, err := strconv.Atoi(matches[1])
@@ -424,31 +621,43 @@ func getExplainOutputs(
 ) (explainStrings []string, err error) {
 	for _, values := range inputs {
 		// Run an explain for each possible input.
-		query := fmt.Sprintf("%s %s", explainPrefix, statement)
 		args := make([]interface{}, len(values))
 		for i, s := range values {
 			args[i] = s
 		}
-		rows, err := conn.Query(context.Background(), query, args...)
+		explainStr, err := getExplainOutput(conn, explainPrefix, statement, args)
 		if err != nil {
 			return nil, err
 		}
-		row := []driver.Value{""}
-		var explainStr = strings.Builder{}
-		for err = rows.Next(row); err == nil; err = rows.Next(row) {
-			fmt.Fprintln(&explainStr, row[0])
-		}
-		if err != io.EOF {
-			return nil, err
-		}
-		if err := rows.Close(); err != nil {
-			return nil, err
-		}
-		explainStrings = append(explainStrings, explainStr.String())
+		explainStrings = append(explainStrings, explainStr)
 	}
 	return explainStrings, nil
 }
 
+// getExplainOutput runs the explain style given in explainPrefix on the
+// statement with the given placeholder values and returns its output.
+func getExplainOutput(
+	conn clisqlclient.Conn, explainPrefix string, statement string, args []interface{},
+) (string, error) {
+	query := fmt.Sprintf("%s %s", explainPrefix, statement)
+	rows, err := conn.Query(context.Background(), query, args...)
+	if err != nil {
+		return "", err
+	}
+	row := []driver.Value{""}
+	var explainStr = strings.Builder{}
+	for err = rows.Next(row); err == nil; err = rows.Next(row) {
+		fmt.Fprintln(&explainStr, row[0])
+	}
+	if err != io.EOF {
+		return "", err
+	}
+	if err := rows.Close(); err != nil {
+		return "", err
+	}
+	return explainStr.String(), nil
+}
+
 // getPlaceholderCombinations returns a list of lists, which each inner list is
 // a possible set of placeholders that can be inserted into the statement, where
 // each possible value for each placeholder is taken from the input statsMap.
@@ -88,3 +88,55 @@ func TestRunExplainCombinations(t *testing.T) {
 		assert.Equal(t, test.expectedOutputs, outputs)
 	}
 }
+
+func TestParseBundleStatement(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	tests := []struct {
+		stmtFile          string
+		expectedStatement string
+		expectedArgs      []interface{}
+	}{
+		{
+			stmtFile:          "SELECT * FROM a",
+			expectedStatement: "SELECT * FROM a",
+		},
+		{
+			stmtFile: `SELECT * FROM a WHERE a = $1:::INT8 AND b = $2:::INT8
+
+Arguments:
+  $1: 1
+  $2: 1
+`,
+			expectedStatement: "SELECT * FROM a WHERE a = $1:::INT8 AND b = $2:::INT8",
+			expectedArgs:      []interface{}{"1", "1"},
+		},
+		{
+			stmtFile: `SELECT * FROM t WHERE s = $1 AND d = $2
+
+-- Arguments:
+--  $1: 'foo':::STRING
+--  $2: '2022-01-01':::DATE
+`,
+			expectedStatement: "SELECT * FROM t WHERE s = $1 AND d = $2",
+			expectedArgs:      []interface{}{"foo", "2022-01-01"},
+		},
+		{
+			stmtFile: `SELECT * FROM t WHERE s = $1 AND a = $2 AND b = $3
+
+-- Arguments:
+--  $1: NULL
+--  $2: ARRAY[1:::INT8, 2:::INT8]
+--  $3: ARRAY['a b':::STRING, NULL]
+`,
+			expectedStatement: "SELECT * FROM t WHERE s = $1 AND a = $2 AND b = $3",
+			expectedArgs:      []interface{}{nil, "{1,2}", `{"a b",NULL}`},
+		},
+	}
+
+	for _, test := range tests {
+		statement, args, err := parseBundleStatement([]byte(test.stmtFile))
+		assert.NoError(t, err)
+		assert.Equal(t, test.expectedStatement, statement)
+		assert.Equal(t, test.expectedArgs, args)
+	}
+}
@@ -171,7 +171,7 @@ func uploadUserFileRecursive(conn clisqlclient.Conn, srcDir, dstDir string) erro
 				relativePath := strings.TrimPrefix(path, srcDir+"/")
 				fmt.Printf("uploading: %s\n", relativePath)
 
-				uploadedFile, err := uploadUserFile(ctx, conn, path, dstDir+"/"+relativePath)
+				uploadedFile, err := uploadUserFile(ctx, conn, path, dstDir+"/"+relativePath, nil /* progress */)
 				if err != nil {
 					return err
 				}
@@ -207,7 +207,7 @@ func runUserFileUpload(cmd *cobra.Command, args []string) (resErr error) {
 		}
 	} else {
 		uploadedFile, err := uploadUserFile(context.Background(), conn, source,
-			destination)
+			destination, nil /* progress */)
 		if err != nil {
 			return err
 		}
@@ -585,9 +585,10 @@ func renameUserFile(
 // uploadUserFile is responsible for uploading the local source file to the user
 // scoped storage referenced by destination.
 // This method returns the complete userfile URI representation to which the
-// file is uploaded to.
+// file is uploaded to. If progress is not nil, it is called with the size of
+// each uploaded chunk.
 func uploadUserFile(
-	ctx context.Context, conn clisqlclient.Conn, source, destination string,
+	ctx context.Context, conn clisqlclient.Conn, source, destination string, progress func(n int),
 ) (string, error) {
 	reader, err := openUserFile(source)
 	if err != nil {
@@ -658,6 +659,9 @@ func uploadUserFile(
 			if err != nil {
 				return "", err
 			}
+			if progress != nil {
+				progress(n)
+			}
 		} else if err == io.EOF {
 			break
 		} else if err != nil {
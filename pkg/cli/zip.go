@@ -159,6 +159,14 @@ func runDebugZip(_ *cobra.Command, args []string) (retErr error) {
 	if err := zipCtx.files.validate(); err != nil {
 		return err
 	}
+	redactor, err := newZipRedactor(zipCtx.redactProfile, zipCtx.redactRulesFile)
+	if err != nil {
+		return err
+	}
+	if zipCtx.redactProfile != zipRedactNone {
+		// All the profiles but none redact the log entries server-side.
+		zipCtx.redactLogs = true
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -223,6 +231,7 @@ func runDebugZip(_ *cobra.Command, args []string) (retErr error) {
 	}
 
 	z := newZipper(out)
+	z.redactor = redactor
 	defer func() {
 		cErr := z.close()
 		retErr = errors.CombineErrors(retErr, cErr)
@@ -35,6 +35,11 @@ type zipper struct {
 
 	f *os.File
 	z *zip.Writer
+
+	// redactor, if set, scrubs the contents of the entries.
+	redactor *zipRedactor
+	// cur is the writer of the last entry created, if it is redacted.
+	cur *zipRedactWriter
 }
 
 func newZipper(f *os.File) *zipper {
@@ -48,23 +53,43 @@ func (z *zipper) close() error {
 	z.Lock()
 	defer z.Unlock()
 
-	err1 := z.z.Close()
-	err2 := z.f.Close()
-	return errors.CombineErrors(err1, err2)
+	err1 := z.flushLocked()
+	err2 := z.z.Close()
+	err3 := z.f.Close()
+	return errors.CombineErrors(err1, errors.CombineErrors(err2, err3))
+}
+
+// flushLocked completes the redaction of the last entry created. The
+// caller is responsible for locking the zipper beforehand.
+func (z *zipper) flushLocked() error {
+	if z.cur == nil {
+		return nil
+	}
+	err := z.cur.flush()
+	z.cur = nil
+	return err
 }
 
 // createLocked opens a new entry in the zip file. The caller is
 // responsible for locking the zipper beforehand.
 // Unsafe for concurrent use otherwise.
 func (z *zipper) createLocked(name string, mtime time.Time) (io.Writer, error) {
+	if err := z.flushLocked(); err != nil {
+		return nil, err
+	}
 	if mtime.IsZero() {
 		mtime = timeutil.Now()
 	}
-	return z.z.CreateHeader(&zip.FileHeader{
+	w, err := z.z.CreateHeader(&zip.FileHeader{
 		Name:     name,
 		Method:   zip.Deflate,
 		Modified: mtime,
 	})
+	if err != nil || z.redactor == nil || !isRedactableZipEntry(name) {
+		return w, err
+	}
+	z.cur = &zipRedactWriter{r: z.redactor, w: w}
+	return z.cur, nil
 }
 
 // createRaw creates an entry and writes its contents as a byte slice.
@@ -73,6 +98,11 @@ func (z *zipper) createRaw(s *zipReporter, name string, b []byte) error {
 	z.Lock()
 	defer z.Unlock()
 
+	if z.redactor != nil && z.redactor.omitBinary && !isRedactableZipEntry(name) {
+		s.progress("omitting binary output, which cannot be redacted: %s", name)
+		s.done()
+		return nil
+	}
 	s.progress("writing binary output: %s", name)
 	w, err := z.createLocked(name, time.Time{})
 	if err != nil {
@@ -73,6 +73,7 @@ var debugZipTablesPerNode = []string{
 	"crdb_internal.node_execution_outliers",
 	"crdb_internal.node_inflight_trace_spans",
 	"crdb_internal.node_metrics",
+	"crdb_internal.node_plan_baselines",
 	"crdb_internal.node_queries",
 	"crdb_internal.node_runtime_info",
 	"crdb_internal.node_sessions",
@@ -80,6 +81,7 @@ var debugZipTablesPerNode = []string{
 	"crdb_internal.node_transaction_statistics",
 	"crdb_internal.node_transactions",
 	"crdb_internal.node_txn_stats",
+	"crdb_internal.node_vectorized_fallbacks",
 	"crdb_internal.active_range_feeds",
 }
 
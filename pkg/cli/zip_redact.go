@@ -0,0 +1,233 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/redact"
+)
+
+// zipRedactProfile is the redaction applied to the contents of the zip
+// file, selected with --redact-profile.
+type zipRedactProfile int
+
+const (
+	// zipRedactNone does not redact anything beyond what --redact-logs
+	// and --redact-rules request.
+	zipRedactNone zipRedactProfile = iota
+	// zipRedactPII redacts the log entries server-side and scrubs the
+	// email and IP addresses from all the text files.
+	zipRedactPII
+	// zipRedactFull extends zipRedactPII by also scrubbing the string
+	// literals from the text files, and omits the files that cannot be
+	// scrubbed (profiles, goroutine dumps).
+	zipRedactFull
+)
+
+// Type implements the pflag.Value interface.
+func (p *zipRedactProfile) Type() string { return "string" }
+
+// String implements the pflag.Value interface.
+func (p *zipRedactProfile) String() string {
+	switch *p {
+	case zipRedactNone:
+		return "none"
+	case zipRedactPII:
+		return "pii"
+	case zipRedactFull:
+		return "full"
+	default:
+		panic("unexpected redaction profile (possible values: none, pii, full)")
+	}
+}
+
+// Set implements the pflag.Value interface.
+func (p *zipRedactProfile) Set(value string) error {
+	switch value {
+	case "none":
+		*p = zipRedactNone
+	case "pii":
+		*p = zipRedactPII
+	case "full":
+		*p = zipRedactFull
+	default:
+		return fmt.Errorf("invalid redaction profile: %s "+
+			"(possible values: none, pii, full)", value)
+	}
+	return nil
+}
+
+// zipRedactRule replaces the matches of a regular expression.
+type zipRedactRule struct {
+	re *regexp.Regexp
+	// replacement is expanded as per regexp.Expand.
+	replacement []byte
+}
+
+var zipRedactMarker = redact.RedactedMarker()
+
+// piiRedactRules are the rules of the pii profile.
+var piiRedactRules = []zipRedactRule{
+	// Email addresses.
+	{re: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)},
+	// IPv4 addresses.
+	{re: regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)},
+	// IPv6 addresses, in full or compressed form.
+	{re: regexp.MustCompile(`(?i)\b(?:[0-9a-f]{1,4}:){7}[0-9a-f]{1,4}\b|` +
+		`\b(?:[0-9a-f]{1,4}:){1,6}:(?:[0-9a-f]{1,4}(?::[0-9a-f]{1,4})*\b)?`)},
+}
+
+// fullRedactRules are the rules added by the full profile.
+var fullRedactRules = []zipRedactRule{
+	// SQL string literals, e.g. in statement fingerprints or job
+	// descriptions.
+	{re: regexp.MustCompile(`'(?:[^'\n]|'')*'`), replacement: []byte(`'` + string(zipRedactMarker) + `'`)},
+}
+
+// zipRedactor scrubs the contents of the zip file.
+type zipRedactor struct {
+	rules []zipRedactRule
+	// omitBinary, when set, causes the files whose contents cannot be
+	// scrubbed to be omitted from the zip file.
+	omitBinary bool
+}
+
+// newZipRedactor returns the redactor for the given profile, extended
+// with the rules of the given file if non-empty. It returns nil if
+// there is nothing to redact.
+func newZipRedactor(profile zipRedactProfile, rulesFile string) (*zipRedactor, error) {
+	r := &zipRedactor{}
+	switch profile {
+	case zipRedactPII:
+		r.rules = append(r.rules, piiRedactRules...)
+	case zipRedactFull:
+		r.rules = append(r.rules, piiRedactRules...)
+		r.rules = append(r.rules, fullRedactRules...)
+		r.omitBinary = true
+	}
+	if rulesFile != "" {
+		f, err := os.Open(rulesFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		rules, err := parseZipRedactRules(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid redaction rules file %s", rulesFile)
+		}
+		r.rules = append(r.rules, rules...)
+	}
+	if len(r.rules) == 0 && !r.omitBinary {
+		return nil, nil
+	}
+	return r, nil
+}
+
+// parseZipRedactRules parses a redaction rules file. Each line is
+// either empty, a comment starting with #, or a rule of the form:
+//
+//   <regexp>
+//   <regexp> => <replacement>
+//
+// The matches of the regular expression are replaced by the
+// replacement, in which $1 or ${name} refer to the submatches, or by
+// the redaction marker ‹×› if there is no replacement.
+func parseZipRedactRules(r io.Reader) ([]zipRedactRule, error) {
+	var rules []zipRedactRule
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var rule zipRedactRule
+		pattern := line
+		if i := strings.Index(line, " => "); i >= 0 {
+			pattern = strings.TrimSpace(line[:i])
+			rule.replacement = []byte(strings.TrimSpace(line[i+len(" => "):]))
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", lineNum)
+		}
+		rule.re = re
+		rules = append(rules, rule)
+	}
+	return rules, scanner.Err()
+}
+
+// redact applies the rules to the given line.
+func (r *zipRedactor) redact(line []byte) []byte {
+	for _, rule := range r.rules {
+		repl := rule.replacement
+		if repl == nil {
+			line = rule.re.ReplaceAllLiteral(line, zipRedactMarker)
+		} else {
+			line = rule.re.ReplaceAll(line, repl)
+		}
+	}
+	return line
+}
+
+// isRedactableZipEntry returns whether the zip entry with the given
+// name contains text, which the redaction rules can be applied to.
+func isRedactableZipEntry(name string) bool {
+	switch filepath.Ext(name) {
+	case ".txt", ".json", ".log", ".sh", ".skipped":
+		return true
+	}
+	return false
+}
+
+// zipRedactWriter applies the redaction rules to the contents written
+// to it, line by line.
+type zipRedactWriter struct {
+	r   *zipRedactor
+	w   io.Writer
+	buf []byte
+}
+
+// Write implements the io.Writer interface.
+func (w *zipRedactWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	rest := w.buf
+	for {
+		i := bytes.IndexByte(rest, '\n')
+		if i < 0 {
+			break
+		}
+		if _, err := w.w.Write(w.r.redact(rest[:i+1])); err != nil {
+			return 0, err
+		}
+		rest = rest[i+1:]
+	}
+	w.buf = append(w.buf[:0], rest...)
+	return len(p), nil
+}
+
+// flush writes the last line, if it was not terminated by a newline.
+func (w *zipRedactWriter) flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	_, err := w.w.Write(w.r.redact(w.buf))
+	w.buf = w.buf[:0]
+	return err
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZipRedactor(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const input = "user alice@example.com connected from 10.0.0.1:26257 and [2001:db8::1]:26257\n" +
+		"SELECT * FROM t WHERE name = 'bob' AND id = 12:34:56"
+
+	rulesFile := filepath.Join(t.TempDir(), "rules")
+	require.NoError(t, ioutil.WriteFile(rulesFile, []byte(`
+# Table names.
+\bFROM (\w+) => FROM tbl
+\bid = [\d:]+
+`), 0644))
+
+	testCases := []struct {
+		profile  zipRedactProfile
+		rules    string
+		expected string
+	}{
+		{zipRedactNone, rulesFile,
+			"user alice@example.com connected from 10.0.0.1:26257 and [2001:db8::1]:26257\n" +
+				"SELECT * FROM tbl WHERE name = 'bob' AND ‹×›"},
+		{zipRedactPII, "",
+			"user ‹×› connected from ‹×›:26257 and [‹×›]:26257\n" +
+				"SELECT * FROM t WHERE name = 'bob' AND id = 12:34:56"},
+		{zipRedactFull, "",
+			"user ‹×› connected from ‹×›:26257 and [‹×›]:26257\n" +
+				"SELECT * FROM t WHERE name = '‹×›' AND id = 12:34:56"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.profile.String(), func(t *testing.T) {
+			r, err := newZipRedactor(tc.profile, tc.rules)
+			require.NoError(t, err)
+			require.Equal(t, tc.profile == zipRedactFull, r.omitBinary)
+
+			// Write the input in small pieces to check that the rules are
+			// applied to whole lines.
+			var buf strings.Builder
+			w := &zipRedactWriter{r: r, w: &buf}
+			for i := 0; i < len(input); i += 7 {
+				end := i + 7
+				if end > len(input) {
+					end = len(input)
+				}
+				_, err := w.Write([]byte(input[i:end]))
+				require.NoError(t, err)
+			}
+			require.NoError(t, w.flush())
+			require.Equal(t, tc.expected, buf.String())
+		})
+	}
+
+	r, err := newZipRedactor(zipRedactNone, "")
+	require.NoError(t, err)
+	require.Nil(t, r)
+
+	_, err = parseZipRedactRules(strings.NewReader("foo\n(bar => baz\n"))
+	require.Error(t, err)
+	require.Regexp(t, `line 2: error parsing regexp`, err.Error())
+}
+
+func TestZipperRedaction(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	zipName := filepath.Join(t.TempDir(), "test.zip")
+	out, err := os.Create(zipName)
+	require.NoError(t, err)
+	z := newZipper(out)
+	z.redactor, err = newZipRedactor(zipRedactFull, "")
+	require.NoError(t, err)
+
+	zr := zipCtx.newZipReporter("test")
+	require.NoError(t, z.createRaw(zr.start("raw"), "nodes/1/stacks.txt", []byte("at 10.0.0.1")))
+	require.NoError(t, z.createRaw(zr.start("raw"), "nodes/1/heap.pprof", []byte("at 10.0.0.1")))
+	require.NoError(t, z.createJSON(zr.start("json"), "nodes/1/status.json",
+		map[string]string{"address": "10.0.0.2:26257"}))
+	require.NoError(t, z.close())
+
+	zf, err := zip.OpenReader(zipName)
+	require.NoError(t, err)
+	defer zf.Close()
+	contents := map[string]string{}
+	for _, f := range zf.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		b, err := ioutil.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		contents[f.Name] = string(b)
+	}
+	var names []string
+	for name := range contents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	require.Equal(t, []string{"nodes/1/stacks.txt", "nodes/1/status.json"}, names)
+	require.Equal(t, "at ‹×›", contents["nodes/1/stacks.txt"])
+	require.Equal(t, "{\n  \"address\": \"‹×›:26257\"\n}", contents["nodes/1/status.json"])
+}
@@ -77,6 +77,7 @@ table_name NOT IN (
 	'databases',
 	'forward_dependencies',
 	'index_columns',
+	'index_recommendations',
 	'lost_descriptors_with_data',
 	'table_columns',
 	'table_row_statistics',
@@ -365,6 +365,37 @@ const (
 	// version is guaranteed to reside in a cluster where all nodes support range
 	// keys at the Pebble layer.
 	EnablePebbleFormatVersionRangeKeys
+	// TimeBoundScans is the version at which all nodes honor the MinTimestamp
+	// field of ScanRequest and ReverseScanRequest, which is used by the
+	// CHANGES_SINCE table hint.
+	TimeBoundScans
+	// TableSampleScans is the version at which all nodes honor the sample field
+	// of TableReaderSpec, which is used by the TABLESAMPLE clause.
+	TableSampleScans
+	// RowLevelSecurity is the version at which all nodes understand the
+	// row-level security policies stored in table descriptors.
+	RowLevelSecurity
+	// RowLevelTriggers is the version at which all nodes understand the
+	// row-level triggers stored in table descriptors.
+	RowLevelTriggers
+	// TSearch is the version at which all nodes can decode the tsvector and
+	// tsquery column types used by full-text search.
+	TSearch
+	// SystemVersioning is the version at which all nodes honor the history
+	// fields of TableReaderSpec, which are used by the FOR SYSTEM_TIME clause,
+	// and understand the system_versioning storage parameter.
+	SystemVersioning
+	// UserDefinedAggregates is the version at which all nodes understand the
+	// user-defined aggregates stored in schema descriptors and the
+	// user_defined field of the aggregator specs.
+	UserDefinedAggregates
+	// TableSplitPolicy is the version at which all nodes understand the
+	// split_policy storage parameter and can run the jobs that maintain the
+	// splits of the tables.
+	TableSplitPolicy
+	// IndexAdvisorJob is the version at which all nodes can run the job that
+	// computes the index recommendations of crdb_internal.index_recommendations.
+	IndexAdvisorJob
 
 	// *************************************************
 	// Step (1): Add new versions here.
@@ -638,6 +669,42 @@ var versionsSingleton = keyedVersions{
 		Key:     EnablePebbleFormatVersionRangeKeys,
 		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 8},
 	},
+	{
+		Key:     TimeBoundScans,
+		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 10},
+	},
+	{
+		Key:     TableSampleScans,
+		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 12},
+	},
+	{
+		Key:     RowLevelSecurity,
+		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 14},
+	},
+	{
+		Key:     RowLevelTriggers,
+		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 16},
+	},
+	{
+		Key:     TSearch,
+		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 18},
+	},
+	{
+		Key:     SystemVersioning,
+		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 20},
+	},
+	{
+		Key:     UserDefinedAggregates,
+		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 22},
+	},
+	{
+		Key:     TableSplitPolicy,
+		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 24},
+	},
+	{
+		Key:     IndexAdvisorJob,
+		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 26},
+	},
 
 	// *************************************************
 	// Step (2): Add new versions here.
@@ -184,6 +184,11 @@ func runSQL(cmd *cobra.Command, args []string) (resErr error) {
 		fmt.Print(welcomeMessage)
 	}
 
+	cfg.ConnCtx.AlternateServerAddrs, err = clientsecopts.ResolveAlternateServerAddrs(copts)
+	if err != nil {
+		return err
+	}
+
 	conn, err := cfg.MakeConn(connURL.ToPQ().String())
 	if err != nil {
 		return err
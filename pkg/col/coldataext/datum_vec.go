@@ -11,6 +11,7 @@
 package coldataext
 
 import (
+	"bytes"
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
@@ -55,6 +56,14 @@ func newDatumVec(t *types.T, n int, evalCtx *eval.Context) coldata.DatumVec {
 // Note that the method is named differently from "Compare" so that we do not
 // overload tree.Datum.Compare method.
 func CompareDatum(d, dVec, other interface{}) int {
+	if l, ok := d.(*tree.DCollatedString); ok {
+		if r, ok := other.(*tree.DCollatedString); ok {
+			// Collated strings of the same type are ordered by their collation
+			// keys, so we compare the keys directly to avoid the type checks of
+			// tree.Datum.Compare.
+			return bytes.Compare(l.Key, r.Key)
+		}
+	}
 	return d.(tree.Datum).Compare(dVec.(*datumVec).evalCtx, convertToDatum(other))
 }
 
@@ -92,3 +92,29 @@ func TestDatumVec(t *testing.T) {
 	require.True(t, dv1.Get(1).(tree.Datum).Compare(evalCtx, tree.DNull) == 0)
 	require.True(t, dv1.Get(2).(tree.Datum).Compare(evalCtx, tree.NewDJSON(json.FromString("string2"))) == 0)
 }
+
+func TestCompareCollatedStrings(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	evalCtx := &eval.Context{}
+	var env tree.CollationEnvironment
+	dv := newDatumVec(types.MakeCollatedString(types.String, "und-u-ks-level2"), 0 /* n */, evalCtx)
+	for _, s := range []string{"abc", "ABC", "Abd", "ábc"} {
+		d, err := tree.NewDCollatedString(s, "und-u-ks-level2", &env)
+		require.NoError(t, err)
+		dv.AppendVal(d)
+	}
+	for i, tc := range []struct {
+		left, right int
+		expected    int
+	}{
+		{0, 1, 0},
+		{0, 2, -1},
+		{2, 1, 1},
+		{0, 3, -1},
+	} {
+		left, right := dv.Get(tc.left), dv.Get(tc.right)
+		require.Equal(t, tc.expected, CompareDatum(left, dv, right), "case %d", i)
+		require.Equal(t, tc.expected, left.(tree.Datum).Compare(evalCtx, right.(tree.Datum)), "case %d", i)
+	}
+}
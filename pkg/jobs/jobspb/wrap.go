@@ -47,6 +47,8 @@ var (
 	_ Details = ImportDetails{}
 	_ Details = StreamReplicationDetails{}
 	_ Details = RowLevelTTLDetails{}
+	_ Details = TableSplitPolicyDetails{}
+	_ Details = IndexAdvisorDetails{}
 )
 
 // ProgressDetails is a marker interface for job progress details proto structs.
@@ -65,6 +67,8 @@ var (
 	_ ProgressDetails = AutoSpanConfigReconciliationDetails{}
 	_ ProgressDetails = StreamReplicationProgress{}
 	_ ProgressDetails = RowLevelTTLProgress{}
+	_ ProgressDetails = TableSplitPolicyProgress{}
+	_ ProgressDetails = IndexAdvisorProgress{}
 )
 
 // Type returns the payload's job type.
@@ -129,6 +133,10 @@ func DetailsType(d isPayload_Details) Type {
 		return TypeStreamReplication
 	case *Payload_RowLevelTTL:
 		return TypeRowLevelTTL
+	case *Payload_TableSplitPolicy:
+		return TypeTableSplitPolicy
+	case *Payload_IndexAdvisor:
+		return TypeIndexAdvisor
 	default:
 		panic(errors.AssertionFailedf("Payload.Type called on a payload with an unknown details type: %T", d))
 	}
@@ -173,6 +181,10 @@ func WrapProgressDetails(details ProgressDetails) interface {
 		return &Progress_StreamReplication{StreamReplication: &d}
 	case RowLevelTTLProgress:
 		return &Progress_RowLevelTTL{RowLevelTTL: &d}
+	case TableSplitPolicyProgress:
+		return &Progress_TableSplitPolicy{TableSplitPolicy: &d}
+	case IndexAdvisorProgress:
+		return &Progress_IndexAdvisor{IndexAdvisor: &d}
 	default:
 		panic(errors.AssertionFailedf("WrapProgressDetails: unknown details type %T", d))
 	}
@@ -212,6 +224,10 @@ func (p *Payload) UnwrapDetails() Details {
 		return *d.StreamReplication
 	case *Payload_RowLevelTTL:
 		return *d.RowLevelTTL
+	case *Payload_TableSplitPolicy:
+		return *d.TableSplitPolicy
+	case *Payload_IndexAdvisor:
+		return *d.IndexAdvisor
 	default:
 		return nil
 	}
@@ -251,6 +267,10 @@ func (p *Progress) UnwrapDetails() ProgressDetails {
 		return *d.StreamReplication
 	case *Progress_RowLevelTTL:
 		return *d.RowLevelTTL
+	case *Progress_TableSplitPolicy:
+		return *d.TableSplitPolicy
+	case *Progress_IndexAdvisor:
+		return *d.IndexAdvisor
 	default:
 		return nil
 	}
@@ -303,6 +323,10 @@ func WrapPayloadDetails(details Details) interface {
 		return &Payload_StreamReplication{StreamReplication: &d}
 	case RowLevelTTLDetails:
 		return &Payload_RowLevelTTL{RowLevelTTL: &d}
+	case TableSplitPolicyDetails:
+		return &Payload_TableSplitPolicy{TableSplitPolicy: &d}
+	case IndexAdvisorDetails:
+		return &Payload_IndexAdvisor{IndexAdvisor: &d}
 	default:
 		panic(errors.AssertionFailedf("jobs.WrapPayloadDetails: unknown details type %T", d))
 	}
@@ -338,7 +362,7 @@ const (
 func (Type) SafeValue() {}
 
 // NumJobTypes is the number of jobs types.
-const NumJobTypes = 17
+const NumJobTypes = 19
 
 // MarshalJSONPB implements jsonpb.JSONPBMarshaller to  redact sensitive sink URI
 // parameters from ChangefeedDetails.
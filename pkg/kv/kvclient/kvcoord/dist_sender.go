@@ -2217,6 +2217,13 @@ func (ds *DistSender) sendToReplicas(
 					// immediately redirect to the leaseholder, without a backoff
 					// period.
 					intentionallySentToFollower := first && !leaseholderFirst
+					if !intentionallySentToFollower {
+						// Record the redirect so that it can be attributed to the
+						// operation (e.g. a SQL scan) that issued the request.
+						tracing.SpanFromContext(ctx).RecordStructured(
+							&roachpb.LeaseRedirectEvent{RangeID: routing.Desc().RangeID},
+						)
+					}
 					// See if we want to backoff a little before the next attempt. If
 					// the lease info we got is stale and we were intending to send to
 					// the leaseholder, we backoff because it might be the case that
@@ -1369,6 +1369,42 @@ func (tc *TxnCoordSender) ManualRefresh(ctx context.Context) error {
 	return pErr.GoError()
 }
 
+// StepReadTimestamp is part of the TxnSender interface.
+func (tc *TxnCoordSender) StepReadTimestamp(ctx context.Context) error {
+	if tc.typ != kv.RootTxn {
+		return errors.WithContextTags(errors.AssertionFailedf(
+			"cannot step read timestamp of leaf txn"), ctx)
+	}
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	switch tc.mu.txnState {
+	case txnRetryableError:
+		return tc.mu.storedRetryableErr
+	case txnError:
+		return tc.mu.storedErr.GoError()
+	}
+	if tc.mu.txnState != txnPending || tc.mu.txn.Status != roachpb.PENDING {
+		return errors.WithContextTags(errors.AssertionFailedf(
+			"cannot step read timestamp of non-pending txn %s", tc.mu.txn), ctx)
+	}
+	if tc.mu.txn.CommitTimestampFixed {
+		return errors.WithContextTags(errors.AssertionFailedf(
+			"cannot step read timestamp of txn %s with fixed commit timestamp", tc.mu.txn), ctx)
+	}
+
+	// The reads performed from now on observe the writes of all the
+	// transactions that committed before this point. The uncertainty interval
+	// of the transaction is moved along with its read timestamp, and the
+	// observed timestamps, which were taken before this point, are discarded so
+	// that they don't shorten it.
+	now := tc.clock.Now()
+	tc.mu.txn.Refresh(now)
+	tc.mu.txn.GlobalUncertaintyLimit.Forward(now.Add(tc.clock.MaxOffset().Nanoseconds(), 0))
+	tc.mu.txn.ResetObservedTimestamps()
+	tc.interceptorAlloc.txnSpanRefresher.stepReadTimestampLocked(tc.mu.txn.ReadTimestamp)
+	return nil
+}
+
 // DeferCommitWait is part of the TxnSender interface.
 func (tc *TxnCoordSender) DeferCommitWait(ctx context.Context) func(context.Context) error {
 	tc.mu.Lock()
@@ -2959,3 +2959,53 @@ func TestTxnCoordSenderSetFixedTimestamp(t *testing.T) {
 		})
 	}
 }
+
+// TestTxnCoordSenderStepReadTimestamp verifies that a transaction that steps
+// its read timestamp observes the writes that committed before the step, and
+// that the reads it performed before the step aren't refreshed when its
+// timestamp is later pushed.
+func TestTxnCoordSenderStepReadTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	s := createTestDB(t)
+	defer s.Stop()
+
+	txn := kv.NewTxn(ctx, s.DB, 0 /* gatewayNodeID */)
+	kv1, err := txn.Get(ctx, "a")
+	require.NoError(t, err)
+	require.False(t, kv1.Exists())
+
+	// A write that commits after the read isn't observed until the read
+	// timestamp is stepped. The clock is moved past the uncertainty interval of
+	// the transaction.
+	s.Manual.Increment(2 * s.Clock.MaxOffset().Nanoseconds())
+	require.NoError(t, s.DB.Put(ctx, "a", "1"))
+	kv1, err = txn.Get(ctx, "a")
+	require.NoError(t, err)
+	require.False(t, kv1.Exists())
+
+	readTS := txn.ReadTimestamp()
+	require.NoError(t, txn.StepReadTimestamp(ctx))
+	require.True(t, readTS.Less(txn.ReadTimestamp()))
+	kv1, err = txn.Get(ctx, "a")
+	require.NoError(t, err)
+	require.True(t, kv1.Exists())
+
+	// Push the transaction by writing to a key that was read at a higher
+	// timestamp. The transaction commits without a retry even though "a" was
+	// written after its first read, because that read isn't refreshed.
+	require.NoError(t, s.DB.Put(ctx, "a", "2"))
+	_, err = s.DB.Get(ctx, "b")
+	require.NoError(t, err)
+	require.NoError(t, txn.Put(ctx, "b", "1"))
+	require.True(t, txn.ReadTimestamp().Less(txn.ProvisionalCommitTimestamp()))
+	require.NoError(t, txn.Commit(ctx))
+
+	// The read timestamp of a transaction with a fixed commit timestamp can't
+	// be stepped.
+	txn = kv.NewTxn(ctx, s.DB, 0 /* gatewayNodeID */)
+	require.NoError(t, txn.SetFixedTimestamp(ctx, s.Clock.Now()))
+	require.Regexp(t, "cannot step read timestamp of txn .* with fixed commit timestamp",
+		txn.StepReadTimestamp(ctx))
+}
@@ -688,6 +688,16 @@ func (sr *txnSpanRefresher) epochBumpedLocked() {
 	sr.refreshedTimestamp.Reset()
 }
 
+// stepReadTimestampLocked is called when the read timestamp of the
+// transaction is moved forward to the given timestamp by StepReadTimestamp. The
+// reads performed so far don't need to be refreshed anymore, so the refresh
+// spans are discarded.
+func (sr *txnSpanRefresher) stepReadTimestampLocked(ts hlc.Timestamp) {
+	sr.refreshFootprint.clear()
+	sr.refreshInvalid = false
+	sr.refreshedTimestamp.Forward(ts)
+}
+
 // createSavepointLocked is part of the txnInterceptor interface.
 func (sr *txnSpanRefresher) createSavepointLocked(ctx context.Context, s *savepoint) {
 	s.refreshSpans = make([]roachpb.Span, len(sr.refreshFootprint.asSlice()))
@@ -54,6 +54,7 @@ func ReverseScan(
 		FailOnMoreRecent:       args.KeyLocking != lock.None,
 		Reverse:                true,
 		MemoryAccount:          cArgs.EvalCtx.GetResponseMemoryAccount(),
+		MinTimestamp:           args.MinTimestamp,
 	}
 
 	switch args.ScanFormat {
@@ -55,6 +55,7 @@ func Scan(
 		FailOnMoreRecent:       args.KeyLocking != lock.None,
 		Reverse:                false,
 		MemoryAccount:          cArgs.EvalCtx.GetResponseMemoryAccount(),
+		MinTimestamp:           args.MinTimestamp,
 	}
 
 	switch args.ScanFormat {
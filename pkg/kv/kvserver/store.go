@@ -1638,6 +1638,21 @@ func (s *Store) SetDraining(drain bool, reporter func(int, redact.SafeString), v
 	}
 }
 
+// NumLeasesToDrain returns the number of ranges whose lease is held by
+// the store and would be transferred away by SetDraining, i.e. the
+// ranges with more than one voting replica.
+func (s *Store) NumLeasesToDrain(ctx context.Context) int {
+	now := s.Clock().NowAsClockTimestamp()
+	var numLeases int
+	newStoreReplicaVisitor(s).Visit(func(r *Replica) bool {
+		if len(r.Desc().Replicas().VoterDescriptors()) > 1 && r.OwnsValidLease(ctx, now) {
+			numLeases++
+		}
+		return true
+	})
+	return numLeases
+}
+
 // IsStarted returns true if the Store has been started.
 func (s *Store) IsStarted() bool {
 	return atomic.LoadInt32(&s.started) == 1
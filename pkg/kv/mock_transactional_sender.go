@@ -215,6 +215,11 @@ func (m *MockTransactionalSender) ManualRefresh(ctx context.Context) error {
 	panic("unimplemented")
 }
 
+// StepReadTimestamp is part of the TxnSender interface.
+func (m *MockTransactionalSender) StepReadTimestamp(ctx context.Context) error {
+	panic("unimplemented")
+}
+
 // DeferCommitWait is part of the TxnSender interface.
 func (m *MockTransactionalSender) DeferCommitWait(ctx context.Context) func(context.Context) error {
 	panic("unimplemented")
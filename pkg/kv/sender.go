@@ -317,6 +317,17 @@ type TxnSender interface {
 	// before the merge transaction completed.
 	ManualRefresh(ctx context.Context) error
 
+	// StepReadTimestamp moves the read timestamp of the transaction forward to
+	// the current time, without refreshing the reads it has performed so far.
+	// Reads performed afterwards observe the writes of all the transactions
+	// that committed before the call, and only they need to be refreshed if the
+	// transaction's timestamp is later pushed. It is used to implement the READ
+	// COMMITTED isolation level, where each statement reads from a new snapshot.
+	//
+	// It returns an error if the transaction's commit timestamp is fixed or if
+	// the transaction is not pending.
+	StepReadTimestamp(ctx context.Context) error
+
 	// DeferCommitWait defers the transaction's commit-wait operation, passing
 	// responsibility of commit-waiting from the TxnSender to the caller of this
 	// method. The method returns a function which the caller must eventually
@@ -208,6 +208,20 @@ func NewTxnFromProto(
 // NewLeafTxn instantiates a new leaf transaction.
 func NewLeafTxn(
 	ctx context.Context, db *DB, gatewayNodeID roachpb.NodeID, tis *roachpb.LeafTxnInputState,
+) *Txn {
+	return NewLeafTxnWithAdmissionControl(ctx, db, gatewayNodeID, tis, roachpb.AdmissionHeader{})
+}
+
+// NewLeafTxnWithAdmissionControl instantiates a new leaf transaction whose
+// work is subject to admission control with the given admission header, which
+// is normally the admission header of the root transaction. See NewLeafTxn()
+// for details.
+func NewLeafTxnWithAdmissionControl(
+	ctx context.Context,
+	db *DB,
+	gatewayNodeID roachpb.NodeID,
+	tis *roachpb.LeafTxnInputState,
+	admissionHeader roachpb.AdmissionHeader,
 ) *Txn {
 	if db == nil {
 		panic(errors.WithContextTags(
@@ -218,7 +232,7 @@ func NewLeafTxn(
 			errors.AssertionFailedf("can't create leaf txn with non-PENDING proto: %s", tis.Txn), ctx))
 	}
 	tis.Txn.AssertInitialized(ctx)
-	txn := &Txn{db: db, typ: LeafTxn, gatewayNodeID: gatewayNodeID}
+	txn := &Txn{db: db, typ: LeafTxn, gatewayNodeID: gatewayNodeID, admissionHeader: admissionHeader}
 	txn.mu.ID = tis.Txn.ID
 	txn.mu.userPriority = roachpb.NormalUserPriority
 	txn.mu.sender = db.factory.LeafTransactionalSender(tis)
@@ -1652,6 +1666,31 @@ func (txn *Txn) ManualRefresh(ctx context.Context) error {
 	return sender.ManualRefresh(ctx)
 }
 
+// StepReadTimestamp moves the read timestamp of the transaction forward to the
+// current time, so that the reads performed afterwards observe the writes of
+// all the transactions that committed before the call. The reads performed so
+// far aren't refreshed when the transaction's timestamp is pushed anymore. This
+// is used by SQL transactions that run at the READ COMMITTED isolation level
+// before each statement.
+//
+// The deadline of the transaction, which was derived from state read at the
+// previous read timestamp, is discarded. The caller is responsible for setting
+// a new one.
+func (txn *Txn) StepReadTimestamp(ctx context.Context) error {
+	if txn.typ != RootTxn {
+		return errors.WithContextTags(
+			errors.AssertionFailedf("StepReadTimestamp() called on leaf txn"), ctx)
+	}
+
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	if err := txn.mu.sender.StepReadTimestamp(ctx); err != nil {
+		return err
+	}
+	txn.resetDeadlineLocked()
+	return nil
+}
+
 // DeferCommitWait defers the transaction's commit-wait operation, passing
 // responsibility of commit-waiting from the Txn to the caller of this
 // method. The method returns a function which the caller must eventually
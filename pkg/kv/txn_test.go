@@ -19,7 +19,9 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -445,6 +447,32 @@ func TestSetPriority(t *testing.T) {
 	}
 }
 
+// TestLeafTxnAdmissionHeader verifies that a leaf txn uses the admission
+// header it was created with.
+func TestLeafTxnAdmissionHeader(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+
+	clock := hlc.NewClockWithSystemTimeSource(time.Nanosecond /* maxOffset */)
+	db := NewDB(log.MakeTestingAmbientCtxWithNewTracer(), newTestTxnFactory(nil), clock, stopper)
+	root := NewTxnWithSteppingEnabled(ctx, db, 0 /* gatewayNodeID */, sessiondatapb.UserLow)
+	rootHeader := root.AdmissionHeader()
+	require.Equal(t, int32(admissionpb.UserLowPri), rootHeader.Priority)
+	require.Equal(t, roachpb.AdmissionHeader_FROM_SQL, rootHeader.Source)
+
+	tis := &roachpb.LeafTxnInputState{Txn: *root.TestingCloneTxn()}
+	leaf := NewLeafTxnWithAdmissionControl(ctx, db, 0 /* gatewayNodeID */, tis, rootHeader)
+	require.Equal(t, rootHeader, leaf.AdmissionHeader())
+
+	// Without an admission header, the work of a leaf txn is not subject to
+	// admission control.
+	leaf = NewLeafTxn(ctx, db, 0 /* gatewayNodeID */, tis)
+	require.Equal(t, roachpb.AdmissionHeader_OTHER, leaf.AdmissionHeader().Source)
+}
+
 // Tests that a retryable error for an inner txn doesn't cause the outer txn to
 // be retried.
 func TestWrongTxnRetry(t *testing.T) {
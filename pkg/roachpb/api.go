@@ -1785,6 +1785,16 @@ func (s *ScanStats) String() string {
 	return redact.StringWithoutMarkers(s)
 }
 
+// SafeFormat implements redact.SafeFormatter.
+func (e *LeaseRedirectEvent) SafeFormat(w redact.SafePrinter, _ rune) {
+	w.Printf("redirected to the leaseholder of r%d", e.RangeID)
+}
+
+// String implements fmt.Stringer.
+func (e *LeaseRedirectEvent) String() string {
+	return redact.StringWithoutMarkers(e)
+}
+
 // TenantSettingsPrecedence identifies the precedence of a set of setting
 // overrides. It is used by the TenantSettings API which supports passing
 // multiple overrides for the same setting.
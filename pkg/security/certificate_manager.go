@@ -15,8 +15,11 @@ import (
 	"crypto/tls"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/security/certnames"
+	"github.com/cockroachdb/cockroach/pkg/security/securityassets"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
@@ -242,17 +245,67 @@ func (cm *CertificateManager) RegisterSignalHandler(stopper *stop.Stopper) {
 				return
 			case sig := <-ch:
 				log.Ops.Infof(ctx, "received signal %q, triggering certificate reload", sig)
-				if err := cm.LoadCertificates(); err != nil {
-					log.Ops.Warningf(ctx, "could not reload certificates: %v", err)
-					log.StructuredEvent(ctx, &eventpb.CertsReload{Success: false, ErrorMessage: err.Error()})
-				} else {
-					log.StructuredEvent(ctx, &eventpb.CertsReload{Success: true})
+				cm.reloadCertificates(ctx)
+			}
+		}
+	}()
+}
+
+// WatchCertsDir checks the certificates directory for changes every
+// interval, triggering a refresh of the certificates when files are added,
+// removed or rewritten. This makes it possible to rotate the certificates
+// without sending a signal to the process.
+func (cm *CertificateManager) WatchCertsDir(stopper *stop.Stopper, interval time.Duration) {
+	ctx := context.Background()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		state := certsDirState(cm.CertsDir())
+		for {
+			select {
+			case <-stopper.ShouldQuiesce():
+				return
+			case <-ticker.C:
+				newState := certsDirState(cm.CertsDir())
+				if newState == state {
+					continue
 				}
+				// The state is updated even if the reload fails: a partially
+				// written directory is picked up again when it next changes.
+				state = newState
+				log.Ops.Infof(ctx, "certificates directory %s changed, triggering certificate reload", cm.CertsDir())
+				cm.reloadCertificates(ctx)
 			}
 		}
 	}()
 }
 
+// certsDirState returns a description of the names, sizes and
+// modification times of the files in the certificates directory.
+func certsDirState(certsDir string) string {
+	fileInfos, err := securityassets.GetLoader().ReadDir(certsDir)
+	if err != nil {
+		// Reported by the reload, if any.
+		return err.Error()
+	}
+	var buf strings.Builder
+	for _, info := range fileInfos {
+		fmt.Fprintf(&buf, "%s %d %d\n", info.Name(), info.Size(), info.ModTime().UnixNano())
+	}
+	return buf.String()
+}
+
+// reloadCertificates reloads the certificates and reports the outcome in
+// a CertsReload event.
+func (cm *CertificateManager) reloadCertificates(ctx context.Context) {
+	if err := cm.LoadCertificates(); err != nil {
+		log.Ops.Warningf(ctx, "could not reload certificates: %v", err)
+		log.StructuredEvent(ctx, &eventpb.CertsReload{Success: false, ErrorMessage: err.Error()})
+	} else {
+		log.StructuredEvent(ctx, &eventpb.CertsReload{Success: true})
+	}
+}
+
 // CACert returns the CA cert. May be nil.
 // Callers should check for an internal Error field.
 func (cm *CertificateManager) CACert() *CertInfo {
@@ -11,6 +11,8 @@
 package security_test
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -21,8 +23,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/security/securityassets"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -143,3 +148,31 @@ func TestManagerWithPrincipalMap(t *testing.T) {
 	setCertPrincipalMap("testuser:foo,node.crdb.io:node")
 	require.NoError(t, loadUserCert(username.MakeSQLUsernameFromPreNormalizedString("foo")))
 }
+
+func TestWatchCertsDir(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// Do not mock cert access for this test.
+	securityassets.ResetLoader()
+	defer ResetTest()
+
+	certsDir := t.TempDir()
+	require.NoError(t, generateBaseCerts(certsDir))
+	cm, err := security.NewCertificateManager(certsDir, security.CommandTLSSettings{})
+	require.NoError(t, err)
+
+	stopper := stop.NewStopper()
+	defer stopper.Stop(context.Background())
+	cm.WatchCertsDir(stopper, 10*time.Millisecond)
+
+	// Regenerate the certificates. They are reloaded without a signal.
+	oldCACert := cm.CACert().FileContents
+	require.NoError(t, os.RemoveAll(certsDir))
+	require.NoError(t, generateBaseCerts(certsDir))
+	testutils.SucceedsSoon(t, func() error {
+		if bytes.Equal(cm.CACert().FileContents, oldCACert) {
+			return errors.New("certificates not reloaded yet")
+		}
+		return nil
+	})
+}
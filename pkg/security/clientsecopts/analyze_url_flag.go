@@ -12,6 +12,7 @@ package clientsecopts
 
 import (
 	"fmt"
+	"net/url"
 	"path/filepath"
 
 	"github.com/cockroachdb/cockroach/pkg/security/certnames"
@@ -57,6 +58,11 @@ type CLIFlagInterfaceForClientURL interface {
 	// SetPort is called when the URL contains a server port name / number.
 	SetPort(string)
 
+	// SetAlternateServerAddrs is called with the addresses following
+	// the first one when the host part of the URL is a comma-separated
+	// list of addresses, or with an empty list otherwise.
+	SetAlternateServerAddrs([]string)
+
 	// SetDatabase is called when the URL contains a database name.
 	SetDatabase(string)
 
@@ -90,10 +96,24 @@ type CLIFlagInterfaceForClientURL interface {
 // This is set for all non-SQL client commands, which only support
 // the insecure boolean and certs-dir with maximum SSL validation.
 func AnalyzeClientURL(newURL string, flags CLIFlagInterfaceForClientURL) (*pgurl.URL, error) {
-	parsedURL, err := pgurl.Parse(newURL)
+	// If the URL contains multiple hosts, the first one is analyzed and
+	// the others are forwarded as alternate addresses. Their default port
+	// is resolved later, see ResolveAlternateServerAddrs.
+	urls := pgurl.ExpandMultiHostURL(newURL, "")
+	var alternateAddrs []string
+	for _, u := range urls[1:] {
+		alternateURL, err := url.Parse(u)
+		if err != nil {
+			return nil, err
+		}
+		alternateAddrs = append(alternateAddrs, alternateURL.Host)
+	}
+
+	parsedURL, err := pgurl.Parse(urls[0])
 	if err != nil {
 		return nil, err
 	}
+	flags.SetAlternateServerAddrs(alternateAddrs)
 
 	if user := parsedURL.GetUsername(); user != "" {
 		flags.SetUser(user)
@@ -11,6 +11,7 @@
 package clientsecopts
 
 import (
+	"net"
 	"net/url"
 
 	"github.com/cockroachdb/cockroach/pkg/security/certnames"
@@ -189,6 +190,27 @@ type ClientOptions struct {
 	// function should be passed an update callback that updates this
 	// field if the URL contains a port number/name.
 	ServerPort string
+
+	// AlternateServerAddrs are the addresses of other servers of the
+	// cluster, as specified via a comma-separated list in --host or
+	// --url. The addresses may omit the port number, in which case
+	// ServerPort applies. SQL clients fall back to these servers when
+	// the one at ServerHost cannot be reached.
+	AlternateServerAddrs []string
+}
+
+// ResolveAlternateServerAddrs returns the host:port addresses of
+// copts.AlternateServerAddrs.
+func ResolveAlternateServerAddrs(copts ClientOptions) ([]string, error) {
+	var addrs []string
+	for _, a := range copts.AlternateServerAddrs {
+		host, port, err := addr.SplitHostPort(a, copts.ServerPort)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, net.JoinHostPort(host, port))
+	}
+	return addrs, nil
 }
 
 // MakeClientConnURL constructs a connection URL from the given input options.
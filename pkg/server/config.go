@@ -644,6 +644,13 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 					return nil, err
 				}
 			}
+			// If the spec specifies a compression algorithm, use it for all
+			// levels. This takes precedence over the Pebble options above.
+			if spec.Compression != pebble.DefaultCompression {
+				for l := range pebbleConfig.Opts.Levels {
+					pebbleConfig.Opts.Levels[l].Compression = spec.Compression
+				}
+			}
 			if len(spec.RocksDBOptions) > 0 {
 				return nil, errors.Errorf("store %d: using Pebble storage engine but StoreSpec provides RocksDB options", i)
 			}
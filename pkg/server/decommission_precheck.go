@@ -0,0 +1,282 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/allocator"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/constraint"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness/livenesspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/spanconfig"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// The names of the checks run by the decommission pre-check, as
+// reported in DecommissionPreCheckResponse_Blocker.Check.
+const (
+	decommissionCheckReplicationFactor = "replication-factor"
+	decommissionCheckConstraints       = "constraints"
+	decommissionCheckDiskSpace         = "disk-space"
+)
+
+// decommissionPreChecker validates that the replicas of the nodes to
+// decommission can be moved to the remaining nodes. The ranges with
+// replicas on the nodes to decommission are fed to addRange, and
+// blockers reports the issues found, the ranges affected by the same
+// issue being aggregated.
+type decommissionPreChecker struct {
+	// targetStores are the stores of the nodes to decommission.
+	targetStores []roachpb.StoreDescriptor
+	// remainingStores are the stores of the live nodes that are not
+	// being decommissioned.
+	remainingStores []roachpb.StoreDescriptor
+	// remainingNodes is the number of nodes of remainingStores.
+	remainingNodes int
+
+	issues map[decommissionIssueKey]*decommissionIssue
+}
+
+type decommissionIssueKey struct {
+	check string
+	// needed is the number of replicas that the ranges need.
+	needed int
+	// constraint is the conjunction that the replicas must match, for
+	// the constraints check.
+	constraint string
+}
+
+type decommissionIssue struct {
+	// available is the number of remaining nodes that can host the
+	// replicas.
+	available    int
+	numRanges    int
+	exampleRange roachpb.RangeID
+}
+
+func newDecommissionPreChecker(
+	targetStores, remainingStores []roachpb.StoreDescriptor,
+) *decommissionPreChecker {
+	nodes := make(map[roachpb.NodeID]struct{})
+	for _, s := range remainingStores {
+		nodes[s.Node.NodeID] = struct{}{}
+	}
+	return &decommissionPreChecker{
+		targetStores:    targetStores,
+		remainingStores: remainingStores,
+		remainingNodes:  len(nodes),
+		issues:          make(map[decommissionIssueKey]*decommissionIssue),
+	}
+}
+
+// addRange checks that the replicas of the given range, which has
+// replicas on the nodes to decommission, can be hosted by the
+// remaining nodes.
+func (c *decommissionPreChecker) addRange(desc *roachpb.RangeDescriptor, conf roachpb.SpanConfig) {
+	numReplicas := int(conf.NumReplicas)
+	if numReplicas == 0 {
+		numReplicas = len(desc.Replicas().Descriptors())
+	}
+	if numReplicas > c.remainingNodes {
+		// Replicas of a range must be on distinct nodes. When there are not
+		// enough nodes, the constraints can't be satisfied either, so there
+		// is no point in reporting them too.
+		c.addIssue(decommissionIssueKey{check: decommissionCheckReplicationFactor, needed: numReplicas},
+			c.remainingNodes, desc.RangeID)
+		return
+	}
+	c.checkConstraints(desc.RangeID, conf.Constraints, numReplicas)
+	c.checkConstraints(desc.RangeID, conf.VoterConstraints, int(conf.GetNumVoters()))
+}
+
+// checkConstraints checks that there are enough remaining nodes
+// matching each of the given conjunctions. numReplicas is the number of
+// replicas a conjunction applies to when it does not specify one.
+func (c *decommissionPreChecker) checkConstraints(
+	rangeID roachpb.RangeID, conjunctions []roachpb.ConstraintsConjunction, numReplicas int,
+) {
+	for _, conj := range conjunctions {
+		needed := int(conj.NumReplicas)
+		if needed == 0 {
+			needed = numReplicas
+		}
+		matching := make(map[roachpb.NodeID]struct{})
+		for _, s := range c.remainingStores {
+			if constraint.ConjunctionsCheck(s, conj.Constraints) {
+				matching[s.Node.NodeID] = struct{}{}
+			}
+		}
+		if len(matching) < needed {
+			c.addIssue(decommissionIssueKey{
+				check: decommissionCheckConstraints, needed: needed, constraint: conj.String(),
+			}, len(matching), rangeID)
+		}
+	}
+}
+
+func (c *decommissionPreChecker) addIssue(
+	key decommissionIssueKey, available int, rangeID roachpb.RangeID,
+) {
+	issue, ok := c.issues[key]
+	if !ok {
+		issue = &decommissionIssue{available: available, exampleRange: rangeID}
+		c.issues[key] = issue
+	}
+	issue.numRanges++
+}
+
+// blockers returns the issues found by the checks, sorted by check and
+// message.
+func (c *decommissionPreChecker) blockers() []serverpb.DecommissionPreCheckResponse_Blocker {
+	var blockers []serverpb.DecommissionPreCheckResponse_Blocker
+	for key, issue := range c.issues {
+		var msg string
+		switch key.check {
+		case decommissionCheckReplicationFactor:
+			msg = fmt.Sprintf("%d range(s) (e.g. r%d) need %d replicas, but only %d node(s) would remain",
+				issue.numRanges, issue.exampleRange, key.needed, issue.available)
+		case decommissionCheckConstraints:
+			msg = fmt.Sprintf("%d range(s) (e.g. r%d) need %d replicas matching constraints [%s], "+
+				"but only %d of the remaining nodes match",
+				issue.numRanges, issue.exampleRange, key.needed, key.constraint, issue.available)
+		}
+		blockers = append(blockers, serverpb.DecommissionPreCheckResponse_Blocker{Check: key.check, Message: msg})
+	}
+
+	// The data of the nodes to decommission must fit on the remaining
+	// stores without pushing them past the threshold above which the
+	// allocator stops moving replicas to a store.
+	var toMove, headroom int64
+	for _, s := range c.targetStores {
+		toMove += s.Capacity.Used
+	}
+	for _, s := range c.remainingStores {
+		reserved := int64((1 - allocator.MaxFractionUsedThreshold) * float64(s.Capacity.Capacity))
+		if avail := s.Capacity.Available - reserved; avail > 0 {
+			headroom += avail
+		}
+	}
+	if toMove > headroom {
+		blockers = append(blockers, serverpb.DecommissionPreCheckResponse_Blocker{
+			Check: decommissionCheckDiskSpace,
+			Message: fmt.Sprintf("the nodes to decommission hold %s of data, but the remaining nodes "+
+				"only have %s available below the %.0f%% disk usage threshold",
+				humanizeutil.IBytes(toMove), humanizeutil.IBytes(headroom),
+				allocator.MaxFractionUsedThreshold*100),
+		})
+	}
+
+	sort.Slice(blockers, func(i, j int) bool {
+		if blockers[i].Check != blockers[j].Check {
+			return blockers[i].Check < blockers[j].Check
+		}
+		return blockers[i].Message < blockers[j].Message
+	})
+	return blockers
+}
+
+// DecommissionPreCheck runs the validations of the decommission
+// pre-check for the given nodes, without decommissioning them.
+func (s *adminServer) DecommissionPreCheck(
+	ctx context.Context, req *serverpb.DecommissionPreCheckRequest,
+) (*serverpb.DecommissionPreCheckResponse, error) {
+	if len(req.NodeIDs) == 0 {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "no node ID specified")
+	}
+	r, err := s.decommissionPreCheckHelper(ctx, req.NodeIDs)
+	if err != nil {
+		return nil, serverError(ctx, err)
+	}
+	return r, nil
+}
+
+// Note that the function returns plain errors, and it is the caller's
+// responsibility to convert them to serverErrors.
+func (s *adminServer) decommissionPreCheckHelper(
+	ctx context.Context, nodeIDs []roachpb.NodeID,
+) (*serverpb.DecommissionPreCheckResponse, error) {
+	targets := make(map[roachpb.NodeID]bool, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		targets[nodeID] = true
+	}
+
+	ns, err := s.server.status.ListNodesInternal(ctx, &serverpb.NodesRequest{})
+	if err != nil {
+		return nil, errors.Wrap(err, "loading node statuses")
+	}
+	var targetStores, remainingStores []roachpb.StoreDescriptor
+	for _, n := range ns.Nodes {
+		for _, ss := range n.StoreStatuses {
+			if targets[n.Desc.NodeID] {
+				targetStores = append(targetStores, ss.Desc)
+			} else if ns.LivenessByNodeID[n.Desc.NodeID] == livenesspb.NodeLivenessStatus_LIVE {
+				remainingStores = append(remainingStores, ss.Desc)
+			}
+		}
+	}
+
+	// All the stores share the same span configs; use the first one.
+	var confReader spanconfig.StoreReader
+	if err := s.server.node.stores.VisitStores(func(store *kvserver.Store) error {
+		if confReader != nil {
+			return nil
+		}
+		var err error
+		confReader, err = store.GetConfReader(ctx)
+		return err
+	}); err != nil {
+		return nil, errors.Wrap(err, "loading span configs")
+	}
+	if confReader == nil {
+		return nil, errors.New("no local store to load the span configs from")
+	}
+
+	var checker *decommissionPreChecker
+	if err := s.server.db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		const pageSize = 10000
+		checker = newDecommissionPreChecker(targetStores, remainingStores)
+		return txn.Iterate(ctx, keys.Meta2Prefix, keys.MetaMax, pageSize,
+			func(rows []kv.KeyValue) error {
+				for _, row := range rows {
+					var desc roachpb.RangeDescriptor
+					if err := row.ValueProto(&desc); err != nil {
+						return errors.Wrapf(err, "%s: unable to unmarshal range descriptor", row.Key)
+					}
+					onTarget := false
+					for _, r := range desc.Replicas().Descriptors() {
+						onTarget = onTarget || targets[r.NodeID]
+					}
+					if !onTarget {
+						continue
+					}
+					conf, err := confReader.GetSpanConfigForKey(ctx, desc.StartKey)
+					if err != nil {
+						return err
+					}
+					checker.addRange(&desc, conf)
+				}
+				return nil
+			})
+	}); err != nil {
+		return nil, err
+	}
+	return &serverpb.DecommissionPreCheckResponse{Blockers: checker.blockers()}, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecommissionPreChecker(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const gb = 1 << 30
+	store := func(nodeID int, region string, used, available int64) roachpb.StoreDescriptor {
+		return roachpb.StoreDescriptor{
+			StoreID: roachpb.StoreID(nodeID),
+			Node: roachpb.NodeDescriptor{
+				NodeID: roachpb.NodeID(nodeID),
+				Locality: roachpb.Locality{Tiers: []roachpb.Tier{
+					{Key: "region", Value: region},
+				}},
+			},
+			Capacity: roachpb.StoreCapacity{
+				Capacity:  100 * gb,
+				Available: available,
+				Used:      used,
+			},
+		}
+	}
+	rangeDesc := func(rangeID int, nodeIDs ...int) *roachpb.RangeDescriptor {
+		desc := &roachpb.RangeDescriptor{RangeID: roachpb.RangeID(rangeID)}
+		for _, n := range nodeIDs {
+			desc.InternalReplicas = append(desc.InternalReplicas, roachpb.ReplicaDescriptor{
+				NodeID: roachpb.NodeID(n), StoreID: roachpb.StoreID(n),
+			})
+		}
+		return desc
+	}
+	east := []roachpb.Constraint{{Type: roachpb.Constraint_REQUIRED, Key: "region", Value: "east"}}
+
+	// Node 4 is decommissioned, nodes 1-3 remain.
+	target := []roachpb.StoreDescriptor{store(4, "east", 10*gb, 90*gb)}
+	remaining := []roachpb.StoreDescriptor{
+		store(1, "east", 10*gb, 90*gb),
+		store(2, "west", 10*gb, 90*gb),
+		store(3, "west", 10*gb, 90*gb),
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		c := newDecommissionPreChecker(target, remaining)
+		c.addRange(rangeDesc(1, 1, 2, 4), roachpb.SpanConfig{NumReplicas: 3})
+		c.addRange(rangeDesc(2, 1, 2, 4), roachpb.SpanConfig{
+			NumReplicas: 3,
+			Constraints: []roachpb.ConstraintsConjunction{{NumReplicas: 1, Constraints: east}},
+		})
+		require.Empty(t, c.blockers())
+	})
+
+	t.Run("blockers", func(t *testing.T) {
+		c := newDecommissionPreChecker(target, remaining)
+		for _, rangeID := range []int{1, 2} {
+			c.addRange(rangeDesc(rangeID, 1, 2, 3, 4), roachpb.SpanConfig{NumReplicas: 5})
+		}
+		c.addRange(rangeDesc(3, 1, 2, 4), roachpb.SpanConfig{
+			NumReplicas:      3,
+			NumVoters:        3,
+			VoterConstraints: []roachpb.ConstraintsConjunction{{NumReplicas: 2, Constraints: east}},
+		})
+		require.Equal(t, []serverpb.DecommissionPreCheckResponse_Blocker{
+			{
+				Check:   decommissionCheckConstraints,
+				Message: "1 range(s) (e.g. r3) need 2 replicas matching constraints [+region=east:2], but only 1 of the remaining nodes match",
+			},
+			{
+				Check:   decommissionCheckReplicationFactor,
+				Message: "2 range(s) (e.g. r1) need 5 replicas, but only 3 node(s) would remain",
+			},
+		}, c.blockers())
+	})
+
+	t.Run("disk space", func(t *testing.T) {
+		full := []roachpb.StoreDescriptor{
+			store(1, "east", 92*gb, 8*gb),
+			store(2, "west", 92*gb, 8*gb),
+			store(3, "west", 92*gb, 8*gb),
+		}
+		c := newDecommissionPreChecker(target, full)
+		require.Equal(t, []serverpb.DecommissionPreCheckResponse_Blocker{{
+			Check: decommissionCheckDiskSpace,
+			Message: "the nodes to decommission hold 10 GiB of data, but the remaining nodes " +
+				"only have 9.0 GiB available below the 95% disk usage threshold",
+		}}, c.blockers())
+	})
+}
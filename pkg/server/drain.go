@@ -14,6 +14,7 @@ import (
 	"context"
 	"io"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness"
@@ -65,6 +66,10 @@ var (
 	).WithPublic()
 )
 
+// drainProgressInterval is the period at which the DrainProgress RPC
+// reports the progress of a drain.
+const drainProgressInterval = time.Second
+
 // Drain puts the node into the specified drain mode(s) and optionally
 // instructs the process to terminate.
 // This method is part of the serverpb.AdminClient interface.
@@ -95,6 +100,30 @@ func (s *adminServer) Drain(req *serverpb.DrainRequest, stream serverpb.Admin_Dr
 	return s.server.drain.handleDrain(ctx, req, stream)
 }
 
+// DrainProgress streams the progress of the drain of the node.
+// This method is part of the serverpb.AdminClient interface.
+func (s *adminServer) DrainProgress(
+	req *serverpb.DrainProgressRequest, stream serverpb.Admin_DrainProgressServer,
+) error {
+	ctx := stream.Context()
+	ctx = s.server.AnnotateCtx(ctx)
+
+	nodeID, local, err := s.server.status.parseNodeID(req.NodeId)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if !local {
+		// This request is for another node. Forward it.
+		client, err := s.dialNode(ctx, nodeID)
+		if err != nil {
+			return serverError(ctx, err)
+		}
+		return delegateDrainProgress(ctx, req, client, stream)
+	}
+
+	return s.server.drain.handleDrainProgress(ctx, stream)
+}
+
 type drainServer struct {
 	stopper      *stop.Stopper
 	grpc         *grpcServer
@@ -104,6 +133,11 @@ type drainServer struct {
 	kvServer struct {
 		nodeLiveness *liveness.NodeLiveness
 		node         *Node
+		// leaseTransfersAtDrainStart is the number of lease transfers
+		// performed by the node's stores when the node started draining,
+		// used to report the number of leases transferred by the drain.
+		// Accessed atomically.
+		leaseTransfersAtDrainStart int64
 	}
 }
 
@@ -245,6 +279,85 @@ func delegateDrain(
 	return nil
 }
 
+// delegateDrainProgress forwards a drain progress request to another
+// node. 'client' is where the request should be forwarded to. 'stream'
+// is where the request came from, and where the responses should go.
+func delegateDrainProgress(
+	ctx context.Context,
+	req *serverpb.DrainProgressRequest,
+	client serverpb.AdminClient,
+	stream serverpb.Admin_DrainProgressServer,
+) error {
+	progressClient, err := client.DrainProgress(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := progressClient.Recv()
+		if err != nil {
+			if err == io.EOF || grpcutil.IsClosedConnection(err) {
+				// The target node may shut down at the end of its drain.
+				return nil
+			}
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// handleDrainProgress sends a snapshot of the progress of the drain to
+// the stream every drainProgressInterval, until the client cancels the
+// request or the server shuts down.
+func (s *drainServer) handleDrainProgress(
+	ctx context.Context, stream serverpb.Admin_DrainProgressServer,
+) error {
+	ticker := time.NewTicker(drainProgressInterval)
+	defer ticker.Stop()
+	for {
+		resp, err := s.drainProgress(ctx)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&resp); err != nil {
+			return err
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		case <-s.stopper.ShouldQuiesce():
+			return nil
+		}
+	}
+}
+
+// drainProgress returns a snapshot of the progress of the drain.
+func (s *drainServer) drainProgress(
+	ctx context.Context,
+) (serverpb.DrainProgressResponse, error) {
+	resp := serverpb.DrainProgressResponse{
+		IsDraining:              s.isDraining(),
+		SQLConnectionsRemaining: int64(s.sqlServer.pgServer.GetConnCancelMapLen()),
+	}
+	if node := s.kvServer.node; node != nil {
+		var err error
+		if resp.RangesRemaining, err = node.numLeasesToDrain(ctx); err != nil {
+			return resp, err
+		}
+		if node.IsDraining() {
+			var numTransfers int64
+			if numTransfers, err = node.numLeaseTransfers(); err != nil {
+				return resp, err
+			}
+			resp.LeasesTransferred = numTransfers -
+				atomic.LoadInt64(&s.kvServer.leaseTransfersAtDrainStart)
+		}
+	}
+	return resp, nil
+}
+
 // runDrain idempotently activates the draining mode.
 // Note: this represents ONE round of draining. This code is iterated on
 // indefinitely until all range leases have been drained.
@@ -380,6 +493,14 @@ func (s *drainServer) drainNode(
 		// No KV subsystem. Nothing to do.
 		return nil
 	}
+	if !s.kvServer.node.IsDraining() {
+		// Remember where the lease transfers started, for DrainProgress.
+		var numTransfers int64
+		if numTransfers, err = s.kvServer.node.numLeaseTransfers(); err != nil {
+			return err
+		}
+		atomic.StoreInt64(&s.kvServer.leaseTransfersAtDrainStart, numTransfers)
+	}
 	// Set the node's liveness status to "draining".
 	if err = s.kvServer.nodeLiveness.SetDraining(ctx, true /* drain */, reporter); err != nil {
 		return err
@@ -156,6 +156,44 @@ INSERT INTO t.test VALUES (3);
 	)
 }
 
+func TestDrainProgress(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	var drainSleepCallCount = 0
+	drainCtx := newTestDrainContext(t, &drainSleepCallCount)
+	defer drainCtx.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	progress := func() *serverpb.DrainProgressResponse {
+		stream, err := drainCtx.c.DrainProgress(ctx, &serverpb.DrainProgressRequest{})
+		require.NoError(t, err)
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := progress()
+	require.False(t, resp.IsDraining)
+	require.Zero(t, resp.LeasesTransferred)
+
+	drainCtx.sendDrainNoShutdown()
+
+	resp = progress()
+	require.True(t, resp.IsDraining)
+	require.Zero(t, resp.RangesRemaining)
+	require.Zero(t, resp.SQLConnectionsRemaining)
+
+	// The progress is streamed until the client cancels the request.
+	stream, err := drainCtx.c.DrainProgress(ctx, &serverpb.DrainProgressRequest{})
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err := stream.Recv()
+		require.NoError(t, err)
+	}
+}
+
 type testDrainContext struct {
 	*testing.T
 	tc         *testcluster.TestCluster
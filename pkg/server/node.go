@@ -572,6 +572,28 @@ func (n *Node) IsDraining() bool {
 	return isDraining
 }
 
+// numLeasesToDrain returns the number of range leases held by the node's
+// stores that remain to be transferred away by a drain.
+func (n *Node) numLeasesToDrain(ctx context.Context) (int64, error) {
+	var numLeases int64
+	err := n.stores.VisitStores(func(s *kvserver.Store) error {
+		numLeases += int64(s.NumLeasesToDrain(ctx))
+		return nil
+	})
+	return numLeases, err
+}
+
+// numLeaseTransfers returns the number of successful lease transfers
+// performed by the node's stores since the node started.
+func (n *Node) numLeaseTransfers() (int64, error) {
+	var numTransfers int64
+	err := n.stores.VisitStores(func(s *kvserver.Store) error {
+		numTransfers += s.Metrics().LeaseTransferSuccessCount.Count()
+		return nil
+	})
+	return numTransfers, err
+}
+
 // SetDraining sets the draining mode on all of the node's underlying stores.
 // The reporter callback, if non-nil, is called on a best effort basis
 // to report work that needed to be done and which may or may not have
@@ -11,6 +11,7 @@
 package pgurl
 
 import (
+	"net"
 	"net/url"
 	"strings"
 
@@ -66,6 +67,119 @@ func Parse(s string) (*URL, error) {
 	return dst, err
 }
 
+// ParseDSN parses a connection string in the key/value format accepted
+// by libpq (for example "host=localhost port=26257 dbname=defaultdb"),
+// including the format produced by ToDSN, and extracts its properties.
+// Like with Parse, use the Validate() method to verify the result.
+func ParseDSN(s string) (*URL, error) {
+	q := make(url.Values)
+	for i := 0; ; {
+		// Skip the whitespace before the key.
+		for i < len(s) && isDSNSpace(s[i]) {
+			i++
+		}
+		if i == len(s) {
+			break
+		}
+		start := i
+		for i < len(s) && s[i] != '=' && !isDSNSpace(s[i]) {
+			i++
+		}
+		key := s[start:i]
+		if key == "" {
+			return nil, errors.Newf("missing key before \"=\" in connection string")
+		}
+		for i < len(s) && isDSNSpace(s[i]) {
+			i++
+		}
+		if i == len(s) || s[i] != '=' {
+			return nil, errors.Newf("missing \"=\" after %q in connection string", key)
+		}
+		i++
+		for i < len(s) && isDSNSpace(s[i]) {
+			i++
+		}
+
+		// Read the value, which is either quoted with single quotes or
+		// ends at the next unescaped whitespace.
+		var val strings.Builder
+		quoted := i < len(s) && s[i] == '\''
+		if quoted {
+			i++
+		}
+		for ; i < len(s); i++ {
+			c := s[i]
+			if c == '\\' && i+1 < len(s) {
+				i++
+				val.WriteByte(s[i])
+				continue
+			}
+			if (quoted && c == '\'') || (!quoted && isDSNSpace(c)) {
+				break
+			}
+			val.WriteByte(c)
+		}
+		if quoted {
+			if i == len(s) {
+				return nil, errors.Newf("unterminated quoted value for %q in connection string", key)
+			}
+			// Skip the closing quote.
+			i++
+		}
+
+		// libpq calls the database "dbname".
+		if key == "dbname" {
+			key = "database"
+		}
+		q.Add(key, val.String())
+	}
+
+	dst := New()
+	err := dst.parseOptions(q)
+	return dst, err
+}
+
+func isDSNSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// ExpandMultiHostURL splits a connection string whose host part is a
+// comma-separated list of addresses, as accepted by libpq (for example
+// postgres://user@host1:26257,host2:26257/db), into one connection
+// string per address. Other connection strings are returned as-is.
+//
+// If defaultPort is not empty, it is added to the expanded addresses
+// that do not specify a port. Otherwise, they are left without a port,
+// which the Postgres drivers interpret as port 5432.
+func ExpandMultiHostURL(s string, defaultPort string) []string {
+	start := strings.Index(s, "://")
+	if start == -1 {
+		return []string{s}
+	}
+	start += len("://")
+	end := len(s)
+	if i := strings.IndexAny(s[start:], "/?#"); i != -1 {
+		end = start + i
+	}
+	if i := strings.LastIndexByte(s[start:end], '@'); i != -1 {
+		start += i + 1
+	}
+	hosts := strings.Split(s[start:end], ",")
+	if len(hosts) == 1 {
+		return []string{s}
+	}
+	urls := make([]string, len(hosts))
+	for i, host := range hosts {
+		if defaultPort != "" && host != "" {
+			if h, p, err := addr.SplitHostPort(host, defaultPort); err == nil {
+				host = net.JoinHostPort(h, p)
+			}
+		}
+		urls[i] = s[:start] + host + s[end:]
+	}
+	return urls
+}
+
 func (u *URL) parseOptions(extra url.Values) error {
 	q := u.extraOptions
 	if q == nil {
@@ -39,6 +39,14 @@ func TestURL(t *testing.T) {
 				return result.String()
 			}
 
+		case "dsn":
+			var err error
+			u, err = ParseDSN(td.Input)
+			if err != nil {
+				fmt.Fprintf(&result, "parse error: %v", err)
+				return result.String()
+			}
+
 		default:
 			t.Fatalf("unrecognized command: %s", td.Cmd)
 		}
@@ -139,6 +147,22 @@ func TestOptions(t *testing.T) {
 	require.Equal(t, u.extraOptions["application_name"], []string{"baz"})
 }
 
+func TestExpandMultiHostURL(t *testing.T) {
+	require.Equal(t,
+		[]string{"postgres://root@a:26257/db?sslmode=disable"},
+		ExpandMultiHostURL("postgres://root@a:26257/db?sslmode=disable", ""))
+	require.Equal(t,
+		[]string{"postgres://u:p%40w@a:1/db?x=a,b", "postgres://u:p%40w@b/db?x=a,b", "postgres://u:p%40w@[::1]:3/db?x=a,b"},
+		ExpandMultiHostURL("postgres://u:p%40w@a:1,b,[::1]:3/db?x=a,b", ""))
+	require.Equal(t,
+		[]string{"postgresql://a?sslmode=disable", "postgresql://b?sslmode=disable"},
+		ExpandMultiHostURL("postgresql://a,b?sslmode=disable", ""))
+	require.Equal(t,
+		[]string{"postgres://root@a:1/db", "postgres://root@b:26257/db", "postgres://root@[::1]:26257/db"},
+		ExpandMultiHostURL("postgres://root@a:1,b,[::1]/db", "26257"))
+	require.Equal(t, []string{"a,b"}, ExpandMultiHostURL("a,b", "26257"))
+}
+
 // Silence the unused linter
 var _ = ProtoUndefined
 var _ = TLSVerifyCA
@@ -67,8 +67,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire"
 	_ "github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scjob" // register jobs declared outside of pkg/sql
-	_ "github.com/cockroachdb/cockroach/pkg/sql/ttl/ttljob"          // register jobs declared outside of pkg/sql
-	_ "github.com/cockroachdb/cockroach/pkg/sql/ttl/ttlschedule"     // register schedules declared outside of pkg/sql
+	_ "github.com/cockroachdb/cockroach/pkg/sql/splitpolicy"         // register jobs declared outside of pkg/sql
+	"github.com/cockroachdb/cockroach/pkg/sql/systemversioning"
+	_ "github.com/cockroachdb/cockroach/pkg/sql/ttl/ttljob"      // register jobs declared outside of pkg/sql
+	_ "github.com/cockroachdb/cockroach/pkg/sql/ttl/ttlschedule" // register schedules declared outside of pkg/sql
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/ts"
 	"github.com/cockroachdb/cockroach/pkg/util"
@@ -267,6 +269,9 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 			return nil, err
 		}
 		cm.RegisterSignalHandler(stopper)
+		if cfg.CertRotationInterval > 0 {
+			cm.WatchCertsDir(stopper, cfg.CertRotationInterval)
+		}
 		registry.AddMetricStruct(cm.Metrics())
 	}
 
@@ -469,6 +474,7 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 				jobRegistry, internalExecutor, jobsprotectedts.Jobs),
 			jobsprotectedts.GetMetaType(jobsprotectedts.Schedules): jobsprotectedts.MakeStatusFunc(jobRegistry,
 				internalExecutor, jobsprotectedts.Schedules),
+			systemversioning.MetaType: systemversioning.MakeStatusFunc(keys.SystemSQLCodec),
 		},
 	})
 	if err != nil {
@@ -745,6 +751,7 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 			externalStorageFromURI:   externalStorageFromURI,
 			isMeta1Leaseholder:       node.stores.IsMeta1Leaseholder,
 			sqlSQLResponseAdmissionQ: gcoords.Regular.GetWorkQueue(admission.SQLSQLResponseWork),
+			kvAdmissionQ:             gcoords.Regular.GetWorkQueue(admission.KVWork),
 			spanConfigKVAccessor:     spanConfig.kvAccessorForTenantRecords,
 			kvStoresIterator:         kvserver.MakeStoresIterator(node.stores),
 		},
@@ -43,6 +43,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlinstance"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlliveness"
+	"github.com/cockroachdb/cockroach/pkg/sql/systemversioning"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -495,6 +496,7 @@ func makeTenantSQLServerArgs(
 				circularJobRegistry, circularInternalExecutor, jobsprotectedts.Jobs),
 			jobsprotectedts.GetMetaType(jobsprotectedts.Schedules): jobsprotectedts.MakeStatusFunc(
 				circularJobRegistry, circularInternalExecutor, jobsprotectedts.Schedules),
+			systemversioning.MetaType: systemversioning.MakeStatusFunc(keys.MakeSQLCodec(sqlCfg.TenantID)),
 		},
 	})
 	if err != nil {
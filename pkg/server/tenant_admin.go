@@ -148,3 +148,31 @@ func (t *tenantAdminServer) Drain(
 
 	return t.drain.handleDrain(ctx, req, stream)
 }
+
+// DrainProgress streams the progress of the drain of the node.
+// This method is part of the serverpb.AdminClient interface.
+func (t *tenantAdminServer) DrainProgress(
+	req *serverpb.DrainProgressRequest, stream serverpb.Admin_DrainProgressServer,
+) error {
+	ctx := stream.Context()
+	ctx = t.AnnotateCtx(ctx)
+
+	parsedInstanceID, local, err := t.status.parseInstanceID(req.NodeId)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if !local {
+		instance, err := t.sqlServer.sqlInstanceProvider.GetInstance(ctx, parsedInstanceID)
+		if err != nil {
+			return err
+		}
+		// This request is for another node. Forward it.
+		client, err := t.dialPod(ctx, parsedInstanceID, instance.InstanceAddr)
+		if err != nil {
+			return serverError(ctx, err)
+		}
+		return delegateDrainProgress(ctx, req, client, stream)
+	}
+
+	return t.drain.handleDrainProgress(ctx, stream)
+}
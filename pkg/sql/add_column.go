@@ -80,6 +80,18 @@ func (p *planner) addColumnImpl(
 	idx := cdd.PrimaryKeyOrUniqueIndexDescriptor
 	incTelemetryForNewColumn(d, col)
 
+	// Computed columns over a JSONB path can get an index automatically. These
+	// columns are never unique, so they do not have an index already.
+	if idxDef := schemaexpr.MakeJSONComputedColumnIndexDef(d, toType, params.SessionData()); idxDef != nil {
+		idx = &descpb.IndexDescriptor{}
+		if idxDef.Inverted {
+			idx.Type = descpb.IndexDescriptor_INVERTED
+		}
+		if err := idx.FillColumns(idxDef.Columns); err != nil {
+			return err
+		}
+	}
+
 	// Ensure all new indexes are partitioned appropriately.
 	if idx != nil {
 		if n.tableDesc.IsLocalityRegionalByRow() {
@@ -105,6 +105,9 @@ func AlterColumnType(
 	if err != nil {
 		return err
 	}
+	if err := tabledesc.ValidateColumnTypeIsActive(ctx, params.ExecCfg().Settings.Version, typ); err != nil {
+		return err
+	}
 
 	var kind schemachange.ColumnConversionKind
 	if t.Using != nil {
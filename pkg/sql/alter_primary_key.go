@@ -40,7 +40,8 @@ type alterPrimaryKeyLocalitySwap struct {
 	// allowed to exist in the same transaction as an ALTER PRIMARY KEY.
 	// It is required for the case where we're adding a column to the table
 	// (the implicit crdb_internal_region column) as part of a PRIMARY KEY
-	// change, when transitioning a table to REGIONAL BY ROW.
+	// change, when transitioning a table to REGIONAL BY ROW, and for the case
+	// where we're adding a NOT NULL constraint to a computed region column.
 	// It is nilable to prevent the 0 index from being used in case the struct
 	// is initialized with values improperly set.
 	mutationIdxAllowedInSameTxn *int
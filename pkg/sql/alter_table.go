@@ -707,6 +707,20 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 			descriptorChanged = descriptorChanged || changed
 
+		case *tree.AlterTableSetRowLevelSecurity:
+			if err := checkRowLevelSecuritySupported(
+				params.ctx, params.p, "ALTER TABLE ... ROW LEVEL SECURITY",
+			); err != nil {
+				return err
+			}
+			if err := params.p.checkTableOwnerOrAdmin(params.ctx, n.tableDesc); err != nil {
+				return err
+			}
+			if n.tableDesc.RowLevelSecurityEnabled != t.Enabled {
+				n.tableDesc.RowLevelSecurityEnabled = t.Enabled
+				descriptorChanged = true
+			}
+
 		case *tree.AlterTableInjectStats:
 			sd, ok := n.statsData[i]
 			if !ok {
@@ -725,6 +739,11 @@ func (n *alterTableNode) startExec(params runParams) error {
 			if ttl := n.tableDesc.GetRowLevelTTL(); ttl != nil {
 				ttlBefore = protoutil.Clone(ttl).(*catpb.RowLevelTTL)
 			}
+			systemVersioningBefore := n.tableDesc.GetSystemVersioning()
+			var splitPolicyBefore *descpb.SplitPolicy
+			if sp := n.tableDesc.GetSplitPolicy(); sp != nil {
+				splitPolicyBefore = protoutil.Clone(sp).(*descpb.SplitPolicy)
+			}
 			if err := paramparse.SetStorageParameters(
 				params.ctx,
 				params.p.SemaCtx(),
@@ -745,6 +764,16 @@ func (n *alterTableNode) startExec(params runParams) error {
 			); err != nil {
 				return err
 			}
+			if err := handleSystemVersioningStorageParamChange(
+				params, n.tableDesc, systemVersioningBefore,
+			); err != nil {
+				return err
+			}
+			if err := handleSplitPolicyStorageParamChange(
+				params, n.tableDesc, splitPolicyBefore,
+			); err != nil {
+				return err
+			}
 
 			newTableHasAutoStatsSettings := n.tableDesc.GetAutoStatsSettings() != nil
 			if err := checkDisallowedAutoStatsSettingChange(
@@ -759,6 +788,11 @@ func (n *alterTableNode) startExec(params runParams) error {
 			if ttl := n.tableDesc.GetRowLevelTTL(); ttl != nil {
 				ttlBefore = protoutil.Clone(ttl).(*catpb.RowLevelTTL)
 			}
+			systemVersioningBefore := n.tableDesc.GetSystemVersioning()
+			var splitPolicyBefore *descpb.SplitPolicy
+			if sp := n.tableDesc.GetSplitPolicy(); sp != nil {
+				splitPolicyBefore = protoutil.Clone(sp).(*descpb.SplitPolicy)
+			}
 			if err := paramparse.ResetStorageParameters(
 				params.ctx,
 				params.EvalContext(),
@@ -778,6 +812,16 @@ func (n *alterTableNode) startExec(params runParams) error {
 			); err != nil {
 				return err
 			}
+			if err := handleSystemVersioningStorageParamChange(
+				params, n.tableDesc, systemVersioningBefore,
+			); err != nil {
+				return err
+			}
+			if err := handleSplitPolicyStorageParamChange(
+				params, n.tableDesc, splitPolicyBefore,
+			); err != nil {
+				return err
+			}
 
 			newTableHasAutoStatsSettings := n.tableDesc.GetAutoStatsSettings() != nil
 			if err := checkDisallowedAutoStatsSettingChange(
@@ -1651,6 +1695,39 @@ func dropColumnImpl(
 		return nil, err
 	}
 
+	// We cannot remove this column if there are row-level security policies
+	// that use it, unless CASCADE is specified, in which case the policies are
+	// dropped too.
+	policiesToKeep := make([]descpb.PolicyDescriptor, 0, len(tableDesc.Policies))
+	for _, policy := range tableDesc.Policies {
+		if !descpb.ColumnIDs(policy.ColumnIDs).Contains(colToDrop.GetID()) {
+			policiesToKeep = append(policiesToKeep, policy)
+		} else if t.DropBehavior != tree.DropCascade {
+			return nil, errors.WithHint(
+				pgerror.Newf(pgcode.DependentObjectsStillExist,
+					"column %q is referenced by policy %q", colToDrop.GetName(), policy.Name),
+				"use CASCADE to drop the policy along with the column",
+			)
+		}
+	}
+	tableDesc.Policies = policiesToKeep
+
+	// Likewise for the row-level triggers that refer to the column through NEW
+	// or OLD.
+	triggersToKeep := make([]descpb.TriggerDescriptor, 0, len(tableDesc.Triggers))
+	for _, trigger := range tableDesc.Triggers {
+		if !descpb.ColumnIDs(trigger.ColumnIDs).Contains(colToDrop.GetID()) {
+			triggersToKeep = append(triggersToKeep, trigger)
+		} else if t.DropBehavior != tree.DropCascade {
+			return nil, errors.WithHint(
+				pgerror.Newf(pgcode.DependentObjectsStillExist,
+					"column %q is referenced by trigger %q", colToDrop.GetName(), trigger.Name),
+				"use CASCADE to drop the trigger along with the column",
+			)
+		}
+	}
+	tableDesc.Triggers = triggersToKeep
+
 	if tableDesc.GetPrimaryIndex().CollectKeyColumnIDs().Contains(colToDrop.GetID()) {
 		return nil, pgerror.Newf(pgcode.InvalidColumnReference,
 			"column %q is referenced by the primary key", colToDrop.GetName())
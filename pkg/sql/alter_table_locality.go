@@ -296,7 +296,18 @@ func (n *alterTableSetLocalityNode) alterTableLocalityToRegionalByRow(
 		}
 
 		// Check whether the given row is NOT NULL.
-		if partCol.IsNullable() {
+		if partCol.IsNullable() && partCol.IsComputed() {
+			// A computed region column is made NOT NULL as part of the locality
+			// change, like it is when a table is created REGIONAL BY ROW AS the
+			// column. The constraint is validated along with the new primary key,
+			// so the change is rolled back if the computed expression evaluates
+			// to NULL for any existing row.
+			if err := addNotNullConstraintMutationForCol(n.tableDesc, partCol); err != nil {
+				return err
+			}
+			mutationIdx := len(n.tableDesc.Mutations) - 1
+			mutationIdxAllowedInSameTxn = &mutationIdx
+		} else if partCol.IsNullable() {
 			return errors.WithHintf(
 				pgerror.Newf(
 					pgcode.InvalidTableDefinition,
@@ -13,8 +13,10 @@ package sql
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -23,6 +25,15 @@ import (
 	"github.com/cockroachdb/redact"
 )
 
+// vectorizedApplyJoinEnabled determines whether the apply joins can be
+// executed natively by the vectorized engine.
+var vectorizedApplyJoinEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.distsql.vectorized_apply_join.enabled",
+	"set to true to enable the native execution of apply joins by the vectorized engine",
+	true,
+)
+
 // applyJoinNode implements apply join: the execution component of correlated
 // subqueries. Note that only correlated subqueries that the optimizer's
 // tranformations couldn't decorrelate get planned using apply joins.
@@ -32,6 +43,10 @@ import (
 // plan is then executed and joined with the left row according to normal join
 // semantics. This node doesn't support right or full outer joins, or set
 // operations.
+//
+// If the left side of the join is planned by DistSQL, the vectorized engine
+// can execute the join natively (see applyJoinRowSource), in which case only
+// the right side is handled by this node's machinery.
 type applyJoinNode struct {
 	joinType descpb.JoinType
 
@@ -51,6 +66,13 @@ type applyJoinNode struct {
 
 	planRightSideFn exec.ApplyJoinPlanRightSideFn
 
+	// leftBoundCols are the ordinals of the left columns that are referenced
+	// by the right side. If cacheRightSide is true, the results of the right
+	// side only depend on the values in these columns, so they are reused for
+	// consecutive left rows with the same values.
+	leftBoundCols  []exec.NodeColumnOrdinal
+	cacheRightSide bool
+
 	run struct {
 		// emptyRight is a cached, all-NULL slice that's used for left outer joins
 		// in the case of finding no match on the left.
@@ -60,11 +82,9 @@ type applyJoinNode struct {
 		// leftRowFoundAMatch is set to true when a left row found any match at all,
 		// so that left outer joins and antijoins can know to output a row.
 		leftRowFoundAMatch bool
-		// rightRows will be populated with the result of the right side of the join
-		// each time it's run.
-		rightRows rowContainerHelper
-		// rightRowsIterator, if non-nil, is the iterator into rightRows.
-		rightRowsIterator *rowContainerIterator
+		// right re-plans and runs the right side of the join for each left
+		// row.
+		right applyJoinRightSide
 		// out is the full result row, populated on each call to Next.
 		out tree.Datums
 		// done is true if the left side has been exhausted.
@@ -78,6 +98,8 @@ func newApplyJoinNode(
 	rightCols colinfo.ResultColumns,
 	pred *joinPredicate,
 	planRightSideFn exec.ApplyJoinPlanRightSideFn,
+	leftBoundCols []exec.NodeColumnOrdinal,
+	cacheRightSide bool,
 ) (planNode, error) {
 	switch joinType {
 	case descpb.RightOuterJoin, descpb.FullOuterJoin:
@@ -88,12 +110,26 @@ func newApplyJoinNode(
 		return nil, errors.AssertionFailedf("unsupported right semi/anti apply join: %d", redact.Safe(joinType))
 	}
 
+	if cacheRightSide {
+		for _, ord := range leftBoundCols {
+			if colinfo.CanHaveCompositeKeyEncoding(left.columns[ord].Typ) {
+				// Values of such types can be equal while having different
+				// representations (like 1.0 and 1.00 decimals), and the
+				// right side might depend on the representation.
+				cacheRightSide = false
+				break
+			}
+		}
+	}
+
 	return &applyJoinNode{
 		joinType:        joinType,
 		input:           left,
 		pred:            pred,
 		rightTypes:      getTypesFromResultColumns(rightCols),
 		planRightSideFn: planRightSideFn,
+		leftBoundCols:   leftBoundCols,
+		cacheRightSide:  cacheRightSide,
 		columns:         pred.cols,
 	}, nil
 }
@@ -108,7 +144,7 @@ func (a *applyJoinNode) startExec(params runParams) error {
 		}
 	}
 	a.run.out = make(tree.Datums, len(a.columns))
-	a.run.rightRows.Init(a.rightTypes, params.extendedEvalCtx, "apply-join" /* opName */)
+	a.run.right.init(a, params)
 	return nil
 }
 
@@ -118,28 +154,18 @@ func (a *applyJoinNode) Next(params runParams) (bool, error) {
 	}
 
 	for {
-		if a.run.rightRowsIterator != nil {
-			// We have right rows set up - check the next one for a match.
+		if a.run.leftRow != nil {
+			// We have the right side set up - check the next right row for a
+			// match.
 			for {
-				// Note that if a.rightTypes has zero length, non-nil rrow is
-				// returned the correct number of times.
-				rrow, err := a.run.rightRowsIterator.Next()
+				rrow, err := a.run.right.NextMatch(params.ctx)
 				if err != nil {
 					return false, err
 				}
 				if rrow == nil {
-					// We have exhausted all rows from the right side.
+					// We have exhausted all matching rows from the right side.
 					break
 				}
-				// Compute join.
-				predMatched, err := a.pred.eval(params.EvalContext(), a.run.leftRow, rrow)
-				if err != nil {
-					return false, err
-				}
-				if !predMatched {
-					// Didn't match? Try with the next right-side row.
-					continue
-				}
 
 				a.run.leftRowFoundAMatch = true
 				if a.joinType == descpb.LeftAntiJoin ||
@@ -155,37 +181,27 @@ func (a *applyJoinNode) Next(params runParams) (bool, error) {
 
 			// We're either out of right side rows or we broke out of the loop
 			// before consuming all right rows because we found a match for an
-			// anti or semi join. Clear the right rows to prepare them for the
-			// next left row.
-			if err := a.clearRightRows(params); err != nil {
-				return false, err
-			}
-		}
-		// We're out of right side rows. Reset the match state for next time.
-		foundAMatch := a.run.leftRowFoundAMatch
-		a.run.leftRowFoundAMatch = false
-
-		if a.run.leftRow != nil {
-			// If we have a left row already, we have to check to see if we need to
-			// emit rows for semi, outer, or anti joins.
+			// anti or semi join. Reset the match state for next time and check
+			// to see if we need to emit rows for semi, outer, or anti joins.
+			foundAMatch := a.run.leftRowFoundAMatch
+			a.run.leftRowFoundAMatch = false
+			leftRow := a.run.leftRow
+			a.run.leftRow = nil
 			if foundAMatch {
 				if a.joinType == descpb.LeftSemiJoin {
 					// We found a match, and we're doing an semi-join, so we're done
 					// with this left row after we output it.
-					a.pred.prepareRow(a.run.out, a.run.leftRow, nil)
-					a.run.leftRow = nil
+					a.pred.prepareRow(a.run.out, leftRow, nil)
 					return true, nil
 				}
 			} else {
 				// We found no match. Output LEFT OUTER or ANTI match if necessary.
 				switch a.joinType {
 				case descpb.LeftOuterJoin:
-					a.pred.prepareRow(a.run.out, a.run.leftRow, a.run.emptyRight)
-					a.run.leftRow = nil
+					a.pred.prepareRow(a.run.out, leftRow, a.run.emptyRight)
 					return true, nil
 				case descpb.LeftAntiJoin:
-					a.pred.prepareRow(a.run.out, a.run.leftRow, nil)
-					a.run.leftRow = nil
+					a.pred.prepareRow(a.run.out, leftRow, nil)
 					return true, nil
 				}
 			}
@@ -204,20 +220,8 @@ func (a *applyJoinNode) Next(params runParams) (bool, error) {
 
 		// Extract the values of the outer columns of the other side of the apply
 		// from the latest input row.
-		leftRow := a.input.plan.Values()
-		a.run.leftRow = leftRow
-
-		// At this point, it's time to do the major lift of apply join: re-planning
-		// the right side of the join using the optimizer, with all outer columns
-		// in the right side replaced by the bindings that were defined by the most
-		// recently read left row.
-		p, err := a.planRightSideFn(newExecFactory(params.p), leftRow)
-		if err != nil {
-			return false, err
-		}
-		plan := p.(*planComponents)
-
-		if err := a.runRightSidePlan(params, plan); err != nil {
+		a.run.leftRow = a.input.plan.Values()
+		if err := a.run.right.Start(params.ctx, a.run.leftRow); err != nil {
 			return false, err
 		}
 
@@ -226,31 +230,158 @@ func (a *applyJoinNode) Next(params runParams) (bool, error) {
 	}
 }
 
-// clearRightRows clears rightRows and resets rightRowsIterator. This function
-// must be called before reusing rightRows and rightRowIterator.
-func (a *applyJoinNode) clearRightRows(params runParams) error {
-	if err := a.run.rightRows.Clear(params.ctx); err != nil {
-		return err
+// applyJoinRightSide re-plans and runs the right side of an apply join for the
+// rows of the left side, and it evaluates the join predicate on the resulting
+// rows. It is used both by the applyJoinNode and by the vectorized apply join
+// operator.
+//
+// If the right side can be cached, its results for the last left row are
+// reused as long as the following left rows have the same values in the bound
+// columns. This avoids re-planning and re-running the right side for each of
+// such left rows, which is common when the left side is ordered on the bound
+// columns or when they have few distinct values.
+type applyJoinRightSide struct {
+	node   *applyJoinNode
+	params runParams
+
+	// rightRows will be populated with the result of the right side of the join
+	// each time it's run.
+	rightRows rowContainerHelper
+	// rightRowsIterator, if non-nil, is the iterator into rightRows.
+	rightRowsIterator *rowContainerIterator
+	// leftRow is the left row passed to the last call to Start.
+	leftRow tree.Datums
+	// cachedKey contains the values of the bound columns of the left row for
+	// which rightRows have been computed if haveCachedRows is true. It is only
+	// allocated if the right side can be cached.
+	cachedKey      tree.Datums
+	haveCachedRows bool
+}
+
+var _ colexec.ApplyJoinRightSide = &applyJoinRightSide{}
+
+func (r *applyJoinRightSide) init(n *applyJoinNode, params runParams) {
+	r.node = n
+	r.params = params
+	r.rightRows.Init(n.rightTypes, params.extendedEvalCtx, "apply-join" /* opName */)
+	if n.cacheRightSide {
+		r.cachedKey = make(tree.Datums, len(n.leftBoundCols))
 	}
-	a.run.rightRowsIterator.Close()
-	a.run.rightRowsIterator = nil
-	return nil
 }
 
-// runRightSidePlan runs a planTop that's been generated based on the
-// re-optimized right hand side of the apply join, stashing the result in
-// a.run.rightRows, ready for retrieval. An error indicates that something went
-// wrong during execution of the right hand side of the join, and that we should
-// completely give up on the outer join.
-func (a *applyJoinNode) runRightSidePlan(params runParams, plan *planComponents) error {
-	rowResultWriter := NewRowResultWriter(&a.run.rightRows)
+// Start is part of the colexec.ApplyJoinRightSide interface.
+func (r *applyJoinRightSide) Start(ctx context.Context, leftRow tree.Datums) error {
+	r.leftRow = leftRow
+	if r.rightRowsIterator != nil {
+		r.rightRowsIterator.Close()
+		r.rightRowsIterator = nil
+	}
+	if r.haveCachedRows && r.cachedKeyMatches(leftRow) {
+		// The right side would produce the same rows as for the previous left
+		// row, so we simply iterate over them again.
+		r.rightRowsIterator = newRowContainerIterator(ctx, r.rightRows, r.node.rightTypes)
+		return nil
+	}
+	r.haveCachedRows = false
+	// Clear the right rows to prepare them for the new left row.
+	if err := r.rightRows.Clear(ctx); err != nil {
+		return err
+	}
+
+	// At this point, it's time to do the major lift of apply join: re-planning
+	// the right side of the join using the optimizer, with all outer columns
+	// in the right side replaced by the bindings that were defined by the most
+	// recently read left row.
+	params := r.params
+	params.ctx = ctx
+	p, err := r.node.planRightSideFn(newExecFactory(params.p), leftRow)
+	if err != nil {
+		return err
+	}
+	plan := p.(*planComponents)
+	rowResultWriter := NewRowResultWriter(&r.rightRows)
+	// An error indicates that something went wrong during execution of the
+	// right hand side of the join, and that we should completely give up on
+	// the outer join.
 	if err := runPlanInsidePlan(params, plan, rowResultWriter); err != nil {
 		return err
 	}
-	a.run.rightRowsIterator = newRowContainerIterator(params.ctx, a.run.rightRows, a.rightTypes)
+	if r.cachedKey != nil {
+		for i, ord := range r.node.leftBoundCols {
+			r.cachedKey[i] = leftRow[ord]
+		}
+		r.haveCachedRows = true
+	}
+	r.rightRowsIterator = newRowContainerIterator(ctx, r.rightRows, r.node.rightTypes)
 	return nil
 }
 
+// cachedKeyMatches returns whether leftRow has the same values in the bound
+// columns as the left row for which the right rows have been computed.
+func (r *applyJoinRightSide) cachedKeyMatches(leftRow tree.Datums) bool {
+	evalCtx := r.params.EvalContext()
+	for i, ord := range r.node.leftBoundCols {
+		if r.cachedKey[i].Compare(evalCtx, leftRow[ord]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// NextMatch is part of the colexec.ApplyJoinRightSide interface.
+func (r *applyJoinRightSide) NextMatch(context.Context) (tree.Datums, error) {
+	for {
+		// Note that if rightTypes has zero length, non-nil rrow is returned
+		// the correct number of times.
+		rrow, err := r.rightRowsIterator.Next()
+		if err != nil || rrow == nil {
+			return nil, err
+		}
+		// Compute join.
+		predMatched, err := r.node.pred.eval(r.params.EvalContext(), r.leftRow, rrow)
+		if err != nil {
+			return nil, err
+		}
+		if predMatched {
+			return rrow, nil
+		}
+		// Didn't match? Try with the next right-side row.
+	}
+}
+
+// Close is part of the colexec.ApplyJoinRightSide interface.
+func (r *applyJoinRightSide) Close(ctx context.Context) {
+	if r.rightRowsIterator != nil {
+		r.rightRowsIterator.Close()
+		r.rightRowsIterator = nil
+	}
+	r.rightRows.Close(ctx)
+}
+
+// applyJoinRowSource is the LocalProcessor for an applyJoinNode which has its
+// left side planned by DistSQL. In addition to the row-by-row execution of the
+// whole node, it allows the vectorized engine to execute the join natively,
+// with the left rows coming from the flow and only the right side being run
+// by an applyJoinRightSide.
+type applyJoinRowSource struct {
+	*planNodeToRowSource
+	node *applyJoinNode
+}
+
+var _ colexec.LocalApplyJoin = &applyJoinRowSource{}
+
+// MakeApplyJoinArgs is part of the colexec.LocalApplyJoin interface.
+func (s *applyJoinRowSource) MakeApplyJoinArgs() colexec.ApplyJoinArgs {
+	rightSide := &applyJoinRightSide{}
+	rightSide.init(s.node, s.params)
+	return colexec.ApplyJoinArgs{
+		JoinType:   s.node.joinType,
+		LeftTypes:  getTypesFromResultColumns(s.node.input.columns),
+		RightTypes: s.node.rightTypes,
+		RightSide:  rightSide,
+	}
+}
+
 // runPlanInsidePlan is used to run a plan and gather the results in the
 // resultWriter, as part of the execution of an "outer" plan.
 func runPlanInsidePlan(params runParams, plan *planComponents, resultWriter rowResultWriter) error {
@@ -328,9 +459,5 @@ func (a *applyJoinNode) Values() tree.Datums {
 
 func (a *applyJoinNode) Close(ctx context.Context) {
 	a.input.plan.Close(ctx)
-	a.run.rightRows.Close(ctx)
-	if a.run.rightRowsIterator != nil {
-		a.run.rightRowsIterator.Close()
-		a.run.rightRowsIterator = nil
-	}
+	a.run.right.Close(ctx)
 }
@@ -1047,12 +1047,18 @@ func (sc *SchemaChanger) distIndexBackfill(
 		)
 	}
 
-	origNRanges := -1
+	// origNRanges is the number of ranges left to backfill when the flow
+	// starts, against which the progress is reported.
+	origNRanges, err := numRangesInSpans(ctx, sc.db, sc.distSQLPlanner, todoSpans)
+	if err != nil {
+		return err
+	}
 	updateJobProgress = func() error {
 		// Report schema change progress. We define progress at this point as the fraction of
 		// fully-backfilled ranges of the primary index of the table being scanned. We scale that
 		// fraction of ranges completed by the remaining fraction of the job's progress bar allocated to
-		// this phase of the backfill.
+		// this phase of the backfill. The number of completed ranges is also shown
+		// in the running status of the job.
 		updatedTodoSpans := getTodoSpansForUpdate()
 		if updatedTodoSpans == nil {
 			return nil
@@ -1061,11 +1067,8 @@ func (sc *SchemaChanger) distIndexBackfill(
 		if err != nil {
 			return err
 		}
-		if origNRanges == -1 {
-			origNRanges = nRanges
-		}
 		return sc.db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
-			// No processor has returned completed spans yet.
+			// The ranges might have been split since the flow started.
 			if nRanges < origNRanges {
 				fractionRangesFinished := float32(origNRanges-nRanges) / float32(origNRanges)
 				fractionCompleted, err := fractionScaler.fractionCompleteFromStageFraction(stageBackfill, fractionRangesFinished)
@@ -1076,6 +1079,13 @@ func (sc *SchemaChanger) distIndexBackfill(
 					jobs.FractionUpdater(fractionCompleted)); err != nil {
 					return jobs.SimplifyInvalidStatusError(err)
 				}
+				if err := sc.job.RunningStatus(ctx, txn, func(
+					_ context.Context, _ jobspb.Details,
+				) (jobs.RunningStatus, error) {
+					return backfillRangesRunningStatus(origNRanges-nRanges, origNRanges), nil
+				}); err != nil {
+					return jobs.SimplifyInvalidStatusError(err)
+				}
 			}
 			return nil
 		})
@@ -1177,6 +1187,14 @@ func (sc *SchemaChanger) distIndexBackfill(
 	return nil
 }
 
+// backfillRangesRunningStatus returns the running status of an index backfill
+// that has completed the given number of ranges.
+func backfillRangesRunningStatus(completed, total int) jobs.RunningStatus {
+	return jobs.RunningStatus(fmt.Sprintf(
+		"%s: %d of %d ranges backfilled", RunningStatusBackfill, completed, total,
+	))
+}
+
 // distColumnBackfill runs (or continues) a backfill for the first mutation
 // enqueued on the SchemaChanger's table descriptor that passes the input
 // MutationFilter.
@@ -14,13 +14,13 @@ import (
 	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
-	"golang.org/x/text/language"
 )
 
 // ColTypeInfo is a type that allows multiple representations of column type
@@ -72,7 +72,7 @@ func ValidateColumnDefType(t *types.T) error {
 	switch t.Family() {
 	case types.StringFamily, types.CollatedStringFamily:
 		if t.Family() == types.CollatedStringFamily {
-			if _, err := language.Parse(t.Locale()); err != nil {
+			if err := lex.ValidateLocale(t.Locale()); err != nil {
 				return pgerror.Newf(pgcode.Syntax, `invalid locale %s`, t.Locale())
 			}
 		}
@@ -105,7 +105,8 @@ func ValidateColumnDefType(t *types.T) error {
 	case types.BitFamily, types.IntFamily, types.FloatFamily, types.BoolFamily, types.BytesFamily, types.DateFamily,
 		types.INetFamily, types.IntervalFamily, types.JsonFamily, types.OidFamily, types.TimeFamily,
 		types.TimestampFamily, types.TimestampTZFamily, types.UuidFamily, types.TimeTZFamily,
-		types.GeographyFamily, types.GeometryFamily, types.EnumFamily, types.Box2DFamily:
+		types.GeographyFamily, types.GeometryFamily, types.EnumFamily, types.Box2DFamily,
+		types.TSQueryFamily, types.TSVectorFamily:
 		// These types are OK.
 
 	default:
@@ -134,7 +135,8 @@ func ColumnTypeIsInvertedIndexable(t *types.T) bool {
 	}
 	family := t.Family()
 	return family == types.JsonFamily || family == types.ArrayFamily ||
-		family == types.GeographyFamily || family == types.GeometryFamily
+		family == types.GeographyFamily || family == types.GeometryFamily ||
+		family == types.TSVectorFamily
 }
 
 // MustBeValueEncoded returns true if columns of the given kind can only be value
@@ -148,7 +150,8 @@ func MustBeValueEncoded(semanticType *types.T) bool {
 		default:
 			return MustBeValueEncoded(semanticType.ArrayContents())
 		}
-	case types.JsonFamily, types.TupleFamily, types.GeographyFamily, types.GeometryFamily:
+	case types.JsonFamily, types.TupleFamily, types.GeographyFamily, types.GeometryFamily,
+		types.TSQueryFamily, types.TSVectorFamily:
 		return true
 	}
 	return false
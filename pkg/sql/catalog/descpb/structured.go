@@ -389,6 +389,12 @@ func (ni NameInfo) GetName() string {
 	return ni.Name
 }
 
+var _ tree.UserDefinedAggregateOverload = &UserDefinedAggregate{}
+
+// UserDefinedAggregate implements the tree.UserDefinedAggregateOverload
+// interface.
+func (*UserDefinedAggregate) UserDefinedAggregate() {}
+
 func init() {
 	protoreflect.RegisterShorthands((*Descriptor)(nil), "descriptor", "desc")
 }
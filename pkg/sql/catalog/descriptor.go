@@ -314,6 +314,10 @@ type TableDescriptor interface {
 	// created at, for materialized views and CREATE TABLE AS. Only valid if
 	// IsAs or MaterializedView returns true.
 	GetCreateAsOfTime() hlc.Timestamp
+	// GetMaterializedViewRefreshTime returns the timestamp as of which the data
+	// of the materialized view was last computed, or an empty timestamp if it is
+	// unknown. Only valid if MaterializedView returns true.
+	GetMaterializedViewRefreshTime() hlc.Timestamp
 
 	// GetViewQuery returns this view's CREATE VIEW declaration. Only valid if
 	// IsView is true.
@@ -689,6 +693,19 @@ type TableDescriptor interface {
 	// GetExcludeDataFromBackup returns true if the table's row data is configured
 	// to be excluded during backup.
 	GetExcludeDataFromBackup() bool
+	// GetPolicies returns the row-level security policies of the table.
+	GetPolicies() []descpb.PolicyDescriptor
+	// GetTriggers returns the row-level triggers of the table.
+	GetTriggers() []descpb.TriggerDescriptor
+	// GetSystemVersioning returns the system versioning config of the table,
+	// or nil if system versioning is not enabled.
+	GetSystemVersioning() *descpb.SystemVersioning
+	// GetSplitPolicy returns the split policy of the table, or nil if the
+	// splits of the table are not maintained.
+	GetSplitPolicy() *descpb.SplitPolicy
+	// IsRowLevelSecurityEnabled returns true if row-level security is enabled
+	// on the table, in which case its policies restrict the visible rows.
+	IsRowLevelSecurityEnabled() bool
 	// GetStorageParams returns a list of storage parameters for the table.
 	GetStorageParams(spaceBetweenEqual bool) []string
 	// NoAutoStatsSettingsOverrides is true if no auto stats related settings are
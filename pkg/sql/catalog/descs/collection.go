@@ -177,11 +177,36 @@ func (tc *Collection) ReleaseLeases(ctx context.Context) {
 // ReleaseAll calls ReleaseLeases.
 func (tc *Collection) ReleaseAll(ctx context.Context) {
 	tc.ReleaseLeases(ctx)
+	tc.ResetUncommitted(ctx)
+	tc.skipValidationOnWrite = false
+}
+
+// ResetUncommitted discards the descriptors modified or read from storage by
+// the transaction, but keeps the leases. It is used when the writes of the
+// transaction to the descriptors were rolled back, after which the descriptors
+// are read from storage again.
+func (tc *Collection) ResetUncommitted(ctx context.Context) {
 	tc.uncommitted.reset()
 	tc.kv.reset(ctx)
 	tc.synthetic.reset()
 	tc.deletedDescs = catalog.DescriptorIDSet{}
-	tc.skipValidationOnWrite = false
+}
+
+// ResetForNewReadTimestamp prepares the Collection for the read timestamp of
+// its transaction to move forward, as it does before each statement of a
+// transaction that runs at the READ COMMITTED isolation level. The leases and
+// the descriptors read from storage, which are only valid at the previous read
+// timestamp, are released so that the descriptors are read again at the new
+// one. It returns false and leaves the Collection untouched if the transaction
+// has modified descriptors, in which case the read timestamp must not move.
+func (tc *Collection) ResetForNewReadTimestamp(ctx context.Context) bool {
+	if tc.uncommitted.hasUncommittedDescriptors() || !tc.deletedDescs.Empty() {
+		return false
+	}
+	tc.leased.releaseAll(ctx)
+	tc.uncommitted.reset()
+	tc.kv.reset(ctx)
+	return true
 }
 
 // HasUncommittedTables returns true if the Collection contains uncommitted
@@ -367,6 +367,14 @@ func (ud *uncommittedDescriptors) hasUncommittedTables() (has bool) {
 	return has
 }
 
+func (ud *uncommittedDescriptors) hasUncommittedDescriptors() (has bool) {
+	_ = ud.iterateUncommittedByID(func(desc catalog.Descriptor) error {
+		has = true
+		return iterutil.StopIteration()
+	})
+	return has
+}
+
 func (ud *uncommittedDescriptors) hasUncommittedTypes() (has bool) {
 	_ = ud.iterateUncommittedByID(func(desc catalog.Descriptor) error {
 		if _, has = desc.(catalog.TypeDescriptor); has {
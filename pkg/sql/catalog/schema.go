@@ -26,6 +26,10 @@ type SchemaDescriptor interface {
 	// GetDefaultPrivilegeDescriptor returns the default privileges for this
 	// database.
 	GetDefaultPrivilegeDescriptor() DefaultPrivilegeDescriptor
+
+	// GetAggregates returns the aggregates defined with CREATE AGGREGATE in
+	// this schema.
+	GetAggregates() []descpb.UserDefinedAggregate
 }
 
 // ResolvedSchemaKind is an enum that represents what kind of schema
@@ -189,6 +189,18 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		// Validate the default privilege descriptor.
 		vea.Report(catprivilege.ValidateDefaultPrivileges(*desc.GetDefaultPrivileges()))
 	}
+
+	// Validate the user-defined aggregates.
+	for i := range desc.Aggregates {
+		agg := &desc.Aggregates[i]
+		vea.Report(catalog.ValidateName(agg.Name, "aggregate"))
+		if agg.ArgType == nil || agg.StateType == nil || agg.ResultType == nil {
+			vea.Report(errors.AssertionFailedf("aggregate %q has missing types", agg.Name))
+		}
+		if agg.StateFunc == "" {
+			vea.Report(errors.AssertionFailedf("aggregate %q has no state function", agg.Name))
+		}
+	}
 }
 
 // GetReferencedDescIDs returns the IDs of all descriptors referenced by
@@ -126,3 +126,8 @@ func (p synthetic) HasConcurrentSchemaChanges() bool {
 func (p synthetic) GetDefaultPrivilegeDescriptor() catalog.DefaultPrivilegeDescriptor {
 	return catprivilege.MakeDefaultPrivileges(catprivilege.MakeDefaultPrivilegeDescriptor(catpb.DefaultPrivilegeDescriptor_SCHEMA))
 }
+
+// GetAggregates implements the SchemaDescriptor interface.
+func (p synthetic) GetAggregates() []descpb.UserDefinedAggregate {
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// MakeJSONComputedColumnIndexDef returns the definition of the index that is
+// automatically created on the new column d of type typ, or nil if no index
+// should be created. An index is created when the
+// enable_auto_index_json_computed_columns session setting is true and d is a
+// STORED computed column that fetches a value at a constant path of a JSONB
+// column, for example:
+//
+//   a_b STRING AS (j->'a'->>'b') STORED
+//   a_b JSONB AS (j #> '{a,b}') STORED
+//   a_b INT AS ((j->>'a')::INT) STORED
+//
+// The index is inverted if typ can only be indexed by an inverted index, like
+// JSONB or arrays, and it is a forward index otherwise. Columns that are
+// already indexed because they are part of a primary key or a unique
+// constraint do not get another index.
+func MakeJSONComputedColumnIndexDef(
+	d *tree.ColumnTableDef, typ *types.T, sessionData *sessiondata.SessionData,
+) *tree.IndexTableDef {
+	if sessionData == nil || !sessionData.AutoIndexJSONComputedColumns {
+		return nil
+	}
+	if !d.IsComputed() || d.IsVirtual() || d.PrimaryKey.IsPrimaryKey || d.Unique.IsUnique {
+		return nil
+	}
+	if !IsJSONPathExpr(d.Computed.Expr) {
+		return nil
+	}
+	def := &tree.IndexTableDef{
+		Columns: tree.IndexElemList{{Column: d.Name, Direction: tree.Ascending}},
+	}
+	switch {
+	case colinfo.ColumnTypeIsInvertedIndexable(typ):
+		def.Inverted = true
+	case !colinfo.ColumnTypeIsIndexable(typ):
+		return nil
+	}
+	return def
+}
+
+// IsJSONPathExpr returns true if expr is a chain of the ->, ->>, #> and #>>
+// operators with constant operands applied to a column, optionally wrapped in
+// a cast.
+func IsJSONPathExpr(expr tree.Expr) bool {
+	expr = tree.StripParens(expr)
+	if cast, ok := expr.(*tree.CastExpr); ok {
+		expr = tree.StripParens(cast.Expr)
+	}
+	return isJSONFetchChain(expr, true /* allowText */)
+}
+
+// isJSONFetchChain returns true if expr is a fetch operator with a constant
+// operand applied to either a column or to another such fetch operator. The
+// ->> and #>> operators are only allowed if allowText is true, since they do
+// not return JSON.
+func isJSONFetchChain(expr tree.Expr, allowText bool) bool {
+	bin, ok := expr.(*tree.BinaryExpr)
+	if !ok {
+		return false
+	}
+	switch bin.Operator.Symbol {
+	case treebin.JSONFetchVal, treebin.JSONFetchValPath:
+	case treebin.JSONFetchText, treebin.JSONFetchTextPath:
+		if !allowText {
+			return false
+		}
+	default:
+		return false
+	}
+	if !isConstJSONPath(bin.Right) {
+		return false
+	}
+	switch left := tree.StripParens(bin.Left).(type) {
+	case *tree.UnresolvedName:
+		return left.NumParts == 1 && !left.Star
+	case *tree.BinaryExpr:
+		return isJSONFetchChain(left, false /* allowText */)
+	}
+	return false
+}
+
+// isConstJSONPath returns true if expr is a constant key, index or path.
+func isConstJSONPath(expr tree.Expr) bool {
+	switch t := tree.StripParens(expr).(type) {
+	case *tree.CastExpr:
+		return isConstJSONPath(t.Expr)
+	case *tree.StrVal, *tree.NumVal:
+		return true
+	case *tree.Array:
+		for _, e := range t.Exprs {
+			if _, ok := tree.StripParens(e).(*tree.StrVal); !ok {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
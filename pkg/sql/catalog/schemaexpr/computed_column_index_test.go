@@ -0,0 +1,58 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+func TestIsJSONPathExpr(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		expr     string
+		expected bool
+	}{
+		{"j->'a'", true},
+		{"j->>'a'", true},
+		{"j->0", true},
+		{"j->'a'->'b'->>'c'", true},
+		{"(j->'a')->>'b'", true},
+		{"j #> '{a,b}'", true},
+		{"j #>> ARRAY['a', 'b']", true},
+		{"j #> '{a}' -> 'b'", true},
+		{"(j->>'a')::INT", true},
+		{"j", false},
+		{"j->>'a'->'b'", false},
+		{"j->k", false},
+		{"j #> ARRAY['a', k]", false},
+		{"t.j->'a'", false},
+		{"lower(j->>'a')", false},
+		{"(j->'a') || (j->'b')", false},
+		{"j @> '{\"a\": 1}'", false},
+	}
+	for _, d := range testData {
+		t.Run(d.expr, func(t *testing.T) {
+			expr, err := parser.ParseExpr(d.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res := IsJSONPathExpr(expr); res != d.expected {
+				t.Errorf("expected %v, got %v", d.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// ValidatePolicyExpr verifies that an expression is a valid USING expression
+// of a row-level security policy. If the expression is valid, it returns the
+// serialized expression with the columns dequalified, along with the IDs of
+// the columns it references.
+//
+// A policy expression is valid if all of the following are true:
+//
+//   - It results in a boolean.
+//   - It refers only to columns in the table.
+//   - It does not include subqueries.
+//   - It does not include volatile, aggregate, window, or set returning
+//     functions. Stable functions are allowed so that the policies can refer
+//     to the current user.
+//   - It does not reference a column which is in the process of being added
+//     or removed.
+//
+func ValidatePolicyExpr(
+	ctx context.Context,
+	desc catalog.TableDescriptor,
+	e tree.Expr,
+	tn *tree.TableName,
+	semaCtx *tree.SemaContext,
+) (string, catalog.TableColSet, error) {
+	expr, _, cols, err := DequalifyAndValidateExpr(
+		ctx,
+		desc,
+		e,
+		types.Bool,
+		"policy",
+		semaCtx,
+		volatility.Stable,
+		tn,
+	)
+	if err != nil {
+		return "", catalog.TableColSet{}, err
+	}
+	for _, colID := range cols.Ordered() {
+		col, err := desc.FindColumnWithID(colID)
+		if err != nil {
+			return "", catalog.TableColSet{}, err
+		}
+		if !col.Public() {
+			return "", catalog.TableColSet{}, pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot create policy on column %q (%d) which is not public",
+				col.GetName(), col.GetID())
+		}
+	}
+	return expr, cols, nil
+}
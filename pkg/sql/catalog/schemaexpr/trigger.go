@@ -0,0 +1,271 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+// TriggerRowRef is a reference, in the WHEN condition or the body of a
+// row-level trigger, to a column of the row that fired the trigger.
+// NEW.<column> refers to the value of the column after the modification and
+// OLD.<column> to its value before the modification.
+type TriggerRowRef struct {
+	// New is true for NEW.<column> and false for OLD.<column>.
+	New    bool
+	Column tree.Name
+}
+
+// ReplaceTriggerRowRefs replaces the NEW.<column> and OLD.<column> references
+// in the given statement with the expressions returned by fn.
+func ReplaceTriggerRowRefs(
+	stmt tree.Statement, fn func(ref TriggerRowRef) (tree.Expr, error),
+) (tree.Statement, error) {
+	var visitErr error
+	newStmt, err := tree.SimpleStmtVisit(stmt, func(expr tree.Expr) (bool, tree.Expr, error) {
+		newExpr, recurse, err := replaceTriggerRowRef(expr, fn)
+		if err != nil {
+			// SimpleStmtVisit drops the errors of the visitor, so we have to
+			// remember them here.
+			visitErr = err
+		}
+		return recurse, newExpr, err
+	})
+	if visitErr != nil {
+		return nil, visitErr
+	}
+	return newStmt, err
+}
+
+// ReplaceTriggerRowRefsInExpr is like ReplaceTriggerRowRefs, but for an
+// expression.
+func ReplaceTriggerRowRefsInExpr(
+	expr tree.Expr, fn func(ref TriggerRowRef) (tree.Expr, error),
+) (tree.Expr, error) {
+	return tree.SimpleVisit(expr, func(expr tree.Expr) (bool, tree.Expr, error) {
+		newExpr, recurse, err := replaceTriggerRowRef(expr, fn)
+		return recurse, newExpr, err
+	})
+}
+
+func replaceTriggerRowRef(
+	expr tree.Expr, fn func(ref TriggerRowRef) (tree.Expr, error),
+) (_ tree.Expr, recurse bool, _ error) {
+	n, ok := expr.(*tree.UnresolvedName)
+	if !ok || n.NumParts != 2 {
+		return expr, true, nil
+	}
+	var ref TriggerRowRef
+	switch n.Parts[1] {
+	case "new":
+		ref.New = true
+	case "old":
+	default:
+		return expr, true, nil
+	}
+	if n.Star {
+		return nil, false, unimplemented.Newf("trigger row star",
+			"%s.* is not supported in triggers", strings.ToUpper(n.Parts[1]))
+	}
+	ref.Column = tree.Name(n.Parts[0])
+	newExpr, err := fn(ref)
+	if err != nil {
+		return nil, false, err
+	}
+	return newExpr, false, nil
+}
+
+// ParseTriggerBody parses the body of a row-level trigger. The body must
+// contain at least one statement, and only SELECT, INSERT, UPSERT, UPDATE and
+// DELETE statements are allowed.
+func ParseTriggerBody(body string) (parser.Statements, error) {
+	stmts, err := parser.Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(stmts) == 0 {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition,
+			"trigger body must contain at least one statement")
+	}
+	for _, stmt := range stmts {
+		switch stmt.AST.(type) {
+		case *tree.Select, *tree.Insert, *tree.Update, *tree.Delete:
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"%s statements are not allowed in triggers", stmt.AST.StatementTag())
+		}
+	}
+	return stmts, nil
+}
+
+// ValidateTrigger verifies that the WHEN condition and the body of a
+// row-level trigger are valid. If they are, it returns the serialized WHEN
+// condition (empty if there is none) and body, along with the IDs of the
+// columns they reference through NEW and OLD.
+//
+// The trigger is valid if all of the following are true:
+//
+//   - The body only contains SELECT, INSERT, UPSERT, UPDATE and DELETE
+//     statements.
+//   - NEW and OLD only refer to public, non-virtual columns of the table.
+//   - The WHEN condition results in a boolean, doesn't refer to columns other
+//     than through NEW and OLD, and doesn't include subqueries or aggregate,
+//     window or set returning functions.
+//   - The WHEN condition of an INSERT trigger doesn't refer to OLD, and the
+//     WHEN condition of a DELETE trigger doesn't refer to NEW.
+//
+// In the body, NEW is NULL for DELETE events and OLD is NULL for INSERT
+// events.
+func ValidateTrigger(
+	ctx context.Context,
+	desc catalog.TableDescriptor,
+	events []descpb.TriggerDescriptor_Event,
+	when tree.Expr,
+	body string,
+	semaCtx *tree.SemaContext,
+) (whenExpr string, newBody string, _ catalog.TableColSet, _ error) {
+	var cols catalog.TableColSet
+	var hasInsert, hasDelete bool
+	for _, ev := range events {
+		switch ev {
+		case descpb.TriggerDescriptor_INSERT:
+			hasInsert = true
+		case descpb.TriggerDescriptor_DELETE:
+			hasDelete = true
+		}
+	}
+	resolve := func(ref TriggerRowRef) (catalog.Column, error) {
+		col, err := desc.FindColumnWithName(ref.Column)
+		if err != nil {
+			return nil, err
+		}
+		if col.IsSystemColumn() || col.IsVirtual() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"triggers cannot refer to column %q", col.GetName())
+		}
+		if !col.Public() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot create trigger on column %q (%d) which is not public",
+				col.GetName(), col.GetID())
+		}
+		cols.Add(col.GetID())
+		return col, nil
+	}
+
+	if when != nil {
+		// Replace the references with NULLs of the right type so that the
+		// condition can be type-checked.
+		replaced, err := ReplaceTriggerRowRefsInExpr(when, func(ref TriggerRowRef) (tree.Expr, error) {
+			if ref.New && hasDelete {
+				return nil, pgerror.New(pgcode.InvalidObjectDefinition,
+					"DELETE trigger's WHEN condition cannot reference NEW values")
+			}
+			if !ref.New && hasInsert {
+				return nil, pgerror.New(pgcode.InvalidObjectDefinition,
+					"INSERT trigger's WHEN condition cannot reference OLD values")
+			}
+			col, err := resolve(ref)
+			if err != nil {
+				return nil, err
+			}
+			return &tree.CastExpr{Expr: tree.DNull, Type: col.GetType(), SyntaxMode: tree.CastShort}, nil
+		})
+		if err != nil {
+			return "", "", cols, err
+		}
+		if _, err := SanitizeVarFreeExpr(
+			ctx, replaced, types.Bool, "trigger WHEN condition", semaCtx, volatility.Volatile,
+		); err != nil {
+			return "", "", cols, err
+		}
+		whenExpr = tree.Serialize(when)
+	}
+
+	stmts, err := ParseTriggerBody(body)
+	if err != nil {
+		return "", "", cols, err
+	}
+	var b strings.Builder
+	for i, stmt := range stmts {
+		if _, err := ReplaceTriggerRowRefs(stmt.AST, func(ref TriggerRowRef) (tree.Expr, error) {
+			_, err := resolve(ref)
+			return tree.DNull, err
+		}); err != nil {
+			return "", "", cols, err
+		}
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(tree.Serialize(stmt.AST))
+	}
+	return whenExpr, b.String(), cols, nil
+}
+
+// RenameColumnInTriggerWhen returns the given WHEN condition of a row-level
+// trigger with the NEW and OLD references to column from renamed to to.
+func RenameColumnInTriggerWhen(whenExpr string, from, to tree.Name) (string, error) {
+	expr, err := parser.ParseExpr(whenExpr)
+	if err != nil {
+		return "", err
+	}
+	expr, err = ReplaceTriggerRowRefsInExpr(expr, triggerRowRefRenamer(from, to))
+	if err != nil {
+		return "", err
+	}
+	return tree.Serialize(expr), nil
+}
+
+// RenameColumnInTriggerBody returns the given body of a row-level trigger
+// with the NEW and OLD references to column from renamed to to. References to
+// the column that don't go through NEW or OLD are left untouched.
+func RenameColumnInTriggerBody(body string, from, to tree.Name) (string, error) {
+	stmts, err := parser.Parse(body)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for i, stmt := range stmts {
+		newStmt, err := ReplaceTriggerRowRefs(stmt.AST, triggerRowRefRenamer(from, to))
+		if err != nil {
+			return "", err
+		}
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(tree.Serialize(newStmt))
+	}
+	return b.String(), nil
+}
+
+func triggerRowRefRenamer(from, to tree.Name) func(ref TriggerRowRef) (tree.Expr, error) {
+	return func(ref TriggerRowRef) (tree.Expr, error) {
+		prefix := "old"
+		if ref.New {
+			prefix = "new"
+		}
+		col := ref.Column
+		if col == from {
+			col = to
+		}
+		return tree.NewUnresolvedName(prefix, string(col)), nil
+	}
+}
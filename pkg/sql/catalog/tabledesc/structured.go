@@ -1791,6 +1791,13 @@ func (desc *Mutable) MakeMutationComplete(m descpb.DescriptorMutation) error {
 			// indexes with the new indexes that have been backfilled already.
 			desc.SetPrimaryIndex(t.MaterializedViewRefresh.NewPrimaryIndex)
 			desc.SetPublicNonPrimaryIndexes(t.MaterializedViewRefresh.NewIndexes)
+			// The new indexes contain the result of the view query as of the
+			// refresh timestamp, unless they were left empty.
+			if t.MaterializedViewRefresh.ShouldBackfill {
+				desc.MaterializedViewRefreshTime = t.MaterializedViewRefresh.AsOf
+			} else {
+				desc.MaterializedViewRefreshTime = hlc.Timestamp{}
+			}
 		}
 
 	case descpb.DescriptorMutation_DROP:
@@ -2544,6 +2551,11 @@ func (desc *wrapper) GetExcludeDataFromBackup() bool {
 	return desc.ExcludeDataFromBackup
 }
 
+// IsRowLevelSecurityEnabled implements the TableDescriptor interface.
+func (desc *wrapper) IsRowLevelSecurityEnabled() bool {
+	return desc.RowLevelSecurityEnabled
+}
+
 // GetStorageParams implements the TableDescriptor interface.
 func (desc *wrapper) GetStorageParams(spaceBetweenEqual bool) []string {
 	var storageParams []string
@@ -2584,6 +2596,12 @@ func (desc *wrapper) GetStorageParams(spaceBetweenEqual bool) []string {
 	if exclude := desc.GetExcludeDataFromBackup(); exclude {
 		appendStorageParam(`exclude_data_from_backup`, `true`)
 	}
+	if desc.GetSystemVersioning() != nil {
+		appendStorageParam(`system_versioning`, `true`)
+	}
+	if splitPolicy := desc.GetSplitPolicy(); splitPolicy != nil {
+		appendStorageParam(`split_policy`, fmt.Sprintf(`'prefix(%d)'`, splitPolicy.PrefixColumns))
+	}
 	if settings := desc.AutoStatsSettings; settings != nil {
 		if settings.Enabled != nil {
 			value := *settings.Enabled
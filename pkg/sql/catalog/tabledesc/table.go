@@ -16,6 +16,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
@@ -85,6 +86,26 @@ func (cdd *ColumnDefDescs) ForEachTypedExpr(
 	return nil
 }
 
+// ValidateColumnTypeIsActive returns an error if the type can't be used as the
+// type of a column until the cluster is fully upgraded, because older nodes
+// can't decode it.
+func ValidateColumnTypeIsActive(ctx context.Context, version clusterversion.Handle, t *types.T) error {
+	if t.Family() == types.ArrayFamily {
+		return ValidateColumnTypeIsActive(ctx, version, t.ArrayContents())
+	}
+	switch t.Family() {
+	case types.TSQueryFamily, types.TSVectorFamily:
+		if !version.IsActive(ctx, clusterversion.TSearch) {
+			return pgerror.Newf(
+				pgcode.FeatureNotSupported,
+				"type %s is only available once the cluster is fully upgraded",
+				t.SQLString(),
+			)
+		}
+	}
+	return nil
+}
+
 // MakeColumnDefDescs creates the column descriptor for a column, as well as the
 // index descriptor if the column is a primary key or unique.
 //
@@ -155,6 +176,11 @@ func MakeColumnDefDescs(
 	if err = colinfo.ValidateColumnDefType(resType); err != nil {
 		return nil, err
 	}
+	if evalCtx != nil && evalCtx.Settings != nil {
+		if err = ValidateColumnTypeIsActive(ctx, evalCtx.Settings.Version, resType); err != nil {
+			return nil, err
+		}
+	}
 	col.Type = resType
 
 	if d.HasDefaultExpr() {
@@ -681,6 +707,30 @@ func RenameColumnInTable(
 		}
 	}
 
+	// Rename the column in row-level security policies.
+	for i := range tableDesc.Policies {
+		if err := renameInExpr(&tableDesc.Policies[i].UsingExpr); err != nil {
+			return err
+		}
+	}
+
+	// Rename the column in the NEW and OLD references of row-level triggers.
+	for i := range tableDesc.Triggers {
+		trigger := &tableDesc.Triggers[i]
+		if trigger.WhenExpr != "" {
+			newExpr, err := schemaexpr.RenameColumnInTriggerWhen(trigger.WhenExpr, col.ColName(), newName)
+			if err != nil {
+				return err
+			}
+			trigger.WhenExpr = newExpr
+		}
+		newBody, err := schemaexpr.RenameColumnInTriggerBody(trigger.Body, col.ColName(), newName)
+		if err != nil {
+			return err
+		}
+		trigger.Body = newBody
+	}
+
 	// Rename the column in computed columns.
 	for i := range tableDesc.Columns {
 		if otherCol := &tableDesc.Columns[i]; otherCol.IsComputed() {
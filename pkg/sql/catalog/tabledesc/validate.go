@@ -612,6 +612,8 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			desc.validateColumnFamilies(columnIDs),
 			desc.validateCheckConstraints(columnIDs),
 			desc.validateUniqueWithoutIndexConstraints(columnIDs),
+			desc.validatePolicies(columnIDs),
+			desc.validateTriggers(columnIDs),
 			desc.validateTableIndexes(columnNames, vea),
 			desc.validatePartitioning(),
 		}
@@ -988,6 +990,105 @@ func (desc *wrapper) validateCheckConstraints(
 	return nil
 }
 
+// validatePolicies validates that the row-level security policies are well
+// formed. Checks include validating the names, the column IDs and column names.
+func (desc *wrapper) validatePolicies(
+	columnIDs map[descpb.ColumnID]*descpb.ColumnDescriptor,
+) error {
+	names := make(map[string]struct{}, len(desc.Policies))
+	for i := range desc.Policies {
+		policy := &desc.Policies[i]
+		if err := catalog.ValidateName(policy.Name, "policy"); err != nil {
+			return err
+		}
+		if _, ok := names[policy.Name]; ok {
+			return errors.Newf("duplicate policy name: %q", policy.Name)
+		}
+		names[policy.Name] = struct{}{}
+		if len(policy.Roles) == 0 {
+			return errors.Newf("policy %q does not apply to any roles", policy.Name)
+		}
+
+		// Verify that the policy's column IDs are valid.
+		for _, colID := range policy.ColumnIDs {
+			if _, ok := columnIDs[colID]; !ok {
+				return errors.Newf("policy %q contains unknown column \"%d\"", policy.Name, colID)
+			}
+		}
+
+		// Verify that the policy's expression is valid.
+		expr, err := parser.ParseExpr(policy.UsingExpr)
+		if err != nil {
+			return err
+		}
+		valid, err := schemaexpr.HasValidColumnReferences(desc, expr)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return errors.Newf("policy %q refers to unknown columns in expression: %s",
+				policy.Name, policy.UsingExpr)
+		}
+	}
+	return nil
+}
+
+// validateTriggers validates that the row-level triggers are well formed.
+// Checks include validating the names, the events, the column IDs and the
+// columns referenced by the WHEN condition and the body.
+func (desc *wrapper) validateTriggers(
+	columnIDs map[descpb.ColumnID]*descpb.ColumnDescriptor,
+) error {
+	names := make(map[string]struct{}, len(desc.Triggers))
+	for i := range desc.Triggers {
+		trigger := &desc.Triggers[i]
+		if err := catalog.ValidateName(trigger.Name, "trigger"); err != nil {
+			return err
+		}
+		if _, ok := names[trigger.Name]; ok {
+			return errors.Newf("duplicate trigger name: %q", trigger.Name)
+		}
+		names[trigger.Name] = struct{}{}
+		if len(trigger.Events) == 0 {
+			return errors.Newf("trigger %q does not fire on any events", trigger.Name)
+		}
+
+		// Verify that the trigger's column IDs are valid.
+		for _, colID := range trigger.ColumnIDs {
+			if _, ok := columnIDs[colID]; !ok {
+				return errors.Newf("trigger %q contains unknown column \"%d\"", trigger.Name, colID)
+			}
+		}
+
+		// Verify that the NEW and OLD references of the trigger are valid.
+		checkRef := func(ref schemaexpr.TriggerRowRef) (tree.Expr, error) {
+			if _, err := desc.FindColumnWithName(ref.Column); err != nil {
+				return nil, errors.Newf("trigger %q refers to unknown column %q", trigger.Name, ref.Column)
+			}
+			return tree.DNull, nil
+		}
+		if trigger.WhenExpr != "" {
+			expr, err := parser.ParseExpr(trigger.WhenExpr)
+			if err != nil {
+				return err
+			}
+			if _, err := schemaexpr.ReplaceTriggerRowRefsInExpr(expr, checkRef); err != nil {
+				return err
+			}
+		}
+		stmts, err := schemaexpr.ParseTriggerBody(trigger.Body)
+		if err != nil {
+			return err
+		}
+		for _, stmt := range stmts {
+			if _, err := schemaexpr.ReplaceTriggerRowRefs(stmt.AST, checkRef); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // validateUniqueWithoutIndexConstraints validates that unique without index
 // constraints are well formed. Checks include validating the column IDs and
 // column names.
@@ -133,6 +133,10 @@ var validationMap = []struct {
 			"DeclarativeSchemaChangerState": {status: iSolemnlySwearThisFieldIsValidated},
 			"AutoStatsSettings":             {status: iSolemnlySwearThisFieldIsValidated},
 			"ForecastStats":                 {status: thisFieldReferencesNoObjects},
+			"Policies":                      {status: iSolemnlySwearThisFieldIsValidated},
+			"RowLevelSecurityEnabled":       {status: thisFieldReferencesNoObjects},
+			"MaterializedViewRefreshTime":   {status: thisFieldReferencesNoObjects},
+			"Triggers":                      {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -13,7 +13,6 @@ package colencoding
 import (
 	"time"
 
-	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -145,13 +144,14 @@ func decodeTableKeyToCol(
 		}
 		vecs.Float64Cols[colIdx][rowIdx] = f
 	case types.DecimalFamily:
-		var d apd.Decimal
+		// Decode directly into the vector so that the coefficient storage of
+		// the decimal is reused across batches.
+		d := &vecs.DecimalCols[colIdx][rowIdx]
 		if dir == descpb.IndexDescriptor_ASC {
-			rkey, d, err = encoding.DecodeDecimalAscending(key, scratch[:0])
+			rkey, err = encoding.DecodeIntoDecimalAscending(d, key, scratch[:0])
 		} else {
-			rkey, d, err = encoding.DecodeDecimalDescending(key, scratch[:0])
+			rkey, err = encoding.DecodeIntoDecimalDescending(d, key, scratch[:0])
 		}
-		vecs.DecimalCols[colIdx][rowIdx] = d
 	case types.BytesFamily, types.StringFamily, types.UuidFamily:
 		if dir == descpb.IndexDescriptor_ASC {
 			// We ask for the deep copy to be made so that scratch doesn't
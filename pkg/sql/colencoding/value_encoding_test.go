@@ -67,3 +67,47 @@ func TestDecodeTableValueToCol(t *testing.T) {
 		t.Fatalf("leftover bytes %s", buf)
 	}
 }
+
+// BenchmarkDecodeTableValueToColComposite measures the cost of decoding the
+// values of the types that have composite key encodings.
+func BenchmarkDecodeTableValueToColComposite(b *testing.B) {
+	rng, _ := randutil.NewTestRand()
+	for _, typ := range []*types.T{
+		types.Decimal,
+		types.MakeCollatedString(types.String, "en_US"),
+	} {
+		b.Run(typ.String(), func(b *testing.B) {
+			const numRows = 1024
+			var scratch []byte
+			encoded := make([][]byte, numRows)
+			for i := range encoded {
+				datum := randgen.RandDatum(rng, typ, false /* nullOk */)
+				var err error
+				encoded[i], err = valueside.Encode(nil, valueside.NoColumnID, datum, scratch)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+			typs := []*types.T{typ}
+			batch := coldata.NewMemBatchWithCapacity(typs, numRows, coldataext.NewExtendedColumnFactory(nil /*evalCtx */))
+			var vecs coldata.TypedVecs
+			vecs.SetBatch(batch)
+			var da tree.DatumAlloc
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				rowIdx := i % numRows
+				buf := encoded[rowIdx]
+				typeOffset, dataOffset, _, encTyp, err := encoding.DecodeValueTag(buf)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err = DecodeTableValueToCol(
+					&da, &vecs, 0 /* vecIdx */, rowIdx, encTyp, dataOffset, typ, buf[typeOffset:],
+				); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
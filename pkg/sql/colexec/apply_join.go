@@ -0,0 +1,278 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/colconv"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// ApplyJoinRightSide runs the right side of an apply join for the rows of the
+// left side. Planning of the right side requires the optimizer, so it is
+// implemented outside of the vectorized engine.
+type ApplyJoinRightSide interface {
+	// Start prepares the right side of the join for the given row of the left
+	// side. This involves re-planning the right side with its outer columns
+	// replaced by the values from leftRow and running it, unless the results
+	// for the same values of the outer columns can be reused.
+	Start(ctx context.Context, leftRow tree.Datums) error
+	// NextMatch returns the next row of the right side that satisfies the join
+	// predicate together with the left row passed to the last call to Start,
+	// or nil if there are no more such rows. The returned row is only valid
+	// until the next call.
+	NextMatch(ctx context.Context) (tree.Datums, error)
+	// Close releases the resources held by the right side.
+	Close(ctx context.Context)
+}
+
+// ApplyJoinArgs describes an apply join that is executed by the vectorized
+// apply join operator.
+type ApplyJoinArgs struct {
+	JoinType descpb.JoinType
+	// LeftTypes is the schema of the left side of the join. It must be a
+	// prefix of the schema of the input to the operator.
+	LeftTypes  []*types.T
+	RightTypes []*types.T
+	RightSide  ApplyJoinRightSide
+}
+
+// LocalApplyJoin is implemented by the LocalProcessors that execute apply
+// joins which can also be planned natively by the vectorized engine.
+type LocalApplyJoin interface {
+	execinfra.LocalProcessor
+	// MakeApplyJoinArgs returns the arguments for the vectorized apply join
+	// operator. The LocalProcessor itself is not used afterwards.
+	MakeApplyJoinArgs() ApplyJoinArgs
+}
+
+// NewApplyJoinOp returns an operator that executes an apply join. For each row
+// of the left side, the right side of the join is re-planned and run by
+// args.RightSide, and the left row is joined with the right rows according to
+// the join type. Only inner, left outer, left semi and left anti joins are
+// supported.
+func NewApplyJoinOp(
+	allocator *colmem.Allocator, input colexecop.Operator, args ApplyJoinArgs,
+) colexecop.ClosableOperator {
+	switch args.JoinType {
+	case descpb.InnerJoin, descpb.LeftOuterJoin, descpb.LeftSemiJoin, descpb.LeftAntiJoin:
+	default:
+		colexecerror.InternalError(errors.AssertionFailedf("unsupported apply join type %s", args.JoinType))
+	}
+	a := &applyJoinOp{
+		OneInputNode: colexecop.NewOneInputNode(input),
+		allocator:    allocator,
+		args:         args,
+		outputTypes:  args.JoinType.MakeOutputTypes(args.LeftTypes, args.RightTypes),
+		leftRow:      make(tree.Datums, len(args.LeftTypes)),
+	}
+	if args.JoinType.ShouldIncludeRightColsInOutput() {
+		a.nullRightRow = make(tree.Datums, len(args.RightTypes))
+		for i := range a.nullRightRow {
+			a.nullRightRow[i] = tree.DNull
+		}
+	}
+	return a
+}
+
+type applyJoinOp struct {
+	colexecop.OneInputNode
+	colexecop.InitHelper
+	colexecop.CloserHelper
+
+	allocator   *colmem.Allocator
+	args        ApplyJoinArgs
+	outputTypes []*types.T
+
+	// converter materializes the left columns of the input batches.
+	converter *colconv.VecToDatumConverter
+	// leftBatch is the last batch read from the input, and leftIdx is the
+	// position (after the deselection) of the current left row within it.
+	leftBatch coldata.Batch
+	leftIdx   int
+	leftRow   tree.Datums
+	// started is true if the right side has been started for the current left
+	// row. leftRowMatched indicates whether the current left row has found
+	// any match.
+	started        bool
+	leftRowMatched bool
+	// nullRightRow is the all NULL right row used by left outer joins for the
+	// left rows without any matches.
+	nullRightRow tree.Datums
+
+	output coldata.Batch
+	// outputLeftSel contains, for each output row, the position of its left
+	// row in leftBatch.
+	outputLeftSel []int
+	// outputRightRows contains, for each output row, its right row.
+	outputRightRows rowenc.EncDatumRows
+	da              tree.DatumAlloc
+	done            bool
+}
+
+var _ colexecop.ClosableOperator = &applyJoinOp{}
+
+func (a *applyJoinOp) Init(ctx context.Context) {
+	if !a.InitHelper.Init(ctx) {
+		return
+	}
+	a.Input.Init(a.Ctx)
+	leftIdxs := make([]int, len(a.args.LeftTypes))
+	for i := range leftIdxs {
+		leftIdxs[i] = i
+	}
+	a.converter = colconv.NewVecToDatumConverter(len(a.args.LeftTypes), leftIdxs, true /* willRelease */)
+	a.output = a.allocator.NewMemBatchWithMaxCapacity(a.outputTypes)
+	a.outputLeftSel = make([]int, a.output.Capacity())
+	a.allocator.AdjustMemoryUsage(colmem.SizeOfBatchSizeSelVector)
+	if a.args.JoinType.ShouldIncludeRightColsInOutput() {
+		a.outputRightRows = make(rowenc.EncDatumRows, a.output.Capacity())
+		for i := range a.outputRightRows {
+			a.outputRightRows[i] = make(rowenc.EncDatumRow, len(a.args.RightTypes))
+		}
+	}
+}
+
+func (a *applyJoinOp) Next() coldata.Batch {
+	a.output.ResetInternalBatch()
+	if a.done {
+		return coldata.ZeroBatch
+	}
+	var numOutput int
+	for numOutput < a.output.Capacity() {
+		if a.leftBatch == nil || a.leftIdx == a.leftBatch.Length() {
+			if numOutput > 0 {
+				// The output rows reference the current left batch, so we
+				// have to emit them before reading the next one.
+				break
+			}
+			a.leftBatch, a.leftIdx = a.Input.Next(), 0
+			if a.leftBatch.Length() == 0 {
+				a.done = true
+				break
+			}
+			a.converter.ConvertBatchAndDeselect(a.leftBatch)
+		}
+		if !a.started {
+			for i := range a.leftRow {
+				a.leftRow[i] = a.converter.GetDatumColumn(i)[a.leftIdx]
+			}
+			if err := a.args.RightSide.Start(a.Ctx, a.leftRow); err != nil {
+				colexecerror.ExpectedError(err)
+			}
+			a.started, a.leftRowMatched = true, false
+		}
+		rightRow, err := a.args.RightSide.NextMatch(a.Ctx)
+		if err != nil {
+			colexecerror.ExpectedError(err)
+		}
+		if rightRow != nil {
+			a.leftRowMatched = true
+			switch a.args.JoinType {
+			case descpb.InnerJoin, descpb.LeftOuterJoin:
+				a.addOutputRow(numOutput, rightRow)
+				numOutput++
+				continue
+			case descpb.LeftSemiJoin:
+				a.addOutputRow(numOutput, nil /* rightRow */)
+				numOutput++
+			}
+			// We're doing a semi or an anti join, so we're done with this left
+			// row after the first match.
+		} else if !a.leftRowMatched {
+			switch a.args.JoinType {
+			case descpb.LeftOuterJoin:
+				a.addOutputRow(numOutput, a.nullRightRow)
+				numOutput++
+			case descpb.LeftAntiJoin:
+				a.addOutputRow(numOutput, nil /* rightRow */)
+				numOutput++
+			}
+		}
+		// Advance to the next left row.
+		a.started = false
+		a.leftIdx++
+	}
+	if numOutput > 0 {
+		a.populateOutput(numOutput)
+	}
+	a.output.SetLength(numOutput)
+	return a.output
+}
+
+// addOutputRow records the output row at position outputIdx which joins the
+// current left row with rightRow. rightRow is ignored if the right columns are
+// not included in the output.
+func (a *applyJoinOp) addOutputRow(outputIdx int, rightRow tree.Datums) {
+	leftIdx := a.leftIdx
+	if sel := a.leftBatch.Selection(); sel != nil {
+		leftIdx = sel[leftIdx]
+	}
+	a.outputLeftSel[outputIdx] = leftIdx
+	if a.outputRightRows != nil {
+		outputRow := a.outputRightRows[outputIdx]
+		for i := range outputRow {
+			outputRow[i] = rowenc.DatumToEncDatum(a.args.RightTypes[i], rightRow[i])
+		}
+	}
+}
+
+// populateOutput writes the first numOutput output rows into the output batch.
+func (a *applyJoinOp) populateOutput(numOutput int) {
+	numLeftCols := len(a.args.LeftTypes)
+	a.allocator.PerformOperation(a.output.ColVecs()[:numLeftCols], func() {
+		for i := 0; i < numLeftCols; i++ {
+			a.output.ColVec(i).Copy(
+				coldata.SliceArgs{
+					Src:       a.leftBatch.ColVec(i),
+					Sel:       a.outputLeftSel[:numOutput],
+					SrcEndIdx: numOutput,
+				},
+			)
+		}
+	})
+	if a.outputRightRows == nil {
+		return
+	}
+	if a.da.AllocSize < numOutput {
+		a.da.AllocSize = numOutput
+	}
+	for i, typ := range a.args.RightTypes {
+		if err := EncDatumRowsToColVec(
+			a.allocator, a.outputRightRows[:numOutput], a.output.ColVec(numLeftCols+i), i, typ, &a.da,
+		); err != nil {
+			colexecerror.InternalError(err)
+		}
+	}
+}
+
+// Close is part of the colexecop.ClosableOperator interface.
+func (a *applyJoinOp) Close(ctx context.Context) error {
+	if !a.CloserHelper.Close() {
+		return nil
+	}
+	a.args.RightSide.Close(ctx)
+	if a.converter != nil {
+		a.converter.Release()
+		a.converter = nil
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexectestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// testApplyJoinRightSide is an ApplyJoinRightSide that, for the left row with
+// value k, produces the right rows with the odd values in range [1, k].
+type testApplyJoinRightSide struct {
+	rows []tree.Datums
+	idx  int
+}
+
+var _ ApplyJoinRightSide = &testApplyJoinRightSide{}
+
+func (r *testApplyJoinRightSide) Start(_ context.Context, leftRow tree.Datums) error {
+	r.rows, r.idx = r.rows[:0], 0
+	if leftRow[0] == tree.DNull {
+		return nil
+	}
+	for i := 1; i <= int(tree.MustBeDInt(leftRow[0])); i += 2 {
+		r.rows = append(r.rows, tree.Datums{tree.NewDInt(tree.DInt(i))})
+	}
+	return nil
+}
+
+func (r *testApplyJoinRightSide) NextMatch(context.Context) (tree.Datums, error) {
+	if r.idx == len(r.rows) {
+		return nil, nil
+	}
+	r.idx++
+	return r.rows[r.idx-1], nil
+}
+
+func (r *testApplyJoinRightSide) Close(context.Context) {}
+
+func TestApplyJoinOp(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	input := colexectestutils.Tuples{{0}, {1}, {2}, {3}, {nil}}
+	for _, tc := range []struct {
+		joinType descpb.JoinType
+		expected colexectestutils.Tuples
+	}{
+		{
+			joinType: descpb.InnerJoin,
+			expected: colexectestutils.Tuples{{1, 1}, {2, 1}, {3, 1}, {3, 3}},
+		},
+		{
+			joinType: descpb.LeftOuterJoin,
+			expected: colexectestutils.Tuples{{0, nil}, {1, 1}, {2, 1}, {3, 1}, {3, 3}, {nil, nil}},
+		},
+		{
+			joinType: descpb.LeftSemiJoin,
+			expected: colexectestutils.Tuples{{1}, {2}, {3}},
+		},
+		{
+			joinType: descpb.LeftAntiJoin,
+			expected: colexectestutils.Tuples{{0}, {nil}},
+		},
+	} {
+		t.Run(tc.joinType.String(), func(t *testing.T) {
+			colexectestutils.RunTests(
+				t, testAllocator, []colexectestutils.Tuples{input}, tc.expected, colexectestutils.OrderedVerifier,
+				func(inputs []colexecop.Operator) (colexecop.Operator, error) {
+					return NewApplyJoinOp(testAllocator, inputs[0], ApplyJoinArgs{
+						JoinType:   tc.joinType,
+						LeftTypes:  []*types.T{types.Int},
+						RightTypes: []*types.T{types.Int},
+						RightSide:  &testApplyJoinRightSide{},
+					}), nil
+				},
+			)
+		})
+	}
+}
+
+// TestApplyJoinOpManyMatches verifies that the operator correctly handles the
+// left rows with more matches than fit into a single output batch.
+func TestApplyJoinOpManyMatches(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	numMatches := 3*coldata.BatchSize() + 1
+	input := colexectestutils.Tuples{{2*numMatches - 1}, {0}, {1}}
+	typs := []*types.T{types.Int}
+	source := colexectestutils.NewOpTestInput(testAllocator, coldata.BatchSize(), input, typs)
+	op := NewApplyJoinOp(testAllocator, source, ApplyJoinArgs{
+		JoinType:   descpb.LeftOuterJoin,
+		LeftTypes:  typs,
+		RightTypes: typs,
+		RightSide:  &testApplyJoinRightSide{},
+	})
+	op.Init(ctx)
+	defer func() { require.NoError(t, op.Close(ctx)) }()
+	var numRows int
+	for b := op.Next(); b.Length() > 0; b = op.Next() {
+		for i := 0; i < b.Length(); i++ {
+			left, right := b.ColVec(0).Int64(), b.ColVec(1)
+			switch {
+			case numRows < numMatches:
+				require.Equal(t, int64(2*numMatches-1), left.Get(i))
+				require.Equal(t, int64(2*numRows+1), right.Int64().Get(i))
+			case numRows == numMatches:
+				// The left row with no matches is NULL-extended.
+				require.Equal(t, int64(0), left.Get(i))
+				require.True(t, right.Nulls().NullAt(i))
+			default:
+				require.Equal(t, int64(1), left.Get(i))
+				require.Equal(t, int64(1), right.Int64().Get(i))
+			}
+			numRows++
+		}
+	}
+	require.Equal(t, numMatches+2, numRows)
+}
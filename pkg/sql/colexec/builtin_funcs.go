@@ -120,6 +120,11 @@ func NewBuiltinFunctionOperator(
 	outputType := funcExpr.ResolvedType()
 	input = colexecutils.NewVectorTypeEnforcer(allocator, input, outputType, outputIdx)
 	switch overload.SpecializedVecBuiltin {
+	case tree.JSONExtractPath, tree.JSONExtractPathText:
+		return newJSONExtractPathOperator(
+			allocator, argumentCols, outputIdx, input,
+			overload.SpecializedVecBuiltin == tree.JSONExtractPathText, /* asText */
+		), nil
 	case tree.SubstringStringIntInt:
 		return newSubstringOperator(
 			allocator, columnTypes, argumentCols, outputIdx, input,
@@ -109,6 +109,42 @@ func TestBasicBuiltinFunctions(t *testing.T) {
 				{"hi你好吗ciao", 6, 4, "ciao"},
 			},
 		},
+		{
+			desc:      "JSONExtractPath",
+			expr:      "jsonb_extract_path(@1, @2, @3)",
+			inputCols: []int{0},
+			inputTuples: colexectestutils.Tuples{
+				{`{"a": {"b": [1, 2]}}`, "a", "b"},
+				{`{"a": [{"b": 3}]}`, "a", "0"},
+				{`{"a": {"b": 1}}`, "a", "c"},
+				{`{"a": {"b": 1}}`, nil, "b"},
+				{nil, "a", "b"},
+			},
+			inputTypes: []*types.T{types.Jsonb, types.String, types.String},
+			outputTuples: colexectestutils.Tuples{
+				{mustParseJSON(`{"a": {"b": [1, 2]}}`), "a", "b", mustParseJSON(`[1, 2]`)},
+				{mustParseJSON(`{"a": [{"b": 3}]}`), "a", "0", mustParseJSON(`{"b": 3}`)},
+				{mustParseJSON(`{"a": {"b": 1}}`), "a", "c", nil},
+				{mustParseJSON(`{"a": {"b": 1}}`), nil, "b", nil},
+				{nil, "a", "b", nil},
+			},
+		},
+		{
+			desc:      "JSONExtractPathText",
+			expr:      "jsonb_extract_path_text(@1, @2)",
+			inputCols: []int{0},
+			inputTuples: colexectestutils.Tuples{
+				{`{"a": "x"}`, "a"},
+				{`{"a": {"b": 1}}`, "a"},
+				{`{"a": null}`, "a"},
+			},
+			inputTypes: []*types.T{types.Jsonb, types.String},
+			outputTuples: colexectestutils.Tuples{
+				{mustParseJSON(`{"a": "x"}`), "a", "x"},
+				{mustParseJSON(`{"a": {"b": 1}}`), "a", `{"b": 1}`},
+				{mustParseJSON(`{"a": null}`), "a", nil},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
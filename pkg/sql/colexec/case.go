@@ -39,7 +39,8 @@ type caseOp struct {
 	// scratch is used to populate the output vector out of order, before
 	// copying it into the batch. We need this because each WHEN arm can match
 	// an arbitrary set of tuples and some vectors don't support SET operation
-	// in arbitrary order.
+	// in arbitrary order. The scratch is only populated if more than one arm
+	// matches the tuples of the batch (see pending below).
 	//
 	// Consider the following example:
 	//   input column c = {0, 1, 2, 1, 0, 2}
@@ -103,6 +104,22 @@ type caseOp struct {
 	// by the current case arm (those present in the "previous" sel and not
 	// present in the "current" sel).
 	prevSel []int
+
+	// pending describes the only arm that has matched any tuples of the
+	// current batch so far. Its results are left in the arm's projection
+	// column, and the scratch is only materialized once another arm matches
+	// some tuples too. This way, if all tuples of the batch end up matching a
+	// single arm (which is common for CASE expressions with many arms), the
+	// results are copied straight into the output column, without the
+	// intermediate copy into the scratch.
+	pending struct {
+		// armIdx is the index of the arm in thenIdxs, or -1 if no arm has
+		// matched any tuples yet.
+		armIdx int
+		// sel is the selection of the tuples matched by the arm. It is only
+		// populated if the arm didn't match all tuples of the batch.
+		sel []int
+	}
 }
 
 var _ colexecop.Operator = &caseOp{}
@@ -143,9 +160,9 @@ func NewCaseOp(
 	outputIdx int,
 	typ *types.T,
 ) colexecop.Operator {
-	// We internally use three selection vectors, scratch.order, origSel, and
-	// prevSel.
-	allocator.AdjustMemoryUsage(3 * colmem.SizeOfBatchSizeSelVector)
+	// We internally use four selection vectors, scratch.order, origSel,
+	// prevSel, and pending.sel.
+	allocator.AdjustMemoryUsage(4 * colmem.SizeOfBatchSizeSelVector)
 	return &caseOp{
 		allocator: allocator,
 		buffer:    buffer.(*bufferOp),
@@ -167,28 +184,67 @@ func (c *caseOp) Init(ctx context.Context) {
 	c.elseOp.Init(c.Ctx)
 }
 
-// copyIntoScratch copies all values specified by the selection vector of the
-// batch from column at position inputColIdx into the scratch output vector. The
-// copied values are put starting at numAlreadyMatched index in the output
-// vector. It is assumed that the selection vector of batch is non-nil.
-func (c *caseOp) copyIntoScratch(batch coldata.Batch, inputColIdx int, numAlreadyMatched int) {
-	n := batch.Length()
-	inputCol := batch.ColVec(inputColIdx)
-	// Copy the results into the scratch output vector, using the selection
-	// vector to copy only the elements that we actually wrote according to the
-	// current projection arm.
-	c.scratch.output.Copy(
-		coldata.SliceArgs{
-			Src:       inputCol,
-			Sel:       batch.Selection()[:n],
-			DestIdx:   numAlreadyMatched,
-			SrcEndIdx: n,
-		})
-	for j, tupleIdx := range batch.Selection()[:n] {
+// prepareScratch makes sure that the scratch can hold the results for a batch
+// of length n. orderCapacity is the length of scratch.order that is sufficient
+// to support all indices mentioned in the selection vector of the batch.
+func (c *caseOp) prepareScratch(n int, orderCapacity int) {
+	if c.scratch.output == nil || c.scratch.output.Capacity() < n {
+		c.scratch.output = c.allocator.NewMemColumn(c.typ, n)
+	} else {
+		coldata.ResetIfBytesLike(c.scratch.output)
+	}
+	c.scratch.order = colexecutils.EnsureSelectionVectorLength(c.scratch.order, orderCapacity)
+}
+
+// copyIntoScratch copies all values specified by sel from src into the scratch
+// output vector. The copied values are put starting at numAlreadyMatched index
+// in the output vector.
+func (c *caseOp) copyIntoScratch(src coldata.Vec, sel []int, numAlreadyMatched int) {
+	c.allocator.PerformOperation([]coldata.Vec{c.scratch.output}, func() {
+		// Copy the results into the scratch output vector, using the selection
+		// vector to copy only the elements that we actually wrote according to
+		// the current projection arm.
+		c.scratch.output.Copy(
+			coldata.SliceArgs{
+				Src:       src,
+				Sel:       sel,
+				DestIdx:   numAlreadyMatched,
+				SrcEndIdx: len(sel),
+			})
+	})
+	for j, tupleIdx := range sel {
 		c.scratch.order[tupleIdx] = numAlreadyMatched + j
 	}
 }
 
+// addMatches records that the tuples specified by sel have been matched by the
+// arm at position armIdx in thenIdxs. numAlreadyMatched is the number of tuples
+// matched by the previous arms. If any tuples have already been matched by
+// another arm, the results of both arms are copied into the scratch. origLen
+// and orderCapacity describe the buffered batch.
+func (c *caseOp) addMatches(
+	batch coldata.Batch, armIdx int, sel []int, numAlreadyMatched int, origLen int, orderCapacity int,
+) {
+	if numAlreadyMatched == 0 {
+		// This is the first arm that matched any tuples, so we don't need to
+		// copy its results anywhere yet.
+		c.pending.armIdx = armIdx
+		if len(sel) < origLen {
+			c.pending.sel = colexecutils.EnsureSelectionVectorLength(c.pending.sel, len(sel))
+			copy(c.pending.sel, sel)
+		}
+		return
+	}
+	if c.pending.armIdx >= 0 {
+		// This is the second arm that matched any tuples, so we have to
+		// materialize the scratch with the results of the pending arm.
+		c.prepareScratch(origLen, orderCapacity)
+		c.copyIntoScratch(batch.ColVec(c.thenIdxs[c.pending.armIdx]), c.pending.sel[:numAlreadyMatched], 0 /* numAlreadyMatched */)
+		c.pending.armIdx = -1
+	}
+	c.copyIntoScratch(batch.ColVec(c.thenIdxs[armIdx]), sel, numAlreadyMatched)
+}
+
 func (c *caseOp) Next() coldata.Batch {
 	c.buffer.advance()
 	origLen := c.buffer.batch.Length()
@@ -210,150 +266,142 @@ func (c *caseOp) Next() coldata.Batch {
 	}
 	outputCol := c.buffer.batch.ColVec(c.outputIdx)
 
-	if c.scratch.output == nil || c.scratch.output.Capacity() < origLen {
-		c.scratch.output = c.allocator.NewMemColumn(c.typ, origLen)
-	} else {
-		coldata.ResetIfBytesLike(c.scratch.output)
-	}
 	orderCapacity := origLen
 	if origHasSel {
 		orderCapacity = c.origSel[origLen-1] + 1
 	}
-	c.scratch.order = colexecutils.EnsureSelectionVectorLength(c.scratch.order, orderCapacity)
+	c.pending.armIdx = -1
 
 	// Run all WHEN arms.
 	numAlreadyMatched := 0
-	c.allocator.PerformOperation([]coldata.Vec{c.scratch.output}, func() {
-		for i := range c.caseOps {
-			// Run the next case operator chain. It will project its THEN expression
-			// for all tuples that matched its WHEN expression and that were not
-			// already matched.
-			batch := c.caseOps[i].Next()
-			// The batch's projection column now additionally contains results for all
-			// of the tuples that passed the ith WHEN clause. The batch's selection
-			// vector is set to the same selection of tuples.
-			// Now, we must subtract this selection vector from the previous
-			// selection vector, so that the next operator gets to operate on the
-			// remaining set of tuples in the input that haven't matched an arm of the
-			// case statement.
-			// As an example, imagine the first WHEN op matched tuple 3. The following
-			// diagram shows the selection vector before running WHEN, after running
-			// WHEN, and then the desired selection vector after subtraction:
-			// - origSel
-			// | - selection vector after running WHEN
-			// | | - desired selection vector after subtraction
-			// | | |
-			// 1   1
-			// 2   2
-			// 3 3
-			// 4   4
-			toSubtract := batch.Selection()
-			toSubtract = toSubtract[:batch.Length()]
-			// toSubtract is now a selection vector containing all matched tuples of the
-			// current case arm.
-			if batch.Length() > 0 {
-				c.copyIntoScratch(batch, c.thenIdxs[i], numAlreadyMatched)
+	for i := range c.caseOps {
+		// Run the next case operator chain. It will project its THEN expression
+		// for all tuples that matched its WHEN expression and that were not
+		// already matched.
+		batch := c.caseOps[i].Next()
+		// The batch's projection column now additionally contains results for all
+		// of the tuples that passed the ith WHEN clause. The batch's selection
+		// vector is set to the same selection of tuples.
+		// Now, we must subtract this selection vector from the previous
+		// selection vector, so that the next operator gets to operate on the
+		// remaining set of tuples in the input that haven't matched an arm of the
+		// case statement.
+		// As an example, imagine the first WHEN op matched tuple 3. The following
+		// diagram shows the selection vector before running WHEN, after running
+		// WHEN, and then the desired selection vector after subtraction:
+		// - origSel
+		// | - selection vector after running WHEN
+		// | | - desired selection vector after subtraction
+		// | | |
+		// 1   1
+		// 2   2
+		// 3 3
+		// 4   4
+		toSubtract := batch.Selection()
+		toSubtract = toSubtract[:batch.Length()]
+		// toSubtract is now a selection vector containing all matched tuples of the
+		// current case arm.
+		if batch.Length() > 0 {
+			c.addMatches(batch, i, toSubtract, numAlreadyMatched, origLen, orderCapacity)
 
-				numAlreadyMatched += len(toSubtract)
-				if numAlreadyMatched == origLen {
-					// All tuples have already matched, so we can short-circuit.
-					return
-				}
+			numAlreadyMatched += len(toSubtract)
+			if numAlreadyMatched == origLen {
+				// All tuples have already matched, so we can short-circuit.
+				break
+			}
 
-				var subtractIdx int
-				var curIdx int
-				if prevHasSel {
-					// We have a previous selection vector, which represents the tuples
-					// that haven't yet been matched. Remove the ones that just matched
-					// from the previous selection vector.
-					for i := range c.prevSel {
-						if subtractIdx < len(toSubtract) && toSubtract[subtractIdx] == c.prevSel[i] {
-							// The ith element of the previous selection vector matched the
-							// current one in toSubtract. Skip writing this element, removing
-							// it from the previous selection vector.
-							subtractIdx++
-							continue
-						}
-						c.prevSel[curIdx] = c.prevSel[i]
-						curIdx++
-					}
-				} else {
-					// No selection vector means there have been no matches yet, and we were
-					// considering the entire batch of tuples for this case arm. Make a new
-					// selection vector with all of the tuples but the ones that just matched.
-					c.prevSel = colexecutils.EnsureSelectionVectorLength(c.prevSel, origLen)
-					for i := 0; i < origLen; i++ {
-						// Note that here we rely on the assumption that
-						// toSubtract is an increasing sequence (because our
-						// selection vectors are such) to optimize the
-						// subtraction.
-						if subtractIdx < len(toSubtract) && toSubtract[subtractIdx] == i {
-							subtractIdx++
-							continue
-						}
-						c.prevSel[curIdx] = i
-						curIdx++
+			var subtractIdx int
+			var curIdx int
+			if prevHasSel {
+				// We have a previous selection vector, which represents the tuples
+				// that haven't yet been matched. Remove the ones that just matched
+				// from the previous selection vector.
+				for i := range c.prevSel {
+					if subtractIdx < len(toSubtract) && toSubtract[subtractIdx] == c.prevSel[i] {
+						// The ith element of the previous selection vector matched the
+						// current one in toSubtract. Skip writing this element, removing
+						// it from the previous selection vector.
+						subtractIdx++
+						continue
 					}
+					c.prevSel[curIdx] = c.prevSel[i]
+					curIdx++
 				}
-				// Set the buffered batch into the desired state.
-				colexecutils.UpdateBatchState(c.buffer.batch, curIdx, true /* usesSel */, c.prevSel)
-				prevHasSel = true
-				c.prevSel = c.prevSel[:curIdx]
 			} else {
-				// There were no matches with the current WHEN arm, so we simply need
-				// to restore the buffered batch into the previous state.
-				prevLen := origLen - numAlreadyMatched
-				colexecutils.UpdateBatchState(c.buffer.batch, prevLen, prevHasSel, c.prevSel)
+				// No selection vector means there have been no matches yet, and we were
+				// considering the entire batch of tuples for this case arm. Make a new
+				// selection vector with all of the tuples but the ones that just matched.
+				c.prevSel = colexecutils.EnsureSelectionVectorLength(c.prevSel, origLen)
+				for i := 0; i < origLen; i++ {
+					// Note that here we rely on the assumption that
+					// toSubtract is an increasing sequence (because our
+					// selection vectors are such) to optimize the
+					// subtraction.
+					if subtractIdx < len(toSubtract) && toSubtract[subtractIdx] == i {
+						subtractIdx++
+						continue
+					}
+					c.prevSel[curIdx] = i
+					curIdx++
+				}
 			}
-			// Now our selection vector is set to exclude all the things that have
-			// matched so far. Reset the buffer and run the next case arm.
-			c.buffer.rewind()
+			// Set the buffered batch into the desired state.
+			colexecutils.UpdateBatchState(c.buffer.batch, curIdx, true /* usesSel */, c.prevSel)
+			prevHasSel = true
+			c.prevSel = c.prevSel[:curIdx]
+		} else {
+			// There were no matches with the current WHEN arm, so we simply need
+			// to restore the buffered batch into the previous state.
+			prevLen := origLen - numAlreadyMatched
+			colexecutils.UpdateBatchState(c.buffer.batch, prevLen, prevHasSel, c.prevSel)
 		}
-	})
+		// Now our selection vector is set to exclude all the things that have
+		// matched so far. Reset the buffer and run the next case arm.
+		c.buffer.rewind()
+	}
 
 	// Run the ELSE arm if necessary.
-	outputReady := false
-	if numAlreadyMatched == 0 && !origHasSel {
-		// If no tuples matched with any of the WHEN arms and the original batch
-		// didn't have a selection vector, we can copy the result of the ELSE
-		// projection straight into the output batch because the result will be
-		// in the correct order.
+	if numAlreadyMatched < origLen {
 		batch := c.elseOp.Next()
-		c.allocator.PerformOperation([]coldata.Vec{outputCol}, func() {
+		if numAlreadyMatched == 0 {
+			// No tuples matched any of the WHEN arms, so all of them are
+			// matched by the ELSE arm.
+			c.pending.armIdx = len(c.thenIdxs) - 1
+		} else {
+			c.addMatches(
+				batch, len(c.thenIdxs)-1, batch.Selection()[:batch.Length()],
+				numAlreadyMatched, origLen, orderCapacity,
+			)
+		}
+	}
+
+	c.allocator.PerformOperation([]coldata.Vec{outputCol}, func() {
+		if c.pending.armIdx >= 0 {
+			// All tuples have been matched by a single arm, so we can copy its
+			// results straight into the output batch because they are already
+			// in the correct positions. Note that if the original batch had a
+			// selection vector, some garbage values of not selected tuples are
+			// copied too, which is ok.
 			outputCol.Copy(
 				coldata.SliceArgs{
-					Src:       batch.ColVec(c.thenIdxs[len(c.thenIdxs)-1]),
+					Src:       c.buffer.batch.ColVec(c.thenIdxs[c.pending.armIdx]),
+					SrcEndIdx: orderCapacity,
+				})
+		} else if origHasSel {
+			// If the original batch had a selection vector, we cannot just
+			// copy the output from the scratch because we want to preserve
+			// that selection vector. See comment above c.scratch for an
+			// example.
+			outputCol.CopyWithReorderedSource(c.scratch.output, c.origSel, c.scratch.order)
+		} else {
+			outputCol.Copy(
+				coldata.SliceArgs{
+					Src:       c.scratch.output,
+					Sel:       c.scratch.order,
 					SrcEndIdx: origLen,
 				})
-		})
-		outputReady = true
-	} else if numAlreadyMatched < origLen {
-		batch := c.elseOp.Next()
-		c.allocator.PerformOperation([]coldata.Vec{c.scratch.output}, func() {
-			c.copyIntoScratch(batch, c.thenIdxs[len(c.thenIdxs)-1], numAlreadyMatched)
-		})
-	}
-
-	// Copy the output vector from the scratch space into the batch if
-	// necessary.
-	if !outputReady {
-		c.allocator.PerformOperation([]coldata.Vec{outputCol}, func() {
-			if origHasSel {
-				// If the original batch had a selection vector, we cannot just
-				// copy the output from the scratch because we want to preserve
-				// that selection vector. See comment above c.scratch for an
-				// example.
-				outputCol.CopyWithReorderedSource(c.scratch.output, c.origSel, c.scratch.order)
-			} else {
-				outputCol.Copy(
-					coldata.SliceArgs{
-						Src:       c.scratch.output,
-						Sel:       c.scratch.order,
-						SrcEndIdx: origLen,
-					})
-			}
-		})
-	}
+		}
+	})
 
 	// Restore the original state of the buffered batch.
 	colexecutils.UpdateBatchState(c.buffer.batch, origLen, origHasSel, c.origSel)
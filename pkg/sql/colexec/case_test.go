@@ -82,6 +82,22 @@ func TestCaseOp(t *testing.T) {
 			expected:   colexectestutils.Tuples{{42}, {42}, {42}, {42}},
 			inputTypes: []*types.T{types.Int},
 		},
+		{
+			// Test when a single WHEN arm other than the first one matches all
+			// tuples.
+			tuples:     colexectestutils.Tuples{{1}, {2}, {3}, {4}},
+			renderExpr: "CASE WHEN @1 > 4 THEN 0 WHEN @1 > 0 THEN @1 * 10 ELSE 42 END",
+			expected:   colexectestutils.Tuples{{10}, {20}, {30}, {40}},
+			inputTypes: []*types.T{types.Int},
+		},
+		{
+			// Test a CASE with many arms, each matching a different subset of
+			// tuples.
+			tuples:     colexectestutils.Tuples{{4}, {1}, {nil}, {3}, {1}, {5}, {2}, {4}},
+			renderExpr: "CASE WHEN @1 = 1 THEN 'a' WHEN @1 = 2 THEN 'b' WHEN @1 = 3 THEN 'c' WHEN @1 = 4 THEN 'd' ELSE 'e' END",
+			expected:   colexectestutils.Tuples{{"d"}, {"a"}, {"e"}, {"c"}, {"a"}, {"e"}, {"b"}, {"d"}},
+			inputTypes: []*types.T{types.Int},
+		},
 	} {
 		colexectestutils.RunTests(t, testAllocator, []colexectestutils.Tuples{tc.tuples}, tc.expected, colexectestutils.OrderedVerifier, func(inputs []colexecop.Operator) (colexecop.Operator, error) {
 			caseOp, err := colexectestutils.CreateTestProjectingOperator(
@@ -0,0 +1,135 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexec
+
+import (
+	"context"
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// CoalesceSmallBatchesEnabled is a cluster setting that controls whether the
+// vectorized planner puts a coalescer in front of the inputs of the operators
+// that benefit from processing full batches (the probe side of hash joiners
+// and sorters).
+var CoalesceSmallBatchesEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.distsql.vectorize_coalesce_small_batches.enabled",
+	"set to true to merge small batches (e.g. produced by selective filters) "+
+		"into full batches before hash joiners and sorters",
+	true,
+)
+
+// coalescerOp is an operator that merges runs of small batches from its input
+// into fuller batches. Batches that already have at least minBatchLength
+// tuples are emitted as is (without copying) when there are no buffered
+// tuples, so the operator is cheap when the input produces full batches.
+//
+// Note that the coalescerOp preserves the order of tuples.
+type coalescerOp struct {
+	colexecop.OneInputHelper
+	colexecop.NonExplainable
+
+	allocator      *colmem.Allocator
+	typs           []*types.T
+	minBatchLength int
+
+	output coldata.Batch
+	// pending, if set, is the last batch read from the input that didn't fit
+	// into the output batch. It will be processed on the next call to Next.
+	pending coldata.Batch
+}
+
+var _ colexecop.ResettableOperator = &coalescerOp{}
+
+// NewCoalescerOp creates a new coalescer operator on the given input operator
+// with the given column types. Batches with fewer than coldata.BatchSize()/2
+// tuples are merged together.
+func NewCoalescerOp(
+	allocator *colmem.Allocator, input colexecop.Operator, typs []*types.T,
+) colexecop.Operator {
+	return &coalescerOp{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		allocator:      allocator,
+		typs:           typs,
+		minBatchLength: coldata.BatchSize() / 2,
+	}
+}
+
+func (c *coalescerOp) Next() coldata.Batch {
+	var outputLength int
+	for {
+		batch := c.pending
+		c.pending = nil
+		if batch == nil {
+			batch = c.Input.Next()
+		}
+		n := batch.Length()
+		if n == 0 {
+			if outputLength == 0 {
+				return coldata.ZeroBatch
+			}
+			break
+		}
+		if outputLength == 0 && n >= c.minBatchLength {
+			// The batch is large enough, so we emit it directly.
+			return batch
+		}
+		if outputLength+n > coldata.BatchSize() {
+			// The batch doesn't fit into the output, so we emit the buffered
+			// tuples and process this batch on the next call.
+			c.pending = batch
+			break
+		}
+		if outputLength == 0 {
+			// The output batch has already been returned (if it was), so it
+			// can be reset. The coalescerOp only ever holds a single batch, so
+			// we don't limit its memory footprint.
+			const maxBatchMemSize = math.MaxInt64
+			c.output, _ = c.allocator.ResetMaybeReallocate(
+				c.typs, c.output, coldata.BatchSize(), maxBatchMemSize,
+				true, /* desiredCapacitySufficient */
+			)
+		}
+		sel := batch.Selection()
+		c.allocator.PerformOperation(c.output.ColVecs(), func() {
+			for i := range c.typs {
+				c.output.ColVec(i).Copy(
+					coldata.SliceArgs{
+						Src:       batch.ColVec(i),
+						Sel:       sel,
+						DestIdx:   outputLength,
+						SrcEndIdx: n,
+					},
+				)
+			}
+		})
+		outputLength += n
+		if outputLength >= c.minBatchLength {
+			break
+		}
+	}
+	c.output.SetLength(outputLength)
+	return c.output
+}
+
+// Reset is part of the colexecop.Resetter interface.
+func (c *coalescerOp) Reset(ctx context.Context) {
+	c.pending = nil
+	if r, ok := c.Input.(colexecop.Resetter); ok {
+		r.Reset(ctx)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexectestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoalescer(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	rng, _ := randutil.NewTestRand()
+	typs := []*types.T{types.Int, types.String}
+	numTuples := rng.Intn(3 * coldata.BatchSize())
+	tuples := make(colexectestutils.Tuples, numTuples)
+	for i := range tuples {
+		tuples[i] = colexectestutils.Tuple{i, string(rune('a' + i%26))}
+	}
+	colexectestutils.RunTestsWithTyps(t, testAllocator, []colexectestutils.Tuples{tuples}, [][]*types.T{typs}, tuples, colexectestutils.OrderedVerifier,
+		func(input []colexecop.Operator) (colexecop.Operator, error) {
+			return NewCoalescerOp(testAllocator, input[0], typs), nil
+		})
+}
+
+// TestCoalescerMergesSmallBatches verifies that the coalescer merges tiny
+// batches into batches of at least half of coldata.BatchSize() tuples (except
+// for the last one).
+func TestCoalescerMergesSmallBatches(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	if coldata.BatchSize() < 2 {
+		return
+	}
+	rng, _ := randutil.NewTestRand()
+	typs := []*types.T{types.Int}
+	numTuples := 5 * coldata.BatchSize()
+	tuples := make(colexectestutils.Tuples, numTuples)
+	for i := range tuples {
+		tuples[i] = colexectestutils.Tuple{i}
+	}
+	inputBatchSize := 1 + rng.Intn(coldata.BatchSize()/2)
+	input := colexectestutils.NewOpTestInput(testAllocator, inputBatchSize, tuples, typs)
+	coalescer := NewCoalescerOp(testAllocator, input, typs)
+	coalescer.Init(context.Background())
+
+	var lengths []int
+	expected := 0
+	for {
+		b := coalescer.Next()
+		if b.Length() == 0 {
+			break
+		}
+		lengths = append(lengths, b.Length())
+		col := b.ColVec(0).Int64()
+		for i := 0; i < b.Length(); i++ {
+			idx := i
+			if sel := b.Selection(); sel != nil {
+				idx = sel[i]
+			}
+			require.Equal(t, int64(expected), col[idx])
+			expected++
+		}
+	}
+	require.Equal(t, numTuples, expected)
+	for _, l := range lengths[:len(lengths)-1] {
+		require.GreaterOrEqual(t, l, coldata.BatchSize()/2)
+		require.LessOrEqual(t, l, coldata.BatchSize())
+	}
+}
@@ -13,6 +13,7 @@ package colbuilder
 import (
 	"context"
 	"reflect"
+	"runtime"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
@@ -187,6 +188,9 @@ func supportedNatively(spec *execinfrapb.ProcessorSpec) error {
 	case spec.Core.Filterer != nil:
 		return nil
 
+	case spec.Core.ProjectSet != nil:
+		return nil
+
 	case spec.Core.Aggregator != nil:
 		for _, agg := range spec.Core.Aggregator.Aggregations {
 			if agg.FilterColIdx != nil {
@@ -196,6 +200,10 @@ func supportedNatively(spec *execinfrapb.ProcessorSpec) error {
 		return nil
 
 	case spec.Core.Distinct != nil:
+		if len(spec.Core.Distinct.GroupOrdering.Columns) > 0 &&
+			len(spec.Core.Distinct.OrderedColumns) != len(spec.Core.Distinct.DistinctColumns) {
+			return errors.Newf("distinct with group ordering on non-ordered columns not supported")
+		}
 		return nil
 
 	case spec.Core.Ordinality != nil:
@@ -208,8 +216,16 @@ func supportedNatively(spec *execinfrapb.ProcessorSpec) error {
 		return nil
 
 	case spec.Core.MergeJoiner != nil:
-		if !spec.Core.MergeJoiner.OnExpr.Empty() && spec.Core.MergeJoiner.Type != descpb.InnerJoin {
-			return errors.Errorf("can't plan non-inner merge join with ON expressions")
+		if !spec.Core.MergeJoiner.OnExpr.Empty() {
+			switch spec.Core.MergeJoiner.Type {
+			case descpb.InnerJoin:
+			case descpb.LeftOuterJoin, descpb.LeftSemiJoin, descpb.LeftAntiJoin:
+				if len(spec.Core.MergeJoiner.RightOrdering.Columns) == 0 {
+					return errors.Errorf("can't plan non-inner merge join with ON expressions and no equality columns")
+				}
+			default:
+				return errors.Errorf("can't plan %s merge join with ON expressions", spec.Core.MergeJoiner.Type)
+			}
 		}
 		return nil
 
@@ -230,9 +246,13 @@ func supportedNatively(spec *execinfrapb.ProcessorSpec) error {
 		return nil
 
 	case spec.Core.LocalPlanNode != nil:
-		// LocalPlanNode core is special (we don't have any plans on vectorizing
-		// it at the moment), so we want to return a custom error for it to
-		// distinguish from other unsupported cores.
+		if spec.Core.LocalPlanNode.IsApplyJoin {
+			return nil
+		}
+		// LocalPlanNode core is special (apart from the apply joins, we don't
+		// have any plans on vectorizing it at the moment), so we want to
+		// return a custom error for it to distinguish from other unsupported
+		// cores.
 		return errLocalPlanNodeWrap
 
 	default:
@@ -321,6 +341,25 @@ func canWrap(mode sessiondatapb.VectorizeExecMode, spec *execinfrapb.ProcessorSp
 	return nil
 }
 
+// parallelSortNumWorkers returns the number of goroutines that the general
+// sort of the input with the given types on the given ordering should use.
+func parallelSortNumWorkers(
+	flowCtx *execinfra.FlowCtx, inputTypes []*types.T, ordering execinfrapb.Ordering,
+) int {
+	numWorkers := int(colexec.SortMaxWorkers.Get(&flowCtx.Cfg.Settings.SV))
+	if maxProcs := runtime.GOMAXPROCS(0); numWorkers > maxProcs {
+		numWorkers = maxProcs
+	}
+	for _, col := range ordering.Columns {
+		// The comparison of datum-backed values might use the eval context,
+		// which isn't safe for concurrent use.
+		if typeconv.TypeFamilyToCanonicalTypeFamily(inputTypes[col.ColIdx].Family()) == typeconv.DatumVecCanonicalTypeFamily {
+			return 1
+		}
+	}
+	return numWorkers
+}
+
 // createDiskBackedSort creates a new disk-backed operator that sorts the input
 // according to ordering.
 // - matchLen specifies the length of the prefix of ordering columns the input
@@ -394,6 +433,20 @@ func (r opResult) createDiskBackedSort(
 			deselectorUnlimitedAllocator, colmem.NewAllocator(ctx, sortChunksMemAccount, factory),
 			input, inputTypes, ordering.Columns, int(matchLen), maxOutputBatchMemSize,
 		)
+	} else if numWorkers := parallelSortNumWorkers(flowCtx, inputTypes, ordering); numWorkers > 1 {
+		// No optimizations possible, but the input can be sorted by multiple
+		// goroutines, each of which needs its own memory account.
+		var sorterMemAccounts []*mon.BoundAccount
+		sorterMemAccounts, sorterMemMonitorName = args.MonitorRegistry.CreateMemAccountsForSpillStrategyWithLimit(
+			ctx, flowCtx, spoolMemLimit, opNamePrefix+"parallel-sort", processorID, numWorkers,
+		)
+		allocators := make([]*colmem.Allocator, numWorkers)
+		for i := range allocators {
+			allocators[i] = colmem.NewAllocator(ctx, sorterMemAccounts[i], factory)
+		}
+		inMemorySorter = colexec.NewParallelSorter(
+			allocators, input, inputTypes, ordering.Columns, maxOutputBatchMemSize,
+		)
 	} else {
 		// No optimizations possible. Default to the standard sort operator.
 		var sorterMemAccount *mon.BoundAccount
@@ -534,6 +587,15 @@ func (r opResult) createAndWrapRowSource(
 		// natively since it is more interesting.
 		return causeToWrap
 	}
+	if rec := flowCtx.Cfg.VectorizedFallbacks; rec != nil {
+		component := spec.Core.Name()
+		if spec.Core.Noop != nil {
+			// Noop cores are supported natively, so we're wrapping only the
+			// post-processing stage.
+			component = "PostProcess"
+		}
+		rec.RecordFallback(true /* wrapped */, component, causeToWrap)
+	}
 	// Note that the materializers aren't safe to release in all cases since in
 	// some cases they could be released before being closed. Namely, this would
 	// occur if we have a subquery with LocalPlanNode core and a materializer is
@@ -628,6 +690,57 @@ func getStreamingAllocator(
 	return colmem.NewAllocator(ctx, args.StreamingMemAccount, args.Factory)
 }
 
+// maybePlanCoalescer plans a coalescer on top of the input, if enabled, so
+// that the runs of small batches (e.g. produced by selective filters) are
+// merged before being processed by an expensive operator.
+func maybePlanCoalescer(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	args *colexecargs.NewColOperatorArgs,
+	input colexecop.Operator,
+	typs []*types.T,
+) colexecop.Operator {
+	if !colexec.CoalesceSmallBatchesEnabled.Get(&flowCtx.Cfg.Settings.SV) {
+		return input
+	}
+	return colexec.NewCoalescerOp(getStreamingAllocator(ctx, args), input, typs)
+}
+
+// maybePlanRuntimeFilter plans a runtime filter for the hash join, if enabled
+// and supported, and returns the updated inputs. The filter is pushed into
+// the probe (left) input if it accepts it (e.g. when the probe input is a
+// ColBatchScan), and it is applied by a separate operator otherwise.
+func maybePlanRuntimeFilter(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	args *colexecargs.NewColOperatorArgs,
+	spec *execinfrapb.HashJoinerSpec,
+	leftInput, rightInput colexecop.Operator,
+	leftTypes, rightTypes []*types.T,
+) (colexecop.Operator, colexecop.Operator) {
+	if !colexecjoin.RuntimeFiltersEnabled.Get(&flowCtx.Cfg.Settings.SV) ||
+		!colexecjoin.RuntimeFilterSupported(spec.Type) {
+		return leftInput, rightInput
+	}
+	for i := range spec.LeftEqColumns {
+		leftType, rightType := leftTypes[spec.LeftEqColumns[i]], rightTypes[spec.RightEqColumns[i]]
+		// The keys must hash to the same values on both sides, which is also
+		// the case for integers of different widths.
+		if !leftType.Identical(rightType) &&
+			(leftType.Family() != types.IntFamily || rightType.Family() != types.IntFamily) {
+			return leftInput, rightInput
+		}
+	}
+	filter := colexecjoin.NewRuntimeFilter(
+		getStreamingAllocator(ctx, args), spec.RightEqColumns, spec.LeftEqColumns,
+	)
+	rightInput = filter.NewBuilderOp(rightInput)
+	if a, ok := colexec.MaybeUnwrapInvariantsChecker(leftInput).(colexecop.RuntimeFilterAcceptor); ok && a.AcceptRuntimeFilter(filter) {
+		return leftInput, rightInput
+	}
+	return colexecjoin.NewRuntimeFilterOp(leftInput, filter), rightInput
+}
+
 // NOTE: throughout this file we do not append an output type of a projecting
 // operator to the passed-in type schema - we, instead, always allocate a new
 // type slice and copy over the old schema and set the output column of a
@@ -794,6 +907,20 @@ func NewColOperator(
 				return r, err
 			}
 
+		case core.ProjectSet != nil:
+			if err := checkNumIn(inputs, 1); err != nil {
+				return r, err
+			}
+
+			result.ColumnTypes = make([]*types.T, len(spec.Input[0].ColumnTypes))
+			copy(result.ColumnTypes, spec.Input[0].ColumnTypes)
+			result.Root = inputs[0].Root
+			if err := result.planAndMaybeWrapProjectSet(
+				ctx, flowCtx, args, spec.ProcessorID, core.ProjectSet, factory,
+			); err != nil {
+				return r, err
+			}
+
 		case core.Aggregator != nil:
 			if err := checkNumIn(inputs, 1); err != nil {
 				return r, err
@@ -977,7 +1104,12 @@ func NewColOperator(
 			}
 			result.ColumnTypes = make([]*types.T, len(spec.Input[0].ColumnTypes))
 			copy(result.ColumnTypes, spec.Input[0].ColumnTypes)
-			if len(core.Distinct.OrderedColumns) == len(core.Distinct.DistinctColumns) {
+			if len(core.Distinct.GroupOrdering.Columns) > 0 {
+				result.Root = colexec.NewDistinctOnOp(
+					getStreamingAllocator(ctx, args), inputs[0].Root, result.ColumnTypes,
+					core.Distinct.DistinctColumns, core.Distinct.GroupOrdering.Columns,
+				)
+			} else if len(core.Distinct.OrderedColumns) == len(core.Distinct.DistinctColumns) {
 				result.Root = colexecbase.NewOrderedDistinct(
 					inputs[0].Root, core.Distinct.OrderedColumns, result.ColumnTypes,
 					core.Distinct.NullsAreDistinct, core.Distinct.ErrorOnDup,
@@ -1081,9 +1213,24 @@ func NewColOperator(
 					core.HashJoiner.RightEqColumnsAreKey,
 				)
 
+				leftInput, rightInput := maybePlanRuntimeFilter(
+					ctx, flowCtx, args, core.HashJoiner, inputs[0].Root, inputs[1].Root, leftTypes, rightTypes,
+				)
+				// The build (right) side is buffered by the hash joiner in its
+				// entirety anyway, so only the probe side is coalesced.
+				leftInput = maybePlanCoalescer(ctx, flowCtx, args, leftInput, leftTypes)
+				// Only the in-memory hash joiner can spill to disk early, so
+				// the disk-backed one uses the original spec.
+				inMemoryHJSpec := hjSpec
+				if !args.TestingKnobs.DiskSpillingDisabled {
+					inMemoryHJSpec.EarlySpillBuildRows = colexecjoin.EarlySpillBuildRows(
+						&flowCtx.Cfg.Settings.SV, core.HashJoiner.RightEstimatedRowCount,
+					)
+					inMemoryHJSpec.EarlySpillMemMonitorName = hashJoinerMemMonitorName
+				}
 				inMemoryHashJoiner := colexecjoin.NewHashJoiner(
 					colmem.NewAllocator(ctx, hashJoinerMemAccount, factory),
-					hashJoinerUnlimitedAllocator, hjSpec, inputs[0].Root, inputs[1].Root,
+					hashJoinerUnlimitedAllocator, inMemoryHJSpec, leftInput, rightInput,
 					colexecjoin.HashJoinerInitialNumBuckets,
 				)
 				if args.TestingKnobs.DiskSpillingDisabled {
@@ -1095,7 +1242,7 @@ func NewColOperator(
 					opName := redact.RedactableString("external-hash-joiner")
 					diskAccount := args.MonitorRegistry.CreateDiskAccount(ctx, flowCtx, opName, spec.ProcessorID)
 					result.Root = colexecdisk.NewTwoInputDiskSpiller(
-						inputs[0].Root, inputs[1].Root, inMemoryHashJoiner.(colexecop.BufferingInMemoryOperator),
+						leftInput, rightInput, inMemoryHashJoiner.(colexecop.BufferingInMemoryOperator),
 						hashJoinerMemMonitorName,
 						func(inputOne, inputTwo colexecop.Operator) colexecop.Operator {
 							unlimitedAllocator := colmem.NewAllocator(
@@ -1142,10 +1289,20 @@ func NewColOperator(
 			var onExpr *execinfrapb.Expression
 			if !core.MergeJoiner.OnExpr.Empty() {
 				if joinType != descpb.InnerJoin {
-					return r, errors.AssertionFailedf(
-						"ON expression (%s) was unexpectedly planned for merge joiner with join type %s",
-						core.MergeJoiner.OnExpr.String(), core.MergeJoiner.Type.String(),
-					)
+					if err = result.planMergeJoinWithOnExpr(
+						ctx, flowCtx, args, spec, inputs, leftTypes, rightTypes, factory,
+					); err != nil {
+						// The ON expression couldn't be planned natively, so we
+						// wrap the row-execution merge joiner which will also
+						// take care of the post-processing.
+						if err = result.createAndWrapRowSource(
+							ctx, flowCtx, args, inputs, [][]*types.T{leftTypes, rightTypes}, spec, factory, err,
+						); err != nil {
+							return r, err
+						}
+						post = &execinfrapb.PostProcessSpec{}
+					}
+					break
 				}
 				onExpr = &core.MergeJoiner.OnExpr
 			}
@@ -1189,11 +1346,30 @@ func NewColOperator(
 			ordering := core.Sorter.OutputOrdering
 			matchLen := core.Sorter.OrderingMatchLen
 			limit := core.Sorter.Limit
+			if matchLen == 0 {
+				// When the input is partially ordered, the chunker does the
+				// coalescing itself.
+				input = maybePlanCoalescer(ctx, flowCtx, args, input, result.ColumnTypes)
+			}
 			result.Root = result.createDiskBackedSort(
 				ctx, flowCtx, args, input, result.ColumnTypes, ordering, limit, matchLen, 0, /* maxNumberPartitions */
 				spec.ProcessorID, "" /* opNamePrefix */, factory,
 			)
 
+		case core.LocalPlanNode != nil:
+			if err := checkNumIn(inputs, 1); err != nil {
+				return r, err
+			}
+			localProcessor := args.LocalProcessors[core.LocalPlanNode.RowSourceIdx]
+			applyJoin, ok := localProcessor.(colexec.LocalApplyJoin)
+			if !ok {
+				return r, errors.AssertionFailedf("unexpectedly %T is not an apply join", localProcessor)
+			}
+			applyJoinArgs := applyJoin.MakeApplyJoinArgs()
+			result.Root = colexec.NewApplyJoinOp(getStreamingAllocator(ctx, args), inputs[0].Root, applyJoinArgs)
+			result.ColumnTypes = applyJoinArgs.JoinType.MakeOutputTypes(applyJoinArgs.LeftTypes, applyJoinArgs.RightTypes)
+			result.ToClose = append(result.ToClose, result.Root.(colexecop.Closer))
+
 		case core.Windower != nil:
 			if err := checkNumIn(inputs, 1); err != nil {
 				return r, err
@@ -1563,6 +1739,91 @@ func NewColOperator(
 	return r, err
 }
 
+// planMergeJoinWithOnExpr plans a LEFT OUTER, LEFT SEMI or LEFT ANTI merge
+// join with an ON expression. The ON expression is evaluated on the output of
+// the LEFT OUTER merge join of the inputs, to which a row identifier of the
+// left rows is added, and the tuples are then selected by the merge join ON
+// expression operator according to the join type. r is only updated if there
+// is no error.
+func (r opResult) planMergeJoinWithOnExpr(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	args *colexecargs.NewColOperatorArgs,
+	spec *execinfrapb.ProcessorSpec,
+	inputs []colexecargs.OpWithMetaInfo,
+	leftTypes, rightTypes []*types.T,
+	factory coldata.ColumnFactory,
+) error {
+	core := spec.Core.MergeJoiner
+	numLeftCols, numRightCols := len(leftTypes), len(rightTypes)
+	// The row identifier is appended to the left input, and it is moved after
+	// the right columns once the inputs are joined so that the ordinals in
+	// the ON expression remain valid.
+	leftInput := colexecbase.NewOrdinalityOp(
+		getStreamingAllocator(ctx, args), inputs[0].Root, numLeftCols,
+	)
+	opName := redact.RedactableString("merge-joiner")
+	unlimitedAllocator := colmem.NewAllocator(
+		ctx, args.MonitorRegistry.CreateUnlimitedMemAccount(
+			ctx, flowCtx, opName, spec.ProcessorID,
+		), factory)
+	diskAccount := args.MonitorRegistry.CreateDiskAccount(ctx, flowCtx, opName, spec.ProcessorID)
+	mj := colexecjoin.NewMergeJoinOp(
+		unlimitedAllocator, execinfra.GetWorkMemLimit(flowCtx),
+		args.DiskQueueCfg, args.FDSemaphore,
+		descpb.LeftOuterJoin, leftInput, inputs[1].Root, appendOneType(leftTypes, types.Int), rightTypes,
+		core.LeftOrdering.Columns, core.RightOrdering.Columns,
+		diskAccount, flowCtx.EvalCtx,
+	)
+	projection := make([]uint32, 0, numLeftCols+numRightCols+1)
+	for i := 0; i < numLeftCols; i++ {
+		projection = append(projection, uint32(i))
+	}
+	for i := 0; i < numRightCols; i++ {
+		projection = append(projection, uint32(numLeftCols+1+i))
+	}
+	projection = append(projection, uint32(numLeftCols))
+	inputTypes := make([]*types.T, 0, numLeftCols+numRightCols+1)
+	inputTypes = append(inputTypes, leftTypes...)
+	inputTypes = append(inputTypes, rightTypes...)
+	inputTypes = append(inputTypes, types.Int)
+	op := colexecop.Operator(colexecbase.NewSimpleProjectOp(mj, len(inputTypes), projection))
+
+	expr, err := args.ExprHelper.ProcessExpr(core.OnExpr, flowCtx.EvalCtx, inputTypes)
+	if err != nil {
+		return err
+	}
+	var onExprIdx int
+	op, onExprIdx, inputTypes, err = planProjectionOperators(
+		ctx, flowCtx.EvalCtx, expr, inputTypes, op, args.StreamingMemAccount, factory, &r.Releasables,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "unable to columnarize ON expression %q", core.OnExpr)
+	}
+	if onExprIdx < 0 || inputTypes[onExprIdx].Family() != types.BoolFamily {
+		return errors.Newf("unexpected result of ON expression %q", core.OnExpr)
+	}
+	op = colexecjoin.NewMergeJoinOnExprOp(
+		getStreamingAllocator(ctx, args), op, colexecjoin.MergeJoinOnExprSpec{
+			JoinType:      core.Type,
+			InputTypes:    inputTypes,
+			NumLeftCols:   numLeftCols,
+			NumRightCols:  numRightCols,
+			RightEqColIdx: numLeftCols + int(core.RightOrdering.Columns[0].ColIdx),
+			RowIDIdx:      numLeftCols + numRightCols,
+			OnExprIdx:     onExprIdx,
+		},
+	)
+	r.ColumnTypes = core.Type.MakeOutputTypes(leftTypes, rightTypes)
+	outputColumns := make([]uint32, len(r.ColumnTypes))
+	for i := range outputColumns {
+		outputColumns[i] = uint32(i)
+	}
+	r.Root = colexecbase.NewSimpleProjectOp(op, len(inputTypes), outputColumns)
+	r.ToClose = append(r.ToClose, mj.(colexecop.Closer))
+	return nil
+}
+
 // planAndMaybeWrapFilter plans a filter. If the filter is unsupported, it is
 // planned as a wrapped filterer processor.
 func (r opResult) planAndMaybeWrapFilter(
@@ -1599,6 +1860,91 @@ func (r opResult) planAndMaybeWrapFilter(
 	return nil
 }
 
+// planAndMaybeWrapProjectSet plans a project set. If any of its expressions
+// is unsupported, it is planned as a wrapped projectSet processor.
+func (r opResult) planAndMaybeWrapProjectSet(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	args *colexecargs.NewColOperatorArgs,
+	processorID int32,
+	projectSet *execinfrapb.ProjectSetSpec,
+	factory coldata.ColumnFactory,
+) error {
+	err := r.planProjectSet(ctx, flowCtx, args, projectSet, factory)
+	if err != nil {
+		// Project set planning failed. Fall back to planning the project set
+		// using row execution.
+		resultTypes := make([]*types.T, 0, len(r.ColumnTypes)+len(projectSet.GeneratedColumns))
+		resultTypes = append(resultTypes, r.ColumnTypes...)
+		resultTypes = append(resultTypes, projectSet.GeneratedColumns...)
+		projectSetSpec := &execinfrapb.ProcessorSpec{
+			Core: execinfrapb.ProcessorCoreUnion{
+				ProjectSet: projectSet,
+			},
+			ProcessorID: processorID,
+			ResultTypes: resultTypes,
+		}
+		inputToMaterializer := colexecargs.OpWithMetaInfo{Root: r.Root}
+		takeOverMetaInfo(&inputToMaterializer, args.Inputs)
+		return r.createAndWrapRowSource(
+			ctx, flowCtx, args, []colexecargs.OpWithMetaInfo{inputToMaterializer},
+			[][]*types.T{r.ColumnTypes}, projectSetSpec, factory, err,
+		)
+	}
+	return nil
+}
+
+// planProjectSet plans the vectorized project set operator on top of r.Root.
+// The arguments of the set-returning functions are projected into extra
+// columns of the input, which are not emitted.
+func (r opResult) planProjectSet(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	args *colexecargs.NewColOperatorArgs,
+	projectSet *execinfrapb.ProjectSetSpec,
+	factory coldata.ColumnFactory,
+) error {
+	if len(projectSet.NumColsPerGen) != len(projectSet.Exprs) {
+		return errors.AssertionFailedf("unexpected number of generated columns")
+	}
+	numInputCols := len(r.ColumnTypes)
+	op, typs := r.Root, r.ColumnTypes
+	var releasables []execreleasable.Releasable
+	srfs := make([]colexec.ProjectSetSRF, len(projectSet.Exprs))
+	for i := range projectSet.Exprs {
+		expr, err := args.ExprHelper.ProcessExpr(projectSet.Exprs[i], flowCtx.EvalCtx, r.ColumnTypes)
+		if err != nil {
+			return err
+		}
+		fn, err := colexec.CheckProjectSetSRF(expr)
+		if err != nil {
+			return err
+		}
+		srfs[i].FuncExpr = fn
+		srfs[i].NumCols = int(projectSet.NumColsPerGen[i])
+		for _, arg := range fn.Exprs {
+			var argIdx int
+			op, argIdx, typs, err = planProjectionOperators(
+				ctx, flowCtx.EvalCtx, arg.(tree.TypedExpr), typs, op,
+				args.StreamingMemAccount, factory, &releasables,
+			)
+			if err != nil {
+				return errors.Wrapf(err, "unable to columnarize project set expression %q", expr)
+			}
+			srfs[i].ArgumentCols = append(srfs[i].ArgumentCols, argIdx)
+		}
+	}
+	projectSetOp := colexec.NewProjectSetOp(
+		getStreamingAllocator(ctx, args), flowCtx.EvalCtx, op, typs, numInputCols,
+		srfs, projectSet.GeneratedColumns, execinfra.GetWorkMemLimit(flowCtx),
+	)
+	r.Root = projectSetOp
+	r.ColumnTypes = append(r.ColumnTypes, projectSet.GeneratedColumns...)
+	r.Releasables = append(r.Releasables, releasables...)
+	r.Releasables = append(r.Releasables, projectSetOp.(execreleasable.Releasable))
+	return nil
+}
+
 // wrapPostProcessSpec plans the given post process spec by wrapping a noop
 // processor with that output spec. This is used to fall back to row execution
 // when encountering unsupported post processing specs. An error is returned
@@ -1747,6 +2093,7 @@ func planFilterExpr(
 		// we put a zero operator.
 		return colexecutils.NewZeroOp(input), nil
 	}
+	maybePushArrayFilterIntoScan(input, expr)
 	op, _, filterColumnTypes, err := planSelectionOperators(
 		ctx, flowCtx.EvalCtx, expr, columnTypes, input, acc, factory, releasables,
 	)
@@ -1765,6 +2112,37 @@ func planFilterExpr(
 	return op, nil
 }
 
+// maybePushArrayFilterIntoScan pushes a conjunct of the filter of the form
+// 'constant = ANY(array_col)' into the input if it is a ColBatchScan, so that
+// the rows that don't satisfy it are skipped when decoding. The whole filter
+// must still be planned on top of the input.
+func maybePushArrayFilterIntoScan(input colexecop.Operator, filter tree.TypedExpr) {
+	if c, ok := input.(*colexecutils.CancelChecker); ok {
+		input = c.Input
+	}
+	scan, ok := colexec.MaybeUnwrapInvariantsChecker(input).(*colfetcher.ColBatchScan)
+	if !ok {
+		return
+	}
+	var push func(expr tree.TypedExpr) bool
+	push = func(expr tree.TypedExpr) bool {
+		switch t := expr.(type) {
+		case *tree.AndExpr:
+			return push(t.TypedLeft()) || push(t.TypedRight())
+		case *tree.ComparisonExpr:
+			if (t.Operator.Symbol != treecmp.Any && t.Operator.Symbol != treecmp.Some) ||
+				t.SubOperator.Symbol != treecmp.EQ {
+				return false
+			}
+			elem, isDatum := t.Left.(tree.Datum)
+			col, isColumn := t.Right.(*tree.IndexedVar)
+			return isDatum && isColumn && scan.AcceptArrayFilter(col.Idx, elem)
+		}
+		return false
+	}
+	push(filter)
+}
+
 // addProjection adds a simple projection on top of op according to projection
 // and returns the updated operator and type schema.
 func addProjection(
@@ -1824,10 +2202,21 @@ func planSelectionOperators(
 		lTyp := ct[leftIdx]
 		if constArg, ok := t.Right.(tree.Datum); ok {
 			switch cmpOp.Symbol {
-			case treecmp.Like, treecmp.NotLike:
-				negate := cmpOp.Symbol == treecmp.NotLike
+			case treecmp.Like, treecmp.NotLike, treecmp.ILike, treecmp.NotILike:
+				negate := cmpOp.Symbol == treecmp.NotLike || cmpOp.Symbol == treecmp.NotILike
+				caseInsensitive := cmpOp.Symbol == treecmp.ILike || cmpOp.Symbol == treecmp.NotILike
 				op, err = colexecsel.GetLikeOperator(
-					evalCtx, leftOp, leftIdx, string(tree.MustBeDString(constArg)), negate,
+					evalCtx, leftOp, leftIdx, string(tree.MustBeDString(constArg)), negate, caseInsensitive,
+				)
+			case treecmp.RegMatch, treecmp.NotRegMatch, treecmp.RegIMatch, treecmp.NotRegIMatch:
+				pattern, ok := constArg.(*tree.DString)
+				if !ok || lTyp.Family() != types.StringFamily {
+					break
+				}
+				negate := cmpOp.Symbol == treecmp.NotRegMatch || cmpOp.Symbol == treecmp.NotRegIMatch
+				caseInsensitive := cmpOp.Symbol == treecmp.RegIMatch || cmpOp.Symbol == treecmp.NotRegIMatch
+				op, err = colexecsel.GetRegexpConstOperator(
+					evalCtx, leftOp, leftIdx, string(*pattern), negate, caseInsensitive,
 				)
 			case treecmp.In, treecmp.NotIn:
 				negate := cmpOp.Symbol == treecmp.NotIn
@@ -1868,6 +2257,17 @@ func planSelectionOperators(
 		if err != nil {
 			return nil, resultIdx, ct, err
 		}
+		switch cmpOp.Symbol {
+		case treecmp.RegMatch, treecmp.NotRegMatch, treecmp.RegIMatch, treecmp.NotRegIMatch:
+			if lTyp.Family() == types.StringFamily && ct[rightIdx].Family() == types.StringFamily {
+				negate := cmpOp.Symbol == treecmp.NotRegMatch || cmpOp.Symbol == treecmp.NotRegIMatch
+				caseInsensitive := cmpOp.Symbol == treecmp.RegIMatch || cmpOp.Symbol == treecmp.NotRegIMatch
+				op = colexecsel.GetRegexpOperator(
+					evalCtx, rightOp, leftIdx, rightIdx, negate, caseInsensitive,
+				)
+				return op, resultIdx, ct, nil
+			}
+		}
 		op, err = colexecsel.GetSelectionOperator(
 			cmpOp, rightOp, ct, leftIdx, rightIdx, evalCtx, t,
 		)
@@ -2331,7 +2731,7 @@ func planProjectionExpr(
 	var hasOptimizedOp bool
 	if isCmpProjOp {
 		switch cmpProjOp.Symbol {
-		case treecmp.Like, treecmp.NotLike, treecmp.In, treecmp.NotIn, treecmp.IsDistinctFrom, treecmp.IsNotDistinctFrom:
+		case treecmp.Like, treecmp.NotLike, treecmp.ILike, treecmp.NotILike, treecmp.In, treecmp.NotIn, treecmp.IsDistinctFrom, treecmp.IsNotDistinctFrom:
 			hasOptimizedOp = true
 		}
 	}
@@ -2398,11 +2798,12 @@ func planProjectionExpr(
 			resultIdx = len(typs)
 			if isCmpProjOp {
 				switch cmpProjOp.Symbol {
-				case treecmp.Like, treecmp.NotLike:
-					negate := cmpProjOp.Symbol == treecmp.NotLike
+				case treecmp.Like, treecmp.NotLike, treecmp.ILike, treecmp.NotILike:
+					negate := cmpProjOp.Symbol == treecmp.NotLike || cmpProjOp.Symbol == treecmp.NotILike
+					caseInsensitive := cmpProjOp.Symbol == treecmp.ILike || cmpProjOp.Symbol == treecmp.NotILike
 					op, err = colexecprojconst.GetLikeProjectionOperator(
 						allocator, evalCtx, input, leftIdx, resultIdx,
-						string(tree.MustBeDString(rConstArg)), negate,
+						string(tree.MustBeDString(rConstArg)), negate, caseInsensitive,
 					)
 				case treecmp.In, treecmp.NotIn:
 					negate := cmpProjOp.Symbol == treecmp.NotIn
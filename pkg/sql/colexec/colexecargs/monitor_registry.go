@@ -96,6 +96,39 @@ func (r *MonitorRegistry) CreateMemAccountForSpillStrategyWithLimit(
 	return &bufferingMemAccount, monitorName
 }
 
+// CreateMemAccountsForSpillStrategyWithLimit is the same as
+// CreateMemAccountForSpillStrategyWithLimit except that it instantiates
+// numAccounts memory accounts, all of which share the limit of a single memory
+// monitor. It is used by the buffering operators that use multiple goroutines
+// since a memory account cannot be used concurrently. Memory monitor name is
+// also returned.
+func (r *MonitorRegistry) CreateMemAccountsForSpillStrategyWithLimit(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	limit int64,
+	opName redact.RedactableString,
+	processorID int32,
+	numAccounts int,
+) ([]*mon.BoundAccount, redact.RedactableString) {
+	if flowCtx.Cfg.TestingKnobs.ForceDiskSpill {
+		if limit != 1 {
+			colexecerror.InternalError(errors.AssertionFailedf(
+				"expected limit of 1 when forcing disk spilling, got %d", limit,
+			))
+		}
+	}
+	monitorName := r.getMemMonitorName(opName, processorID, "limited" /* suffix */)
+	bufferingOpMemMonitor := mon.NewMonitorInheritWithLimit(monitorName, limit, flowCtx.EvalCtx.Mon)
+	bufferingOpMemMonitor.Start(ctx, flowCtx.EvalCtx.Mon, mon.BoundAccount{})
+	r.monitors = append(r.monitors, bufferingOpMemMonitor)
+	oldLen := len(r.accounts)
+	for i := 0; i < numAccounts; i++ {
+		acc := bufferingOpMemMonitor.MakeBoundAccount()
+		r.accounts = append(r.accounts, &acc)
+	}
+	return r.accounts[oldLen:len(r.accounts)], monitorName
+}
+
 // CreateUnlimitedMemAccount instantiates an unlimited memory monitor and a
 // memory account to be used with a buffering disk-backed colexecop.Operator (or
 // in special circumstances in place of a streaming account when the precise
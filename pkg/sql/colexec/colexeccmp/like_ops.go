@@ -13,6 +13,8 @@ package colexeccmp
 import (
 	"bytes"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // LikeOpType is an enum that describes all of the different variants of LIKE
@@ -151,3 +153,116 @@ func GetLikeOperatorType(pattern string, negate bool) (LikeOpType, [][]byte, err
 func isWildcard(c byte) bool {
 	return c == '%' || c == '_'
 }
+
+// The functions below implement the case-insensitive variants of the optimized
+// LIKE patterns (i.e. ILIKE) without allocating. The prefix, suffix, and
+// contains variants compare the strings rune by rune after upper-casing them,
+// the same way as the row-by-row engine does with strings.ToUpper, whereas
+// MatchSkeletonFold uses the simple Unicode case-folding, the same way as the
+// case-insensitive regular expression used for such patterns by the row-by-row
+// engine.
+
+// equalUpper returns whether the runes are equal once upper-cased.
+func equalUpper(a, b rune) bool {
+	return a == b || unicode.ToUpper(a) == unicode.ToUpper(b)
+}
+
+// HasPrefixCaseInsensitive returns whether s begins with prefix ignoring case.
+func HasPrefixCaseInsensitive(s, prefix []byte) bool {
+	for len(prefix) > 0 {
+		if len(s) == 0 {
+			return false
+		}
+		sr, sSize := utf8.DecodeRune(s)
+		pr, pSize := utf8.DecodeRune(prefix)
+		if !equalUpper(sr, pr) {
+			return false
+		}
+		s, prefix = s[sSize:], prefix[pSize:]
+	}
+	return true
+}
+
+// HasSuffixCaseInsensitive returns whether s ends with suffix ignoring case.
+func HasSuffixCaseInsensitive(s, suffix []byte) bool {
+	for len(suffix) > 0 {
+		if len(s) == 0 {
+			return false
+		}
+		sr, sSize := utf8.DecodeLastRune(s)
+		pr, pSize := utf8.DecodeLastRune(suffix)
+		if !equalUpper(sr, pr) {
+			return false
+		}
+		s, suffix = s[:len(s)-sSize], suffix[:len(suffix)-pSize]
+	}
+	return true
+}
+
+// ContainsCaseInsensitive returns whether substr is within s ignoring case.
+func ContainsCaseInsensitive(s, substr []byte) bool {
+	for {
+		if HasPrefixCaseInsensitive(s, substr) {
+			return true
+		}
+		if len(s) == 0 {
+			return false
+		}
+		_, size := utf8.DecodeRune(s)
+		s = s[size:]
+	}
+}
+
+// equalFold returns whether the runes are equal under the simple Unicode
+// case-folding.
+func equalFold(a, b rune) bool {
+	if a == b {
+		return true
+	}
+	for r := unicode.SimpleFold(a); r != a; r = unicode.SimpleFold(r) {
+		if r == b {
+			return true
+		}
+	}
+	return false
+}
+
+// prefixFoldLen returns the length of the prefix of s that is equal to prefix
+// under the simple Unicode case-folding, or -1 if there is no such prefix.
+func prefixFoldLen(s, prefix []byte) int {
+	var n int
+	for len(prefix) > 0 {
+		if len(s) == 0 {
+			return -1
+		}
+		sr, sSize := utf8.DecodeRune(s)
+		pr, pSize := utf8.DecodeRune(prefix)
+		if !equalFold(sr, pr) {
+			return -1
+		}
+		s, prefix = s[sSize:], prefix[pSize:]
+		n += sSize
+	}
+	return n
+}
+
+// MatchSkeletonFold returns whether s matches the "skeleton" pattern (of the
+// form '%word1%word2%...%' where the words come from skeleton) ignoring case.
+// Similar to the case-sensitive variant, we find the first occurrence of each
+// word in the unprocessed part of s and advance s right past it.
+func MatchSkeletonFold(s []byte, skeleton [][]byte) bool {
+	for _, word := range skeleton {
+		for {
+			if n := prefixFoldLen(s, word); n >= 0 {
+				s = s[n:]
+				break
+			}
+			if len(s) == 0 {
+				return false
+			}
+			_, size := utf8.DecodeRune(s)
+			s = s[size:]
+		}
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexeccmp
+
+import (
+	"bytes"
+	"regexp"
+	"regexp/syntax"
+	"unicode/utf8"
+)
+
+// RegexpPrefilter performs cheap byte comparisons against the literals that
+// any string matching a regular expression must contain, so that most of the
+// non-matching strings are rejected without running the regular expression.
+type RegexpPrefilter struct {
+	// prefix and suffix, if non-empty, must start and end the string,
+	// respectively.
+	prefix, suffix []byte
+	// contains are the literals that must appear in the string.
+	contains [][]byte
+	// exact indicates that the comparisons are sufficient to determine a
+	// match, so the regular expression never needs to be run.
+	exact bool
+	// whole indicates that the expression is a single literal anchored at
+	// both ends (stored in prefix), so the string must be equal to it.
+	whole bool
+}
+
+// MakeRegexpPrefilter returns the prefilter of the given regular expression.
+// The literals are extracted from the top-level concatenation of the
+// expression, so an expression like 'foo.*bar' requires the string to contain
+// "foo" and "bar", and '^foo' requires the string to start with "foo".
+// Case-insensitive literals are ignored.
+func MakeRegexpPrefilter(re *regexp.Regexp) RegexpPrefilter {
+	var p RegexpPrefilter
+	parsed, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		// The expression has already been compiled, so this is unexpected,
+		// but we can always fall back to not prefiltering.
+		return p
+	}
+	parsed = parsed.Simplify()
+	subs := []*syntax.Regexp{parsed}
+	if parsed.Op == syntax.OpConcat {
+		subs = parsed.Sub
+	}
+	var anchoredStart, anchoredEnd bool
+	if len(subs) > 0 && subs[0].Op == syntax.OpBeginText {
+		anchoredStart = true
+		subs = subs[1:]
+	}
+	if len(subs) > 0 && subs[len(subs)-1].Op == syntax.OpEndText {
+		anchoredEnd = true
+		subs = subs[:len(subs)-1]
+	}
+	literals := make([][]byte, len(subs))
+	for i, sub := range subs {
+		literals[i] = regexpLiteral(sub)
+	}
+	for i, lit := range literals {
+		switch {
+		case lit == nil:
+		case i == 0 && anchoredStart:
+			p.prefix = lit
+		case i == len(literals)-1 && anchoredEnd:
+			p.suffix = lit
+		default:
+			p.contains = append(p.contains, lit)
+		}
+	}
+	p.exact = len(literals) == 1 && literals[0] != nil
+	p.whole = p.exact && anchoredStart && anchoredEnd
+	return p
+}
+
+// regexpLiteral returns the UTF-8 encoding of the given expression if it is a
+// case-sensitive literal, and nil otherwise.
+func regexpLiteral(re *syntax.Regexp) []byte {
+	if re.Op != syntax.OpLiteral || re.Flags&syntax.FoldCase != 0 {
+		return nil
+	}
+	lit := make([]byte, 0, len(re.Rune))
+	var buf [utf8.UTFMax]byte
+	for _, r := range re.Rune {
+		if r == utf8.RuneError {
+			// The regular expression matches invalid UTF-8 sequences with
+			// utf8.RuneError, so the literal cannot be compared bytewise.
+			return nil
+		}
+		n := utf8.EncodeRune(buf[:], r)
+		lit = append(lit, buf[:n]...)
+	}
+	return lit
+}
+
+// Match returns whether s matches re, the regular expression of the
+// prefilter, only evaluating re if the prefilter can't determine the result.
+func (p *RegexpPrefilter) Match(re *regexp.Regexp, s []byte) bool {
+	if !bytes.HasPrefix(s, p.prefix) || !bytes.HasSuffix(s, p.suffix) {
+		return false
+	}
+	for _, c := range p.contains {
+		if !bytes.Contains(s, c) {
+			return false
+		}
+	}
+	if p.exact {
+		return !p.whole || len(s) == len(p.prefix)
+	}
+	return re.Match(s)
+}
@@ -57,6 +57,9 @@ import (
 // - the right side chain is bufferExportingOperator -> diskBackedOp. The
 //   former will first export all the buffered tuples from inMemoryOp and then
 //   will proceed on emitting from input.
+// - inMemoryOp can also ask the disk spiller to fall back to the right side
+//   chain before it reaches the memory limit by throwing an error created by
+//   colexecop.NewEarlySpillError.
 
 // NewOneInputDiskSpiller returns a new oneInputDiskSpiller. It takes the
 // following arguments:
@@ -198,8 +201,9 @@ func (d *diskSpillerBase) Next() coldata.Batch {
 			batch = d.inMemoryOp.Next()
 		},
 	); err != nil {
-		if sqlerrors.IsOutOfMemoryError(err) &&
-			strings.Contains(err.Error(), d.inMemoryMemMonitorName) {
+		if (sqlerrors.IsOutOfMemoryError(err) &&
+			strings.Contains(err.Error(), d.inMemoryMemMonitorName)) ||
+			colexecop.IsEarlySpillError(err, d.inMemoryMemMonitorName) {
 			d.spilled = true
 			if d.spillingCallbackFn != nil {
 				d.spillingCallbackFn()
@@ -0,0 +1,120 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexechash
+
+import (
+	"context"
+	"math/bits"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// KeyHasher computes the hash values of the key tuples (the values in the
+// key columns) of batches. Unlike when distributing the tuples, the hash
+// values aren't reduced to a number of buckets.
+type KeyHasher struct {
+	hashes        []uint64
+	cancelChecker colexecutils.CancelChecker
+	datumAlloc    tree.DatumAlloc
+}
+
+// Init initializes the KeyHasher.
+func (h *KeyHasher) Init(ctx context.Context) {
+	h.cancelChecker.Init(ctx)
+}
+
+// Hash returns the hash values of the key tuples of the batch such that the
+// i'th hash value corresponds to the i'th tuple according to the selection
+// vector of the batch. The returned slice is only valid until the next call
+// to Hash. Note that the hash values are computed for the tuples with NULL
+// keys too, and the caller is responsible for ignoring them if needed.
+// NOTE: b is assumed to be non-zero batch.
+func (h *KeyHasher) Hash(b coldata.Batch, keyCols []uint32) []uint64 {
+	n := b.Length()
+	if cap(h.hashes) < n {
+		h.hashes = make([]uint64, n)
+	} else {
+		h.hashes = h.hashes[:n]
+	}
+	initHash(h.hashes, n, DefaultInitHashValue)
+	if n > h.datumAlloc.AllocSize {
+		h.datumAlloc.AllocSize = n
+	}
+	for _, i := range keyCols {
+		rehash(h.hashes, b.ColVec(int(i)), n, b.Selection(), h.cancelChecker, &h.datumAlloc)
+	}
+	return h.hashes
+}
+
+// bloomFilterBitsPerKey and bloomFilterNumProbes determine the false positive
+// rate of the BloomFilter, which is about 1% for these values.
+const (
+	bloomFilterBitsPerKey = 10
+	bloomFilterNumProbes  = 7
+)
+
+// BloomFilter is a Bloom filter on the hash values of keys. It never has
+// false negatives, i.e. MayContain always returns true for the hash values
+// that have been inserted.
+type BloomFilter struct {
+	bits []uint64
+	// mask is used to reduce the probes to the number of bits, which is a
+	// power of two.
+	mask uint64
+}
+
+// NewBloomFilter returns a new BloomFilter sized for numKeys keys.
+func NewBloomFilter(numKeys int) *BloomFilter {
+	numBits := uint64(numKeys * bloomFilterBitsPerKey)
+	if numBits < 64 {
+		numBits = 64
+	}
+	// Round up to the next power of two.
+	numBits = 1 << (64 - bits.LeadingZeros64(numBits-1))
+	return &BloomFilter{
+		bits: make([]uint64, numBits/64),
+		mask: numBits - 1,
+	}
+}
+
+// MemoryUsage returns the size of the BloomFilter in bytes.
+func (f *BloomFilter) MemoryUsage() int64 {
+	return int64(len(f.bits)) * 8
+}
+
+// Insert adds the hash value of a key to the BloomFilter.
+func (f *BloomFilter) Insert(hash uint64) {
+	// The probes are derived from two halves of the hash value (see "Less
+	// Hashing, Same Performance: Building a Better Bloom Filter" by Kirsch and
+	// Mitzenmacher).
+	h1, h2 := hash, hash>>32|1
+	for i := 0; i < bloomFilterNumProbes; i++ {
+		bit := h1 & f.mask
+		f.bits[bit/64] |= 1 << (bit % 64)
+		h1 += h2
+	}
+}
+
+// MayContain returns false if the hash value definitely hasn't been inserted
+// into the BloomFilter.
+func (f *BloomFilter) MayContain(hash uint64) bool {
+	h1, h2 := hash, hash>>32|1
+	for i := 0; i < bloomFilterNumProbes; i++ {
+		bit := h1 & f.mask
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+		h1 += h2
+	}
+	return true
+}
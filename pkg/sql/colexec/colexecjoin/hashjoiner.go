@@ -15,6 +15,7 @@ import (
 	"math"
 
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexechash"
 	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecutils"
@@ -24,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
 	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/redact"
 )
 
 // hashJoinerState represents the state of the hash join columnar operator.
@@ -71,6 +73,58 @@ type HashJoinerSpec struct {
 	// rightDistinct indicates whether or not the build table equality column
 	// tuples are distinct. If they are distinct, performance can be optimized.
 	rightDistinct bool
+
+	// EarlySpillBuildRows, if positive, is the number of tuples from the build
+	// table after which the hash joiner stops building the hash table and
+	// throws an error created by colexecop.NewEarlySpillError with
+	// EarlySpillMemMonitorName. It must only be set on the in-memory hash
+	// joiner that is wrapped by a disk spiller, which then falls back to the
+	// disk-backed hash joiner. See EarlySpillBuildRows().
+	EarlySpillBuildRows      int
+	EarlySpillMemMonitorName redact.RedactableString
+}
+
+// MisestimateSpillRatio determines when the vectorized hash joiner falls back
+// to the disk-backed strategy early because the build side is much larger than
+// the optimizer estimated.
+var MisestimateSpillRatio = settings.RegisterFloatSetting(
+	settings.TenantWritable,
+	"sql.distsql.hash_join.misestimate_spill_ratio",
+	"if positive, the vectorized hash joiner spills to disk once its build side "+
+		"has this many times more rows than estimated by the optimizer, even if "+
+		"the memory limit hasn't been reached; 0 disables this behavior",
+	0,
+	settings.NonNegativeFloat,
+)
+
+// earlySpillMinBuildRows is the minimum number of tuples from the build table
+// that the hash joiner buffers before spilling to disk early. It prevents
+// spilling of small build sides with tiny estimates.
+const earlySpillMinBuildRows = 1 << 16
+
+// EarlySpillBuildRows returns the number of tuples from the build table of the
+// hash join after which the in-memory hash joiner should spill to disk early
+// given the optimizer's estimate of the row count of the build side (0 if
+// unknown). It returns 0 if the hash joiner shouldn't spill early.
+//
+// Note that the estimate is for the whole build side while each hash joiner
+// processor only sees its share when the join is distributed, so the build
+// side of a single processor has to exceed the whole estimate.
+func EarlySpillBuildRows(sv *settings.Values, estimatedRowCount uint64) int {
+	ratio := MisestimateSpillRatio.Get(sv)
+	if ratio == 0 || estimatedRowCount == 0 {
+		return 0
+	}
+	limit := ratio * float64(estimatedRowCount)
+	if limit >= math.MaxInt32 {
+		// The estimate is so large that the memory limit will be reached
+		// first anyway.
+		return 0
+	}
+	if limit < earlySpillMinBuildRows {
+		return earlySpillMinBuildRows
+	}
+	return int(limit)
 }
 
 type hashJoinerSourceSpec struct {
@@ -316,7 +370,11 @@ func (hj *hashJoiner) Next() coldata.Batch {
 }
 
 func (hj *hashJoiner) build() {
-	hj.ht.FullBuild(hj.inputTwo)
+	var buildInput colexecop.Operator = hj.inputTwo
+	if hj.spec.EarlySpillBuildRows > 0 {
+		buildInput = &earlySpillChecker{Operator: hj.inputTwo, hj: hj}
+	}
+	hj.ht.FullBuild(buildInput)
 
 	// We might have duplicates in the hash table, so we need to set up
 	// same and visited slices for the prober.
@@ -347,6 +405,27 @@ func (hj *hashJoiner) build() {
 	hj.state = hjProbing
 }
 
+// earlySpillChecker wraps the right input of the hash joiner during the build
+// phase and throws an early spill error once the hash table has buffered more
+// than EarlySpillBuildRows tuples. The error is thrown before the next batch
+// is read from the input, so all tuples read so far have been buffered and
+// will be exported to the disk-backed hash joiner.
+type earlySpillChecker struct {
+	colexecop.Operator
+	hj *hashJoiner
+}
+
+func (c *earlySpillChecker) Next() coldata.Batch {
+	if c.hj.ht.Vals.Length() > c.hj.spec.EarlySpillBuildRows {
+		colexecerror.ExpectedError(colexecop.NewEarlySpillError(
+			c.hj.spec.EarlySpillMemMonitorName, redact.Sprintf(
+				"the build side of the hash join has more than %d rows", c.hj.spec.EarlySpillBuildRows,
+			),
+		))
+	}
+	return c.Operator.Next()
+}
+
 // emitRight populates the output batch to emit tuples from the right side that
 // didn't get a match when matched==false (right/full outer and right anti
 // joins) or did get a match when matched==true (right semi joins).
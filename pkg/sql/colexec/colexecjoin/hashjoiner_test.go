@@ -0,0 +1,74 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexecjoin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexectestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/redact"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashJoinerEarlySpill(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	require.Zero(t, EarlySpillBuildRows(&st.SV, 100 /* estimatedRowCount */))
+	MisestimateSpillRatio.Override(ctx, &st.SV, 10)
+	require.Zero(t, EarlySpillBuildRows(&st.SV, 0 /* estimatedRowCount */))
+	require.Equal(t, earlySpillMinBuildRows, EarlySpillBuildRows(&st.SV, 100 /* estimatedRowCount */))
+	require.Equal(t, 10*earlySpillMinBuildRows, EarlySpillBuildRows(&st.SV, earlySpillMinBuildRows))
+
+	typs := []*types.T{types.Int}
+	const numBuildRows, earlySpillBuildRows = 100, 10
+	var build colexectestutils.Tuples
+	for i := 0; i < numBuildRows; i++ {
+		build = append(build, colexectestutils.Tuple{i})
+	}
+	const monitorName = redact.RedactableString("hash-joiner-limited")
+	spec := MakeHashJoinerSpec(
+		descpb.InnerJoin, []uint32{0}, []uint32{0}, typs, typs, false, /* rightDistinct */
+	)
+	spec.EarlySpillBuildRows = earlySpillBuildRows
+	spec.EarlySpillMemMonitorName = monitorName
+	leftSource := colexectestutils.NewOpTestInput(testAllocator, 1 /* batchSize */, colexectestutils.Tuples{{0}}, typs)
+	rightSource := colexectestutils.NewOpTestInput(testAllocator, 1 /* batchSize */, build, typs)
+	hj := NewHashJoiner(
+		testAllocator, testAllocator, spec, leftSource, rightSource, HashJoinerInitialNumBuckets,
+	).(colexecop.BufferingInMemoryOperator)
+	hj.Init(ctx)
+	err := colexecerror.CatchVectorizedRuntimeError(func() { hj.Next() })
+	require.True(t, colexecop.IsEarlySpillError(err, string(monitorName)))
+	require.False(t, colexecop.IsEarlySpillError(err, "sorter-limited"))
+
+	// All of the tuples buffered by the hash joiner are exported, and the rest
+	// of the build side can still be read from the input.
+	var numExported, numRemaining int
+	for b := hj.ExportBuffered(rightSource); b.Length() > 0; b = hj.ExportBuffered(rightSource) {
+		numExported += b.Length()
+	}
+	require.Equal(t, earlySpillBuildRows+1, numExported)
+	for b := rightSource.Next(); b.Length() > 0; b = rightSource.Next() {
+		numRemaining += b.Length()
+	}
+	require.Equal(t, numBuildRows, numExported+numRemaining)
+}
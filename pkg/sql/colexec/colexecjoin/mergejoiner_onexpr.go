@@ -0,0 +1,230 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexecjoin
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// MergeJoinOnExprSpec describes the input of the operator returned by
+// NewMergeJoinOnExprOp.
+type MergeJoinOnExprSpec struct {
+	// JoinType must be one of LEFT OUTER, LEFT SEMI or LEFT ANTI.
+	JoinType descpb.JoinType
+	// InputTypes are the types of the input columns. The input must contain
+	// the columns of the left input followed by the columns of the right
+	// input.
+	InputTypes []*types.T
+	// NumLeftCols and NumRightCols are the numbers of the columns of the left
+	// and the right inputs, respectively.
+	NumLeftCols, NumRightCols int
+	// RightEqColIdx is the ordinal of one of the right equality columns. The
+	// column is only NULL for the tuples of the left rows that have no
+	// matches.
+	RightEqColIdx int
+	// RowIDIdx is the ordinal of the INT column which uniquely identifies the
+	// left rows.
+	RowIDIdx int
+	// OnExprIdx is the ordinal of the BOOL column containing the result of
+	// the ON expression.
+	OnExprIdx int
+}
+
+// NewMergeJoinOnExprOp returns an operator that applies the ON expression of
+// a non-inner merge join. Its input must be the output of the LEFT OUTER
+// merge join of the same inputs (without the ON expression) with a row
+// identifier column added to the left input and the ON expression evaluated
+// into a separate column. The operator relies on the merge joiner emitting
+// all tuples of a left row contiguously.
+//
+// For the LEFT OUTER join, the tuples for which the ON expression is true are
+// selected, and the left rows for which none of the tuples are selected are
+// emitted once with the right columns set to NULL. For the LEFT SEMI and LEFT
+// ANTI joins, a single tuple is emitted for each left row that does or
+// doesn't have a match, respectively, and the right columns of the output
+// must be projected away.
+func NewMergeJoinOnExprOp(
+	allocator *colmem.Allocator, input colexecop.Operator, spec MergeJoinOnExprSpec,
+) colexecop.Operator {
+	switch spec.JoinType {
+	case descpb.LeftOuterJoin, descpb.LeftSemiJoin, descpb.LeftAntiJoin:
+	default:
+		colexecerror.InternalError(errors.AssertionFailedf(
+			"unexpected join type %s for the merge join ON expression", spec.JoinType,
+		))
+	}
+	return &mergeJoinOnExprOp{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		allocator:      allocator,
+		spec:           spec,
+		saveIdx:        -1,
+	}
+}
+
+type mergeJoinOnExprOp struct {
+	colexecop.OneInputHelper
+	allocator *colmem.Allocator
+	spec      MergeJoinOnExprSpec
+
+	// run describes the left row whose tuples are currently being processed.
+	run struct {
+		started bool
+		rowID   int64
+		matched bool
+	}
+	// saveIdx, if non-negative, is the index of a tuple of the current run
+	// in the last batch, which needs to be saved in scratch.
+	saveIdx int
+	// scratch contains a copy of a tuple of the current run, which is emitted
+	// if the run turns out not to have a match after its tuples in the batch
+	// it started in have already been returned.
+	scratch coldata.Batch
+	// deferred, if set, is a processed batch which must be returned after
+	// scratch.
+	deferred coldata.Batch
+}
+
+var _ colexecop.Operator = &mergeJoinOnExprOp{}
+
+func (o *mergeJoinOnExprOp) Next() coldata.Batch {
+	if o.deferred != nil {
+		batch := o.deferred
+		o.deferred = nil
+		o.saveRun(batch)
+		if batch.Length() > 0 {
+			return batch
+		}
+	}
+	for {
+		batch := o.Input.Next()
+		if batch.Length() == 0 {
+			if o.run.started && !o.run.matched && o.spec.JoinType != descpb.LeftSemiJoin {
+				o.run.started = false
+				return o.emitScratch()
+			}
+			return coldata.ZeroBatch
+		}
+		if o.processBatch(batch) {
+			o.deferred = batch
+			return o.emitScratch()
+		}
+		o.saveRun(batch)
+		if batch.Length() > 0 {
+			return batch
+		}
+	}
+}
+
+// processBatch updates the selection vector of the batch according to the
+// join type. It returns whether a run with no match, which has no tuples in
+// the batch, was finished, in which case scratch must be emitted before the
+// batch.
+func (o *mergeJoinOnExprOp) processBatch(batch coldata.Batch) (emitScratch bool) {
+	n := batch.Length()
+	sel := batch.Selection()
+	if sel == nil {
+		batch.SetSelection(true)
+		sel = batch.Selection()
+		for i := 0; i < n; i++ {
+			sel[i] = i
+		}
+	}
+	sel = sel[:n]
+	rowIDs := batch.ColVec(o.spec.RowIDIdx).Int64()
+	onExprVec := batch.ColVec(o.spec.OnExprIdx)
+	onExprCol, onExprNulls := onExprVec.Bool(), onExprVec.Nulls()
+	rightEqNulls := batch.ColVec(o.spec.RightEqColIdx).Nulls()
+	// runIdx is the index of the first tuple of the current run in the batch,
+	// or -1 if the run has no tuples in the batch.
+	runIdx := -1
+	var idx int
+	for _, i := range sel {
+		if rowID := rowIDs.Get(i); !o.run.started || rowID != o.run.rowID {
+			if o.run.started && !o.run.matched && o.spec.JoinType != descpb.LeftSemiJoin {
+				// The previous run had no match, so we emit one of its tuples.
+				if runIdx >= 0 {
+					o.setRightNulls(batch, runIdx)
+					sel[idx] = runIdx
+					idx++
+				} else {
+					emitScratch = true
+				}
+			}
+			o.run.started, o.run.rowID, o.run.matched = true, rowID, false
+			runIdx = i
+		} else if runIdx < 0 {
+			runIdx = i
+		}
+		if rightEqNulls.NullAt(i) || onExprNulls.NullAt(i) || !onExprCol.Get(i) {
+			continue
+		}
+		switch o.spec.JoinType {
+		case descpb.LeftOuterJoin:
+			sel[idx] = i
+			idx++
+		case descpb.LeftSemiJoin:
+			if !o.run.matched {
+				sel[idx] = i
+				idx++
+			}
+		}
+		o.run.matched = true
+	}
+	if !o.run.matched {
+		o.saveIdx = runIdx
+	}
+	batch.SetLength(idx)
+	return emitScratch
+}
+
+// setRightNulls sets the right columns of the tuple at position i to NULL if
+// the join is LEFT OUTER.
+func (o *mergeJoinOnExprOp) setRightNulls(batch coldata.Batch, i int) {
+	if o.spec.JoinType != descpb.LeftOuterJoin {
+		return
+	}
+	for j := o.spec.NumLeftCols; j < o.spec.NumLeftCols+o.spec.NumRightCols; j++ {
+		batch.ColVec(j).Nulls().SetNull(i)
+	}
+}
+
+// saveRun copies the tuple at saveIdx of the batch into scratch, if needed.
+func (o *mergeJoinOnExprOp) saveRun(batch coldata.Batch) {
+	if o.saveIdx < 0 {
+		return
+	}
+	if o.scratch == nil {
+		o.scratch = o.allocator.NewMemBatchWithFixedCapacity(o.spec.InputTypes, 1 /* capacity */)
+	}
+	o.scratch.ResetInternalBatch()
+	o.allocator.PerformOperation(o.scratch.ColVecs(), func() {
+		for j, vec := range o.scratch.ColVecs() {
+			vec.Copy(coldata.SliceArgs{
+				Src:         batch.ColVec(j),
+				SrcStartIdx: o.saveIdx,
+				SrcEndIdx:   o.saveIdx + 1,
+			})
+		}
+	})
+	o.scratch.SetLength(1)
+	o.saveIdx = -1
+}
+
+func (o *mergeJoinOnExprOp) emitScratch() coldata.Batch {
+	o.setRightNulls(o.scratch, 0 /* i */)
+	return o.scratch
+}
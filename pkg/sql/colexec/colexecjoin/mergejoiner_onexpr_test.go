@@ -0,0 +1,92 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexecjoin
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexectestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+func TestMergeJoinOnExprOp(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// The input is the output of a LEFT OUTER merge join with a single left
+	// column, a single right column (which is the equality column), the row
+	// identifier and the result of the ON expression.
+	input := colexectestutils.Tuples{
+		{1, 10, 0, true},
+		{1, 11, 0, false},
+		{2, 10, 1, false},
+		{2, 11, 1, nil},
+		// The left rows that have no equality matches never match, even if
+		// the ON expression is true.
+		{3, nil, 2, nil},
+		{3, nil, 3, true},
+		{4, 12, 4, false},
+		{4, 13, 4, true},
+		{4, 14, 4, true},
+		{5, 15, 5, false},
+	}
+	inputTypes := []*types.T{types.Int, types.Int, types.Int, types.Bool}
+	for _, tc := range []struct {
+		joinType      descpb.JoinType
+		outputColumns []uint32
+		expected      colexectestutils.Tuples
+	}{
+		{
+			joinType:      descpb.LeftOuterJoin,
+			outputColumns: []uint32{0, 1},
+			expected: colexectestutils.Tuples{
+				{1, 10}, {2, nil}, {3, nil}, {3, nil}, {4, 13}, {4, 14}, {5, nil},
+			},
+		},
+		{
+			joinType:      descpb.LeftSemiJoin,
+			outputColumns: []uint32{0},
+			expected:      colexectestutils.Tuples{{1}, {4}},
+		},
+		{
+			joinType:      descpb.LeftAntiJoin,
+			outputColumns: []uint32{0},
+			expected:      colexectestutils.Tuples{{2}, {3}, {3}, {5}},
+		},
+	} {
+		t.Run(tc.joinType.String(), func(t *testing.T) {
+			// The random nulls injection would break the grouping of the tuples
+			// by the row identifier.
+			colexectestutils.RunTestsWithoutAllNullsInjectionWithErrorHandler(
+				t, testAllocator, []colexectestutils.Tuples{input}, nil /* typs */, tc.expected,
+				colexectestutils.OrderedVerifier,
+				func(inputs []colexecop.Operator) (colexecop.Operator, error) {
+					op := NewMergeJoinOnExprOp(testAllocator, inputs[0], MergeJoinOnExprSpec{
+						JoinType:      tc.joinType,
+						InputTypes:    inputTypes,
+						NumLeftCols:   1,
+						NumRightCols:  1,
+						RightEqColIdx: 1,
+						RowIDIdx:      2,
+						OnExprIdx:     3,
+					})
+					return colexecbase.NewSimpleProjectOp(op, len(inputTypes), tc.outputColumns), nil
+				},
+				colexectestutils.SkipRandomNullsInjection, nil, /* orderedCols */
+			)
+		})
+	}
+}
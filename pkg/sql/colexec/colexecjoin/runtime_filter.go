@@ -0,0 +1,263 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexecjoin
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexechash"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/memsize"
+)
+
+// RuntimeFiltersEnabled is a cluster setting that controls whether the
+// vectorized hash joiners construct Bloom filters on the equality columns of
+// their build side which are applied to the probe side.
+var RuntimeFiltersEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.distsql.vectorize_runtime_filters.enabled",
+	"set to true to drop the tuples of the probe side of hash joins that "+
+		"definitely don't have a match (as early as during the scan) using a "+
+		"Bloom filter constructed from the build side",
+	true,
+)
+
+// runtimeFilterMaxBuildKeys is the maximum number of keys on the build side
+// of the hash join for which the RuntimeFilter is constructed. Larger build
+// sides are unlikely to make the filter selective.
+const runtimeFilterMaxBuildKeys = 1 << 20
+
+// RuntimeFilterSupported returns whether the tuples of the probe side (the
+// left input) of the hash join of the given type can be dropped if they don't
+// have a match on the build side (the right input).
+func RuntimeFilterSupported(joinType descpb.JoinType) bool {
+	switch joinType {
+	case descpb.InnerJoin, descpb.RightOuterJoin, descpb.LeftSemiJoin,
+		descpb.RightSemiJoin, descpb.RightAntiJoin:
+		return true
+	default:
+		return false
+	}
+}
+
+// RuntimeFilter is a Bloom filter on the equality columns of the build side
+// of a hash join which is applied to the probe side in order to drop the
+// tuples that definitely don't have a match as early as possible. The keys of
+// the build side are inserted by the operator returned by NewBuilderOp, and
+// the filter doesn't drop anything until the build side has been fully
+// consumed.
+//
+// Note that the tuples with NULL keys are dropped too since they never match.
+type RuntimeFilter struct {
+	allocator   *colmem.Allocator
+	buildEqCols []uint32
+	probeEqCols []uint32
+	buildHasher colexechash.KeyHasher
+	probeHasher colexechash.KeyHasher
+	// hashes are the hash values of the build keys which are buffered until
+	// the build side has been fully consumed, since the number of the keys
+	// (and, thus, the size of the Bloom filter) isn't known beforehand.
+	hashes []uint64
+	// disabled indicates that the build side has too many keys, so the
+	// filter doesn't drop anything.
+	disabled bool
+	// bloomFilter is set once the build side has been fully consumed.
+	bloomFilter   *colexechash.BloomFilter
+	probeInitDone bool
+}
+
+var _ colexecop.RuntimeFilter = &RuntimeFilter{}
+
+// NewRuntimeFilter returns a new RuntimeFilter for the hash join with the
+// given equality columns of the build and the probe sides.
+func NewRuntimeFilter(
+	allocator *colmem.Allocator, buildEqCols []uint32, probeEqCols []uint32,
+) *RuntimeFilter {
+	return &RuntimeFilter{
+		allocator:   allocator,
+		buildEqCols: buildEqCols,
+		probeEqCols: probeEqCols,
+	}
+}
+
+// NewBuilderOp returns an operator that passes through the batches of the
+// build side of the hash join inserting their keys into the filter.
+func (f *RuntimeFilter) NewBuilderOp(input colexecop.Operator) colexecop.Operator {
+	return &runtimeFilterBuilderOp{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		filter:         f,
+	}
+}
+
+// hasNullKey returns whether any of the key columns of the tuple at position
+// rowIdx of the batch is NULL.
+func hasNullKey(batch coldata.Batch, eqCols []uint32, rowIdx int) bool {
+	for _, colIdx := range eqCols {
+		if nulls := batch.ColVec(int(colIdx)).Nulls(); nulls.MaybeHasNulls() && nulls.NullAt(rowIdx) {
+			return true
+		}
+	}
+	return false
+}
+
+// insert adds the keys of the batch from the build side to the filter. The
+// filter becomes ready once a zero-length batch is inserted.
+func (f *RuntimeFilter) insert(batch coldata.Batch) {
+	if f.disabled || f.bloomFilter != nil {
+		return
+	}
+	n := batch.Length()
+	if n == 0 {
+		f.bloomFilter = colexechash.NewBloomFilter(len(f.hashes))
+		for _, hash := range f.hashes {
+			f.bloomFilter.Insert(hash)
+		}
+		f.allocator.AdjustMemoryUsage(f.bloomFilter.MemoryUsage() - int64(cap(f.hashes))*memsize.Uint64)
+		f.hashes = nil
+		return
+	}
+	if len(f.hashes)+n > runtimeFilterMaxBuildKeys {
+		f.allocator.AdjustMemoryUsage(-int64(cap(f.hashes)) * memsize.Uint64)
+		f.hashes = nil
+		f.disabled = true
+		return
+	}
+	hashes := f.buildHasher.Hash(batch, f.buildEqCols)
+	sel := batch.Selection()
+	oldCap := cap(f.hashes)
+	for i := 0; i < n; i++ {
+		rowIdx := i
+		if sel != nil {
+			rowIdx = sel[i]
+		}
+		if !hasNullKey(batch, f.buildEqCols, rowIdx) {
+			f.hashes = append(f.hashes, hashes[i])
+		}
+	}
+	f.allocator.AdjustMemoryUsage(int64(cap(f.hashes)-oldCap) * memsize.Uint64)
+}
+
+// Filter implements the colexecop.RuntimeFilter interface.
+func (f *RuntimeFilter) Filter(ctx context.Context, batch coldata.Batch) {
+	n := batch.Length()
+	if f.bloomFilter == nil || n == 0 {
+		return
+	}
+	if !f.probeInitDone {
+		f.probeHasher.Init(ctx)
+		f.probeInitDone = true
+	}
+	hashes := f.probeHasher.Hash(batch, f.probeEqCols)
+	sel := batch.Selection()
+	if sel == nil {
+		batch.SetSelection(true)
+		sel = batch.Selection()
+		for i := 0; i < n; i++ {
+			sel[i] = i
+		}
+	}
+	var idx int
+	for i, rowIdx := range sel[:n] {
+		if hasNullKey(batch, f.probeEqCols, rowIdx) || !f.bloomFilter.MayContain(hashes[i]) {
+			continue
+		}
+		sel[idx] = rowIdx
+		idx++
+	}
+	batch.SetLength(idx)
+}
+
+// reset returns the filter to its initial state.
+func (f *RuntimeFilter) reset() {
+	var memUsage int64
+	if f.bloomFilter != nil {
+		memUsage = f.bloomFilter.MemoryUsage()
+	}
+	f.allocator.AdjustMemoryUsage(-memUsage - int64(cap(f.hashes))*memsize.Uint64)
+	f.hashes = nil
+	f.disabled = false
+	f.bloomFilter = nil
+}
+
+// runtimeFilterBuilderOp inserts the keys of the batches of the build side of
+// a hash join into a RuntimeFilter.
+type runtimeFilterBuilderOp struct {
+	colexecop.OneInputHelper
+	colexecop.NonExplainable
+	filter *RuntimeFilter
+}
+
+var _ colexecop.ResettableOperator = &runtimeFilterBuilderOp{}
+
+func (b *runtimeFilterBuilderOp) Init(ctx context.Context) {
+	if !b.InitHelper.Init(ctx) {
+		return
+	}
+	b.Input.Init(b.Ctx)
+	b.filter.buildHasher.Init(b.Ctx)
+}
+
+func (b *runtimeFilterBuilderOp) Next() coldata.Batch {
+	batch := b.Input.Next()
+	b.filter.insert(batch)
+	return batch
+}
+
+// Reset is part of the colexecop.Resetter interface.
+func (b *runtimeFilterBuilderOp) Reset(ctx context.Context) {
+	if r, ok := b.Input.(colexecop.Resetter); ok {
+		r.Reset(ctx)
+	}
+	b.filter.reset()
+}
+
+// NewRuntimeFilterOp returns an operator that applies the filter to the
+// batches of the input. It should only be used if the input isn't a
+// colexecop.RuntimeFilterAcceptor that accepts the filter.
+func NewRuntimeFilterOp(input colexecop.Operator, filter colexecop.RuntimeFilter) colexecop.Operator {
+	return &runtimeFilterOp{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		filter:         filter,
+	}
+}
+
+// runtimeFilterOp applies a colexecop.RuntimeFilter to its input.
+type runtimeFilterOp struct {
+	colexecop.OneInputHelper
+	colexecop.NonExplainable
+	filter colexecop.RuntimeFilter
+}
+
+var _ colexecop.ResettableOperator = &runtimeFilterOp{}
+
+func (p *runtimeFilterOp) Next() coldata.Batch {
+	for {
+		batch := p.Input.Next()
+		if batch.Length() == 0 {
+			return batch
+		}
+		p.filter.Filter(p.Ctx, batch)
+		if batch.Length() > 0 {
+			return batch
+		}
+	}
+}
+
+// Reset is part of the colexecop.Resetter interface.
+func (p *runtimeFilterOp) Reset(ctx context.Context) {
+	if r, ok := p.Input.(colexecop.Resetter); ok {
+		r.Reset(ctx)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexecjoin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexectestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeFilter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	buildTypes := []*types.T{types.String, types.Int}
+	probeTypes := []*types.T{types.Int, types.Int4}
+	// The build side has the even keys and a NULL key.
+	const numBuildKeys, numProbeKeys = 1000, 10000
+	var build colexectestutils.Tuples
+	for i := 0; i < numBuildKeys; i++ {
+		build = append(build, colexectestutils.Tuple{"a", 2 * i})
+	}
+	build = append(build, colexectestutils.Tuple{"b", nil})
+	var probe colexectestutils.Tuples
+	for i := 0; i < numProbeKeys; i++ {
+		probe = append(probe, colexectestutils.Tuple{i, i})
+	}
+	probe = append(probe, colexectestutils.Tuple{-1, nil})
+
+	for _, batchSize := range []int{1, coldata.BatchSize()} {
+		filter := NewRuntimeFilter(testAllocator, []uint32{1} /* buildEqCols */, []uint32{1} /* probeEqCols */)
+		builder := filter.NewBuilderOp(colexectestutils.NewOpTestInput(testAllocator, batchSize, build, buildTypes))
+		builder.Init(ctx)
+		// The filter must not drop anything until the build side has been
+		// fully consumed.
+		for b := builder.Next(); ; b = builder.Next() {
+			probeBatch := colexectestutils.NewOpTestInput(testAllocator, coldata.BatchSize(), probe, probeTypes)
+			probeBatch.Init(ctx)
+			batch := probeBatch.Next()
+			length := batch.Length()
+			filter.Filter(ctx, batch)
+			if b.Length() > 0 {
+				require.Equal(t, length, batch.Length())
+			} else {
+				break
+			}
+		}
+
+		op := NewRuntimeFilterOp(colexectestutils.NewOpTestInput(testAllocator, batchSize, probe, probeTypes), filter)
+		op.Init(ctx)
+		var numMatches, numFalsePositives int
+		for batch := op.Next(); batch.Length() > 0; batch = op.Next() {
+			for _, i := range batch.Selection()[:batch.Length()] {
+				vec := batch.ColVec(1)
+				require.False(t, vec.Nulls().NullAt(i))
+				if key := vec.Int32().Get(i); key%2 == 0 && key < 2*numBuildKeys {
+					numMatches++
+				} else {
+					numFalsePositives++
+				}
+			}
+		}
+		// There must be no false negatives, and the false positive rate is
+		// expected to be about 1%.
+		require.Equal(t, numBuildKeys, numMatches)
+		require.Less(t, numFalsePositives, (numProbeKeys-numBuildKeys)/20)
+	}
+}
@@ -5499,9 +5499,11 @@ func (p projPlusFloat64Float64Op) Next() coldata.Batch {
 
 type projPlusTimestampIntervalOp struct {
 	projOpBase
+	colexecutils.BinaryOverloadHelper
 }
 
 func (p projPlusTimestampIntervalOp) Next() coldata.Batch {
+	_overloadHelper := p.BinaryOverloadHelper
 	batch := p.Input.Next()
 	n := batch.Length()
 	if n == 0 {
@@ -5530,7 +5532,7 @@ func (p projPlusTimestampIntervalOp) Next() coldata.Batch {
 						// null.
 						arg1 := col1.Get(i)
 						arg2 := col2.Get(i)
-						t_res := duration.Add(arg1, arg2)
+						t_res := duration.Add(arg1.In(_overloadHelper.TimestampLocation), arg2)
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5550,7 +5552,7 @@ func (p projPlusTimestampIntervalOp) Next() coldata.Batch {
 						arg1 := col1.Get(i)
 						//gcassert:bce
 						arg2 := col2.Get(i)
-						t_res := duration.Add(arg1, arg2)
+						t_res := duration.Add(arg1.In(_overloadHelper.TimestampLocation), arg2)
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5571,7 +5573,7 @@ func (p projPlusTimestampIntervalOp) Next() coldata.Batch {
 				for _, i := range sel {
 					arg1 := col1.Get(i)
 					arg2 := col2.Get(i)
-					t_res := duration.Add(arg1, arg2)
+					t_res := duration.Add(arg1.In(_overloadHelper.TimestampLocation), arg2)
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5587,7 +5589,7 @@ func (p projPlusTimestampIntervalOp) Next() coldata.Batch {
 					arg1 := col1.Get(i)
 					//gcassert:bce
 					arg2 := col2.Get(i)
-					t_res := duration.Add(arg1, arg2)
+					t_res := duration.Add(arg1.In(_overloadHelper.TimestampLocation), arg2)
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5607,9 +5609,11 @@ func (p projPlusTimestampIntervalOp) Next() coldata.Batch {
 
 type projPlusIntervalTimestampOp struct {
 	projOpBase
+	colexecutils.BinaryOverloadHelper
 }
 
 func (p projPlusIntervalTimestampOp) Next() coldata.Batch {
+	_overloadHelper := p.BinaryOverloadHelper
 	batch := p.Input.Next()
 	n := batch.Length()
 	if n == 0 {
@@ -5638,7 +5642,7 @@ func (p projPlusIntervalTimestampOp) Next() coldata.Batch {
 						// null.
 						arg1 := col1.Get(i)
 						arg2 := col2.Get(i)
-						t_res := duration.Add(arg2, arg1)
+						t_res := duration.Add(arg2.In(_overloadHelper.TimestampLocation), arg1)
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5658,7 +5662,7 @@ func (p projPlusIntervalTimestampOp) Next() coldata.Batch {
 						arg1 := col1.Get(i)
 						//gcassert:bce
 						arg2 := col2.Get(i)
-						t_res := duration.Add(arg2, arg1)
+						t_res := duration.Add(arg2.In(_overloadHelper.TimestampLocation), arg1)
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5679,7 +5683,7 @@ func (p projPlusIntervalTimestampOp) Next() coldata.Batch {
 				for _, i := range sel {
 					arg1 := col1.Get(i)
 					arg2 := col2.Get(i)
-					t_res := duration.Add(arg2, arg1)
+					t_res := duration.Add(arg2.In(_overloadHelper.TimestampLocation), arg1)
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5695,7 +5699,7 @@ func (p projPlusIntervalTimestampOp) Next() coldata.Batch {
 					arg1 := col1.Get(i)
 					//gcassert:bce
 					arg2 := col2.Get(i)
-					t_res := duration.Add(arg2, arg1)
+					t_res := duration.Add(arg2.In(_overloadHelper.TimestampLocation), arg1)
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -9087,9 +9091,11 @@ func (p projMinusTimestampTimestampOp) Next() coldata.Batch {
 
 type projMinusTimestampIntervalOp struct {
 	projOpBase
+	colexecutils.BinaryOverloadHelper
 }
 
 func (p projMinusTimestampIntervalOp) Next() coldata.Batch {
+	_overloadHelper := p.BinaryOverloadHelper
 	batch := p.Input.Next()
 	n := batch.Length()
 	if n == 0 {
@@ -9118,7 +9124,7 @@ func (p projMinusTimestampIntervalOp) Next() coldata.Batch {
 						// null.
 						arg1 := col1.Get(i)
 						arg2 := col2.Get(i)
-						t_res := duration.Add(arg1, arg2.Mul(-1))
+						t_res := duration.Add(arg1.In(_overloadHelper.TimestampLocation), arg2.Mul(-1))
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -9138,7 +9144,7 @@ func (p projMinusTimestampIntervalOp) Next() coldata.Batch {
 						arg1 := col1.Get(i)
 						//gcassert:bce
 						arg2 := col2.Get(i)
-						t_res := duration.Add(arg1, arg2.Mul(-1))
+						t_res := duration.Add(arg1.In(_overloadHelper.TimestampLocation), arg2.Mul(-1))
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -9159,7 +9165,7 @@ func (p projMinusTimestampIntervalOp) Next() coldata.Batch {
 				for _, i := range sel {
 					arg1 := col1.Get(i)
 					arg2 := col2.Get(i)
-					t_res := duration.Add(arg1, arg2.Mul(-1))
+					t_res := duration.Add(arg1.In(_overloadHelper.TimestampLocation), arg2.Mul(-1))
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -9175,7 +9181,7 @@ func (p projMinusTimestampIntervalOp) Next() coldata.Batch {
 					arg1 := col1.Get(i)
 					//gcassert:bce
 					arg2 := col2.Get(i)
-					t_res := duration.Add(arg1, arg2.Mul(-1))
+					t_res := duration.Add(arg1.In(_overloadHelper.TimestampLocation), arg2.Mul(-1))
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -56277,7 +56283,7 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projBitandDatumDatumOp{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -56347,7 +56353,7 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projBitorDatumDatumOp{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -56417,7 +56423,7 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projBitxorDatumDatumOp{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -56481,7 +56487,7 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projPlusInt16DatumOp{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -56512,7 +56518,7 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projPlusInt32DatumOp{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -56544,7 +56550,7 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projPlusInt64DatumOp{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -56573,6 +56579,7 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projPlusTimestampIntervalOp{projOpBase: projOpBase}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -56587,6 +56594,7 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projPlusIntervalTimestampOp{projOpBase: projOpBase}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					case types.IntervalFamily:
@@ -56601,7 +56609,7 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projPlusIntervalDatumOp{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -56616,23 +56624,23 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projPlusDatumIntervalOp{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					case types.IntFamily:
 						switch rightType.Width() {
 						case 16:
 							op := &projPlusDatumInt16Op{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case 32:
 							op := &projPlusDatumInt32Op{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case -1:
 						default:
 							op := &projPlusDatumInt64Op{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -56696,7 +56704,7 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projMinusInt16DatumOp{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -56727,7 +56735,7 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projMinusInt32DatumOp{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -56759,7 +56767,7 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projMinusInt64DatumOp{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -56795,6 +56803,7 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projMinusTimestampIntervalOp{projOpBase: projOpBase}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -56816,7 +56825,7 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projMinusIntervalDatumOp{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -56858,7 +56867,7 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projMinusDatumDatumOp{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					case types.IntervalFamily:
@@ -56866,7 +56875,7 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projMinusDatumIntervalOp{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					case types.BytesFamily:
@@ -56874,23 +56883,23 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projMinusDatumBytesOp{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					case types.IntFamily:
 						switch rightType.Width() {
 						case 16:
 							op := &projMinusDatumInt16Op{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case 32:
 							op := &projMinusDatumInt32Op{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case -1:
 						default:
 							op := &projMinusDatumInt64Op{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -57617,7 +57626,7 @@ func GetProjectionOperator(
 						case -1:
 						default:
 							op := &projConcatDatumDatumOp{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -57686,16 +57695,16 @@ func GetProjectionOperator(
 						switch rightType.Width() {
 						case 16:
 							op := &projLShiftDatumInt16Op{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case 32:
 							op := &projLShiftDatumInt32Op{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case -1:
 						default:
 							op := &projLShiftDatumInt64Op{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -57764,16 +57773,16 @@ func GetProjectionOperator(
 						switch rightType.Width() {
 						case 16:
 							op := &projRShiftDatumInt16Op{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case 32:
 							op := &projRShiftDatumInt32Op{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case -1:
 						default:
 							op := &projRShiftDatumInt64Op{projOpBase: projOpBase}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
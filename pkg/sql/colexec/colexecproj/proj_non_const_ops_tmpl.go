@@ -98,8 +98,9 @@ func (p _OP_NAME) Next() coldata.Batch {
 	// {{if .NeedsBinaryOverloadHelper}}
 	// {{/*
 	//     In order to inline the templated code of the binary overloads
-	//     operating on datums, we need to have a `_overloadHelper` local
-	//     variable of type `colexecutils.BinaryOverloadHelper`.
+	//     operating on datums (or on timestamps in the session time zone), we
+	//     need to have a `_overloadHelper` local variable of type
+	//     `colexecutils.BinaryOverloadHelper`.
 	// */}}
 	_overloadHelper := p.BinaryOverloadHelper
 	// {{end}}
@@ -271,7 +272,7 @@ func GetProjectionOperator(
 						case _RIGHT_TYPE_WIDTH:
 							op := &_OP_NAME{projOpBase: projOpBase}
 							// {{if .NeedsBinaryOverloadHelper}}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							// {{end}}
 							return op, nil
 							// {{end}}
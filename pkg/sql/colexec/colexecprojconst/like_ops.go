@@ -22,7 +22,8 @@ import (
 
 // GetLikeProjectionOperator returns a projection operator which projects the
 // result of the specified LIKE pattern, or NOT LIKE if the negate argument is
-// true. The implementation varies depending on the complexity of the pattern.
+// true. If caseInsensitive is true, then ILIKE (or NOT ILIKE) is projected. The
+// implementation varies depending on the complexity of the pattern.
 func GetLikeProjectionOperator(
 	allocator *colmem.Allocator,
 	ctx *eval.Context,
@@ -31,6 +32,7 @@ func GetLikeProjectionOperator(
 	resultIdx int,
 	pattern string,
 	negate bool,
+	caseInsensitive bool,
 ) (colexecop.Operator, error) {
 	likeOpType, patterns, err := colexeccmp.GetLikeOperatorType(pattern, negate)
 	if err != nil {
@@ -44,6 +46,63 @@ func GetLikeProjectionOperator(
 		colIdx:         colIdx,
 		outputIdx:      resultIdx,
 	}
+	if caseInsensitive {
+		// ILIKE (or NOT ILIKE) uses the case-insensitive variants of the
+		// optimized operators. Note that the patterns that always or never
+		// match as well as the regular expressions are handled below.
+		switch likeOpType {
+		case colexeccmp.LikeConstant:
+			return &projILikeConstantBytesBytesConstOp{
+				projConstOpBase: base,
+				constArg:        pat,
+			}, nil
+		case colexeccmp.LikeConstantNegate:
+			return &projNotILikeConstantBytesBytesConstOp{
+				projConstOpBase: base,
+				constArg:        pat,
+			}, nil
+		case colexeccmp.LikeSuffix:
+			return &projILikeSuffixBytesBytesConstOp{
+				projConstOpBase: base,
+				constArg:        pat,
+			}, nil
+		case colexeccmp.LikeSuffixNegate:
+			return &projNotILikeSuffixBytesBytesConstOp{
+				projConstOpBase: base,
+				constArg:        pat,
+			}, nil
+		case colexeccmp.LikePrefix:
+			return &projILikePrefixBytesBytesConstOp{
+				projConstOpBase: base,
+				constArg:        pat,
+			}, nil
+		case colexeccmp.LikePrefixNegate:
+			return &projNotILikePrefixBytesBytesConstOp{
+				projConstOpBase: base,
+				constArg:        pat,
+			}, nil
+		case colexeccmp.LikeContains:
+			return &projILikeContainsBytesBytesConstOp{
+				projConstOpBase: base,
+				constArg:        pat,
+			}, nil
+		case colexeccmp.LikeContainsNegate:
+			return &projNotILikeContainsBytesBytesConstOp{
+				projConstOpBase: base,
+				constArg:        pat,
+			}, nil
+		case colexeccmp.LikeSkeleton:
+			return &projILikeSkeletonBytesBytesConstOp{
+				projConstOpBase: base,
+				constArg:        patterns,
+			}, nil
+		case colexeccmp.LikeSkeletonNegate:
+			return &projNotILikeSkeletonBytesBytesConstOp{
+				projConstOpBase: base,
+				constArg:        patterns,
+			}, nil
+		}
+	}
 	switch likeOpType {
 	case colexeccmp.LikeConstant:
 		return &projEQBytesBytesConstOp{
@@ -108,7 +167,7 @@ func GetLikeProjectionOperator(
 			constArg:        patterns,
 		}, nil
 	case colexeccmp.LikeRegexp:
-		re, err := eval.ConvertLikeToRegexp(ctx, string(patterns[0]), false, '\\')
+		re, err := eval.ConvertLikeToRegexp(ctx, string(patterns[0]), caseInsensitive, '\\')
 		if err != nil {
 			return nil, err
 		}
@@ -117,7 +176,7 @@ func GetLikeProjectionOperator(
 			constArg:        re,
 		}, nil
 	case colexeccmp.LikeRegexpNegate:
-		re, err := eval.ConvertLikeToRegexp(ctx, string(patterns[0]), false, '\\')
+		re, err := eval.ConvertLikeToRegexp(ctx, string(patterns[0]), caseInsensitive, '\\')
 		if err != nil {
 			return nil, err
 		}
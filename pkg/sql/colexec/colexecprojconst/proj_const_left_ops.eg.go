@@ -5101,10 +5101,12 @@ func (p projPlusFloat64ConstFloat64Op) Next() coldata.Batch {
 
 type projPlusTimestampConstIntervalOp struct {
 	projConstOpBase
+	colexecutils.BinaryOverloadHelper
 	constArg time.Time
 }
 
 func (p projPlusTimestampConstIntervalOp) Next() coldata.Batch {
+	_overloadHelper := p.BinaryOverloadHelper
 	batch := p.Input.Next()
 	n := batch.Length()
 	if n == 0 {
@@ -5132,7 +5134,7 @@ func (p projPlusTimestampConstIntervalOp) Next() coldata.Batch {
 					if !colNulls.NullAt(i) {
 						// We only want to perform the projection operation if the value is not null.
 						arg := col.Get(i)
-						t_res := duration.Add(p.constArg, arg)
+						t_res := duration.Add(p.constArg.In(_overloadHelper.TimestampLocation), arg)
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5148,7 +5150,7 @@ func (p projPlusTimestampConstIntervalOp) Next() coldata.Batch {
 						// We only want to perform the projection operation if the value is not null.
 						//gcassert:bce
 						arg := col.Get(i)
-						t_res := duration.Add(p.constArg, arg)
+						t_res := duration.Add(p.constArg.In(_overloadHelper.TimestampLocation), arg)
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5168,7 +5170,7 @@ func (p projPlusTimestampConstIntervalOp) Next() coldata.Batch {
 				sel = sel[:n]
 				for _, i := range sel {
 					arg := col.Get(i)
-					t_res := duration.Add(p.constArg, arg)
+					t_res := duration.Add(p.constArg.In(_overloadHelper.TimestampLocation), arg)
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5181,7 +5183,7 @@ func (p projPlusTimestampConstIntervalOp) Next() coldata.Batch {
 				for i := 0; i < n; i++ {
 					//gcassert:bce
 					arg := col.Get(i)
-					t_res := duration.Add(p.constArg, arg)
+					t_res := duration.Add(p.constArg.In(_overloadHelper.TimestampLocation), arg)
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5201,10 +5203,12 @@ func (p projPlusTimestampConstIntervalOp) Next() coldata.Batch {
 
 type projPlusIntervalConstTimestampOp struct {
 	projConstOpBase
+	colexecutils.BinaryOverloadHelper
 	constArg duration.Duration
 }
 
 func (p projPlusIntervalConstTimestampOp) Next() coldata.Batch {
+	_overloadHelper := p.BinaryOverloadHelper
 	batch := p.Input.Next()
 	n := batch.Length()
 	if n == 0 {
@@ -5232,7 +5236,7 @@ func (p projPlusIntervalConstTimestampOp) Next() coldata.Batch {
 					if !colNulls.NullAt(i) {
 						// We only want to perform the projection operation if the value is not null.
 						arg := col.Get(i)
-						t_res := duration.Add(arg, p.constArg)
+						t_res := duration.Add(arg.In(_overloadHelper.TimestampLocation), p.constArg)
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5248,7 +5252,7 @@ func (p projPlusIntervalConstTimestampOp) Next() coldata.Batch {
 						// We only want to perform the projection operation if the value is not null.
 						//gcassert:bce
 						arg := col.Get(i)
-						t_res := duration.Add(arg, p.constArg)
+						t_res := duration.Add(arg.In(_overloadHelper.TimestampLocation), p.constArg)
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5268,7 +5272,7 @@ func (p projPlusIntervalConstTimestampOp) Next() coldata.Batch {
 				sel = sel[:n]
 				for _, i := range sel {
 					arg := col.Get(i)
-					t_res := duration.Add(arg, p.constArg)
+					t_res := duration.Add(arg.In(_overloadHelper.TimestampLocation), p.constArg)
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5281,7 +5285,7 @@ func (p projPlusIntervalConstTimestampOp) Next() coldata.Batch {
 				for i := 0; i < n; i++ {
 					//gcassert:bce
 					arg := col.Get(i)
-					t_res := duration.Add(arg, p.constArg)
+					t_res := duration.Add(arg.In(_overloadHelper.TimestampLocation), p.constArg)
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -8465,10 +8469,12 @@ func (p projMinusTimestampConstTimestampOp) Next() coldata.Batch {
 
 type projMinusTimestampConstIntervalOp struct {
 	projConstOpBase
+	colexecutils.BinaryOverloadHelper
 	constArg time.Time
 }
 
 func (p projMinusTimestampConstIntervalOp) Next() coldata.Batch {
+	_overloadHelper := p.BinaryOverloadHelper
 	batch := p.Input.Next()
 	n := batch.Length()
 	if n == 0 {
@@ -8496,7 +8502,7 @@ func (p projMinusTimestampConstIntervalOp) Next() coldata.Batch {
 					if !colNulls.NullAt(i) {
 						// We only want to perform the projection operation if the value is not null.
 						arg := col.Get(i)
-						t_res := duration.Add(p.constArg, arg.Mul(-1))
+						t_res := duration.Add(p.constArg.In(_overloadHelper.TimestampLocation), arg.Mul(-1))
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -8512,7 +8518,7 @@ func (p projMinusTimestampConstIntervalOp) Next() coldata.Batch {
 						// We only want to perform the projection operation if the value is not null.
 						//gcassert:bce
 						arg := col.Get(i)
-						t_res := duration.Add(p.constArg, arg.Mul(-1))
+						t_res := duration.Add(p.constArg.In(_overloadHelper.TimestampLocation), arg.Mul(-1))
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -8532,7 +8538,7 @@ func (p projMinusTimestampConstIntervalOp) Next() coldata.Batch {
 				sel = sel[:n]
 				for _, i := range sel {
 					arg := col.Get(i)
-					t_res := duration.Add(p.constArg, arg.Mul(-1))
+					t_res := duration.Add(p.constArg.In(_overloadHelper.TimestampLocation), arg.Mul(-1))
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -8545,7 +8551,7 @@ func (p projMinusTimestampConstIntervalOp) Next() coldata.Batch {
 				for i := 0; i < n; i++ {
 					//gcassert:bce
 					arg := col.Get(i)
-					t_res := duration.Add(p.constArg, arg.Mul(-1))
+					t_res := duration.Add(p.constArg.In(_overloadHelper.TimestampLocation), arg.Mul(-1))
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -26387,7 +26393,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -26487,7 +26493,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -26587,7 +26593,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -26678,7 +26684,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int16),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -26724,7 +26730,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int32),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -26771,7 +26777,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int64),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -26806,6 +26812,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(time.Time),
 							}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -26823,6 +26830,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(duration.Duration),
 							}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					case types.IntervalFamily:
@@ -26843,7 +26851,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(duration.Duration),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -26861,7 +26869,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					case types.IntFamily:
@@ -26871,14 +26879,14 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case 32:
 							op := &projPlusDatumConstInt32Op{
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case -1:
 						default:
@@ -26886,7 +26894,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -26977,7 +26985,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int16),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -27023,7 +27031,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int32),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -27070,7 +27078,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int64),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -27115,6 +27123,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(time.Time),
 							}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -27142,7 +27151,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(duration.Duration),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -27199,7 +27208,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					case types.IntervalFamily:
@@ -27210,7 +27219,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					case types.BytesFamily:
@@ -27221,7 +27230,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					case types.IntFamily:
@@ -27231,14 +27240,14 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case 32:
 							op := &projMinusDatumConstInt32Op{
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case -1:
 						default:
@@ -27246,7 +27255,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -28279,7 +28288,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -28378,14 +28387,14 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case 32:
 							op := &projLShiftDatumConstInt32Op{
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case -1:
 						default:
@@ -28393,7 +28402,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -28492,14 +28501,14 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case 32:
 							op := &projRShiftDatumConstInt32Op{
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case -1:
 						default:
@@ -28507,7 +28516,7 @@ func GetProjectionLConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
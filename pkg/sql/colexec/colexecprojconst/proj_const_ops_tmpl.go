@@ -99,8 +99,9 @@ func (p _OP_CONST_NAME) Next() coldata.Batch {
 	// {{if .NeedsBinaryOverloadHelper}}
 	// {{/*
 	//     In order to inline the templated code of the binary overloads
-	//     operating on datums, we need to have a `_overloadHelper` local
-	//     variable of type `colexecutils.BinaryOverloadHelper`.
+	//     operating on datums (or on timestamps in the session time zone), we
+	//     need to have a `_overloadHelper` local variable of type
+	//     `colexecutils.BinaryOverloadHelper`.
 	// */}}
 	_overloadHelper := p.BinaryOverloadHelper
 	// {{end}}
@@ -311,7 +312,7 @@ func GetProjection_CONST_SIDEConstOperator(
 								// {{end}}
 							}
 							// {{if .NeedsBinaryOverloadHelper}}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							// {{end}}
 							return op, nil
 							// {{end}}
@@ -5098,10 +5098,12 @@ func (p projPlusFloat64Float64ConstOp) Next() coldata.Batch {
 
 type projPlusTimestampIntervalConstOp struct {
 	projConstOpBase
+	colexecutils.BinaryOverloadHelper
 	constArg duration.Duration
 }
 
 func (p projPlusTimestampIntervalConstOp) Next() coldata.Batch {
+	_overloadHelper := p.BinaryOverloadHelper
 	batch := p.Input.Next()
 	n := batch.Length()
 	if n == 0 {
@@ -5129,7 +5131,7 @@ func (p projPlusTimestampIntervalConstOp) Next() coldata.Batch {
 					if !colNulls.NullAt(i) {
 						// We only want to perform the projection operation if the value is not null.
 						arg := col.Get(i)
-						t_res := duration.Add(arg, p.constArg)
+						t_res := duration.Add(arg.In(_overloadHelper.TimestampLocation), p.constArg)
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5145,7 +5147,7 @@ func (p projPlusTimestampIntervalConstOp) Next() coldata.Batch {
 						// We only want to perform the projection operation if the value is not null.
 						//gcassert:bce
 						arg := col.Get(i)
-						t_res := duration.Add(arg, p.constArg)
+						t_res := duration.Add(arg.In(_overloadHelper.TimestampLocation), p.constArg)
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5165,7 +5167,7 @@ func (p projPlusTimestampIntervalConstOp) Next() coldata.Batch {
 				sel = sel[:n]
 				for _, i := range sel {
 					arg := col.Get(i)
-					t_res := duration.Add(arg, p.constArg)
+					t_res := duration.Add(arg.In(_overloadHelper.TimestampLocation), p.constArg)
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5178,7 +5180,7 @@ func (p projPlusTimestampIntervalConstOp) Next() coldata.Batch {
 				for i := 0; i < n; i++ {
 					//gcassert:bce
 					arg := col.Get(i)
-					t_res := duration.Add(arg, p.constArg)
+					t_res := duration.Add(arg.In(_overloadHelper.TimestampLocation), p.constArg)
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5198,10 +5200,12 @@ func (p projPlusTimestampIntervalConstOp) Next() coldata.Batch {
 
 type projPlusIntervalTimestampConstOp struct {
 	projConstOpBase
+	colexecutils.BinaryOverloadHelper
 	constArg time.Time
 }
 
 func (p projPlusIntervalTimestampConstOp) Next() coldata.Batch {
+	_overloadHelper := p.BinaryOverloadHelper
 	batch := p.Input.Next()
 	n := batch.Length()
 	if n == 0 {
@@ -5229,7 +5233,7 @@ func (p projPlusIntervalTimestampConstOp) Next() coldata.Batch {
 					if !colNulls.NullAt(i) {
 						// We only want to perform the projection operation if the value is not null.
 						arg := col.Get(i)
-						t_res := duration.Add(p.constArg, arg)
+						t_res := duration.Add(p.constArg.In(_overloadHelper.TimestampLocation), arg)
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5245,7 +5249,7 @@ func (p projPlusIntervalTimestampConstOp) Next() coldata.Batch {
 						// We only want to perform the projection operation if the value is not null.
 						//gcassert:bce
 						arg := col.Get(i)
-						t_res := duration.Add(p.constArg, arg)
+						t_res := duration.Add(p.constArg.In(_overloadHelper.TimestampLocation), arg)
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5265,7 +5269,7 @@ func (p projPlusIntervalTimestampConstOp) Next() coldata.Batch {
 				sel = sel[:n]
 				for _, i := range sel {
 					arg := col.Get(i)
-					t_res := duration.Add(p.constArg, arg)
+					t_res := duration.Add(p.constArg.In(_overloadHelper.TimestampLocation), arg)
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5278,7 +5282,7 @@ func (p projPlusIntervalTimestampConstOp) Next() coldata.Batch {
 				for i := 0; i < n; i++ {
 					//gcassert:bce
 					arg := col.Get(i)
-					t_res := duration.Add(p.constArg, arg)
+					t_res := duration.Add(p.constArg.In(_overloadHelper.TimestampLocation), arg)
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -8462,10 +8466,12 @@ func (p projMinusTimestampTimestampConstOp) Next() coldata.Batch {
 
 type projMinusTimestampIntervalConstOp struct {
 	projConstOpBase
+	colexecutils.BinaryOverloadHelper
 	constArg duration.Duration
 }
 
 func (p projMinusTimestampIntervalConstOp) Next() coldata.Batch {
+	_overloadHelper := p.BinaryOverloadHelper
 	batch := p.Input.Next()
 	n := batch.Length()
 	if n == 0 {
@@ -8493,7 +8499,7 @@ func (p projMinusTimestampIntervalConstOp) Next() coldata.Batch {
 					if !colNulls.NullAt(i) {
 						// We only want to perform the projection operation if the value is not null.
 						arg := col.Get(i)
-						t_res := duration.Add(arg, p.constArg.Mul(-1))
+						t_res := duration.Add(arg.In(_overloadHelper.TimestampLocation), p.constArg.Mul(-1))
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -8509,7 +8515,7 @@ func (p projMinusTimestampIntervalConstOp) Next() coldata.Batch {
 						// We only want to perform the projection operation if the value is not null.
 						//gcassert:bce
 						arg := col.Get(i)
-						t_res := duration.Add(arg, p.constArg.Mul(-1))
+						t_res := duration.Add(arg.In(_overloadHelper.TimestampLocation), p.constArg.Mul(-1))
 						rounded_res := t_res.Round(time.Microsecond)
 						if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 							colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -8529,7 +8535,7 @@ func (p projMinusTimestampIntervalConstOp) Next() coldata.Batch {
 				sel = sel[:n]
 				for _, i := range sel {
 					arg := col.Get(i)
-					t_res := duration.Add(arg, p.constArg.Mul(-1))
+					t_res := duration.Add(arg.In(_overloadHelper.TimestampLocation), p.constArg.Mul(-1))
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -8542,7 +8548,7 @@ func (p projMinusTimestampIntervalConstOp) Next() coldata.Batch {
 				for i := 0; i < n; i++ {
 					//gcassert:bce
 					arg := col.Get(i)
-					t_res := duration.Add(arg, p.constArg.Mul(-1))
+					t_res := duration.Add(arg.In(_overloadHelper.TimestampLocation), p.constArg.Mul(-1))
 					rounded_res := t_res.Round(time.Microsecond)
 					if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 						colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -53096,7 +53102,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -53196,7 +53202,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -53296,7 +53302,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -53387,7 +53393,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -53433,7 +53439,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -53480,7 +53486,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -53515,6 +53521,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(duration.Duration),
 							}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -53532,6 +53539,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(time.Time),
 							}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					case types.IntervalFamily:
@@ -53552,7 +53560,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -53570,7 +53578,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(duration.Duration),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					case types.IntFamily:
@@ -53580,14 +53588,14 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int16),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case 32:
 							op := &projPlusDatumInt32ConstOp{
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int32),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case -1:
 						default:
@@ -53595,7 +53603,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int64),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -53686,7 +53694,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -53732,7 +53740,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -53779,7 +53787,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -53824,6 +53832,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(duration.Duration),
 							}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -53851,7 +53860,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -53908,7 +53917,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					case types.IntervalFamily:
@@ -53919,7 +53928,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(duration.Duration),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					case types.BytesFamily:
@@ -53930,7 +53939,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.([]byte),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					case types.IntFamily:
@@ -53940,14 +53949,14 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int16),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case 32:
 							op := &projMinusDatumInt32ConstOp{
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int32),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case -1:
 						default:
@@ -53955,7 +53964,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int64),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -54988,7 +54997,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        constArg,
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -55087,14 +55096,14 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int16),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case 32:
 							op := &projLShiftDatumInt32ConstOp{
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int32),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case -1:
 						default:
@@ -55102,7 +55111,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int64),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
@@ -55201,14 +55210,14 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int16),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case 32:
 							op := &projRShiftDatumInt32ConstOp{
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int32),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						case -1:
 						default:
@@ -55216,7 +55225,7 @@ func GetProjectionRConstOperator(
 								projConstOpBase: projConstOpBase,
 								constArg:        c.(int64),
 							}
-							op.BinaryOverloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx}
+							op.BinaryOverloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp, evalCtx)
 							return op, nil
 						}
 					}
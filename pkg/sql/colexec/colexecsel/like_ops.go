@@ -18,10 +18,16 @@ import (
 )
 
 // GetLikeOperator returns a selection operator which applies the specified LIKE
-// pattern, or NOT LIKE if the negate argument is true. The implementation
-// varies depending on the complexity of the pattern.
+// pattern, or NOT LIKE if the negate argument is true. If caseInsensitive is
+// true, then ILIKE (or NOT ILIKE) is applied. The implementation varies
+// depending on the complexity of the pattern.
 func GetLikeOperator(
-	ctx *eval.Context, input colexecop.Operator, colIdx int, pattern string, negate bool,
+	ctx *eval.Context,
+	input colexecop.Operator,
+	colIdx int,
+	pattern string,
+	negate bool,
+	caseInsensitive bool,
 ) (colexecop.Operator, error) {
 	likeOpType, patterns, err := colexeccmp.GetLikeOperatorType(pattern, negate)
 	if err != nil {
@@ -32,6 +38,63 @@ func GetLikeOperator(
 		OneInputHelper: colexecop.MakeOneInputHelper(input),
 		colIdx:         colIdx,
 	}
+	if caseInsensitive {
+		// ILIKE (or NOT ILIKE) uses the case-insensitive variants of the
+		// optimized operators. Note that the patterns that always or never
+		// match as well as the regular expressions are handled below.
+		switch likeOpType {
+		case colexeccmp.LikeConstant:
+			return &selILikeConstantBytesBytesConstOp{
+				selConstOpBase: base,
+				constArg:       pat,
+			}, nil
+		case colexeccmp.LikeConstantNegate:
+			return &selNotILikeConstantBytesBytesConstOp{
+				selConstOpBase: base,
+				constArg:       pat,
+			}, nil
+		case colexeccmp.LikeSuffix:
+			return &selILikeSuffixBytesBytesConstOp{
+				selConstOpBase: base,
+				constArg:       pat,
+			}, nil
+		case colexeccmp.LikeSuffixNegate:
+			return &selNotILikeSuffixBytesBytesConstOp{
+				selConstOpBase: base,
+				constArg:       pat,
+			}, nil
+		case colexeccmp.LikePrefix:
+			return &selILikePrefixBytesBytesConstOp{
+				selConstOpBase: base,
+				constArg:       pat,
+			}, nil
+		case colexeccmp.LikePrefixNegate:
+			return &selNotILikePrefixBytesBytesConstOp{
+				selConstOpBase: base,
+				constArg:       pat,
+			}, nil
+		case colexeccmp.LikeContains:
+			return &selILikeContainsBytesBytesConstOp{
+				selConstOpBase: base,
+				constArg:       pat,
+			}, nil
+		case colexeccmp.LikeContainsNegate:
+			return &selNotILikeContainsBytesBytesConstOp{
+				selConstOpBase: base,
+				constArg:       pat,
+			}, nil
+		case colexeccmp.LikeSkeleton:
+			return &selILikeSkeletonBytesBytesConstOp{
+				selConstOpBase: base,
+				constArg:       patterns,
+			}, nil
+		case colexeccmp.LikeSkeletonNegate:
+			return &selNotILikeSkeletonBytesBytesConstOp{
+				selConstOpBase: base,
+				constArg:       patterns,
+			}, nil
+		}
+	}
 	switch likeOpType {
 	case colexeccmp.LikeConstant:
 		return &selEQBytesBytesConstOp{
@@ -96,23 +159,17 @@ func GetLikeOperator(
 			constArg:       patterns,
 		}, nil
 	case colexeccmp.LikeRegexp:
-		re, err := eval.ConvertLikeToRegexp(ctx, string(patterns[0]), false, '\\')
+		re, err := eval.ConvertLikeToRegexp(ctx, string(patterns[0]), caseInsensitive, '\\')
 		if err != nil {
 			return nil, err
 		}
-		return &selRegexpBytesBytesConstOp{
-			selConstOpBase: base,
-			constArg:       re,
-		}, nil
+		return newSelRegexpConstOp(base, re, false /* negate */), nil
 	case colexeccmp.LikeRegexpNegate:
-		re, err := eval.ConvertLikeToRegexp(ctx, string(patterns[0]), false, '\\')
+		re, err := eval.ConvertLikeToRegexp(ctx, string(patterns[0]), caseInsensitive, '\\')
 		if err != nil {
 			return nil, err
 		}
-		return &selNotRegexpBytesBytesConstOp{
-			selConstOpBase: base,
-			constArg:       re,
-		}, nil
+		return newSelRegexpConstOp(base, re, true /* negate */), nil
 	default:
 		return nil, errors.AssertionFailedf("unsupported like op type %d", likeOpType)
 	}
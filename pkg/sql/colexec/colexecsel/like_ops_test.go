@@ -31,10 +31,11 @@ func TestLikeOperators(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
 	for _, tc := range []struct {
-		pattern  string
-		negate   bool
-		tups     colexectestutils.Tuples
-		expected colexectestutils.Tuples
+		pattern         string
+		negate          bool
+		caseInsensitive bool
+		tups            colexectestutils.Tuples
+		expected        colexectestutils.Tuples
 	}{
 		{
 			pattern:  "def",
@@ -138,12 +139,85 @@ func TestLikeOperators(t *testing.T) {
 			},
 			expected: colexectestutils.Tuples{{"abc22def333fghi"}, {"a1bc2def333fghi"}, {"a1bc22def33fghi"}},
 		},
+		// The remaining cases use ILIKE.
+		{
+			pattern:         "dEf",
+			caseInsensitive: true,
+			tups:            colexectestutils.Tuples{{"abc"}, {"DeF"}, {"ghi"}, {"defg"}},
+			expected:        colexectestutils.Tuples{{"DeF"}},
+		},
+		{
+			pattern:         "dEf",
+			negate:          true,
+			caseInsensitive: true,
+			tups:            colexectestutils.Tuples{{"abc"}, {"DeF"}, {"ghi"}, {"defg"}},
+			expected:        colexectestutils.Tuples{{"abc"}, {"ghi"}, {"defg"}},
+		},
+		{
+			pattern:         "DE%",
+			caseInsensitive: true,
+			tups:            colexectestutils.Tuples{{"abc"}, {"def"}, {"Deg"}, {"ghi"}},
+			expected:        colexectestutils.Tuples{{"def"}, {"Deg"}},
+		},
+		{
+			pattern:         "DE%",
+			negate:          true,
+			caseInsensitive: true,
+			tups:            colexectestutils.Tuples{{"abc"}, {"def"}, {"Deg"}, {"ghi"}},
+			expected:        colexectestutils.Tuples{{"abc"}, {"ghi"}},
+		},
+		{
+			pattern:         "%éF",
+			caseInsensitive: true,
+			tups:            colexectestutils.Tuples{{"abc"}, {"dÉf"}, {"def"}, {"ghi"}},
+			expected:        colexectestutils.Tuples{{"dÉf"}},
+		},
+		{
+			pattern:         "%éF",
+			negate:          true,
+			caseInsensitive: true,
+			tups:            colexectestutils.Tuples{{"abc"}, {"dÉf"}, {"def"}, {"ghi"}},
+			expected:        colexectestutils.Tuples{{"abc"}, {"def"}, {"ghi"}},
+		},
+		{
+			pattern:         "%E%",
+			caseInsensitive: true,
+			tups:            colexectestutils.Tuples{{"abc"}, {"def"}, {"GHE"}, {"ghi"}},
+			expected:        colexectestutils.Tuples{{"def"}, {"GHE"}},
+		},
+		{
+			pattern:         "%E%",
+			negate:          true,
+			caseInsensitive: true,
+			tups:            colexectestutils.Tuples{{"abc"}, {"def"}, {"GHE"}, {"ghi"}},
+			expected:        colexectestutils.Tuples{{"abc"}, {"ghi"}},
+		},
+		{
+			pattern:         "%A%e%",
+			caseInsensitive: true,
+			tups:            colexectestutils.Tuples{{"abc"}, {"aDEF"}, {"gAhiE"}, {"beb"}, {"ae"}},
+			expected:        colexectestutils.Tuples{{"aDEF"}, {"gAhiE"}, {"ae"}},
+		},
+		{
+			pattern:         "%A%e%",
+			negate:          true,
+			caseInsensitive: true,
+			tups:            colexectestutils.Tuples{{"abc"}, {"aDEF"}, {"gAhiE"}, {"beb"}, {"ae"}},
+			expected:        colexectestutils.Tuples{{"abc"}, {"beb"}},
+		},
+		// The slow regex matcher is used for this pattern.
+		{
+			pattern:         "_E_",
+			caseInsensitive: true,
+			tups:            colexectestutils.Tuples{{"abc"}, {"def"}, {"gEh"}, {"ghi"}},
+			expected:        colexectestutils.Tuples{{"def"}, {"gEh"}},
+		},
 	} {
 		colexectestutils.RunTests(
 			t, testAllocator, []colexectestutils.Tuples{tc.tups}, tc.expected, colexectestutils.OrderedVerifier,
 			func(input []colexecop.Operator) (colexecop.Operator, error) {
 				ctx := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
-				return GetLikeOperator(&ctx, input[0], 0, tc.pattern, tc.negate)
+				return GetLikeOperator(&ctx, input[0], 0, tc.pattern, tc.negate, tc.caseInsensitive)
 			})
 	}
 }
@@ -197,6 +271,7 @@ func BenchmarkLikeOps(b *testing.B) {
 		selConstOpBase: base,
 		constArg:       regexp.MustCompile(pattern),
 	}
+	regexpPrefilterOp := newSelRegexpConstOp(base, regexp.MustCompile(pattern), false /* negate */)
 	skeletonOp := &selSkeletonBytesBytesConstOp{
 		selConstOpBase: base,
 		constArg:       [][]byte{[]byte(prefix), []byte(contains), []byte(suffix)},
@@ -217,6 +292,7 @@ func BenchmarkLikeOps(b *testing.B) {
 		{name: "selSuffixBytesBytesConstOp", op: suffixOp},
 		{name: "selContainsBytesBytesConstOp", op: containsOp},
 		{name: "selRegexpBytesBytesConstOp", op: regexpOp},
+		{name: "selRegexpConstOp", op: regexpPrefilterOp},
 		{name: "selSkeletonBytesBytesConstOp", op: skeletonOp},
 		{name: "selRegexpSkeleton", op: regexpSkeletonOp},
 	}
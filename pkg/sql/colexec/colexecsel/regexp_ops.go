@@ -0,0 +1,192 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexecsel
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexeccmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+)
+
+// GetRegexpConstOperator returns a selection operator which applies the ~
+// operator with the specified pattern, or !~ if the negate argument is true.
+// If caseInsensitive is true, then ~* (or !~*) is applied.
+func GetRegexpConstOperator(
+	ctx *eval.Context,
+	input colexecop.Operator,
+	colIdx int,
+	pattern string,
+	negate bool,
+	caseInsensitive bool,
+) (colexecop.Operator, error) {
+	re, err := eval.ConvertRegexp(ctx, pattern, caseInsensitive)
+	if err != nil {
+		return nil, err
+	}
+	return newSelRegexpConstOp(selConstOpBase{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		colIdx:         colIdx,
+	}, re, negate), nil
+}
+
+func newSelRegexpConstOp(
+	base selConstOpBase, re *regexp.Regexp, negate bool,
+) colexecop.Operator {
+	return &selRegexpConstOp{
+		selConstOpBase: base,
+		re:             re,
+		prefilter:      colexeccmp.MakeRegexpPrefilter(re),
+		negate:         negate,
+	}
+}
+
+// selRegexpConstOp selects the tuples matching a constant regular expression.
+// The literals of the expression are compared against the tuples first, so
+// that the expression is only run for the tuples that might match it.
+type selRegexpConstOp struct {
+	selConstOpBase
+	re        *regexp.Regexp
+	prefilter colexeccmp.RegexpPrefilter
+	negate    bool
+}
+
+var _ colexecop.Operator = &selRegexpConstOp{}
+
+func (p *selRegexpConstOp) Next() coldata.Batch {
+	for {
+		batch := p.Input.Next()
+		n := batch.Length()
+		if n == 0 {
+			return batch
+		}
+
+		vec := batch.ColVec(p.colIdx)
+		col := vec.Bytes()
+		nulls := vec.Nulls()
+		hasNulls := nulls.MaybeHasNulls()
+		sel := batch.Selection()
+		if sel == nil {
+			batch.SetSelection(true)
+			sel = batch.Selection()
+			for i := 0; i < n; i++ {
+				sel[i] = i
+			}
+		}
+		sel = sel[:n]
+		var idx int
+		for _, i := range sel {
+			if hasNulls && nulls.NullAt(i) {
+				continue
+			}
+			if p.prefilter.Match(p.re, col.Get(i)) != p.negate {
+				sel[idx] = i
+				idx++
+			}
+		}
+		if idx > 0 {
+			batch.SetLength(idx)
+			return batch
+		}
+	}
+}
+
+// GetRegexpOperator returns a selection operator which applies the ~ operator
+// (or one of its variants, as for GetRegexpConstOperator) with the patterns
+// in the column at col2Idx.
+func GetRegexpOperator(
+	ctx *eval.Context,
+	input colexecop.Operator,
+	col1Idx int,
+	col2Idx int,
+	negate bool,
+	caseInsensitive bool,
+) colexecop.Operator {
+	return &selRegexpOp{
+		selOpBase: selOpBase{
+			OneInputHelper: colexecop.MakeOneInputHelper(input),
+			col1Idx:        col1Idx,
+			col2Idx:        col2Idx,
+		},
+		evalCtx:         ctx,
+		negate:          negate,
+		caseInsensitive: caseInsensitive,
+	}
+}
+
+// selRegexpOp selects the tuples matching the regular expression of the
+// pattern column. The regular expression and the prefilter of the last
+// pattern are memoized, which avoids compiling (or looking up in the cache)
+// the expression for every tuple when the patterns repeat.
+type selRegexpOp struct {
+	selOpBase
+	evalCtx         *eval.Context
+	negate          bool
+	caseInsensitive bool
+
+	// lastPattern, if lastRe is set, is the pattern of lastRe.
+	lastPattern   []byte
+	lastRe        *regexp.Regexp
+	lastPrefilter colexeccmp.RegexpPrefilter
+}
+
+var _ colexecop.Operator = &selRegexpOp{}
+
+func (p *selRegexpOp) Next() coldata.Batch {
+	for {
+		batch := p.Input.Next()
+		n := batch.Length()
+		if n == 0 {
+			return batch
+		}
+
+		vec1, vec2 := batch.ColVec(p.col1Idx), batch.ColVec(p.col2Idx)
+		col1, col2 := vec1.Bytes(), vec2.Bytes()
+		nulls1, nulls2 := vec1.Nulls(), vec2.Nulls()
+		hasNulls := nulls1.MaybeHasNulls() || nulls2.MaybeHasNulls()
+		sel := batch.Selection()
+		if sel == nil {
+			batch.SetSelection(true)
+			sel = batch.Selection()
+			for i := 0; i < n; i++ {
+				sel[i] = i
+			}
+		}
+		sel = sel[:n]
+		var idx int
+		for _, i := range sel {
+			if hasNulls && (nulls1.NullAt(i) || nulls2.NullAt(i)) {
+				continue
+			}
+			if pattern := col2.Get(i); p.lastRe == nil || !bytes.Equal(pattern, p.lastPattern) {
+				re, err := eval.ConvertRegexp(p.evalCtx, string(pattern), p.caseInsensitive)
+				if err != nil {
+					colexecerror.ExpectedError(err)
+				}
+				p.lastPattern = append(p.lastPattern[:0], pattern...)
+				p.lastRe = re
+				p.lastPrefilter = colexeccmp.MakeRegexpPrefilter(re)
+			}
+			if p.lastPrefilter.Match(p.lastRe, col1.Get(i)) != p.negate {
+				sel[idx] = i
+				idx++
+			}
+		}
+		if idx > 0 {
+			batch.SetLength(idx)
+			return batch
+		}
+	}
+}
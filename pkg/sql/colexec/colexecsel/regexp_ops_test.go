@@ -0,0 +1,112 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexecsel
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexectestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+func TestRegexpOperators(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	tups := colexectestutils.Tuples{
+		{"foobar"}, {"barfoo"}, {"foo"}, {"FOO"}, {"fobaro"}, {"xfooy"}, {nil},
+	}
+	for _, tc := range []struct {
+		pattern         string
+		negate          bool
+		caseInsensitive bool
+		expected        colexectestutils.Tuples
+	}{
+		{
+			pattern:  "foo",
+			expected: colexectestutils.Tuples{{"foobar"}, {"barfoo"}, {"foo"}, {"xfooy"}},
+		},
+		{
+			pattern:  "foo",
+			negate:   true,
+			expected: colexectestutils.Tuples{{"FOO"}, {"fobaro"}},
+		},
+		{
+			pattern:  "^foo",
+			expected: colexectestutils.Tuples{{"foobar"}, {"foo"}},
+		},
+		{
+			pattern:  "foo$",
+			expected: colexectestutils.Tuples{{"barfoo"}, {"foo"}},
+		},
+		{
+			pattern:  "^foo$",
+			expected: colexectestutils.Tuples{{"foo"}},
+		},
+		{
+			pattern:  "^fo.*o$",
+			expected: colexectestutils.Tuples{{"foo"}, {"fobaro"}},
+		},
+		{
+			pattern:  "o(ba|y)",
+			expected: colexectestutils.Tuples{{"foobar"}, {"fobaro"}, {"xfooy"}},
+		},
+		{
+			pattern:         "^foo",
+			caseInsensitive: true,
+			expected:        colexectestutils.Tuples{{"foobar"}, {"foo"}, {"FOO"}},
+		},
+		{
+			pattern:         "^foo",
+			negate:          true,
+			caseInsensitive: true,
+			expected:        colexectestutils.Tuples{{"barfoo"}, {"fobaro"}, {"xfooy"}},
+		},
+	} {
+		colexectestutils.RunTests(
+			t, testAllocator, []colexectestutils.Tuples{tups}, tc.expected, colexectestutils.OrderedVerifier,
+			func(input []colexecop.Operator) (colexecop.Operator, error) {
+				ctx := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+				return GetRegexpConstOperator(&ctx, input[0], 0, tc.pattern, tc.negate, tc.caseInsensitive)
+			})
+	}
+
+	// The patterns are the second column, and the same pattern repeats in
+	// consecutive tuples to exercise the memoization.
+	patternTups := colexectestutils.Tuples{
+		{"foobar", "^foo"},
+		{"barfoo", "^foo"},
+		{"barfoo", "foo$"},
+		{"FOO", "(?i)foo"},
+		{"FOO", "foo"},
+		{nil, "foo"},
+		{"foo", nil},
+	}
+	colexectestutils.RunTests(
+		t, testAllocator, []colexectestutils.Tuples{patternTups},
+		colexectestutils.Tuples{{"foobar", "^foo"}, {"barfoo", "foo$"}, {"FOO", "(?i)foo"}},
+		colexectestutils.OrderedVerifier,
+		func(input []colexecop.Operator) (colexecop.Operator, error) {
+			ctx := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+			return GetRegexpOperator(&ctx, input[0], 0, 1, false /* negate */, false /* caseInsensitive */), nil
+		})
+	colexectestutils.RunTests(
+		t, testAllocator, []colexectestutils.Tuples{patternTups},
+		colexectestutils.Tuples{{"barfoo", "^foo"}, {"FOO", "foo"}},
+		colexectestutils.OrderedVerifier,
+		func(input []colexecop.Operator) (colexecop.Operator, error) {
+			ctx := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+			return GetRegexpOperator(&ctx, input[0], 0, 1, true /* negate */, false /* caseInsensitive */), nil
+		})
+}
@@ -11,12 +11,15 @@
 package colexecutils
 
 import (
+	"time"
+
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 )
 
 // BinaryOverloadHelper is a utility struct used for templates of the binary
-// overloads that fall back to the row-based tree.Datum computation.
+// overloads that fall back to the row-based tree.Datum computation or that
+// depend on the session time zone.
 //
 // In order for the templates to see it correctly, a local variable named
 // `_overloadHelper` of this type must be declared before the inlined
@@ -24,4 +27,22 @@ import (
 type BinaryOverloadHelper struct {
 	BinOp   tree.BinaryEvalOp
 	EvalCtx *eval.Context
+	// TimestampLocation is the location in which the timestamp ± interval
+	// overloads perform the computation. It is the session time zone for
+	// TIMESTAMPTZ values, and UTC for TIMESTAMP values, which matches the
+	// row-based computation.
+	TimestampLocation *time.Location
+}
+
+// MakeBinaryOverloadHelper returns a new BinaryOverloadHelper for the given
+// binary operator.
+func MakeBinaryOverloadHelper(
+	binOp tree.BinaryEvalOp, evalCtx *eval.Context,
+) BinaryOverloadHelper {
+	h := BinaryOverloadHelper{BinOp: binOp, EvalCtx: evalCtx, TimestampLocation: time.UTC}
+	switch binOp.(type) {
+	case *tree.PlusTimestampTZIntervalOp, *tree.MinusTimestampTZIntervalOp, *tree.PlusIntervalTimestampTZOp:
+		h.TimestampLocation = evalCtx.GetLocation()
+	}
+	return h
 }
@@ -132,6 +132,9 @@ func newRangeOffsetHandler(
 						op := &rangeHandlerOffsetPrecedingStartAscTimestamp{
 							offset: decodeOffset(datumAlloc, ordColType, bound.TypedOffset).(duration.Duration),
 						}
+						_, binOp, _ := eval.WindowFrameRangeOps{}.LookupImpl(
+							ordColType, getOffsetType(ordColType))
+						op.overloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp.EvalOp, evalCtx)
 						return op
 					}
 				case types.TimeTZFamily, types.TimeFamily:
@@ -143,7 +146,7 @@ func newRangeOffsetHandler(
 						}
 						_, binOp, _ := eval.WindowFrameRangeOps{}.LookupImpl(
 							ordColType, getOffsetType(ordColType))
-						op.overloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp.EvalOp, EvalCtx: evalCtx}
+						op.overloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp.EvalOp, evalCtx)
 						return op
 					}
 				}
@@ -211,6 +214,9 @@ func newRangeOffsetHandler(
 						op := &rangeHandlerOffsetPrecedingStartDescTimestamp{
 							offset: decodeOffset(datumAlloc, ordColType, bound.TypedOffset).(duration.Duration),
 						}
+						binOp, _, _ := eval.WindowFrameRangeOps{}.LookupImpl(
+							ordColType, getOffsetType(ordColType))
+						op.overloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp.EvalOp, evalCtx)
 						return op
 					}
 				case types.TimeTZFamily, types.TimeFamily:
@@ -222,7 +228,7 @@ func newRangeOffsetHandler(
 						}
 						binOp, _, _ := eval.WindowFrameRangeOps{}.LookupImpl(
 							ordColType, getOffsetType(ordColType))
-						op.overloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp.EvalOp, EvalCtx: evalCtx}
+						op.overloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp.EvalOp, evalCtx)
 						return op
 					}
 				}
@@ -293,6 +299,9 @@ func newRangeOffsetHandler(
 						op := &rangeHandlerOffsetPrecedingEndAscTimestamp{
 							offset: decodeOffset(datumAlloc, ordColType, bound.TypedOffset).(duration.Duration),
 						}
+						_, binOp, _ := eval.WindowFrameRangeOps{}.LookupImpl(
+							ordColType, getOffsetType(ordColType))
+						op.overloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp.EvalOp, evalCtx)
 						return op
 					}
 				case types.TimeTZFamily, types.TimeFamily:
@@ -304,7 +313,7 @@ func newRangeOffsetHandler(
 						}
 						_, binOp, _ := eval.WindowFrameRangeOps{}.LookupImpl(
 							ordColType, getOffsetType(ordColType))
-						op.overloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp.EvalOp, EvalCtx: evalCtx}
+						op.overloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp.EvalOp, evalCtx)
 						return op
 					}
 				}
@@ -372,6 +381,9 @@ func newRangeOffsetHandler(
 						op := &rangeHandlerOffsetPrecedingEndDescTimestamp{
 							offset: decodeOffset(datumAlloc, ordColType, bound.TypedOffset).(duration.Duration),
 						}
+						binOp, _, _ := eval.WindowFrameRangeOps{}.LookupImpl(
+							ordColType, getOffsetType(ordColType))
+						op.overloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp.EvalOp, evalCtx)
 						return op
 					}
 				case types.TimeTZFamily, types.TimeFamily:
@@ -383,7 +395,7 @@ func newRangeOffsetHandler(
 						}
 						binOp, _, _ := eval.WindowFrameRangeOps{}.LookupImpl(
 							ordColType, getOffsetType(ordColType))
-						op.overloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp.EvalOp, EvalCtx: evalCtx}
+						op.overloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp.EvalOp, evalCtx)
 						return op
 					}
 				}
@@ -457,6 +469,9 @@ func newRangeOffsetHandler(
 						op := &rangeHandlerOffsetFollowingStartAscTimestamp{
 							offset: decodeOffset(datumAlloc, ordColType, bound.TypedOffset).(duration.Duration),
 						}
+						binOp, _, _ := eval.WindowFrameRangeOps{}.LookupImpl(
+							ordColType, getOffsetType(ordColType))
+						op.overloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp.EvalOp, evalCtx)
 						return op
 					}
 				case types.TimeTZFamily, types.TimeFamily:
@@ -468,7 +483,7 @@ func newRangeOffsetHandler(
 						}
 						binOp, _, _ := eval.WindowFrameRangeOps{}.LookupImpl(
 							ordColType, getOffsetType(ordColType))
-						op.overloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp.EvalOp, EvalCtx: evalCtx}
+						op.overloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp.EvalOp, evalCtx)
 						return op
 					}
 				}
@@ -536,6 +551,9 @@ func newRangeOffsetHandler(
 						op := &rangeHandlerOffsetFollowingStartDescTimestamp{
 							offset: decodeOffset(datumAlloc, ordColType, bound.TypedOffset).(duration.Duration),
 						}
+						_, binOp, _ := eval.WindowFrameRangeOps{}.LookupImpl(
+							ordColType, getOffsetType(ordColType))
+						op.overloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp.EvalOp, evalCtx)
 						return op
 					}
 				case types.TimeTZFamily, types.TimeFamily:
@@ -547,7 +565,7 @@ func newRangeOffsetHandler(
 						}
 						_, binOp, _ := eval.WindowFrameRangeOps{}.LookupImpl(
 							ordColType, getOffsetType(ordColType))
-						op.overloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp.EvalOp, EvalCtx: evalCtx}
+						op.overloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp.EvalOp, evalCtx)
 						return op
 					}
 				}
@@ -618,6 +636,9 @@ func newRangeOffsetHandler(
 						op := &rangeHandlerOffsetFollowingEndAscTimestamp{
 							offset: decodeOffset(datumAlloc, ordColType, bound.TypedOffset).(duration.Duration),
 						}
+						binOp, _, _ := eval.WindowFrameRangeOps{}.LookupImpl(
+							ordColType, getOffsetType(ordColType))
+						op.overloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp.EvalOp, evalCtx)
 						return op
 					}
 				case types.TimeTZFamily, types.TimeFamily:
@@ -629,7 +650,7 @@ func newRangeOffsetHandler(
 						}
 						binOp, _, _ := eval.WindowFrameRangeOps{}.LookupImpl(
 							ordColType, getOffsetType(ordColType))
-						op.overloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp.EvalOp, EvalCtx: evalCtx}
+						op.overloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp.EvalOp, evalCtx)
 						return op
 					}
 				}
@@ -697,6 +718,9 @@ func newRangeOffsetHandler(
 						op := &rangeHandlerOffsetFollowingEndDescTimestamp{
 							offset: decodeOffset(datumAlloc, ordColType, bound.TypedOffset).(duration.Duration),
 						}
+						_, binOp, _ := eval.WindowFrameRangeOps{}.LookupImpl(
+							ordColType, getOffsetType(ordColType))
+						op.overloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp.EvalOp, evalCtx)
 						return op
 					}
 				case types.TimeTZFamily, types.TimeFamily:
@@ -708,7 +732,7 @@ func newRangeOffsetHandler(
 						}
 						_, binOp, _ := eval.WindowFrameRangeOps{}.LookupImpl(
 							ordColType, getOffsetType(ordColType))
-						op.overloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp.EvalOp, EvalCtx: evalCtx}
+						op.overloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp.EvalOp, evalCtx)
 						return op
 					}
 				}
@@ -1727,7 +1751,8 @@ func (h *rangeHandlerOffsetPrecedingStartAscDate) close() {
 // the start or end bound for each row when in RANGE mode with an offset.
 type rangeHandlerOffsetPrecedingStartAscTimestamp struct {
 	rangeOffsetHandlerBase
-	offset duration.Duration
+	overloadHelper colexecutils.BinaryOverloadHelper
+	offset         duration.Duration
 }
 
 var _ rangeOffsetHandler = &rangeHandlerOffsetPrecedingStartAscTimestamp{}
@@ -1751,6 +1776,7 @@ var _ rangeOffsetHandler = &rangeHandlerOffsetPrecedingStartAscTimestamp{}
 // the partition, whichever comes first. In this case, the returned index would
 // be '4' to indicate that the end index is the end of the partition.
 func (h *rangeHandlerOffsetPrecedingStartAscTimestamp) getIdx(ctx context.Context, currRow, lastIdx int) (idx int) {
+	_overloadHelper := h.overloadHelper
 
 	if lastIdx >= h.storedCols.Length() {
 		return lastIdx
@@ -1778,7 +1804,7 @@ func (h *rangeHandlerOffsetPrecedingStartAscTimestamp) getIdx(ctx context.Contex
 	)
 	col := vec.Timestamp()
 	currRowVal := col.Get(vecIdx)
-	t_res := duration.Add(currRowVal, h.offset.Mul(-1))
+	t_res := duration.Add(currRowVal.In(_overloadHelper.TimestampLocation), h.offset.Mul(-1))
 	rounded_res := t_res.Round(time.Microsecond)
 	if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 		colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -2807,7 +2833,8 @@ func (h *rangeHandlerOffsetPrecedingStartDescDate) close() {
 // the start or end bound for each row when in RANGE mode with an offset.
 type rangeHandlerOffsetPrecedingStartDescTimestamp struct {
 	rangeOffsetHandlerBase
-	offset duration.Duration
+	overloadHelper colexecutils.BinaryOverloadHelper
+	offset         duration.Duration
 }
 
 var _ rangeOffsetHandler = &rangeHandlerOffsetPrecedingStartDescTimestamp{}
@@ -2831,6 +2858,7 @@ var _ rangeOffsetHandler = &rangeHandlerOffsetPrecedingStartDescTimestamp{}
 // the partition, whichever comes first. In this case, the returned index would
 // be '4' to indicate that the end index is the end of the partition.
 func (h *rangeHandlerOffsetPrecedingStartDescTimestamp) getIdx(ctx context.Context, currRow, lastIdx int) (idx int) {
+	_overloadHelper := h.overloadHelper
 
 	if lastIdx >= h.storedCols.Length() {
 		return lastIdx
@@ -2858,7 +2886,7 @@ func (h *rangeHandlerOffsetPrecedingStartDescTimestamp) getIdx(ctx context.Conte
 	)
 	col := vec.Timestamp()
 	currRowVal := col.Get(vecIdx)
-	t_res := duration.Add(currRowVal, h.offset)
+	t_res := duration.Add(currRowVal.In(_overloadHelper.TimestampLocation), h.offset)
 	rounded_res := t_res.Round(time.Microsecond)
 	if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 		colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -4141,7 +4169,8 @@ func (h *rangeHandlerOffsetPrecedingEndAscDate) close() {
 // the start or end bound for each row when in RANGE mode with an offset.
 type rangeHandlerOffsetPrecedingEndAscTimestamp struct {
 	rangeOffsetHandlerBase
-	offset duration.Duration
+	overloadHelper colexecutils.BinaryOverloadHelper
+	offset         duration.Duration
 }
 
 var _ rangeOffsetHandler = &rangeHandlerOffsetPrecedingEndAscTimestamp{}
@@ -4165,6 +4194,7 @@ var _ rangeOffsetHandler = &rangeHandlerOffsetPrecedingEndAscTimestamp{}
 // the partition, whichever comes first. In this case, the returned index would
 // be '4' to indicate that the end index is the end of the partition.
 func (h *rangeHandlerOffsetPrecedingEndAscTimestamp) getIdx(ctx context.Context, currRow, lastIdx int) (idx int) {
+	_overloadHelper := h.overloadHelper
 
 	if lastIdx >= h.storedCols.Length() {
 		return lastIdx
@@ -4209,7 +4239,7 @@ func (h *rangeHandlerOffsetPrecedingEndAscTimestamp) getIdx(ctx context.Context,
 	)
 	col := vec.Timestamp()
 	currRowVal := col.Get(vecIdx)
-	t_res := duration.Add(currRowVal, h.offset.Mul(-1))
+	t_res := duration.Add(currRowVal.In(_overloadHelper.TimestampLocation), h.offset.Mul(-1))
 	rounded_res := t_res.Round(time.Microsecond)
 	if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 		colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -5374,7 +5404,8 @@ func (h *rangeHandlerOffsetPrecedingEndDescDate) close() {
 // the start or end bound for each row when in RANGE mode with an offset.
 type rangeHandlerOffsetPrecedingEndDescTimestamp struct {
 	rangeOffsetHandlerBase
-	offset duration.Duration
+	overloadHelper colexecutils.BinaryOverloadHelper
+	offset         duration.Duration
 }
 
 var _ rangeOffsetHandler = &rangeHandlerOffsetPrecedingEndDescTimestamp{}
@@ -5398,6 +5429,7 @@ var _ rangeOffsetHandler = &rangeHandlerOffsetPrecedingEndDescTimestamp{}
 // the partition, whichever comes first. In this case, the returned index would
 // be '4' to indicate that the end index is the end of the partition.
 func (h *rangeHandlerOffsetPrecedingEndDescTimestamp) getIdx(ctx context.Context, currRow, lastIdx int) (idx int) {
+	_overloadHelper := h.overloadHelper
 
 	if lastIdx >= h.storedCols.Length() {
 		return lastIdx
@@ -5442,7 +5474,7 @@ func (h *rangeHandlerOffsetPrecedingEndDescTimestamp) getIdx(ctx context.Context
 	)
 	col := vec.Timestamp()
 	currRowVal := col.Get(vecIdx)
-	t_res := duration.Add(currRowVal, h.offset)
+	t_res := duration.Add(currRowVal.In(_overloadHelper.TimestampLocation), h.offset)
 	rounded_res := t_res.Round(time.Microsecond)
 	if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 		colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -6623,7 +6655,8 @@ func (h *rangeHandlerOffsetFollowingStartAscDate) close() {
 // the start or end bound for each row when in RANGE mode with an offset.
 type rangeHandlerOffsetFollowingStartAscTimestamp struct {
 	rangeOffsetHandlerBase
-	offset duration.Duration
+	overloadHelper colexecutils.BinaryOverloadHelper
+	offset         duration.Duration
 }
 
 var _ rangeOffsetHandler = &rangeHandlerOffsetFollowingStartAscTimestamp{}
@@ -6647,6 +6680,7 @@ var _ rangeOffsetHandler = &rangeHandlerOffsetFollowingStartAscTimestamp{}
 // the partition, whichever comes first. In this case, the returned index would
 // be '4' to indicate that the end index is the end of the partition.
 func (h *rangeHandlerOffsetFollowingStartAscTimestamp) getIdx(ctx context.Context, currRow, lastIdx int) (idx int) {
+	_overloadHelper := h.overloadHelper
 
 	if lastIdx >= h.storedCols.Length() {
 		return lastIdx
@@ -6674,7 +6708,7 @@ func (h *rangeHandlerOffsetFollowingStartAscTimestamp) getIdx(ctx context.Contex
 	)
 	col := vec.Timestamp()
 	currRowVal := col.Get(vecIdx)
-	t_res := duration.Add(currRowVal, h.offset)
+	t_res := duration.Add(currRowVal.In(_overloadHelper.TimestampLocation), h.offset)
 	rounded_res := t_res.Round(time.Microsecond)
 	if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 		colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -7703,7 +7737,8 @@ func (h *rangeHandlerOffsetFollowingStartDescDate) close() {
 // the start or end bound for each row when in RANGE mode with an offset.
 type rangeHandlerOffsetFollowingStartDescTimestamp struct {
 	rangeOffsetHandlerBase
-	offset duration.Duration
+	overloadHelper colexecutils.BinaryOverloadHelper
+	offset         duration.Duration
 }
 
 var _ rangeOffsetHandler = &rangeHandlerOffsetFollowingStartDescTimestamp{}
@@ -7727,6 +7762,7 @@ var _ rangeOffsetHandler = &rangeHandlerOffsetFollowingStartDescTimestamp{}
 // the partition, whichever comes first. In this case, the returned index would
 // be '4' to indicate that the end index is the end of the partition.
 func (h *rangeHandlerOffsetFollowingStartDescTimestamp) getIdx(ctx context.Context, currRow, lastIdx int) (idx int) {
+	_overloadHelper := h.overloadHelper
 
 	if lastIdx >= h.storedCols.Length() {
 		return lastIdx
@@ -7754,7 +7790,7 @@ func (h *rangeHandlerOffsetFollowingStartDescTimestamp) getIdx(ctx context.Conte
 	)
 	col := vec.Timestamp()
 	currRowVal := col.Get(vecIdx)
-	t_res := duration.Add(currRowVal, h.offset.Mul(-1))
+	t_res := duration.Add(currRowVal.In(_overloadHelper.TimestampLocation), h.offset.Mul(-1))
 	rounded_res := t_res.Round(time.Microsecond)
 	if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 		colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -9037,7 +9073,8 @@ func (h *rangeHandlerOffsetFollowingEndAscDate) close() {
 // the start or end bound for each row when in RANGE mode with an offset.
 type rangeHandlerOffsetFollowingEndAscTimestamp struct {
 	rangeOffsetHandlerBase
-	offset duration.Duration
+	overloadHelper colexecutils.BinaryOverloadHelper
+	offset         duration.Duration
 }
 
 var _ rangeOffsetHandler = &rangeHandlerOffsetFollowingEndAscTimestamp{}
@@ -9061,6 +9098,7 @@ var _ rangeOffsetHandler = &rangeHandlerOffsetFollowingEndAscTimestamp{}
 // the partition, whichever comes first. In this case, the returned index would
 // be '4' to indicate that the end index is the end of the partition.
 func (h *rangeHandlerOffsetFollowingEndAscTimestamp) getIdx(ctx context.Context, currRow, lastIdx int) (idx int) {
+	_overloadHelper := h.overloadHelper
 
 	if lastIdx >= h.storedCols.Length() {
 		return lastIdx
@@ -9105,7 +9143,7 @@ func (h *rangeHandlerOffsetFollowingEndAscTimestamp) getIdx(ctx context.Context,
 	)
 	col := vec.Timestamp()
 	currRowVal := col.Get(vecIdx)
-	t_res := duration.Add(currRowVal, h.offset)
+	t_res := duration.Add(currRowVal.In(_overloadHelper.TimestampLocation), h.offset)
 	rounded_res := t_res.Round(time.Microsecond)
 	if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 		colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
@@ -10270,7 +10308,8 @@ func (h *rangeHandlerOffsetFollowingEndDescDate) close() {
 // the start or end bound for each row when in RANGE mode with an offset.
 type rangeHandlerOffsetFollowingEndDescTimestamp struct {
 	rangeOffsetHandlerBase
-	offset duration.Duration
+	overloadHelper colexecutils.BinaryOverloadHelper
+	offset         duration.Duration
 }
 
 var _ rangeOffsetHandler = &rangeHandlerOffsetFollowingEndDescTimestamp{}
@@ -10294,6 +10333,7 @@ var _ rangeOffsetHandler = &rangeHandlerOffsetFollowingEndDescTimestamp{}
 // the partition, whichever comes first. In this case, the returned index would
 // be '4' to indicate that the end index is the end of the partition.
 func (h *rangeHandlerOffsetFollowingEndDescTimestamp) getIdx(ctx context.Context, currRow, lastIdx int) (idx int) {
+	_overloadHelper := h.overloadHelper
 
 	if lastIdx >= h.storedCols.Length() {
 		return lastIdx
@@ -10338,7 +10378,7 @@ func (h *rangeHandlerOffsetFollowingEndDescTimestamp) getIdx(ctx context.Context
 	)
 	col := vec.Timestamp()
 	currRowVal := col.Get(vecIdx)
-	t_res := duration.Add(currRowVal, h.offset.Mul(-1))
+	t_res := duration.Add(currRowVal.In(_overloadHelper.TimestampLocation), h.offset.Mul(-1))
 	rounded_res := t_res.Round(time.Microsecond)
 	if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSupportedTime) {
 		colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
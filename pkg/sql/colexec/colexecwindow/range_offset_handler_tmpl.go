@@ -118,7 +118,7 @@ func newRangeOffsetHandler(
 						op := &_OP_STRING{
 							offset: decodeOffset(datumAlloc, ordColType, bound.TypedOffset).(_OFFSET_GOTYPE),
 						}
-						// {{if eq .VecMethod "Datum"}}
+						// {{if .NeedsOverloadHelper}}
 						// {{if .BinOpIsPlus}}
 						binOp, _, _ := eval.WindowFrameRangeOps{}.LookupImpl(
 							ordColType, getOffsetType(ordColType))
@@ -126,7 +126,7 @@ func newRangeOffsetHandler(
 						_, binOp, _ := eval.WindowFrameRangeOps{}.LookupImpl(
 							ordColType, getOffsetType(ordColType))
 						// {{end}}
-						op.overloadHelper = colexecutils.BinaryOverloadHelper{BinOp: binOp.EvalOp, EvalCtx: evalCtx}
+						op.overloadHelper = colexecutils.MakeBinaryOverloadHelper(binOp.EvalOp, evalCtx)
 						// {{end}}
 						return op
 						// {{end}}
@@ -161,7 +161,7 @@ type rangeOffsetHandlerBase struct {
 // the start or end bound for each row when in RANGE mode with an offset.
 type _OP_STRING struct {
 	rangeOffsetHandlerBase
-	// {{if eq .VecMethod "Datum"}}
+	// {{if .NeedsOverloadHelper}}
 	overloadHelper colexecutils.BinaryOverloadHelper
 	// {{end}}
 	offset _OFFSET_GOTYPE
@@ -191,13 +191,14 @@ var _ rangeOffsetHandler = &_OP_STRING{}
 // the partition, whichever comes first. In this case, the returned index would
 // be '4' to indicate that the end index is the end of the partition.
 func (h *_OP_STRING) getIdx(ctx context.Context, currRow, lastIdx int) (idx int) {
-	// {{if eq .VecMethod "Datum"}}
+	// {{if .NeedsOverloadHelper}}
 	// {{/*
 	//     In order to inline the templated code of the binary overloads
 	//     operating on datums, we need to have a `_overloadHelper` local
 	//     variable of type `colexecutils.BinaryOverloadHelper`. This is
 	//     necessary when dealing with Time and TimeTZ columns since they aren't
-	//     yet handled natively.
+	//     yet handled natively, as well as with Timestamp and TimestampTZ
+	//     columns since the offset is added in the location of the helper.
 	// */}}
 	_overloadHelper := h.overloadHelper
 	// {{end}}
@@ -0,0 +1,207 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	distinctOnBestVecIdx  = 0
+	distinctOnInputVecIdx = 1
+)
+
+// NewDistinctOnOp returns an operator that emits a single tuple for each group
+// of tuples with equal values in distinctCols, namely the first tuple of the
+// group according to groupOrdering. The input must be ordered on distinctCols
+// but not necessarily on groupOrdering, so the operator replaces a sort
+// followed by an ordered distinct while only buffering a single output batch.
+// NULL values are treated as equal to one another.
+func NewDistinctOnOp(
+	allocator *colmem.Allocator,
+	input colexecop.Operator,
+	inputTypes []*types.T,
+	distinctCols []uint32,
+	groupOrdering []execinfrapb.Ordering_Column,
+) colexecop.ResettableOperator {
+	if len(groupOrdering) == 0 {
+		colexecerror.InternalError(errors.AssertionFailedf("no group ordering for the distinct on operator"))
+	}
+	d := &distinctOnOp{
+		OneInputNode:  colexecop.NewOneInputNode(input),
+		allocator:     allocator,
+		inputTypes:    inputTypes,
+		groupOrdering: groupOrdering,
+	}
+	d.distincterInput = &colexecop.FeedOperator{}
+	d.distincter, d.distinctOutput = colexecbase.OrderedDistinctColsToOperators(
+		d.distincterInput, distinctCols, inputTypes, false, /* nullsAreDistinct */
+	)
+	return d
+}
+
+type distinctOnOp struct {
+	colexecop.OneInputNode
+	colexecop.InitHelper
+
+	allocator     *colmem.Allocator
+	inputTypes    []*types.T
+	groupOrdering []execinfrapb.Ordering_Column
+	// comparators stores one comparator per column of groupOrdering, comparing
+	// the tuples of best and of the input batch.
+	comparators []vecComparator
+
+	// distincter marks the tuples of the input batch that start a new group in
+	// distinctOutput.
+	distincterInput *colexecop.FeedOperator
+	distincter      colexecop.ResettableOperator
+	distinctOutput  []bool
+
+	// inputBatch is the last read batch from the input, and inputIdx is the
+	// index of the first tuple of it that hasn't been processed yet.
+	inputBatch coldata.Batch
+	inputIdx   int
+	// best contains a copy of the first tuple of the current group seen so
+	// far, if haveBest is true.
+	best     coldata.Batch
+	haveBest bool
+	output   coldata.Batch
+	done     bool
+}
+
+var _ colexecop.ResettableOperator = &distinctOnOp{}
+
+func (d *distinctOnOp) Init(ctx context.Context) {
+	if !d.InitHelper.Init(ctx) {
+		return
+	}
+	d.Input.Init(d.Ctx)
+	d.distincter.Init(d.Ctx)
+	d.best = d.allocator.NewMemBatchWithFixedCapacity(d.inputTypes, 1 /* capacity */)
+	d.output = d.allocator.NewMemBatchWithMaxCapacity(d.inputTypes)
+	d.comparators = make([]vecComparator, len(d.groupOrdering))
+	for i, col := range d.groupOrdering {
+		d.comparators[i] = GetVecComparator(d.inputTypes[col.ColIdx], 2 /* numVecs */)
+		d.comparators[i].setVec(distinctOnBestVecIdx, d.best.ColVec(int(col.ColIdx)))
+	}
+}
+
+func (d *distinctOnOp) Next() coldata.Batch {
+	if d.done {
+		return coldata.ZeroBatch
+	}
+	d.output.ResetInternalBatch()
+	for {
+		if d.inputBatch == nil || d.inputIdx == d.inputBatch.Length() {
+			d.inputBatch, d.inputIdx = d.Input.Next(), 0
+			if d.inputBatch.Length() == 0 {
+				if d.haveBest {
+					d.emitBest()
+				}
+				d.done = true
+				return d.output
+			}
+			d.distincterInput.SetBatch(d.inputBatch)
+			d.distincter.Next()
+			for i, col := range d.groupOrdering {
+				d.comparators[i].setVec(distinctOnInputVecIdx, d.inputBatch.ColVec(int(col.ColIdx)))
+			}
+		}
+		sel := d.inputBatch.Selection()
+		for n := d.inputBatch.Length(); d.inputIdx < n; d.inputIdx++ {
+			idx := d.inputIdx
+			if sel != nil {
+				idx = sel[idx]
+			}
+			if d.distinctOutput[idx] {
+				if d.haveBest {
+					if d.output.Length() == d.output.Capacity() {
+						// The tuple will be processed again on the next call.
+						return d.output
+					}
+					d.emitBest()
+				}
+				d.setBest(idx)
+			} else if d.less(idx) {
+				d.setBest(idx)
+			}
+		}
+	}
+}
+
+// less returns whether the tuple at position idx of the input batch precedes
+// best according to the group ordering.
+func (d *distinctOnOp) less(idx int) bool {
+	for i, col := range d.groupOrdering {
+		res := d.comparators[i].compare(distinctOnInputVecIdx, distinctOnBestVecIdx, idx, 0 /* valIdx2 */)
+		if res != 0 {
+			switch dir := col.Direction; dir {
+			case execinfrapb.Ordering_Column_ASC:
+				return res < 0
+			case execinfrapb.Ordering_Column_DESC:
+				return res > 0
+			default:
+				colexecerror.InternalError(errors.AssertionFailedf("unexpected direction value %d", dir))
+			}
+		}
+	}
+	return false
+}
+
+// setBest copies the tuple at position idx of the input batch into best.
+func (d *distinctOnOp) setBest(idx int) {
+	d.allocator.PerformOperation(d.best.ColVecs(), func() {
+		for i, vec := range d.best.ColVecs() {
+			vec.Copy(coldata.SliceArgs{
+				Src:         d.inputBatch.ColVec(i),
+				SrcStartIdx: idx,
+				SrcEndIdx:   idx + 1,
+			})
+		}
+	})
+	d.haveBest = true
+}
+
+// emitBest appends best to the output batch.
+func (d *distinctOnOp) emitBest() {
+	outputIdx := d.output.Length()
+	d.allocator.PerformOperation(d.output.ColVecs(), func() {
+		for i, vec := range d.output.ColVecs() {
+			vec.Copy(coldata.SliceArgs{
+				Src:         d.best.ColVec(i),
+				DestIdx:     outputIdx,
+				SrcStartIdx: 0,
+				SrcEndIdx:   1,
+			})
+		}
+	})
+	d.output.SetLength(outputIdx + 1)
+	d.haveBest = false
+}
+
+// Reset is part of the colexecop.Resetter interface.
+func (d *distinctOnOp) Reset(ctx context.Context) {
+	if r, ok := d.Input.(colexecop.Resetter); ok {
+		r.Reset(ctx)
+	}
+	d.distincter.Reset(ctx)
+	d.inputBatch, d.inputIdx = nil, 0
+	d.haveBest, d.done = false, false
+}
@@ -0,0 +1,102 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexectestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistinctOn(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	typs := []*types.T{types.Int, types.String, types.Int}
+	groupOrdering := []execinfrapb.Ordering_Column{
+		{ColIdx: 1, Direction: execinfrapb.Ordering_Column_DESC},
+		{ColIdx: 2, Direction: execinfrapb.Ordering_Column_ASC},
+	}
+	for _, tc := range []struct {
+		description string
+		input       colexectestutils.Tuples
+		expected    colexectestutils.Tuples
+	}{
+		{
+			description: "single group",
+			input:       colexectestutils.Tuples{{1, "a", 1}, {1, "c", 3}, {1, "c", 2}, {1, "b", 4}},
+			expected:    colexectestutils.Tuples{{1, "c", 2}},
+		},
+		{
+			description: "multiple groups",
+			input: colexectestutils.Tuples{
+				{1, "a", 1}, {1, "b", 2}, {2, "b", 3}, {2, "a", 4}, {3, "a", 5},
+				{4, "a", 6}, {4, "a", 7}, {4, "c", 8},
+			},
+			expected: colexectestutils.Tuples{{1, "b", 2}, {2, "b", 3}, {3, "a", 5}, {4, "c", 8}},
+		},
+		{
+			description: "nulls",
+			input: colexectestutils.Tuples{
+				{nil, "a", 1}, {nil, nil, 0}, {nil, "b", 2}, {1, nil, 3}, {1, nil, nil},
+			},
+			expected: colexectestutils.Tuples{{nil, "b", 2}, {1, nil, nil}},
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			colexectestutils.RunTestsWithTyps(
+				t, testAllocator, []colexectestutils.Tuples{tc.input}, [][]*types.T{typs}, tc.expected,
+				colexectestutils.OrderedVerifier,
+				func(input []colexecop.Operator) (colexecop.Operator, error) {
+					return NewDistinctOnOp(testAllocator, input[0], typs, []uint32{0}, groupOrdering), nil
+				},
+			)
+		})
+	}
+}
+
+// TestDistinctOnManyGroups verifies that the operator correctly handles the
+// input with more groups than fit into a single output batch.
+func TestDistinctOnManyGroups(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	typs := []*types.T{types.Int, types.Int}
+	numGroups := 3*coldata.BatchSize() + 1
+	var input colexectestutils.Tuples
+	for i := 0; i < numGroups; i++ {
+		input = append(input, colexectestutils.Tuple{i, 2}, colexectestutils.Tuple{i, i % 2})
+	}
+	source := colexectestutils.NewOpTestInput(testAllocator, coldata.BatchSize(), input, typs)
+	op := NewDistinctOnOp(
+		testAllocator, source, typs, []uint32{0},
+		[]execinfrapb.Ordering_Column{{ColIdx: 1}},
+	)
+	op.Init(ctx)
+	var numRows int
+	for b := op.Next(); b.Length() > 0; b = op.Next() {
+		for i := 0; i < b.Length(); i++ {
+			require.Equal(t, int64(numRows), b.ColVec(0).Int64().Get(i))
+			require.Equal(t, int64(numRows%2), b.ColVec(1).Int64().Get(i))
+			numRows++
+		}
+	}
+	require.Equal(t, numGroups, numRows)
+}
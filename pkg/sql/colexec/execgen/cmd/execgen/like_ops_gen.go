@@ -47,6 +47,7 @@ import (
 	"regexp"
 
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexeccmp"
 )
 
 {{range .}}
@@ -172,6 +173,37 @@ func genLikeOps(
 			makeOverload("NotRegexp", "*regexp.Regexp", func(targetElem, leftElem, rightElem string) string {
 				return fmt.Sprintf("%s = !%s.Match(%s)", targetElem, rightElem, leftElem)
 			}),
+			// The case-insensitive variants are used for ILIKE.
+			makeOverload("ILikeConstant", bytesRepresentation, func(targetElem, leftElem, rightElem string) string {
+				return fmt.Sprintf("%s = bytes.EqualFold(%s, %s)", targetElem, leftElem, rightElem)
+			}),
+			makeOverload("ILikePrefix", bytesRepresentation, func(targetElem, leftElem, rightElem string) string {
+				return fmt.Sprintf("%s = colexeccmp.HasPrefixCaseInsensitive(%s, %s)", targetElem, leftElem, rightElem)
+			}),
+			makeOverload("ILikeSuffix", bytesRepresentation, func(targetElem, leftElem, rightElem string) string {
+				return fmt.Sprintf("%s = colexeccmp.HasSuffixCaseInsensitive(%s, %s)", targetElem, leftElem, rightElem)
+			}),
+			makeOverload("ILikeContains", bytesRepresentation, func(targetElem, leftElem, rightElem string) string {
+				return fmt.Sprintf("%s = colexeccmp.ContainsCaseInsensitive(%s, %s)", targetElem, leftElem, rightElem)
+			}),
+			makeOverload("ILikeSkeleton", "[][]byte", func(targetElem, leftElem, rightElem string) string {
+				return fmt.Sprintf("%s = colexeccmp.MatchSkeletonFold(%s, %s)", targetElem, leftElem, rightElem)
+			}),
+			makeOverload("NotILikeConstant", bytesRepresentation, func(targetElem, leftElem, rightElem string) string {
+				return fmt.Sprintf("%s = !bytes.EqualFold(%s, %s)", targetElem, leftElem, rightElem)
+			}),
+			makeOverload("NotILikePrefix", bytesRepresentation, func(targetElem, leftElem, rightElem string) string {
+				return fmt.Sprintf("%s = !colexeccmp.HasPrefixCaseInsensitive(%s, %s)", targetElem, leftElem, rightElem)
+			}),
+			makeOverload("NotILikeSuffix", bytesRepresentation, func(targetElem, leftElem, rightElem string) string {
+				return fmt.Sprintf("%s = !colexeccmp.HasSuffixCaseInsensitive(%s, %s)", targetElem, leftElem, rightElem)
+			}),
+			makeOverload("NotILikeContains", bytesRepresentation, func(targetElem, leftElem, rightElem string) string {
+				return fmt.Sprintf("%s = !colexeccmp.ContainsCaseInsensitive(%s, %s)", targetElem, leftElem, rightElem)
+			}),
+			makeOverload("NotILikeSkeleton", "[][]byte", func(targetElem, leftElem, rightElem string) string {
+				return fmt.Sprintf("%s = !colexeccmp.MatchSkeletonFold(%s, %s)", targetElem, leftElem, rightElem)
+			}),
 		}
 		return tmpl.Execute(wr, overloads)
 	}
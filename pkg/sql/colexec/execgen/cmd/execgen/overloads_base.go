@@ -317,7 +317,12 @@ type twoArgsResolvedOverload struct {
 // NeedsBinaryOverloadHelper returns true iff the overload is such that it needs
 // access to colexecutils.BinaryOverloadHelper.
 func (o *twoArgsResolvedOverload) NeedsBinaryOverloadHelper() bool {
-	return o.kind == binaryOverload && o.Right.RetVecMethod == "Datum"
+	if o.kind != binaryOverload {
+		return false
+	}
+	// The timestamp ± interval overloads need the location in which the
+	// computation is performed.
+	return o.Right.RetVecMethod == "Datum" || o.Right.RetType.Family() == types.TimestampTZFamily
 }
 
 // twoArgsResolvedOverloadsInfo contains all overloads that take in two
@@ -701,11 +706,19 @@ type jsonCustomizer struct{}
 
 // timestampIntervalCustomizer supports mixed type expression with a timestamp
 // left-hand side and an interval right-hand side.
-type timestampIntervalCustomizer struct{}
+type timestampIntervalCustomizer struct {
+	// inLocation, if set, indicates that the timestamp is converted to the
+	// location of the `_overloadHelper` before the interval is added, so that
+	// the days and months of the interval are added in the session time zone.
+	inLocation bool
+}
 
 // intervalTimestampCustomizer supports mixed type expression with an interval
 // left-hand side and a timestamp right-hand side.
-type intervalTimestampCustomizer struct{}
+type intervalTimestampCustomizer struct {
+	// inLocation has the same meaning as for timestampIntervalCustomizer.
+	inLocation bool
+}
 
 // intervalIntCustomizer supports mixed type expression with an interval
 // left-hand side and an int right-hand side.
@@ -795,8 +808,8 @@ func registerTypeCustomizers() {
 		registerTypeCustomizer(typePair{types.IntFamily, leftIntWidth, types.FloatFamily, anyWidth}, intFloatCustomizer{})
 		registerTypeCustomizer(typePair{types.IntFamily, leftIntWidth, types.IntervalFamily, anyWidth}, intIntervalCustomizer{})
 	}
-	registerTypeCustomizer(typePair{types.TimestampTZFamily, anyWidth, types.IntervalFamily, anyWidth}, timestampIntervalCustomizer{})
-	registerTypeCustomizer(typePair{types.IntervalFamily, anyWidth, types.TimestampTZFamily, anyWidth}, intervalTimestampCustomizer{})
+	registerTypeCustomizer(typePair{types.TimestampTZFamily, anyWidth, types.IntervalFamily, anyWidth}, timestampIntervalCustomizer{inLocation: true})
+	registerTypeCustomizer(typePair{types.IntervalFamily, anyWidth, types.TimestampTZFamily, anyWidth}, intervalTimestampCustomizer{inLocation: true})
 	registerTypeCustomizer(typePair{types.IntervalFamily, anyWidth, types.DecimalFamily, anyWidth}, intervalDecimalCustomizer{})
 	registerTypeCustomizer(typePair{types.DecimalFamily, anyWidth, types.IntervalFamily, anyWidth}, decimalIntervalCustomizer{})
 
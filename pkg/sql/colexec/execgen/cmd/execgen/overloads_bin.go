@@ -808,8 +808,18 @@ if rounded_res.After(tree.MaxSupportedTime) || rounded_res.Before(tree.MinSuppor
 		colexecerror.ExpectedError(errors.Newf("timestamp %q exceeds supported timestamp bounds", t_res.Format(time.RFC3339)))
 }`
 
+// timestampInLocation returns the expression converting the given timestamp to
+// the location of the `_overloadHelper` if inLocation is set.
+func timestampInLocation(elem string, inLocation bool) string {
+	if !inLocation {
+		return elem
+	}
+	return fmt.Sprintf("%s.In(_overloadHelper.TimestampLocation)", elem)
+}
+
 func (c timestampIntervalCustomizer) getBinOpAssignFunc() assignFunc {
 	return func(op *lastArgWidthOverload, targetElem, leftElem, rightElem, targetCol, leftCol, rightCol string) string {
+		leftElem = timestampInLocation(leftElem, c.inLocation)
 		switch op.overloadBase.BinOp {
 		case treebin.Plus:
 			return fmt.Sprintf(`t_res := duration.Add(%[1]s, %[2]s)`,
@@ -827,6 +837,7 @@ func (c timestampIntervalCustomizer) getBinOpAssignFunc() assignFunc {
 
 func (c intervalTimestampCustomizer) getBinOpAssignFunc() assignFunc {
 	return func(op *lastArgWidthOverload, targetElem, leftElem, rightElem, targetCol, leftCol, rightCol string) string {
+		rightElem = timestampInLocation(rightElem, c.inLocation)
 		switch op.overloadBase.BinOp {
 		case treebin.Plus:
 			return fmt.Sprintf(`t_res := duration.Add(%[2]s, %[1]s)`,
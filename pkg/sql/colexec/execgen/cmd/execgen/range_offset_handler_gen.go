@@ -93,17 +93,19 @@ func rangeOffsetHandlerGenerator(inputFileContents string, wr io.Writer) error {
 							opString += "Desc"
 						}
 						opString += typeName(typeFamily, width)
+						vecMethod := toVecMethod(canonicalTypeFamily, width)
 						widthOverload := windowFrameOrderWidthOverload{
-							Width:           width,
-							VecMethod:       toVecMethod(canonicalTypeFamily, width),
-							OffsetGoType:    getOffsetGoType(typeFamily, width),
-							CmpGoType:       getCmpGoType(typeFamily),
-							OpString:        opString,
-							IsStart:         isStart,
-							IsOrdColAsc:     isOrdColAsc,
-							assignFunc:      getAssignFunc(typeFamily),
-							valueByOffsetOp: getValueByOffsetOp(bound, isOrdColAsc),
-							cmpFunc:         getCmpFunc(typeFamily),
+							Width:               width,
+							VecMethod:           vecMethod,
+							OffsetGoType:        getOffsetGoType(typeFamily, width),
+							CmpGoType:           getCmpGoType(typeFamily),
+							OpString:            opString,
+							IsStart:             isStart,
+							IsOrdColAsc:         isOrdColAsc,
+							NeedsOverloadHelper: vecMethod == "Datum" || typeFamily == types.TimestampTZFamily,
+							assignFunc:          getAssignFunc(typeFamily),
+							valueByOffsetOp:     getValueByOffsetOp(bound, isOrdColAsc),
+							cmpFunc:             getCmpFunc(typeFamily),
 						}
 						typeFamilyInfo.WidthOverloads = append(typeFamilyInfo.WidthOverloads, widthOverload)
 					}
@@ -149,12 +151,15 @@ type windowFrameOrderWidthOverload struct {
 	OffsetGoType string
 	CmpGoType    string
 
-	OpString        string
-	IsStart         bool
-	IsOrdColAsc     bool
-	cmpFunc         compareFunc
-	assignFunc      assignFunc
-	valueByOffsetOp treebin.BinaryOperatorSymbol
+	OpString    string
+	IsStart     bool
+	IsOrdColAsc bool
+	// NeedsOverloadHelper is true if the ValueByOffset code uses the
+	// `_overloadHelper` local variable.
+	NeedsOverloadHelper bool
+	cmpFunc             compareFunc
+	assignFunc          assignFunc
+	valueByOffsetOp     treebin.BinaryOperatorSymbol
 }
 
 func (overload windowFrameOrderWidthOverload) ValueByOffset(
@@ -182,7 +187,10 @@ var _ = windowFrameOrderWidthOverload{}.AssignCmp
 func getAssignFunc(typeFamily types.Family) assignFunc {
 	switch typeFamily {
 	case types.TimestampFamily, types.TimestampTZFamily:
-		var c timestampIntervalCustomizer
+		// The offset is added in the location of the `_overloadHelper`, which
+		// is the session time zone for TIMESTAMPTZ columns and UTC for
+		// TIMESTAMP columns, as the row-based engine does.
+		c := timestampIntervalCustomizer{inLocation: true}
 		return c.getBinOpAssignFunc()
 	case types.DateFamily:
 		// Date needs to be specially handled because it is represented as int64s in
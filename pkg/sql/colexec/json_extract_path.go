@@ -0,0 +1,142 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexec
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+)
+
+// newJSONExtractPathOperator returns an operator that evaluates
+// json_extract_path (or json_extract_path_text if asText is set). The first
+// argument column is the JSON value, and the remaining ones are the path
+// elements.
+func newJSONExtractPathOperator(
+	allocator *colmem.Allocator,
+	argumentCols []int,
+	outputIdx int,
+	input colexecop.Operator,
+	asText bool,
+) colexecop.Operator {
+	return &jsonExtractPathOperator{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		allocator:      allocator,
+		argumentCols:   argumentCols,
+		outputIdx:      outputIdx,
+		asText:         asText,
+		path:           make([]string, len(argumentCols)-1),
+		pathCols:       make([]*coldata.Bytes, len(argumentCols)-1),
+		pathNulls:      make([]*coldata.Nulls, len(argumentCols)-1),
+	}
+}
+
+type jsonExtractPathOperator struct {
+	colexecop.OneInputHelper
+	allocator    *colmem.Allocator
+	argumentCols []int
+	outputIdx    int
+	// asText indicates whether the extracted value is output as a string
+	// rather than as JSON.
+	asText bool
+	// path is a scratch slice for the path elements of the current row.
+	path []string
+	// pathCols and pathNulls are scratch slices for the vectors of the path
+	// elements.
+	pathCols  []*coldata.Bytes
+	pathNulls []*coldata.Nulls
+}
+
+var _ colexecop.Operator = &jsonExtractPathOperator{}
+
+func (j *jsonExtractPathOperator) Next() coldata.Batch {
+	batch := j.Input.Next()
+	n := batch.Length()
+	if n == 0 {
+		return coldata.ZeroBatch
+	}
+
+	sel := batch.Selection()
+	jsonVec := batch.ColVec(j.argumentCols[0])
+	jsonCol := jsonVec.JSON()
+	jsonNulls := jsonVec.Nulls()
+	pathCols, pathNulls := j.pathCols, j.pathNulls
+	argsMaybeHaveNulls := jsonNulls.MaybeHasNulls()
+	for i := range j.path {
+		pathVec := batch.ColVec(j.argumentCols[i+1])
+		pathCols[i] = pathVec.Bytes()
+		pathNulls[i] = pathVec.Nulls()
+		argsMaybeHaveNulls = argsMaybeHaveNulls || pathNulls[i].MaybeHasNulls()
+	}
+	outputVec := batch.ColVec(j.outputIdx)
+	outputNulls := outputVec.Nulls()
+	var outputJSONCol *coldata.JSONs
+	var outputTextCol *coldata.Bytes
+	if j.asText {
+		outputTextCol = outputVec.Bytes()
+	} else {
+		outputJSONCol = outputVec.JSON()
+	}
+	j.allocator.PerformOperation(
+		[]coldata.Vec{outputVec},
+		func() {
+		rowLoop:
+			for i := 0; i < n; i++ {
+				rowIdx := i
+				if sel != nil {
+					rowIdx = sel[i]
+				}
+
+				if argsMaybeHaveNulls {
+					// If any of the arguments are NULL, we output NULL.
+					if jsonNulls.NullAt(rowIdx) {
+						outputNulls.SetNull(rowIdx)
+						continue
+					}
+					for k := range pathNulls {
+						if pathNulls[k].NullAt(rowIdx) {
+							outputNulls.SetNull(rowIdx)
+							continue rowLoop
+						}
+					}
+				}
+
+				for k := range pathCols {
+					j.path[k] = string(pathCols[k].Get(rowIdx))
+				}
+				res, err := json.FetchPath(jsonCol.Get(rowIdx), j.path)
+				if err != nil {
+					colexecerror.ExpectedError(err)
+				}
+				if res == nil {
+					outputNulls.SetNull(rowIdx)
+					continue
+				}
+				if !j.asText {
+					outputJSONCol.Set(rowIdx, res)
+					continue
+				}
+				text, err := res.AsText()
+				if err != nil {
+					colexecerror.ExpectedError(err)
+				}
+				if text == nil {
+					outputNulls.SetNull(rowIdx)
+					continue
+				}
+				outputTextCol.Set(rowIdx, []byte(*text))
+			}
+		},
+	)
+	return batch
+}
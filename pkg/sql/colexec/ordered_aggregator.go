@@ -66,7 +66,10 @@ const (
 // aggregations, the aggregator sets up a chain of distinct operators that will
 // produce a vector of booleans (referenced in groupCol) that specifies whether
 // or not the corresponding columns in the input batch are part of a new group.
-// The memory is modified by the distinct operator flow.
+// The memory is modified by the distinct operator flow. If the input can
+// determine the group boundaries itself (see
+// colexecop.GroupBoundariesProducer), the distinct operators are omitted and
+// the input populates groupCol directly.
 // Every aggregate function will change the shape of the data. i.e. a new column
 // value will be output for each input group. Since the number of input groups
 // is variable and the number of output values is constant, care must be taken
@@ -147,9 +150,21 @@ func NewOrderedAggregator(args *colexecagg.NewAggregatorArgs) colexecop.Resettab
 			colexecerror.InternalError(errors.AssertionFailedf("filtering ordered aggregation is not supported"))
 		}
 	}
-	op, groupCol := colexecbase.OrderedDistinctColsToOperators(
-		args.Input, args.Spec.GroupCols, args.InputTypes, false, /* nullsAreDistinct */
-	)
+	var op colexecop.Operator
+	var groupCol []bool
+	if p, ok := MaybeUnwrapInvariantsChecker(args.Input).(colexecop.GroupBoundariesProducer); ok {
+		// If the input can determine the group boundaries itself (e.g. the
+		// scan of an index that has the grouping columns as its prefix), we
+		// don't need to plan the distinct operators.
+		if groupCol, ok = p.ProduceGroupBoundaries(args.Spec.GroupCols); ok {
+			op = args.Input
+		}
+	}
+	if op == nil {
+		op, groupCol = colexecbase.OrderedDistinctColsToOperators(
+			args.Input, args.Spec.GroupCols, args.InputTypes, false, /* nullsAreDistinct */
+		)
+	}
 
 	// We will be reusing the same aggregate functions, so we use 1 as the
 	// allocation size.
@@ -0,0 +1,298 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexec
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colconv"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execreleasable"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// projectSetSupportedSRFs are the set-returning functions supported by the
+// projectSetOp. Their generators only depend on their arguments, so they can be
+// evaluated without access to the transaction or the planner.
+var projectSetSupportedSRFs = map[string]struct{}{
+	"generate_series":           {},
+	"json_array_elements":       {},
+	"jsonb_array_elements":      {},
+	"json_array_elements_text":  {},
+	"jsonb_array_elements_text": {},
+}
+
+// CheckProjectSetSRF returns an error if expr is not the application of a
+// set-returning function supported by the projectSetOp.
+func CheckProjectSetSRF(expr tree.TypedExpr) (*tree.FuncExpr, error) {
+	fn, ok := expr.(*tree.FuncExpr)
+	if !ok || !fn.IsGeneratorApplication() {
+		return nil, errors.Newf("%s is not a set-returning function", expr)
+	}
+	def, ok := fn.Func.FunctionReference.(*tree.FunctionDefinition)
+	if !ok {
+		return nil, errors.AssertionFailedf("unresolved function %s", fn.Func)
+	}
+	if _, ok := projectSetSupportedSRFs[def.Name]; !ok || fn.ResolvedOverload().Generator == nil {
+		return nil, errors.Newf("set-returning function %s is not supported", def.Name)
+	}
+	return fn, nil
+}
+
+// ProjectSetSRF describes a set-returning function evaluated by the
+// projectSetOp.
+type ProjectSetSRF struct {
+	// FuncExpr is the application of the function.
+	FuncExpr *tree.FuncExpr
+	// ArgumentCols are the indices of the input columns containing the
+	// arguments of the function.
+	ArgumentCols []int
+	// NumCols is the number of columns generated by the function.
+	NumCols int
+}
+
+// projectSetOp is the vectorized version of the projectSet processor. For
+// each input tuple, it evaluates the set-returning functions and emits the
+// input tuple combined with each set of values generated by the functions,
+// using NULLs for the functions that have generated fewer rows than the
+// others. An input tuple for which all functions generate no rows is not
+// emitted.
+type projectSetOp struct {
+	colexecop.OneInputHelper
+
+	allocator       *colmem.Allocator
+	evalCtx         *eval.Context
+	srfs            []ProjectSetSRF
+	outputTypes     []*types.T
+	maxBatchMemSize int64
+	// numInputCols is the number of the input columns that are emitted. The
+	// remaining input columns only contain the arguments of the functions.
+	numInputCols int
+
+	toDatumConverter     *colconv.VecToDatumConverter
+	datumToVecConverters []func(tree.Datum) interface{}
+
+	output coldata.Batch
+	// srcIdxs contains, for each tuple of the output, the index of the
+	// corresponding tuple in the current input batch.
+	srcIdxs []int
+
+	// batch is the current input batch and curIdx is the position of the
+	// current input tuple among its selected tuples.
+	batch  coldata.Batch
+	curIdx int
+	// tupleStarted is true if the generators were created for the current
+	// input tuple.
+	tupleStarted bool
+	// gens contains the generators of the current input tuple. A generator
+	// is nil if it has been exhausted.
+	gens    []eval.ValueGenerator
+	hasVals []bool
+	// args contains the scratch space for the arguments of each function.
+	args []tree.Datums
+}
+
+var _ colexecop.Operator = &projectSetOp{}
+var _ execreleasable.Releasable = &projectSetOp{}
+
+// NewProjectSetOp returns an operator that evaluates the given set-returning
+// functions on the tuples of the input, whose first numInputCols columns are
+// emitted followed by the columns of generatedTypes. The functions must have
+// been checked with CheckProjectSetSRF, and their arguments must have been
+// projected into the input.
+func NewProjectSetOp(
+	allocator *colmem.Allocator,
+	evalCtx *eval.Context,
+	input colexecop.Operator,
+	inputTypes []*types.T,
+	numInputCols int,
+	srfs []ProjectSetSRF,
+	generatedTypes []*types.T,
+	maxBatchMemSize int64,
+) colexecop.Operator {
+	outputTypes := make([]*types.T, 0, numInputCols+len(generatedTypes))
+	outputTypes = append(outputTypes, inputTypes[:numInputCols]...)
+	outputTypes = append(outputTypes, generatedTypes...)
+	var argumentCols []int
+	args := make([]tree.Datums, len(srfs))
+	for i := range srfs {
+		argumentCols = append(argumentCols, srfs[i].ArgumentCols...)
+		args[i] = make(tree.Datums, len(srfs[i].ArgumentCols))
+	}
+	datumToVecConverters := make([]func(tree.Datum) interface{}, len(generatedTypes))
+	for i, typ := range generatedTypes {
+		datumToVecConverters[i] = colconv.GetDatumToPhysicalFn(typ)
+	}
+	return &projectSetOp{
+		OneInputHelper:       colexecop.MakeOneInputHelper(input),
+		allocator:            allocator,
+		evalCtx:              evalCtx,
+		srfs:                 srfs,
+		outputTypes:          outputTypes,
+		maxBatchMemSize:      maxBatchMemSize,
+		numInputCols:         numInputCols,
+		toDatumConverter:     colconv.NewVecToDatumConverter(len(inputTypes), argumentCols, true /* willRelease */),
+		datumToVecConverters: datumToVecConverters,
+		gens:                 make([]eval.ValueGenerator, len(srfs)),
+		hasVals:              make([]bool, len(srfs)),
+		args:                 args,
+	}
+}
+
+func (p *projectSetOp) Next() coldata.Batch {
+	p.output, _ = p.allocator.ResetMaybeReallocate(
+		p.outputTypes, p.output, coldata.BatchSize(), p.maxBatchMemSize,
+		true, /* desiredCapacitySufficient */
+	)
+	p.srcIdxs = p.srcIdxs[:0]
+	outputIdx := 0
+	p.allocator.PerformOperation(p.output.ColVecs()[p.numInputCols:], func() {
+		for outputIdx < p.output.Capacity() {
+			if p.batch == nil || p.curIdx == p.batch.Length() {
+				if outputIdx > 0 {
+					// The output refers to the tuples of the current input
+					// batch, so it must be emitted before the next input batch
+					// is read.
+					return
+				}
+				p.batch = p.Input.Next()
+				p.curIdx = 0
+				if p.batch.Length() == 0 {
+					return
+				}
+				p.toDatumConverter.ConvertBatchAndDeselect(p.batch)
+			}
+			if !p.tupleStarted {
+				p.startGenerators()
+				p.tupleStarted = true
+			}
+			if !p.nextGeneratorValues(outputIdx) {
+				// The values generated for the current input tuple are
+				// exhausted, so we move on to the next input tuple.
+				p.tupleStarted = false
+				p.curIdx++
+				continue
+			}
+			srcIdx := p.curIdx
+			if sel := p.batch.Selection(); sel != nil {
+				srcIdx = sel[p.curIdx]
+			}
+			p.srcIdxs = append(p.srcIdxs, srcIdx)
+			outputIdx++
+		}
+	})
+	if outputIdx == 0 {
+		return coldata.ZeroBatch
+	}
+	p.allocator.PerformOperation(p.output.ColVecs()[:p.numInputCols], func() {
+		for i := 0; i < p.numInputCols; i++ {
+			p.output.ColVec(i).Copy(
+				coldata.SliceArgs{
+					Src:       p.batch.ColVec(i),
+					Sel:       p.srcIdxs,
+					SrcEndIdx: outputIdx,
+				},
+			)
+		}
+	})
+	p.output.SetLength(outputIdx)
+	return p.output
+}
+
+// startGenerators creates the generators of the current input tuple.
+func (p *projectSetOp) startGenerators() {
+	for i := range p.srfs {
+		srf := &p.srfs[i]
+		p.gens[i] = nil
+		args := p.args[i]
+		hasNulls := false
+		for j, argumentCol := range srf.ArgumentCols {
+			// Note that we don't need to apply the selection vector because
+			// the converter returns "dense" datum columns.
+			args[j] = p.toDatumConverter.GetDatumColumn(argumentCol)[p.curIdx]
+			hasNulls = hasNulls || args[j] == tree.DNull
+		}
+		if hasNulls && !srf.FuncExpr.CanHandleNulls() {
+			// The function generates no rows.
+			continue
+		}
+		gen, err := srf.FuncExpr.ResolvedOverload().Generator.(eval.GeneratorOverload)(p.evalCtx, args)
+		if err != nil {
+			colexecerror.ExpectedError(err)
+		}
+		if err := gen.Start(p.Ctx, p.evalCtx.Txn); err != nil {
+			colexecerror.ExpectedError(err)
+		}
+		p.gens[i] = gen
+	}
+}
+
+// nextGeneratorValues writes the next set of generated values at position
+// outputIdx of the output. It returns false, without writing anything, if all
+// the generators are exhausted.
+func (p *projectSetOp) nextGeneratorValues(outputIdx int) bool {
+	newValAvail := false
+	for i, gen := range p.gens {
+		p.hasVals[i] = false
+		if gen == nil {
+			continue
+		}
+		hasVals, err := gen.Next(p.Ctx)
+		if err != nil {
+			colexecerror.ExpectedError(err)
+		}
+		if !hasVals {
+			gen.Close(p.Ctx)
+			p.gens[i] = nil
+			continue
+		}
+		p.hasVals[i] = true
+		newValAvail = true
+	}
+	if !newValAvail {
+		return false
+	}
+	colIdx := p.numInputCols
+	for i := range p.srfs {
+		numCols := p.srfs[i].NumCols
+		if !p.hasVals[i] {
+			for j := 0; j < numCols; j++ {
+				p.output.ColVec(colIdx + j).Nulls().SetNull(outputIdx)
+			}
+			colIdx += numCols
+			continue
+		}
+		values, err := p.gens[i].Values()
+		if err != nil {
+			colexecerror.ExpectedError(err)
+		}
+		for _, value := range values {
+			vec := p.output.ColVec(colIdx)
+			if value == tree.DNull {
+				vec.Nulls().SetNull(outputIdx)
+			} else {
+				converted := p.datumToVecConverters[colIdx-p.numInputCols](value)
+				coldata.SetValueAt(vec, converted, outputIdx)
+			}
+			colIdx++
+		}
+	}
+	return true
+}
+
+// Release is part of the execinfra.Releasable interface.
+func (p *projectSetOp) Release() {
+	p.toDatumConverter.Release()
+}
@@ -0,0 +1,147 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecargs"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexectestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectSet(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	// Trick to get the init() for the builtins package to run.
+	_ = builtins.AllBuiltinNames
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	evalCtx := eval.MakeTestingEvalContext(st)
+	defer evalCtx.Stop(ctx)
+	flowCtx := &execinfra.FlowCtx{
+		EvalCtx: &evalCtx,
+		Cfg: &execinfra.ServerConfig{
+			Settings: st,
+		},
+	}
+
+	testCases := []struct {
+		desc           string
+		exprs          []string
+		inputTuples    colexectestutils.Tuples
+		inputTypes     []*types.T
+		generatedTypes []*types.T
+		outputTuples   colexectestutils.Tuples
+	}{
+		{
+			desc:           "GenerateSeries",
+			exprs:          []string{"generate_series(1, @1)"},
+			inputTuples:    colexectestutils.Tuples{{2}, {0}, {3}},
+			inputTypes:     []*types.T{types.Int},
+			generatedTypes: []*types.T{types.Int},
+			outputTuples:   colexectestutils.Tuples{{2, 1}, {2, 2}, {3, 1}, {3, 2}, {3, 3}},
+		},
+		{
+			desc:           "NullArgument",
+			exprs:          []string{"generate_series(@1, @2)"},
+			inputTuples:    colexectestutils.Tuples{{1, nil}, {1, 1}, {nil, 2}},
+			inputTypes:     []*types.T{types.Int, types.Int},
+			generatedTypes: []*types.T{types.Int},
+			outputTuples:   colexectestutils.Tuples{{1, 1, 1}},
+		},
+		{
+			desc:           "JSONArrayElements",
+			exprs:          []string{"jsonb_array_elements_text(@1)"},
+			inputTuples:    colexectestutils.Tuples{{`["a", null]`}, {`[]`}, {`[1]`}},
+			inputTypes:     []*types.T{types.Jsonb},
+			generatedTypes: []*types.T{types.String},
+			outputTuples: colexectestutils.Tuples{
+				{mustParseJSON(`["a", null]`), "a"},
+				{mustParseJSON(`["a", null]`), nil},
+				{mustParseJSON(`[1]`), "1"},
+			},
+		},
+		{
+			desc:           "Zip",
+			exprs:          []string{"generate_series(1, @1)", "generate_series(@1, 2)"},
+			inputTuples:    colexectestutils.Tuples{{0}, {3}},
+			inputTypes:     []*types.T{types.Int},
+			generatedTypes: []*types.T{types.Int, types.Int},
+			outputTuples: colexectestutils.Tuples{
+				{0, nil, 0},
+				{0, nil, 1},
+				{0, nil, 2},
+				{3, 1, nil},
+				{3, 2, nil},
+				{3, 3, nil},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		log.Infof(ctx, "%s", tc.desc)
+		exprs := make([]execinfrapb.Expression, len(tc.exprs))
+		numColsPerGen := make([]uint32, len(tc.exprs))
+		for i, e := range tc.exprs {
+			expr, err := parser.ParseExpr(e)
+			require.NoError(t, err)
+			semaCtx := tree.MakeSemaContext()
+			semaCtx.IVarContainer = &colexectestutils.MockTypeContext{Typs: tc.inputTypes}
+			exprs[i].LocalExpr, err = tree.TypeCheck(ctx, expr, &semaCtx, types.Any)
+			require.NoError(t, err)
+			numColsPerGen[i] = 1
+		}
+		// NULL arguments make the set-returning functions generate no rows, so
+		// the all nulls injection doesn't apply.
+		colexectestutils.RunTestsWithoutAllNullsInjection(
+			t, testAllocator, []colexectestutils.Tuples{tc.inputTuples}, [][]*types.T{tc.inputTypes},
+			tc.outputTuples, colexectestutils.OrderedVerifier,
+			func(input []colexecop.Operator) (colexecop.Operator, error) {
+				spec := &execinfrapb.ProcessorSpec{
+					Input: []execinfrapb.InputSyncSpec{{ColumnTypes: tc.inputTypes}},
+					Core: execinfrapb.ProcessorCoreUnion{
+						ProjectSet: &execinfrapb.ProjectSetSpec{
+							Exprs:            exprs,
+							GeneratedColumns: tc.generatedTypes,
+							NumColsPerGen:    numColsPerGen,
+						},
+					},
+					ResultTypes: append(tc.inputTypes[:len(tc.inputTypes):len(tc.inputTypes)], tc.generatedTypes...),
+				}
+				args := &colexecargs.NewColOperatorArgs{
+					Spec:                spec,
+					Inputs:              []colexecargs.OpWithMetaInfo{{Root: input[0]}},
+					StreamingMemAccount: testMemAcc,
+				}
+				result, err := colexecargs.TestNewColOperator(ctx, flowCtx, args)
+				if err != nil {
+					return nil, err
+				}
+				if _, ok := MaybeUnwrapInvariantsChecker(result.Root).(*projectSetOp); !ok {
+					t.Fatalf("unexpectedly planned %T", result.Root)
+				}
+				return result.Root, nil
+			})
+	}
+}
@@ -323,6 +323,15 @@ func (p *sortOp) Next() coldata.Batch {
 	}
 }
 
+// spoolAndSort spools and sorts the input, after which Next emits the sorted
+// tuples. It is used by the parallel sorter to sort its partitions
+// concurrently.
+func (p *sortOp) spoolAndSort() {
+	p.input.spool()
+	p.sort()
+	p.state = sortEmitting
+}
+
 // sort sorts the spooled tuples, so it must be called after spool() has been
 // performed.
 func (p *sortOp) sort() {
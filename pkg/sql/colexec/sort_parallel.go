@@ -0,0 +1,233 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexec
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecargs"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexecutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// SortMaxWorkers is a cluster setting that determines the maximum number of
+// goroutines that are used by a single vectorized in-memory sorter.
+var SortMaxWorkers = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.distsql.vectorize_sort.max_workers",
+	"maximum number of goroutines used by a single vectorized sort of unordered "+
+		"input; set to 1 to disable the parallel sort",
+	4,
+	settings.PositiveInt,
+)
+
+// parallelSortChunkNumBatches is the number of consecutive input batches that
+// are appended to the same partition of the parallel sorter. It makes sure
+// that small inputs are sorted by a single worker.
+const parallelSortChunkNumBatches = 8
+
+// NewParallelSorter returns a new sort operator, which sorts its input on the
+// columns given in orderingCols using a worker goroutine per allocator. The
+// input is spooled into a partition per worker, the partitions are sorted
+// concurrently, and the sorted partitions are merged. Each allocator is only
+// used by a single goroutine at a time, so every worker must have its own
+// memory account. The inputTypes must correspond 1-1 with the columns in the
+// input operator.
+func NewParallelSorter(
+	allocators []*colmem.Allocator,
+	input colexecop.Operator,
+	inputTypes []*types.T,
+	orderingCols []execinfrapb.Ordering_Column,
+	maxOutputBatchMemSize int64,
+) colexecop.Operator {
+	s := &parallelSorter{
+		OneInputNode:          colexecop.NewOneInputNode(input),
+		inputTypes:            inputTypes,
+		orderingCols:          orderingCols,
+		maxOutputBatchMemSize: maxOutputBatchMemSize,
+	}
+	for _, allocator := range allocators {
+		spooler := &bufferedSpooler{allocator: allocator, inputTypes: inputTypes}
+		s.partitions = append(s.partitions, parallelSortPartition{
+			spooler: spooler,
+			sorter:  newSorter(allocator, spooler, inputTypes, orderingCols, maxOutputBatchMemSize).(*sortOp),
+		})
+	}
+	return s
+}
+
+// parallelSortPartition is the part of the input sorted by a single worker.
+type parallelSortPartition struct {
+	spooler *bufferedSpooler
+	sorter  *sortOp
+	err     error
+}
+
+type parallelSorter struct {
+	colexecop.OneInputNode
+	colexecop.InitHelper
+
+	inputTypes            []*types.T
+	orderingCols          []execinfrapb.Ordering_Column
+	maxOutputBatchMemSize int64
+	partitions            []parallelSortPartition
+
+	// state is the current state of the sort.
+	state sortState
+	// merger merges the sorted partitions.
+	merger   colexecop.Operator
+	exported int
+}
+
+var _ colexecop.BufferingInMemoryOperator = &parallelSorter{}
+
+func (s *parallelSorter) Init(ctx context.Context) {
+	if !s.InitHelper.Init(ctx) {
+		return
+	}
+	s.Input.Init(s.Ctx)
+	for i := range s.partitions {
+		s.partitions[i].sorter.Init(s.Ctx)
+	}
+}
+
+func (s *parallelSorter) Next() coldata.Batch {
+	for {
+		switch s.state {
+		case sortSpooling:
+			s.spool()
+			s.state = sortSorting
+		case sortSorting:
+			s.sort()
+			s.state = sortEmitting
+		case sortEmitting:
+			return s.merger.Next()
+		default:
+			colexecerror.InternalError(errors.AssertionFailedf("invalid sort state %v", s.state))
+			// This code is unreachable, but the compiler cannot infer that.
+			return nil
+		}
+	}
+}
+
+// spool appends all input tuples to the partitions, chunk by chunk.
+func (s *parallelSorter) spool() {
+	var numBatches int
+	for batch := s.Input.Next(); batch.Length() != 0; batch = s.Input.Next() {
+		p := s.partitions[(numBatches/parallelSortChunkNumBatches)%len(s.partitions)]
+		p.spooler.bufferedTuples.AppendTuples(batch, 0 /* startIdx */, batch.Length())
+		numBatches++
+	}
+}
+
+// sort sorts all non-empty partitions concurrently and sets up the merger of
+// the sorted partitions.
+func (s *parallelSorter) sort() {
+	var wg sync.WaitGroup
+	var inputs []colexecargs.OpWithMetaInfo
+	for i := range s.partitions {
+		p := &s.partitions[i]
+		if p.spooler.getNumTuples() == 0 {
+			continue
+		}
+		inputs = append(inputs, colexecargs.OpWithMetaInfo{Root: p.sorter})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.err = colexecerror.CatchVectorizedRuntimeError(p.sorter.spoolAndSort)
+		}()
+	}
+	wg.Wait()
+	for i := range s.partitions {
+		if err := s.partitions[i].err; err != nil {
+			// Propagate the error (which might be an out of memory error that
+			// makes the disk spiller fall back to the external sort) to the
+			// main goroutine. The error has already been processed by the
+			// catcher of the worker, so it is propagated unchanged: wrapping
+			// it would hide internal and storage errors from the catchers
+			// up the stack.
+			colexecerror.InternalError(err)
+		}
+	}
+	if len(inputs) == 1 {
+		s.merger = inputs[0].Root
+		return
+	}
+	// The merger is only used by the main goroutine after all workers have
+	// finished, so it can use the allocator of any of them.
+	merger := NewOrderedSynchronizer(
+		s.partitions[0].spooler.allocator, s.maxOutputBatchMemSize, inputs, s.inputTypes,
+		execinfrapb.ConvertToColumnOrdering(execinfrapb.Ordering{Columns: s.orderingCols}),
+	)
+	merger.Init(s.Ctx)
+	s.merger = merger
+}
+
+func (s *parallelSorter) ExportBuffered(input colexecop.Operator) coldata.Batch {
+	for ; s.exported < len(s.partitions); s.exported++ {
+		if b := s.partitions[s.exported].sorter.ExportBuffered(input); b.Length() > 0 {
+			return b
+		}
+	}
+	return coldata.ZeroBatch
+}
+
+// bufferedSpooler is the spooler over the tuples which have been appended to
+// it directly rather than read from an input. It is used by the parallel
+// sorter for the partitions of the input.
+type bufferedSpooler struct {
+	colexecop.ZeroInputNode
+	colexecop.NonExplainable
+
+	allocator      *colmem.Allocator
+	inputTypes     []*types.T
+	bufferedTuples *colexecutils.AppendOnlyBufferedBatch
+	windowedBatch  coldata.Batch
+}
+
+var _ spooler = &bufferedSpooler{}
+
+func (p *bufferedSpooler) init(context.Context) {
+	p.bufferedTuples = colexecutils.NewAppendOnlyBufferedBatch(p.allocator, p.inputTypes, nil /* colsToStore */)
+	p.windowedBatch = p.allocator.NewMemBatchWithFixedCapacity(p.inputTypes, 0 /* size */)
+}
+
+func (p *bufferedSpooler) spool() {}
+
+func (p *bufferedSpooler) getValues(i int) coldata.Vec {
+	return p.bufferedTuples.ColVec(i)
+}
+
+func (p *bufferedSpooler) getNumTuples() int {
+	return p.bufferedTuples.Length()
+}
+
+func (p *bufferedSpooler) getPartitionsCol() []bool {
+	return nil
+}
+
+func (p *bufferedSpooler) getWindowedBatch(startIdx, endIdx int) coldata.Batch {
+	for i := range p.inputTypes {
+		window := p.bufferedTuples.ColVec(i).Window(startIdx, endIdx)
+		p.windowedBatch.ReplaceCol(window, i)
+	}
+	p.windowedBatch.SetSelection(false)
+	p.windowedBatch.SetLength(endIdx - startIdx)
+	return p.windowedBatch
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexec
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexec/colexectestutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+)
+
+func TestParallelSort(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	var accounts []*mon.BoundAccount
+	defer func() {
+		for _, acc := range accounts {
+			acc.Close(ctx)
+		}
+	}()
+	// newAllocators returns a separate allocator for each worker since the
+	// allocators are used concurrently.
+	newAllocators := func(numWorkers int) []*colmem.Allocator {
+		allocators := make([]*colmem.Allocator, numWorkers)
+		for i := range allocators {
+			acc := testMemMonitor.MakeBoundAccount()
+			accounts = append(accounts, &acc)
+			allocators[i] = colmem.NewAllocator(ctx, &acc, testColumnFactory)
+		}
+		return allocators
+	}
+
+	for _, numWorkers := range []int{1, 3} {
+		for _, tc := range sortAllTestCases {
+			colexectestutils.RunTestsWithTyps(t, testAllocator, []colexectestutils.Tuples{tc.tuples}, [][]*types.T{tc.typs}, tc.expected, colexectestutils.OrderedVerifier,
+				func(input []colexecop.Operator) (colexecop.Operator, error) {
+					return NewParallelSorter(newAllocators(numWorkers), input[0], tc.typs, tc.ordCols, execinfra.DefaultMemoryLimit), nil
+				})
+		}
+	}
+
+	// Use an input that is large enough to be split into multiple partitions.
+	rng, _ := randutil.NewTestRand()
+	nTups := coldata.BatchSize()*parallelSortChunkNumBatches*3 + 1
+	nCols := 2
+	typs := []*types.T{types.Int, types.Int}
+	for nOrderingCols := 1; nOrderingCols <= nCols; nOrderingCols++ {
+		for _, numWorkers := range []int{2, 4} {
+			t.Run(fmt.Sprintf("nOrderingCols=%d/numWorkers=%d", nOrderingCols, numWorkers), func(t *testing.T) {
+				tups, expected, ordCols := generateRandomDataForTestSort(rng, nTups, nCols, nOrderingCols, 0 /* matchLen */)
+				colexectestutils.RunTestsWithTyps(t, testAllocator, []colexectestutils.Tuples{tups}, [][]*types.T{typs}, expected, colexectestutils.OrderedVerifier,
+					func(input []colexecop.Operator) (colexecop.Operator, error) {
+						return NewParallelSorter(newAllocators(numWorkers), input[0], typs, ordCols, execinfra.DefaultMemoryLimit), nil
+					})
+			})
+		}
+	}
+}
@@ -12,6 +12,7 @@ package colexecop
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
@@ -21,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/redact"
 )
 
 // Operator is a column vector operator that produces a Batch as output.
@@ -78,6 +80,46 @@ type KVReader interface {
 	GetScanStats() execstats.ScanStats
 }
 
+// GroupBoundariesProducer is an Operator that can determine the boundaries of
+// the groups of tuples with equal values in some of its output columns while
+// producing its batches (e.g. because its output is ordered by those columns
+// and it decodes them anyway). This allows the downstream ordered operators to
+// avoid an extra pass over the batches to detect the boundaries themselves.
+type GroupBoundariesProducer interface {
+	Operator
+	// ProduceGroupBoundaries asks the operator to populate the returned slice
+	// for every output batch such that groups[i] is true iff the i'th tuple
+	// of the batch starts a new group of tuples that have equal values in
+	// groupCols (with NULLs considered equal to each other). The first tuple
+	// of a batch starts a new group only if it differs from the last tuple of
+	// the previous batch. ok=false is returned if the operator cannot
+	// determine the boundaries for the given columns. It must be called
+	// before Init.
+	ProduceGroupBoundaries(groupCols []uint32) (groups []bool, ok bool)
+}
+
+// RuntimeFilter is a filter on tuples that is only constructed at runtime
+// (for example, from the build side of a hash join), so it might not filter
+// anything out until it is ready.
+type RuntimeFilter interface {
+	// Filter updates the selection vector and the length of the batch to drop
+	// the tuples that are known not to be needed. The length of the batch
+	// might become zero.
+	Filter(ctx context.Context, batch coldata.Batch)
+}
+
+// RuntimeFilterAcceptor is an Operator that can apply a RuntimeFilter to its
+// output as early as possible while producing its batches (e.g. right after
+// decoding the tuples).
+type RuntimeFilterAcceptor interface {
+	Operator
+	// AcceptRuntimeFilter asks the operator to apply the filter to all of its
+	// output batches. ok=false is returned if the operator cannot apply the
+	// filter, in which case the caller must apply it itself. It must be called
+	// before Init.
+	AcceptRuntimeFilter(filter RuntimeFilter) (ok bool)
+}
+
 // ZeroInputNode is an execopnode.OpNode with no inputs.
 type ZeroInputNode struct{}
 
@@ -135,6 +177,24 @@ type BufferingInMemoryOperator interface {
 	ExportBuffered(input Operator) coldata.Batch
 }
 
+var errEarlySpill = errors.New("spilling to disk early")
+
+// NewEarlySpillError returns an error that a BufferingInMemoryOperator can
+// throw (as an expected error) in order to ask the disk spiller wrapping it to
+// fall back to the disk-backed operator before the memory limit is reached
+// (for example, once it detects that it would buffer much more data than
+// expected). memMonitorName must be the name of the memory monitor used by the
+// in-memory operator.
+func NewEarlySpillError(memMonitorName, reason redact.RedactableString) error {
+	return errors.Mark(errors.Newf("%s: %s", memMonitorName, reason), errEarlySpill)
+}
+
+// IsEarlySpillError returns whether err has been created by NewEarlySpillError
+// for the operator with the given memory monitor name.
+func IsEarlySpillError(err error, memMonitorName string) bool {
+	return errors.Is(err, errEarlySpill) && strings.Contains(err.Error(), memMonitorName)
+}
+
 // Closer is an object that releases resources when Close is called. Note that
 // this interface must be implemented by all operators that could be planned on
 // top of other operators that do actually need to release the resources (e.g.
@@ -240,6 +300,14 @@ type NonExplainable interface {
 	nonExplainableMarker()
 }
 
+// VerboseExplainer is an operator that describes its configuration in the
+// output of EXPLAIN (VEC, VERBOSE).
+type VerboseExplainer interface {
+	// ExplainDetails returns the lines that are printed under the name of the
+	// operator.
+	ExplainDetails() []string
+}
+
 // InitHelper is a simple struct that helps Operators implement Init() method.
 type InitHelper struct {
 	// Ctx is the context passed on the first call to Init(). If it is nil, then
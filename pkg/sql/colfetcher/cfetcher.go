@@ -35,6 +35,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execreleasable"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/scrub"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
@@ -45,6 +46,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
 	"github.com/cockroachdb/errors"
 )
 
@@ -179,12 +181,29 @@ type cFetcherArgs struct {
 	// estimatedRowCount is the optimizer-derived number of expected rows that
 	// this fetch will produce, if non-zero.
 	estimatedRowCount uint64
+	// minBatchSize, if positive, is the capacity of the first output batch,
+	// overriding the capacity derived from the limit hint and
+	// estimatedRowCount.
+	minBatchSize int
 	// reverse denotes whether or not the spans should be read in reverse or not
 	// when StartScan is invoked.
 	reverse bool
 	// traceKV indicates whether or not session tracing is enabled. It is set
 	// when initializing the fetcher.
 	traceKV bool
+	// verifyChecksums, if set, makes the fetcher verify the checksum of every
+	// value it reads.
+	verifyChecksums bool
+	// minTimestamp, if set, restricts the fetcher to only the rows that have
+	// changed after this timestamp (see TableReaderSpec.MinTimestamp).
+	minTimestamp hlc.Timestamp
+	// historyStartTime and historyEndTime, if the latter is set, make the
+	// fetcher return the history of the rows between these timestamps (see
+	// TableReaderSpec.HistoryEndTime).
+	historyStartTime, historyEndTime hlc.Timestamp
+	// responseQuota, if set, is the node-wide quota pool that limits the number
+	// of bytes of KV responses in flight.
+	responseQuota *quotapool.IntPool
 }
 
 // noOutputColumn is a sentinel value to denote that a system column is not
@@ -277,8 +296,31 @@ type cFetcher struct {
 		timestampCol []apd.Decimal
 		// tableoidCol is the same as timestampCol but for the tableoid system column.
 		tableoidCol coldata.DatumVec
+
+		// lastGroupPrefix is the encoding of the group key columns of the last
+		// row (see groups). It is empty if no rows have been seen yet.
+		lastGroupPrefix []byte
+
+		// rowRejected is set when the current row doesn't satisfy the array
+		// filter, in which case it is not emitted.
+		rowRejected bool
+		// numRejectedRows is the number of rows rejected by the array filter
+		// since the last call to takeNumRejectedRows.
+		numRejectedRows int64
 	}
 
+	// groups, if set, is populated for every output batch with the boundaries
+	// of the groups of rows that have equal values in the first
+	// numGroupKeyCols key columns of the index (see setGroupBoundaries).
+	groups          []bool
+	numGroupKeyCols int
+
+	// arrayFilter, if set, is the filter of the form 'elem = ANY(array_col)'
+	// that is evaluated on the encoded values of the array column during the
+	// decoding, and the rows that don't satisfy it are skipped (see
+	// setArrayFilter).
+	arrayFilter *cFetcherArrayFilter
+
 	// scratch is a scratch space used when decoding bytes-like and decimal
 	// keys.
 	scratch []byte
@@ -302,6 +344,12 @@ func (cf *cFetcher) resetBatch() {
 		// If we have already exceeded the memory limit for the output batch, we
 		// will only be using the same batch from now on.
 		minDesiredCapacity = cf.maxCapacity
+	} else if cf.minBatchSize > 0 {
+		// The batch size was forced by the min_scan_batch_size session
+		// variable. Note that if it exceeds coldata.BatchSize,
+		// ResetMaybeReallocate will chop it down, and that the following
+		// batches are still grown by ResetMaybeReallocate as usual.
+		minDesiredCapacity = cf.minBatchSize
 	} else if cf.machine.limitHint > 0 && (cf.estimatedRowCount == 0 || uint64(cf.machine.limitHint) < cf.estimatedRowCount) {
 		// If we have a limit hint, and either
 		//   1) we don't have an estimate, or
@@ -350,6 +398,13 @@ func (cf *cFetcher) Init(
 	if tableArgs.spec.Version != descpb.IndexFetchSpecVersionInitial {
 		return errors.Newf("unsupported IndexFetchSpec version %d", tableArgs.spec.Version)
 	}
+	if cf.lockWaitPolicy == descpb.ScanLockingWaitPolicy_SKIP {
+		// SKIP LOCKED needs support from the KV layer which doesn't exist yet
+		// (there is no corresponding lock.WaitPolicy), so such queries are
+		// rejected during planning (#40476). Return an error here rather than
+		// panicking later in the KV fetcher.
+		return errors.AssertionFailedf("unsupported wait policy %s", cf.lockWaitPolicy)
+	}
 	cf.kvFetcherMemAcc = kvFetcherMemAcc
 	table := newCTableInfo()
 	nCols := tableArgs.ColIdxMap.Len()
@@ -544,6 +599,13 @@ func (cf *cFetcher) StartScan(
 		firstBatchLimit = rowinfra.KeyLimit(int(limitHint) * int(cf.table.spec.MaxKeysPerRow))
 	}
 
+	if !cf.historyEndTime.IsEmpty() {
+		cf.setFetcher(row.NewHistoryKVFetcher(
+			txn, spans, cf.historyStartTime, cf.historyEndTime, batchBytesLimit, cf.kvFetcherMemAcc,
+		), limitHint)
+		return nil
+	}
+
 	f, err := row.NewKVFetcher(
 		ctx,
 		txn,
@@ -556,7 +618,9 @@ func (cf *cFetcher) StartScan(
 		cf.lockStrength,
 		cf.lockWaitPolicy,
 		cf.lockTimeout,
+		cf.minTimestamp,
 		cf.kvFetcherMemAcc,
+		cf.responseQuota,
 		forceProductionKVBatchSize,
 	)
 	if err != nil {
@@ -683,6 +747,16 @@ func (cf *cFetcher) setNextKV(kv roachpb.KeyValue, needsCopy bool) {
 	cf.machine.nextKV = kvCopy
 }
 
+// verifyNextKVChecksum verifies the checksum of the value of the next KV to
+// process, returning an error that identifies the corrupted key on mismatch.
+func (cf *cFetcher) verifyNextKVChecksum() error {
+	kv := &cf.machine.nextKV
+	if err := kv.Value.Verify(kv.Key); err != nil {
+		return row.NewValueChecksumError(&cf.table.spec, kv.Key, err)
+	}
+	return nil
+}
+
 // NextBatch processes keys until we complete one batch of rows (subject to the
 // limit hint and the memory limit while being max coldata.BatchSize() in
 // length), which are returned in columnar format as a coldata.Batch. The batch
@@ -730,6 +804,11 @@ func (cf *cFetcher) NextBatch(ctx context.Context) (coldata.Batch, error) {
 			*/
 
 			cf.setNextKV(kv, finalReferenceToBatch)
+			if cf.verifyChecksums {
+				if err := cf.verifyNextKVChecksum(); err != nil {
+					return nil, err
+				}
+			}
 			cf.machine.state[0] = stateDecodeFirstKVOfRow
 
 		case stateResetBatch:
@@ -779,6 +858,11 @@ func (cf *cFetcher) NextBatch(ctx context.Context) (coldata.Batch, error) {
 				}
 				cf.machine.lastRowPrefix = cf.machine.nextKV.Key[:prefixLen]
 			}
+			if cf.groups != nil {
+				if err := cf.markGroupBoundary(); err != nil {
+					return nil, err
+				}
+			}
 
 			// For unique secondary indexes on tables with multiple column
 			// families, the index-key does not distinguish one row from the
@@ -862,6 +946,11 @@ func (cf *cFetcher) NextBatch(ctx context.Context) (coldata.Batch, error) {
 			// TODO(jordan): if nextKV returns newSpan = true, set the new span
 			// prefix and indicate that it needs decoding.
 			cf.setNextKV(kv, finalReferenceToBatch)
+			if cf.verifyChecksums {
+				if err := cf.verifyNextKVChecksum(); err != nil {
+					return nil, err
+				}
+			}
 			if debugState {
 				log.Infof(ctx, "decoding next key %s", cf.machine.nextKV.Key)
 			}
@@ -914,6 +1003,18 @@ func (cf *cFetcher) NextBatch(ctx context.Context) (coldata.Batch, error) {
 			if err := cf.fillNulls(); err != nil {
 				return nil, err
 			}
+			if cf.machine.rowRejected {
+				// The row doesn't satisfy the array filter, so we reuse its
+				// position in the batch for the next row. All values will be
+				// overwritten, but the nulls have to be unset explicitly.
+				for _, nulls := range cf.machine.colvecs.Nulls {
+					nulls.UnsetNull(cf.machine.rowIdx)
+				}
+				cf.machine.rowRejected = false
+				cf.machine.numRejectedRows++
+				cf.shiftState()
+				continue
+			}
 			// Note that we haven't set the tableoid value (if that system
 			// column is requested) yet, but it is ok for the purposes of the
 			// memory accounting - oids are fixed length values and, thus, have
@@ -977,6 +1078,69 @@ func (cf *cFetcher) pushState(state fetcherState) {
 	cf.machine.state[0] = state
 }
 
+// setGroupBoundaries makes the fetcher populate groups for every output batch
+// so that groups[i] is true iff the i'th row of the batch has different values
+// in the first numKeyCols key columns of the index than the previous row. The
+// rows must be fetched in the index order, and groups must have enough
+// capacity for the largest output batch.
+func (cf *cFetcher) setGroupBoundaries(groups []bool, numKeyCols int) {
+	cf.groups = groups
+	cf.numGroupKeyCols = numKeyCols
+}
+
+// cFetcherArrayFilter is the filter of the form 'elem = ANY(array_col)' that
+// is pushed into the cFetcher.
+type cFetcherArrayFilter struct {
+	// vecIdx is the ordinal of the array column among the fetched columns.
+	vecIdx  int
+	elem    tree.Datum
+	matcher valueside.ArrayElementMatcher
+}
+
+// setArrayFilter makes the cFetcher skip all rows in which the array column
+// with the given ordinal doesn't contain the given element. The column must
+// not be part of the index key (so that its value encoding is always
+// available), and the filter must be set before the first batch is fetched.
+func (cf *cFetcher) setArrayFilter(vecIdx int, elem tree.Datum) error {
+	matcher, err := valueside.MakeArrayElementMatcher(elem)
+	if err != nil {
+		return err
+	}
+	cf.arrayFilter = &cFetcherArrayFilter{vecIdx: vecIdx, elem: elem, matcher: matcher}
+	return nil
+}
+
+// takeNumRejectedRows returns the number of rows rejected by the array filter
+// since the last call and resets the counter.
+func (cf *cFetcher) takeNumRejectedRows() int64 {
+	n := cf.machine.numRejectedRows
+	cf.machine.numRejectedRows = 0
+	return n
+}
+
+// markGroupBoundary records whether the current row starts a new group (see
+// setGroupBoundaries). The key encoding is canonical (i.e. two values have
+// the same encoding iff they are equal, with NULLs being equal to each other),
+// so the boundaries are found by comparing the encoded key columns without
+// decoding them.
+func (cf *cFetcher) markGroupBoundary() error {
+	key := cf.machine.nextKV.Key[cf.table.spec.KeyPrefixLength:]
+	remaining := key
+	for i := 0; i < cf.numGroupKeyCols; i++ {
+		var err error
+		if remaining, err = keyside.Skip(remaining); err != nil {
+			return err
+		}
+	}
+	groupPrefix := key[:len(key)-len(remaining)]
+	newGroup := len(cf.machine.lastGroupPrefix) == 0 || !bytes.Equal(groupPrefix, cf.machine.lastGroupPrefix)
+	cf.groups[cf.machine.rowIdx] = newGroup
+	if newGroup {
+		cf.machine.lastGroupPrefix = append(cf.machine.lastGroupPrefix[:0], groupPrefix...)
+	}
+	return nil
+}
+
 // getDatumAt returns the converted datum object at the given (colIdx, rowIdx).
 // This function is meant for tracing and should not be used in hot paths.
 func (cf *cFetcher) getDatumAt(colIdx int, rowIdx int) tree.Datum {
@@ -1158,6 +1322,19 @@ func (cf *cFetcher) processValueSingle(
 		if len(val.RawBytes) == 0 {
 			return prettyKey, "", nil
 		}
+		if f := cf.arrayFilter; f != nil && f.vecIdx == idx {
+			b, err := val.GetBytes()
+			if err != nil {
+				return "", "", err
+			}
+			if matches, err := f.matcher.Matches(b); err != nil {
+				return "", "", err
+			} else if !matches {
+				cf.machine.rowRejected = true
+				cf.machine.remainingValueColsByIdx.Remove(idx)
+				return prettyKey, "<rejected>", nil
+			}
+		}
 		typ := cf.table.spec.FetchedColumns[idx].Type
 		err := colencoding.UnmarshalColumnValueToCol(
 			&table.da, &cf.machine.colvecs, idx, cf.machine.rowIdx, typ, val,
@@ -1250,6 +1427,25 @@ func (cf *cFetcher) processValueBytes(
 			prettyKey = fmt.Sprintf("%s/%s", prettyKey, table.spec.FetchedColumns[vecIdx].Name)
 		}
 
+		if f := cf.arrayFilter; f != nil && f.vecIdx == vecIdx {
+			rejected, err := cf.rejectedByArrayFilter(valueBytes[dataOffset:], typ)
+			if err != nil {
+				return "", "", err
+			}
+			if rejected {
+				// There is no need to decode the array since the row won't be
+				// emitted.
+				cf.machine.rowRejected = true
+				len, err := encoding.PeekValueLengthWithOffsetsAndType(valueBytes, dataOffset, typ)
+				if err != nil {
+					return "", "", err
+				}
+				valueBytes = valueBytes[len:]
+				cf.machine.remainingValueColsByIdx.Remove(vecIdx)
+				continue
+			}
+		}
+
 		valueBytes, err = colencoding.DecodeTableValueToCol(
 			&table.da, &cf.machine.colvecs, vecIdx, cf.machine.rowIdx, typ,
 			dataOffset, cf.table.typs[vecIdx], valueBytes,
@@ -1271,6 +1467,22 @@ func (cf *cFetcher) processValueBytes(
 	return prettyKey, prettyValue, nil
 }
 
+// rejectedByArrayFilter returns whether the row doesn't satisfy the array
+// filter given the tagged value of the array column (with the tag already
+// stripped). NULL arrays never satisfy the filter.
+func (cf *cFetcher) rejectedByArrayFilter(buf []byte, typ encoding.Type) (bool, error) {
+	if typ == encoding.Null {
+		return true, nil
+	}
+	// Skip the encoded data length.
+	buf, _, _, err := encoding.DecodeNonsortingUvarint(buf)
+	if err != nil {
+		return false, err
+	}
+	matches, err := cf.arrayFilter.matcher.Matches(buf)
+	return !matches, err
+}
+
 func (cf *cFetcher) fillNulls() error {
 	table := cf.table
 	if cf.machine.remainingValueColsByIdx.Empty() {
@@ -1294,6 +1506,10 @@ func (cf *cFetcher) fillNulls() error {
 				table.spec.TableName, table.spec.FetchedColumns[i].Name, table.spec.IndexName,
 				strings.Join(indexColNames, ","), indexColValues.String()))
 		}
+		if f := cf.arrayFilter; f != nil && f.vecIdx == i {
+			// The array column is NULL, so the filter isn't satisfied.
+			cf.machine.rowRejected = true
+		}
 		cf.machine.colvecs.Nulls[i].SetNull(cf.machine.rowIdx)
 	}
 	return nil
@@ -1354,12 +1570,52 @@ func (cf *cFetcher) getBytesRead() int64 {
 	return cf.bytesRead
 }
 
+// getMemUsage returns the amount of memory currently registered with the
+// cFetcher's allocator and the memory account of the underlying KV fetcher.
+func (cf *cFetcher) getMemUsage() int64 {
+	var memUsage int64
+	if cf.accountingHelper.Allocator != nil {
+		memUsage += cf.accountingHelper.Allocator.Used()
+	}
+	if cf.kvFetcherMemAcc != nil {
+		memUsage += cf.kvFetcherMemAcc.Used()
+	}
+	return memUsage
+}
+
 var cFetcherPool = sync.Pool{
 	New: func() interface{} {
 		return &cFetcher{}
 	},
 }
 
+// trimRetainedCapacity drops the slices of the cFetcher (including the ones of
+// its table) with the capacity exceeding maxCapacity so that a single wide
+// scan doesn't make the pooled objects retain a lot of memory. It should be
+// called right before Release.
+func (cf *cFetcher) trimRetainedCapacity(maxCapacity int) {
+	if cap(cf.scratch) > maxCapacity {
+		cf.scratch = nil
+	}
+	if cap(cf.machine.colvecs.Nulls) > maxCapacity {
+		cf.machine.colvecs = coldata.TypedVecs{}
+	}
+	if t := cf.table; t != nil {
+		if cap(t.indexColOrdinals) > maxCapacity {
+			t.indexColOrdinals = nil
+		}
+		if cap(t.extraValColOrdinals) > maxCapacity {
+			t.extraValColOrdinals = nil
+		}
+		if m := t.orderedColIdxMap; cap(m.vals) > maxCapacity || cap(m.ords) > maxCapacity {
+			m.vals, m.ords = nil, nil
+		}
+		if t.cFetcherTableArgs != nil && cap(t.typs) > maxCapacity {
+			t.typs = nil
+		}
+	}
+}
+
 func (cf *cFetcher) Release() {
 	cf.accountingHelper.Release()
 	if cf.table != nil {
@@ -24,6 +24,11 @@ import (
 // cFetcherTableArgs describes the information about the index we're fetching
 // from. Note that only columns that need to be fetched (i.e. requested by the
 // caller) are included in the internal state.
+//
+// For inverted indexes, the inverted column (if fetched) has the inverted key
+// type (see rowenc.InitIndexFetchSpec) and is populated with the raw encoded
+// key, while the prefix columns of multi-column inverted indexes are decoded
+// like any other key column.
 type cFetcherTableArgs struct {
 	spec descpb.IndexFetchSpec
 	// ColIdxMap is a mapping from ColumnID to the ordinal of the corresponding
@@ -73,15 +78,22 @@ func populateTableArgs(
 	// they are hydrated. In row execution engine it is done during the processor
 	// initialization, but neither ColBatchScan nor cFetcher are processors, so we
 	// need to do the hydration ourselves.
-	resolver := flowCtx.NewTypeResolver(flowCtx.Txn)
-	for i := range args.spec.FetchedColumns {
-		if err := typedesc.EnsureTypeIsHydrated(ctx, args.spec.FetchedColumns[i].Type, &resolver); err != nil {
-			return nil, err
+	//
+	// On the gateway the types come from the leased descriptors and are
+	// already hydrated, so we only create the type resolver (which escapes to
+	// the heap) when it is actually needed. This keeps the repeated executions
+	// of hot point lookups from paying for it.
+	if needsHydration(args.spec.FetchedColumns) || needsHydrationKeyCols(args.spec.KeyAndSuffixColumns) {
+		resolver := flowCtx.NewTypeResolver(flowCtx.Txn)
+		for i := range args.spec.FetchedColumns {
+			if err := typedesc.EnsureTypeIsHydrated(ctx, args.spec.FetchedColumns[i].Type, &resolver); err != nil {
+				return nil, err
+			}
 		}
-	}
-	for i := range args.spec.KeyAndSuffixColumns {
-		if err := typedesc.EnsureTypeIsHydrated(ctx, args.spec.KeyAndSuffixColumns[i].Type, &resolver); err != nil {
-			return nil, err
+		for i := range args.spec.KeyAndSuffixColumns {
+			if err := typedesc.EnsureTypeIsHydrated(ctx, args.spec.KeyAndSuffixColumns[i].Type, &resolver); err != nil {
+				return nil, err
+			}
 		}
 	}
 	args.populateTypes(args.spec.FetchedColumns)
@@ -91,3 +103,35 @@ func populateTableArgs(
 
 	return args, nil
 }
+
+// typeNeedsHydration returns whether typedesc.EnsureTypeIsHydrated would need
+// to resolve the type descriptor of t (or of any of its tuple contents).
+func typeNeedsHydration(t *types.T) bool {
+	if t.Family() == types.TupleFamily {
+		for _, typ := range t.TupleContents() {
+			if typeNeedsHydration(typ) {
+				return true
+			}
+		}
+		return false
+	}
+	return t.UserDefined() && !t.IsHydrated()
+}
+
+func needsHydration(cols []descpb.IndexFetchSpec_Column) bool {
+	for i := range cols {
+		if typeNeedsHydration(cols[i].Type) {
+			return true
+		}
+	}
+	return false
+}
+
+func needsHydrationKeyCols(cols []descpb.IndexFetchSpec_KeyColumn) bool {
+	for i := range cols {
+		if typeNeedsHydration(cols[i].Type) {
+			return true
+		}
+	}
+	return false
+}
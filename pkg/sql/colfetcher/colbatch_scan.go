@@ -12,11 +12,15 @@ package colfetcher
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"math/rand"
 	"sync"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
 	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
@@ -24,10 +28,16 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execreleasable"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/limit"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
 )
@@ -40,6 +50,45 @@ import (
 // should get rid off table readers entirely. We will have to be careful about
 // propagating the metadata though.
 
+var maxConcurrentScans = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.distsql.max_concurrent_scans",
+	"maximum number of ColBatchScans that can be reading from KV concurrently "+
+		"on a single node; scans are queued once the limit is reached (0 = no limit)",
+	0,
+	settings.NonNegativeInt,
+)
+
+// maxRetainedCapacity is the maximum capacity of the slices that the pooled
+// ColBatchScans and cFetchers keep for reuse when they are released.
+var maxRetainedCapacity = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.distsql.scan_pool.max_retained_capacity",
+	"maximum capacity (in number of elements) of the slices that pooled "+
+		"vectorized scan objects retain once released; larger slices are "+
+		"dropped so that memory returns to steady state after wide scans",
+	1024,
+	settings.NonNegativeInt,
+)
+
+// MakeAndRegisterScanLimiter makes a concurrency limiter for ColBatchScans and
+// registers it with the setting on-change hook; it should be called only once
+// during server setup due to the side-effects of the on-change registration.
+func MakeAndRegisterScanLimiter(sv *settings.Values) *limit.ConcurrentRequestLimiter {
+	getLimit := func() int {
+		newLimit := int(maxConcurrentScans.Get(sv))
+		if newLimit == 0 {
+			newLimit = math.MaxInt
+		}
+		return newLimit
+	}
+	l := limit.MakeConcurrentRequestLimiter("colbatchscan-limit", getLimit())
+	maxConcurrentScans.SetOnChange(sv, func(ctx context.Context) {
+		l.SetLimit(getLimit())
+	})
+	return &l
+}
+
 // ColBatchScan is the exec.Operator implementation of TableReader. It reads a
 // table from kv, presenting it as coldata.Batches via the exec.Operator
 // interface.
@@ -54,14 +103,39 @@ type ColBatchScan struct {
 	limitHint       rowinfra.RowLimit
 	batchBytesLimit rowinfra.BytesLimit
 	parallelize     bool
+	// remoteSpans, if set, are the spans that are scanned only once the scan
+	// of Spans is exhausted and hasn't satisfied the hard limit. It is unset
+	// once the remote scan is started.
+	remoteSpans roachpb.Spans
+	// hardLimit, if non-zero, is the number of rows after which the consumer
+	// of the ColBatchScan stops reading (i.e. the limit and the offset of the
+	// PostProcessSpec). It is only set when all rows read by the cFetcher are
+	// emitted.
+	hardLimit int64
+	// sampler, if set, performs the BERNOULLI sampling of the batches right
+	// after they are produced by the cFetcher.
+	sampler *bernoulliSampler
+	// runtimeFilter, if set, is applied to the batches right after they are
+	// produced by the cFetcher (and sampled).
+	runtimeFilter colexecop.RuntimeFilter
 	// tracingSpan is created when the stats should be collected for the query
 	// execution, and it will be finished when closing the operator.
 	tracingSpan *tracing.Span
-	mu          struct {
+	// queueWaitTime is the total amount of time this ColBatchScan spent
+	// waiting for a slot in the node-wide scan limiter.
+	queueWaitTime time.Duration
+	// bytesReadRecorded is the number of bytes read that have already been
+	// reported to the node-wide TableReadTracker. It allows Close to be called
+	// multiple times without counting the same bytes twice.
+	bytesReadRecorded int64
+	mu                struct {
 		syncutil.Mutex
 		// rowsRead contains the number of total rows this ColBatchScan has
 		// returned so far.
 		rowsRead int64
+		// maxMemUsage is the peak memory usage of the cFetcher (including the
+		// KV fetcher) observed so far.
+		maxMemUsage int64
 	}
 	// ResultTypes is the slice of resulting column types from this operator.
 	// It should be used rather than the slice of column types from the scanned
@@ -78,6 +152,9 @@ type ScanOperator interface {
 }
 
 var _ ScanOperator = &ColBatchScan{}
+var _ colexecop.GroupBoundariesProducer = &ColBatchScan{}
+var _ colexecop.RuntimeFilterAcceptor = &ColBatchScan{}
+var _ colexecop.VerboseExplainer = &ColBatchScan{}
 
 // Init initializes a ColBatchScan.
 func (s *ColBatchScan) Init(ctx context.Context) {
@@ -89,36 +166,242 @@ func (s *ColBatchScan) Init(ctx context.Context) {
 	// cFetcher. Note that ProcessorSpan method itself will check whether
 	// tracing is enabled.
 	s.Ctx, s.tracingSpan = execinfra.ProcessorSpan(s.Ctx, "colbatchscan")
+	if len(s.Spans) == 0 {
+		// All spans have been skipped by the SYSTEM sampling.
+		return
+	}
+	s.startScan(s.Spans, s.limitHint)
+	s.mu.Lock()
+	s.updateMaxMemUsageLocked()
+	s.mu.Unlock()
+}
+
+// startScan starts the scan of the given spans.
+func (s *ColBatchScan) startScan(spans roachpb.Spans, limitHint rowinfra.RowLimit) {
 	limitBatches := !s.parallelize
 	if err := s.cf.StartScan(
 		s.Ctx,
 		s.flowCtx.Txn,
-		s.Spans,
+		spans,
 		s.bsHeader,
 		limitBatches,
 		s.batchBytesLimit,
-		s.limitHint,
+		limitHint,
 		s.flowCtx.EvalCtx.TestingKnobs.ForceProductionValues,
 	); err != nil {
 		colexecerror.InternalError(err)
 	}
+	if tracker := s.flowCtx.Cfg.TableReadTracker; tracker != nil && tracker.ExceedsQuota(s.cf.table.spec.TableID) {
+		// This table has been read too much from recently, so we ask the
+		// admission control to deprioritize our reads in favor of other work.
+		s.cf.fetcher.LowerAdmissionPriority(overQuotaAdmissionPriority)
+	}
 }
 
-// Next is part of the Operator interface.
-func (s *ColBatchScan) Next() coldata.Batch {
+// maybeStartRemoteScan starts the scan of the remote spans if they haven't
+// been scanned yet and the scan of the local spans didn't satisfy the hard
+// limit. It returns whether the remote scan has been started.
+func (s *ColBatchScan) maybeStartRemoteScan() bool {
+	if len(s.remoteSpans) == 0 {
+		return false
+	}
+	remoteSpans := s.remoteSpans
+	s.remoteSpans = nil
+	limitHint := s.limitHint
+	if s.hardLimit != 0 {
+		rowsRead := s.GetRowsRead()
+		if rowsRead >= s.hardLimit {
+			// The local spans produced enough rows, so there is no need to
+			// reach out to the remote regions.
+			return false
+		}
+		limitHint = rowinfra.RowLimit(s.hardLimit - rowsRead)
+	}
+	s.startScan(remoteSpans, limitHint)
+	return true
+}
+
+// nextBatch returns the next batch from the cFetcher. If the node-wide scan
+// limiter is configured, a slot in it is held only while the batch is being
+// fetched: the consumers of the ColBatchScan might be waiting on other scans
+// (e.g. on the other input of a join), so holding the slot until the scan is
+// closed could lead to deadlocks. The limiter is skipped entirely when
+// sql.distsql.max_concurrent_scans is 0 (unlimited).
+func (s *ColBatchScan) nextBatch() coldata.Batch {
+	if limiter := s.flowCtx.Cfg.ColBatchScanLimiter; limiter != nil && maxConcurrentScans.Get(&s.flowCtx.Cfg.Settings.SV) != 0 {
+		start := timeutil.Now()
+		res, err := limiter.Begin(s.Ctx)
+		if err != nil {
+			colexecerror.ExpectedError(err)
+		}
+		defer res.Release()
+		s.queueWaitTime += timeutil.Since(start)
+	}
 	bat, err := s.cf.NextBatch(s.Ctx)
 	if err != nil {
 		colexecerror.InternalError(err)
 	}
-	if bat.Selection() != nil {
-		colexecerror.InternalError(errors.AssertionFailedf("unexpectedly a selection vector is set on the batch coming from CFetcher"))
-	}
-	s.mu.Lock()
-	s.mu.rowsRead += int64(bat.Length())
-	s.mu.Unlock()
 	return bat
 }
 
+// Next is part of the Operator interface.
+func (s *ColBatchScan) Next() coldata.Batch {
+	if len(s.Spans) == 0 {
+		return coldata.ZeroBatch
+	}
+	for {
+		bat := s.nextBatch()
+		if bat.Length() == 0 && s.maybeStartRemoteScan() {
+			bat = s.nextBatch()
+		}
+		if bat.Selection() != nil {
+			colexecerror.InternalError(errors.AssertionFailedf("unexpectedly a selection vector is set on the batch coming from CFetcher"))
+		}
+		s.mu.Lock()
+		// The rows rejected by the array filter have been read too.
+		s.mu.rowsRead += int64(bat.Length()) + s.cf.takeNumRejectedRows()
+		s.updateMaxMemUsageLocked()
+		s.mu.Unlock()
+		if s.sampler != nil && bat.Length() > 0 {
+			s.sampler.sample(bat)
+			if bat.Length() == 0 {
+				continue
+			}
+		}
+		if s.runtimeFilter == nil || bat.Length() == 0 {
+			return bat
+		}
+		s.runtimeFilter.Filter(s.Ctx, bat)
+		if bat.Length() > 0 {
+			return bat
+		}
+	}
+}
+
+// AcceptRuntimeFilter implements the colexecop.RuntimeFilterAcceptor
+// interface.
+func (s *ColBatchScan) AcceptRuntimeFilter(filter colexecop.RuntimeFilter) (ok bool) {
+	if s.hardLimit != 0 {
+		// The hard limit is enforced based on the number of rows read, so it
+		// would be incorrect to drop any of them.
+		return false
+	}
+	s.runtimeFilter = filter
+	return true
+}
+
+// AcceptArrayFilter asks the ColBatchScan to skip all rows in which the array
+// column with the given ordinal doesn't contain the given element (i.e. to
+// evaluate 'elem = ANY(array_col)' filter) while decoding them, without
+// materializing the arrays of such rows. ok=false is returned if the filter
+// cannot be evaluated this way. The caller is still expected to apply the
+// filter itself, so an accepted filter only reduces the amount of decoding
+// work. It must be called before Init.
+func (s *ColBatchScan) AcceptArrayFilter(colIdx int, elem tree.Datum) (ok bool) {
+	if s.hardLimit != 0 || s.sampler != nil || s.cf.groups != nil || s.cf.arrayFilter != nil {
+		// The hard limit and the sampling are based on the rows produced by
+		// the cFetcher, and so are the group boundaries.
+		return false
+	}
+	table := s.cf.table
+	if colIdx < 0 || colIdx >= len(table.typs) || table.typs[colIdx].Family() != types.ArrayFamily {
+		return false
+	}
+	if !table.neededValueColsByIdx.Contains(colIdx) || table.compositeIndexColOrdinals.Contains(colIdx) {
+		// Only the columns that are always value encoded are supported.
+		return false
+	}
+	if elem == tree.DNull || !elem.ResolvedType().Equivalent(table.typs[colIdx].ArrayContents()) ||
+		!valueside.CanMatchEncodedArrayElements(table.typs[colIdx].ArrayContents()) {
+		return false
+	}
+	return s.cf.setArrayFilter(colIdx, elem) == nil
+}
+
+// ProduceGroupBoundaries implements the colexecop.GroupBoundariesProducer
+// interface. The boundaries can be determined when the grouping columns are
+// exactly the first len(groupCols) key columns of the scanned index (in any
+// order) since the rows are fetched in the index order.
+func (s *ColBatchScan) ProduceGroupBoundaries(groupCols []uint32) (groups []bool, ok bool) {
+	keyCols := s.cf.table.spec.KeyColumns()
+	if len(groupCols) == 0 || len(groupCols) > len(keyCols) {
+		return nil, false
+	}
+	if s.runtimeFilter != nil || s.sampler != nil || s.cf.arrayFilter != nil {
+		// The boundaries are set for the tuples before they are filtered.
+		return nil, false
+	}
+	if len(s.remoteSpans) > 0 {
+		// The remote spans are scanned after the local ones, so the output
+		// isn't ordered by the index across them.
+		return nil, false
+	}
+	var groupColsSet util.FastIntSet
+	for _, colIdx := range groupCols {
+		groupColsSet.Add(int(colIdx))
+	}
+	if groupColsSet.Len() != len(groupCols) {
+		return nil, false
+	}
+	for i := range groupCols {
+		if keyCols[i].IsInverted {
+			return nil, false
+		}
+		if colIdx := s.cf.table.indexColOrdinals[i]; colIdx == -1 || !groupColsSet.Contains(colIdx) {
+			return nil, false
+		}
+	}
+	groups = make([]bool, coldata.BatchSize())
+	s.cf.setGroupBoundaries(groups, len(groupCols))
+	return groups, true
+}
+
+// ExplainDetails implements the colexecop.VerboseExplainer interface.
+func (s *ColBatchScan) ExplainDetails() []string {
+	details := []string{fmt.Sprintf("parallelize: %t", s.parallelize)}
+	switch s.batchBytesLimit {
+	case 0:
+		details = append(details, "batch bytes limit: none")
+	case rowinfra.GetDefaultBatchBytesLimit(s.flowCtx.EvalCtx.TestingKnobs.ForceProductionValues):
+		details = append(details, "batch bytes limit: default")
+	default:
+		details = append(details, fmt.Sprintf("batch bytes limit: %s", humanizeutil.IBytes(int64(s.batchBytesLimit))))
+	}
+	if s.limitHint == 0 {
+		details = append(details, "limit hint: none")
+	} else {
+		details = append(details, fmt.Sprintf("limit hint: %d", s.limitHint))
+	}
+	details = append(details,
+		fmt.Sprintf("locking strength: %s", s.cf.lockStrength.PrettyString()),
+		fmt.Sprintf("locking wait policy: %s", s.cf.lockWaitPolicy.PrettyString()),
+	)
+	if f := s.cf.arrayFilter; f != nil {
+		details = append(details, fmt.Sprintf(
+			"array filter: %s = ANY(%s)", f.elem, s.cf.table.spec.FetchedColumns[f.vecIdx].Name,
+		))
+	}
+	if h := s.bsHeader; h != nil {
+		bounds := fmt.Sprintf("bounded staleness: min timestamp bound %s", h.MinTimestampBound)
+		if h.MinTimestampBoundStrict {
+			bounds += " (strict)"
+		}
+		if !h.MaxTimestampBound.IsEmpty() {
+			bounds += fmt.Sprintf(", max timestamp bound %s", h.MaxTimestampBound)
+		}
+		details = append(details, bounds)
+	}
+	return details
+}
+
+// updateMaxMemUsageLocked updates the memory usage watermark of the
+// ColBatchScan. s.mu must be held.
+func (s *ColBatchScan) updateMaxMemUsageLocked() {
+	if memUsage := s.cf.getMemUsage(); memUsage > s.mu.maxMemUsage {
+		s.mu.maxMemUsage = memUsage
+	}
+}
+
 // DrainMeta is part of the colexecop.MetadataSource interface.
 func (s *ColBatchScan) DrainMeta() []execinfrapb.ProducerMetadata {
 	var trailingMeta []execinfrapb.ProducerMetadata
@@ -159,6 +442,12 @@ func (s *ColBatchScan) GetRowsRead() int64 {
 	return s.mu.rowsRead
 }
 
+func (s *ColBatchScan) getMaxMemUsage() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mu.maxMemUsage
+}
+
 // GetCumulativeContentionTime is part of the colexecop.KVReader interface.
 func (s *ColBatchScan) GetCumulativeContentionTime() time.Duration {
 	return execstats.GetCumulativeContentionTime(s.Ctx)
@@ -166,7 +455,10 @@ func (s *ColBatchScan) GetCumulativeContentionTime() time.Duration {
 
 // GetScanStats is part of the colexecop.KVReader interface.
 func (s *ColBatchScan) GetScanStats() execstats.ScanStats {
-	return execstats.GetScanStats(s.Ctx)
+	ss := execstats.GetScanStats(s.Ctx)
+	ss.QueueWaitTime = s.queueWaitTime
+	ss.MaxMemUsage = s.getMaxMemUsage()
+	return ss
 }
 
 var colBatchScanPool = sync.Pool{
@@ -202,8 +494,14 @@ func NewColBatchScan(
 		flowCtx.EvalCtx.SessionData().LockTimeout,
 		execinfra.GetWorkMemLimit(flowCtx),
 		estimatedRowCount,
+		int(flowCtx.EvalCtx.SessionData().MinScanBatchSize),
 		spec.Reverse,
 		flowCtx.TraceKV,
+		flowCtx.EvalCtx.SessionData().VerifyScanChecksums,
+		spec.MinTimestamp,
+		spec.HistoryStartTime,
+		spec.HistoryEndTime,
+		flowCtx.Cfg.KVResponseQuota,
 	}
 
 	if err = fetcher.Init(allocator, kvFetcherMemAcc, tableArgs); err != nil {
@@ -228,11 +526,29 @@ func NewColBatchScan(
 		}
 	}
 
+	spans := spec.Spans
+	var sampler *bernoulliSampler
+	if sample := spec.Sample; sample != nil {
+		rng := rand.New(rand.NewSource(rand.Int63()))
+		switch sample.Method {
+		case execinfrapb.TableSampleSpec_BERNOULLI:
+			sampler = newBernoulliSampler(rng, sample.Probability)
+		case execinfrapb.TableSampleSpec_SYSTEM:
+			// Prune the spans up front so that the skipped ones aren't read at
+			// all.
+			spans = execinfra.SampleSpans(rng, spans, sample.Probability)
+		default:
+			fetcher.Release()
+			return nil, errors.AssertionFailedf("unexpected table sample method %s", sample.Method)
+		}
+	}
+
 	s := colBatchScanPool.Get().(*ColBatchScan)
-	s.Spans = spec.Spans
+	s.Spans = spans
 	if !flowCtx.Local {
 		// Make a copy of the spans so that we could get the misplanned ranges
-		// info.
+		// info. The remote spans are not included since the leases of their
+		// ranges are expected to be held by other nodes.
 		allocator.AdjustMemoryUsage(s.Spans.MemUsage())
 		s.MakeSpansCopy()
 	}
@@ -250,6 +566,15 @@ func NewColBatchScan(
 		}
 	}
 
+	var hardLimit int64
+	// The remote scan is skipped based on the number of rows read by the
+	// cFetcher, so the hard limit is only used when every row read is also
+	// emitted, i.e. when there is neither the filter nor the sampling (which
+	// are applied after the rows have been read).
+	if len(spec.RemoteSpans) > 0 && post.Limit != 0 && post.Filter.Empty() && sampler == nil {
+		hardLimit = int64(post.Limit + post.Offset)
+	}
+
 	*s = ColBatchScan{
 		SpansWithCopy:   s.SpansWithCopy,
 		flowCtx:         flowCtx,
@@ -258,6 +583,9 @@ func NewColBatchScan(
 		limitHint:       limitHint,
 		batchBytesLimit: batchBytesLimit,
 		parallelize:     spec.Parallelize,
+		remoteSpans:     spec.RemoteSpans,
+		hardLimit:       hardLimit,
+		sampler:         sampler,
 		ResultTypes:     tableArgs.typs,
 	}
 	return s, nil
@@ -265,9 +593,14 @@ func NewColBatchScan(
 
 // Release implements the execinfra.Releasable interface.
 func (s *ColBatchScan) Release() {
+	maxCapacity := int(maxRetainedCapacity.Get(&s.flowCtx.Cfg.Settings.SV))
+	s.cf.trimRetainedCapacity(maxCapacity)
 	s.cf.Release()
 	// Deeply reset the spans so that we don't hold onto the keys of the spans.
 	s.SpansWithCopy.Reset()
+	if cap(s.SpansCopy) > maxCapacity {
+		s.SpansCopy = nil
+	}
 	*s = ColBatchScan{
 		SpansWithCopy: s.SpansWithCopy,
 	}
@@ -281,6 +614,11 @@ func (s *ColBatchScan) Close(context.Context) error {
 	// span.
 	ctx := s.EnsureCtx()
 	s.cf.Close(ctx)
+	if tracker := s.flowCtx.Cfg.TableReadTracker; tracker != nil {
+		bytesRead := s.GetBytesRead()
+		tracker.RecordBytesRead(s.cf.table.spec.TableID, bytesRead-s.bytesReadRecorded)
+		s.bytesReadRecorded = bytesRead
+	}
 	if s.tracingSpan != nil {
 		s.tracingSpan.Finish()
 		s.tracingSpan = nil
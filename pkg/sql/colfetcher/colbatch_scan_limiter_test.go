@@ -0,0 +1,75 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colfetcher_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestColBatchScanLimiterWithJoins verifies that the flows with multiple
+// ColBatchScans don't deadlock when the node-wide scan limiter allows only a
+// single scan to read from KV at a time.
+func TestColBatchScanLimiterWithJoins(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{
+		Knobs: base.TestingKnobs{
+			// Make the scans issue a KV request for every row so that the
+			// fetches of both inputs of the join are interleaved.
+			DistSQL: &execinfra.TestingKnobs{TableReaderBatchBytesLimit: 1},
+		},
+	})
+	defer s.Stopper().Stop(ctx)
+
+	runner := sqlutils.MakeSQLRunner(db)
+	runner.Exec(t, "SET CLUSTER SETTING sql.distsql.max_concurrent_scans = 1")
+	runner.Exec(t, "CREATE TABLE t (k INT PRIMARY KEY, v INT)")
+	runner.Exec(t, "CREATE TABLE u (k INT PRIMARY KEY, w INT)")
+	runner.Exec(t, "INSERT INTO t SELECT i, i FROM generate_series(1, 100) AS g(i)")
+	runner.Exec(t, "INSERT INTO u SELECT i, i FROM generate_series(1, 100) AS g(i)")
+
+	for _, query := range []string{
+		"SELECT count(*) FROM t INNER MERGE JOIN u ON t.k = u.k",
+		"SELECT count(*) FROM t INNER HASH JOIN u ON t.v = u.w",
+	} {
+		t.Run(query, func(t *testing.T) {
+			// Run several joins concurrently so that the scans of different
+			// queries share the limiter too.
+			g := ctxgroup.WithContext(ctx)
+			for i := 0; i < 4; i++ {
+				g.GoCtx(func(ctx context.Context) error {
+					var count int
+					if err := db.QueryRowContext(ctx, query).Scan(&count); err != nil {
+						return err
+					}
+					if count != 100 {
+						return errors.Newf("expected 100 rows, got %d", count)
+					}
+					return nil
+				})
+			}
+			require.NoError(t, g.Wait())
+		})
+	}
+}
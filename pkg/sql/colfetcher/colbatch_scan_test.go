@@ -0,0 +1,94 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colfetcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTrimRetainedCapacity verifies that only the slices of the cFetcher
+// with the capacity exceeding the limit are dropped before the cFetcher is
+// returned to the pool.
+func TestTrimRetainedCapacity(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const maxCapacity = 16
+	const wide, narrow = 4 * maxCapacity, maxCapacity
+	for _, tc := range []struct {
+		name     string
+		capacity int
+		retained int
+	}{
+		{name: "wide", capacity: wide, retained: 0},
+		{name: "narrow", capacity: narrow, retained: narrow},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cf := &cFetcher{scratch: make([]byte, 0, tc.capacity)}
+			cf.machine.colvecs.Nulls = make([]*coldata.Nulls, 0, tc.capacity)
+			cf.table = &cTableInfo{
+				cFetcherTableArgs: &cFetcherTableArgs{typs: make([]*types.T, 0, tc.capacity)},
+				orderedColIdxMap: &colIdxMap{
+					vals: make(descpb.ColumnIDs, 0, tc.capacity),
+					ords: make([]int, 0, tc.capacity),
+				},
+				indexColOrdinals:    make([]int, 0, tc.capacity),
+				extraValColOrdinals: make([]int, 0, tc.capacity),
+			}
+
+			cf.trimRetainedCapacity(maxCapacity)
+			require.Equal(t, tc.retained, cap(cf.scratch))
+			require.Equal(t, tc.retained, cap(cf.machine.colvecs.Nulls))
+			require.Equal(t, tc.retained, cap(cf.table.typs))
+			require.Equal(t, tc.retained, cap(cf.table.orderedColIdxMap.vals))
+			require.Equal(t, tc.retained, cap(cf.table.orderedColIdxMap.ords))
+			require.Equal(t, tc.retained, cap(cf.table.indexColOrdinals))
+			require.Equal(t, tc.retained, cap(cf.table.extraValColOrdinals))
+		})
+	}
+}
+
+// TestColBatchScanCloseRecordsBytesReadOnce verifies that closing a
+// ColBatchScan multiple times reports the bytes it read to the
+// TableReadTracker only once.
+func TestColBatchScanCloseRecordsBytesReadOnce(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	tracker := NewTableReadTracker(&st.SV)
+	const tableID = descpb.ID(42)
+	s := &ColBatchScan{
+		flowCtx: &execinfra.FlowCtx{
+			Cfg: &execinfra.ServerConfig{Settings: st, TableReadTracker: tracker},
+		},
+		cf: &cFetcher{
+			table: &cTableInfo{
+				cFetcherTableArgs: &cFetcherTableArgs{spec: descpb.IndexFetchSpec{TableID: tableID}},
+			},
+			bytesRead: 100,
+		},
+	}
+	require.NoError(t, s.Close(ctx))
+	require.NoError(t, s.Close(ctx))
+	require.Equal(t, int64(100), tracker.TotalBytesRead(tableID))
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
@@ -521,8 +522,14 @@ func NewColIndexJoin(
 		// Note that the correct estimated row count will be set by the index
 		// joiner for each set of spans to read.
 		0,     /* estimatedRowCount */
+		0,     /* minBatchSize */
 		false, /* reverse */
 		flowCtx.TraceKV,
+		flowCtx.EvalCtx.SessionData().VerifyScanChecksums,
+		hlc.Timestamp{}, /* minTimestamp */
+		hlc.Timestamp{}, /* historyStartTime */
+		hlc.Timestamp{}, /* historyEndTime */
+		flowCtx.Cfg.KVResponseQuota,
 	}
 	if err = fetcher.Init(
 		fetcherAllocator, kvFetcherMemAcc, tableArgs,
@@ -556,20 +563,23 @@ func NewColIndexJoin(
 		op.streamerInfo.diskBuffer = rowcontainer.NewKVStreamerResultDiskBuffer(
 			flowCtx.Cfg.TempStorage, diskMonitor,
 		)
-		if memoryLimit < op.mem.inputBatchSizeLimit {
-			// If we have a low workmem limit, then we want to reduce the input
-			// batch size limit.
-			//
-			// The Streamer gets three quarters of workmem as its budget which
-			// accounts for two usages - for the footprint of the spans
-			// themselves in the enqueued requests as well as the footprint of
-			// the responses received by the Streamer. If we don't reduce the
-			// input batch size limit here, then 4MiB value will be used, and
-			// the constructed spans (i.e. the enqueued requests) alone might
-			// exceed the budget leading to the Streamer erroring out in
-			// Enqueue().
-			op.mem.inputBatchSizeLimit = memoryLimit
-		}
+	}
+	if memoryLimit < op.mem.inputBatchSizeLimit {
+		// If we have a low workmem limit, then we want to reduce the input
+		// batch size limit so that the buffered lookup spans never exceed
+		// workmem. Note that we don't need to spill the spans to disk - once
+		// the limit is reached, we perform the lookups for the spans
+		// constructed so far and only then proceed to consume more input rows.
+		//
+		// When the Streamer is used, this is also required for correctness.
+		// The Streamer gets three quarters of workmem as its budget which
+		// accounts for two usages - for the footprint of the spans themselves
+		// in the enqueued requests as well as the footprint of the responses
+		// received by the Streamer. If we don't reduce the input batch size
+		// limit here, then 4MiB value will be used, and the constructed spans
+		// (i.e. the enqueued requests) alone might exceed the budget leading to
+		// the Streamer erroring out in Enqueue().
+		op.mem.inputBatchSizeLimit = memoryLimit
 	}
 
 	return op, nil
@@ -630,6 +640,7 @@ func (s *ColIndexJoin) GetScanStats() execstats.ScanStats {
 
 // Release implements the execinfra.Releasable interface.
 func (s *ColIndexJoin) Release() {
+	s.cf.trimRetainedCapacity(int(maxRetainedCapacity.Get(&s.flowCtx.Cfg.Settings.SV)))
 	s.cf.Release()
 	s.spanAssembler.Release()
 	*s = ColIndexJoin{}
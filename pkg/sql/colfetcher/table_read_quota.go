@@ -0,0 +1,120 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colfetcher
+
+import (
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+var tableReadQuota = settings.RegisterByteSizeSetting(
+	settings.TenantWritable,
+	"sql.distsql.table_read_quota.bytes",
+	"maximum number of bytes that can be read from a single table on a single "+
+		"node within sql.distsql.table_read_quota.window before the scans of "+
+		"that table are deprioritized by admission control (0 = no quota)",
+	0,
+	settings.NonNegativeInt,
+)
+
+var tableReadQuotaWindow = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"sql.distsql.table_read_quota.window",
+	"duration of the window over which the bytes read from each table are "+
+		"compared against sql.distsql.table_read_quota.bytes",
+	time.Minute,
+	settings.PositiveDuration,
+)
+
+// overQuotaAdmissionPriority is the admission priority used by the scans of
+// the tables that have exceeded their read quota.
+const overQuotaAdmissionPriority = admissionpb.UserLowPri
+
+// TableReadTracker is the node-wide implementation of the
+// execinfra.TableReadTracker interface. It keeps track of the cumulative
+// number of bytes read from each table as well as of the number of bytes read
+// within the current quota window.
+type TableReadTracker struct {
+	sv *settings.Values
+	mu struct {
+		syncutil.Mutex
+		// windowStart is the time at which the current quota window started.
+		windowStart time.Time
+		// windowBytesRead contains the number of bytes read from each table
+		// since windowStart.
+		windowBytesRead map[descpb.ID]int64
+		// totalBytesRead contains the number of bytes read from each table
+		// since the tracker was created.
+		totalBytesRead map[descpb.ID]int64
+	}
+}
+
+var _ execinfra.TableReadTracker = &TableReadTracker{}
+
+// NewTableReadTracker returns a new TableReadTracker.
+func NewTableReadTracker(sv *settings.Values) *TableReadTracker {
+	t := &TableReadTracker{sv: sv}
+	t.mu.windowStart = timeutil.Now()
+	t.mu.windowBytesRead = make(map[descpb.ID]int64)
+	t.mu.totalBytesRead = make(map[descpb.ID]int64)
+	return t
+}
+
+// maybeAdvanceWindowLocked starts a new quota window if the current one has
+// expired. t.mu must be held.
+func (t *TableReadTracker) maybeAdvanceWindowLocked() {
+	now := timeutil.Now()
+	if now.Sub(t.mu.windowStart) < tableReadQuotaWindow.Get(t.sv) {
+		return
+	}
+	t.mu.windowStart = now
+	for tableID := range t.mu.windowBytesRead {
+		delete(t.mu.windowBytesRead, tableID)
+	}
+}
+
+// RecordBytesRead is part of the execinfra.TableReadTracker interface.
+func (t *TableReadTracker) RecordBytesRead(tableID descpb.ID, bytes int64) {
+	if bytes <= 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.maybeAdvanceWindowLocked()
+	t.mu.windowBytesRead[tableID] += bytes
+	t.mu.totalBytesRead[tableID] += bytes
+}
+
+// ExceedsQuota is part of the execinfra.TableReadTracker interface.
+func (t *TableReadTracker) ExceedsQuota(tableID descpb.ID) bool {
+	quota := tableReadQuota.Get(t.sv)
+	if quota == 0 {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.maybeAdvanceWindowLocked()
+	return t.mu.windowBytesRead[tableID] > quota
+}
+
+// TotalBytesRead returns the cumulative number of bytes read from the table
+// on this node.
+func (t *TableReadTracker) TotalBytesRead(tableID descpb.ID) int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.mu.totalBytesRead[tableID]
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colfetcher_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/colfetcher"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTableReadTracker verifies that the bytes read by the ColBatchScans are
+// attributed to the scanned table and are compared against the per-table read
+// quota.
+func TestTableReadTracker(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	runner := sqlutils.MakeSQLRunner(db)
+	runner.Exec(t, "CREATE TABLE t (k INT PRIMARY KEY, v STRING)")
+	runner.Exec(t, "CREATE TABLE u (k INT PRIMARY KEY)")
+	runner.Exec(t, "INSERT INTO t SELECT i, repeat('a', 100) FROM generate_series(1, 100) AS g(i)")
+	var tableID, otherTableID descpb.ID
+	runner.QueryRow(t, "SELECT 't'::regclass::oid").Scan(&tableID)
+	runner.QueryRow(t, "SELECT 'u'::regclass::oid").Scan(&otherTableID)
+
+	tracker, ok := s.ExecutorConfig().(sql.ExecutorConfig).DistSQLSrv.TableReadTracker.(*colfetcher.TableReadTracker)
+	require.True(t, ok)
+
+	// Without the quota, no table is considered to be over it.
+	runner.Exec(t, "SELECT * FROM t")
+	bytesRead := tracker.TotalBytesRead(tableID)
+	require.Greater(t, bytesRead, int64(0))
+	require.False(t, tracker.ExceedsQuota(tableID))
+
+	// Set the quota so that a single full scan of t exceeds it.
+	runner.Exec(t, "SET CLUSTER SETTING sql.distsql.table_read_quota.window = '1h'")
+	runner.Exec(t, "SET CLUSTER SETTING sql.distsql.table_read_quota.bytes = $1", bytesRead/2)
+	runner.Exec(t, "SELECT * FROM t")
+	require.Greater(t, tracker.TotalBytesRead(tableID), bytesRead)
+	require.True(t, tracker.ExceedsQuota(tableID))
+	require.False(t, tracker.ExceedsQuota(otherTableID))
+
+	// Queries against the table should still succeed, albeit deprioritized.
+	var count int
+	runner.QueryRow(t, "SELECT count(*) FROM t").Scan(&count)
+	require.Equal(t, 100, count)
+
+	// Disabling the quota lifts the restriction.
+	runner.Exec(t, "SET CLUSTER SETTING sql.distsql.table_read_quota.bytes = 0")
+	require.False(t, tracker.ExceedsQuota(tableID))
+}
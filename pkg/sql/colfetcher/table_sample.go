@@ -0,0 +1,94 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colfetcher
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+)
+
+// maxBernoulliSkip is the maximum number of rows that are skipped at once by
+// the bernoulliSampler. It protects from the overflow when computing the index
+// of the next sampled row.
+const maxBernoulliSkip = math.MaxInt32
+
+// bernoulliSampler performs the BERNOULLI sampling of the batches produced by
+// the cFetcher, keeping each row independently with the given probability.
+//
+// Rather than generating a random number for every row, the sampler draws the
+// number of rows to skip before the next sampled row from the geometric
+// distribution, so the cost of sampling a batch is proportional to the number
+// of the sampled rows.
+type bernoulliSampler struct {
+	rng         *rand.Rand
+	probability float64
+	// logOneMinusProbability is log(1-probability), cached for nextSkip.
+	logOneMinusProbability float64
+	// toSkip is the number of rows that must be skipped before the next
+	// sampled one. It carries over from one batch to the next.
+	toSkip int
+}
+
+func newBernoulliSampler(rng *rand.Rand, probability float64) *bernoulliSampler {
+	s := &bernoulliSampler{
+		rng:                    rng,
+		probability:            probability,
+		logOneMinusProbability: math.Log1p(-probability),
+	}
+	s.toSkip = s.nextSkip()
+	return s
+}
+
+// nextSkip returns the number of rows to skip before the next sampled row.
+func (s *bernoulliSampler) nextSkip() int {
+	if s.probability >= 1 {
+		return 0
+	}
+	// If U is uniformly distributed in (0, 1], then floor(log(U)/log(1-p)) is
+	// the number of failures before the first success of Bernoulli trials with
+	// the probability of success p.
+	skip := math.Floor(math.Log(1-s.rng.Float64()) / s.logOneMinusProbability)
+	if skip >= maxBernoulliSkip {
+		return maxBernoulliSkip
+	}
+	return int(skip)
+}
+
+// sample sets a selection vector on the batch with the sampled rows. The batch
+// must not have a selection vector.
+func (s *bernoulliSampler) sample(batch coldata.Batch) {
+	n := batch.Length()
+	if s.probability >= 1 {
+		return
+	}
+	if s.probability <= 0 {
+		batch.SetLength(0)
+		return
+	}
+	if s.toSkip >= n {
+		s.toSkip -= n
+		batch.SetLength(0)
+		return
+	}
+	batch.SetSelection(true)
+	sel := batch.Selection()
+	var idx int
+	i := s.toSkip
+	for i < n {
+		sel[idx] = i
+		idx++
+		i += s.nextSkip() + 1
+	}
+	s.toSkip = i - n
+	batch.SetLength(idx)
+}
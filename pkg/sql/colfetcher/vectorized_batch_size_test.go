@@ -131,6 +131,43 @@ CREATE TABLE t (a PRIMARY KEY, b) AS SELECT i, i FROM generate_series(1, 511) AS
 	}
 }
 
+// TestMinScanBatchSize verifies that the min_scan_batch_size session variable
+// overrides the initial batch size derived from the estimated row count.
+func TestMinScanBatchSize(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	skip.UnderMetamorphic(t, "This test doesn't work with metamorphic batch sizes.")
+
+	tc := testcluster.StartTestCluster(t, 1, base.TestClusterArgs{ReplicationMode: base.ReplicationAuto})
+	ctx := context.Background()
+	defer tc.Stopper().Stop(ctx)
+	conn := tc.Conns[0]
+
+	_, err := conn.ExecContext(ctx, `
+CREATE TABLE t (a PRIMARY KEY, b) AS SELECT i, i FROM generate_series(1, 511) AS g(i);
+ANALYZE t;
+SET min_scan_batch_size = 1;`)
+	assert.NoError(t, err)
+
+	// Without the session variable, the estimated row count would make the
+	// cFetcher use a single batch (see TestScanBatchSize). Starting with a
+	// batch of capacity 1 and doubling it every time, the 511 rows are
+	// returned in batches of 1, 2, 4, ..., 256 rows.
+	batchCountRegex := regexp.MustCompile(`vectorized batch count: (\d+)`)
+	rows, err := conn.QueryContext(ctx, `EXPLAIN ANALYZE (VERBOSE) SELECT * FROM t`)
+	assert.NoError(t, err)
+	foundBatches := -1
+	for rows.Next() {
+		var res string
+		assert.NoError(t, rows.Scan(&res))
+		if matches := batchCountRegex.FindStringSubmatch(res); len(matches) > 0 {
+			foundBatches, err = strconv.Atoi(matches[1])
+			assert.NoError(t, err)
+		}
+	}
+	assert.Equal(t, 9, foundBatches)
+}
+
 // TestCFetcherLimitsOutputBatch verifies that cFetcher limits its output batch
 // based on the memory footprint.
 func TestCFetcherLimitsOutputBatch(t *testing.T) {
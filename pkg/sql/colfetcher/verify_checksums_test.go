@@ -0,0 +1,68 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colfetcher_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVerifyScanChecksums verifies that the cFetcher detects a value whose
+// checksum doesn't match its contents when verify_scan_checksums is set.
+func TestVerifyScanChecksums(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, sqlDB, kvDB := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	_, err := sqlDB.Exec(`
+CREATE TABLE t (a INT PRIMARY KEY, b STRING);
+INSERT INTO t VALUES (1, 'foo');
+`)
+	require.NoError(t, err)
+	var tableID uint32
+	require.NoError(t, sqlDB.QueryRow(`SELECT 't'::REGCLASS::OID`).Scan(&tableID))
+
+	// Read the only KV of the table and write it back with its contents
+	// modified but with the original checksum.
+	tablePrefix := keys.SystemSQLCodec.TablePrefix(tableID)
+	kvs, err := kvDB.Scan(ctx, tablePrefix, tablePrefix.PrefixEnd(), 0 /* maxRows */)
+	require.NoError(t, err)
+	require.Len(t, kvs, 1)
+	key := kvs[0].Key
+	corrupted := roachpb.Value{RawBytes: append([]byte(nil), kvs[0].Value.RawBytes...)}
+	corrupted.RawBytes[len(corrupted.RawBytes)-1]++
+	require.Error(t, corrupted.Verify(key))
+	require.NoError(t, storage.MVCCPut(
+		ctx, s.Engines()[0], nil /* ms */, key, s.Clock().Now(), hlc.ClockTimestamp{}, corrupted, nil, /* txn */
+	))
+
+	_, err = sqlDB.Exec(`SET vectorize = on; SET verify_scan_checksums = true`)
+	require.NoError(t, err)
+	_, err = sqlDB.Exec(`SELECT * FROM t`)
+	require.Error(t, err)
+	require.Equal(t, pgcode.DataCorrupted, pgerror.GetPGCode(err), "unexpected error %v", err)
+	require.Contains(t, err.Error(), "value checksum mismatch on row (a)=(1) in t@t_pkey")
+}
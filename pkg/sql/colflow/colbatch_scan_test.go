@@ -34,8 +34,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
 )
 
 // TestColBatchScanMeta makes sure that the ColBatchScan propagates the leaf
@@ -116,6 +118,107 @@ func TestColBatchScanMeta(t *testing.T) {
 	}
 }
 
+// TestColBatchScanRemoteSpans verifies that the ColBatchScan reads the remote
+// spans only when the local spans don't satisfy the limit.
+func TestColBatchScanRemoteSpans(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, sqlDB, kvDB := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	const numRows = 10
+	sqlutils.CreateTable(t, sqlDB, "t",
+		"num INT PRIMARY KEY",
+		numRows,
+		sqlutils.ToRowFn(sqlutils.RowIdxFn))
+
+	td := desctestutils.TestingGetPublicTableDescriptor(kvDB, keys.SystemSQLCodec, "test", "t")
+	var fetchSpec descpb.IndexFetchSpec
+	if err := rowenc.InitIndexFetchSpec(
+		&fetchSpec, keys.SystemSQLCodec, td, td.GetPrimaryIndex(),
+		[]descpb.ColumnID{td.PublicColumns()[0].GetID()},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	st := s.ClusterSettings()
+	evalCtx := eval.MakeTestingEvalContext(st)
+	defer evalCtx.Stop(ctx)
+	var monitorRegistry colexecargs.MonitorRegistry
+	defer monitorRegistry.Close(ctx)
+
+	// The local spans contain the first three rows, the remote spans contain
+	// the rest.
+	const numLocalRows = 3
+	for _, tc := range []struct {
+		limit uint64
+		// filter, if set, is the filter of the PostProcessSpec.
+		filter       string
+		expectedRows int
+		// maxRowsRead is the upper bound on the number of rows read by the
+		// ColBatchScan. If it doesn't exceed numLocalRows, then the remote
+		// spans must not have been scanned.
+		maxRowsRead int64
+	}{
+		{limit: 2, expectedRows: 2, maxRowsRead: numLocalRows},
+		{limit: numLocalRows, expectedRows: numLocalRows, maxRowsRead: numLocalRows},
+		{limit: 5, expectedRows: 5, maxRowsRead: numRows},
+		{limit: 0, expectedRows: numRows, maxRowsRead: numRows},
+		// Only one local row passes the filter, so the remote spans must be
+		// scanned even though the number of local rows exceeds the limit.
+		{limit: 2, filter: "@1 % 2 = 0", expectedRows: 2, maxRowsRead: numRows},
+	} {
+		t.Run(fmt.Sprintf("limit=%d/filter=%s", tc.limit, tc.filter), func(t *testing.T) {
+			span := td.PrimaryIndexSpan(keys.SystemSQLCodec)
+			splitKey := encoding.EncodeVarintAscending(
+				keys.SystemSQLCodec.IndexPrefix(uint32(td.GetID()), uint32(td.GetPrimaryIndexID())),
+				numLocalRows+1,
+			)
+			spec := execinfrapb.ProcessorSpec{
+				Core: execinfrapb.ProcessorCoreUnion{
+					TableReader: &execinfrapb.TableReaderSpec{
+						FetchSpec:   fetchSpec,
+						Spans:       []roachpb.Span{{Key: span.Key, EndKey: splitKey}},
+						RemoteSpans: []roachpb.Span{{Key: splitKey, EndKey: span.EndKey}},
+					}},
+				Post: execinfrapb.PostProcessSpec{
+					Filter: execinfrapb.Expression{Expr: tc.filter},
+					Limit:  tc.limit,
+				},
+				ResultTypes: types.OneIntCol,
+			}
+			flowCtx := execinfra.FlowCtx{
+				EvalCtx: &evalCtx,
+				Cfg:     &execinfra.ServerConfig{Settings: st},
+				Txn:     kv.NewTxn(ctx, s.DB(), s.NodeID()),
+				Local:   true,
+				NodeID:  evalCtx.NodeID,
+			}
+			args := &colexecargs.NewColOperatorArgs{
+				Spec:                &spec,
+				StreamingMemAccount: testMemAcc,
+				MonitorRegistry:     &monitorRegistry,
+			}
+			res, err := colbuilder.NewColOperator(ctx, &flowCtx, args)
+			require.NoError(t, err)
+			defer res.TestCleanupNoError(t)
+			res.Root.Init(ctx)
+			var numOutputRows int
+			for {
+				b := res.Root.Next()
+				if b.Length() == 0 {
+					break
+				}
+				numOutputRows += b.Length()
+			}
+			require.Equal(t, tc.expectedRows, numOutputRows)
+			require.LessOrEqual(t, res.KVReader.GetRowsRead(), tc.maxRowsRead)
+		})
+	}
+}
+
 func BenchmarkColBatchScan(b *testing.B) {
 	defer leaktest.AfterTest(b)()
 	logScope := log.Scope(b)
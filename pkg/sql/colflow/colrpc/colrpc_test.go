@@ -870,6 +870,7 @@ func TestOutboxStreamIDPropagation(t *testing.T) {
 		outbox.Run(
 			ctx,
 			dialer,
+			nil, /* muxer */
 			base.SQLInstanceID(0),
 			execinfrapb.FlowID{UUID: uuid.MakeV4()},
 			outboxStreamID,
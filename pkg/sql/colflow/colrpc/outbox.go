@@ -126,7 +126,8 @@ func (o *Outbox) close(ctx context.Context) {
 
 // Run starts an outbox by connecting to the provided node and pushing
 // coldata.Batches over the stream after sending a header with the provided flow
-// and stream ID. Note that an extra goroutine is spawned so that Recv may be
+// and stream ID. The stream is opened using the given muxer (which can be nil
+// in tests, see execinfra.FlowStreamMuxer). Note that an extra goroutine is spawned so that Recv may be
 // called concurrently wrt the Send goroutine to listen for drain signals.
 // If an io.EOF is received while sending, the outbox will cancel all components
 // from the same tree as the outbox.
@@ -147,6 +148,7 @@ func (o *Outbox) close(ctx context.Context) {
 func (o *Outbox) Run(
 	ctx context.Context,
 	dialer execinfra.Dialer,
+	muxer *execinfra.FlowStreamMuxer,
 	sqlInstanceID base.SQLInstanceID,
 	flowID execinfrapb.FlowID,
 	streamID execinfrapb.StreamID,
@@ -171,7 +173,7 @@ func (o *Outbox) Run(
 	ctx = logtags.AddTag(ctx, "streamID", streamID)
 	log.VEventf(ctx, 2, "Outbox Dialing %s", sqlInstanceID)
 
-	var stream execinfrapb.DistSQL_FlowStreamClient
+	var stream execinfra.FlowStreamClient
 	if err := func() error {
 		conn, err := execinfra.GetConnForOutbox(ctx, dialer, sqlInstanceID, connectionTimeout)
 		if err != nil {
@@ -189,7 +191,7 @@ func (o *Outbox) Run(
 		// running. If, however, the flow context is canceled, then the
 		// termination of the whole query is ungraceful, so we're ok with the
 		// gRPC stream being ungracefully shutdown too.
-		stream, err = client.FlowStream(flowCtx)
+		stream, err = muxer.OpenFlowStream(flowCtx, client, sqlInstanceID)
 		if err != nil {
 			log.Warningf(
 				ctx,
@@ -332,6 +334,9 @@ func (o *Outbox) sendBatches(
 // errToSend as metadata if non-nil.
 func (o *Outbox) sendMetadata(ctx context.Context, stream flowStreamClient, errToSend error) error {
 	msg := &execinfrapb.ProducerMessage{}
+	// If the flow was shed because of memory pressure, make sure that the
+	// consumer learns about that rather than about the context cancellation.
+	errToSend = execinfra.MaybeReplaceWithShedError(ctx, errToSend)
 	if errToSend != nil {
 		log.VEventf(ctx, 1, "Outbox sending an error as metadata: %v", errToSend)
 		msg.Data.Metadata = append(
@@ -347,6 +352,7 @@ func (o *Outbox) sendMetadata(ctx context.Context, stream flowStreamClient, errT
 			}
 		}
 		for _, meta := range o.inputMetaInfo.MetadataSources.DrainMeta() {
+			meta.Err = execinfra.MaybeReplaceWithShedError(ctx, meta.Err)
 			msg.Data.Metadata = append(msg.Data.Metadata, execinfrapb.LocalMetaToRemoteProducerMeta(ctx, meta))
 		}
 	}
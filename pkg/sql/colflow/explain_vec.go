@@ -15,6 +15,7 @@ import (
 	"math"
 	"reflect"
 	"sort"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
@@ -185,10 +186,22 @@ func shouldOutput(operator execopnode.OpNode, verbose bool) bool {
 	return !nonExplainable || verbose
 }
 
+// opText returns the text describing the operator in the EXPLAIN (VEC)
+// output.
+func opText(operator execopnode.OpNode, verbose bool) string {
+	name := reflect.TypeOf(operator).String()
+	if e, ok := operator.(colexecop.VerboseExplainer); ok && verbose {
+		if details := e.ExplainDetails(); len(details) > 0 {
+			return name + "\n" + strings.Join(details, "\n")
+		}
+	}
+	return name
+}
+
 func formatOpChain(operator execopnode.OpNode, node treeprinter.Node, verbose bool) {
 	seenOps := make(map[reflect.Value]struct{})
 	if shouldOutput(operator, verbose) {
-		doFormatOpChain(operator, node.Child(reflect.TypeOf(operator).String()), verbose, seenOps)
+		doFormatOpChain(operator, node.Child(opText(operator, verbose)), verbose, seenOps)
 	} else {
 		doFormatOpChain(operator, node, verbose, seenOps)
 	}
@@ -213,7 +226,7 @@ func doFormatOpChain(
 		}
 		seenOps[childOpValue] = struct{}{}
 		if shouldOutput(child, verbose) {
-			doFormatOpChain(child, node.Child(childOpName), verbose, seenOps)
+			doFormatOpChain(child, node.Child(opText(child, verbose)), verbose, seenOps)
 		} else {
 			doFormatOpChain(child, node, verbose, seenOps)
 		}
@@ -0,0 +1,123 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colflow
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// maxFallbackEntries is the maximum number of distinct fallbacks tracked by
+// the FallbackRegistry. Once the limit is reached, the entry that occurred
+// least recently is evicted to make room for a new one.
+const maxFallbackEntries = 1000
+
+// FallbackEntry describes all occurrences of the vectorized engine falling
+// back to the row-by-row engine because of the same component and reason.
+type FallbackEntry struct {
+	// Wrapped is true if only the component was executed by the row-by-row
+	// engine (after being wrapped into the vectorized flow), and false if the
+	// whole flow was.
+	Wrapped   bool
+	Component string
+	// Reason is the redacted message of the error that caused the fallback,
+	// so that the fallbacks caused by the same error with different values
+	// (for example, literals of the query) are grouped together.
+	Reason string
+	// Count is the number of times this fallback occurred.
+	Count int64
+	// LastOccurred is the time at which this fallback occurred last.
+	LastOccurred time.Time
+}
+
+type fallbackKey struct {
+	wrapped   bool
+	component string
+	reason    string
+}
+
+// FallbackRegistry is the node-wide implementation of the
+// execinfra.VectorizedFallbackRecorder interface. It keeps track of how many
+// times each component caused the vectorized engine to fall back to the
+// row-by-row engine, and for which reason.
+type FallbackRegistry struct {
+	mu struct {
+		syncutil.Mutex
+		// cache maps fallbackKeys to *FallbackEntry. The least recently
+		// occurred entries are evicted first.
+		cache *cache.UnorderedCache
+	}
+}
+
+var _ execinfra.VectorizedFallbackRecorder = &FallbackRegistry{}
+
+// NewFallbackRegistry returns a new FallbackRegistry.
+func NewFallbackRegistry() *FallbackRegistry {
+	r := &FallbackRegistry{}
+	r.mu.cache = cache.NewUnorderedCache(cache.Config{
+		Policy: cache.CacheLRU,
+		ShouldEvict: func(size int, _, _ interface{}) bool {
+			return size > maxFallbackEntries
+		},
+	})
+	return r
+}
+
+// RecordFallback is part of the execinfra.VectorizedFallbackRecorder
+// interface.
+func (r *FallbackRegistry) RecordFallback(wrapped bool, component string, cause error) {
+	telemetry.Inc(sqltelemetry.VecFallbackCounter(wrapped, component))
+	key := fallbackKey{wrapped: wrapped, component: component}
+	if cause != nil {
+		key.reason = errors.Redact(cause)
+	}
+	now := timeutil.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var e *FallbackEntry
+	if v, ok := r.mu.cache.Get(key); ok {
+		e = v.(*FallbackEntry)
+	} else {
+		e = &FallbackEntry{Wrapped: wrapped, Component: component, Reason: key.reason}
+		r.mu.cache.Add(key, e)
+	}
+	e.Count++
+	e.LastOccurred = now
+}
+
+// Entries returns a copy of all fallbacks recorded on this node, ordered by
+// the fallback type, the component, and the reason.
+func (r *FallbackRegistry) Entries() []FallbackEntry {
+	r.mu.Lock()
+	entries := make([]FallbackEntry, 0, r.mu.cache.Len())
+	r.mu.cache.Do(func(e *cache.Entry) {
+		entries = append(entries, *e.Value.(*FallbackEntry))
+	})
+	r.mu.Unlock()
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Wrapped != entries[j].Wrapped {
+			return !entries[i].Wrapped
+		}
+		if entries[i].Component != entries[j].Component {
+			return entries[i].Component < entries[j].Component
+		}
+		return entries[i].Reason < entries[j].Reason
+	})
+	return entries
+}
@@ -0,0 +1,73 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colflow
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/redact"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFallbackRegistry(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	r := NewFallbackRegistry()
+	errUnsupported := errors.New("unsupported")
+	r.RecordFallback(true /* wrapped */, "Windower", errUnsupported)
+	r.RecordFallback(false /* wrapped */, "Sampler", errUnsupported)
+	r.RecordFallback(true /* wrapped */, "Windower", errUnsupported)
+	r.RecordFallback(true /* wrapped */, "Windower", errors.New("other"))
+
+	entries := r.Entries()
+	require.Len(t, entries, 3)
+	// The entries for the whole flow fallbacks come first.
+	require.Equal(t, false, entries[0].Wrapped)
+	require.Equal(t, "Sampler", entries[0].Component)
+	require.Equal(t, int64(1), entries[0].Count)
+	require.Equal(t, "Windower", entries[1].Component)
+	require.Equal(t, "other", entries[1].Reason)
+	require.Equal(t, int64(1), entries[1].Count)
+	require.Equal(t, "unsupported", entries[2].Reason)
+	require.Equal(t, int64(2), entries[2].Count)
+
+	// Verify that the unsafe values of the reasons are redacted, so that the
+	// fallbacks differing only by them share the same entry.
+	r = NewFallbackRegistry()
+	r.RecordFallback(true /* wrapped */, "Windower", errors.Newf("unsupported value %d", 1))
+	r.RecordFallback(true /* wrapped */, "Windower", errors.Newf("unsupported value %d", 2))
+	entries = r.Entries()
+	require.Len(t, entries, 1)
+	require.Equal(t, "unsupported value ×", entries[0].Reason)
+	require.Equal(t, int64(2), entries[0].Count)
+
+	// Verify that the number of entries is bounded and the least recent ones
+	// are evicted.
+	for i := 0; i < maxFallbackEntries; i++ {
+		r.RecordFallback(true /* wrapped */, "Sorter", errors.Newf("reason %d", redact.Safe(i)))
+		if i == 0 {
+			// Make the first Sorter entry less recent than the Windower one.
+			r.RecordFallback(true /* wrapped */, "Windower", errors.Newf("unsupported value %d", 3))
+		}
+	}
+	entries = r.Entries()
+	require.Len(t, entries, maxFallbackEntries)
+	for _, e := range entries {
+		require.NotEqual(t, "reason 0", e.Reason, fmt.Sprintf("unexpected entry %+v", e))
+	}
+	require.Equal(t, "Windower", entries[len(entries)-1].Component)
+	require.Equal(t, int64(3), entries[len(entries)-1].Count)
+}
@@ -14,6 +14,7 @@ import (
 	"context"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/sql/colcontainer"
@@ -29,7 +30,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/optional"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
 	"github.com/marusama/semaphore"
@@ -54,6 +57,9 @@ type routerOutput interface {
 	// Calling forwardErr multiple times will result in the most recent error
 	// overwriting the previous error.
 	forwardErr(error)
+	// getBlockedTime returns the cumulated time during which the output was
+	// blocked.
+	getBlockedTime() time.Duration
 	// resetForTests resets the routerOutput for a benchmark or test run.
 	resetForTests(context.Context)
 }
@@ -126,6 +132,11 @@ type routerOutputOp struct {
 		data      *colexecutils.SpillingQueue
 		numUnread int
 		blocked   bool
+		// blockedStart is the time at which the output last became blocked,
+		// and blockedTime is the cumulated time during which the output was
+		// blocked (excluding the current blocked period, if any).
+		blockedStart time.Time
+		blockedTime  time.Duration
 	}
 
 	testingKnobs routerOutputOpTestingKnobs
@@ -369,6 +380,7 @@ func (o *routerOutputOp) addBatch(ctx context.Context, batch coldata.Batch) bool
 	if o.mu.numUnread > o.testingKnobs.blockedThreshold && !o.mu.blocked {
 		// The output is now blocked.
 		o.mu.blocked = true
+		o.mu.blockedStart = timeutil.Now()
 		stateChanged = true
 	}
 	o.mu.cond.Signal()
@@ -381,10 +393,21 @@ func (o *routerOutputOp) addBatch(ctx context.Context, batch coldata.Batch) bool
 func (o *routerOutputOp) maybeUnblockLocked() {
 	if o.mu.blocked {
 		o.mu.blocked = false
+		o.mu.blockedTime += timeutil.Since(o.mu.blockedStart)
 		o.unblockedEventsChan <- struct{}{}
 	}
 }
 
+func (o *routerOutputOp) getBlockedTime() time.Duration {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	blockedTime := o.mu.blockedTime
+	if o.mu.blocked {
+		blockedTime += timeutil.Since(o.mu.blockedStart)
+	}
+	return blockedTime
+}
+
 // resetForTests resets the routerOutputOp for a test or benchmark run.
 func (o *routerOutputOp) resetForTests(ctx context.Context) {
 	o.mu.Lock()
@@ -394,6 +417,7 @@ func (o *routerOutputOp) resetForTests(ctx context.Context) {
 	o.mu.data.Reset(ctx)
 	o.mu.numUnread = 0
 	o.mu.blocked = false
+	o.mu.blockedTime = 0
 }
 
 // hashRouterDrainState is a state that specifically describes the hashRouter's
@@ -453,11 +477,28 @@ type HashRouter struct {
 	// tupleDistributor is used to decide to which output a particular tuple
 	// should be routed.
 	tupleDistributor *colexechash.TupleHashDistributor
+
+	// regionCol, if non-negative, is the index of the column containing the
+	// region of each tuple, and streamsByRegion contains the indices of the
+	// outputs in each of the regions. If set, the tuples are routed only among
+	// the outputs in their region (see execinfrapb.OutputRouterSpec for
+	// details).
+	regionCol       int
+	streamsByRegion map[string][]int
+	// tupleBuckets and regionalSelections are the scratch space used when
+	// routing the tuples by region.
+	tupleBuckets       []int
+	regionalSelections [][]int
+
+	// outputComponentIDs, if set, contains the component IDs of the streams
+	// corresponding to each of the outputs. These are used to report the
+	// backpressure stats of the outputs when collecting execution stats.
+	outputComponentIDs []execinfrapb.ComponentID
 }
 
 // NewHashRouter creates a new hash router that consumes coldata.Batches from
-// input and hashes each row according to hashCols to one of the outputs
-// returned as Operators.
+// input and hashes each row according to the hash columns of spec to one of
+// the outputs returned as Operators.
 // The number of allocators provided will determine the number of outputs
 // returned. Note that each allocator must be unlimited, memory will be limited
 // by comparing memory use in the allocator with the memoryLimit argument. Each
@@ -465,15 +506,19 @@ type HashRouter struct {
 // should be linked to an independent mem account) as Operator.Next will usually
 // be called concurrently between different outputs. Similarly, each output
 // needs to have a separate disk account.
+// outputComponentIDs is optional; if set, the router reports the time during
+// which each of the outputs was blocked as the stats of the corresponding
+// stream.
 func NewHashRouter(
 	unlimitedAllocators []*colmem.Allocator,
 	input colexecargs.OpWithMetaInfo,
 	types []*types.T,
-	hashCols []uint32,
+	spec *execinfrapb.OutputRouterSpec,
 	memoryLimit int64,
 	diskQueueCfg colcontainer.DiskQueueCfg,
 	fdSemaphore semaphore.Semaphore,
 	diskAccounts []*mon.BoundAccount,
+	outputComponentIDs []execinfrapb.ComponentID,
 ) (*HashRouter, []colexecop.DrainableClosableOperator) {
 	outputs := make([]routerOutput, len(unlimitedAllocators))
 	outputsAsOps := make([]colexecop.DrainableClosableOperator, len(unlimitedAllocators))
@@ -507,7 +552,13 @@ func NewHashRouter(
 		outputs[i] = op
 		outputsAsOps[i] = op
 	}
-	return newHashRouterWithOutputs(input, hashCols, unblockEventsChan, outputs), outputsAsOps
+	r := newHashRouterWithOutputs(input, spec.HashColumns, unblockEventsChan, outputs)
+	if spec.RegionColumn != nil {
+		r.regionCol = int(*spec.RegionColumn)
+		r.streamsByRegion = spec.StreamsByRegion()
+	}
+	r.outputComponentIDs = outputComponentIDs
+	return r, outputsAsOps
 }
 
 func newHashRouterWithOutputs(
@@ -526,6 +577,7 @@ func newHashRouterWithOutputs(
 		// read the metadata.
 		waitForMetadata:  make(chan []execinfrapb.ProducerMetadata, 1),
 		tupleDistributor: colexechash.NewTupleHashDistributor(colexechash.DefaultInitHashValue, len(outputs)),
+		regionCol:        -1,
 	}
 	for i := range outputs {
 		outputs[i].initWithHashRouter(r)
@@ -634,6 +686,14 @@ func (r *HashRouter) Run(ctx context.Context) {
 			for _, s := range r.inputMetaInfo.StatsCollectors {
 				span.RecordStructured(s.GetStats())
 			}
+			for i, id := range r.outputComponentIDs {
+				span.RecordStructured(&execinfrapb.ComponentStats{
+					Component: id,
+					Output: execinfrapb.OutputStats{
+						BlockedTime: optional.MakeTimeValue(r.outputs[i].getBlockedTime()),
+					},
+				})
+			}
 			if meta := execinfra.GetTraceDataAsMetadata(span); meta != nil {
 				r.bufferedMeta = append(r.bufferedMeta, *meta)
 			}
@@ -667,6 +727,9 @@ func (r *HashRouter) processNextBatch(ctx context.Context) bool {
 	// the first one are noops.
 	r.tupleDistributor.Init(ctx)
 	selections := r.tupleDistributor.Distribute(b, r.hashCols)
+	if r.streamsByRegion != nil {
+		selections = r.routeByRegion(b, selections)
+	}
 	for i, o := range r.outputs {
 		if len(selections[i]) > 0 {
 			colexecutils.UpdateBatchState(b, len(selections[i]), true /* usesSel */, selections[i])
@@ -679,6 +742,54 @@ func (r *HashRouter) processNextBatch(ctx context.Context) bool {
 	return false
 }
 
+// routeByRegion takes the selection vectors computed by the tuple distributor
+// for batch b and returns the selection vectors such that each tuple is routed
+// only among the outputs in its region. The tuples with NULL region as well as
+// the tuples from the regions without any outputs are routed according to the
+// original selection vectors.
+func (r *HashRouter) routeByRegion(b coldata.Batch, selections [][]int) [][]int {
+	regionVec := b.ColVec(r.regionCol)
+	// Find the bucket of each tuple first so that the tuples could be
+	// distributed in their original order (which is needed to preserve the
+	// ordering of the tuples within each output).
+	if cap(r.tupleBuckets) < regionVec.Length() {
+		r.tupleBuckets = make([]int, regionVec.Length())
+	}
+	tupleBuckets := r.tupleBuckets[:regionVec.Length()]
+	for bucket, sel := range selections {
+		for _, tupleIdx := range sel {
+			tupleBuckets[tupleIdx] = bucket
+		}
+	}
+	if r.regionalSelections == nil {
+		r.regionalSelections = make([][]int, len(selections))
+	}
+	for i := range r.regionalSelections {
+		r.regionalSelections[i] = r.regionalSelections[i][:0]
+	}
+	regions, nulls := regionVec.Bytes(), regionVec.Nulls()
+	route := func(tupleIdx int) {
+		outputIdx := tupleBuckets[tupleIdx]
+		if !nulls.NullAt(tupleIdx) {
+			if regionOutputs, ok := r.streamsByRegion[string(regions.Get(tupleIdx))]; ok {
+				outputIdx = regionOutputs[outputIdx%len(regionOutputs)]
+			}
+		}
+		r.regionalSelections[outputIdx] = append(r.regionalSelections[outputIdx], tupleIdx)
+	}
+	n := b.Length()
+	if sel := b.Selection(); sel != nil {
+		for _, tupleIdx := range sel[:n] {
+			route(tupleIdx)
+		}
+	} else {
+		for tupleIdx := 0; tupleIdx < n; tupleIdx++ {
+			route(tupleIdx)
+		}
+	}
+	return r.regionalSelections
+}
+
 // resetForTests resets the HashRouter for a test or benchmark run.
 func (r *HashRouter) resetForTests(ctx context.Context) {
 	if i, ok := r.Input.(colexecop.Resetter); ok {
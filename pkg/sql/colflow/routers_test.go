@@ -494,6 +494,9 @@ func TestRouterOutputNext(t *testing.T) {
 			// Verify that an unblock event is sent on the channel. This test will fail
 			// with a timeout on a channel read if not.
 			<-ch
+			// The time between blocking and unblocking the output should have been
+			// recorded.
+			require.NotZero(t, o.getBlockedTime())
 		})
 	}
 }
@@ -680,6 +683,10 @@ func (o *callbackRouterOutput) forwardErr(err error) {
 	o.forwardedErr = err
 }
 
+func (o *callbackRouterOutput) getBlockedTime() time.Duration {
+	return 0
+}
+
 func (o *callbackRouterOutput) resetForTests(context.Context) {
 	o.forwardedErr = nil
 }
@@ -764,6 +771,85 @@ func TestHashRouterComputesDestination(t *testing.T) {
 	}
 }
 
+func TestHashRouterRoutesByRegion(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	tu := newTestUtils(ctx)
+	defer tu.cleanup(ctx)
+
+	// The tuples are (key, region) where the region is one of "a", "b", "c"
+	// (the region without any outputs) and NULL.
+	regions := []interface{}{"a", "b", "c", nil}
+	data := make(colexectestutils.Tuples, 3*coldata.BatchSize())
+	for i := range data {
+		data[i] = colexectestutils.Tuple{i, regions[i%len(regions)]}
+	}
+	typs := []*types.T{types.Int, types.Bytes}
+	in := colexectestutils.NewOpTestInput(tu.testAllocator, coldata.BatchSize(), data, typs)
+	in.Init(ctx)
+
+	const numOutputs = 4
+	regionCol := uint32(1)
+	spec := &execinfrapb.OutputRouterSpec{
+		Type:          execinfrapb.OutputRouterSpec_BY_HASH,
+		HashColumns:   []uint32{0, 1},
+		RegionColumn:  &regionCol,
+		StreamRegions: [][]byte{[]byte("a"), []byte("a"), []byte("b"), nil},
+	}
+	// expectedOutputs contains the outputs that the tuples from each region
+	// can be routed to.
+	expectedOutputs := map[string][]int{
+		"a":    {0, 1},
+		"b":    {2},
+		"c":    {0, 1, 2, 3},
+		"NULL": {0, 1, 2, 3},
+	}
+	lastKeys := make([]int64, numOutputs)
+	numPushed := make([]int, numOutputs)
+	outputs := make([]routerOutput, numOutputs)
+	for i := range outputs {
+		outputIdx := i
+		lastKeys[outputIdx] = -1
+		outputs[i] = &callbackRouterOutput{
+			addBatchCb: func(batch coldata.Batch) bool {
+				for _, j := range batch.Selection()[:batch.Length()] {
+					key := batch.ColVec(0).Int64()[j]
+					region := "NULL"
+					if !batch.ColVec(1).Nulls().NullAt(j) {
+						region = string(batch.ColVec(1).Bytes().Get(j))
+					}
+					require.Contains(t, expectedOutputs[region], outputIdx, "tuple %d from region %s", key, region)
+					// The tuples must be routed in their original order.
+					require.Greater(t, key, lastKeys[outputIdx])
+					lastKeys[outputIdx] = key
+					numPushed[outputIdx]++
+				}
+				return false
+			},
+		}
+	}
+
+	r := newHashRouterWithOutputs(
+		colexecargs.OpWithMetaInfo{Root: in},
+		spec.HashColumns,
+		nil, /* unblockEventsChan */
+		outputs,
+	)
+	r.regionCol = int(*spec.RegionColumn)
+	r.streamsByRegion = spec.StreamsByRegion()
+	for !r.processNextBatch(ctx) {
+	}
+
+	var total int
+	for i := range numPushed {
+		// Every output should have received some tuples.
+		require.NotZero(t, numPushed[i], "output %d", i)
+		total += numPushed[i]
+	}
+	require.Equal(t, len(data), total)
+}
+
 func TestHashRouterCancellation(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -904,11 +990,12 @@ func TestHashRouterOneOutput(t *testing.T) {
 					Root: colexectestutils.NewOpFixedSelTestInput(tu.testAllocator, sel, len(sel), data, typs),
 				},
 				typs,
-				[]uint32{0}, /* hashCols */
+				&execinfrapb.OutputRouterSpec{HashColumns: []uint32{0}},
 				mtc.bytes,
 				queueCfg,
 				colexecop.NewTestingSemaphore(2),
 				[]*mon.BoundAccount{&diskAcc},
+				nil, /* outputComponentIDs */
 			)
 
 			if len(routerOutputs) != 1 {
@@ -1329,11 +1416,12 @@ func BenchmarkHashRouter(b *testing.B) {
 					allocators,
 					colexecargs.OpWithMetaInfo{Root: input},
 					typs,
-					[]uint32{0}, /* hashCols */
+					&execinfrapb.OutputRouterSpec{HashColumns: []uint32{0}},
 					execinfra.DefaultMemoryLimit,
 					queueCfg,
 					&colexecop.TestingSemaphore{},
 					diskAccounts,
+					nil, /* outputComponentIDs */
 				)
 				b.SetBytes(8 * int64(coldata.BatchSize()) * int64(numInputBatches))
 				// We expect distribution to not change. This is a sanity check that
@@ -12,6 +12,7 @@ package colflow
 
 import (
 	"context"
+	"math/rand"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
@@ -22,7 +23,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
+	"github.com/cockroachdb/cockroach/pkg/util/grunning"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/randutil"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
@@ -32,6 +35,7 @@ import (
 // colexecop.VectorizedStatsCollector's childStatsCollectors.
 type childStatsCollector interface {
 	getElapsedTime() time.Duration
+	getElapsedCPUTime() time.Duration
 }
 
 // batchInfoCollector is a helper used by collector implementations.
@@ -122,19 +126,27 @@ func (bic *batchInfoCollector) Next() coldata.Batch {
 // operator. ok indicates whether the stats collection was successful.
 func (bic *batchInfoCollector) finishAndGetStats() (
 	numBatches, numTuples uint64,
-	time time.Duration,
+	time, cpuTime time.Duration,
 	ok bool,
 ) {
 	tm := bic.stopwatch.Elapsed()
+	cpuTime = bic.stopwatch.ElapsedCPU()
 	// Subtract the time spent in each of the child stats collectors, to produce
 	// the amount of time that the wrapped operator spent doing work itself, not
 	// including time spent waiting on its inputs.
 	for _, statsCollectors := range bic.childStatsCollectors {
 		tm -= statsCollectors.getElapsedTime()
+		cpuTime -= statsCollectors.getElapsedCPUTime()
+	}
+	if cpuTime < 0 {
+		// The inputs might have been run in separate goroutines (e.g. by the
+		// parallel unordered synchronizer), in which case their CPU time
+		// isn't included into the CPU time of this operator's goroutine.
+		cpuTime = 0
 	}
 	bic.mu.Lock()
 	defer bic.mu.Unlock()
-	return bic.mu.numBatches, bic.mu.numTuples, tm, bic.mu.initialized
+	return bic.mu.numBatches, bic.mu.numTuples, tm, cpuTime, bic.mu.initialized
 }
 
 // getElapsedTime implements the childStatsCollector interface.
@@ -142,11 +154,18 @@ func (bic *batchInfoCollector) getElapsedTime() time.Duration {
 	return bic.stopwatch.Elapsed()
 }
 
+// getElapsedCPUTime implements the childStatsCollector interface.
+func (bic *batchInfoCollector) getElapsedCPUTime() time.Duration {
+	return bic.stopwatch.ElapsedCPU()
+}
+
 // newVectorizedStatsCollector creates a colexecop.VectorizedStatsCollector
 // which wraps 'op' that corresponds to a component with either ProcessorID or
 // StreamID 'id' (with 'idTagKey' distinguishing between the two). 'kvReader' is
 // a component (either an operator or a wrapped processor) that performs KV
-// reads that is present in the chain of operators rooted at 'op'.
+// reads that is present in the chain of operators rooted at 'op'. If
+// 'sampleRows' is true, a sample of the tuples returned by 'op' is collected
+// as well.
 func newVectorizedStatsCollector(
 	op colexecop.Operator,
 	kvReader colexecop.KVReader,
@@ -156,16 +175,21 @@ func newVectorizedStatsCollector(
 	memMonitors []*mon.BytesMonitor,
 	diskMonitors []*mon.BytesMonitor,
 	inputStatsCollectors []childStatsCollector,
+	sampleRows bool,
 ) colexecop.VectorizedStatsCollector {
 	// TODO(cathymw): Refactor to have specialized stats collectors for
 	// memory/disk stats and IO operators.
-	return &vectorizedStatsCollectorImpl{
+	vsc := &vectorizedStatsCollectorImpl{
 		batchInfoCollector: makeBatchInfoCollector(op, id, inputWatch, inputStatsCollectors),
 		kvReader:           kvReader,
 		columnarizer:       columnarizer,
 		memMonitors:        memMonitors,
 		diskMonitors:       diskMonitors,
 	}
+	if sampleRows {
+		vsc.sampler = newRowSampler(numSampledRows)
+	}
+	return vsc
 }
 
 // vectorizedStatsCollectorImpl is the implementation behind
@@ -177,11 +201,25 @@ type vectorizedStatsCollectorImpl struct {
 	columnarizer colexecop.VectorizedStatsCollector
 	memMonitors  []*mon.BytesMonitor
 	diskMonitors []*mon.BytesMonitor
+	// sampler, if set, maintains a sample of the tuples returned by the wrapped
+	// operator.
+	sampler *rowSampler
+}
+
+// Next is part of the colexecop.Operator interface.
+func (vsc *vectorizedStatsCollectorImpl) Next() coldata.Batch {
+	batch := vsc.batchInfoCollector.Next()
+	if vsc.sampler != nil && batch.Length() > 0 {
+		// Note that the sampling is performed outside of the stopwatch so
+		// that it doesn't affect the execution time of the wrapped operator.
+		vsc.sampler.sampleBatch(batch)
+	}
+	return batch
 }
 
 // GetStats is part of the colexecop.VectorizedStatsCollector interface.
 func (vsc *vectorizedStatsCollectorImpl) GetStats() *execinfrapb.ComponentStats {
-	numBatches, numTuples, time, ok := vsc.batchInfoCollector.finishAndGetStats()
+	numBatches, numTuples, time, cpuTime, ok := vsc.batchInfoCollector.finishAndGetStats()
 	if !ok {
 		// The stats collection wasn't successful for some reason, so we will
 		// return an empty object (since nil is not allowed by the contract of
@@ -229,12 +267,97 @@ func (vsc *vectorizedStatsCollectorImpl) GetStats() *execinfrapb.ComponentStats
 	} else {
 		s.Exec.ExecTime.Set(time)
 	}
+	// The CPU time is reported for all operators (including the ColBatchScans)
+	// so that CPU-bound operators can be distinguished from the ones that spend
+	// most of their time waiting on KV.
+	if grunning.Supported() {
+		s.Exec.CPUTime.Set(cpuTime)
+	}
 
 	s.Output.NumBatches.Set(numBatches)
 	s.Output.NumTuples.Set(numTuples)
+	if vsc.sampler != nil {
+		s.Output.SampledRows = vsc.sampler.getSamples()
+	}
 	return s
 }
 
+// numSampledRows is the number of tuples sampled from the output of each
+// processor for EXPLAIN ANALYZE (SAMPLE).
+const numSampledRows = 5
+
+// rowSampler maintains a uniform sample (via reservoir sampling) of the tuples
+// from the batches it is given. The sampled tuples are kept as strings, so the
+// sampler retains no references to the batches.
+type rowSampler struct {
+	rng     *rand.Rand
+	size    int
+	numSeen int64
+	// sel and slots are scratch slices that contain, respectively, the
+	// positions of the tuples of the current batch that made it into the
+	// sample and the positions of those tuples in samples.
+	sel   []int
+	slots []int
+	mu    struct {
+		// We need a mutex because getSamples() and sampleBatch() might be
+		// called from different goroutines.
+		syncutil.Mutex
+		samples []string
+	}
+}
+
+func newRowSampler(size int) *rowSampler {
+	rng, _ := randutil.NewPseudoRand()
+	return &rowSampler{rng: rng, size: size}
+}
+
+// sampleBatch updates the sample with the tuples from the batch.
+func (s *rowSampler) sampleBatch(batch coldata.Batch) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sel, s.slots = s.sel[:0], s.slots[:0]
+	numFilled := len(s.mu.samples)
+	sel := batch.Selection()
+	for i, n := 0, batch.Length(); i < n; i++ {
+		s.numSeen++
+		slot := -1
+		if numFilled < s.size {
+			slot = numFilled
+			numFilled++
+		} else if j := s.rng.Int63n(s.numSeen); j < int64(s.size) {
+			slot = int(j)
+		}
+		if slot >= 0 {
+			tupleIdx := i
+			if sel != nil {
+				tupleIdx = sel[i]
+			}
+			s.sel = append(s.sel, tupleIdx)
+			s.slots = append(s.slots, slot)
+		}
+	}
+	if len(s.sel) == 0 {
+		return
+	}
+	// Only the tuples that made it into the sample are converted. If the same
+	// slot is chosen multiple times, the later tuple wins, as it should.
+	rows := coldata.VecsToStringWithRowPrefix(batch.ColVecs(), len(s.sel), s.sel, "" /* prefix */)
+	for i, slot := range s.slots {
+		if slot == len(s.mu.samples) {
+			s.mu.samples = append(s.mu.samples, rows[i])
+		} else {
+			s.mu.samples[slot] = rows[i]
+		}
+	}
+}
+
+// getSamples returns the current sample.
+func (s *rowSampler) getSamples() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.mu.samples...)
+}
+
 // newNetworkVectorizedStatsCollector creates a new
 // colexecop.VectorizedStatsCollector for streams. In addition to the base stats,
 // newNetworkVectorizedStatsCollector collects the network latency for a stream.
@@ -263,7 +386,7 @@ type networkVectorizedStatsCollectorImpl struct {
 
 // GetStats is part of the colexecop.VectorizedStatsCollector interface.
 func (nvsc *networkVectorizedStatsCollectorImpl) GetStats() *execinfrapb.ComponentStats {
-	numBatches, numTuples, time, ok := nvsc.batchInfoCollector.finishAndGetStats()
+	numBatches, numTuples, time, _, ok := nvsc.batchInfoCollector.finishAndGetStats()
 	if !ok {
 		// The stats collection wasn't successful for some reason, so we will
 		// return an empty object (since nil is not allowed by the contract of
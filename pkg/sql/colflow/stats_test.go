@@ -12,6 +12,7 @@ package colflow
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -43,7 +44,7 @@ func TestNumBatches(t *testing.T) {
 	vsc := newVectorizedStatsCollector(
 		noop, nil /* kvReader */, nil /* columnarizer */, execinfrapb.ComponentID{},
 		timeutil.NewStopWatch(), nil /* memMonitors */, nil, /* diskMonitors */
-		nil, /* inputStatsCollectors */
+		nil /* inputStatsCollectors */, false, /* sampleRows */
 	)
 	vsc.Init(ctx)
 	for {
@@ -69,7 +70,7 @@ func TestNumTuples(t *testing.T) {
 		vsc := newVectorizedStatsCollector(
 			noop, nil /* kvReader */, nil /* columnarizer */, execinfrapb.ComponentID{},
 			timeutil.NewStopWatch(), nil /* memMonitors */, nil, /* diskMonitors */
-			nil, /* inputStatsCollectors */
+			nil /* inputStatsCollectors */, false, /* sampleRows */
 		)
 		vsc.Init(ctx)
 		for {
@@ -83,6 +84,50 @@ func TestNumTuples(t *testing.T) {
 	}
 }
 
+// TestSampledRows verifies that the stats collector retains a sample of the
+// tuples returned by the wrapped operator when row sampling is enabled.
+func TestSampledRows(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	tu := newTestUtils(ctx)
+	defer tu.cleanup(ctx)
+	for _, tc := range []struct {
+		nBatches, batchSize int
+		expected            []string
+	}{
+		// All tuples fit into the sample.
+		{nBatches: 1, batchSize: 2, expected: []string{"[0]", "[1]"}},
+		{nBatches: 10, batchSize: 16},
+	} {
+		noop := colexecop.NewNoop(makeFiniteChunksSourceWithBatchSize(tu.testAllocator, tc.nBatches, tc.batchSize))
+		vsc := newVectorizedStatsCollector(
+			noop, nil /* kvReader */, nil /* columnarizer */, execinfrapb.ComponentID{},
+			timeutil.NewStopWatch(), nil /* memMonitors */, nil, /* diskMonitors */
+			nil /* inputStatsCollectors */, true, /* sampleRows */
+		)
+		vsc.Init(ctx)
+		for {
+			b := vsc.Next()
+			if b.Length() == 0 {
+				break
+			}
+		}
+		s := vsc.(*vectorizedStatsCollectorImpl).GetStats()
+		if tc.expected != nil {
+			require.Equal(t, tc.expected, s.Output.SampledRows)
+			continue
+		}
+		require.Len(t, s.Output.SampledRows, numSampledRows)
+		for _, row := range s.Output.SampledRows {
+			var val int
+			_, err := fmt.Sscanf(row, "[%d]", &val)
+			require.NoError(t, err)
+			require.True(t, val >= 0 && val < tc.batchSize)
+		}
+	}
+}
+
 // TestVectorizedStatsCollector is an integration test for the
 // VectorizedStatsCollector. It creates two inputs and feeds them into the
 // merge joiner and makes sure that all the stats measured on the latter are as
@@ -105,7 +150,7 @@ func TestVectorizedStatsCollector(t *testing.T) {
 		leftInput := newVectorizedStatsCollector(
 			leftSource, nil /* kvReader */, nil /* columnarizer */, execinfrapb.ComponentID{ID: 0},
 			timeutil.NewTestStopWatch(timeSource.Now), nil /* memMonitors */, nil, /* diskMonitors */
-			nil, /* inputStatsCollectors */
+			nil /* inputStatsCollectors */, false, /* sampleRows */
 		)
 		rightSource := &timeAdvancingOperator{
 			OneInputHelper: colexecop.MakeOneInputHelper(makeFiniteChunksSourceWithBatchSize(tu.testAllocator, nBatches, coldata.BatchSize())),
@@ -114,7 +159,7 @@ func TestVectorizedStatsCollector(t *testing.T) {
 		rightInput := newVectorizedStatsCollector(
 			rightSource, nil /* kvReader */, nil /* columnarizer */, execinfrapb.ComponentID{ID: 1},
 			timeutil.NewTestStopWatch(timeSource.Now), nil /* memMonitors */, nil, /* diskMonitors */
-			nil, /* inputStatsCollectors */
+			nil /* inputStatsCollectors */, false, /* sampleRows */
 		)
 		mergeJoiner := colexecjoin.NewMergeJoinOp(
 			tu.testAllocator, execinfra.DefaultMemoryLimit, queueCfg,
@@ -133,6 +178,7 @@ func TestVectorizedStatsCollector(t *testing.T) {
 			timeAdvancingMergeJoiner, nil /* kvReader */, nil /* columnarizer */, execinfrapb.ComponentID{ID: 2},
 			mjInputWatch, nil /* memMonitors */, nil, /* diskMonitors */
 			[]childStatsCollector{leftInput.(childStatsCollector), rightInput.(childStatsCollector)},
+			false, /* sampleRows */
 		)
 
 		// The inputs are identical, so the merge joiner should output
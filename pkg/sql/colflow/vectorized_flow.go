@@ -363,8 +363,9 @@ func (s *vectorizedFlowCreator) wrapWithVectorizedStatsCollectorBase(
 	inputs []colexecargs.OpWithMetaInfo,
 	component execinfrapb.ComponentID,
 	monitors []*mon.BytesMonitor,
+	sampleRows bool,
 ) error {
-	inputWatch := timeutil.NewStopWatch()
+	inputWatch := timeutil.NewStopWatchWithCPU()
 	var memMonitors, diskMonitors []*mon.BytesMonitor
 	for _, m := range monitors {
 		if m.Resource() == mon.DiskResource {
@@ -383,7 +384,7 @@ func (s *vectorizedFlowCreator) wrapWithVectorizedStatsCollectorBase(
 	}
 	vsc := newVectorizedStatsCollector(
 		op.Root, kvReader, columnarizer, component, inputWatch,
-		memMonitors, diskMonitors, inputStatsCollectors,
+		memMonitors, diskMonitors, inputStatsCollectors, sampleRows,
 	)
 	op.Root = vsc
 	op.StatsCollectors = append(op.StatsCollectors, vsc)
@@ -694,6 +695,7 @@ func (s *vectorizedFlowCreator) setupRemoteOutputStream(
 		outbox.Run(
 			ctx,
 			s.nodeDialer,
+			flowCtx.Cfg.FlowStreamMuxer,
 			stream.TargetNodeID,
 			s.flowID,
 			stream.StreamID,
@@ -742,9 +744,16 @@ func (s *vectorizedFlowCreator) setupRouter(
 		allocators[i] = colmem.NewAllocator(ctx, accounts[i], factory)
 	}
 	diskMon, diskAccounts := s.monitorRegistry.CreateDiskAccounts(ctx, flowCtx, mmName, len(output.Streams))
+	var outputComponentIDs []execinfrapb.ComponentID
+	if s.recordingStats {
+		outputComponentIDs = make([]execinfrapb.ComponentID, len(output.Streams))
+		for i := range output.Streams {
+			outputComponentIDs[i] = flowCtx.StreamComponentID(output.Streams[i].StreamID)
+		}
+	}
 	router, outputs := NewHashRouter(
-		allocators, input, outputTyps, output.HashColumns, execinfra.GetWorkMemLimit(flowCtx),
-		s.diskQueueCfg, s.fdSemaphore, diskAccounts,
+		allocators, input, outputTyps, output, execinfra.GetWorkMemLimit(flowCtx),
+		s.diskQueueCfg, s.fdSemaphore, diskAccounts, outputComponentIDs,
 	)
 	runRouter := func(ctx context.Context, _ context.CancelFunc) {
 		router.Run(logtags.AddTag(ctx, "hashRouterID", streamIDs))
@@ -788,6 +797,7 @@ func (s *vectorizedFlowCreator) setupRouter(
 				if err := s.wrapWithVectorizedStatsCollectorBase(
 					&opWithMetaInfo, nil /* kvReader */, nil, /* columnarizer */
 					nil /* inputs */, flowCtx.StreamComponentID(stream.StreamID), mons,
+					false, /* sampleRows */
 				); err != nil {
 					return err
 				}
@@ -937,6 +947,7 @@ func (s *vectorizedFlowCreator) setupInput(
 			if err := s.wrapWithVectorizedStatsCollectorBase(
 				&opWithMetaInfo, nil /* kvReader */, nil, /* columnarizer */
 				statsInputsAsOps, execinfrapb.ComponentID{}, nil, /* monitors */
+				false, /* sampleRows */
 			); err != nil {
 				return colexecargs.OpWithMetaInfo{}, err
 			}
@@ -1159,7 +1170,7 @@ func (s *vectorizedFlowCreator) setupFlow(
 				newMonitors := s.monitorRegistry.GetMonitors()[numOldMonitors:]
 				if err := s.wrapWithVectorizedStatsCollectorBase(
 					&result.OpWithMetaInfo, result.KVReader, result.Columnarizer, inputs,
-					flowCtx.ProcessorComponentID(pspec.ProcessorID), newMonitors,
+					flowCtx.ProcessorComponentID(pspec.ProcessorID), newMonitors, flowCtx.SampleRows,
 				); err != nil {
 					return
 				}
@@ -1364,20 +1375,24 @@ func (r *noopFlowCreatorHelper) Release() {
 	noopFlowCreatorHelperPool.Put(r)
 }
 
-// IsSupported returns whether a flow specified by spec can be vectorized.
-func IsSupported(mode sessiondatapb.VectorizeExecMode, spec *execinfrapb.FlowSpec) error {
+// IsSupported returns whether a flow specified by spec can be vectorized. If
+// it cannot, the name of the component (a processor core or an output router)
+// that isn't supported is returned along with the error.
+func IsSupported(
+	mode sessiondatapb.VectorizeExecMode, spec *execinfrapb.FlowSpec,
+) (unsupportedComponent string, _ error) {
 	for pIdx := range spec.Processors {
 		if err := colbuilder.IsSupported(mode, &spec.Processors[pIdx]); err != nil {
-			return err
+			return spec.Processors[pIdx].Core.Name(), err
 		}
 		for _, procOutput := range spec.Processors[pIdx].Output {
 			switch procOutput.Type {
 			case execinfrapb.OutputRouterSpec_PASS_THROUGH,
 				execinfrapb.OutputRouterSpec_BY_HASH:
 			default:
-				return errors.New("only pass-through and hash routers are supported")
+				return "OutputRouter(" + procOutput.Type.String() + ")", errors.New("only pass-through and hash routers are supported")
 			}
 		}
 	}
-	return nil
+	return "", nil
 }
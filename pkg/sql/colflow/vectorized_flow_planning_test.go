@@ -57,6 +57,11 @@ func TestVectorizedPlanning(t *testing.T) {
 			"          └ *colexecutils.CancelChecker",
 			"            └ *colexec.invariantsChecker",
 			"              └ *colfetcher.ColBatchScan",
+			"                parallelize: false",
+			"                batch bytes limit: default",
+			"                limit hint: none",
+			"                locking strength: for none",
+			"                locking wait policy: block",
 		}
 		for rows.Next() {
 			var actual string
@@ -210,11 +210,12 @@ func TestVectorizedFlowShutdown(t *testing.T) {
 						MetadataSources: toDrain,
 					},
 					typs,
-					[]uint32{0}, /* hashCols */
+					&execinfrapb.OutputRouterSpec{HashColumns: []uint32{0}},
 					execinfra.DefaultMemoryLimit,
 					queueCfg,
 					&colexecop.TestingSemaphore{},
 					diskAccounts,
+					nil, /* outputComponentIDs */
 				)
 				for i := 0; i < numInboxes; i++ {
 					inboxMemAccount := testMemMonitor.MakeBoundAccount()
@@ -274,6 +275,7 @@ func TestVectorizedFlowShutdown(t *testing.T) {
 						outbox.Run(
 							outboxCtx,
 							dialer,
+							nil, /* muxer */
 							execinfra.StaticSQLInstanceID,
 							flowID,
 							execinfrapb.StreamID(id),
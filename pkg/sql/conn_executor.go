@@ -289,6 +289,9 @@ type Server struct {
 	// fingerprint IDs for all recently executed transactions.
 	txnIDCache *txnidcache.Cache
 
+	// planBaselines is the registry of the plan regression guard.
+	planBaselines *planBaselines
+
 	// Metrics is used to account normal queries.
 	Metrics Metrics
 
@@ -383,6 +386,7 @@ func NewServer(cfg *ExecutorConfig, pool *mon.BytesMonitor) *Server {
 		txnIDCache: txnidcache.NewTxnIDCache(
 			cfg.Settings,
 			&serverMetrics.ContentionSubsystemMetrics),
+		planBaselines: newPlanBaselines(cfg.Settings),
 	}
 
 	telemetryLoggingMetrics := &TelemetryLoggingMetrics{}
@@ -416,6 +420,8 @@ func makeMetrics(internal bool) Metrics {
 			SQLOptFallbackCount:   metric.NewCounter(getMetricMeta(MetaSQLOptFallback, internal)),
 			SQLOptPlanCacheHits:   metric.NewCounter(getMetricMeta(MetaSQLOptPlanCacheHits, internal)),
 			SQLOptPlanCacheMisses: metric.NewCounter(getMetricMeta(MetaSQLOptPlanCacheMisses, internal)),
+			SQLResultCacheHits:    metric.NewCounter(getMetricMeta(MetaSQLResultCacheHits, internal)),
+			SQLResultCacheMisses:  metric.NewCounter(getMetricMeta(MetaSQLResultCacheMisses, internal)),
 			// TODO(mrtracy): See HistogramWindowInterval in server/config.go for the 6x factor.
 			DistSQLExecLatency: metric.NewLatency(getMetricMeta(MetaDistSQLExecLatency, internal),
 				6*metricsSampleInterval),
@@ -1001,6 +1007,7 @@ func (s *Server) newConnExecutorWithTxn(
 		nil, /* historicalTimestamp */
 		roachpb.UnspecifiedUserPriority,
 		tree.ReadWrite,
+		tree.SerializableIsolation,
 		txn,
 		ex.transitionCtx,
 		ex.QualityOfService())
@@ -2576,7 +2583,13 @@ func (ex *connExecutor) setTransactionModes(
 			return err
 		}
 	}
-	if modes.Isolation != tree.UnspecifiedIsolation && modes.Isolation != tree.SerializableIsolation {
+	switch modes.Isolation {
+	case tree.UnspecifiedIsolation:
+	case tree.SerializableIsolation, tree.ReadCommittedIsolation:
+		if err := ex.state.setIsolationLevel(ex.txnIsolationLevelWithSessionDefault(modes.Isolation)); err != nil {
+			return err
+		}
+	default:
 		return errors.AssertionFailedf(
 			"unknown isolation level: %s", errors.Safe(modes.Isolation))
 	}
@@ -2619,6 +2632,23 @@ func (ex *connExecutor) txnPriorityWithSessionDefault(mode tree.UserPriority) ro
 	return txnPriorityToProto(mode)
 }
 
+// txnIsolationLevelWithSessionDefault returns the isolation level that a
+// transaction which requested the given one runs at. Transactions that don't
+// request a level use the default_transaction_isolation session setting, and
+// READ COMMITTED is upgraded to SERIALIZABLE unless it's allowed by the
+// sql.txn.read_committed_isolation.enabled cluster setting.
+func (ex *connExecutor) txnIsolationLevelWithSessionDefault(
+	level tree.IsolationLevel,
+) tree.IsolationLevel {
+	if level == tree.UnspecifiedIsolation {
+		level = tree.IsolationLevel(ex.sessionData().DefaultTxnIsolationLevel)
+	}
+	if level == tree.ReadCommittedIsolation && allowReadCommittedIsolation.Get(&ex.server.cfg.Settings.SV) {
+		return tree.ReadCommittedIsolation
+	}
+	return tree.SerializableIsolation
+}
+
 // QualityOfService returns the QoSLevel session setting if the session
 // settings are populated, otherwise the default QoSLevel.
 func (ex *connExecutor) QualityOfService() sessiondatapb.QoSLevel {
@@ -2691,6 +2721,7 @@ func (ex *connExecutor) initEvalCtx(ctx context.Context, evalCtx *extendedEvalCo
 		SchemaChangeJobRecords: ex.extraTxnState.schemaChangeJobRecords,
 		statsProvider:          ex.server.sqlStats,
 		indexUsageStats:        ex.indexUsageStats,
+		planBaselines:          ex.server.planBaselines,
 		statementPreparer:      ex,
 	}
 	evalCtx.copyFromExecCfg(ex.server.cfg)
@@ -2707,6 +2738,7 @@ func (ex *connExecutor) resetEvalCtx(evalCtx *extendedEvalContext, txn *kv.Txn,
 	newTxn := txn == nil || evalCtx.Txn != txn
 	evalCtx.TxnState = ex.getTransactionState()
 	evalCtx.TxnReadOnly = ex.state.readOnly
+	evalCtx.TxnIsoLevel = ex.state.isolationLevel
 	evalCtx.TxnImplicit = ex.implicitTxn()
 	evalCtx.TxnIsSingleStmt = false
 	if newTxn || !ex.implicitTxn() {
@@ -38,10 +38,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessionphase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
@@ -448,7 +451,13 @@ func (ex *connExecutor) execStmtInOpenState(
 		}
 
 		// TODO(radu): what about .SQL, .NumAnnotations, .NumPlaceholders?
+		hints, hintsErr := stmt.Hints, stmt.HintsErr
 		stmt.Statement = ps.Statement
+		if len(hints) > 0 || hintsErr != nil {
+			// The hints given to EXECUTE override those of the prepared
+			// statement.
+			stmt.Hints, stmt.HintsErr = hints, hintsErr
+		}
 		stmt.Prepared = ps
 		stmt.ExpectedTypes = ps.Columns
 		stmt.StmtNoConstants = ps.StatementNoConstants
@@ -485,10 +494,30 @@ func (ex *connExecutor) execStmtInOpenState(
 	}
 
 	// We exempt `SET` statements from the statement timeout, particularly so as
-	// not to block the `SET statement_timeout` command itself.
-	if ex.sessionData().StmtTimeout > 0 && ast.StatementTag() != "SET" {
+	// not to block the `SET statement_timeout` command itself. A timeout given
+	// in a hint of the statement overrides the session's and applies to all
+	// statements.
+	if ex.sessionData().RejectUnknownStatementHints {
+		if stmt.HintsErr != nil {
+			return makeErrEvent(stmt.HintsErr)
+		}
+		if err := checkStatementHints(stmt.Hints); err != nil {
+			return makeErrEvent(err)
+		}
+	} else if stmt.HintsErr != nil {
+		// Hint comments that can't be parsed are ignored like other comments.
+		p.BufferClientNotice(ctx, pgnotice.Newf("ignoring %v", stmt.HintsErr))
+	}
+	stmtTimeout, hinted, err := stmtTimeoutFromHints(ex.sessionData().GetIntervalStyle(), stmt.Hints)
+	if err != nil {
+		return makeErrEvent(err)
+	}
+	if !hinted {
+		stmtTimeout = ex.sessionData().StmtTimeout
+	}
+	if stmtTimeout > 0 && (hinted || ast.StatementTag() != "SET") {
 		timerDuration :=
-			ex.sessionData().StmtTimeout - timeutil.Since(ex.phaseTimes.GetSessionPhaseTime(sessionphase.SessionQueryReceived))
+			stmtTimeout - timeutil.Since(ex.phaseTimes.GetSessionPhaseTime(sessionphase.SessionQueryReceived))
 		// There's no need to proceed with execution if the timer has already expired.
 		if timerDuration < 0 {
 			queryTimedOut = true
@@ -639,6 +668,12 @@ func (ex *connExecutor) execStmtInOpenState(
 	prevSteppingMode := ex.state.mu.txn.ConfigureStepping(ctx, kv.SteppingEnabled)
 	defer func() { _ = ex.state.mu.txn.ConfigureStepping(ctx, prevSteppingMode) }()
 
+	// Transactions that run at the READ COMMITTED isolation level read from a
+	// new snapshot for each statement.
+	if err := ex.maybeStepReadTimestamp(ctx); err != nil {
+		return makeErrEvent(err)
+	}
+
 	// Then we create a sequencing point.
 	//
 	// This is not the only place where a sequencing point is
@@ -1145,9 +1180,14 @@ func (ex *connExecutor) dispatchToExecutionEngine(
 		distribute = DistributionTypeAlways
 	}
 	ex.sessionTracing.TraceExecStart(ctx, "distributed")
-	stats, err := ex.execWithDistSQLEngine(
-		ctx, planner, stmt.AST.StatementReturnType(), res, distribute, progAtomic,
-	)
+	var stats topLevelQueryStats
+	if planner.curPlan.resultCacheStmt != nil && ex.executorType != executorTypeInternal {
+		stats, err = ex.execWithResultCache(ctx, planner, res, distribute, progAtomic)
+	} else {
+		stats, err = ex.execWithDistSQLEngine(
+			ctx, planner, stmt.AST.StatementReturnType(), res, distribute, progAtomic,
+		)
+	}
 	if res.Err() == nil {
 		// numTxnRetryErrors is the number of times an error will be injected if
 		// the transaction is retried using SAVEPOINTs.
@@ -1433,6 +1473,7 @@ func (ex *connExecutor) execWithDistSQLEngine(
 	}
 	planCtx.traceMetadata = planner.instrumentation.traceMetadata
 	planCtx.collectExecStats = planner.instrumentation.ShouldCollectExecStats()
+	planCtx.sampleRows = planner.instrumentation.ShouldSampleRows()
 
 	var evalCtxFactory func() *extendedEvalContext
 	if len(planner.curPlan.subqueryPlans) != 0 ||
@@ -1539,6 +1580,31 @@ func (ex *connExecutor) beginTransactionTimestampsAndReadMode(
 var eventStartImplicitTxn fsm.Event = eventTxnStart{ImplicitTxn: fsm.True}
 var eventStartExplicitTxn fsm.Event = eventTxnStart{ImplicitTxn: fsm.False}
 
+// maybeStepReadTimestamp moves the read timestamp of a transaction that runs at
+// the READ COMMITTED isolation level forward, so that the statement that's
+// about to be executed observes the writes of all the transactions that
+// committed before it started. The reads performed by the previous statements
+// aren't refreshed anymore, so the transaction doesn't need to be retried if
+// they're invalidated by later writes. Transactions that have a historical
+// timestamp, and transactions that modified descriptors, keep reading at a
+// single timestamp.
+func (ex *connExecutor) maybeStepReadTimestamp(ctx context.Context) error {
+	if ex.state.isolationLevel != tree.ReadCommittedIsolation || ex.state.isHistorical {
+		return nil
+	}
+	// The descriptors leased or read by the previous statements are released,
+	// since they may not be valid at the new read timestamp.
+	if !ex.extraTxnState.descCollection.ResetForNewReadTimestamp(ctx) {
+		return nil
+	}
+	if err := ex.state.mu.txn.StepReadTimestamp(ctx); err != nil {
+		return err
+	}
+	// Stepping the read timestamp discards the deadline of the transaction, so
+	// set it again now that the leases are gone.
+	return ex.extraTxnState.descCollection.MaybeUpdateDeadline(ctx, ex.state.mu.txn)
+}
+
 // execStmtInNoTxnState "executes" a statement when no transaction is in scope.
 // For anything but BEGIN, this method doesn't actually execute the statement;
 // it just returns an Event that will generate a transaction. The statement will
@@ -1568,6 +1634,7 @@ func (ex *connExecutor) execStmtInNoTxnState(
 			makeEventTxnStartPayload(
 				ex.txnPriorityWithSessionDefault(s.Modes.UserPriority),
 				mode,
+				ex.txnIsolationLevelWithSessionDefault(s.Modes.Isolation),
 				sqlTs,
 				historicalTs,
 				ex.transitionCtx,
@@ -1589,6 +1656,7 @@ func (ex *connExecutor) execStmtInNoTxnState(
 			makeEventTxnStartPayload(
 				ex.txnPriorityWithSessionDefault(tree.UnspecifiedUserPriority),
 				mode,
+				ex.txnIsolationLevelWithSessionDefault(tree.UnspecifiedIsolation),
 				sqlTs,
 				historicalTs,
 				ex.transitionCtx,
@@ -1619,6 +1687,7 @@ func (ex *connExecutor) beginImplicitTxn(
 		makeEventTxnStartPayload(
 			ex.txnPriorityWithSessionDefault(tree.UnspecifiedUserPriority),
 			mode,
+			ex.txnIsolationLevelWithSessionDefault(tree.UnspecifiedIsolation),
 			sqlTs,
 			historicalTs,
 			ex.transitionCtx,
@@ -1875,7 +1944,9 @@ func (ex *connExecutor) runShowCompletions(
 	if err != nil {
 		return err
 	}
-	completions, err := delegate.RunShowCompletions(n.Statement.RawString(), offset)
+	completions, err := delegate.RunShowCompletions(
+		ctx, n.Statement.RawString(), offset, showCompletionsSource{ex: ex},
+	)
 	if err != nil {
 		return err
 	}
@@ -1889,6 +1960,69 @@ func (ex *connExecutor) runShowCompletions(
 	return nil
 }
 
+// showCompletionsSource implements delegate.CompletionsSource. The schema
+// objects are listed with the internal executor, as the user and in the
+// current database of the session, so that only the objects visible to the
+// user are completed.
+type showCompletionsSource struct {
+	ex *connExecutor
+}
+
+var _ delegate.CompletionsSource = showCompletionsSource{}
+
+// FunctionNames is part of the delegate.CompletionsSource interface.
+func (showCompletionsSource) FunctionNames() []string {
+	return builtins.AllBuiltinNames
+}
+
+// TableNames is part of the delegate.CompletionsSource interface.
+func (s showCompletionsSource) TableNames(ctx context.Context) ([]string, error) {
+	return s.queryNames(ctx, "show-completions-tables", `
+SELECT DISTINCT table_name FROM information_schema.tables
+ WHERE table_catalog = current_database() AND table_schema = ANY current_schemas(false)
+ ORDER BY table_name`)
+}
+
+// ColumnNames is part of the delegate.CompletionsSource interface.
+func (s showCompletionsSource) ColumnNames(
+	ctx context.Context, tables []string,
+) ([]string, error) {
+	tableNames := tree.NewDArray(types.String)
+	for _, t := range tables {
+		if err := tableNames.Append(tree.NewDString(t)); err != nil {
+			return nil, err
+		}
+	}
+	return s.queryNames(ctx, "show-completions-columns", `
+SELECT DISTINCT column_name FROM information_schema.columns
+ WHERE table_catalog = current_database() AND table_schema = ANY current_schemas(false)
+   AND table_name = ANY $1
+ ORDER BY column_name`, tableNames)
+}
+
+func (s showCompletionsSource) queryNames(
+	ctx context.Context, opName string, query string, qargs ...interface{},
+) ([]string, error) {
+	sd := s.ex.sessionData()
+	rows, err := s.ex.server.cfg.InternalExecutor.QueryBufferedEx(
+		ctx, opName, nil, /* txn */
+		sessiondata.InternalExecutorOverride{
+			User:       sd.User(),
+			Database:   sd.Database,
+			SearchPath: &sd.SearchPath,
+		},
+		query, qargs...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(rows))
+	for i, row := range rows {
+		names[i] = string(tree.MustBeDString(row[0]))
+	}
+	return names, nil
+}
+
 // showQueryStatsFns maps column names as requested by the SQL clients
 // to timing retrieval functions from the execution phase times.
 var showQueryStatsFns = map[tree.Name]func(*sessionphase.Times) time.Duration{
@@ -14,6 +14,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
@@ -203,6 +204,7 @@ func (ex *connExecutor) execRollbackToSavepointInOpenState(
 		ev, payload := ex.makeErrEvent(err, s)
 		return ev, payload
 	}
+	ex.maybeRollbackSchemaChangesToSavepoint(ctx, entry)
 
 	if err := ex.popSavepointsToIdx(s, idx); err != nil {
 		return ex.makeErrEvent(err, s)
@@ -229,13 +231,13 @@ func (ex *connExecutor) checkRollbackValidity(
 		return ev, payload, true
 	}
 
-	if !entry.kvToken.Initial() {
+	if !entry.kvToken.Initial() && !ex.canRollbackDeclarativeSchemaChanges(entry) {
 		// We don't yet support rolling back a regular savepoint over
-		// DDL. Instead of creating an inconsistent txn or schema state,
-		// prefer to tell the users we don't know how to proceed
-		// yet. Initial savepoints are a special case - we can always
-		// rollback to them because we can reset all the schema change
-		// state.
+		// DDL executed by the legacy schema changer. Instead of creating
+		// an inconsistent txn or schema state, prefer to tell the users
+		// we don't know how to proceed yet. Initial savepoints are a
+		// special case - we can always rollback to them because we can
+		// reset all the schema change state.
 		ev, payload = ex.makeErrEvent(unimplemented.NewWithIssueDetail(10735, "rollback-after-ddl",
 			"ROLLBACK TO SAVEPOINT not yet supported after DDL statements"), s)
 		return ev, payload, false
@@ -287,6 +289,7 @@ func (ex *connExecutor) execRollbackToSavepointInAbortedState(
 	if err := ex.state.mu.txn.RollbackToSavepoint(ctx, entry.kvToken); err != nil {
 		return ex.makeErrEvent(err, s)
 	}
+	ex.maybeRollbackSchemaChangesToSavepoint(ctx, entry)
 
 	if entry.kvToken.Initial() {
 		return eventTxnRestart{}, nil
@@ -294,6 +297,36 @@ func (ex *connExecutor) execRollbackToSavepointInAbortedState(
 	return eventSavepointRollback{}, nil
 }
 
+// canRollbackDeclarativeSchemaChanges returns true if the DDL statements
+// executed since the creation of the savepoint can be rolled back along with
+// the KV writes of the transaction. This is the case if they were all executed
+// by the declarative schema changer, which keeps their effects in memory until
+// the transaction commits, and if the savepoint was created before any DDL
+// statement, so that the state of the schema changer and of the descriptors
+// as of the savepoint is the state as of the start of the transaction.
+func (ex *connExecutor) canRollbackDeclarativeSchemaChanges(entry *savepoint) bool {
+	scs := &ex.extraTxnState.schemaChangerState
+	return entry.numDDL == 0 && scs.jobID == jobspb.InvalidJobID &&
+		scs.numDDL == ex.extraTxnState.numDDL
+}
+
+// maybeRollbackSchemaChangesToSavepoint resets the state of the declarative
+// schema changer and the descriptors modified by the transaction after the KV
+// transaction was rolled back to a savepoint created before any DDL statement.
+// The descriptors are then read from storage again, as of the savepoint.
+func (ex *connExecutor) maybeRollbackSchemaChangesToSavepoint(
+	ctx context.Context, entry *savepoint,
+) {
+	scs := &ex.extraTxnState.schemaChangerState
+	if entry.kvToken.Initial() || entry.numDDL != 0 ||
+		(ex.extraTxnState.numDDL == 0 && len(scs.stmts) == 0) {
+		return
+	}
+	*scs = SchemaChangerState{mode: scs.mode}
+	ex.extraTxnState.numDDL = 0
+	ex.extraTxnState.descCollection.ResetUncommitted(ctx)
+}
+
 // popSavepointsToIdx pops savepoints and SessionData elements related to
 // the savepoint up to the given idx.
 func (ex *connExecutor) popSavepointsToIdx(stmt tree.Statement, idx int) error {
@@ -348,7 +381,8 @@ type savepoint struct {
 
 	// The number of DDL statements that had been executed in the transaction (at
 	// the time the savepoint was created). We refuse to roll back a savepoint if
-	// more DDL statements were executed since the savepoint's creation.
+	// more DDL statements were executed since the savepoint's creation, unless
+	// they can be rolled back by the declarative schema changer.
 	// TODO(knz): support partial DDL cancellation in pending txns.
 	numDDL int
 }
@@ -108,6 +108,7 @@ type eventTxnStartPayload struct {
 	// current_timestamp(), transaction_timestamp().
 	txnSQLTimestamp     time.Time
 	readOnly            tree.ReadWriteMode
+	isolationLevel      tree.IsolationLevel
 	historicalTimestamp *hlc.Timestamp
 	// qualityOfService denotes the user-level admission queue priority to use for
 	// any new Txn started using this payload.
@@ -118,6 +119,7 @@ type eventTxnStartPayload struct {
 func makeEventTxnStartPayload(
 	pri roachpb.UserPriority,
 	readOnly tree.ReadWriteMode,
+	isolationLevel tree.IsolationLevel,
 	txnSQLTimestamp time.Time,
 	historicalTimestamp *hlc.Timestamp,
 	tranCtx transitionCtx,
@@ -126,6 +128,7 @@ func makeEventTxnStartPayload(
 	return eventTxnStartPayload{
 		pri:                 pri,
 		readOnly:            readOnly,
+		isolationLevel:      isolationLevel,
 		txnSQLTimestamp:     txnSQLTimestamp,
 		historicalTimestamp: historicalTimestamp,
 		tranCtx:             tranCtx,
@@ -481,6 +484,7 @@ func noTxnToOpen(args fsm.Args) error {
 		payload.historicalTimestamp,
 		payload.pri,
 		payload.readOnly,
+		payload.isolationLevel,
 		nil, /* txn */
 		payload.tranCtx,
 		payload.qualityOfService,
@@ -50,6 +50,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
+	"github.com/cockroachdb/cockroach/pkg/sql/colflow"
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -128,6 +129,7 @@ var crdbInternal = virtualSchema{
 		catconstants.CrdbInternalGossipNetworkTableID:               crdbInternalGossipNetworkTable,
 		catconstants.CrdbInternalTransactionContentionEvents:        crdbInternalTransactionContentionEventsTable,
 		catconstants.CrdbInternalIndexColumnsTableID:                crdbInternalIndexColumnsTable,
+		catconstants.CrdbInternalIndexRecommendationsTableID:        crdbInternalIndexRecommendationsTable,
 		catconstants.CrdbInternalIndexUsageStatisticsTableID:        crdbInternalIndexUsageStatistics,
 		catconstants.CrdbInternalInflightTraceSpanTableID:           crdbInternalInflightTraceSpanTable,
 		catconstants.CrdbInternalJobsTableID:                        crdbInternalJobsTable,
@@ -141,8 +143,10 @@ var crdbInternal = virtualSchema{
 		catconstants.CrdbInternalLocalSessionsTableID:               crdbInternalLocalSessionsTable,
 		catconstants.CrdbInternalLocalMetricsTableID:                crdbInternalLocalMetricsTable,
 		catconstants.CrdbInternalNodeExecutionOutliersTableID:       crdbInternalNodeExecutionOutliersTable,
+		catconstants.CrdbInternalNodePlanBaselinesTableID:           crdbInternalNodePlanBaselinesTable,
 		catconstants.CrdbInternalNodeStmtStatsTableID:               crdbInternalNodeStmtStatsTable,
 		catconstants.CrdbInternalNodeTxnStatsTableID:                crdbInternalNodeTxnStatsTable,
+		catconstants.CrdbInternalNodeVectorizedFallbacksTableID:     crdbInternalNodeVectorizedFallbacksTable,
 		catconstants.CrdbInternalPartitionsTableID:                  crdbInternalPartitionsTable,
 		catconstants.CrdbInternalPredefinedCommentsTableID:          crdbInternalPredefinedCommentsTable,
 		catconstants.CrdbInternalRangesNoLeasesTableID:              crdbInternalRangesNoLeasesTable,
@@ -6190,3 +6194,211 @@ CREATE TABLE crdb_internal.node_execution_outliers (
 		return err
 	},
 }
+
+var crdbInternalNodeVectorizedFallbacksTable = virtualSchemaTable{
+	comment: "fallbacks of the vectorized engine to the row-by-row engine (RAM; local node only)",
+	schema: `
+CREATE TABLE crdb_internal.node_vectorized_fallbacks (
+	node_id       INT NOT NULL,
+	fallback_type STRING NOT NULL, -- 'wrapped' if only the component was executed by the row-by-row engine, 'flow' if the whole flow was
+	component     STRING NOT NULL, -- the processor core or the output router that caused the fallback
+	reason        STRING NOT NULL,
+	count         INT NOT NULL,
+	last_occurred TIMESTAMPTZ NOT NULL
+);`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.node_vectorized_fallbacks"); err != nil {
+			return err
+		}
+		registry, ok := p.ExecCfg().DistSQLSrv.VectorizedFallbacks.(*colflow.FallbackRegistry)
+		if !ok {
+			return nil
+		}
+		nodeID, _ := p.execCfg.NodeID.OptionalNodeID() // zero if not available
+		for _, e := range registry.Entries() {
+			fallbackType := "flow"
+			if e.Wrapped {
+				fallbackType = "wrapped"
+			}
+			lastOccurred, err := tree.MakeDTimestampTZ(e.LastOccurred, time.Microsecond)
+			if err != nil {
+				return err
+			}
+			if err := addRow(
+				tree.NewDInt(tree.DInt(nodeID)),
+				tree.NewDString(fallbackType),
+				tree.NewDString(e.Component),
+				tree.NewDString(e.Reason),
+				tree.NewDInt(tree.DInt(e.Count)),
+				lastOccurred,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var crdbInternalNodePlanBaselinesTable = virtualSchemaTable{
+	comment: "baseline plans of the plan regression guard (RAM; local node only)",
+	schema: `
+CREATE TABLE crdb_internal.node_plan_baselines (
+	node_id                   INT NOT NULL,
+	fingerprint               STRING NOT NULL,
+	database_name             STRING NOT NULL,
+	baseline_plan_gist        STRING NOT NULL,
+	baseline_hints            STRING NOT NULL, -- the optimizer hints that steer the optimizer towards the baseline plan
+	baseline_executions       INT NOT NULL,
+	baseline_mean_run_latency FLOAT NOT NULL,  -- in seconds
+	plan_gist                 STRING,          -- the last other plan, once the baseline plan was executed enough times
+	executions                INT,
+	mean_run_latency          FLOAT,           -- in seconds
+	fallback                  BOOL NOT NULL,   -- true if the statements are planned with the baseline hints
+	fallback_count            INT NOT NULL,
+	last_fallback             TIMESTAMPTZ
+);`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.node_plan_baselines"); err != nil {
+			return err
+		}
+		if p.extendedEvalCtx.planBaselines == nil {
+			return nil
+		}
+		nodeID, _ := p.execCfg.NodeID.OptionalNodeID() // zero if not available
+		for _, e := range p.extendedEvalCtx.planBaselines.entries() {
+			planGist, executions, meanLatency := tree.DNull, tree.DNull, tree.DNull
+			if e.current.count > 0 {
+				planGist = tree.NewDString(e.current.gist)
+				executions = tree.NewDInt(tree.DInt(e.current.count))
+				meanLatency = tree.NewDFloat(tree.DFloat(e.current.meanLatency))
+			}
+			lastFallback := tree.DNull
+			if e.fallbacks > 0 {
+				ts, err := tree.MakeDTimestampTZ(e.lastFallback, time.Microsecond)
+				if err != nil {
+					return err
+				}
+				lastFallback = ts
+			}
+			if err := addRow(
+				tree.NewDInt(tree.DInt(nodeID)),
+				tree.NewDString(e.key.fingerprint),
+				tree.NewDString(e.key.database),
+				tree.NewDString(e.baseline.gist),
+				tree.NewDString(formatStatementHints(e.hints)),
+				tree.NewDInt(tree.DInt(e.baseline.count)),
+				tree.NewDFloat(tree.DFloat(e.baseline.meanLatency)),
+				planGist,
+				executions,
+				meanLatency,
+				tree.MakeDBool(tree.DBool(e.fallback)),
+				tree.NewDInt(tree.DInt(e.fallbacks)),
+				lastFallback,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var crdbInternalIndexRecommendationsTable = virtualSchemaTable{
+	comment: `index recommendations of the index advisor for the most frequently executed statements`,
+	schema: `
+CREATE TABLE crdb_internal.index_recommendations (
+	rank              INT NOT NULL,
+	database_name     STRING NOT NULL,
+	schema_name       STRING NOT NULL,
+	table_name        STRING NOT NULL,
+	type              STRING NOT NULL, -- 'index creation' or 'index replacement'
+	statement         STRING NOT NULL,
+	estimated_benefit FLOAT NOT NULL,  -- reduction of the estimated cost of the statements, weighted by their executions
+	write_overhead    INT NOT NULL,    -- executions of the statements that write to the table
+	fingerprints      STRING[] NOT NULL,
+	last_updated      TIMESTAMPTZ NOT NULL
+);`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		hasViewActivityOrViewActivityRedacted, err := p.HasViewActivityOrViewActivityRedactedRole(ctx)
+		if err != nil {
+			return err
+		}
+		if !hasViewActivityOrViewActivityRedacted {
+			return pgerror.Newf(pgcode.InsufficientPrivilege,
+				"user %s does not have %s or %s privilege", p.User(), roleoption.VIEWACTIVITY, roleoption.VIEWACTIVITYREDACTED)
+		}
+
+		// The recommendations are those of the most recently created index advisor
+		// job, which is the running one if the advisor is enabled.
+		it, err := p.ExtendedEvalContext().ExecCfg.InternalExecutor.QueryIteratorEx(
+			ctx, "crdb-internal-index-recommendations", p.Txn(),
+			sessiondata.InternalExecutorOverride{User: username.RootUserName()},
+			`SELECT payload, progress FROM system.jobs ORDER BY created DESC`)
+		if err != nil {
+			return err
+		}
+		var progress *jobspb.IndexAdvisorProgress
+		for progress == nil {
+			ok, err := it.Next(ctx)
+			if err != nil {
+				return errors.CombineErrors(err, it.Close())
+			}
+			if !ok {
+				break
+			}
+			payload, err := jobs.UnmarshalPayload(it.Cur()[0])
+			if err != nil {
+				return errors.CombineErrors(err, it.Close())
+			}
+			if payload.Type() != jobspb.TypeIndexAdvisor {
+				continue
+			}
+			jobProgress, err := jobs.UnmarshalProgress(it.Cur()[1])
+			if err != nil {
+				return errors.CombineErrors(err, it.Close())
+			}
+			progress = jobProgress.GetIndexAdvisor()
+			if progress == nil {
+				progress = &jobspb.IndexAdvisorProgress{}
+			}
+		}
+		if err := it.Close(); err != nil {
+			return err
+		}
+		if progress == nil {
+			return nil
+		}
+
+		lastUpdated, err := tree.MakeDTimestampTZ(progress.LastRun.GoTime(), time.Microsecond)
+		if err != nil {
+			return err
+		}
+		for i := range progress.Recommendations {
+			rec := &progress.Recommendations[i]
+			recType := "index creation"
+			if rec.Replacement {
+				recType = "index replacement"
+			}
+			fingerprints := tree.NewDArray(types.String)
+			for _, f := range rec.Fingerprints {
+				if err := fingerprints.Append(tree.NewDString(f)); err != nil {
+					return err
+				}
+			}
+			if err := addRow(
+				tree.NewDInt(tree.DInt(i+1)),
+				tree.NewDString(rec.Database),
+				tree.NewDString(rec.Schema),
+				tree.NewDString(rec.Table),
+				tree.NewDString(recType),
+				tree.NewDString(rec.Statement),
+				tree.NewDFloat(tree.DFloat(rec.EstimatedBenefit)),
+				tree.NewDInt(tree.DInt(rec.WriteOverhead)),
+				fingerprints,
+				lastUpdated,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
@@ -0,0 +1,254 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+type createAggregateNode struct {
+	n      *tree.CreateAggregate
+	schema *schemadesc.Mutable
+	agg    descpb.UserDefinedAggregate
+}
+
+// CreateAggregate creates a user-defined aggregate function.
+// Privileges: CREATE on the schema.
+func (p *planner) CreateAggregate(ctx context.Context, n *tree.CreateAggregate) (planNode, error) {
+	if err := checkUserDefinedAggregatesSupported(ctx, p, "CREATE AGGREGATE"); err != nil {
+		return nil, err
+	}
+	if _, overloads := builtins.GetBuiltinProperties(n.Name.Object()); overloads != nil {
+		return nil, pgerror.Newf(pgcode.DuplicateFunction,
+			"%s is the name of a built-in function", n.Name.Object())
+	}
+
+	db, _, prefix, err := p.ResolveTargetObject(ctx, n.Name)
+	if err != nil {
+		return nil, err
+	}
+	if db.GetID() == keys.SystemDatabaseID {
+		return nil, errors.New("cannot create an aggregate in the system database")
+	}
+	sc, err := p.getNonTemporarySchemaForCreate(ctx, db, prefix.Schema())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.canCreateOnSchema(
+		ctx, sc.GetID(), db.GetID(), p.User(), checkPublicSchema,
+	); err != nil {
+		return nil, err
+	}
+	schema, ok := sc.(*schemadesc.Mutable)
+	if !ok {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot create an aggregate in schema %q", sc.GetName())
+	}
+
+	agg, err := p.makeUserDefinedAggregate(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	return &createAggregateNode{n: n, schema: schema, agg: agg}, nil
+}
+
+// makeUserDefinedAggregate validates the definition of the aggregate created
+// by n and returns its descriptor.
+func (p *planner) makeUserDefinedAggregate(
+	ctx context.Context, n *tree.CreateAggregate,
+) (descpb.UserDefinedAggregate, error) {
+	agg := descpb.UserDefinedAggregate{Name: n.Name.Object()}
+	if len(n.ArgTypes) != 1 {
+		return agg, unimplemented.New("create aggregate arguments",
+			"user-defined aggregates must have exactly one argument")
+	}
+	argType, err := p.resolveUserDefinedAggregateType(ctx, n.ArgTypes[0])
+	if err != nil {
+		return agg, err
+	}
+	agg.ArgType = argType
+
+	var stateFunc, finalFunc *tree.UnresolvedName
+	seen := make(map[tree.AggregateOptionKind]bool, len(n.Options))
+	for i := range n.Options {
+		o := &n.Options[i]
+		if seen[o.Kind] {
+			return agg, pgerror.New(pgcode.Syntax, "conflicting or redundant options")
+		}
+		seen[o.Kind] = true
+		switch o.Kind {
+		case tree.AggregateOptionStateFunc:
+			stateFunc = o.Func
+		case tree.AggregateOptionStateType:
+			if agg.StateType, err = p.resolveUserDefinedAggregateType(ctx, o.Type); err != nil {
+				return agg, err
+			}
+		case tree.AggregateOptionFinalFunc:
+			finalFunc = o.Func
+		case tree.AggregateOptionInitCond:
+			initCond := o.Value
+			agg.InitCond = &initCond
+		}
+	}
+	if stateFunc == nil {
+		return agg, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate sfunc must be specified")
+	}
+	if agg.StateType == nil {
+		return agg, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate stype must be specified")
+	}
+
+	stateTypes := []*types.T{agg.StateType, agg.ArgType}
+	stateProps, stateOverload, err := p.lookupAggregateSupportFunction(stateFunc, stateTypes)
+	if err != nil {
+		return agg, err
+	}
+	agg.StateFunc = stateProps.name
+	if typ := stateOverload.InferReturnTypeFromInputArgTypes(stateTypes); !typ.Equivalent(agg.StateType) {
+		return agg, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"return type of transition function %s is not %s", stateFunc, agg.StateType.SQLString())
+	}
+	if stateProps.strict && agg.InitCond == nil && !agg.ArgType.Equivalent(agg.StateType) {
+		return agg, pgerror.New(pgcode.InvalidFunctionDefinition,
+			"must not omit initial value when transition function is strict and "+
+				"transition type is not compatible with input type")
+	}
+
+	agg.ResultType = agg.StateType
+	if finalFunc != nil {
+		finalTypes := []*types.T{agg.StateType}
+		finalProps, finalOverload, err := p.lookupAggregateSupportFunction(finalFunc, finalTypes)
+		if err != nil {
+			return agg, err
+		}
+		agg.FinalFunc = finalProps.name
+		agg.ResultType = finalOverload.InferReturnTypeFromInputArgTypes(finalTypes)
+	}
+
+	if agg.InitCond != nil {
+		if _, err := eval.PerformCast(
+			p.EvalContext(), tree.NewDString(*agg.InitCond), agg.StateType,
+		); err != nil {
+			return agg, err
+		}
+	}
+	return agg, nil
+}
+
+// resolveUserDefinedAggregateType resolves a type used by a user-defined
+// aggregate. User-defined types are not supported.
+func (p *planner) resolveUserDefinedAggregateType(
+	ctx context.Context, ref tree.ResolvableTypeReference,
+) (*types.T, error) {
+	typ, err := tree.ResolveType(ctx, ref, p.semaCtx.GetTypeResolver())
+	if err != nil {
+		return nil, err
+	}
+	if typ.UserDefined() {
+		return nil, unimplemented.New("create aggregate user-defined type",
+			"user-defined types cannot be used in user-defined aggregates")
+	}
+	return typ, nil
+}
+
+// aggregateSupportFunctionProps describes a function used as the state or the
+// final function of a user-defined aggregate.
+type aggregateSupportFunctionProps struct {
+	// name is the name of the built-in function.
+	name string
+	// strict is true if the function is not called with NULL arguments.
+	strict bool
+}
+
+// lookupAggregateSupportFunction resolves fn to the built-in function with
+// the given argument types.
+func (p *planner) lookupAggregateSupportFunction(
+	fn *tree.UnresolvedName, argTypes []*types.T,
+) (aggregateSupportFunctionProps, *tree.Overload, error) {
+	def, err := fn.ResolveFunction(p.SessionData().SearchPath)
+	if err != nil {
+		return aggregateSupportFunctionProps{}, nil, err
+	}
+	props, overload, err := builtins.LookupAggregateSupportFunction(def.Name, argTypes)
+	if err != nil {
+		return aggregateSupportFunctionProps{}, nil, err
+	}
+	return aggregateSupportFunctionProps{name: def.Name, strict: !props.NullableArgs}, overload, nil
+}
+
+func (n *createAggregateNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("aggregate"))
+
+	if idx := findUserDefinedAggregate(n.schema, n.agg.Name, n.agg.ArgType); idx != -1 {
+		return pgerror.Newf(pgcode.DuplicateFunction,
+			"aggregate %s(%s) already exists", n.agg.Name, n.agg.ArgType.SQLString())
+	}
+	n.schema.Aggregates = append(n.schema.Aggregates, n.agg)
+	return params.p.writeSchemaDescChange(
+		params.ctx, n.schema, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *createAggregateNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createAggregateNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createAggregateNode) Close(context.Context)        {}
+
+// checkUserDefinedAggregatesSupported returns an error if the user-defined
+// aggregates cannot be changed yet.
+func checkUserDefinedAggregatesSupported(ctx context.Context, p *planner, opName string) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.UserDefinedAggregates) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is not supported until the cluster version is upgraded", opName)
+	}
+	return checkSchemaChangeEnabled(ctx, p.ExecCfg(), opName)
+}
+
+// findUserDefinedAggregates returns the aggregates with the given name defined
+// in the schema.
+func findUserDefinedAggregates(
+	sc catalog.SchemaDescriptor, name string,
+) []*descpb.UserDefinedAggregate {
+	var res []*descpb.UserDefinedAggregate
+	aggs := sc.GetAggregates()
+	for i := range aggs {
+		if aggs[i].Name == name {
+			res = append(res, &aggs[i])
+		}
+	}
+	return res
+}
+
+// findUserDefinedAggregate returns the index of the aggregate with the given
+// name and argument type in the Aggregates of the schema, or -1 if there is no
+// such aggregate.
+func findUserDefinedAggregate(sc *schemadesc.Mutable, name string, argType *types.T) int {
+	for i := range sc.Aggregates {
+		if sc.Aggregates[i].Name == name && sc.Aggregates[i].ArgType.Equivalent(argType) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,225 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/decodeusername"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type createPolicyNode struct {
+	n         *tree.CreatePolicy
+	tn        tree.TableName
+	tableDesc *tabledesc.Mutable
+	roles     []username.SQLUsername
+}
+
+// CreatePolicy creates a row-level security policy on a table.
+// Privileges: ownership of the table.
+func (p *planner) CreatePolicy(ctx context.Context, n *tree.CreatePolicy) (planNode, error) {
+	if err := checkRowLevelSecuritySupported(ctx, p, "CREATE POLICY"); err != nil {
+		return nil, err
+	}
+	if n.WithCheck != nil || n.Command == tree.PolicyCommandInsert {
+		return nil, unimplemented.New("create policy with check",
+			"WITH CHECK expressions of row-level security policies are not supported")
+	}
+	if n.Using == nil {
+		return nil, pgerror.New(pgcode.Syntax, "CREATE POLICY requires a USING expression")
+	}
+
+	tn := n.Table.ToTableName()
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkTableOwnerOrAdmin(ctx, tableDesc); err != nil {
+		return nil, err
+	}
+
+	var roles []username.SQLUsername
+	if len(n.Roles) == 0 {
+		roles = []username.SQLUsername{username.PublicRoleName()}
+	} else {
+		roles, err = decodeusername.FromRoleSpecList(
+			p.SessionData(), username.PurposeValidation, n.Roles,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range roles {
+			if role.IsPublicRole() {
+				continue
+			}
+			exists, err := RoleExists(ctx, p.ExecCfg(), p.Txn(), role)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				return nil, pgerror.Newf(pgcode.UndefinedObject, "role/user %q does not exist", role)
+			}
+		}
+	}
+
+	return &createPolicyNode{n: n, tn: tn, tableDesc: tableDesc, roles: roles}, nil
+}
+
+func (n *createPolicyNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("policy"))
+
+	tableDesc := n.tableDesc
+	for i := range tableDesc.Policies {
+		if tableDesc.Policies[i].Name == string(n.n.Name) {
+			return pgerror.Newf(pgcode.DuplicateObject,
+				"policy %q for table %q already exists", n.n.Name, tableDesc.GetName())
+		}
+	}
+
+	expr, cols, err := schemaexpr.ValidatePolicyExpr(
+		params.ctx, tableDesc, n.n.Using, &n.tn, params.p.SemaCtx(),
+	)
+	if err != nil {
+		return err
+	}
+	policy := descpb.PolicyDescriptor{
+		Name:      string(n.n.Name),
+		Command:   policyCommandToProto(n.n.Command),
+		UsingExpr: expr,
+		ColumnIDs: cols.Ordered(),
+	}
+	for _, role := range n.roles {
+		policy.Roles = append(policy.Roles, role.Normalized())
+	}
+	tableDesc.Policies = append(tableDesc.Policies, policy)
+
+	if err := params.p.writeSchemaChange(
+		params.ctx, tableDesc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+	return params.p.logEvent(params.ctx,
+		tableDesc.ID,
+		&eventpb.AlterTable{
+			TableName: n.tn.FQString(),
+		})
+}
+
+func (n *createPolicyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createPolicyNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createPolicyNode) Close(context.Context)        {}
+
+// checkRowLevelSecuritySupported returns an error if the row-level security
+// policies cannot be changed yet.
+func checkRowLevelSecuritySupported(ctx context.Context, p *planner, opName string) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.RowLevelSecurity) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is not supported until the cluster version is upgraded", opName)
+	}
+	return checkSchemaChangeEnabled(ctx, p.ExecCfg(), opName)
+}
+
+// checkTableOwnerOrAdmin returns an error if the current user is neither the
+// owner of the table nor an admin. Only they can change the row-level security
+// policies and the triggers of the table.
+func (p *planner) checkTableOwnerOrAdmin(ctx context.Context, desc *tabledesc.Mutable) error {
+	ok, err := p.isTableOwnerOrAdmin(ctx, desc)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of table %s", tree.Name(desc.GetName()))
+	}
+	return nil
+}
+
+// isTableOwnerOrAdmin returns true if the current user is an admin or a
+// member of the role that owns the given table.
+func (p *planner) isTableOwnerOrAdmin(ctx context.Context, desc catalog.Descriptor) (bool, error) {
+	hasAdmin, err := p.HasAdminRole(ctx)
+	if err != nil || hasAdmin {
+		return hasAdmin, err
+	}
+	return p.HasOwnership(ctx, desc)
+}
+
+// checkRowLevelSecurityExemption returns an error if row-level security is
+// enabled on the given table and the current user is neither an admin nor a
+// member of the role that owns the table. It guards the operations that
+// expose the values of the rows of the table without applying its policies.
+func (p *planner) checkRowLevelSecurityExemption(
+	ctx context.Context, desc catalog.TableDescriptor, opName string,
+) error {
+	if !desc.IsRowLevelSecurityEnabled() {
+		return nil
+	}
+	ok, err := p.isTableOwnerOrAdmin(ctx, desc)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is not supported on table %s with row-level security enabled",
+			opName, tree.Name(desc.GetName()))
+	}
+	return nil
+}
+
+func policyCommandToProto(c tree.PolicyCommand) descpb.PolicyDescriptor_Command {
+	switch c {
+	case tree.PolicyCommandAll:
+		return descpb.PolicyDescriptor_ALL
+	case tree.PolicyCommandSelect:
+		return descpb.PolicyDescriptor_SELECT
+	case tree.PolicyCommandInsert:
+		return descpb.PolicyDescriptor_INSERT
+	case tree.PolicyCommandUpdate:
+		return descpb.PolicyDescriptor_UPDATE
+	case tree.PolicyCommandDelete:
+		return descpb.PolicyDescriptor_DELETE
+	default:
+		panic(errors.AssertionFailedf("unknown policy command %d", c))
+	}
+}
+
+func policyCommandFromProto(c descpb.PolicyDescriptor_Command) tree.PolicyCommand {
+	switch c {
+	case descpb.PolicyDescriptor_ALL:
+		return tree.PolicyCommandAll
+	case descpb.PolicyDescriptor_SELECT:
+		return tree.PolicyCommandSelect
+	case descpb.PolicyDescriptor_INSERT:
+		return tree.PolicyCommandInsert
+	case descpb.PolicyDescriptor_UPDATE:
+		return tree.PolicyCommandUpdate
+	case descpb.PolicyDescriptor_DELETE:
+		return tree.PolicyCommandDelete
+	default:
+		panic(errors.AssertionFailedf("unknown policy command %d", c))
+	}
+}
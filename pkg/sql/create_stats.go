@@ -65,6 +65,12 @@ var featureStatsEnabled = settings.RegisterBoolSetting(
 
 const nonIndexColHistogramBuckets = 2
 
+// nonIndexInvertedColHistogramBuckets is the number of buckets of the
+// histograms of the inverted index keys of JSON and array columns without an
+// inverted index. They are larger than those of other non-index columns since
+// they are needed to estimate the selectivity of containment filters.
+const nonIndexInvertedColHistogramBuckets = 20
+
 // StubTableStats generates "stub" statistics for a table which are missing
 // histograms and have 0 for all values.
 func StubTableStats(
@@ -561,6 +567,18 @@ func createStatsDefaultColumns(
 			HasHistogram:        !colinfo.ColumnTypeIsInvertedIndexable(col.GetType()),
 			HistogramMaxBuckets: maxHistBuckets,
 		})
+		// Make histograms of the inverted index keys of JSON and array columns
+		// even if they are not indexed, so that the optimizer can estimate the
+		// selectivity of containment filters on them. Geospatial columns are
+		// excluded since their keys depend on the configuration of an index.
+		if typ := col.GetType(); typ.Family() == types.JsonFamily || typ.Family() == types.ArrayFamily {
+			colStats = append(colStats, jobspb.CreateStatsDetails_ColStat{
+				ColumnIDs:           colList,
+				HasHistogram:        true,
+				Inverted:            true,
+				HistogramMaxBuckets: nonIndexInvertedColHistogramBuckets,
+			})
+		}
 		nonIdxCols++
 	}
 
@@ -1248,6 +1248,17 @@ func NewTableDescOptionBypassLocalityOnNonMultiRegionDatabaseCheck() NewTableDes
 	}
 }
 
+// hasIndexOnColumn returns true if defs contain the definition of an index
+// whose first column is the given column.
+func hasIndexOnColumn(defs tree.TableDefs, col tree.Name) bool {
+	for _, def := range defs {
+		if d, ok := def.(*tree.IndexTableDef); ok && len(d.Columns) > 0 && d.Columns[0].Column == col {
+			return true
+		}
+	}
+	return false
+}
+
 // NewTableDesc creates a table descriptor from a CreateTable statement.
 //
 // txn and vt can be nil if the table to be created does not contain references
@@ -1602,6 +1613,16 @@ func NewTableDesc(
 				implicitColumnDefIdxs = append(implicitColumnDefIdxs, implicitColumnDefIdx{idx: idx, def: d})
 			}
 
+			// Add the index that is automatically created on a computed column
+			// over a JSONB path to the list of TableDefs, so that it is created
+			// like the explicitly defined indexes below.
+			if idxDef := schemaexpr.MakeJSONComputedColumnIndexDef(
+				d, defType, sessionData,
+			); idxDef != nil && !hasIndexOnColumn(n.Defs, d.Name) {
+				n.Defs = append(n.Defs, idxDef)
+				cdd = append(cdd, nil)
+			}
+
 			if d.HasColumnFamily() {
 				// Pass true for `create` and `ifNotExists` because when we're creating
 				// a table, we always want to create the specified family if it doesn't
@@ -2372,6 +2393,15 @@ func newTableDesc(
 		}
 		ttl.ScheduleID = j.ScheduleID()
 	}
+
+	// The revision history of the tables with system versioning enabled is
+	// protected from the start.
+	if err := handleSystemVersioningStorageParamChange(params, ret, nil /* before */); err != nil {
+		return nil, err
+	}
+	if err := handleSplitPolicyStorageParamChange(params, ret, nil /* before */); err != nil {
+		return nil, err
+	}
 	return ret, nil
 }
 
@@ -0,0 +1,157 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type createTriggerNode struct {
+	n         *tree.CreateTrigger
+	tn        tree.TableName
+	tableDesc *tabledesc.Mutable
+	events    []descpb.TriggerDescriptor_Event
+}
+
+// CreateTrigger creates a row-level trigger on a table.
+// Privileges: ownership of the table.
+func (p *planner) CreateTrigger(ctx context.Context, n *tree.CreateTrigger) (planNode, error) {
+	if err := checkRowLevelTriggersSupported(ctx, p, "CREATE TRIGGER"); err != nil {
+		return nil, err
+	}
+	switch n.ActionTime {
+	case tree.TriggerActionTimeBefore:
+		return nil, unimplemented.New("create trigger before", "BEFORE triggers are not supported")
+	case tree.TriggerActionTimeInsteadOf:
+		return nil, unimplemented.New("create trigger instead of", "INSTEAD OF triggers are not supported")
+	}
+	if !n.ForEachRow {
+		return nil, unimplemented.New("create trigger for each statement",
+			"statement-level triggers are not supported")
+	}
+	var events []descpb.TriggerDescriptor_Event
+	for _, e := range n.Events {
+		if e == tree.TriggerEventTruncate {
+			return nil, unimplemented.New("create trigger truncate", "TRUNCATE triggers are not supported")
+		}
+		ev := triggerEventToProto(e)
+		isDuplicate := false
+		for _, other := range events {
+			isDuplicate = isDuplicate || other == ev
+		}
+		if !isDuplicate {
+			events = append(events, ev)
+		}
+	}
+
+	tn := n.Table.ToTableName()
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkTableOwnerOrAdmin(ctx, tableDesc); err != nil {
+		return nil, err
+	}
+
+	return &createTriggerNode{n: n, tn: tn, tableDesc: tableDesc, events: events}, nil
+}
+
+func (n *createTriggerNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("trigger"))
+
+	tableDesc := n.tableDesc
+	for i := range tableDesc.Triggers {
+		if tableDesc.Triggers[i].Name == string(n.n.Name) {
+			return pgerror.Newf(pgcode.DuplicateObject,
+				"trigger %q for table %q already exists", n.n.Name, tableDesc.GetName())
+		}
+	}
+
+	whenExpr, body, cols, err := schemaexpr.ValidateTrigger(
+		params.ctx, tableDesc, n.events, n.n.When, n.n.Body, params.p.SemaCtx(),
+	)
+	if err != nil {
+		return err
+	}
+	tableDesc.Triggers = append(tableDesc.Triggers, descpb.TriggerDescriptor{
+		Name:      string(n.n.Name),
+		Events:    n.events,
+		WhenExpr:  whenExpr,
+		Body:      body,
+		ColumnIDs: cols.Ordered(),
+	})
+
+	if err := params.p.writeSchemaChange(
+		params.ctx, tableDesc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+	return params.p.logEvent(params.ctx,
+		tableDesc.ID,
+		&eventpb.AlterTable{
+			TableName: n.tn.FQString(),
+		})
+}
+
+func (n *createTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createTriggerNode) Close(context.Context)        {}
+
+// checkRowLevelTriggersSupported returns an error if the row-level triggers
+// cannot be changed yet.
+func checkRowLevelTriggersSupported(ctx context.Context, p *planner, opName string) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.RowLevelTriggers) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is not supported until the cluster version is upgraded", opName)
+	}
+	return checkSchemaChangeEnabled(ctx, p.ExecCfg(), opName)
+}
+
+func triggerEventToProto(e tree.TriggerEvent) descpb.TriggerDescriptor_Event {
+	switch e {
+	case tree.TriggerEventInsert:
+		return descpb.TriggerDescriptor_INSERT
+	case tree.TriggerEventUpdate:
+		return descpb.TriggerDescriptor_UPDATE
+	case tree.TriggerEventDelete:
+		return descpb.TriggerDescriptor_DELETE
+	default:
+		panic(errors.AssertionFailedf("unsupported trigger event %s", e))
+	}
+}
+
+func triggerEventFromProto(e descpb.TriggerDescriptor_Event) tree.TriggerEvent {
+	switch e {
+	case descpb.TriggerDescriptor_INSERT:
+		return tree.TriggerEventInsert
+	case descpb.TriggerDescriptor_UPDATE:
+		return tree.TriggerEventUpdate
+	case descpb.TriggerDescriptor_DELETE:
+		return tree.TriggerEventDelete
+	default:
+		panic(errors.AssertionFailedf("unknown trigger event %d", e))
+	}
+}
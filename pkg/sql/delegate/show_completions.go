@@ -12,6 +12,7 @@ package delegate
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -28,7 +29,10 @@ func (d *delegator) delegateShowCompletions(n *tree.ShowCompletions) (tree.State
 		return nil, err
 	}
 
-	completions, err := RunShowCompletions(n.Statement.RawString(), int(offset))
+	// The delegator has no way to list the schema objects, so only keywords
+	// are completed here; the connExecutor handles SHOW COMPLETIONS itself
+	// and provides a CompletionsSource.
+	completions, err := RunShowCompletions(d.ctx, n.Statement.RawString(), int(offset), nil /* src */)
 	if err != nil {
 		return nil, err
 	}
@@ -53,20 +57,50 @@ func (d *delegator) delegateShowCompletions(n *tree.ShowCompletions) (tree.State
 	return parse(query.String())
 }
 
-// RunShowCompletions returns a list of completion keywords for the given
-// statement and offset.
-func RunShowCompletions(stmt string, offset int) ([]string, error) {
-	if offset <= 0 || offset > len(stmt) {
-		return nil, nil
-	}
+// CompletionsSource provides the names, other than the keywords, that
+// SHOW COMPLETIONS can complete.
+type CompletionsSource interface {
+	// FunctionNames returns the sorted names of the builtin functions.
+	FunctionNames() []string
+	// TableNames returns the sorted names of the tables of the current
+	// database that are visible through the search path.
+	TableNames(ctx context.Context) ([]string, error)
+	// ColumnNames returns the sorted names of the columns of the given
+	// tables, which are resolved like TableNames.
+	ColumnNames(ctx context.Context, tables []string) ([]string, error)
+}
+
+// tableKeywords are the keywords that are followed by a table name.
+var tableKeywords = map[string]bool{
+	"from": true, "join": true, "into": true, "update": true, "table": true, "truncate": true,
+}
 
-	// For simplicity, if we're on a whitespace, return no completions.
-	// Currently, parser.TokensIgnoreErrors does not consider whitespaces
-	// after the last token.
-	// Ie "SELECT ", will only return one token being "SELECT".
-	// If we're at the whitespace, we do not want to return completion
-	// recommendations for "SELECT".
-	if unicode.IsSpace([]rune(stmt)[offset-1]) {
+// exprStartTokens are the tokens that can be followed by a scalar
+// expression, and so by a column or a function name.
+var exprStartTokens = map[string]bool{
+	"select": true, "where": true, "and": true, "or": true, "not": true, "on": true,
+	"having": true, "by": true, "set": true, "when": true, "then": true, "else": true,
+	"returning": true, "distinct": true, ",": true, "(": true, "=": true, "<": true,
+	">": true, "<=": true, ">=": true, "!=": true, "<>": true, "+": true, "-": true,
+	"/": true, "%": true, "||": true,
+}
+
+// dmlKeywords are the first keywords of the statements whose expressions
+// are completed with column and function names.
+var dmlKeywords = map[string]bool{
+	"select": true, "insert": true, "upsert": true, "update": true, "delete": true, "with": true,
+}
+
+// RunShowCompletions returns a list of completions for the given statement
+// and offset. The completions depend on the position of the offset in the
+// statement: a table name is completed after FROM, a column name after the
+// name of its table followed by a dot, and a column or a function name in
+// the expressions of DML statements, in addition to the keywords. If src
+// is nil, only keywords are completed.
+func RunShowCompletions(
+	ctx context.Context, stmt string, offset int, src CompletionsSource,
+) ([]string, error) {
+	if offset <= 0 || offset > len(stmt) {
 		return nil, nil
 	}
 
@@ -74,25 +108,169 @@ func RunShowCompletions(stmt string, offset int) ([]string, error) {
 	if len(sqlTokens) == 0 {
 		return nil, nil
 	}
+	allSQLTokens := parser.TokensIgnoreErrors(stmt)
 
 	sqlTokenStrings := make([]string, len(sqlTokens))
 	for i, sqlToken := range sqlTokens {
 		sqlTokenStrings[i] = sqlToken.Str
 	}
 
+	// If we're on a whitespace or right after a punctuation, there is no
+	// word to complete, and only the names that make sense at this position
+	// are returned, if any. Note that parser.TokensIgnoreErrors does not
+	// consider whitespaces after the last token. Ie "SELECT ", will only
+	// return one token being "SELECT".
+	if unicode.IsSpace([]rune(stmt)[offset-1]) || !isWord(sqlTokenStrings[len(sqlTokenStrings)-1]) {
+		if src == nil {
+			return nil, nil
+		}
+		return completeNames(ctx, src, "" /* prefix */, sqlTokenStrings, allSQLTokens)
+	}
+
 	lastWordTruncated := sqlTokenStrings[len(sqlTokenStrings)-1]
 
 	// If the offset is in the middle of a word, we return the full word.
 	// For example if the stmt is SELECT with offset 2, even though SEARCH would
 	// come first for "SE", we want to return "SELECT".
 	// Similarly, if we have SEL with offset 2, we want to return "SEL".
-	allSQLTokens := parser.TokensIgnoreErrors(stmt)
 	lastWordFull := allSQLTokens[len(sqlTokenStrings)-1]
 	if lastWordFull.Str != lastWordTruncated {
 		return []string{strings.ToUpper(lastWordFull.Str)}, nil
 	}
 
-	return getCompletionsForWord(lastWordTruncated, lexbase.KeywordNames), nil
+	keywords := getCompletionsForWord(lastWordTruncated, lexbase.KeywordNames)
+	if src == nil || len(sqlTokenStrings) == 1 {
+		return keywords, nil
+	}
+	prevTokens := sqlTokenStrings[:len(sqlTokenStrings)-1]
+	names, err := completeNames(ctx, src, lastWordTruncated, prevTokens, allSQLTokens)
+	if err != nil {
+		return nil, err
+	}
+	// A qualified name can't be a keyword.
+	if prevTokens[len(prevTokens)-1] == "." {
+		return names, nil
+	}
+	return append(names, keywords...), nil
+}
+
+// completeNames returns the table, column and function names starting with
+// prefix that can follow the given tokens in the statement.
+func completeNames(
+	ctx context.Context,
+	src CompletionsSource,
+	prefix string,
+	prevTokens []string,
+	allTokens []parser.TokenString,
+) ([]string, error) {
+	if len(prevTokens) == 0 {
+		return nil, nil
+	}
+	prev := strings.ToLower(prevTokens[len(prevTokens)-1])
+	switch {
+	case prev == "." && len(prevTokens) >= 2 && isWord(prevTokens[len(prevTokens)-2]):
+		cols, err := src.ColumnNames(ctx, []string{prevTokens[len(prevTokens)-2]})
+		if err != nil {
+			return nil, err
+		}
+		return getNameCompletionsForWord(prefix, cols), nil
+
+	case tableKeywords[prev] || (prev == "," && inFromClause(prevTokens)):
+		tables, err := src.TableNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return getNameCompletionsForWord(prefix, tables), nil
+
+	case exprStartTokens[prev] && dmlKeywords[strings.ToLower(prevTokens[0])]:
+		var names []string
+		if tables := referencedTables(allTokens); len(tables) > 0 {
+			cols, err := src.ColumnNames(ctx, tables)
+			if err != nil {
+				return nil, err
+			}
+			names = getNameCompletionsForWord(prefix, cols)
+		}
+		// There are too many functions to list them all when there is no
+		// prefix.
+		if prefix != "" {
+			names = append(names, getNameCompletionsForWord(prefix, src.FunctionNames())...)
+		}
+		return names, nil
+	}
+	return nil, nil
+}
+
+// isWord returns whether the token is a word, ie a keyword, an identifier
+// or a string, rather than a punctuation or a number.
+func isWord(tok string) bool {
+	for _, r := range tok {
+		return unicode.IsLetter(r) || r == '_'
+	}
+	return false
+}
+
+// clauseKeywords are the keywords that start the clauses of a statement
+// other than FROM.
+var clauseKeywords = map[string]bool{
+	"select": true, "where": true, "group": true, "having": true, "window": true,
+	"order": true, "limit": true, "offset": true, "on": true, "using": true, "set": true,
+	"values": true, "returning": true, "union": true, "intersect": true, "except": true,
+}
+
+// inFromClause returns whether the last of the tokens is in a FROM clause.
+func inFromClause(tokens []string) bool {
+	for i := len(tokens) - 1; i >= 0; i-- {
+		tok := strings.ToLower(tokens[i])
+		if tok == "from" || tok == "join" {
+			return true
+		}
+		if clauseKeywords[tok] || tok == "(" || tok == ")" {
+			return false
+		}
+	}
+	return false
+}
+
+// referencedTables returns the names of the tables that follow a table
+// keyword in the statement, or that are listed in its FROM clauses.
+func referencedTables(tokens []parser.TokenString) []string {
+	var tables []string
+	seen := map[string]bool{}
+	for i := 0; i < len(tokens); i++ {
+		if !tableKeywords[strings.ToLower(tokens[i].Str)] {
+			continue
+		}
+		for i+1 < len(tokens) && isWord(tokens[i+1].Str) {
+			// Skip the qualification of the table name.
+			i++
+			for i+2 < len(tokens) && tokens[i+1].Str == "." && isWord(tokens[i+2].Str) {
+				i += 2
+			}
+			if name := tokens[i].Str; !seen[name] {
+				seen[name] = true
+				tables = append(tables, name)
+			}
+			// Skip the alias of the table, and continue with the next table of
+			// the list, if any.
+			if i+1 < len(tokens) && strings.ToLower(tokens[i+1].Str) == "as" {
+				i++
+			}
+			if i+1 < len(tokens) && isWord(tokens[i+1].Str) && !isKeyword(tokens[i+1].Str) {
+				i++
+			}
+			if i+1 >= len(tokens) || tokens[i+1].Str != "," {
+				break
+			}
+			i++
+		}
+	}
+	return tables
+}
+
+func isKeyword(tok string) bool {
+	_, ok := lexbase.KeywordsCategories[strings.ToLower(tok)]
+	return ok
 }
 
 // Binary search for range with matching prefixes
@@ -125,3 +303,15 @@ func getCompletionsForWord(w string, words []string) []string {
 	}
 	return completions
 }
+
+// getNameCompletionsForWord is like getCompletionsForWord for the sorted
+// names of functions or schema objects, which are quoted if needed rather
+// than uppercased.
+func getNameCompletionsForWord(w string, names []string) []string {
+	left, right := binarySearch(w, names)
+	completions := make([]string, right-left)
+	for i, name := range names[left:right] {
+		completions[i] = tree.NameString(name)
+	}
+	return completions
+}
@@ -11,7 +11,9 @@
 package delegate
 
 import (
+	"context"
 	"reflect"
+	"sort"
 	"testing"
 )
 
@@ -93,7 +95,7 @@ func TestCompletions(t *testing.T) {
 		if tc.offset == 0 {
 			offset = len(tc.stmt)
 		}
-		completions, err := RunShowCompletions(tc.stmt, offset)
+		completions, err := RunShowCompletions(context.Background(), tc.stmt, offset, nil /* src */)
 		if err != nil {
 			t.Error(err)
 		}
@@ -103,3 +105,105 @@ func TestCompletions(t *testing.T) {
 		}
 	}
 }
+
+type testCompletionsSource map[string][]string
+
+func (s testCompletionsSource) FunctionNames() []string {
+	return []string{"now", "nullif", "upper"}
+}
+
+func (s testCompletionsSource) TableNames(context.Context) ([]string, error) {
+	var tables []string
+	for t := range s {
+		tables = append(tables, t)
+	}
+	sort.Strings(tables)
+	return tables, nil
+}
+
+func (s testCompletionsSource) ColumnNames(_ context.Context, tables []string) ([]string, error) {
+	var cols []string
+	for _, t := range tables {
+		cols = append(cols, s[t]...)
+	}
+	sort.Strings(cols)
+	return cols, nil
+}
+
+func TestCompletionsWithSource(t *testing.T) {
+	src := testCompletionsSource{
+		"users":  {"id", "name", "nickname"},
+		"orders": {"amount", "order id", "user_id"},
+	}
+	tests := []struct {
+		stmt                string
+		offset              int
+		expectedCompletions []string
+	}{
+		{
+			stmt:                "sel",
+			expectedCompletions: []string{"SELECT"},
+		},
+		{
+			stmt:                "select * from ",
+			expectedCompletions: []string{"orders", "users"},
+		},
+		{
+			stmt:                "select * from us",
+			expectedCompletions: []string{"users", "USE", "USER", "USERS", "USING"},
+		},
+		{
+			stmt:                "select * from users, or",
+			expectedCompletions: []string{"orders", "OR", "ORDER", "ORDINALITY"},
+		},
+		{
+			stmt:                "select ni from users",
+			offset:              9,
+			expectedCompletions: []string{"nickname"},
+		},
+		{
+			stmt:                "select nu from users",
+			offset:              9,
+			expectedCompletions: []string{"nullif", "NULL", "NULLIF", "NULLS", "NUMERIC"},
+		},
+		{
+			stmt:                "select id,  from orders o, users",
+			offset:              11,
+			expectedCompletions: []string{"amount", "id", "name", "nickname", `"order id"`, "user_id"},
+		},
+		{
+			stmt:                "select users.n",
+			expectedCompletions: []string{"name", "nickname"},
+		},
+		{
+			stmt:                "select orders.",
+			expectedCompletions: []string{"amount", `"order id"`, "user_id"},
+		},
+		{
+			stmt:                "update users set ni",
+			expectedCompletions: []string{"nickname"},
+		},
+		{
+			stmt:                "show na",
+			expectedCompletions: []string{"NAMES", "NAN", "NATURAL"},
+		},
+		{
+			stmt:                "select * ",
+			expectedCompletions: []string{},
+		},
+	}
+	for _, tc := range tests {
+		offset := tc.offset
+		if tc.offset == 0 {
+			offset = len(tc.stmt)
+		}
+		completions, err := RunShowCompletions(context.Background(), tc.stmt, offset, src)
+		if err != nil {
+			t.Error(err)
+		}
+		if !(len(completions) == 0 && len(tc.expectedCompletions) == 0) &&
+			!reflect.DeepEqual(completions, tc.expectedCompletions) {
+			t.Errorf("%q: expected %v, got %v", tc.stmt, tc.expectedCompletions, completions)
+		}
+	}
+}
@@ -60,6 +60,10 @@ type deleteRun struct {
 	// of the mutation. Otherwise, the value at the i-th index refers to the
 	// index of the resultRowBuffer where the i-th column is to be returned.
 	rowIdxToRetIdx []int
+
+	// triggers buffers the deleted rows for the row-level triggers of the
+	// table. It is nil if no trigger fires on DELETE.
+	triggers *rowTriggerEvents
 }
 
 var _ mutationPlanNode = &deleteNode{}
@@ -175,6 +179,12 @@ func (d *deleteNode) processSourceRow(params runParams, sourceVals tree.Datums)
 		return err
 	}
 
+	if d.run.triggers != nil {
+		if err := d.run.triggers.addRow(params, sourceVals, nil /* newValues */); err != nil {
+			return err
+		}
+	}
+
 	// If result rows need to be accumulated, do it.
 	if d.run.td.rows != nil {
 		// The new values can include all columns, so the values may contain
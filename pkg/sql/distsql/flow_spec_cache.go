@@ -0,0 +1,101 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package distsql
+
+import (
+	"bytes"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// maxFlowSpecCacheBytes is the maximum total size of the encoded processors
+// kept by the flowSpecCache.
+const maxFlowSpecCacheBytes = 16 << 20 // 16 MiB
+
+// flowSpecCache keeps the encoded processors of the remote flows that were
+// recently set up on this node, keyed by the gateway and the digest of the
+// processors. It allows the gateways to omit the processors from the
+// SetupFlow requests on the repeated executions of the same plan (see
+// SetupFlowRequest.FlowSpecCached).
+type flowSpecCache struct {
+	mu struct {
+		syncutil.Mutex
+		cache *cache.UnorderedCache
+		// bytes is the total size of the cached processors.
+		bytes int64
+	}
+}
+
+type flowSpecCacheKey struct {
+	gateway base.SQLInstanceID
+	digest  string
+}
+
+func newFlowSpecCache() *flowSpecCache {
+	c := &flowSpecCache{}
+	c.mu.cache = cache.NewUnorderedCache(cache.Config{
+		Policy: cache.CacheLRU,
+		ShouldEvict: func(int, interface{}, interface{}) bool {
+			return c.mu.bytes > maxFlowSpecCacheBytes
+		},
+		OnEvicted: func(_, value interface{}) {
+			c.mu.bytes -= int64(len(value.([]byte)))
+		},
+	})
+	return c
+}
+
+// resolve prepares the flow spec of the given request for the setup. If the
+// request omits the processors, they are filled in from the cache, and
+// execinfrapb.ErrFlowSpecNotCached is returned if they aren't found. If the
+// request contains the processors and their digest, the processors are cached.
+//
+// resolve must be called before the flow is set up since the setup can modify
+// the processor specs.
+func (c *flowSpecCache) resolve(req *execinfrapb.SetupFlowRequest) error {
+	if len(req.FlowSpecDigest) == 0 {
+		return nil
+	}
+	key := flowSpecCacheKey{gateway: req.Flow.Gateway, digest: string(req.FlowSpecDigest)}
+	if req.FlowSpecCached {
+		c.mu.Lock()
+		v, ok := c.mu.cache.Get(key)
+		c.mu.Unlock()
+		if !ok {
+			return execinfrapb.ErrFlowSpecNotCached
+		}
+		processors, err := execinfrapb.UnmarshalFlowSpecProcessors(v.([]byte))
+		if err != nil {
+			return err
+		}
+		req.Flow.Processors = processors
+		return nil
+	}
+	data, digest, err := execinfrapb.MarshalFlowSpecProcessors(&req.Flow)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(digest, req.FlowSpecDigest) {
+		// The gateway computed the digest differently (which shouldn't
+		// happen), so we can't cache the processors.
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.mu.cache.Get(key); !ok {
+		c.mu.bytes += int64(len(data))
+		c.mu.cache.Add(key, data)
+	}
+	return nil
+}
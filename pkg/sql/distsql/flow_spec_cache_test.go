@@ -0,0 +1,75 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package distsql
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlowSpecCache(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	spec := execinfrapb.FlowSpec{
+		Gateway: 1,
+		Processors: []execinfrapb.ProcessorSpec{{
+			Core: execinfrapb.ProcessorCoreUnion{Values: &execinfrapb.ValuesCoreSpec{}},
+			Output: []execinfrapb.OutputRouterSpec{{
+				Type:    execinfrapb.OutputRouterSpec_PASS_THROUGH,
+				Streams: []execinfrapb.StreamEndpointSpec{{Type: execinfrapb.StreamEndpointSpec_REMOTE}},
+			}},
+		}},
+	}
+	_, digest, err := execinfrapb.MarshalFlowSpecProcessors(&spec)
+	require.NoError(t, err)
+	cachedReq := func(gateway base.SQLInstanceID) *execinfrapb.SetupFlowRequest {
+		req := &execinfrapb.SetupFlowRequest{FlowSpecDigest: digest, FlowSpecCached: true}
+		req.Flow.Gateway = gateway
+		return req
+	}
+
+	c := newFlowSpecCache()
+	// The requests without the digest are left unchanged.
+	noDigestReq := &execinfrapb.SetupFlowRequest{Flow: spec}
+	require.NoError(t, c.resolve(noDigestReq))
+	require.Equal(t, spec, noDigestReq.Flow)
+
+	// The processors haven't been cached yet.
+	err = c.resolve(cachedReq(1))
+	require.True(t, errors.Is(err, execinfrapb.ErrFlowSpecNotCached))
+
+	// The full spec is cached.
+	require.NoError(t, c.resolve(&execinfrapb.SetupFlowRequest{Flow: spec, FlowSpecDigest: digest}))
+	req := cachedReq(1)
+	require.NoError(t, c.resolve(req))
+	_, resolvedDigest, err := execinfrapb.MarshalFlowSpecProcessors(&req.Flow)
+	require.NoError(t, err)
+	require.Equal(t, digest, resolvedDigest)
+
+	// The processors are cached per gateway.
+	err = c.resolve(cachedReq(2))
+	require.True(t, errors.Is(err, execinfrapb.ErrFlowSpecNotCached))
+
+	// The processors are not cached if the digest doesn't match.
+	c = newFlowSpecCache()
+	badDigest := append([]byte(nil), digest...)
+	badDigest[0]++
+	require.NoError(t, c.resolve(&execinfrapb.SetupFlowRequest{Flow: spec, FlowSpecDigest: badDigest}))
+	err = c.resolve(cachedReq(1))
+	require.True(t, errors.Is(err, execinfrapb.ErrFlowSpecNotCached))
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
@@ -65,6 +66,8 @@ type ServerImpl struct {
 	flowScheduler *flowinfra.FlowScheduler
 	memMonitor    *mon.BytesMonitor
 	regexpCache   *tree.RegexpCache
+	flowShedder   *execinfra.FlowShedder
+	flowSpecCache *flowSpecCache
 }
 
 var _ execinfrapb.DistSQLServer = &ServerImpl{}
@@ -78,6 +81,7 @@ func NewServer(
 		regexpCache:   tree.NewRegexpCache(512),
 		flowRegistry:  flowinfra.NewFlowRegistry(),
 		flowScheduler: flowScheduler,
+		flowSpecCache: newFlowSpecCache(),
 		memMonitor: mon.NewMonitor(
 			"distsql",
 			mon.MemoryResource,
@@ -89,6 +93,8 @@ func NewServer(
 		),
 	}
 	ds.memMonitor.Start(ctx, cfg.ParentMemoryMonitor, mon.BoundAccount{})
+	ds.ServerConfig.FlowStreamMuxer = execinfra.NewFlowStreamMuxer(cfg.Settings, cfg.Stopper)
+	ds.flowShedder = execinfra.NewFlowShedder(cfg.Settings, cfg.ParentMemoryMonitor, cfg.Metrics.FlowsShed)
 	// We have to initialize the flow scheduler at the same time we're creating
 	// the DistSQLServer because the latter will be registered as a gRPC service
 	// right away, so the RPCs might start coming in pretty much right after the
@@ -128,6 +134,9 @@ func (ds *ServerImpl) Start() {
 	}
 
 	ds.flowScheduler.Start()
+	if err := ds.flowShedder.Start(ds.AnnotateCtx(context.Background()), ds.Stopper); err != nil {
+		panic(err)
+	}
 }
 
 // NumRemoteFlowsInQueue returns the number of remote flows scheduled to run on
@@ -291,7 +300,9 @@ func (ds *ServerImpl) setupFlow(
 		}
 		// The flow will run in a LeafTxn because we do not want each distributed
 		// Txn to heartbeat the transaction.
-		return kv.NewLeafTxn(ctx, ds.DB, roachpb.NodeID(req.Flow.Gateway), tis), nil
+		return kv.NewLeafTxnWithAdmissionControl(
+			ctx, ds.DB, roachpb.NodeID(req.Flow.Gateway), tis, req.LeafTxnAdmissionHeader,
+		), nil
 	}
 
 	var evalCtx *eval.Context
@@ -371,6 +382,7 @@ func (ds *ServerImpl) setupFlow(
 	flowCtx := ds.newFlowContext(
 		ctx, req.Flow.FlowID, evalCtx, req.TraceKV, req.CollectStats, localState, req.Flow.Gateway == ds.NodeID.SQLInstanceID(),
 	)
+	flowCtx.SampleRows = req.SampleRows
 
 	// req always contains the desired vectorize mode, regardless of whether we
 	// have non-nil localState.EvalContext. We don't want to update EvalContext
@@ -402,6 +414,10 @@ func (ds *ServerImpl) setupFlow(
 		flowCtx.AmbientContext.AddLogTag("f", f.GetFlowCtx().ID.Short())
 		ctx = flowCtx.AmbientContext.AnnotateCtx(ctx)
 		telemetry.Inc(sqltelemetry.DistSQLExecCounter)
+		// Distributed flows can be shed when the node is under memory pressure.
+		if sf, ok := f.(sheddableFlow); ok {
+			ctx = ds.flowShedder.Register(ctx, sf.GetAdmissionInfo(), monitor, sf.GetCancelFlowFn())
+		}
 	}
 	if f.IsVectorized() {
 		telemetry.Inc(sqltelemetry.VecExecCounter)
@@ -482,6 +498,13 @@ func (ds *ServerImpl) newFlowContext(
 	return flowCtx
 }
 
+// sheddableFlow is implemented by the flows that can be registered with the
+// execinfra.FlowShedder.
+type sheddableFlow interface {
+	GetAdmissionInfo() admission.WorkInfo
+	GetCancelFlowFn() context.CancelFunc
+}
+
 func newFlow(
 	flowCtx execinfra.FlowCtx,
 	sp *tracing.Span,
@@ -608,10 +631,14 @@ func (ds *ServerImpl) SetupFlow(
 	// Note: the passed context will be canceled when this RPC completes, so we
 	// can't associate it with the flow.
 	ctx = ds.AnnotateCtx(context.Background())
-	ctx, f, _, err := ds.setupFlow(
-		ctx, rpcSpan, ds.memMonitor, req, nil, /* rowSyncFlowConsumer */
-		nil /* batchSyncFlowConsumer */, LocalState{},
-	)
+	var f flowinfra.Flow
+	err := ds.flowSpecCache.resolve(req)
+	if err == nil {
+		ctx, f, _, err = ds.setupFlow(
+			ctx, rpcSpan, ds.memMonitor, req, nil, /* rowSyncFlowConsumer */
+			nil /* batchSyncFlowConsumer */, LocalState{},
+		)
+	}
 	if err == nil {
 		err = ds.flowScheduler.ScheduleFlow(ctx, f)
 	}
@@ -675,6 +702,11 @@ func (ds *ServerImpl) FlowStream(stream execinfrapb.DistSQL_FlowStreamServer) er
 	return err
 }
 
+// FlowStreamMux is part of the execinfrapb.DistSQLServer interface.
+func (ds *ServerImpl) FlowStreamMux(stream execinfrapb.DistSQL_FlowStreamMuxServer) error {
+	return execinfra.ServeFlowStreamMux(stream, ds.FlowStream)
+}
+
 // lazyInternalExecutor is a tree.InternalExecutor that initializes
 // itself only on the first call to QueryRow.
 type lazyInternalExecutor struct {
@@ -137,6 +137,14 @@ type DistSQLPlanner struct {
 	// codec allows the DistSQLPlanner to determine whether it is creating plans
 	// for a system tenant or non-system tenant.
 	codec keys.SQLCodec
+
+	// pinnedPartitions caches the span partitionings of the statements when
+	// plan pinning is enabled.
+	pinnedPartitions *pinnedSpanPartitionings
+
+	// warmFlows tracks the flow specs that have been cached by the remote nodes
+	// when the warm flows are enabled.
+	warmFlows *warmFlowSpecs
 }
 
 // DistributionType is an enum defining when a plan should be distributed.
@@ -205,6 +213,8 @@ func NewDistSQLPlanner(
 		metadataTestTolerance: execinfra.NoExplain,
 		sqlInstanceProvider:   sqlInstanceProvider,
 		codec:                 codec,
+		pinnedPartitions:      newPinnedSpanPartitionings(),
+		warmFlows:             newWarmFlowSpecs(),
 	}
 
 	dsp.parallelLocalScansSem = quotapool.NewIntPool("parallel local scans concurrency",
@@ -747,6 +757,10 @@ type PlanningCtx struct {
 	// If set, statement execution stats should be collected.
 	collectExecStats bool
 
+	// If set, a sample of the rows produced by each processor should be
+	// collected along with the execution stats (for EXPLAIN ANALYZE (SAMPLE)).
+	sampleRows bool
+
 	// parallelizeScansIfLocal indicates whether we might want to create
 	// multiple table readers if the physical plan ends up being fully local.
 	// This value is determined based on whether there are any mutations in the
@@ -1007,19 +1021,30 @@ func (h *distSQLNodeHealth) check(ctx context.Context, sqlInstanceID base.SQLIns
 // PartitionSpans does its best to not assign ranges on nodes that are known to
 // either be unhealthy or running an incompatible version. The ranges owned by
 // such nodes are assigned to the gateway.
+//
+// If plan pinning is enabled, the partitioning is reused by the later
+// executions of the same statement until any of the ranges of the spans
+// changes.
 func (dsp *DistSQLPlanner) PartitionSpans(
 	ctx context.Context, planCtx *PlanningCtx, spans roachpb.Spans,
 ) ([]SpanPartition, error) {
 	if dsp.codec.ForSystemTenant() {
-		return dsp.partitionSpansSystem(ctx, planCtx, spans)
+		if dsp.shouldPinSpanPartitions(planCtx) {
+			return dsp.partitionSpansPinned(ctx, planCtx, spans)
+		}
+		return dsp.partitionSpansSystem(ctx, planCtx, spans, nil /* ranges */)
 	}
 	return dsp.partitionSpansTenant(ctx, planCtx, spans)
 }
 
 // partitionSpansSystem finds node owners for ranges touching the given spans
 // for a system tenant.
+//
+// If ranges is not nil, the ranges that the spans were partitioned over are
+// appended to it, in order to pin the partitioning. It is reset to nil if any
+// of these ranges changed in the range cache during the partitioning.
 func (dsp *DistSQLPlanner) partitionSpansSystem(
-	ctx context.Context, planCtx *PlanningCtx, spans roachpb.Spans,
+	ctx context.Context, planCtx *PlanningCtx, spans roachpb.Spans, ranges *[]pinnedRange,
 ) ([]SpanPartition, error) {
 	if len(spans) == 0 {
 		panic("no spans")
@@ -1089,6 +1114,17 @@ func (dsp *DistSQLPlanner) partitionSpansSystem(
 				)
 			}
 
+			if ranges != nil {
+				r, ok := makePinnedRange(ctx, dsp.distSender.RangeDescriptorCache(), desc.StartKey)
+				if ok && r.rangeID == desc.RangeID && r.generation == desc.Generation {
+					*ranges = append(*ranges, r)
+				} else {
+					// The range is no longer cached as it was resolved, so
+					// the partitioning can't be pinned.
+					*ranges, ranges = nil, nil
+				}
+			}
+
 			// Limit the end key to the end of the span we are resolving.
 			endKey := desc.EndKey
 			if rSpan.EndKey.Less(endKey) {
@@ -1259,6 +1295,10 @@ func initTableReaderSpecTemplate(
 		TableDescriptorModificationTime: n.desc.GetModificationTime(),
 		LockingStrength:                 n.lockingStrength,
 		LockingWaitPolicy:               n.lockingWaitPolicy,
+		MinTimestamp:                    n.changesSince,
+		Sample:                          makeTableSampleSpec(n.sampleMethod, n.sampleProbability),
+		HistoryStartTime:                n.systemTimeStart,
+		HistoryEndTime:                  n.systemTimeEnd,
 	}
 	if err := rowenc.InitIndexFetchSpec(&s.FetchSpec, codec, n.desc, n.index, colIDs); err != nil {
 		return nil, execinfrapb.PostProcessSpec{}, err
@@ -1273,6 +1313,66 @@ func initTableReaderSpecTemplate(
 	return s, post, nil
 }
 
+// makeTableSampleSpec returns the spec of the sampling performed by the
+// TableReaders of a scan, or nil if the scan isn't sampled.
+func makeTableSampleSpec(
+	method tree.TableSampleMethod, probability float64,
+) *execinfrapb.TableSampleSpec {
+	switch method {
+	case tree.TableSampleBernoulli:
+		return &execinfrapb.TableSampleSpec{
+			Method: execinfrapb.TableSampleSpec_BERNOULLI, Probability: probability,
+		}
+	case tree.TableSampleSystem:
+		return &execinfrapb.TableSampleSpec{
+			Method: execinfrapb.TableSampleSpec_SYSTEM, Probability: probability,
+		}
+	default:
+		return nil
+	}
+}
+
+// splitSpansAtRangeBoundaries splits the given spans so that each of them is
+// contained within a single range. It is used for the scans with the SYSTEM
+// sampling, where each span is either read in full or skipped, so that the
+// ranges are sampled independently of one another.
+func (dsp *DistSQLPlanner) splitSpansAtRangeBoundaries(
+	ctx context.Context, planCtx *PlanningCtx, spans roachpb.Spans,
+) (roachpb.Spans, error) {
+	it := planCtx.spanIter
+	result := make(roachpb.Spans, 0, len(spans))
+	for _, span := range spans {
+		if len(span.EndKey) == 0 {
+			// Point lookups are contained within a single range.
+			result = append(result, span)
+			continue
+		}
+		rSpan, err := keys.SpanAddr(span)
+		if err != nil {
+			return nil, err
+		}
+		lastKey := rSpan.Key
+		for it.Seek(ctx, span, kvcoord.Ascending); ; it.Next(ctx) {
+			if !it.Valid() {
+				return nil, it.Error()
+			}
+			endKey := it.Desc().EndKey
+			if rSpan.EndKey.Less(endKey) {
+				endKey = rSpan.EndKey
+			}
+			result = append(result, roachpb.Span{
+				Key:    lastKey.AsRawKey(),
+				EndKey: endKey.AsRawKey(),
+			})
+			if !endKey.Less(rSpan.EndKey) {
+				break
+			}
+			lastKey = endKey
+		}
+	}
+	return result, nil
+}
+
 // convertOrdering maps the columns in props.ordering to the output columns of a
 // processor.
 func (dsp *DistSQLPlanner) convertOrdering(
@@ -1526,6 +1626,16 @@ func (dsp *DistSQLPlanner) planTableReaders(
 		parallelizeLocal bool
 		err              error
 	)
+	sd := planCtx.ExtendedEvalCtx.SessionData()
+	if sd.DisableScanParallelization {
+		info.parallelize = false
+	}
+	if sample := info.spec.Sample; sample != nil && sample.Method == execinfrapb.TableSampleSpec_SYSTEM &&
+		planCtx.spanIter != nil { // This condition can only be false in tests.
+		if info.spans, err = dsp.splitSpansAtRangeBoundaries(ctx, planCtx, info.spans); err != nil {
+			return err
+		}
+	}
 	if planCtx.isLocal {
 		spanPartitions, parallelizeLocal = dsp.maybeParallelizeLocalScans(ctx, planCtx, info)
 	} else if info.post.Limit == 0 {
@@ -1567,7 +1677,10 @@ func (dsp *DistSQLPlanner) planTableReaders(
 
 		tr.Parallelize = info.parallelize
 		if !tr.Parallelize {
-			tr.BatchBytesLimit = dsp.distSQLSrv.TestingKnobs.TableReaderBatchBytesLimit
+			tr.BatchBytesLimit = sd.ScanBatchBytesLimit
+			if knob := dsp.distSQLSrv.TestingKnobs.TableReaderBatchBytesLimit; knob != 0 {
+				tr.BatchBytesLimit = knob
+			}
 		}
 		p.TotalEstimatedScannedRows += info.estimatedRowCount
 
@@ -1691,11 +1804,16 @@ func (dsp *DistSQLPlanner) addAggregators(
 	aggregations := make([]execinfrapb.AggregatorSpec_Aggregation, len(n.funcs))
 	argumentsColumnTypes := make([][]*types.T, len(n.funcs))
 	for i, fholder := range n.funcs {
-		funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
-		if err != nil {
-			return err
+		if fholder.userDefined != nil {
+			aggregations[i].Func = execinfrapb.UserDefined
+			aggregations[i].UserDefined = fholder.userDefined
+		} else {
+			funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
+			if err != nil {
+				return err
+			}
+			aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		}
-		aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		aggregations[i].Distinct = fholder.isDistinct
 		for _, renderIdx := range fholder.argRenderIdxs {
 			aggregations[i].ColIdx = append(aggregations[i].ColIdx, uint32(p.PlanToStreamColMap[renderIdx]))
@@ -2163,8 +2281,13 @@ func (dsp *DistSQLPlanner) planAggregators(
 			argTypes[j] = inputTypes[c]
 		}
 		copy(argTypes[len(agg.ColIdx):], info.argumentsColumnTypes[i])
+		var returnTyp *types.T
 		var err error
-		_, returnTyp, err := execagg.GetAggregateInfo(agg.Func, argTypes...)
+		if agg.Func == execinfrapb.UserDefined {
+			_, returnTyp, err = execagg.GetUserDefinedAggregateInfo(agg.UserDefined, argTypes...)
+		} else {
+			_, returnTyp, err = execagg.GetAggregateInfo(agg.Func, argTypes...)
+		}
 		if err != nil {
 			return err
 		}
@@ -2816,19 +2939,20 @@ func (dsp *DistSQLPlanner) createPlanForJoin(
 	}
 
 	info := joinPlanningInfo{
-		leftPlan:           leftPlan,
-		rightPlan:          rightPlan,
-		joinType:           n.pred.joinType,
-		joinResultTypes:    joinResultTypes,
-		onExpr:             onExpr,
-		post:               post,
-		joinToStreamColMap: joinToStreamColMap,
-		leftEqCols:         leftEqCols,
-		rightEqCols:        rightEqCols,
-		leftEqColsAreKey:   n.pred.leftEqKey,
-		rightEqColsAreKey:  n.pred.rightEqKey,
-		leftMergeOrd:       leftMergeOrd,
-		rightMergeOrd:      rightMergeOrd,
+		leftPlan:               leftPlan,
+		rightPlan:              rightPlan,
+		joinType:               n.pred.joinType,
+		joinResultTypes:        joinResultTypes,
+		onExpr:                 onExpr,
+		post:                   post,
+		joinToStreamColMap:     joinToStreamColMap,
+		leftEqCols:             leftEqCols,
+		rightEqCols:            rightEqCols,
+		leftEqColsAreKey:       n.pred.leftEqKey,
+		rightEqColsAreKey:      n.pred.rightEqKey,
+		rightEstimatedRowCount: n.rightEstimatedRowCount,
+		leftMergeOrd:           leftMergeOrd,
+		rightMergeOrd:          rightMergeOrd,
 		// In the old execFactory we can only have either local or fully
 		// distributed plans, so checking the last stage is sufficient to get
 		// the distribution of the whole plans.
@@ -2892,6 +3016,13 @@ func (dsp *DistSQLPlanner) planJoiners(
 		info.leftMergeOrd, info.rightMergeOrd,
 		leftRouters, rightRouters, info.joinResultTypes,
 	)
+	if len(sqlInstances) > 1 && localityAwareHashRoutingEnabled.Get(&dsp.st.SV) {
+		dsp.maybeRouteJoinByRegion(
+			p, sqlInstances, info.leftEqCols, info.rightEqCols,
+			info.leftPlan.GetResultTypes(), info.rightPlan.GetResultTypes(),
+			leftRouters, rightRouters,
+		)
+	}
 
 	p.PlanToStreamColMap = info.joinToStreamColMap
 
@@ -2901,6 +3032,85 @@ func (dsp *DistSQLPlanner) planJoiners(
 	return p
 }
 
+// localityAwareHashRoutingEnabled determines whether the hash routers of the
+// distributed joins on the region columns co-locate the hash buckets by
+// region.
+var localityAwareHashRoutingEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.distsql.locality_aware_hash_routing.enabled",
+	"if set, the rows of distributed joins with an equality on the region "+
+		"columns are hashed only among the join processors in the region of the row",
+	false,
+)
+
+// maybeRouteJoinByRegion sets up the hash routers of the join stage with the
+// processors on sqlInstances to co-locate the hash buckets by region (see
+// execinfrapb.OutputRouterSpec.RegionColumn) if the join has an equality
+// between the region columns of both sides (as is the case for the joins of
+// REGIONAL BY ROW tables on the primary key). This way the rows stored in a
+// region are joined in that region, which avoids the cross-region shuffles.
+func (dsp *DistSQLPlanner) maybeRouteJoinByRegion(
+	p *PhysicalPlan,
+	sqlInstances []base.SQLInstanceID,
+	leftEqCols, rightEqCols []uint32,
+	leftTypes, rightTypes []*types.T,
+	leftRouters, rightRouters []physicalplan.ProcessorIdx,
+) {
+	eqIdx := -1
+	for i := range leftEqCols {
+		leftType, rightType := leftTypes[leftEqCols[i]], rightTypes[rightEqCols[i]]
+		if isRegionType(leftType) && leftType.Identical(rightType) {
+			eqIdx = i
+			break
+		}
+	}
+	if eqIdx == -1 {
+		return
+	}
+	regionType := leftTypes[leftEqCols[eqIdx]]
+	// Find the region of each of the join processors. The hash bucket i
+	// corresponds to the join processor on sqlInstances[i].
+	streamRegions := make([][]byte, len(sqlInstances))
+	var foundRegion bool
+	for i, sqlInstanceID := range sqlInstances {
+		desc, err := dsp.GetSQLInstanceInfo(sqlInstanceID)
+		if err != nil {
+			return
+		}
+		region, ok := desc.Locality.Find("region")
+		if !ok {
+			continue
+		}
+		for j, rep := range regionType.TypeMeta.EnumData.LogicalRepresentations {
+			if rep == region {
+				streamRegions[i] = regionType.TypeMeta.EnumData.PhysicalRepresentations[j]
+				foundRegion = true
+				break
+			}
+		}
+	}
+	if !foundRegion {
+		return
+	}
+	setupRouters := func(routers []physicalplan.ProcessorIdx, eqCols []uint32) {
+		regionCol := eqCols[eqIdx]
+		for _, resultProc := range routers {
+			output := &p.Processors[resultProc].Spec.Output[0]
+			output.RegionColumn = &regionCol
+			output.StreamRegions = streamRegions
+		}
+	}
+	setupRouters(leftRouters, leftEqCols)
+	setupRouters(rightRouters, rightEqCols)
+}
+
+// isRegionType returns whether typ is the region enum of a multi-region
+// database.
+func isRegionType(typ *types.T) bool {
+	return typ.Family() == types.EnumFamily && typ.TypeMeta.Name != nil &&
+		typ.TypeMeta.Name.Name == tree.RegionEnum && typ.TypeMeta.EnumData != nil
+}
+
 // createPhysPlan creates a PhysicalPlan as well as returns a non-nil cleanup
 // function that must be called after the flow has been cleaned up.
 func (dsp *DistSQLPlanner) createPhysPlan(
@@ -3172,7 +3382,16 @@ func (dsp *DistSQLPlanner) wrapPlan(
 	}
 	wrapper.firstNotWrapped = firstNotWrapped
 
-	localProcIdx := p.AddLocalProcessor(wrapper)
+	var localProcessor execinfra.LocalProcessor = wrapper
+	var isApplyJoin bool
+	if aj, ok := n.(*applyJoinNode); ok && firstNotWrapped != nil && firstNotWrapped == aj.input.plan &&
+		vectorizedApplyJoinEnabled.Get(&dsp.st.SV) {
+		// The left side of the apply join is planned by DistSQL, so the
+		// vectorized engine can execute the join natively.
+		localProcessor = &applyJoinRowSource{planNodeToRowSource: wrapper, node: aj}
+		isApplyJoin = true
+	}
+	localProcIdx := p.AddLocalProcessor(localProcessor)
 	var input []execinfrapb.InputSyncSpec
 	if firstNotWrapped != nil {
 		// We found a DistSQL-plannable subtree - create an input spec for it.
@@ -3190,6 +3409,7 @@ func (dsp *DistSQLPlanner) wrapPlan(
 				RowSourceIdx: uint32(localProcIdx),
 				NumInputs:    nParents,
 				Name:         name,
+				IsApplyJoin:  isApplyJoin,
 			}},
 			Post: execinfrapb.PostProcessSpec{},
 			Output: []execinfrapb.OutputRouterSpec{{
@@ -3337,20 +3557,84 @@ func (dsp *DistSQLPlanner) createDistinctSpec(
 	}
 }
 
+// canFuseSortIntoDistinct returns whether the sort which is the input to the
+// distinct can be omitted by having the distinct processors output the first
+// row of each group according to the ordering of the sort. This is the case
+// for DISTINCT ON (a) ... ORDER BY a, b when the input is already ordered on
+// a, so the sort only orders the rows within the groups.
+func canFuseSortIntoDistinct(n *distinctNode, s *sortNode) bool {
+	prefix := s.alreadyOrderedPrefix
+	if n.nullsAreDistinct || n.errorOnDup != "" || prefix == 0 || prefix == len(s.ordering) {
+		return false
+	}
+	if !n.columnsInOrder.Equals(n.distinctOnColIdxs) || n.distinctOnColIdxs.Len() != prefix {
+		return false
+	}
+	for i, o := range s.ordering[:prefix] {
+		if !n.distinctOnColIdxs.Contains(o.ColIdx) {
+			return false
+		}
+		if i < len(n.reqOrdering) && n.reqOrdering[i] != o {
+			return false
+		}
+	}
+	return true
+}
+
+// orderingHasPrefix returns whether the given prefix is a prefix of o.
+func orderingHasPrefix(o, prefix execinfrapb.Ordering) bool {
+	if len(o.Columns) < len(prefix.Columns) {
+		return false
+	}
+	for i := range prefix.Columns {
+		if o.Columns[i] != prefix.Columns[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (dsp *DistSQLPlanner) createPlanForDistinct(
 	ctx context.Context, planCtx *PlanningCtx, n *distinctNode,
 ) (*PhysicalPlan, error) {
-	plan, err := dsp.createPhysPlanForPlanNode(ctx, planCtx, n.plan)
-	if err != nil {
-		return nil, err
+	var plan *PhysicalPlan
+	var err error
+	var groupOrdering ReqOrdering
+	reqOrdering := n.reqOrdering
+	if s, ok := n.plan.(*sortNode); ok && canFuseSortIntoDistinct(n, s) {
+		plan, err = dsp.createPhysPlanForPlanNode(ctx, planCtx, s.plan)
+		if err != nil {
+			return nil, err
+		}
+		prefix := s.alreadyOrderedPrefix
+		if len(plan.ResultRouters) > 1 && !orderingHasPrefix(
+			plan.MergeOrdering, dsp.convertOrdering(s.ordering[:prefix], plan.PlanToStreamColMap),
+		) {
+			// The streams can't be merged on the distinct columns, so we
+			// have to plan the sort after all.
+			dsp.addSorters(plan, s.ordering, prefix, 0 /* limit */)
+		} else {
+			groupOrdering = s.ordering[prefix:]
+			// The output has a single row per group, so it is ordered on any
+			// columns after the distinct ones.
+			if len(reqOrdering) > prefix {
+				reqOrdering = reqOrdering[:prefix]
+			}
+		}
+	} else {
+		plan, err = dsp.createPhysPlanForPlanNode(ctx, planCtx, n.plan)
+		if err != nil {
+			return nil, err
+		}
 	}
 	spec := dsp.createDistinctSpec(
 		convertFastIntSetToUint32Slice(n.distinctOnColIdxs),
 		convertFastIntSetToUint32Slice(n.columnsInOrder),
 		n.nullsAreDistinct,
 		n.errorOnDup,
-		dsp.convertOrdering(n.reqOrdering, plan.PlanToStreamColMap),
+		dsp.convertOrdering(reqOrdering, plan.PlanToStreamColMap),
 	)
+	spec.GroupOrdering = dsp.convertOrdering(groupOrdering, plan.PlanToStreamColMap)
 	dsp.addDistinctProcessors(plan, spec)
 	return plan, nil
 }
@@ -3477,6 +3761,108 @@ func (dsp *DistSQLPlanner) isOnlyOnGateway(plan *PhysicalPlan) bool {
 	return false
 }
 
+// fusedLocalityOptimizedScansEnabled determines whether the limited UNION ALL
+// of the locality optimized search over a single index can be planned as a
+// single TableReader with remote spans.
+var fusedLocalityOptimizedScansEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.distsql.fused_locality_optimized_scans.enabled",
+	"if set, the locality optimized search over a single index is planned as one "+
+		"vectorized scan that reads the remote regions only if the local region "+
+		"doesn't satisfy the limit",
+	false,
+)
+
+// canFuseLocalityOptimizedScans returns whether the limited UNION ALL of the
+// locality optimized search can be planned as a single TableReader that scans
+// the spans of the remote scan only if the local scan doesn't satisfy the
+// limit (see TableReaderSpec.RemoteSpans). This is the case when both inputs
+// are the scans of the same index that differ only in their spans.
+func (dsp *DistSQLPlanner) canFuseLocalityOptimizedScans(
+	planCtx *PlanningCtx, n *unionNode,
+) (local, remote *scanNode, ok bool) {
+	if !fusedLocalityOptimizedScansEnabled.Get(&dsp.st.SV) {
+		return nil, nil, false
+	}
+	if n.unionType != tree.UnionOp || !n.all || n.hardLimit == 0 ||
+		len(n.streamingOrdering) != 0 || len(n.reqOrdering) != 0 || !planCtx.isLocal {
+		return nil, nil, false
+	}
+	// Only the vectorized engine short-circuits the scan of the remote spans.
+	if planCtx.ExtendedEvalCtx.SessionData().VectorizeMode == sessiondatapb.VectorizeOff {
+		return nil, nil, false
+	}
+	local, ok = n.left.(*scanNode)
+	if !ok {
+		return nil, nil, false
+	}
+	remote, ok = n.right.(*scanNode)
+	if !ok {
+		return nil, nil, false
+	}
+	if n.inverted {
+		local, remote = remote, local
+	}
+	if !local.localityOptimized || !remote.localityOptimized ||
+		local.desc.GetID() != remote.desc.GetID() ||
+		local.index.GetID() != remote.index.GetID() ||
+		len(local.cols) != len(remote.cols) ||
+		local.reverse != remote.reverse ||
+		local.lockingStrength != remote.lockingStrength ||
+		local.lockingWaitPolicy != remote.lockingWaitPolicy ||
+		local.changesSince != remote.changesSince ||
+		local.systemTimeStart != remote.systemTimeStart ||
+		local.systemTimeEnd != remote.systemTimeEnd ||
+		local.sampleMethod != tree.NoTableSample || remote.sampleMethod != tree.NoTableSample {
+		return nil, nil, false
+	}
+	for i := range local.cols {
+		if local.cols[i].GetID() != remote.cols[i].GetID() {
+			return nil, nil, false
+		}
+	}
+	// The limits of the scans themselves must not be stricter than the limit
+	// of the UNION ALL since the fused scan only enforces the latter.
+	for _, scan := range []*scanNode{local, remote} {
+		if scan.hardLimit != 0 && uint64(scan.hardLimit) < n.hardLimit {
+			return nil, nil, false
+		}
+	}
+	return local, remote, true
+}
+
+// createPlanForLocalityOptimizedScans plans a single TableReader that scans the
+// spans of the local scan and then, only if the limit of the UNION ALL hasn't
+// been reached, the spans of the remote scan.
+func (dsp *DistSQLPlanner) createPlanForLocalityOptimizedScans(
+	ctx context.Context, planCtx *PlanningCtx, n *unionNode, local, remote *scanNode,
+) (*PhysicalPlan, error) {
+	spec, post, err := initTableReaderSpecTemplate(local, planCtx.ExtendedEvalCtx.Codec)
+	if err != nil {
+		return nil, err
+	}
+	spec.RemoteSpans = remote.spans
+	spec.LimitHint = 0
+	post.Limit = n.hardLimit
+
+	p := planCtx.NewPhysicalPlan()
+	err = dsp.planTableReaders(
+		ctx,
+		planCtx,
+		p,
+		&tableReaderPlanningInfo{
+			spec:              spec,
+			post:              post,
+			desc:              local.desc,
+			spans:             local.spans,
+			reverse:           local.reverse,
+			parallelize:       false,
+			estimatedRowCount: local.estimatedRowCount,
+		},
+	)
+	return p, err
+}
+
 // TODO(abhimadan): Refactor this function to reduce the UNION vs
 // EXCEPT/INTERSECT and DISTINCT vs ALL branching.
 //
@@ -3519,6 +3905,9 @@ func (dsp *DistSQLPlanner) isOnlyOnGateway(plan *PhysicalPlan) bool {
 func (dsp *DistSQLPlanner) createPlanForSetOp(
 	ctx context.Context, planCtx *PlanningCtx, n *unionNode,
 ) (*PhysicalPlan, error) {
+	if local, remote, ok := dsp.canFuseLocalityOptimizedScans(planCtx, n); ok {
+		return dsp.createPlanForLocalityOptimizedScans(ctx, planCtx, n, local, remote)
+	}
 	leftLogicalPlan := n.left
 	leftPlan, err := dsp.createPhysPlanForPlanNode(ctx, planCtx, n.left)
 	if err != nil {
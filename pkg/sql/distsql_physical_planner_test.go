@@ -43,6 +43,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/randgen"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/skip"
@@ -1453,3 +1456,216 @@ func TestCheckScanParallelizationIfLocal(t *testing.T) {
 		require.Equal(t, tc.hasScanNodeToParallelize, hasScanNodeToParallize)
 	}
 }
+
+// TestPlanTableReadersScanSessionDefaults verifies that the
+// disable_scan_parallelization and scan_batch_bytes_limit session variables
+// are respected when planning TableReaders.
+func TestPlanTableReadersScanSessionDefaults(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	dsp := DistSQLPlanner{
+		planVersion:          execinfra.Version,
+		st:                   cluster.MakeTestingClusterSettings(),
+		gatewaySQLInstanceID: base.SQLInstanceID(1),
+		distSQLSrv:           &distsql.ServerImpl{},
+		codec:                keys.SystemSQLCodec,
+	}
+	span := roachpb.Span{Key: roachpb.Key("A"), EndKey: roachpb.Key("Z")}
+
+	for _, tc := range []struct {
+		parallelize                bool
+		disableScanParallelization bool
+		scanBatchBytesLimit        int64
+		expectedParallelize        bool
+		expectedBatchBytesLimit    int64
+	}{
+		{parallelize: true, expectedParallelize: true},
+		{parallelize: true, scanBatchBytesLimit: 1 << 20, expectedParallelize: true},
+		{parallelize: true, disableScanParallelization: true},
+		{
+			parallelize:                true,
+			disableScanParallelization: true,
+			scanBatchBytesLimit:        1 << 20,
+			expectedBatchBytesLimit:    1 << 20,
+		},
+		{parallelize: false, scanBatchBytesLimit: 1 << 10, expectedBatchBytesLimit: 1 << 10},
+		{parallelize: false},
+	} {
+		t.Run(fmt.Sprintf("%+v", tc), func(t *testing.T) {
+			sd := &sessiondata.SessionData{
+				LocalOnlySessionData: sessiondatapb.LocalOnlySessionData{
+					DisableScanParallelization: tc.disableScanParallelization,
+					ScanBatchBytesLimit:        tc.scanBatchBytesLimit,
+				},
+			}
+			planCtx := dsp.NewPlanningCtx(ctx, &extendedEvalContext{
+				Context: eval.Context{
+					Codec:            keys.SystemSQLCodec,
+					SessionDataStack: sessiondata.NewStack(sd),
+				},
+			}, nil /* planner */, nil /* txn */, DistributionTypeNone)
+			p := planCtx.NewPhysicalPlan()
+			require.NoError(t, dsp.planTableReaders(ctx, planCtx, p, &tableReaderPlanningInfo{
+				spec:        physicalplan.NewTableReaderSpec(),
+				spans:       roachpb.Spans{span},
+				parallelize: tc.parallelize,
+			}))
+			require.Len(t, p.Processors, 1)
+			tr := p.Processors[0].Spec.Core.TableReader
+			require.NotNil(t, tr)
+			require.Equal(t, tc.expectedParallelize, tr.Parallelize)
+			require.Equal(t, tc.expectedBatchBytesLimit, tr.BatchBytesLimit)
+		})
+	}
+}
+
+// TestFuseLocalityOptimizedScans verifies that the limited UNION ALL of the
+// locality optimized search is planned as a single TableReader with remote
+// spans when the fusion is enabled and possible.
+func TestFuseLocalityOptimizedScans(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	dsp := DistSQLPlanner{
+		planVersion:          execinfra.Version,
+		st:                   st,
+		gatewaySQLInstanceID: base.SQLInstanceID(1),
+		distSQLSrv:           &distsql.ServerImpl{},
+		codec:                keys.SystemSQLCodec,
+	}
+	b := tabledesc.NewBuilder(&descpb.TableDescriptor{
+		ID:            100,
+		ParentID:      1,
+		Name:          "t",
+		FormatVersion: descpb.InterleavedFormatVersion,
+		Columns: []descpb.ColumnDescriptor{
+			{ID: 1, Name: "a", Type: types.Int},
+		},
+		NextColumnID: 2,
+		Families: []descpb.ColumnFamilyDescriptor{
+			{ID: 0, Name: "primary", ColumnIDs: []descpb.ColumnID{1}, ColumnNames: []string{"a"}},
+		},
+		PrimaryIndex: descpb.IndexDescriptor{
+			ID: 1, Name: "t_pkey", KeyColumnIDs: []descpb.ColumnID{1},
+			KeyColumnNames:      []string{"a"},
+			KeyColumnDirections: []descpb.IndexDescriptor_Direction{descpb.IndexDescriptor_ASC},
+			EncodingType:        descpb.PrimaryIndexEncoding,
+			Version:             descpb.LatestIndexDescriptorVersion,
+		},
+		NextIndexID: 2,
+	})
+	require.NoError(t, b.RunPostDeserializationChanges())
+	desc := b.BuildImmutableTable()
+
+	localSpans := roachpb.Spans{{Key: roachpb.Key("A"), EndKey: roachpb.Key("B")}}
+	remoteSpans := roachpb.Spans{
+		{Key: roachpb.Key("C"), EndKey: roachpb.Key("D")},
+		{Key: roachpb.Key("E"), EndKey: roachpb.Key("F")},
+	}
+	makeScan := func(spans roachpb.Spans) *scanNode {
+		return &scanNode{
+			desc:              desc,
+			index:             desc.GetPrimaryIndex(),
+			cols:              desc.PublicColumns(),
+			spans:             spans,
+			localityOptimized: true,
+		}
+	}
+	// makeUnion mimics newUnionNode which inverts the inputs of UNION ALL.
+	makeUnion := func(local, remote planNode, hardLimit uint64) *unionNode {
+		return &unionNode{
+			left:      remote,
+			right:     local,
+			inverted:  true,
+			unionType: tree.UnionOp,
+			all:       true,
+			hardLimit: hardLimit,
+		}
+	}
+	makePlanCtx := func(vectorizeMode sessiondatapb.VectorizeExecMode) *PlanningCtx {
+		sd := &sessiondata.SessionData{
+			SessionData: sessiondatapb.SessionData{VectorizeMode: vectorizeMode},
+		}
+		return dsp.NewPlanningCtx(ctx, &extendedEvalContext{
+			Context: eval.Context{
+				Codec:            keys.SystemSQLCodec,
+				SessionDataStack: sessiondata.NewStack(sd),
+			},
+		}, nil /* planner */, nil /* txn */, DistributionTypeNone)
+	}
+
+	t.Run("fused", func(t *testing.T) {
+		fusedLocalityOptimizedScansEnabled.Override(ctx, &st.SV, true)
+		planCtx := makePlanCtx(sessiondatapb.VectorizeOn)
+		p, err := dsp.createPlanForSetOp(ctx, planCtx, makeUnion(makeScan(localSpans), makeScan(remoteSpans), 1))
+		require.NoError(t, err)
+		require.Len(t, p.Processors, 1)
+		tr := p.Processors[0].Spec.Core.TableReader
+		require.NotNil(t, tr)
+		require.Equal(t, []roachpb.Span(localSpans), tr.Spans)
+		require.Equal(t, []roachpb.Span(remoteSpans), tr.RemoteSpans)
+		require.Equal(t, uint64(1), p.Processors[0].Spec.Post.Limit)
+	})
+
+	for _, tc := range []struct {
+		name          string
+		disabled      bool
+		vectorizeMode sessiondatapb.VectorizeExecMode
+		modify        func(local, remote *scanNode)
+		union         func(local, remote *scanNode) *unionNode
+	}{
+		{name: "disabled", disabled: true},
+		{name: "vectorize-off", vectorizeMode: sessiondatapb.VectorizeOff},
+		{
+			name: "not-locality-optimized",
+			modify: func(local, remote *scanNode) {
+				remote.localityOptimized = false
+			},
+		},
+		{
+			name: "stricter-scan-limit",
+			modify: func(local, remote *scanNode) {
+				local.hardLimit = 1
+			},
+			union: func(local, remote *scanNode) *unionNode {
+				return makeUnion(local, remote, 2)
+			},
+		},
+		{
+			name: "different-columns",
+			modify: func(local, remote *scanNode) {
+				remote.cols = nil
+			},
+		},
+		{
+			name: "no-limit",
+			union: func(local, remote *scanNode) *unionNode {
+				return makeUnion(local, remote, 0)
+			},
+		},
+		{
+			name: "not-scan",
+			union: func(local, remote *scanNode) *unionNode {
+				return makeUnion(local, &distinctNode{plan: remote}, 1)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fusedLocalityOptimizedScansEnabled.Override(ctx, &st.SV, !tc.disabled)
+			local, remote := makeScan(localSpans), makeScan(remoteSpans)
+			if tc.modify != nil {
+				tc.modify(local, remote)
+			}
+			n := makeUnion(local, remote, 1)
+			if tc.union != nil {
+				n = tc.union(local, remote)
+			}
+			_, _, ok := dsp.canFuseLocalityOptimizedScans(makePlanCtx(tc.vectorizeMode), n)
+			require.False(t, ok)
+		})
+	}
+}
@@ -36,6 +36,10 @@ type joinPlanningInfo struct {
 	// are only used when planning a hash join.
 	leftEqCols, rightEqCols             []uint32
 	leftEqColsAreKey, rightEqColsAreKey bool
+	// rightEstimatedRowCount, if non-zero, is the optimizer's estimate of the
+	// number of rows in the right input. It is only used when planning a hash
+	// join.
+	rightEstimatedRowCount uint64
 	// leftMergeOrd and rightMergeOrd are the orderings on both inputs to a
 	// merge join. They must be of the same length, and if the length is 0,
 	// then a hash join is planned.
@@ -58,12 +62,13 @@ func (info *joinPlanningInfo) makeCoreSpec() execinfrapb.ProcessorCoreUnion {
 	if len(info.leftMergeOrd.Columns) == 0 {
 		// There is no required ordering on the columns, so we plan a hash join.
 		core.HashJoiner = &execinfrapb.HashJoinerSpec{
-			LeftEqColumns:        info.leftEqCols,
-			RightEqColumns:       info.rightEqCols,
-			OnExpr:               info.onExpr,
-			Type:                 info.joinType,
-			LeftEqColumnsAreKey:  info.leftEqColsAreKey,
-			RightEqColumnsAreKey: info.rightEqColsAreKey,
+			LeftEqColumns:          info.leftEqCols,
+			RightEqColumns:         info.rightEqCols,
+			OnExpr:                 info.onExpr,
+			Type:                   info.joinType,
+			LeftEqColumnsAreKey:    info.leftEqColsAreKey,
+			RightEqColumnsAreKey:   info.rightEqColsAreKey,
+			RightEstimatedRowCount: info.rightEstimatedRowCount,
 		}
 	} else {
 		core.MergeJoiner = &execinfrapb.MergeJoinerSpec{
@@ -0,0 +1,215 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangecache"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+var planPinningEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.distsql.plan_pinning.enabled",
+	"if set, the placement of the processors of distributed plans (i.e. which "+
+		"nodes read which spans) is pinned for each statement fingerprint and "+
+		"reused by later executions until the ranges of the spans change",
+	false,
+)
+
+// maxPinnedSpanPartitionings is the maximum number of span partitionings kept
+// by the pinnedSpanPartitionings cache.
+const maxPinnedSpanPartitionings = 1024
+
+// pinnedSpanPartitionings is a node-wide cache of the results of
+// PartitionSpans keyed by the statement fingerprint and the spans that were
+// partitioned. It allows the repeated executions of the same statement (for
+// example, issued by high-QPS dashboards) to skip the resolution of the ranges
+// and the health checks of the nodes when the data placement is stable.
+//
+// Every entry remembers the descriptor generation and the leaseholder of each
+// range that the spans were partitioned over, and it is ignored once any of
+// these ranges has changed in the range cache (for example, because of a
+// split, a merge, a rebalance, or a lease transfer).
+type pinnedSpanPartitionings struct {
+	mu struct {
+		syncutil.Mutex
+		cache *cache.UnorderedCache
+	}
+}
+
+type pinnedSpanPartitioning struct {
+	ranges     []pinnedRange
+	partitions []SpanPartition
+}
+
+// pinnedRange describes a range, as known by the range cache, at the time the
+// spans of a pinned partitioning were partitioned over it.
+type pinnedRange struct {
+	startKey    roachpb.RKey
+	rangeID     roachpb.RangeID
+	generation  roachpb.RangeGeneration
+	leaseholder roachpb.NodeID
+}
+
+// makePinnedRange returns the pinnedRange describing the range starting at
+// startKey in the range cache, or false if the range isn't cached.
+func makePinnedRange(
+	ctx context.Context, rc *rangecache.RangeCache, startKey roachpb.RKey,
+) (pinnedRange, bool) {
+	entry := rc.GetCached(ctx, startKey, false /* inverted */)
+	if entry == nil || !entry.Desc().StartKey.Equal(startKey) {
+		return pinnedRange{}, false
+	}
+	r := pinnedRange{
+		startKey:   entry.Desc().StartKey,
+		rangeID:    entry.Desc().RangeID,
+		generation: entry.Desc().Generation,
+	}
+	if lh := entry.Leaseholder(); lh != nil {
+		r.leaseholder = lh.NodeID
+	}
+	return r, true
+}
+
+// isCurrent returns whether the range described by r is still cached with the
+// same descriptor generation and leaseholder.
+func (r pinnedRange) isCurrent(ctx context.Context, rc *rangecache.RangeCache) bool {
+	cur, ok := makePinnedRange(ctx, rc, r.startKey)
+	return ok && cur.rangeID == r.rangeID && cur.generation == r.generation &&
+		cur.leaseholder == r.leaseholder
+}
+
+func newPinnedSpanPartitionings() *pinnedSpanPartitionings {
+	p := &pinnedSpanPartitionings{}
+	p.mu.cache = cache.NewUnorderedCache(cache.Config{
+		Policy: cache.CacheLRU,
+		ShouldEvict: func(size int, _, _ interface{}) bool {
+			return size > maxPinnedSpanPartitionings
+		},
+	})
+	return p
+}
+
+// makePinnedSpanPartitioningKey returns the key of the pinned partitioning of
+// the given spans for the statement with the given fingerprint.
+func makePinnedSpanPartitioningKey(fingerprint string, spans roachpb.Spans) string {
+	buf := make([]byte, 0, len(fingerprint)+len(spans)*32)
+	buf = encoding.EncodeStringAscending(buf, fingerprint)
+	for _, sp := range spans {
+		buf = encoding.EncodeBytesAscending(buf, sp.Key)
+		buf = encoding.EncodeBytesAscending(buf, sp.EndKey)
+	}
+	return string(buf)
+}
+
+// get returns the partitioning pinned for the given key, if isCurrent returns
+// true for all the ranges that the spans were partitioned over.
+func (p *pinnedSpanPartitionings) get(
+	key string, isCurrent func(pinnedRange) bool,
+) ([]SpanPartition, bool) {
+	p.mu.Lock()
+	v, ok := p.mu.cache.Get(key)
+	p.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+	// The pinned partitioning is immutable, so it can be validated without
+	// holding the lock.
+	pinned := v.(*pinnedSpanPartitioning)
+	for _, r := range pinned.ranges {
+		if !isCurrent(r) {
+			// The range has changed since the partitioning was computed, so
+			// the spans might have moved.
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			if v, ok := p.mu.cache.StealthyGet(key); ok && v == pinned {
+				p.mu.cache.Del(key)
+			}
+			return nil, false
+		}
+	}
+	return copySpanPartitions(pinned.partitions), true
+}
+
+// put pins the given partitioning of spans over the given ranges.
+func (p *pinnedSpanPartitionings) put(
+	key string, ranges []pinnedRange, partitions []SpanPartition,
+) {
+	pinned := &pinnedSpanPartitioning{
+		ranges:     ranges,
+		partitions: copySpanPartitions(partitions),
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.mu.cache.Add(key, pinned)
+}
+
+// copySpanPartitions returns a copy of partitions that doesn't share the
+// slices with the original.
+func copySpanPartitions(partitions []SpanPartition) []SpanPartition {
+	res := make([]SpanPartition, len(partitions))
+	for i := range partitions {
+		res[i] = SpanPartition{
+			SQLInstanceID: partitions[i].SQLInstanceID,
+			Spans:         append(roachpb.Spans(nil), partitions[i].Spans...),
+		}
+	}
+	return res
+}
+
+// shouldPinSpanPartitions returns whether the partitioning of the spans
+// should go through the pinnedSpanPartitionings cache.
+func (dsp *DistSQLPlanner) shouldPinSpanPartitions(planCtx *PlanningCtx) bool {
+	return !planCtx.isLocal && planCtx.planner != nil &&
+		planCtx.planner.stmt.StmtNoConstants != "" && dsp.distSender != nil &&
+		planPinningEnabled.Get(&dsp.st.SV)
+}
+
+// partitionSpansPinned is like partitionSpansSystem, but it reuses the
+// partitioning of the same spans pinned by a previous execution of the same
+// statement, if none of the ranges of the spans has changed in the range cache
+// since then and all nodes that the spans were assigned to are still healthy.
+func (dsp *DistSQLPlanner) partitionSpansPinned(
+	ctx context.Context, planCtx *PlanningCtx, spans roachpb.Spans,
+) ([]SpanPartition, error) {
+	key := makePinnedSpanPartitioningKey(planCtx.planner.stmt.StmtNoConstants, spans)
+	rc := dsp.distSender.RangeDescriptorCache()
+	isCurrent := func(r pinnedRange) bool { return r.isCurrent(ctx, rc) }
+	if partitions, ok := dsp.pinnedPartitions.get(key, isCurrent); ok {
+		healthy := true
+		for _, partition := range partitions {
+			if dsp.CheckInstanceHealthAndVersion(ctx, planCtx, partition.SQLInstanceID) != NodeOK {
+				healthy = false
+				break
+			}
+		}
+		if healthy {
+			log.VEventf(ctx, 2, "using pinned span partitioning")
+			return partitions, nil
+		}
+	}
+	var ranges []pinnedRange
+	partitions, err := dsp.partitionSpansSystem(ctx, planCtx, spans, &ranges)
+	if err != nil {
+		return nil, err
+	}
+	if ranges != nil {
+		dsp.pinnedPartitions.put(key, ranges, partitions)
+	}
+	return partitions, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPinnedSpanPartitionings(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	mkSpan := func(start, end string) roachpb.Span {
+		return roachpb.Span{Key: roachpb.Key(start), EndKey: roachpb.Key(end)}
+	}
+	const fingerprint = "SELECT * FROM t WHERE k > _"
+	spans := roachpb.Spans{mkSpan("a", "c"), mkSpan("e", "f")}
+	partitions := []SpanPartition{
+		{SQLInstanceID: 1, Spans: roachpb.Spans{mkSpan("a", "b")}},
+		{SQLInstanceID: 2, Spans: roachpb.Spans{mkSpan("b", "c"), mkSpan("e", "f")}},
+	}
+
+	ranges := []pinnedRange{
+		{startKey: roachpb.RKey("a"), rangeID: 1, generation: 1, leaseholder: 1},
+		{startKey: roachpb.RKey("b"), rangeID: 2, generation: 1, leaseholder: 2},
+	}
+	// changedRangeID is the ID of the range that has changed since the
+	// partitioning was pinned, if any.
+	var changedRangeID roachpb.RangeID
+	isCurrent := func(r pinnedRange) bool { return r.rangeID != changedRangeID }
+
+	p := newPinnedSpanPartitionings()
+	key := makePinnedSpanPartitioningKey(fingerprint, spans)
+	_, ok := p.get(key, isCurrent)
+	require.False(t, ok)
+
+	p.put(key, ranges, partitions)
+	pinned, ok := p.get(key, isCurrent)
+	require.True(t, ok)
+	require.Equal(t, partitions, pinned)
+
+	// The pinned partitioning doesn't share memory with the callers.
+	pinned[0].Spans[0] = mkSpan("x", "y")
+	partitions[1].Spans[0] = mkSpan("x", "y")
+	pinned, ok = p.get(key, isCurrent)
+	require.True(t, ok)
+	require.Equal(t, mkSpan("a", "b"), pinned[0].Spans[0])
+	require.Equal(t, mkSpan("b", "c"), pinned[1].Spans[0])
+
+	// The same statement with different spans, or a different statement with
+	// the same spans, doesn't use the pinned partitioning.
+	_, ok = p.get(makePinnedSpanPartitioningKey(fingerprint, spans[:1]), isCurrent)
+	require.False(t, ok)
+	_, ok = p.get(makePinnedSpanPartitioningKey("SELECT * FROM t", spans), isCurrent)
+	require.False(t, ok)
+
+	// A change to a range that doesn't belong to the partitioning doesn't
+	// affect it.
+	changedRangeID = 3
+	_, ok = p.get(key, isCurrent)
+	require.True(t, ok)
+
+	// Once one of the ranges has changed, the pinned partitioning is
+	// discarded.
+	changedRangeID = 2
+	_, ok = p.get(key, isCurrent)
+	require.False(t, ok)
+	changedRangeID = 0
+	_, ok = p.get(key, isCurrent)
+	require.False(t, ok)
+}
@@ -147,6 +147,18 @@ func (dsp *DistSQLPlanner) createStatsPlan(
 						break
 					}
 				}
+				// JSON and array columns without an inverted index still get a
+				// histogram of the keys that an inverted index on the column would
+				// have, since these keys don't depend on the configuration of the
+				// index.
+				colIdx, _ := colIdxMap.Get(col)
+				family := scan.cols[colIdx].GetType().Family()
+				if spec.Index == nil && (family == types.JsonFamily || family == types.ArrayFamily) {
+					spec.Index = &descpb.IndexDescriptor{
+						Type:    descpb.IndexDescriptor_INVERTED,
+						Version: descpb.LatestIndexDescriptorVersion,
+					}
+				}
 			}
 			// Even if spec.Index is nil because there isn't an inverted index
 			// on the requested stats column, we can still proceed. We aren't
@@ -71,6 +71,12 @@ type runnerRequest struct {
 	flowReq       *execinfrapb.SetupFlowRequest
 	sqlInstanceID base.SQLInstanceID
 	resultChan    chan<- runnerResult
+	// warmFlows, if set, remembers that the remote node has cached the
+	// processors of flowReq once the flow is set up successfully.
+	warmFlows *warmFlowSpecs
+	// fullFlow, if set, is the flow spec including the processors that were
+	// omitted from flowReq (see SetupFlowRequest.FlowSpecCached).
+	fullFlow *execinfrapb.FlowSpec
 }
 
 // runnerResult is returned by a worker (via a channel) for each received
@@ -92,16 +98,31 @@ func (req runnerRequest) run() {
 		if sp := tracing.SpanFromContext(req.ctx); sp != nil && !sp.IsNoop() {
 			req.flowReq.TraceInfo = sp.Meta().ToProto()
 		}
-		resp, err := client.SetupFlow(req.ctx, req.flowReq)
-		if err != nil {
-			res.err = err
-		} else {
-			res.err = resp.Error.ErrorDetail(req.ctx)
+		res.err = req.setupFlow(client)
+		if req.flowReq.FlowSpecCached && errors.Is(res.err, execinfrapb.ErrFlowSpecNotCached) {
+			// The remote node no longer has the processors of the flow (for
+			// example, because it was restarted), so we have to send them.
+			req.warmFlows.forget(req.sqlInstanceID, req.flowReq.FlowSpecDigest)
+			req.flowReq.Flow = *req.fullFlow
+			req.flowReq.FlowSpecCached = false
+			res.err = req.setupFlow(client)
+		}
+		if res.err == nil && req.warmFlows != nil {
+			req.warmFlows.add(req.sqlInstanceID, req.flowReq.FlowSpecDigest)
 		}
 	}
 	req.resultChan <- res
 }
 
+// setupFlow issues the SetupFlow RPC for the request.
+func (req runnerRequest) setupFlow(client execinfrapb.DistSQLClient) error {
+	resp, err := client.SetupFlow(req.ctx, req.flowReq)
+	if err != nil {
+		return err
+	}
+	return resp.Error.ErrorDetail(req.ctx)
+}
+
 func (dsp *DistSQLPlanner) initRunners(ctx context.Context) {
 	// This channel has to be unbuffered because we want to only be able to send
 	// requests if a worker is actually there to receive them.
@@ -277,6 +298,7 @@ func (dsp *DistSQLPlanner) setupFlows(
 	recv *DistSQLReceiver,
 	localState distsql.LocalState,
 	collectStats bool,
+	sampleRows bool,
 	statementSQL string,
 ) (context.Context, flowinfra.Flow, execopnode.OpChains, error) {
 	thisNodeID := dsp.gatewaySQLInstanceID
@@ -298,8 +320,14 @@ func (dsp *DistSQLPlanner) setupFlows(
 		EvalContext:       execinfrapb.MakeEvalContext(&evalCtx.Context),
 		TraceKV:           evalCtx.Tracing.KVTracingEnabled(),
 		CollectStats:      collectStats,
+		SampleRows:        sampleRows,
 		StatementSQL:      statementSQL,
 	}
+	if leafInputState != nil && localState.Txn != nil {
+		// The requests of the leaf txns are admitted with the priority of the
+		// root txn, which is derived from the quality of service of the session.
+		setupReq.LeafTxnAdmissionHeader = localState.Txn.AdmissionHeader()
+	}
 
 	// Start all the flows except the flow on this node (there is always a flow on
 	// this node).
@@ -312,8 +340,11 @@ func (dsp *DistSQLPlanner) setupFlows(
 		// Now we determine whether the vectorized engine supports the flow
 		// specs.
 		for _, spec := range flows {
-			if err := colflow.IsSupported(vectorizeMode, spec); err != nil {
+			if component, err := colflow.IsSupported(vectorizeMode, spec); err != nil {
 				log.VEventf(ctx, 2, "failed to vectorize: %s", err)
+				if rec := dsp.distSQLSrv.VectorizedFallbacks; rec != nil {
+					rec.RecordFallback(false /* wrapped */, component, err)
+				}
 				if vectorizeMode == sessiondatapb.VectorizeExperimentalAlways {
 					return nil, nil, nil, err
 				}
@@ -324,6 +355,7 @@ func (dsp *DistSQLPlanner) setupFlows(
 			}
 		}
 	}
+	useWarmFlows := warmFlowsEnabled.Get(&dsp.st.SV)
 	for nodeID, flowSpec := range flows {
 		if nodeID == thisNodeID {
 			// Skip this node.
@@ -338,6 +370,9 @@ func (dsp *DistSQLPlanner) setupFlows(
 			sqlInstanceID: nodeID,
 			resultChan:    resultChan,
 		}
+		if useWarmFlows {
+			dsp.warmFlows.prepare(ctx, &runReq, flowSpec)
+		}
 
 		// Send out a request to the workers; if no worker is available, run
 		// directly.
@@ -523,11 +558,15 @@ func (dsp *DistSQLPlanner) Run(
 		// intended to distribute or not.
 		localState.IsLocal = true
 	} else {
+		queryCtx := ctx
 		defer func() {
-			if recv.resultWriter.Err() != nil {
-				// The execution of this query encountered some error, so we
+			if recv.resultWriter.Err() != nil || queryCtx.Err() != nil {
+				// The execution of this query encountered some error or was
+				// canceled (for example, because of the statement timeout), so we
 				// will eagerly cancel all scheduled flows on the remote nodes
 				// (if they haven't been started yet) because they are now dead.
+				// Otherwise, they would wait for their inbound streams until
+				// sql.distsql.flow_stream_timeout.
 				// TODO(yuzefovich): consider whether augmenting
 				// ConnectInboundStream to keep track of the streams that
 				// initiated FlowStream RPC is worth it - the flows containing
@@ -552,7 +591,7 @@ func (dsp *DistSQLPlanner) Run(
 		statementSQL = planCtx.planner.stmt.StmtNoConstants
 	}
 	ctx, flow, opChains, err := dsp.setupFlows(
-		ctx, evalCtx, leafInputState, flows, recv, localState, planCtx.collectExecStats, statementSQL,
+		ctx, evalCtx, leafInputState, flows, recv, localState, planCtx.collectExecStats, planCtx.sampleRows, statementSQL,
 	)
 	// Make sure that the local flow is always cleaned up if it was created.
 	if flow != nil {
@@ -564,6 +603,7 @@ func (dsp *DistSQLPlanner) Run(
 		recv.SetError(err)
 		return cleanup
 	}
+	recv.flowCtx = ctx
 
 	if finishedSetupFn != nil {
 		finishedSetupFn()
@@ -625,6 +665,11 @@ func (dsp *DistSQLPlanner) Run(
 type DistSQLReceiver struct {
 	ctx context.Context
 
+	// flowCtx, if set, is the context of the gateway flow. It is used to
+	// detect whether the gateway flow was shed because of memory pressure (see
+	// execinfra.FlowShedder).
+	flowCtx context.Context
+
 	// These two interfaces refer to the same object, but batchWriter might be
 	// unset (resultWriter is always set). These are used to send the results
 	// to.
@@ -644,6 +689,11 @@ type DistSQLReceiver struct {
 	// See EXECUTE .. DISCARD ROWS.
 	discardRows bool
 
+	// pager, if set, paginates the rows sent to resultWriter: the flow is
+	// paused after each row (or batch) until the consumer of resultWriter
+	// requests more rows (see execinfra.FlowPager).
+	pager *execinfra.FlowPager
+
 	// commErr keeps track of the error received from interacting with the
 	// resultWriter. This represents a "communication error" and as such is unlike
 	// query execution errors: when the DistSQLReceiver is used within a SQL
@@ -901,10 +951,32 @@ func MakeDistSQLReceiver(
 		tracing:            tracing,
 		contentionRegistry: contentionRegistry,
 	}
+	if w, ok := resultWriter.(pagedResultWriter); ok {
+		r.pager = w.flowPager()
+	}
 	r.testingKnobs.pushCallback = testingPushCallback
 	return r
 }
 
+// pagedResultWriter is implemented by the result writers whose consumers
+// might paginate the results via an execinfra.FlowPager.
+type pagedResultWriter interface {
+	// flowPager returns the pager, or nil if the results aren't paginated.
+	flowPager() *execinfra.FlowPager
+}
+
+// maybeWaitForPager is called after numRows rows have been sent to the result
+// writer. If the results are paginated, it pauses the flow until the consumer
+// requests more rows.
+func (r *DistSQLReceiver) maybeWaitForPager(numRows int) {
+	if r.pager == nil {
+		return
+	}
+	if err := r.pager.Wait(r.ctx, numRows); err != nil {
+		r.SetError(err)
+	}
+}
+
 // Release releases this DistSQLReceiver back to the pool.
 func (r *DistSQLReceiver) Release() {
 	r.cleanup()
@@ -967,6 +1039,9 @@ func (r *DistSQLReceiver) pushMeta(meta *execinfrapb.ProducerMetadata) execinfra
 		}
 	}
 	if meta.Err != nil {
+		if r.flowCtx != nil {
+			meta.Err = execinfra.MaybeReplaceWithShedError(r.flowCtx, meta.Err)
+		}
 		// Check if the error we just received should take precedence over a
 		// previous error (if any).
 		if roachpb.ErrPriority(meta.Err) > roachpb.ErrPriority(r.resultWriter.Err()) {
@@ -1126,6 +1201,8 @@ func (r *DistSQLReceiver) Push(
 	r.tracing.TraceExecRowsResult(r.ctx, r.row)
 	if commErr := r.resultWriter.AddRow(r.ctx, r.row); commErr != nil {
 		r.handleCommErr(commErr)
+	} else {
+		r.maybeWaitForPager(1 /* numRows */)
 	}
 	return r.status
 }
@@ -1170,6 +1247,8 @@ func (r *DistSQLReceiver) PushBatch(
 	r.tracing.TraceExecBatchResult(r.ctx, batch)
 	if commErr := r.batchWriter.AddBatch(r.ctx, batch); commErr != nil {
 		r.handleCommErr(commErr)
+	} else {
+		r.maybeWaitForPager(batch.Length())
 	}
 	return r.status
 }
@@ -1279,6 +1358,7 @@ func (dsp *DistSQLPlanner) planAndRunSubquery(
 	}
 	subqueryPlanCtx.traceMetadata = planner.instrumentation.traceMetadata
 	subqueryPlanCtx.collectExecStats = planner.instrumentation.ShouldCollectExecStats()
+	subqueryPlanCtx.sampleRows = planner.instrumentation.ShouldSampleRows()
 	// Don't close the top-level plan from subqueries - someone else will handle
 	// that.
 	subqueryPlanCtx.ignoreClose = true
@@ -1448,11 +1528,12 @@ func (dsp *DistSQLPlanner) PlanAndRun(
 	}
 }
 
-// PlanAndRunCascadesAndChecks runs any cascade and check queries.
+// PlanAndRunCascadesAndChecks runs any cascade and check queries, as well as
+// the row-level triggers fired by the main query and the cascades.
 //
-// Because cascades can themselves generate more cascades or check queries, this
-// method can append to plan.cascades and plan.checkPlans (and all these plans
-// must be closed later).
+// Because cascades can themselves generate more cascades, check queries or
+// triggers, this method can append to plan.cascades, plan.checkPlans and
+// plan.triggers (and all these plans must be closed later).
 //
 // Returns false if an error was encountered and sets that error in the provided
 // receiver.
@@ -1463,7 +1544,7 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 	plan *planComponents,
 	recv *DistSQLReceiver,
 ) bool {
-	if len(plan.cascades) == 0 && len(plan.checkPlans) == 0 {
+	if len(plan.cascades) == 0 && len(plan.checkPlans) == 0 && len(plan.triggers) == 0 {
 		return false
 	}
 
@@ -1504,9 +1585,9 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 		evalCtx := evalCtxFactory()
 		execFactory := newExecFactory(planner)
 		// The cascading query is allowed to autocommit only if it is the last
-		// cascade and there are no check queries to run.
+		// cascade and there are no check queries or triggers to run.
 		allowAutoCommit := planner.autoCommit
-		if len(plan.checkPlans) > 0 || i < len(plan.cascades)-1 {
+		if len(plan.checkPlans) > 0 || len(plan.triggers) > 0 || i < len(plan.cascades)-1 {
 			allowAutoCommit = false
 		}
 		cascadePlan, err := plan.cascades[i].PlanFn(
@@ -1534,6 +1615,11 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 			plan.checkPlans = append(plan.checkPlans, cp.checkPlans...)
 		}
 
+		// Collect any new triggers.
+		if len(cp.triggers) > 0 {
+			plan.triggers = append(plan.triggers, cp.triggers...)
+		}
+
 		// In cyclical reference situations, the number of cascading operations can
 		// be arbitrarily large. To avoid OOM, we enforce a limit. This is also a
 		// safeguard in case we have a bug that results in an infinite cascade loop.
@@ -1556,6 +1642,20 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 		}
 	}
 
+	for _, ev := range plan.triggers {
+		// We place a sequence point before the triggers so that they observe
+		// the writes of the main query and cascades.
+		_ = planner.Txn().ConfigureStepping(ctx, kv.SteppingEnabled)
+		if err := planner.Txn().Step(ctx); err != nil {
+			recv.SetError(err)
+			return false
+		}
+		if err := planner.runRowTriggers(ctx, ev); err != nil {
+			recv.SetError(err)
+			return false
+		}
+	}
+
 	if len(plan.checkPlans) == 0 {
 		return true
 	}
@@ -1627,6 +1727,7 @@ func (dsp *DistSQLPlanner) planAndRunPostquery(
 	}
 	postqueryPlanCtx.traceMetadata = planner.instrumentation.traceMetadata
 	postqueryPlanCtx.collectExecStats = planner.instrumentation.ShouldCollectExecStats()
+	postqueryPlanCtx.sampleRows = planner.instrumentation.ShouldSampleRows()
 
 	postqueryPhysPlan, physPlanCleanup, err := dsp.createPhysPlan(ctx, postqueryPlanCtx, postqueryPlan)
 	defer physPlanCleanup()
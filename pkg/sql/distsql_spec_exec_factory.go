@@ -255,6 +255,10 @@ func (e *distSQLSpecExecFactory) ConstructScan(
 	}
 	trSpec.LockingStrength = descpb.ToScanLockingStrength(params.Locking.Strength)
 	trSpec.LockingWaitPolicy = descpb.ToScanLockingWaitPolicy(params.Locking.WaitPolicy)
+	trSpec.MinTimestamp = params.ChangesSince
+	trSpec.Sample = makeTableSampleSpec(params.SampleMethod, params.SampleProbability)
+	trSpec.HistoryStartTime = params.SystemTimeStart
+	trSpec.HistoryEndTime = params.SystemTimeEnd
 	if trSpec.LockingStrength != descpb.ScanLockingStrength_FOR_NONE {
 		// Scans that are performing row-level locking cannot currently be
 		// distributed because their locks would not be propagated back to
@@ -410,6 +414,8 @@ func (e *distSQLSpecExecFactory) ConstructApplyJoin(
 	rightColumns colinfo.ResultColumns,
 	onCond tree.TypedExpr,
 	planRightSideFn exec.ApplyJoinPlanRightSideFn,
+	leftBoundCols []exec.NodeColumnOrdinal,
+	cacheRightSide bool,
 ) (exec.Node, error) {
 	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: apply join")
 }
@@ -420,11 +426,13 @@ func (e *distSQLSpecExecFactory) ConstructHashJoin(
 	leftEqCols, rightEqCols []exec.NodeColumnOrdinal,
 	leftEqColsAreKey, rightEqColsAreKey bool,
 	extraOnCond tree.TypedExpr,
+	rightEstimatedRowCount uint64,
 ) (exec.Node, error) {
 	return e.constructHashOrMergeJoin(
 		joinType, left, right, extraOnCond, leftEqCols, rightEqCols,
 		leftEqColsAreKey, rightEqColsAreKey,
 		ReqOrdering{} /* mergeJoinOrdering */, exec.OutputOrdering{}, /* reqOrdering */
+		rightEstimatedRowCount,
 	)
 }
 
@@ -443,12 +451,14 @@ func (e *distSQLSpecExecFactory) ConstructMergeJoin(
 	return e.constructHashOrMergeJoin(
 		joinType, left, right, onCond, leftEqCols, rightEqCols,
 		leftEqColsAreKey, rightEqColsAreKey, mergeJoinOrdering, reqOrdering,
+		0, /* rightEstimatedRowCount */
 	)
 }
 
 func populateAggFuncSpec(
 	spec *execinfrapb.AggregatorSpec_Aggregation,
 	funcName string,
+	userDefined tree.UserDefinedAggregateOverload,
 	distinct bool,
 	argCols []exec.NodeColumnOrdinal,
 	constArgs []tree.Datum,
@@ -456,11 +466,16 @@ func populateAggFuncSpec(
 	planCtx *PlanningCtx,
 	physPlan *PhysicalPlan,
 ) (argumentsColumnTypes []*types.T, err error) {
-	funcIdx, err := execinfrapb.GetAggregateFuncIdx(funcName)
-	if err != nil {
-		return nil, err
+	if userDefined != nil {
+		spec.Func = execinfrapb.UserDefined
+		spec.UserDefined = userDefined.(*descpb.UserDefinedAggregate)
+	} else {
+		funcIdx, err := execinfrapb.GetAggregateFuncIdx(funcName)
+		if err != nil {
+			return nil, err
+		}
+		spec.Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 	}
-	spec.Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 	spec.Distinct = distinct
 	spec.ColIdx = make([]uint32, len(argCols))
 	for i, col := range argCols {
@@ -506,7 +521,7 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 			spec := &aggregationSpecs[i]
 			argColsScratch[0] = col
 			_, err = populateAggFuncSpec(
-				spec, builtins.AnyNotNull, false /* distinct*/, argColsScratch,
+				spec, builtins.AnyNotNull, nil /* userDefined */, false /* distinct*/, argColsScratch,
 				nil /* constArgs */, noFilter, planCtx, physPlan,
 			)
 			if err != nil {
@@ -519,7 +534,7 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 		spec := &aggregationSpecs[i]
 		agg := &aggregations[j]
 		argumentsColumnTypes[i], err = populateAggFuncSpec(
-			spec, agg.FuncName, agg.Distinct, agg.ArgCols,
+			spec, agg.FuncName, agg.UserDefined, agg.Distinct, agg.ArgCols,
 			agg.ConstArgs, agg.Filter, planCtx, physPlan,
 		)
 		if err != nil {
@@ -1164,6 +1179,7 @@ func (e *distSQLSpecExecFactory) constructHashOrMergeJoin(
 	leftEqColsAreKey, rightEqColsAreKey bool,
 	mergeJoinOrdering colinfo.ColumnOrdering,
 	reqOrdering exec.OutputOrdering,
+	rightEstimatedRowCount uint64,
 ) (exec.Node, error) {
 	leftPhysPlan, leftPlan := getPhysPlan(left)
 	rightPhysPlan, rightPlan := getPhysPlan(right)
@@ -1199,6 +1215,7 @@ func (e *distSQLSpecExecFactory) constructHashOrMergeJoin(
 		rightEqCols:              rightEqColsRemapped,
 		leftEqColsAreKey:         leftEqColsAreKey,
 		rightEqColsAreKey:        rightEqColsAreKey,
+		rightEstimatedRowCount:   rightEstimatedRowCount,
 		leftMergeOrd:             distsqlOrdering(mergeJoinOrdering, leftEqColsRemapped),
 		rightMergeOrd:            distsqlOrdering(mergeJoinOrdering, rightEqColsRemapped),
 		leftPlanDistribution:     leftPhysPlan.Distribution,
@@ -0,0 +1,109 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+var warmFlowsEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.distsql.warm_flows.enabled",
+	"if set, the remote nodes keep the specifications of the recently set up "+
+		"flows, and the repeated executions of the same distributed plan omit "+
+		"them from the flow setup requests",
+	false,
+)
+
+// maxWarmFlowSpecs is the maximum number of flow specs tracked by the
+// warmFlowSpecs.
+const maxWarmFlowSpecs = 4096
+
+// warmFlowSpecs tracks which remote nodes have cached the processors of which
+// flows (identified by the digest of the processors), which allows the gateway
+// to omit the processors from the SetupFlow requests when it executes the same
+// plan again.
+//
+// The tracking is best-effort: if a remote node has evicted the processors (or
+// has been restarted), the SetupFlow request is retried with the full flow
+// spec.
+type warmFlowSpecs struct {
+	mu struct {
+		syncutil.Mutex
+		cache *cache.UnorderedCache
+	}
+}
+
+type warmFlowSpecKey struct {
+	sqlInstanceID base.SQLInstanceID
+	digest        string
+}
+
+func newWarmFlowSpecs() *warmFlowSpecs {
+	w := &warmFlowSpecs{}
+	w.mu.cache = cache.NewUnorderedCache(cache.Config{
+		Policy: cache.CacheLRU,
+		ShouldEvict: func(size int, _, _ interface{}) bool {
+			return size > maxWarmFlowSpecs
+		},
+	})
+	return w
+}
+
+// prepare sets up the given runner request so that the processors of the flow
+// are omitted if the remote node has already cached them, and so that they are
+// cached by the remote node otherwise.
+func (w *warmFlowSpecs) prepare(
+	ctx context.Context, req *runnerRequest, flowSpec *execinfrapb.FlowSpec,
+) {
+	_, digest, err := execinfrapb.MarshalFlowSpecProcessors(flowSpec)
+	if err != nil {
+		// This shouldn't happen, but it's not a reason to fail the query.
+		log.Warningf(ctx, "failed to compute the flow spec digest: %v", err)
+		return
+	}
+	req.flowReq.FlowSpecDigest = digest
+	req.warmFlows = w
+	if w.contains(req.sqlInstanceID, digest) {
+		req.fullFlow = flowSpec
+		req.flowReq.Flow.Processors = nil
+		req.flowReq.FlowSpecCached = true
+	}
+}
+
+func (w *warmFlowSpecs) contains(sqlInstanceID base.SQLInstanceID, digest []byte) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, ok := w.mu.cache.Get(warmFlowSpecKey{sqlInstanceID: sqlInstanceID, digest: string(digest)})
+	return ok
+}
+
+// add remembers that the given remote node has cached the processors with the
+// given digest.
+func (w *warmFlowSpecs) add(sqlInstanceID base.SQLInstanceID, digest []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.mu.cache.Add(warmFlowSpecKey{sqlInstanceID: sqlInstanceID, digest: string(digest)}, nil)
+}
+
+// forget removes the given remote node and digest from the tracked ones.
+func (w *warmFlowSpecs) forget(sqlInstanceID base.SQLInstanceID, digest []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.mu.cache.Del(warmFlowSpecKey{sqlInstanceID: sqlInstanceID, digest: string(digest)})
+}
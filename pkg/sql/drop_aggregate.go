@@ -0,0 +1,128 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
+)
+
+type dropAggregateNode struct {
+	n      *tree.DropAggregate
+	schema *schemadesc.Mutable
+	idx    int
+}
+
+// DropAggregate removes a user-defined aggregate function.
+// Privileges: CREATE on the schema.
+func (p *planner) DropAggregate(ctx context.Context, n *tree.DropAggregate) (planNode, error) {
+	if err := checkUserDefinedAggregatesSupported(ctx, p, "DROP AGGREGATE"); err != nil {
+		return nil, err
+	}
+	if len(n.ArgTypes) != 1 {
+		return nil, unimplemented.New("drop aggregate arguments",
+			"user-defined aggregates must have exactly one argument")
+	}
+	argType, err := p.resolveUserDefinedAggregateType(ctx, n.ArgTypes[0])
+	if err != nil {
+		return nil, err
+	}
+
+	schema, idx, err := p.resolveMutableUserDefinedAggregate(ctx, n.Name, argType)
+	if err != nil {
+		return nil, err
+	}
+	if schema == nil {
+		if n.IfExists {
+			// Noop.
+			return newZeroNode(nil /* columns */), nil
+		}
+		return nil, pgerror.Newf(pgcode.UndefinedFunction,
+			"aggregate %s(%s) does not exist", n.Name, argType.SQLString())
+	}
+	if err := p.canCreateOnSchema(
+		ctx, schema.GetID(), schema.GetParentID(), p.User(), checkPublicSchema,
+	); err != nil {
+		return nil, err
+	}
+	return &dropAggregateNode{n: n, schema: schema, idx: idx}, nil
+}
+
+// resolveMutableUserDefinedAggregate returns the mutable descriptor of the
+// schema of the aggregate with the given name and argument type, and the index
+// of the aggregate in the Aggregates of the schema. The schema is nil if there
+// is no such aggregate.
+func (p *planner) resolveMutableUserDefinedAggregate(
+	ctx context.Context, name *tree.UnresolvedObjectName, argType *types.T,
+) (*schemadesc.Mutable, int, error) {
+	dbName := p.CurrentDatabase()
+	if name.HasExplicitCatalog() {
+		dbName = name.Catalog()
+	}
+	db, err := p.Descriptors().GetImmutableDatabaseByName(
+		ctx, p.txn, dbName, tree.DatabaseLookupFlags{Required: true},
+	)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	var schema *schemadesc.Mutable
+	idx := -1
+	lookup := func(scName string) error {
+		sc, err := p.Descriptors().GetMutableSchemaByName(
+			ctx, p.txn, db, scName, tree.SchemaLookupFlags{RequireMutable: true},
+		)
+		if err != nil {
+			return err
+		}
+		mutable, ok := sc.(*schemadesc.Mutable)
+		if !ok {
+			return nil
+		}
+		if idx = findUserDefinedAggregate(mutable, name.Object(), argType); idx != -1 {
+			schema = mutable
+			return iterutil.StopIteration()
+		}
+		return nil
+	}
+	if name.HasExplicitSchema() {
+		err = lookup(name.Schema())
+	} else {
+		searchPath := p.CurrentSearchPath()
+		err = searchPath.IterateSearchPath(lookup)
+	}
+	if err != nil && !iterutil.Done(err) {
+		return nil, -1, err
+	}
+	return schema, idx, nil
+}
+
+func (n *dropAggregateNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("aggregate"))
+
+	n.schema.Aggregates = append(n.schema.Aggregates[:n.idx], n.schema.Aggregates[n.idx+1:]...)
+	return params.p.writeSchemaDescChange(
+		params.ctx, n.schema, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *dropAggregateNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropAggregateNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropAggregateNode) Close(context.Context)        {}
@@ -0,0 +1,90 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+)
+
+type dropPolicyNode struct {
+	n         *tree.DropPolicy
+	tn        tree.TableName
+	tableDesc *tabledesc.Mutable
+}
+
+// DropPolicy removes a row-level security policy from a table.
+// Privileges: ownership of the table.
+func (p *planner) DropPolicy(ctx context.Context, n *tree.DropPolicy) (planNode, error) {
+	if err := checkRowLevelSecuritySupported(ctx, p, "DROP POLICY"); err != nil {
+		return nil, err
+	}
+
+	tn := n.Table.ToTableName()
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, !n.IfExists, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		// Noop.
+		return newZeroNode(nil /* columns */), nil
+	}
+	if err := p.checkTableOwnerOrAdmin(ctx, tableDesc); err != nil {
+		return nil, err
+	}
+	return &dropPolicyNode{n: n, tn: tn, tableDesc: tableDesc}, nil
+}
+
+func (n *dropPolicyNode) startExec(params runParams) error {
+	tableDesc := n.tableDesc
+	idx := -1
+	for i := range tableDesc.Policies {
+		if tableDesc.Policies[i].Name == string(n.n.Name) {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		if n.n.IfExists {
+			return nil
+		}
+		return pgerror.Newf(pgcode.UndefinedObject,
+			"policy %q for table %q does not exist", n.n.Name, tableDesc.GetName())
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("policy"))
+	tableDesc.Policies = append(tableDesc.Policies[:idx], tableDesc.Policies[idx+1:]...)
+
+	if err := params.p.writeSchemaChange(
+		params.ctx, tableDesc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+	return params.p.logEvent(params.ctx,
+		tableDesc.ID,
+		&eventpb.AlterTable{
+			TableName: n.tn.FQString(),
+		})
+}
+
+func (n *dropPolicyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropPolicyNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropPolicyNode) Close(context.Context)        {}
@@ -80,6 +80,7 @@ const (
 	schema           objectType = "schema"
 	typeObject       objectType = "type"
 	defaultPrivilege objectType = "default_privilege"
+	policy           objectType = "policy"
 )
 
 type objectAndType struct {
@@ -183,6 +184,22 @@ func (n *DropRoleNode) startExec(params runParams) error {
 					ObjectName: tn.String(),
 				})
 		}
+		for _, pol := range tableDescriptor.GetPolicies() {
+			for _, r := range pol.Roles {
+				role := username.MakeSQLUsernameFromPreNormalizedString(r)
+				if _, ok := userNames[role]; !ok {
+					continue
+				}
+				tn, err := getTableNameFromTableDescriptor(lCtx, tableDescriptor, "")
+				if err != nil {
+					return err
+				}
+				userNames[role] = append(userNames[role], objectAndType{
+					ObjectType: policy,
+					ObjectName: fmt.Sprintf("%s on table %s", tree.Name(pol.Name), tn.String()),
+				})
+			}
+		}
 		for _, u := range tableDescriptor.GetPrivileges().Users {
 			if _, ok := userNames[u.User()]; ok {
 				if privilegeObjectFormatter.Len() > 0 {
@@ -280,6 +297,8 @@ func (n *DropRoleNode) startExec(params runParams) error {
 				switch obj.ObjectType {
 				case database, table, schema, typeObject:
 					objectsMsg.WriteString(fmt.Sprintf("\nowner of %s %s", obj.ObjectType, obj.ObjectName))
+				case policy:
+					objectsMsg.WriteString(fmt.Sprintf("\ntarget of policy %s", obj.ObjectName))
 				case defaultPrivilege:
 					hasDependentDefaultPrivilege = true
 					objectsMsg.WriteString(fmt.Sprintf("\n%s", obj.ErrorMessage))
@@ -0,0 +1,90 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+)
+
+type dropTriggerNode struct {
+	n         *tree.DropTrigger
+	tn        tree.TableName
+	tableDesc *tabledesc.Mutable
+}
+
+// DropTrigger removes a row-level trigger from a table.
+// Privileges: ownership of the table.
+func (p *planner) DropTrigger(ctx context.Context, n *tree.DropTrigger) (planNode, error) {
+	if err := checkRowLevelTriggersSupported(ctx, p, "DROP TRIGGER"); err != nil {
+		return nil, err
+	}
+
+	tn := n.Table.ToTableName()
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, !n.IfExists, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		// Noop.
+		return newZeroNode(nil /* columns */), nil
+	}
+	if err := p.checkTableOwnerOrAdmin(ctx, tableDesc); err != nil {
+		return nil, err
+	}
+	return &dropTriggerNode{n: n, tn: tn, tableDesc: tableDesc}, nil
+}
+
+func (n *dropTriggerNode) startExec(params runParams) error {
+	tableDesc := n.tableDesc
+	idx := -1
+	for i := range tableDesc.Triggers {
+		if tableDesc.Triggers[i].Name == string(n.n.Name) {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		if n.n.IfExists {
+			return nil
+		}
+		return pgerror.Newf(pgcode.UndefinedObject,
+			"trigger %q for table %q does not exist", n.n.Name, tableDesc.GetName())
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("trigger"))
+	tableDesc.Triggers = append(tableDesc.Triggers[:idx], tableDesc.Triggers[idx+1:]...)
+
+	if err := params.p.writeSchemaChange(
+		params.ctx, tableDesc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+	return params.p.logEvent(params.ctx,
+		tableDesc.ID,
+		&eventpb.AlterTable{
+			TableName: n.tn.FQString(),
+		})
+}
+
+func (n *dropTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropTriggerNode) Close(context.Context)        {}
@@ -77,6 +77,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirecancel"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
+	"github.com/cockroachdb/cockroach/pkg/sql/resultcache"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/scheduledlogging"
@@ -195,6 +196,17 @@ var allowCrossDatabaseSeqReferences = settings.RegisterBoolSetting(
 	false,
 ).WithPublic()
 
+// allowReadCommittedIsolation controls whether transactions may run at the READ
+// COMMITTED isolation level. When it's disabled, transactions that request READ
+// COMMITTED run at SERIALIZABLE instead.
+var allowReadCommittedIsolation = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.txn.read_committed_isolation.enabled",
+	"set to true to allow transactions to use the READ COMMITTED isolation level "+
+		"if specified by BEGIN/SET commands",
+	false,
+).WithPublic()
+
 // SecondaryTenantsZoneConfigsEnabledSettingName controls if secondary tenants
 // are allowed to set zone configurations. It has no effect for the system
 // tenant.
@@ -638,6 +650,20 @@ var dateStyle = settings.RegisterEnumSetting(
 	dateStyleEnumMap,
 ).WithPublic()
 
+// defaultTxnQualityOfService controls the default value of the
+// default_transaction_quality_of_service session setting.
+var defaultTxnQualityOfService = settings.RegisterEnumSetting(
+	settings.TenantWritable,
+	"sql.defaults.default_transaction_quality_of_service",
+	"default value for default_transaction_quality_of_service session setting",
+	sessiondatapb.NormalName,
+	map[int64]string{
+		int64(sessiondatapb.UserLow):  sessiondatapb.UserLowName,
+		int64(sessiondatapb.Normal):   sessiondatapb.NormalName,
+		int64(sessiondatapb.UserHigh): sessiondatapb.UserHighName,
+	},
+).WithPublic()
+
 const intervalStyleEnabledClusterSetting = "sql.defaults.intervalstyle.enabled"
 
 // intervalStyleEnabled controls intervals representation.
@@ -773,6 +799,18 @@ var (
 		Measurement: "SQL Statements",
 		Unit:        metric.Unit_COUNT,
 	}
+	MetaSQLResultCacheHits = metric.Metadata{
+		Name:        "sql.result_cache.hits",
+		Help:        "Number of statements whose results were returned from the result cache",
+		Measurement: "SQL Statements",
+		Unit:        metric.Unit_COUNT,
+	}
+	MetaSQLResultCacheMisses = metric.Metadata{
+		Name:        "sql.result_cache.misses",
+		Help:        "Number of statements whose results were not found in the result cache",
+		Measurement: "SQL Statements",
+		Unit:        metric.Unit_COUNT,
+	}
 	MetaDistSQLSelect = metric.Metadata{
 		Name:        "sql.distsql.select.count",
 		Help:        "Number of DistSQL SELECT statements",
@@ -1203,6 +1241,7 @@ type ExecutorConfig struct {
 	StatsRefresher     *stats.Refresher
 	InternalExecutor   *InternalExecutor
 	QueryCache         *querycache.C
+	ResultCache        *resultcache.Cache
 
 	SchemaChangerMetrics *SchemaChangerMetrics
 	FeatureFlagMetrics   *featureflag.DenialMetrics
@@ -1759,6 +1798,8 @@ func checkResultType(typ *types.T) error {
 	case types.TimestampTZFamily:
 	case types.IntervalFamily:
 	case types.JsonFamily:
+	case types.TSQueryFamily:
+	case types.TSVectorFamily:
 	case types.UuidFamily:
 	case types.INetFamily:
 	case types.OidFamily:
@@ -2912,6 +2953,10 @@ func (m *sessionDataMutator) SetDefaultTransactionPriority(val tree.UserPriority
 	m.data.DefaultTxnPriority = int64(val)
 }
 
+func (m *sessionDataMutator) SetDefaultTransactionIsolationLevel(val tree.IsolationLevel) {
+	m.data.DefaultTxnIsolationLevel = int64(val)
+}
+
 func (m *sessionDataMutator) SetDefaultTransactionReadOnly(val bool) {
 	m.data.DefaultTxnReadOnly = val
 }
@@ -3245,6 +3290,34 @@ func (m *sessionDataMutator) SetTestingOptimizerRandomCostSeed(val int64) {
 	m.data.TestingOptimizerRandomCostSeed = val
 }
 
+func (m *sessionDataMutator) SetDisableScanParallelization(val bool) {
+	m.data.DisableScanParallelization = val
+}
+
+func (m *sessionDataMutator) SetScanBatchBytesLimit(val int64) {
+	m.data.ScanBatchBytesLimit = val
+}
+
+func (m *sessionDataMutator) SetVerifyScanChecksums(val bool) {
+	m.data.VerifyScanChecksums = val
+}
+
+func (m *sessionDataMutator) SetMinScanBatchSize(val int32) {
+	m.data.MinScanBatchSize = val
+}
+
+func (m *sessionDataMutator) SetEnableResultCache(val bool) {
+	m.data.EnableResultCache = val
+}
+
+func (m *sessionDataMutator) SetAutoIndexJSONComputedColumns(val bool) {
+	m.data.AutoIndexJSONComputedColumns = val
+}
+
+func (m *sessionDataMutator) SetRejectUnknownStatementHints(val bool) {
+	m.data.RejectUnknownStatementHints = val
+}
+
 func (m *sessionDataMutator) SetTrigramSimilarityThreshold(val float64) {
 	m.data.TrigramSimilarityThreshold = val
 }
@@ -13,6 +13,7 @@ package execagg
 import (
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
@@ -103,10 +104,35 @@ func GetAggregateConstructor(
 		argTypes[len(aggInfo.ColIdx)+j] = d.ResolvedType()
 		arguments[j] = d
 	}
+	if aggInfo.Func == execinfrapb.UserDefined {
+		constructor, outputType, err = GetUserDefinedAggregateInfo(aggInfo.UserDefined, argTypes...)
+		return
+	}
 	constructor, outputType, err = GetAggregateInfo(aggInfo.Func, argTypes...)
 	return
 }
 
+// GetUserDefinedAggregateInfo returns the aggregate constructor and the return
+// type for the given aggregate defined with CREATE AGGREGATE when applied on
+// the given type.
+func GetUserDefinedAggregateInfo(
+	agg *descpb.UserDefinedAggregate, inputTypes ...*types.T,
+) (aggregateConstructor AggregateConstructor, returnType *types.T, err error) {
+	if agg == nil {
+		return nil, nil, errors.AssertionFailedf("missing user-defined aggregate")
+	}
+	if len(inputTypes) != 1 || !(inputTypes[0].Equivalent(agg.ArgType) ||
+		inputTypes[0].Family() == types.UnknownFamily) {
+		return nil, nil, errors.Errorf(
+			"no user-defined aggregate for %s on %+v", agg.Name, inputTypes,
+		)
+	}
+	constructAgg := func(evalCtx *eval.Context, _ tree.Datums) eval.AggregateFunc {
+		return builtins.NewUserDefinedAggregate(agg, evalCtx)
+	}
+	return constructAgg, agg.ResultType, nil
+}
+
 // GetWindowFunctionInfo returns windowFunc constructor and the return type
 // when given fn is applied to given inputTypes.
 func GetWindowFunctionInfo(
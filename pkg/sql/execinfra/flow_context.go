@@ -77,6 +77,10 @@ type FlowCtx struct {
 	// CollectStats is true if execution stats collection was requested.
 	CollectStats bool
 
+	// SampleRows is true if a sample of the rows produced by each processor
+	// should be collected along with the execution stats.
+	SampleRows bool
+
 	// Local is true if this flow is being run as part of a local-only query.
 	Local bool
 
@@ -0,0 +1,88 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package execinfra
+
+import (
+	"context"
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// FlowPager allows the consumer of a flow to paginate its results: the flow
+// is paused once it has produced the number of rows requested so far, and it
+// is resumed once more rows are requested. A paused flow doesn't perform any
+// work, and the remote flows feeding into it stop as soon as the buffers of
+// their streams fill up, so the results don't have to be buffered by the
+// consumer.
+//
+// The pager is consulted by the flow after each row (or batch) has been
+// pushed to the consumer, so a paused flow never starts computing the next
+// row until it is resumed. Calls to Wait are expected to be made from a single
+// goroutine, whereas ResumeUntil and Close can be called concurrently from
+// any goroutine.
+type FlowPager struct {
+	mu struct {
+		syncutil.Mutex
+		// produced is the number of rows produced by the flow so far.
+		produced int64
+		// limit is the number of rows after which the flow must pause.
+		limit int64
+	}
+	// resumeCh is signaled whenever the limit is raised.
+	resumeCh chan struct{}
+}
+
+// NewFlowPager returns a new FlowPager that pauses the flow after it produces
+// its first row.
+func NewFlowPager() *FlowPager {
+	return &FlowPager{resumeCh: make(chan struct{}, 1)}
+}
+
+// ResumeUntil allows the flow to produce rows until it has produced numRows
+// rows in total. It is a no-op if numRows doesn't exceed the current limit.
+func (p *FlowPager) ResumeUntil(numRows int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if numRows <= p.mu.limit {
+		return
+	}
+	p.mu.limit = numRows
+	select {
+	case p.resumeCh <- struct{}{}:
+	default:
+		// The flow has already been signaled.
+	}
+}
+
+// Close resumes the flow and makes it never pause again.
+func (p *FlowPager) Close() {
+	p.ResumeUntil(math.MaxInt64)
+}
+
+// Wait records that the flow has produced numRows more rows, and blocks while
+// the flow is paused. A non-nil error is returned if the context is canceled
+// while waiting.
+func (p *FlowPager) Wait(ctx context.Context, numRows int) error {
+	p.mu.Lock()
+	p.mu.produced += int64(numRows)
+	for p.mu.produced >= p.mu.limit {
+		p.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.resumeCh:
+		}
+		p.mu.Lock()
+	}
+	p.mu.Unlock()
+	return nil
+}
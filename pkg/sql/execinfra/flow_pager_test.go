@@ -0,0 +1,69 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package execinfra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlowPager(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := NewFlowPager()
+	// The flow sends the ordinal of each row it produces on rows.
+	rows := make(chan int)
+	go func() {
+		for i := 1; ; i++ {
+			select {
+			case rows <- i:
+			case <-ctx.Done():
+				return
+			}
+			if err := p.Wait(ctx, 1 /* numRows */); err != nil {
+				return
+			}
+		}
+	}()
+	expectPaused := func() {
+		select {
+		case i := <-rows:
+			t.Fatalf("unexpectedly produced row %d while paused", i)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	// The flow is paused after the first row.
+	require.Equal(t, 1, <-rows)
+	expectPaused()
+	p.ResumeUntil(3)
+	require.Equal(t, 2, <-rows)
+	require.Equal(t, 3, <-rows)
+	expectPaused()
+	// Lowering the limit has no effect.
+	p.ResumeUntil(2)
+	expectPaused()
+	p.Close()
+	for i := 4; i <= 10; i++ {
+		require.Equal(t, i, <-rows)
+	}
+
+	// A paused flow is unblocked when the context is canceled.
+	canceledCtx, cancelWait := context.WithCancel(context.Background())
+	cancelWait()
+	require.Error(t, NewFlowPager().Wait(canceledCtx, 1 /* numRows */))
+}
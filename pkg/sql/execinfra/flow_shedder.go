@@ -0,0 +1,233 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package execinfra
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// flowSheddingMemoryThreshold determines the fraction of the SQL memory pool
+// above which the FlowShedder starts canceling the distributed flows.
+var flowSheddingMemoryThreshold = settings.RegisterFloatSetting(
+	settings.TenantWritable,
+	"sql.distsql.flow_shedding.memory_threshold",
+	"fraction of the SQL memory pool (--max-sql-memory) above which the "+
+		"distributed flows with the lowest admission priority are canceled in "+
+		"order to relieve the memory pressure on the node; 0 disables the shedding",
+	0,
+	func(v float64) error {
+		if v < 0 || v > 1 {
+			return errors.Errorf("the threshold must be in [0, 1] range, given %f", v)
+		}
+		return nil
+	},
+)
+
+// flowShedderCheckInterval is how often the FlowShedder checks the memory
+// usage of the node.
+var flowShedderCheckInterval = 100 * time.Millisecond
+
+// ErrFlowShed is returned by the flows that were canceled by the FlowShedder
+// because of the memory pressure on the node. The query that hit this error
+// can be retried.
+var ErrFlowShed = pgerror.WithCandidateCode(
+	errors.New("query canceled because of memory pressure on a node"),
+	pgcode.InsufficientResources,
+)
+
+// IsFlowShedError returns true if err was caused by a flow being canceled by
+// the FlowShedder. It can be used on errors received from remote nodes.
+func IsFlowShedError(err error) bool {
+	return errors.Is(err, ErrFlowShed)
+}
+
+// FlowShedder watches the memory usage of the node's root SQL memory monitor
+// and proactively cancels the distributed flows before the node runs out of
+// memory. Once the memory usage exceeds the threshold (as determined by the
+// sql.distsql.flow_shedding.memory_threshold cluster setting), the flows are
+// canceled in the order of increasing admission priority (the most recent
+// flows first among the ones with the same priority) until the memory used by
+// the remaining flows fits under the threshold.
+//
+// The flows that were shed return ErrFlowShed (see MaybeReplaceWithShedError)
+// so that the clients can tell them apart from the queries canceled for other
+// reasons and can retry them.
+type FlowShedder struct {
+	st      *cluster.Settings
+	rootMon *mon.BytesMonitor
+	// numShed, if set, is incremented every time a flow is shed.
+	numShed *metric.Counter
+	mu      struct {
+		syncutil.Mutex
+		flows map[*shedFlowState]struct{}
+	}
+}
+
+// shedFlowState tracks a single flow registered with the FlowShedder.
+type shedFlowState struct {
+	admissionInfo admission.WorkInfo
+	// flowMon is the memory monitor of the flow. It determines how much memory
+	// is released once the flow is shed.
+	flowMon *mon.BytesMonitor
+	cancel  context.CancelFunc
+	ctxDone <-chan struct{}
+	// shed is set (atomically) to 1 once the flow has been shed.
+	shed int32
+}
+
+func (s *shedFlowState) wasShed() bool {
+	return atomic.LoadInt32(&s.shed) == 1
+}
+
+// NewFlowShedder returns a new FlowShedder that watches the given root memory
+// monitor. numShed can be nil.
+func NewFlowShedder(
+	st *cluster.Settings, rootMon *mon.BytesMonitor, numShed *metric.Counter,
+) *FlowShedder {
+	s := &FlowShedder{st: st, rootMon: rootMon, numShed: numShed}
+	s.mu.flows = make(map[*shedFlowState]struct{})
+	return s
+}
+
+// Start starts the goroutine that periodically checks the memory usage.
+func (s *FlowShedder) Start(ctx context.Context, stopper *stop.Stopper) error {
+	return stopper.RunAsyncTask(ctx, "flow-shedder", func(ctx context.Context) {
+		ticker := time.NewTicker(flowShedderCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.maybeShed(ctx)
+			case <-stopper.ShouldQuiesce():
+				return
+			}
+		}
+	})
+}
+
+type shedFlowStateKey struct{}
+
+// Register registers the flow that is about to run with the given context so
+// that it could be shed. flowMon is the memory monitor of the flow, and
+// cancel must cancel the flow's context. The returned context must be used
+// when running the flow.
+//
+// The flow doesn't need to be unregistered: it is forgotten once its context
+// is canceled (which happens when the flow is cleaned up).
+func (s *FlowShedder) Register(
+	ctx context.Context,
+	admissionInfo admission.WorkInfo,
+	flowMon *mon.BytesMonitor,
+	cancel context.CancelFunc,
+) context.Context {
+	state := &shedFlowState{
+		admissionInfo: admissionInfo,
+		flowMon:       flowMon,
+		cancel:        cancel,
+		ctxDone:       ctx.Done(),
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.flows[state] = struct{}{}
+	return context.WithValue(ctx, shedFlowStateKey{}, state)
+}
+
+// MaybeReplaceWithShedError returns ErrFlowShed if err is non-nil and the flow
+// running with the given context was shed by the FlowShedder, in which case
+// err is most likely a consequence of the flow's context being canceled.
+// Otherwise, err is returned unchanged.
+func MaybeReplaceWithShedError(ctx context.Context, err error) error {
+	if err == nil || IsFlowShedError(err) {
+		return err
+	}
+	if state, ok := ctx.Value(shedFlowStateKey{}).(*shedFlowState); ok && state.wasShed() {
+		return ErrFlowShed
+	}
+	return err
+}
+
+// maybeShed sheds the flows if the memory usage exceeds the threshold.
+func (s *FlowShedder) maybeShed(ctx context.Context) {
+	threshold := flowSheddingMemoryThreshold.Get(&s.st.SV)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Forget the flows that have finished. The flows that were shed are kept
+	// until they have released their memory so that we don't shed more flows
+	// than necessary while the already canceled ones are shutting down.
+	var candidates []*shedFlowState
+	var pendingRelease int64
+	for state := range s.mu.flows {
+		select {
+		case <-state.ctxDone:
+			if !state.wasShed() {
+				delete(s.mu.flows, state)
+				continue
+			}
+			allocated := state.flowMon.AllocBytes()
+			if allocated == 0 {
+				delete(s.mu.flows, state)
+				continue
+			}
+			pendingRelease += allocated
+		default:
+			candidates = append(candidates, state)
+		}
+	}
+	if threshold == 0 || len(candidates) == 0 {
+		return
+	}
+	limit := int64(threshold * float64(s.rootMon.Capacity()))
+	used := s.rootMon.AllocBytes() - pendingRelease
+	if used <= limit {
+		return
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i].admissionInfo, candidates[j].admissionInfo
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.CreateTime > b.CreateTime
+	})
+	for _, state := range candidates {
+		if used <= limit {
+			break
+		}
+		allocated := state.flowMon.AllocBytes()
+		if allocated == 0 {
+			// Canceling this flow wouldn't relieve the memory pressure.
+			continue
+		}
+		log.Warningf(ctx, "shedding a flow with priority %s using %d bytes because of memory pressure "+
+			"(%d bytes used, threshold %d bytes)",
+			state.admissionInfo.Priority, allocated, used, limit)
+		atomic.StoreInt32(&state.shed, 1)
+		state.cancel()
+		used -= allocated
+		if s.numShed != nil {
+			s.numShed.Inc(1)
+		}
+	}
+}
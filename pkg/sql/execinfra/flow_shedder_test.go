@@ -0,0 +1,109 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package execinfra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
+	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlowShedder(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	rootMon := mon.NewMonitor("root", mon.MemoryResource,
+		nil /* curCount */, nil /* maxHist */, 1 /* increment */, 1000 /* noteworthy */, st)
+	rootMon.Start(ctx, nil, mon.MakeStandaloneBudget(1000))
+	defer rootMon.Stop(ctx)
+	numShed := metric.NewCounter(metaFlowsShed)
+	s := NewFlowShedder(st, rootMon, numShed)
+
+	type testFlow struct {
+		ctx    context.Context
+		cancel context.CancelFunc
+		mon    *mon.BytesMonitor
+		acc    mon.BoundAccount
+	}
+	startFlow := func(priority admissionpb.WorkPriority, createTime int64, bytes int64) *testFlow {
+		f := &testFlow{}
+		f.mon = mon.NewMonitor("flow", mon.MemoryResource,
+			nil /* curCount */, nil /* maxHist */, 1 /* increment */, 1000 /* noteworthy */, st)
+		f.mon.Start(ctx, rootMon, mon.BoundAccount{})
+		f.acc = f.mon.MakeBoundAccount()
+		require.NoError(t, f.acc.Grow(ctx, bytes))
+		var flowCtx context.Context
+		flowCtx, f.cancel = context.WithCancel(ctx)
+		f.ctx = s.Register(
+			flowCtx, admission.WorkInfo{Priority: priority, CreateTime: createTime}, f.mon, f.cancel,
+		)
+		return f
+	}
+	stopFlow := func(f *testFlow) {
+		f.cancel()
+		f.acc.Close(ctx)
+		f.mon.Stop(ctx)
+	}
+	isShed := func(f *testFlow) bool {
+		return IsFlowShedError(MaybeReplaceWithShedError(f.ctx, errors.New("canceled")))
+	}
+
+	oldLow := startFlow(admissionpb.LowPri, 1 /* createTime */, 300)
+	normal := startFlow(admissionpb.NormalPri, 2 /* createTime */, 300)
+	newLow := startFlow(admissionpb.LowPri, 3 /* createTime */, 100)
+
+	// The shedding is disabled by default.
+	s.maybeShed(ctx)
+	require.Equal(t, int64(0), numShed.Count())
+
+	// The memory usage is below the threshold.
+	flowSheddingMemoryThreshold.Override(ctx, &st.SV, 0.9)
+	s.maybeShed(ctx)
+	require.Equal(t, int64(0), numShed.Count())
+
+	// 700 bytes are used, so two flows must be shed to get under 500 bytes:
+	// the low priority flows are shed first, the most recent one first.
+	flowSheddingMemoryThreshold.Override(ctx, &st.SV, 0.5)
+	s.maybeShed(ctx)
+	require.Equal(t, int64(2), numShed.Count())
+	require.True(t, isShed(newLow))
+	require.True(t, isShed(oldLow))
+	require.False(t, isShed(normal))
+	require.Error(t, newLow.ctx.Err())
+	require.Error(t, oldLow.ctx.Err())
+	require.NoError(t, normal.ctx.Err())
+
+	// The flows that were shed but haven't released their memory yet don't
+	// cause more flows to be shed.
+	s.maybeShed(ctx)
+	require.Equal(t, int64(2), numShed.Count())
+
+	// Errors are only replaced for the flows that were shed.
+	require.NoError(t, MaybeReplaceWithShedError(newLow.ctx, nil))
+	require.False(t, isShed(&testFlow{ctx: ctx}))
+
+	stopFlow(oldLow)
+	stopFlow(newLow)
+	stopFlow(normal)
+	s.maybeShed(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	require.Empty(t, s.mu.flows)
+}
@@ -0,0 +1,558 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package execinfra
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil/singleflight"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc"
+)
+
+var flowStreamMultiplexingEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.distsql.flow_stream_multiplexing.enabled",
+	"if set, the flow streams between the same pair of nodes are multiplexed "+
+		"over a single shared gRPC stream instead of using a gRPC stream each",
+	false,
+)
+
+// flowStreamMuxCredits is the number of messages that the producer is allowed
+// to send on a multiplexed flow stream before the consumer has consumed them.
+// The consumer grants more credits once it has consumed half of them.
+const flowStreamMuxCredits = 16
+
+// FlowStreamClient is the client (producer) side of a flow stream. It is
+// implemented by execinfrapb.DistSQL_FlowStreamClient as well as by the flow
+// streams multiplexed by the FlowStreamMuxer.
+type FlowStreamClient interface {
+	Send(*execinfrapb.ProducerMessage) error
+	Recv() (*execinfrapb.ConsumerSignal, error)
+	CloseSend() error
+}
+
+// FlowStreamMuxer opens the flow streams from this node to the other nodes.
+// When the multiplexing is enabled, all flow streams to the same node are
+// multiplexed over a single FlowStreamMux gRPC stream which is established
+// lazily and shared by all flows running on this node, which reduces the
+// overhead of setting up the flow streams as well as the number of the gRPC
+// streams on large clusters.
+//
+// Every multiplexed flow stream uses its own credit-based flow control, so a
+// slow consumer only blocks its own producer, and not the other flow streams
+// sharing the same gRPC stream.
+type FlowStreamMuxer struct {
+	st      *cluster.Settings
+	stopper *stop.Stopper
+	// dialGroup ensures that a single shared stream to each node is being
+	// established at a time.
+	dialGroup singleflight.Group
+	mu        struct {
+		syncutil.Mutex
+		conns map[base.SQLInstanceID]*muxClientConn
+	}
+}
+
+// NewFlowStreamMuxer returns a new FlowStreamMuxer.
+func NewFlowStreamMuxer(st *cluster.Settings, stopper *stop.Stopper) *FlowStreamMuxer {
+	m := &FlowStreamMuxer{st: st, stopper: stopper}
+	m.mu.conns = make(map[base.SQLInstanceID]*muxClientConn)
+	return m
+}
+
+// OpenFlowStream opens a flow stream to the given node using the given client.
+// If the multiplexing is disabled (or the muxer is nil), a separate FlowStream
+// gRPC stream is used, and ctx is used for that stream. Otherwise, the flow
+// stream is multiplexed over the stream shared with the other flow streams to
+// the same node, and the flow stream is abandoned once ctx is canceled.
+func (m *FlowStreamMuxer) OpenFlowStream(
+	ctx context.Context, client execinfrapb.DistSQLClient, sqlInstanceID base.SQLInstanceID,
+) (FlowStreamClient, error) {
+	if m == nil || !flowStreamMultiplexingEnabled.Get(&m.st.SV) {
+		return client.FlowStream(ctx)
+	}
+	conn, err := m.getConn(ctx, client, sqlInstanceID)
+	if err != nil {
+		return nil, err
+	}
+	return conn.open(ctx)
+}
+
+// getConn returns the shared stream to the given node, establishing it if
+// necessary.
+func (m *FlowStreamMuxer) getConn(
+	ctx context.Context, client execinfrapb.DistSQLClient, sqlInstanceID base.SQLInstanceID,
+) (*muxClientConn, error) {
+	m.mu.Lock()
+	if c, ok := m.mu.conns[sqlInstanceID]; ok {
+		m.mu.Unlock()
+		return c, nil
+	}
+	// The flight is joined while holding the lock so that it can't start
+	// after a concurrent flight has already added the shared stream, but the
+	// shared stream is established without holding the lock so that the
+	// flow streams to other nodes aren't blocked.
+	resC, _ := m.dialGroup.DoChan(sqlInstanceID.String(), func() (interface{}, error) {
+		return m.dial(client, sqlInstanceID)
+	})
+	m.mu.Unlock()
+	select {
+	case res := <-resC:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*muxClientConn), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// dial establishes the shared stream to the given node.
+func (m *FlowStreamMuxer) dial(
+	client execinfrapb.DistSQLClient, sqlInstanceID base.SQLInstanceID,
+) (*muxClientConn, error) {
+	// The shared stream outlives the flow streams that use it, so it is tied
+	// to the lifetime of the server. Its establishment is bounded by a timeout
+	// by canceling its context, unless it was established in time.
+	ctx, cancel := m.stopper.WithCancelOnQuiesce(context.Background())
+	dialTimer := time.AfterFunc(base.NetworkTimeout, cancel)
+	stream, err := client.FlowStreamMux(ctx)
+	if !dialTimer.Stop() {
+		// The timer has fired and canceled the context.
+		err = errors.Newf("timed out establishing the flow stream mux to n%d", sqlInstanceID)
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	c := &muxClientConn{muxer: m, sqlInstanceID: sqlInstanceID, stream: stream, cancel: cancel}
+	c.mu.streams = make(map[uint64]*muxClientStream)
+	// The shared stream is added before the receiving loop is started so that
+	// it is removed if the loop fails right away.
+	m.mu.Lock()
+	m.mu.conns[sqlInstanceID] = c
+	m.mu.Unlock()
+	if err := m.stopper.RunAsyncTask(ctx, "flow-stream-mux-client", c.recvLoop); err != nil {
+		c.fail(err)
+		return nil, err
+	}
+	return c, nil
+}
+
+// muxClientConn is the client side of a FlowStreamMux stream.
+type muxClientConn struct {
+	muxer         *FlowStreamMuxer
+	sqlInstanceID base.SQLInstanceID
+	stream        execinfrapb.DistSQL_FlowStreamMuxClient
+	cancel        context.CancelFunc
+	// sendMu serializes the calls to stream.Send.
+	sendMu syncutil.Mutex
+	mu     struct {
+		syncutil.Mutex
+		lastTag uint64
+		streams map[uint64]*muxClientStream
+		// err, if set, is the error that broke the shared stream.
+		err error
+	}
+}
+
+func (c *muxClientConn) send(msg *execinfrapb.MuxedProducerMessage) error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	return c.stream.Send(msg)
+}
+
+// open opens a new flow stream multiplexed over the shared stream.
+func (c *muxClientConn) open(ctx context.Context) (*muxClientStream, error) {
+	s := &muxClientStream{
+		conn:             c,
+		ctx:              ctx,
+		credits:          make(chan struct{}, flowStreamMuxCredits),
+		signalsAvailable: make(chan struct{}, 1),
+		done:             make(chan struct{}),
+	}
+	for i := 0; i < flowStreamMuxCredits; i++ {
+		s.credits <- struct{}{}
+	}
+	c.mu.Lock()
+	if err := c.mu.err; err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+	c.mu.lastTag++
+	s.tag = c.mu.lastTag
+	c.mu.streams[s.tag] = s
+	c.mu.Unlock()
+	if err := c.muxer.stopper.RunAsyncTask(ctx, "flow-stream-mux-stream", func(ctx context.Context) {
+		select {
+		case <-ctx.Done():
+			// Let the consumer know that the flow stream has been abandoned.
+			_ = c.send(&execinfrapb.MuxedProducerMessage{StreamTag: s.tag, Cancel: true})
+			c.finish(s.tag, ctx.Err())
+		case <-s.done:
+		}
+	}); err != nil {
+		c.finish(s.tag, err)
+		return nil, err
+	}
+	return s, nil
+}
+
+// finish terminates the given flow stream with the given error (io.EOF if the
+// flow stream terminated gracefully).
+func (c *muxClientConn) finish(tag uint64, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.mu.streams[tag]
+	if !ok {
+		return
+	}
+	delete(c.mu.streams, tag)
+	s.err = err
+	close(s.done)
+}
+
+// fail terminates all flow streams once the shared stream is broken.
+func (c *muxClientConn) fail(err error) {
+	if err == io.EOF {
+		// The server never closes the shared stream gracefully, so this is not
+		// a graceful termination of the flow streams.
+		err = errors.New("flow stream mux unexpectedly closed by the server")
+	}
+	c.muxer.mu.Lock()
+	if c.muxer.mu.conns[c.sqlInstanceID] == c {
+		delete(c.muxer.mu.conns, c.sqlInstanceID)
+	}
+	c.muxer.mu.Unlock()
+	c.mu.Lock()
+	c.mu.err = err
+	for tag, s := range c.mu.streams {
+		delete(c.mu.streams, tag)
+		s.err = err
+		close(s.done)
+	}
+	c.mu.Unlock()
+	c.cancel()
+}
+
+// recvLoop receives the consumer signals from the shared stream and dispatches
+// them to the flow streams.
+func (c *muxClientConn) recvLoop(ctx context.Context) {
+	for {
+		msg, err := c.stream.Recv()
+		if err != nil {
+			c.fail(err)
+			return
+		}
+		c.mu.Lock()
+		s, ok := c.mu.streams[msg.StreamTag]
+		c.mu.Unlock()
+		if !ok {
+			// The flow stream has already been terminated.
+			continue
+		}
+		for i := int32(0); i < msg.Credits; i++ {
+			select {
+			case s.credits <- struct{}{}:
+			default:
+				// The consumer granted more credits than it should have.
+			}
+		}
+		if msg.Signal != nil {
+			// The signal is queued without blocking so that a producer that
+			// doesn't receive its signals doesn't block the other flow streams.
+			s.pushSignal(msg.Signal)
+		}
+		if msg.Done {
+			err := io.EOF
+			if msg.Error != nil {
+				err = msg.Error.ErrorDetail(ctx)
+			}
+			c.finish(msg.StreamTag, err)
+		}
+	}
+}
+
+// muxClientStream is a flow stream multiplexed over a shared stream.
+type muxClientStream struct {
+	conn *muxClientConn
+	tag  uint64
+	ctx  context.Context
+	// credits contains a token for every message that the producer is allowed
+	// to send.
+	credits chan struct{}
+	// signalsMu protects the consumer signals that have been received on the
+	// shared stream but not yet by Recv. There are very few signals on every
+	// flow stream, so they aren't bounded.
+	signalsMu struct {
+		syncutil.Mutex
+		pending []*execinfrapb.ConsumerSignal
+	}
+	// signalsAvailable is notified, without blocking, whenever a signal is
+	// added to signalsMu.pending.
+	signalsAvailable chan struct{}
+	// done is closed once the flow stream is terminated, with err set.
+	done chan struct{}
+	err  error
+}
+
+// pushSignal queues the given consumer signal to be returned by Recv.
+func (s *muxClientStream) pushSignal(sig *execinfrapb.ConsumerSignal) {
+	s.signalsMu.Lock()
+	s.signalsMu.pending = append(s.signalsMu.pending, sig)
+	s.signalsMu.Unlock()
+	select {
+	case s.signalsAvailable <- struct{}{}:
+	default:
+	}
+}
+
+// popSignal returns the oldest queued consumer signal, if any.
+func (s *muxClientStream) popSignal() (*execinfrapb.ConsumerSignal, bool) {
+	s.signalsMu.Lock()
+	defer s.signalsMu.Unlock()
+	if len(s.signalsMu.pending) == 0 {
+		return nil, false
+	}
+	sig := s.signalsMu.pending[0]
+	s.signalsMu.pending[0] = nil
+	s.signalsMu.pending = s.signalsMu.pending[1:]
+	return sig, true
+}
+
+var _ FlowStreamClient = &muxClientStream{}
+
+// Send is part of the FlowStreamClient interface.
+func (s *muxClientStream) Send(msg *execinfrapb.ProducerMessage) error {
+	select {
+	case <-s.credits:
+	case <-s.done:
+		// Similar to gRPC, io.EOF is returned if the stream was terminated by
+		// the consumer, and the error can be discovered with Recv.
+		return io.EOF
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+	return s.conn.send(&execinfrapb.MuxedProducerMessage{StreamTag: s.tag, Message: msg})
+}
+
+// Recv is part of the FlowStreamClient interface.
+func (s *muxClientStream) Recv() (*execinfrapb.ConsumerSignal, error) {
+	for {
+		if sig, ok := s.popSignal(); ok {
+			return sig, nil
+		}
+		select {
+		case <-s.signalsAvailable:
+		case <-s.done:
+			// Make sure that the signals received before the termination are
+			// not lost.
+			if sig, ok := s.popSignal(); ok {
+				return sig, nil
+			}
+			return nil, s.err
+		}
+	}
+}
+
+// CloseSend is part of the FlowStreamClient interface.
+func (s *muxClientStream) CloseSend() error {
+	select {
+	case <-s.done:
+		return nil
+	default:
+	}
+	return s.conn.send(&execinfrapb.MuxedProducerMessage{StreamTag: s.tag, CloseSend: true})
+}
+
+// ServeFlowStreamMux serves the flow streams multiplexed over the given
+// FlowStreamMux stream. handler is called for every flow stream in a separate
+// goroutine, and ServeFlowStreamMux returns once the shared stream has been
+// closed by the client and all handlers have returned.
+func ServeFlowStreamMux(
+	stream execinfrapb.DistSQL_FlowStreamMuxServer,
+	handler func(execinfrapb.DistSQL_FlowStreamServer) error,
+) error {
+	c := &muxServerConn{stream: stream, handler: handler}
+	c.mu.streams = make(map[uint64]*muxServerStream)
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			// Abandon all flow streams and wait for their handlers: the shared
+			// stream can't be used once this RPC handler returns.
+			cancel()
+			c.wg.Wait()
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		c.dispatch(ctx, msg)
+	}
+}
+
+// muxServerConn is the server side of a FlowStreamMux stream.
+type muxServerConn struct {
+	stream  execinfrapb.DistSQL_FlowStreamMuxServer
+	handler func(execinfrapb.DistSQL_FlowStreamServer) error
+	wg      sync.WaitGroup
+	// sendMu serializes the calls to stream.Send.
+	sendMu syncutil.Mutex
+	mu     struct {
+		syncutil.Mutex
+		streams map[uint64]*muxServerStream
+	}
+}
+
+func (c *muxServerConn) send(msg *execinfrapb.MuxedConsumerSignal) error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	return c.stream.Send(msg)
+}
+
+// dispatch dispatches the message received on the shared stream to the flow
+// stream it belongs to, starting a new flow stream if necessary.
+func (c *muxServerConn) dispatch(ctx context.Context, msg *execinfrapb.MuxedProducerMessage) {
+	c.mu.Lock()
+	s, ok := c.mu.streams[msg.StreamTag]
+	if !ok {
+		if msg.Message == nil || msg.Message.Header == nil {
+			// The flow stream has already been terminated.
+			c.mu.Unlock()
+			return
+		}
+		s = &muxServerStream{
+			ServerStream: c.stream,
+			conn:         c,
+			tag:          msg.StreamTag,
+			msgs:         make(chan *execinfrapb.ProducerMessage, flowStreamMuxCredits),
+		}
+		s.ctx, s.cancel = context.WithCancel(ctx)
+		c.mu.streams[s.tag] = s
+		c.wg.Add(1)
+		go c.serve(s)
+	}
+	c.mu.Unlock()
+	if msg.Message != nil && !s.sendClosed {
+		select {
+		case s.msgs <- msg.Message:
+		default:
+			// The producer sent more messages than it was allowed to, so the
+			// flow stream is abandoned.
+			s.cancel()
+		}
+	}
+	if msg.CloseSend && !s.sendClosed {
+		s.sendClosed = true
+		close(s.msgs)
+	}
+	if msg.Cancel {
+		s.cancel()
+	}
+}
+
+// serve runs the handler for the given flow stream.
+func (c *muxServerConn) serve(s *muxServerStream) {
+	defer c.wg.Done()
+	err := c.handler(s)
+	s.cancel()
+	c.mu.Lock()
+	delete(c.mu.streams, s.tag)
+	c.mu.Unlock()
+	resp := &execinfrapb.MuxedConsumerSignal{StreamTag: s.tag, Done: true}
+	if err != nil {
+		resp.Error = execinfrapb.NewError(s.ctx, err)
+	}
+	// If the shared stream is broken, the client will learn about it anyway.
+	_ = c.send(resp)
+}
+
+// muxServerStream is the server side of a flow stream multiplexed over a
+// shared stream.
+type muxServerStream struct {
+	// ServerStream is the shared stream. It is embedded only to provide the
+	// metadata methods of grpc.ServerStream.
+	grpc.ServerStream
+	conn   *muxServerConn
+	tag    uint64
+	ctx    context.Context
+	cancel context.CancelFunc
+	// msgs contains the messages received on the flow stream. It is closed
+	// once the producer has called CloseSend.
+	msgs chan *execinfrapb.ProducerMessage
+	// sendClosed is set once msgs has been closed. It is only accessed by the
+	// goroutine dispatching the messages.
+	sendClosed bool
+	// consumed is the number of messages consumed since the last credits were
+	// granted. It is only accessed by the goroutine calling Recv.
+	consumed int32
+}
+
+var _ execinfrapb.DistSQL_FlowStreamServer = &muxServerStream{}
+
+// Context is part of the grpc.ServerStream interface.
+func (s *muxServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// Send is part of the execinfrapb.DistSQL_FlowStreamServer interface.
+func (s *muxServerStream) Send(sig *execinfrapb.ConsumerSignal) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	return s.conn.send(&execinfrapb.MuxedConsumerSignal{StreamTag: s.tag, Signal: sig})
+}
+
+// Recv is part of the execinfrapb.DistSQL_FlowStreamServer interface.
+func (s *muxServerStream) Recv() (*execinfrapb.ProducerMessage, error) {
+	select {
+	case msg, ok := <-s.msgs:
+		if !ok {
+			return nil, io.EOF
+		}
+		s.consumed++
+		if s.consumed >= flowStreamMuxCredits/2 {
+			if err := s.conn.send(&execinfrapb.MuxedConsumerSignal{
+				StreamTag: s.tag, Credits: s.consumed,
+			}); err != nil {
+				return nil, err
+			}
+			s.consumed = 0
+		}
+		return msg, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+// SendMsg is part of the grpc.ServerStream interface. The messages must be
+// sent with Send.
+func (s *muxServerStream) SendMsg(interface{}) error {
+	return errors.AssertionFailedf("SendMsg is not supported on multiplexed flow streams")
+}
+
+// RecvMsg is part of the grpc.ServerStream interface. The messages must be
+// received with Recv.
+func (s *muxServerStream) RecvMsg(interface{}) error {
+	return errors.AssertionFailedf("RecvMsg is not supported on multiplexed flow streams")
+}
@@ -0,0 +1,219 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package execinfra
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// muxPipe connects the client and the server sides of a FlowStreamMux stream
+// in memory.
+type muxPipe struct {
+	ctx          context.Context
+	toServer     chan *execinfrapb.MuxedProducerMessage
+	toClient     chan *execinfrapb.MuxedConsumerSignal
+	serverResult chan error
+}
+
+type muxPipeClient struct {
+	grpc.ClientStream
+	p *muxPipe
+}
+
+func (c *muxPipeClient) Send(msg *execinfrapb.MuxedProducerMessage) error {
+	select {
+	case c.p.toServer <- msg:
+		return nil
+	case <-c.p.ctx.Done():
+		return c.p.ctx.Err()
+	}
+}
+
+func (c *muxPipeClient) Recv() (*execinfrapb.MuxedConsumerSignal, error) {
+	select {
+	case msg := <-c.p.toClient:
+		return msg, nil
+	case <-c.p.ctx.Done():
+		return nil, c.p.ctx.Err()
+	}
+}
+
+type muxPipeServer struct {
+	grpc.ServerStream
+	p *muxPipe
+}
+
+func (s *muxPipeServer) Context() context.Context {
+	return s.p.ctx
+}
+
+func (s *muxPipeServer) Send(msg *execinfrapb.MuxedConsumerSignal) error {
+	select {
+	case s.p.toClient <- msg:
+		return nil
+	case <-s.p.ctx.Done():
+		return s.p.ctx.Err()
+	}
+}
+
+func (s *muxPipeServer) Recv() (*execinfrapb.MuxedProducerMessage, error) {
+	select {
+	case msg := <-s.p.toServer:
+		return msg, nil
+	case <-s.p.ctx.Done():
+		return nil, io.EOF
+	}
+}
+
+// muxPipeDistSQLClient implements FlowStreamMux by serving the stream in
+// memory with the given handler.
+type muxPipeDistSQLClient struct {
+	execinfrapb.DistSQLClient
+	handler func(execinfrapb.DistSQL_FlowStreamServer) error
+	mu      struct {
+		syncutil.Mutex
+		pipes []*muxPipe
+	}
+}
+
+func (c *muxPipeDistSQLClient) FlowStreamMux(
+	ctx context.Context, _ ...grpc.CallOption,
+) (execinfrapb.DistSQL_FlowStreamMuxClient, error) {
+	p := &muxPipe{
+		ctx:          ctx,
+		toServer:     make(chan *execinfrapb.MuxedProducerMessage),
+		toClient:     make(chan *execinfrapb.MuxedConsumerSignal),
+		serverResult: make(chan error, 1),
+	}
+	go func() {
+		p.serverResult <- ServeFlowStreamMux(&muxPipeServer{p: p}, c.handler)
+	}()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mu.pipes = append(c.mu.pipes, p)
+	return &muxPipeClient{p: p}, nil
+}
+
+func TestFlowStreamMux(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	st := cluster.MakeTestingClusterSettings()
+	flowStreamMultiplexingEnabled.Override(ctx, &st.SV, true)
+	m := NewFlowStreamMuxer(st, stopper)
+
+	const numMessages = 5 * flowStreamMuxCredits
+	const failingStreamID = 3
+	// The handler sends a handshake and then consumes all messages (without
+	// sending any more signals) unless the stream has failingStreamID.
+	client := &muxPipeDistSQLClient{}
+	client.handler = func(stream execinfrapb.DistSQL_FlowStreamServer) error {
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if msg.Header.StreamID == failingStreamID {
+			return errors.New("boom")
+		}
+		if err := stream.Send(&execinfrapb.ConsumerSignal{
+			Handshake: &execinfrapb.ConsumerHandshake{ConsumerScheduled: true},
+		}); err != nil {
+			return err
+		}
+		for i := 0; ; i++ {
+			if _, err := stream.Recv(); err != nil {
+				if err == io.EOF && i == numMessages {
+					return nil
+				}
+				return errors.Newf("unexpected error after %d messages: %v", i, err)
+			}
+		}
+	}
+	openStream := func(ctx context.Context, streamID execinfrapb.StreamID) FlowStreamClient {
+		stream, err := m.OpenFlowStream(ctx, client, 1 /* sqlInstanceID */)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&execinfrapb.ProducerMessage{
+			Header: &execinfrapb.ProducerHeader{StreamID: streamID},
+		}))
+		return stream
+	}
+
+	// Run two flow streams concurrently. Each sends more messages than the
+	// initial credits, so they rely on the consumer to grant more credits.
+	errCh := make(chan error, 2)
+	for streamID := 1; streamID <= 2; streamID++ {
+		stream := openStream(ctx, execinfrapb.StreamID(streamID))
+		go func() {
+			errCh <- func() error {
+				for i := 0; i < numMessages; i++ {
+					if err := stream.Send(&execinfrapb.ProducerMessage{}); err != nil {
+						return err
+					}
+				}
+				if err := stream.CloseSend(); err != nil {
+					return err
+				}
+				sig, err := stream.Recv()
+				if err != nil {
+					return err
+				}
+				if sig.Handshake == nil {
+					return errors.Newf("unexpected signal %v", sig)
+				}
+				if _, err := stream.Recv(); err != io.EOF {
+					return errors.Newf("expected io.EOF, got %v", err)
+				}
+				return nil
+			}()
+		}()
+	}
+	for i := 0; i < 2; i++ {
+		require.NoError(t, <-errCh)
+	}
+
+	// The error returned by the handler is propagated to the producer.
+	stream := openStream(ctx, failingStreamID)
+	_, err := stream.Recv()
+	require.Regexp(t, "boom", err)
+
+	// Canceling the context of the producer abandons the flow stream.
+	canceledCtx, cancel := context.WithCancel(ctx)
+	stream = openStream(canceledCtx, 4 /* streamID */)
+	cancel()
+	var recvErr error
+	for recvErr == nil {
+		// The handshake might have been received before the cancellation.
+		_, recvErr = stream.Recv()
+	}
+	require.True(t, errors.Is(recvErr, context.Canceled))
+
+	// All flow streams shared the same gRPC stream.
+	client.mu.Lock()
+	pipes := client.mu.pipes
+	client.mu.Unlock()
+	require.Len(t, pipes, 1)
+
+	// Stopping the server closes the shared stream.
+	stopper.Stop(ctx)
+	require.NoError(t, <-pipes[0].serverResult)
+}
@@ -26,6 +26,7 @@ type DistSQLMetrics struct {
 	FlowsTotal            *metric.Counter
 	FlowsQueued           *metric.Gauge
 	FlowsScheduled        *metric.Counter
+	FlowsShed             *metric.Counter
 	QueueWaitHist         *metric.Histogram
 	MaxBytesHist          *metric.Histogram
 	CurBytesCount         *metric.Gauge
@@ -85,6 +86,12 @@ var (
 		Measurement: "Flows",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaFlowsShed = metric.Metadata{
+		Name:        "sql.distsql.flows.shed",
+		Help:        "Number of distributed SQL flows canceled because of memory pressure",
+		Measurement: "Flows",
+		Unit:        metric.Unit_COUNT,
+	}
 	metaQueueWaitHist = metric.Metadata{
 		Name:        "sql.distsql.flows.queue_wait",
 		Help:        "Duration of time flows spend waiting in the queue",
@@ -155,6 +162,7 @@ func MakeDistSQLMetrics(histogramWindow time.Duration) DistSQLMetrics {
 		FlowsTotal:            metric.NewCounter(metaFlowsTotal),
 		FlowsQueued:           metric.NewGauge(metaFlowsQueued),
 		FlowsScheduled:        metric.NewCounter(metaFlowsScheduled),
+		FlowsShed:             metric.NewCounter(metaFlowsShed),
 		QueueWaitHist:         metric.NewLatency(metaQueueWaitHist, histogramWindow),
 		MaxBytesHist:          metric.NewHistogram(metaMemMaxBytes, histogramWindow, log10int64times1000, 3),
 		CurBytesCount:         metric.NewGauge(metaMemCurBytes),
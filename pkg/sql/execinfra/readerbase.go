@@ -13,6 +13,7 @@ package execinfra
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
@@ -126,6 +127,19 @@ func (s *SpansWithCopy) Reset() {
 	s.SpansCopy = s.SpansCopy[:0]
 }
 
+// SampleSpans returns the spans that are kept by the SYSTEM sampling of a
+// TableReader where each of the given spans is kept independently with the
+// given probability. The given spans are not modified.
+func SampleSpans(rng *rand.Rand, spans roachpb.Spans, probability float64) roachpb.Spans {
+	var sampled roachpb.Spans
+	for _, span := range spans {
+		if rng.Float64() < probability {
+			sampled = append(sampled, span)
+		}
+	}
+	return sampled
+}
+
 // limitHintBatchCount tracks how many times the caller has read LimitHint()
 // number of rows.
 type limitHintBatchCount int
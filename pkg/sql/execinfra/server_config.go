@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/rpc"
 	"github.com/cockroachdb/cockroach/pkg/rpc/nodedialer"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
@@ -41,6 +42,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/limit"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/errors"
 	"github.com/marusama/semaphore"
@@ -118,6 +120,26 @@ type ServerConfig struct {
 	// the processes in a given sql server when sending bulk ingest (AddSST) reqs.
 	BulkSenderLimiter limit.ConcurrentRequestLimiter
 
+	// ColBatchScanLimiter is the concurrency limiter that is shared across all
+	// of the ColBatchScans in a given sql server. It can be nil in which case
+	// the number of concurrent scans is not limited.
+	ColBatchScanLimiter *limit.ConcurrentRequestLimiter
+
+	// TableReadTracker tracks the number of bytes read from each table by the
+	// scans in a given sql server. It can be nil in which case the reads are
+	// not tracked and no per-table read quotas are enforced.
+	TableReadTracker TableReadTracker
+
+	// VectorizedFallbacks records the reasons for which the vectorized engine
+	// fell back to the row-by-row execution in a given sql server. It can be
+	// nil in which case the fallbacks are not recorded.
+	VectorizedFallbacks VectorizedFallbackRecorder
+
+	// KVResponseQuota is the quota pool that limits the number of bytes of KV
+	// responses in flight across all of the fetchers in a given sql server. It
+	// can be nil in which case the bytes in flight are not limited.
+	KVResponseQuota *quotapool.IntPool
+
 	// ParentDiskMonitor is normally the root disk monitor. It should only be used
 	// when setting up a server, a child monitor (usually belonging to a sql
 	// execution flow), or in tests. It is used to monitor temporary storage disk
@@ -149,6 +171,10 @@ type ServerConfig struct {
 	// Dialer for communication between SQL nodes/pods.
 	PodNodeDialer *nodedialer.Dialer
 
+	// FlowStreamMuxer is used by the outboxes to open the flow streams to the
+	// other nodes. It is initialized by the DistSQL server.
+	FlowStreamMuxer *FlowStreamMuxer
+
 	// SessionBoundInternalExecutorFactory is used to construct session-bound
 	// executors. The idea is that a higher-layer binds some of the arguments
 	// required, so that users of ServerConfig don't have to care about them.
@@ -182,6 +208,11 @@ type ServerConfig struct {
 	// SQLSQLResponseWork.
 	SQLSQLResponseAdmissionQ *admission.WorkQueue
 
+	// KVAdmissionQ is the admission queue of the KVWork of the node. The bulk
+	// processors throttle themselves while it's overloaded. It is nil if the
+	// node doesn't have a KV layer.
+	KVAdmissionQ *admission.WorkQueue
+
 	// CollectionFactory is used to construct descs.Collections.
 	CollectionFactory *descs.CollectionFactory
 
@@ -198,6 +229,31 @@ type RuntimeStats interface {
 	GetCPUCombinedPercentNorm() float64
 }
 
+// TableReadTracker accumulates the number of bytes read by the scans from each
+// table on a single node and determines whether a table has exceeded its read
+// quota, in which case the scans of that table should be deprioritized by
+// admission control.
+type TableReadTracker interface {
+	// RecordBytesRead adds the given number of bytes to the amount read from
+	// the table.
+	RecordBytesRead(tableID descpb.ID, bytes int64)
+	// ExceedsQuota returns whether the amount of bytes recently read from the
+	// table exceeds the per-table read quota.
+	ExceedsQuota(tableID descpb.ID) bool
+}
+
+// VectorizedFallbackRecorder accumulates the information about the cases when
+// the vectorized engine couldn't execute a part of a flow natively on a single
+// node.
+type VectorizedFallbackRecorder interface {
+	// RecordFallback records that the given component (a processor core or an
+	// output router) couldn't be planned natively because of cause. wrapped
+	// indicates whether the corresponding row-execution processor was wrapped
+	// into the vectorized flow or whether the whole flow fell back to the
+	// row-by-row engine.
+	RecordFallback(wrapped bool, component string, cause error)
+}
+
 // TestingKnobs are the testing knobs.
 type TestingKnobs struct {
 	// RunBeforeBackfillChunk is called before executing each chunk of a
@@ -64,7 +64,7 @@ import "github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 //
 // ATTENTION: When updating these fields, add a brief description of what
 // changed to the version history below.
-const Version execinfrapb.DistSQLVersion = 68
+const Version execinfrapb.DistSQLVersion = 71
 
 // MinAcceptedVersion is the oldest version that the server is compatible with.
 // A server will not accept flows with older versions.
@@ -76,6 +76,29 @@ const MinAcceptedVersion execinfrapb.DistSQLVersion = 68
 
 Please add new entries at the top.
 
+- Version: 71 (MinAcceptedVersion: 68)
+  - The FlowStreamMux RPC was introduced. The outboxes of a server running
+    v71 might use it to reach the inboxes on other servers, which an older
+    server wouldn't recognize, hence the version bump. However, a server
+    running v71 can still process all plans from servers running v68, thus
+    the MinAcceptedVersion is kept at 68.
+
+- Version: 70 (MinAcceptedVersion: 68)
+  - SetupFlowRequest has new flow_spec_digest and flow_spec_cached fields
+    which allow the gateway to omit the processors of the flows that were
+    already set up on the remote node. A server running older versions would
+    ignore them and set up an empty flow, hence the version bump. However, a
+    server running v70 can still process all plans from servers running v68,
+    thus the MinAcceptedVersion is kept at 68.
+
+- Version: 69 (MinAcceptedVersion: 68)
+  - OutputRouterSpec has new region_column and stream_regions fields which
+    make the hash router co-locate the hash buckets by region. A server
+    running older versions would ignore them and route the rows differently
+    from the other routers of the same stage, hence the version bump.
+    However, a server running v69 can still process all plans from servers
+    running v68, thus the MinAcceptedVersion is kept at 68.
+
 - Version: 68 (MinAcceptedVersion: 68)
   - ZigzagJoinerSpec now uses descpb.IndexFetchSpec instead of table and
     index descriptors.
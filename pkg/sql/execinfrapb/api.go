@@ -11,12 +11,14 @@
 package execinfrapb
 
 import (
+	"crypto/sha256"
 	"strconv"
 
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
 )
 
 // ProcessorID identifies a processor in the context of a specific flow.
@@ -50,6 +52,34 @@ func (f FlowID) IsUnset() bool {
 // DistSQLVersion identifies DistSQL engine versions.
 type DistSQLVersion uint32
 
+// ErrFlowSpecNotCached is returned by SetupFlow when the request omits the
+// processors of the flow (see SetupFlowRequest.FlowSpecCached), but the server
+// doesn't have them.
+var ErrFlowSpecNotCached = errors.New("flow spec is not cached")
+
+// MarshalFlowSpecProcessors returns the encoding of the processors of the given
+// flow spec, along with its digest. The FlowID and the Gateway of the spec are
+// not included, so the digest identifies the flows that execute the same plan.
+func MarshalFlowSpecProcessors(spec *FlowSpec) (data []byte, digest []byte, _ error) {
+	processors := FlowSpec{Processors: spec.Processors}
+	data, err := processors.Marshal()
+	if err != nil {
+		return nil, nil, err
+	}
+	sum := sha256.Sum256(data)
+	return data, sum[:], nil
+}
+
+// UnmarshalFlowSpecProcessors decodes the processors encoded with
+// MarshalFlowSpecProcessors.
+func UnmarshalFlowSpecProcessors(data []byte) ([]ProcessorSpec, error) {
+	var processors FlowSpec
+	if err := processors.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	return processors.Processors, nil
+}
+
 // MakeEvalContext serializes some of the fields of a eval.Context into a
 // execinfrapb.EvalContext proto.
 func MakeEvalContext(evalCtx *eval.Context) EvalContext {
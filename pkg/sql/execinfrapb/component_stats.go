@@ -13,6 +13,7 @@ package execinfrapb
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
@@ -145,12 +146,24 @@ func (s *ComponentStats) formatStats(fn func(suffix string, value interface{}))
 	if s.KV.ContentionTime.HasValue() {
 		fn("KV contention time", humanizeutil.Duration(s.KV.ContentionTime.Value()))
 	}
+	if s.KV.ScanQueueWaitTime.HasValue() {
+		fn("KV scan queue wait time", humanizeutil.Duration(s.KV.ScanQueueWaitTime.Value()))
+	}
 	if s.KV.TuplesRead.HasValue() {
 		fn("KV rows read", humanizeutil.Count(s.KV.TuplesRead.Value()))
 	}
 	if s.KV.BytesRead.HasValue() {
 		fn("KV bytes read", humanize.IBytes(s.KV.BytesRead.Value()))
 	}
+	if s.KV.ScanMaxMemUsage.HasValue() {
+		fn("KV scan max memory usage", humanize.IBytes(s.KV.ScanMaxMemUsage.Value()))
+	}
+	if s.KV.NumLeaseRedirects.HasValue() {
+		fn("KV lease redirects", fmt.Sprintf("%s (ranges: %s)",
+			humanizeutil.Count(s.KV.NumLeaseRedirects.Value()),
+			formatRangeIDs(s.KV.LeaseRedirectRangeIDs)),
+		)
+	}
 	if s.KV.NumInterfaceSteps.HasValue() {
 		fn("MVCC step count (ext/int)",
 			fmt.Sprintf("%s/%s",
@@ -170,6 +183,9 @@ func (s *ComponentStats) formatStats(fn func(suffix string, value interface{}))
 	if s.Exec.ExecTime.HasValue() {
 		fn("execution time", humanizeutil.Duration(s.Exec.ExecTime.Value()))
 	}
+	if s.Exec.CPUTime.HasValue() {
+		fn("sql cpu time", humanizeutil.Duration(s.Exec.CPUTime.Value()))
+	}
 	if s.Exec.MaxAllocatedMem.HasValue() {
 		fn("max memory allocated", humanize.IBytes(s.Exec.MaxAllocatedMem.Value()))
 	}
@@ -184,6 +200,22 @@ func (s *ComponentStats) formatStats(fn func(suffix string, value interface{}))
 	if s.Output.NumTuples.HasValue() {
 		fn("rows output", humanizeutil.Count(s.Output.NumTuples.Value()))
 	}
+	if s.Output.BlockedTime.HasValue() {
+		fn("output blocked time", humanizeutil.Duration(s.Output.BlockedTime.Value()))
+	}
+}
+
+// formatRangeIDs formats the given range IDs as a comma-separated list, e.g.
+// "r1, r5".
+func formatRangeIDs(rangeIDs []int64) string {
+	var sb strings.Builder
+	for i, rangeID := range rangeIDs {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "r%d", rangeID)
+	}
+	return sb.String()
 }
 
 // Union creates a new ComponentStats that contains all statistics in either the
@@ -231,6 +263,16 @@ func (s *ComponentStats) Union(other *ComponentStats) *ComponentStats {
 	if !result.KV.ContentionTime.HasValue() {
 		result.KV.ContentionTime = other.KV.ContentionTime
 	}
+	if !result.KV.ScanQueueWaitTime.HasValue() {
+		result.KV.ScanQueueWaitTime = other.KV.ScanQueueWaitTime
+	}
+	if !result.KV.ScanMaxMemUsage.HasValue() {
+		result.KV.ScanMaxMemUsage = other.KV.ScanMaxMemUsage
+	}
+	if !result.KV.NumLeaseRedirects.HasValue() {
+		result.KV.NumLeaseRedirects = other.KV.NumLeaseRedirects
+		result.KV.LeaseRedirectRangeIDs = other.KV.LeaseRedirectRangeIDs
+	}
 	if !result.KV.NumInterfaceSteps.HasValue() {
 		result.KV.NumInterfaceSteps = other.KV.NumInterfaceSteps
 	}
@@ -254,6 +296,9 @@ func (s *ComponentStats) Union(other *ComponentStats) *ComponentStats {
 	if !result.Exec.ExecTime.HasValue() {
 		result.Exec.ExecTime = other.Exec.ExecTime
 	}
+	if !result.Exec.CPUTime.HasValue() {
+		result.Exec.CPUTime = other.Exec.CPUTime
+	}
 	if !result.Exec.MaxAllocatedMem.HasValue() {
 		result.Exec.MaxAllocatedMem = other.Exec.MaxAllocatedMem
 	}
@@ -268,6 +313,12 @@ func (s *ComponentStats) Union(other *ComponentStats) *ComponentStats {
 	if !result.Output.NumTuples.HasValue() {
 		result.Output.NumTuples = other.Output.NumTuples
 	}
+	if result.Output.SampledRows == nil {
+		result.Output.SampledRows = other.Output.SampledRows
+	}
+	if !result.Output.BlockedTime.HasValue() {
+		result.Output.BlockedTime = other.Output.BlockedTime
+	}
 
 	// Flow stats.
 	if !result.FlowStats.MaxMemUsage.HasValue() {
@@ -332,6 +383,14 @@ func (s *ComponentStats) MakeDeterministic() {
 	// KV.
 	timeVal(&s.KV.KVTime)
 	timeVal(&s.KV.ContentionTime)
+	timeVal(&s.KV.ScanQueueWaitTime)
+	resetUint(&s.KV.ScanMaxMemUsage)
+	if s.KV.NumLeaseRedirects.HasValue() {
+		// Lease redirects depend on the timing of lease transfers, so they are
+		// omitted altogether.
+		s.KV.NumLeaseRedirects.Clear()
+		s.KV.LeaseRedirectRangeIDs = nil
+	}
 	resetUint(&s.KV.NumInterfaceSteps)
 	resetUint(&s.KV.NumInternalSteps)
 	resetUint(&s.KV.NumInterfaceSeeks)
@@ -343,11 +402,13 @@ func (s *ComponentStats) MakeDeterministic() {
 
 	// Exec.
 	timeVal(&s.Exec.ExecTime)
+	timeVal(&s.Exec.CPUTime)
 	resetUint(&s.Exec.MaxAllocatedMem)
 	resetUint(&s.Exec.MaxAllocatedDisk)
 
 	// Output.
 	resetUint(&s.Output.NumBatches)
+	timeVal(&s.Output.BlockedTime)
 
 	// Inputs.
 	for i := range s.Inputs {
@@ -75,10 +75,12 @@ KV bytes read: 80 B`,
 					ExecTime:         optional.MakeTimeValue(time.Second),
 					MaxAllocatedMem:  optional.MakeUint(1024),
 					MaxAllocatedDisk: optional.MakeUint(1024),
+					CPUTime:          optional.MakeTimeValue(time.Second),
 				},
 			},
 			expected: `
 execution time: 0µs
+sql cpu time: 0µs
 max memory allocated: 0 B
 max sql temp disk usage: 0 B`,
 		},
@@ -104,6 +106,17 @@ rows output: 100`,
 input rows: 100
 input stall time: 0µs`,
 		},
+		{ // 7
+			stats: ComponentStats{
+				KV: KVStats{
+					TuplesRead:            optional.MakeUint(10),
+					NumLeaseRedirects:     optional.MakeUint(3),
+					LeaseRedirectRangeIDs: []int64{5, 7},
+				},
+			},
+			expected: `
+KV rows read: 10`,
+		},
 	}
 
 	for i, tc := range testCases {
@@ -309,6 +322,19 @@ max sql temp disk usage: 1.0 KiB
 batches output: 10
 rows output: 100`,
 		},
+		{ // 4
+			a: ComponentStats{},
+			b: ComponentStats{
+				KV: KVStats{
+					TuplesRead:            optional.MakeUint(10),
+					NumLeaseRedirects:     optional.MakeUint(3),
+					LeaseRedirectRangeIDs: []int64{5, 7},
+				},
+			},
+			expected: `
+KV rows read: 10
+KV lease redirects: 3 (ranges: r5, r7)`,
+		},
 	}
 
 	for i, tc := range testCases {
@@ -73,6 +73,24 @@ func ConvertToMappedSpecOrdering(
 	return specOrdering
 }
 
+// StreamsByRegion returns the indices of the streams located in each of the
+// regions of a BY_HASH router that co-locates the hash buckets by region (see
+// the comment on RegionColumn). The map is keyed by the physical
+// representation of the region enum values. nil is returned if the router
+// doesn't co-locate the hash buckets.
+func (spec *OutputRouterSpec) StreamsByRegion() map[string][]int {
+	if spec.RegionColumn == nil {
+		return nil
+	}
+	streamsByRegion := make(map[string][]int)
+	for i, region := range spec.StreamRegions {
+		if region != nil {
+			streamsByRegion[string(region)] = append(streamsByRegion[string(region)], i)
+		}
+	}
+	return streamsByRegion
+}
+
 // ExprFmtCtxBase produces a FmtCtx used for serializing expressions; a proper
 // IndexedVar formatting function needs to be added on. It replaces placeholders
 // with their values.
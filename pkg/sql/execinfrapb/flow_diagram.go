@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
@@ -159,8 +160,7 @@ func (tr *TableReaderSpec) summary() (string, []string) {
 	details = append(details, fmt.Sprintf("%s@%s", tr.FetchSpec.TableName, tr.FetchSpec.IndexName))
 	details = appendColumns(details, tr.FetchSpec.FetchedColumns)
 
-	if len(tr.Spans) > 0 {
-		// only show the first span
+	if len(tr.Spans) > 0 || len(tr.RemoteSpans) > 0 {
 		keyDirs := make([]encoding.Direction, len(tr.FetchSpec.KeyAndSuffixColumns))
 		for i := range keyDirs {
 			keyDirs[i] = encoding.Ascending
@@ -168,25 +168,34 @@ func (tr *TableReaderSpec) summary() (string, []string) {
 				keyDirs[i] = encoding.Descending
 			}
 		}
-
-		var spanStr strings.Builder
-		spanStr.WriteString("Spans: ")
-		spanStr.WriteString(catalogkeys.PrettySpan(keyDirs, tr.Spans[0], 2))
-
-		if len(tr.Spans) > 1 {
-			spanStr.WriteString(fmt.Sprintf(" and %d other", len(tr.Spans)-1))
+		if len(tr.Spans) > 0 {
+			details = append(details, spansSummary("Spans: ", keyDirs, tr.Spans))
 		}
-
-		if len(tr.Spans) > 2 {
-			spanStr.WriteString("s") // pluralize the 'other'
+		if len(tr.RemoteSpans) > 0 {
+			details = append(details, spansSummary("Remote spans: ", keyDirs, tr.RemoteSpans))
 		}
-
-		details = append(details, spanStr.String())
 	}
 
 	return "TableReader", details
 }
 
+// spansSummary returns the description of the given spans that only shows the
+// first span.
+func spansSummary(prefix string, keyDirs []encoding.Direction, spans roachpb.Spans) string {
+	var spanStr strings.Builder
+	spanStr.WriteString(prefix)
+	spanStr.WriteString(catalogkeys.PrettySpan(keyDirs, spans[0], 2))
+
+	if len(spans) > 1 {
+		spanStr.WriteString(fmt.Sprintf(" and %d other", len(spans)-1))
+	}
+
+	if len(spans) > 2 {
+		spanStr.WriteString("s") // pluralize the 'other'
+	}
+	return spanStr.String()
+}
+
 // summary implements the diagramCellType interface.
 func (jr *JoinReaderSpec) summary() (string, []string) {
 	details := make([]string, 0, 5)
@@ -11,6 +11,7 @@
 package execinfrapb
 
 import (
+	"reflect"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -58,7 +59,7 @@ func (a AggregatorSpec_Aggregation) Equals(b AggregatorSpec_Aggregation) bool {
 			return false
 		}
 	}
-	return true
+	return a.UserDefined.Equal(b.UserDefined)
 }
 
 // IsScalar returns whether the aggregate function is in scalar context.
@@ -365,3 +366,23 @@ func (spec *WindowerSpec_Frame) ConvertToAST() (*tree.WindowFrame, error) {
 func (spec *JoinReaderSpec) IsIndexJoin() bool {
 	return len(spec.LookupColumns) == 0 && spec.LookupExpr.Empty()
 }
+
+// Name returns the name of the processor core, for example "HashJoiner". Unlike
+// the output of String(), it doesn't depend on the contents of the core, so it
+// can be used to aggregate the information about the processors of the same
+// kind (e.g. in the telemetry). For LocalPlanNode cores the name of the wrapped
+// planNode is included.
+func (c *ProcessorCoreUnion) Name() string {
+	if c.LocalPlanNode != nil {
+		return "LocalPlanNode(" + c.LocalPlanNode.Name + ")"
+	}
+	v := c.GetValue()
+	if v == nil {
+		return "unknown"
+	}
+	name := reflect.TypeOf(v).Elem().Name()
+	if trimmed := strings.TrimSuffix(name, "CoreSpec"); trimmed != name {
+		return trimmed
+	}
+	return strings.TrimSuffix(name, "Spec")
+}
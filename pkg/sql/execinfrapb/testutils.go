@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/logtags"
 	"google.golang.org/grpc"
 )
@@ -105,6 +106,11 @@ func (ds *MockDistSQLServer) FlowStream(stream DistSQL_FlowStreamServer) error {
 	return <-donec
 }
 
+// FlowStreamMux is part of the DistSQLServer interface.
+func (ds *MockDistSQLServer) FlowStreamMux(stream DistSQL_FlowStreamMuxServer) error {
+	return errors.New("unimplemented")
+}
+
 // MockDialer is a mocked implementation of the Outbox's `Dialer` interface.
 // Used to create a connection with a client stream.
 type MockDialer struct {
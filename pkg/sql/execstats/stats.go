@@ -16,6 +16,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/optional"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	pbtypes "github.com/gogo/protobuf/types"
@@ -71,6 +72,20 @@ type ScanStats struct {
 	// NumInternalSeeks is the number of times that MVCC seek was invoked
 	// internally, including to step over internal, uncompacted Pebble versions.
 	NumInternalSeeks uint64
+	// QueueWaitTime is the amount of time the scan spent queued behind other
+	// scans before it was allowed to start reading from KV. It is not derived
+	// from the trace and is populated by the scan operator itself.
+	QueueWaitTime time.Duration
+	// MaxMemUsage is the peak memory usage of the scan operator, including the
+	// memory used by the KV fetcher. Like QueueWaitTime, it is populated by the
+	// scan operator itself.
+	MaxMemUsage int64
+	// NumLeaseRedirects is the number of times the KV requests of the scan were
+	// redirected to a new leaseholder.
+	NumLeaseRedirects uint64
+	// LeaseRedirectRangeIDs contains the IDs of the ranges whose leaseholder
+	// redirected the requests, sorted and without duplicates.
+	LeaseRedirectRangeIDs []roachpb.RangeID
 }
 
 // PopulateKVMVCCStats adds data from the input ScanStats to the input KVStats.
@@ -79,6 +94,19 @@ func PopulateKVMVCCStats(kvStats *execinfrapb.KVStats, ss *ScanStats) {
 	kvStats.NumInternalSteps = optional.MakeUint(ss.NumInternalSteps)
 	kvStats.NumInterfaceSeeks = optional.MakeUint(ss.NumInterfaceSeeks)
 	kvStats.NumInternalSeeks = optional.MakeUint(ss.NumInternalSeeks)
+	if ss.QueueWaitTime != 0 {
+		kvStats.ScanQueueWaitTime = optional.MakeTimeValue(ss.QueueWaitTime)
+	}
+	if ss.MaxMemUsage != 0 {
+		kvStats.ScanMaxMemUsage = optional.MakeUint(uint64(ss.MaxMemUsage))
+	}
+	if ss.NumLeaseRedirects != 0 {
+		kvStats.NumLeaseRedirects = optional.MakeUint(ss.NumLeaseRedirects)
+		kvStats.LeaseRedirectRangeIDs = make([]int64, len(ss.LeaseRedirectRangeIDs))
+		for i, rangeID := range ss.LeaseRedirectRangeIDs {
+			kvStats.LeaseRedirectRangeIDs[i] = int64(rangeID)
+		}
+	}
 }
 
 // GetScanStats is a helper function to calculate scan stats from the tracing
@@ -89,8 +117,18 @@ func GetScanStats(ctx context.Context) (ss ScanStats) {
 		return ScanStats{}
 	}
 	var ev roachpb.ScanStats
+	var redirectEv roachpb.LeaseRedirectEvent
+	var redirectRanges util.FastIntSet
 	for i := range recording {
 		recording[i].Structured(func(any *pbtypes.Any, _ time.Time) {
+			if pbtypes.Is(any, &redirectEv) {
+				if err := pbtypes.UnmarshalAny(any, &redirectEv); err != nil {
+					return
+				}
+				ss.NumLeaseRedirects++
+				redirectRanges.Add(int(redirectEv.RangeID))
+				return
+			}
 			if !pbtypes.Is(any, &ev) {
 				return
 			}
@@ -104,5 +142,8 @@ func GetScanStats(ctx context.Context) (ss ScanStats) {
 			ss.NumInternalSeeks += ev.NumInternalSeeks
 		})
 	}
+	redirectRanges.ForEach(func(rangeID int) {
+		ss.LeaseRedirectRangeIDs = append(ss.LeaseRedirectRangeIDs, roachpb.RangeID(rangeID))
+	})
 	return ss
 }
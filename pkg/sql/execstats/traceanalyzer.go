@@ -107,6 +107,7 @@ type NodeLevelStats struct {
 	KVTimeGroupedByNode           map[base.SQLInstanceID]time.Duration
 	NetworkMessagesGroupedByNode  map[base.SQLInstanceID]int64
 	ContentionTimeGroupedByNode   map[base.SQLInstanceID]time.Duration
+	CPUTimeGroupedByNode          map[base.SQLInstanceID]time.Duration
 }
 
 // QueryLevelStats returns all the query level stats that correspond to the
@@ -121,6 +122,7 @@ type QueryLevelStats struct {
 	KVTime           time.Duration
 	NetworkMessages  int64
 	ContentionTime   time.Duration
+	CPUTime          time.Duration
 	Regions          []string
 }
 
@@ -138,6 +140,7 @@ func (s *QueryLevelStats) Accumulate(other QueryLevelStats) {
 	s.KVTime += other.KVTime
 	s.NetworkMessages += other.NetworkMessages
 	s.ContentionTime += other.ContentionTime
+	s.CPUTime += other.CPUTime
 	s.Regions = util.CombineUniqueString(s.Regions, other.Regions)
 }
 
@@ -213,6 +216,7 @@ func (a *TraceAnalyzer) ProcessStats() error {
 		KVTimeGroupedByNode:           make(map[base.SQLInstanceID]time.Duration),
 		NetworkMessagesGroupedByNode:  make(map[base.SQLInstanceID]int64),
 		ContentionTimeGroupedByNode:   make(map[base.SQLInstanceID]time.Duration),
+		CPUTimeGroupedByNode:          make(map[base.SQLInstanceID]time.Duration),
 	}
 	var errs error
 
@@ -226,6 +230,7 @@ func (a *TraceAnalyzer) ProcessStats() error {
 		a.nodeLevelStats.KVRowsReadGroupedByNode[instanceID] += int64(stats.KV.TuplesRead.Value())
 		a.nodeLevelStats.KVTimeGroupedByNode[instanceID] += stats.KV.KVTime.Value()
 		a.nodeLevelStats.ContentionTimeGroupedByNode[instanceID] += stats.KV.ContentionTime.Value()
+		a.nodeLevelStats.CPUTimeGroupedByNode[instanceID] += stats.Exec.CPUTime.Value()
 	}
 
 	// Process streamStats.
@@ -331,6 +336,10 @@ func (a *TraceAnalyzer) ProcessStats() error {
 	for _, contentionTime := range a.nodeLevelStats.ContentionTimeGroupedByNode {
 		a.queryLevelStats.ContentionTime += contentionTime
 	}
+
+	for _, cpuTime := range a.nodeLevelStats.CPUTimeGroupedByNode {
+		a.queryLevelStats.CPUTime += cpuTime
+	}
 	return errs
 }
 
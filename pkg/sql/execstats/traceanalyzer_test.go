@@ -194,8 +194,11 @@ func TestTraceAnalyzerProcessStats(t *testing.T) {
 		node1ContentionTime      = 2 * time.Second
 		node2KVTime              = 3 * time.Second
 		node2ContentionTime      = 4 * time.Second
+		node1CPUTime             = 5 * time.Second
+		node2CPUTime             = 6 * time.Second
 		cumulativeKVTime         = node1KVTime + node2KVTime
 		cumulativeContentionTime = node1ContentionTime + node2ContentionTime
+		cumulativeCPUTime        = node1CPUTime + node2CPUTime
 	)
 	a := &execstats.TraceAnalyzer{FlowsMetadata: &execstats.FlowsMetadata{}}
 	n1 := base.SQLInstanceID(1)
@@ -211,6 +214,9 @@ func TestTraceAnalyzerProcessStats(t *testing.T) {
 				KVTime:         optional.MakeTimeValue(node1KVTime),
 				ContentionTime: optional.MakeTimeValue(node1ContentionTime),
 			},
+			Exec: execinfrapb.ExecStats{
+				CPUTime: optional.MakeTimeValue(node1CPUTime),
+			},
 		},
 	)
 
@@ -225,12 +231,16 @@ func TestTraceAnalyzerProcessStats(t *testing.T) {
 				KVTime:         optional.MakeTimeValue(node2KVTime),
 				ContentionTime: optional.MakeTimeValue(node2ContentionTime),
 			},
+			Exec: execinfrapb.ExecStats{
+				CPUTime: optional.MakeTimeValue(node2CPUTime),
+			},
 		},
 	)
 
 	expected := execstats.QueryLevelStats{
 		KVTime:         cumulativeKVTime,
 		ContentionTime: cumulativeContentionTime,
+		CPUTime:        cumulativeCPUTime,
 	}
 
 	assert.NoError(t, a.ProcessStats())
@@ -248,6 +258,7 @@ func TestQueryLevelStatsAccumulate(t *testing.T) {
 		KVTime:           5 * time.Second,
 		NetworkMessages:  6,
 		ContentionTime:   7 * time.Second,
+		CPUTime:          8 * time.Second,
 		MaxDiskUsage:     8,
 		Regions:          []string{"gcp-us-east1"},
 	}
@@ -259,6 +270,7 @@ func TestQueryLevelStatsAccumulate(t *testing.T) {
 		KVTime:           12 * time.Second,
 		NetworkMessages:  13,
 		ContentionTime:   14 * time.Second,
+		CPUTime:          15 * time.Second,
 		MaxDiskUsage:     15,
 		Regions:          []string{"gcp-us-west1"},
 	}
@@ -270,6 +282,7 @@ func TestQueryLevelStatsAccumulate(t *testing.T) {
 		KVTime:           17 * time.Second,
 		NetworkMessages:  19,
 		ContentionTime:   21 * time.Second,
+		CPUTime:          23 * time.Second,
 		MaxDiskUsage:     15,
 		Regions:          []string{"gcp-us-east1", "gcp-us-west1"},
 	}
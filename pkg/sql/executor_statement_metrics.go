@@ -32,6 +32,8 @@ type EngineMetrics struct {
 	SQLOptFallbackCount   *metric.Counter
 	SQLOptPlanCacheHits   *metric.Counter
 	SQLOptPlanCacheMisses *metric.Counter
+	SQLResultCacheHits    *metric.Counter
+	SQLResultCacheMisses  *metric.Counter
 
 	DistSQLExecLatency    *metric.Histogram
 	SQLExecLatency        *metric.Histogram
@@ -155,6 +157,9 @@ func (ex *connExecutor) recordStatementSummary(
 			m.SQLServiceLatency.RecordValue(svcLatRaw.Nanoseconds())
 		}
 	}
+	if planner.curPlan.baseline.guarded && stmtErr == nil {
+		ex.recordPlanBaseline(ctx, planner, runLatRaw)
+	}
 
 	recordedStmtStatsKey := roachpb.StatementStatisticsKey{
 		Query:        stmt.StmtNoConstants,
@@ -271,6 +276,12 @@ func (ex *connExecutor) updateOptCounters(planFlags planFlags) {
 	} else if planFlags.IsSet(planFlagOptCacheMiss) {
 		m.SQLOptPlanCacheMisses.Inc(1)
 	}
+
+	if planFlags.IsSet(planFlagResultCacheHit) {
+		m.SQLResultCacheHits.Inc(1)
+	} else if planFlags.IsSet(planFlagResultCacheMiss) {
+		m.SQLResultCacheMisses.Inc(1)
+	}
 }
 
 // We only want to keep track of DML (Data Manipulation Language) statements in our latency metrics.
@@ -518,6 +518,14 @@ func (c *stmtEnvCollector) PrintSessionSettings(w io.Writer) error {
 		return sessiondatapb.VectorizeExecMode(n).String()
 	}
 
+	qosConv := func(enumVal string) string {
+		n, err := strconv.ParseInt(enumVal, 10, 32)
+		if err != nil {
+			return enumVal
+		}
+		return sessiondatapb.QoSLevel(n).String()
+	}
+
 	// TODO(rytaft): Keeping this list up to date is a challenge. Consider just
 	// printing all session settings.
 	relevantSettings := []struct {
@@ -535,7 +543,7 @@ func (c *stmtEnvCollector) PrintSessionSettings(w io.Writer) error {
 		{sessionSetting: "disallow_full_table_scans", clusterSetting: disallowFullTableScans, convFunc: boolToOnOff},
 		{sessionSetting: "large_full_scan_rows", clusterSetting: largeFullScanRows},
 		{sessionSetting: "cost_scans_with_default_col_size", clusterSetting: costScansWithDefaultColSize, convFunc: boolToOnOff},
-		{sessionSetting: "default_transaction_quality_of_service"},
+		{sessionSetting: "default_transaction_quality_of_service", clusterSetting: defaultTxnQualityOfService, convFunc: qosConv},
 		{sessionSetting: "distsql", clusterSetting: DistSQLClusterExecMode, convFunc: distsqlConv},
 		{sessionSetting: "vectorize", clusterSetting: VectorizeClusterMode, convFunc: vectorizeConv},
 	}
@@ -546,14 +554,7 @@ func (c *stmtEnvCollector) PrintSessionSettings(w io.Writer) error {
 			return err
 		}
 		// Get the default value for the cluster setting.
-		var def string
-		if s.clusterSetting == nil {
-			// Special handling for default_transaction_quality_of_service since it
-			// has no cluster setting.
-			def = sessiondatapb.Normal.String()
-		} else {
-			def = s.clusterSetting.EncodedDefault()
-		}
+		def := s.clusterSetting.EncodedDefault()
 		if s.convFunc != nil {
 			// If necessary, convert the encoded cluster setting to a session setting
 			// value (e.g.  "true"->"on"), depending on the setting.
@@ -99,7 +99,7 @@ func (e *explainPlanNode) startExec(params runParams) error {
 			} else {
 				willVectorize = true
 				for _, flow := range flows {
-					if err := colflow.IsSupported(ctxSessionData.VectorizeMode, flow); err != nil {
+					if _, err := colflow.IsSupported(ctxSessionData.VectorizeMode, flow); err != nil {
 						willVectorize = false
 						break
 					}
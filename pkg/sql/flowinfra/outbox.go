@@ -124,6 +124,12 @@ func (m *Outbox) AddRow(
 	mustFlush := false
 	var encodingErr error
 	if meta != nil {
+		if meta.Err != nil {
+			// If the flow was shed because of memory pressure, make sure that
+			// the consumer learns about that rather than about the context
+			// cancellation.
+			meta.Err = execinfra.MaybeReplaceWithShedError(ctx, meta.Err)
+		}
 		m.encoder.AddMetadata(ctx, *meta)
 		// If we hit an error, let's forward it ASAP. The consumer will probably
 		// close.
@@ -234,7 +240,7 @@ func (m *Outbox) mainLoop(ctx context.Context) error {
 		// The context used here escapes, so it has to be a background context.
 		// TODO(yuzefovich): the usage of the TODO context here is suspicious.
 		// Investigate this.
-		m.stream, err = client.FlowStream(context.TODO())
+		m.stream, err = m.flowCtx.Cfg.FlowStreamMuxer.OpenFlowStream(context.TODO(), client, m.sqlInstanceID)
 		if err != nil {
 			if log.V(1) {
 				log.Infof(ctx, "FlowStream error: %s", err)
@@ -417,7 +423,7 @@ func (m *Outbox) listenForDrainSignalFromConsumer(ctx context.Context) (<-chan d
 
 func (m *Outbox) run(ctx context.Context, wg *sync.WaitGroup) {
 	err := m.mainLoop(ctx)
-	if stream, ok := m.stream.(execinfrapb.DistSQL_FlowStreamClient); ok {
+	if stream, ok := m.stream.(execinfra.FlowStreamClient); ok {
 		closeErr := stream.CloseSend()
 		if err == nil {
 			err = closeErr
@@ -14,6 +14,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 )
 
@@ -74,6 +75,9 @@ type aggregateFuncHolder struct {
 	arguments tree.Datums
 	// isDistinct indicates whether only distinct values are aggregated.
 	isDistinct bool
+	// userDefined is set if the function is an aggregate defined with CREATE
+	// AGGREGATE, in which case funcName is only used for display.
+	userDefined *descpb.UserDefinedAggregate
 }
 
 // newAggregateFuncHolder creates an aggregateFuncHolder.
@@ -0,0 +1,117 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package idxadvisor implements the job that periodically computes the index
+// recommendations shown in crdb_internal.index_recommendations.
+package idxadvisor
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+var enabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.index_advisor.enabled",
+	"if set, a background job periodically computes index recommendations for the most "+
+		"frequently executed statements, shown in crdb_internal.index_recommendations",
+	false,
+).WithPublic()
+
+var interval = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"sql.index_advisor.interval",
+	"the frequency at which the index advisor recomputes its index recommendations",
+	time.Hour,
+	settings.PositiveDuration,
+).WithPublic()
+
+var maxStatements = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.index_advisor.max_statements",
+	"the maximum number of statement fingerprints analyzed by the index advisor, "+
+		"in decreasing order of executions",
+	100,
+	settings.PositiveInt,
+).WithPublic()
+
+type resumer struct {
+	job *jobs.Job
+}
+
+var _ jobs.Resumer = (*resumer)(nil)
+
+// Resume implements the jobs.Resumer interface.
+func (r *resumer) Resume(ctx context.Context, execCtxI interface{}) error {
+	execCtx := execCtxI.(sql.JobExecContext)
+	execCfg := execCtx.ExecCfg()
+
+	// The job runs for as long as the index advisor is enabled. It's always safe
+	// to wind the SQL pod down in between two computations of the
+	// recommendations, which we indicate through the job's idle status.
+	r.job.MarkIdle(true)
+	defer r.job.MarkIdle(false)
+
+	timer := timeutil.NewTimer()
+	defer timer.Stop()
+	for {
+		if !enabled.Get(execCfg.SV()) {
+			log.Infof(ctx, "index advisor was disabled; exiting")
+			return nil
+		}
+		recs, err := sql.ComputeIndexRecommendations(ctx, execCfg, maxStatements.Get(execCfg.SV()))
+		if err != nil {
+			// The recommendations are recomputed at the next run, so a failed run
+			// doesn't fail the job.
+			log.Warningf(ctx, "failed to compute index recommendations: %v", err)
+		} else if err := r.job.Update(ctx, nil /* txn */, func(
+			_ *kv.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+		) error {
+			md.Progress.Details = jobspb.WrapProgressDetails(jobspb.IndexAdvisorProgress{
+				Recommendations: recs,
+				LastRun:         execCfg.Clock.Now(),
+			})
+			ju.UpdateProgress(md.Progress)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		timer.Reset(interval.Get(execCfg.SV()))
+		select {
+		case <-timer.C:
+			timer.Read = true
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// OnFailOrCancel implements the jobs.Resumer interface.
+func (r *resumer) OnFailOrCancel(context.Context, interface{}) error {
+	// The manager creates a new job while the index advisor is enabled.
+	return nil
+}
+
+func init() {
+	jobs.RegisterConstructor(jobspb.TypeIndexAdvisor,
+		func(job *jobs.Job, settings *cluster.Settings) jobs.Resumer {
+			return &resumer{job: job}
+		})
+}
@@ -0,0 +1,139 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package idxadvisor
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// manager ensures that there's an index advisor job while
+// sql.index_advisor.enabled is set.
+type manager struct {
+	db *kv.DB
+	st *cluster.Settings
+	ie sqlutil.InternalExecutor
+	jr *jobs.Registry
+}
+
+// StartManager creates a background task that creates the index advisor job
+// when sql.index_advisor.enabled is set. It also periodically ensures that the
+// job exists while the setting is set, recreating it if it doesn't.
+func StartManager(
+	ctx context.Context,
+	stopper *stop.Stopper,
+	db *kv.DB,
+	st *cluster.Settings,
+	ie sqlutil.InternalExecutor,
+	jr *jobs.Registry,
+) error {
+	m := &manager{db: db, st: st, ie: ie, jr: jr}
+	return stopper.RunAsyncTask(ctx, "index-advisor-mgr", func(ctx context.Context) {
+		m.run(ctx, stopper)
+	})
+}
+
+func (m *manager) run(ctx context.Context, stopper *stop.Stopper) {
+	jobCheckCh := make(chan struct{}, 1)
+	triggerJobCheck := func() {
+		select {
+		case jobCheckCh <- struct{}{}:
+		default:
+		}
+	}
+	enabled.SetOnChange(&m.st.SV, func(ctx context.Context) {
+		triggerJobCheck()
+	})
+	m.st.Version.SetOnChange(func(_ context.Context, _ clusterversion.ClusterVersion) {
+		triggerJobCheck()
+	})
+
+	checkJob := func() {
+		if !enabled.Get(&m.st.SV) || !m.st.Version.IsActive(ctx, clusterversion.IndexAdvisorJob) {
+			return
+		}
+		started, err := m.createJobIfNoneExists(ctx)
+		if err != nil {
+			log.Errorf(ctx, "error starting index advisor job: %v", err)
+		}
+		if started {
+			log.Infof(ctx, "started index advisor job")
+		}
+	}
+
+	timer := timeutil.NewTimer()
+	defer timer.Stop()
+
+	triggerJobCheck()
+	for {
+		timer.Reset(interval.Get(&m.st.SV))
+		select {
+		case <-jobCheckCh:
+			checkJob()
+		case <-timer.C:
+			timer.Read = true
+			checkJob()
+		case <-stopper.ShouldQuiesce():
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// createJobIfNoneExists creates the index advisor job iff it isn't running
+// already and notifies the jobs registry to adopt it. Returns a boolean
+// indicating if the job was created.
+func (m *manager) createJobIfNoneExists(ctx context.Context) (bool, error) {
+	record := jobs.Record{
+		JobID:       m.jr.MakeJobID(),
+		Description: "computing index recommendations",
+		Username:    username.NodeUserName(),
+		Details:     jobspb.IndexAdvisorDetails{},
+		Progress:    jobspb.IndexAdvisorProgress{},
+	}
+
+	var job *jobs.Job
+	if err := m.db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		exists, err := jobs.RunningJobExists(ctx, jobspb.InvalidJobID, m.ie, txn,
+			func(payload *jobspb.Payload) bool {
+				return payload.Type() == jobspb.TypeIndexAdvisor
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if exists {
+			job = nil
+			return nil
+		}
+		job, err = m.jr.CreateJobWithTxn(ctx, record, record.JobID, txn)
+		return err
+	}); err != nil {
+		return false, err
+	}
+
+	if job == nil {
+		return false, nil
+	}
+	m.jr.NotifyToResume(ctx, job.ID())
+	return true, nil
+}
@@ -0,0 +1,265 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/indexrec"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// indexAdvisorFingerprintsQuery returns the most frequently executed DML
+// statement fingerprints of the applications of the users, along with their
+// current database and number of executions.
+const indexAdvisorFingerprintsQuery = `
+SELECT metadata->>'query', metadata->>'db', sum((statistics->'statistics'->>'cnt')::INT8) AS cnt
+  FROM crdb_internal.statement_statistics
+ WHERE app_name NOT LIKE $1 AND metadata->>'stmtTyp' = 'TypeDML'
+ GROUP BY 1, 2
+ ORDER BY cnt DESC
+ LIMIT $2`
+
+// hiddenListElemsRE matches the elements of a list that are elided from a
+// statement fingerprint, like the __more3__ of (_, _, __more3__).
+var hiddenListElemsRE = regexp.MustCompile(`,\s*(\(__more\d*__\)|__more\d*__)`)
+
+// hiddenConstantRE matches the constants hidden in a statement fingerprint.
+var hiddenConstantRE = regexp.MustCompile(`\b_\b`)
+
+// placeholderRE matches the placeholders of a statement fingerprint.
+var placeholderRE = regexp.MustCompile(`\$(\d+)`)
+
+// fingerprintToSQL returns a statement with placeholders in place of the
+// constants hidden in the given statement fingerprint, e.g.
+//
+//   SELECT * FROM t WHERE a = _ AND b IN (_, _, __more3__)
+//
+// becomes:
+//
+//   SELECT * FROM t WHERE a = $1 AND b IN ($2, $3)
+//
+// The hidden constants are numbered after the placeholders of the fingerprint,
+// if any.
+func fingerprintToSQL(fingerprint string) string {
+	sql := hiddenListElemsRE.ReplaceAllString(fingerprint, "")
+	n := 0
+	for _, m := range placeholderRE.FindAllStringSubmatch(sql, -1) {
+		if i, err := strconv.Atoi(m[1]); err == nil && i > n {
+			n = i
+		}
+	}
+	return hiddenConstantRE.ReplaceAllStringFunc(sql, func(string) string {
+		n++
+		return "$" + strconv.Itoa(n)
+	})
+}
+
+// mutationTableName returns the name of the table written by the given
+// statement, or nil if the statement doesn't write to a table.
+func mutationTableName(stmt tree.Statement) *tree.TableName {
+	var texpr tree.TableExpr
+	switch t := stmt.(type) {
+	case *tree.Insert:
+		texpr = t.Table
+	case *tree.Update:
+		texpr = t.Table
+	case *tree.Delete:
+		texpr = t.Table
+	default:
+		return nil
+	}
+	if aliased, ok := texpr.(*tree.AliasedTableExpr); ok {
+		texpr = aliased.Expr
+	}
+	tn, _ := texpr.(*tree.TableName)
+	return tn
+}
+
+// whatIfIndexRecommendation is an index recommendation for a statement, along
+// with the fully qualified name of the table of the index.
+type whatIfIndexRecommendation struct {
+	indexrec.Recommendation
+	tableName cat.DataSourceName
+}
+
+// indexAdvisorResult is the analysis of a statement fingerprint.
+type indexAdvisorResult struct {
+	// recs are the index recommendations for the statement.
+	recs []whatIfIndexRecommendation
+	// cost and whatIfCost are the estimated costs of the optimal plans of the
+	// statement without and with the recommended indexes.
+	cost, whatIfCost float64
+	// writtenTable is the ID of the table written by the statement, or zero if
+	// the statement doesn't write to a table.
+	writtenTable cat.StableID
+}
+
+// analyzeFingerprint runs the what-if analysis of the index recommendation
+// engine on the given statement fingerprint, which is resolved in the given
+// database. It returns false if the statement can't be analyzed, e.g. because
+// the types of its hidden constants can't be inferred.
+func analyzeFingerprint(
+	ctx context.Context, execCfg *ExecutorConfig, fingerprint, database string,
+) (res indexAdvisorResult, ok bool, _ error) {
+	stmt, err := parser.ParseOne(fingerprintToSQL(fingerprint))
+	if err != nil {
+		// Some fingerprints can't be parsed once their constants are replaced by
+		// placeholders, e.g. the placeholders of an INTERVAL literal.
+		return res, false, nil //nolint:returnerrcheck
+	}
+	var analyze bool
+	switch stmt.AST.(type) {
+	case *tree.Select, *tree.Update, *tree.Delete:
+		analyze = true
+	case *tree.Insert:
+		// An INSERT is only analyzed for the write overhead of its table.
+	default:
+		return res, false, nil
+	}
+
+	err = execCfg.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		res, ok = indexAdvisorResult{}, false
+		p, cleanup := NewInternalPlanner(
+			"index-advisor",
+			txn,
+			username.RootUserName(),
+			&MemoryMetrics{},
+			execCfg,
+			sessiondatapb.SessionData{},
+		)
+		defer cleanup()
+		localPlanner := p.(*planner)
+		localPlanner.SessionData().Database = database
+		localPlanner.stmt = makeStatement(stmt, clusterunique.ID{} /* queryID */)
+		localPlanner.optPlanningCtx.init(localPlanner)
+		if err := localPlanner.semaCtx.Placeholders.Init(stmt.NumPlaceholders, nil /* typeHints */); err != nil {
+			return err
+		}
+
+		var buildErr error
+		localPlanner.runWithOptions(resolveFlags{skipCache: true}, func() {
+			opc := &localPlanner.optPlanningCtx
+			if tn := mutationTableName(stmt.AST); tn != nil {
+				var ds cat.DataSource
+				if ds, _, buildErr = opc.catalog.ResolveDataSource(ctx, cat.Flags{}, tn); buildErr != nil {
+					return
+				}
+				res.writtenTable = ds.ID()
+			}
+			if analyze {
+				res.recs, res.cost, res.whatIfCost, buildErr = opc.makeWhatIfIndexRecommendations(ctx)
+			}
+		})
+		if buildErr != nil {
+			log.VEventf(ctx, 2, "index advisor could not analyze %q: %v", fingerprint, buildErr)
+			return nil
+		}
+		ok = true
+		return nil
+	})
+	return res, ok, err
+}
+
+// ComputeIndexRecommendations runs the what-if analysis of the index
+// recommendation engine on the maxStatements most frequently executed
+// statement fingerprints, and returns the recommended indexes ordered by
+// decreasing estimated benefit.
+//
+// The estimated benefit of an index is the reduction of the estimated cost of
+// the statements that use it, weighted by their number of executions. The cost
+// reduction of a statement is split evenly between the indexes recommended for
+// it. Since the hidden constants of the fingerprints are replaced by
+// placeholders, the costs are those of generic plans of the statements. The
+// write overhead of an index is the number of executions of the statements
+// that write to its table.
+func ComputeIndexRecommendations(
+	ctx context.Context, execCfg *ExecutorConfig, maxStatements int64,
+) ([]jobspb.IndexAdvisorRecommendation, error) {
+	rows, err := execCfg.InternalExecutor.QueryBufferedEx(
+		ctx, "index-advisor-fingerprints", nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: username.RootUserName()},
+		indexAdvisorFingerprintsQuery, catconstants.InternalAppNamePrefix+"%", maxStatements,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	type recKey struct {
+		table cat.StableID
+		sql   string
+	}
+	recs := make(map[recKey]*jobspb.IndexAdvisorRecommendation)
+	writes := make(map[cat.StableID]int64)
+	for _, row := range rows {
+		fingerprint, database := tree.MustBeDString(row[0]), tree.MustBeDString(row[1])
+		count := int64(tree.MustBeDInt(row[2]))
+		res, ok, err := analyzeFingerprint(ctx, execCfg, string(fingerprint), string(database))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if res.writtenTable != 0 {
+			writes[res.writtenTable] += count
+		}
+		if len(res.recs) == 0 || res.whatIfCost >= res.cost {
+			continue
+		}
+		benefit := (res.cost - res.whatIfCost) * float64(count) / float64(len(res.recs))
+		for _, r := range res.recs {
+			if r.tableName.CatalogName == catconstants.SystemDatabaseName {
+				continue
+			}
+			key := recKey{table: r.Table.ID(), sql: r.SQL}
+			rec, ok := recs[key]
+			if !ok {
+				rec = &jobspb.IndexAdvisorRecommendation{
+					Database:    r.tableName.Catalog(),
+					Schema:      r.tableName.Schema(),
+					Table:       r.tableName.Table(),
+					Replacement: r.Replacement,
+					Statement:   r.SQL,
+				}
+				recs[key] = rec
+			}
+			rec.EstimatedBenefit += benefit
+			rec.Fingerprints = append(rec.Fingerprints, string(fingerprint))
+		}
+	}
+
+	res := make([]jobspb.IndexAdvisorRecommendation, 0, len(recs))
+	for key, rec := range recs {
+		rec.WriteOverhead = writes[key.table]
+		res = append(res, *rec)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].EstimatedBenefit != res[j].EstimatedBenefit {
+			return res[i].EstimatedBenefit > res[j].EstimatedBenefit
+		}
+		return res[i].Statement < res[j].Statement
+	})
+	return res, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprintToSQL(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCases := []struct {
+		fingerprint string
+		expected    string
+	}{
+		{
+			fingerprint: `SELECT * FROM t WHERE a = _`,
+			expected:    `SELECT * FROM t WHERE a = $1`,
+		},
+		{
+			fingerprint: `SELECT * FROM t WHERE (a = _) AND (b IN (_, _, __more3__))`,
+			expected:    `SELECT * FROM t WHERE (a = $1) AND (b IN ($2, $3))`,
+		},
+		{
+			fingerprint: `UPDATE t SET user_id = _ WHERE a = $1`,
+			expected:    `UPDATE t SET user_id = $2 WHERE a = $1`,
+		},
+		{
+			fingerprint: `INSERT INTO t VALUES (_, _), (__more9__)`,
+			expected:    `INSERT INTO t VALUES ($1, $2)`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.fingerprint, func(t *testing.T) {
+			require.Equal(t, tc.expected, fingerprintToSQL(tc.fingerprint))
+		})
+	}
+}
+
+func TestComputeIndexRecommendations(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	execCfg := s.ExecutorConfig().(ExecutorConfig)
+
+	r := sqlutils.MakeSQLRunner(db)
+	r.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, a INT, b INT)`)
+	r.Exec(t, `INSERT INTO t SELECT i, i % 10, i % 7 FROM generate_series(1, 100) AS g(i)`)
+	r.Exec(t, `ANALYZE t`)
+	for i := 0; i < 10; i++ {
+		r.Exec(t, `SELECT k FROM t WHERE a = $1`, i)
+		r.Exec(t, `INSERT INTO t VALUES ($1, 1, 1)`, 1000+i)
+	}
+
+	recs, err := ComputeIndexRecommendations(ctx, &execCfg, 100 /* maxStatements */)
+	require.NoError(t, err)
+	require.Len(t, recs, 1)
+	rec := recs[0]
+	require.Equal(t, "defaultdb", rec.Database)
+	require.Equal(t, "public", rec.Schema)
+	require.Equal(t, "t", rec.Table)
+	require.False(t, rec.Replacement)
+	require.Equal(t, "CREATE INDEX ON t (a);", rec.Statement)
+	require.Greater(t, rec.EstimatedBenefit, 0.0)
+	require.Equal(t, int64(11), rec.WriteOverhead)
+	require.Equal(t, []string{"SELECT k FROM t WHERE a = $1"}, rec.Fingerprints)
+}
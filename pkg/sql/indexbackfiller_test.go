@@ -563,3 +563,29 @@ INSERT INTO foo VALUES (1), (10), (100);
 		t.Run(test.name, func(t *testing.T) { run(t, test) })
 	}
 }
+
+// TestIndexBackfillRangeConcurrency tests that an index backfill whose
+// processor constructs the index entries of several ranges concurrently, in
+// several batches each, backfills all the rows.
+func TestIndexBackfillRangeConcurrency(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `SET CLUSTER SETTING schemachanger.backfiller.range_concurrency = 3`)
+	sqlDB.Exec(t, `SET CLUSTER SETTING bulkio.index_backfill.batch_size = 10`)
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v INT)`)
+	sqlDB.Exec(t, `INSERT INTO t SELECT i, i * 2 FROM generate_series(1, 1000) AS g(i)`)
+	sqlDB.Exec(t, `ALTER TABLE t SPLIT AT SELECT i * 100 FROM generate_series(1, 9) AS g(i)`)
+
+	sqlDB.Exec(t, `CREATE INDEX t_v_idx ON t (v)`)
+	sqlDB.CheckQueryResults(t, `SELECT count(*), sum(v) FROM t@t_v_idx`, [][]string{{"1000", "1001000"}})
+	sqlDB.CheckQueryResults(t,
+		`SELECT count(*) FROM t@t_v_idx AS a FULL JOIN t@t_pkey AS b ON a.k = b.k AND a.v = b.v WHERE a.k IS NULL OR b.k IS NULL`,
+		[][]string{{"0"}},
+	)
+}
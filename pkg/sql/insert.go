@@ -82,6 +82,10 @@ type insertRun struct {
 
 	// traceKV caches the current KV tracing flag.
 	traceKV bool
+
+	// triggers buffers the inserted rows for the row-level triggers of the
+	// table. It is nil if no trigger fires on INSERT.
+	triggers *rowTriggerEvents
 }
 
 func (r *insertRun) initRowContainer(params runParams, columns colinfo.ResultColumns) {
@@ -161,6 +165,12 @@ func (r *insertRun) processSourceRow(params runParams, rowVals tree.Datums) erro
 		return err
 	}
 
+	if r.triggers != nil {
+		if err := r.triggers.addRow(params, nil /* oldValues */, rowVals); err != nil {
+			return err
+		}
+	}
+
 	// If result rows need to be accumulated, do it.
 	if r.ti.rows != nil {
 		for i, val := range rowVals {
@@ -414,6 +414,12 @@ func (ih *instrumentationHelper) ShouldCollectExecStats() bool {
 	return ih.collectExecStats
 }
 
+// ShouldSampleRows returns true if we should collect a sample of the rows
+// produced by each operator (for EXPLAIN ANALYZE (SAMPLE)).
+func (ih *instrumentationHelper) ShouldSampleRows() bool {
+	return ih.collectExecStats && ih.outputMode == explainAnalyzePlanOutput && ih.explainFlags.ShowSamples
+}
+
 // ShouldSaveMemo returns true if we should save the memo and catalog in planTop.
 func (ih *instrumentationHelper) ShouldSaveMemo() bool {
 	return ih.ShouldBuildExplainPlan()
@@ -477,6 +483,9 @@ func (ih *instrumentationHelper) emitExplainAnalyzePlanToOutputBuilder(
 	if queryStats.ContentionTime != 0 {
 		ob.AddContentionTime(queryStats.ContentionTime)
 	}
+	if queryStats.CPUTime != 0 {
+		ob.AddCPUTime(queryStats.CPUTime)
+	}
 
 	ob.AddMaxMemUsage(queryStats.MaxMemUsage)
 	ob.AddNetworkStats(queryStats.NetworkMessages, queryStats.NetworkBytesSent)
@@ -589,7 +598,7 @@ func (m execNodeTraceMetadata) annotateExplain(
 			var nodeStats exec.ExecutionStats
 
 			incomplete := false
-			var nodes util.FastIntSet
+			var nodes, leaseRedirectRanges util.FastIntSet
 			regionsMap := make(map[string]struct{})
 			for _, c := range components {
 				if c.Type == execinfrapb.ComponentID_PROCESSOR {
@@ -613,6 +622,13 @@ func (m execNodeTraceMetadata) annotateExplain(
 				nodeStats.VectorizedBatchCount.MaybeAdd(stats.Output.NumBatches)
 				nodeStats.MaxAllocatedMem.MaybeAdd(stats.Exec.MaxAllocatedMem)
 				nodeStats.MaxAllocatedDisk.MaybeAdd(stats.Exec.MaxAllocatedDisk)
+				nodeStats.CPUTime.MaybeAdd(stats.Exec.CPUTime)
+				nodeStats.ScanMaxMemUsage.MaybeAdd(stats.KV.ScanMaxMemUsage)
+				nodeStats.KVLeaseRedirects.MaybeAdd(stats.KV.NumLeaseRedirects)
+				for _, rangeID := range stats.KV.LeaseRedirectRangeIDs {
+					leaseRedirectRanges.Add(int(rangeID))
+				}
+				nodeStats.SampledRows = append(nodeStats.SampledRows, stats.Output.SampledRows...)
 			}
 			// If we didn't get statistics for all processors, we don't show the
 			// incomplete results. In the future, we may consider an incomplete flag
@@ -621,6 +637,9 @@ func (m execNodeTraceMetadata) annotateExplain(
 				for i, ok := nodes.Next(0); ok; i, ok = nodes.Next(i + 1) {
 					nodeStats.Nodes = append(nodeStats.Nodes, fmt.Sprintf("n%d", i))
 				}
+				leaseRedirectRanges.ForEach(func(rangeID int) {
+					nodeStats.KVLeaseRedirectRangeIDs = append(nodeStats.KVLeaseRedirectRangeIDs, int64(rangeID))
+				})
 				regions := make([]string, 0, len(regionsMap))
 				for r := range regionsMap {
 					// Add only if the region is not an empty string (it will be an
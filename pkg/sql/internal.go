@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
@@ -570,6 +571,24 @@ func (ie *InternalExecutor) QueryIteratorEx(
 	)
 }
 
+// queryIteratorPaged is like QueryIterator, but the results are paginated
+// via the given pager: the execution of the query is paused once it has
+// produced all rows requested via the pager so far. If the call is
+// successful, the returned iterator *must* be closed.
+func (ie *InternalExecutor) queryIteratorPaged(
+	ctx context.Context,
+	opName string,
+	txn *kv.Txn,
+	pager *execinfra.FlowPager,
+	stmt string,
+	qargs ...interface{},
+) (sqlutil.InternalRows, error) {
+	return ie.execInternal(
+		ctx, opName, newPagedIEResultChannel(pager), txn,
+		ie.maybeRootSessionDataOverride(opName), stmt, qargs...,
+	)
+}
+
 // applyOverrides overrides the respective fields from sd for all the fields set on o.
 func applyOverrides(o sessiondata.InternalExecutorOverride, sd *sessiondata.SessionData) {
 	if !o.User.Undefined() {
@@ -14,6 +14,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/errors"
 )
@@ -94,6 +95,10 @@ type ieResultChannel struct {
 	doneCh   chan struct{}
 	doneErr  error
 	doneOnce sync.Once
+
+	// pager, if set, is used by the reader to paginate the rows produced by
+	// the writer (see newPagedIEResultChannel).
+	pager *execinfra.FlowPager
 }
 
 // newSyncIEResultChannel is used to ensure that in execution scenarios which
@@ -108,6 +113,23 @@ func newSyncIEResultChannel() *ieResultChannel {
 	}
 }
 
+// newPagedIEResultChannel returns an ieResultChannel which doesn't synchronize
+// the writer with the reader after every result. Instead, the reader
+// paginates the rows via the given pager: the flow of the query that writes
+// the results is paused once it has produced all rows requested so far.
+func newPagedIEResultChannel(pager *execinfra.FlowPager) *ieResultChannel {
+	return &ieResultChannel{
+		dataCh: make(chan ieIteratorResult, asyncIEResultChannelBufferSize),
+		doneCh: make(chan struct{}),
+		pager:  pager,
+	}
+}
+
+// flowPager implements the pagedResultWriter interface.
+func (i *ieResultChannel) flowPager() *execinfra.FlowPager {
+	return i.pager
+}
+
 func (i *ieResultChannel) firstResult(
 	ctx context.Context,
 ) (_ ieIteratorResult, done bool, err error) {
@@ -154,6 +176,11 @@ func (i *ieResultChannel) nextResult(
 func (i *ieResultChannel) close() error {
 	i.doneOnce.Do(func() {
 		close(i.doneCh)
+		if i.pager != nil {
+			// The writer might be paused, so we need to resume it in order
+			// for it to notice that the reader has been closed.
+			i.pager.Close()
+		}
 		for {
 			// In the async case, res might contain some actual rows, but we're
 			// not interested in them; in the sync case, only errors are
@@ -34,6 +34,10 @@ type joinNode struct {
 
 	reqOrdering ReqOrdering
 
+	// rightEstimatedRowCount, if non-zero, is the optimizer's estimate of the
+	// number of rows in the right input. It is only set for hash joins.
+	rightEstimatedRowCount uint64
+
 	// columns contains the metadata for the results of this node.
 	columns colinfo.ResultColumns
 }
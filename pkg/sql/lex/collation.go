@@ -0,0 +1,75 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package lex
+
+import (
+	"github.com/cockroachdb/errors"
+	"golang.org/x/text/language"
+)
+
+// collationKeywords are the values allowed for the collation keywords of the
+// Unicode extension of a locale, like the level2 of und-u-ks-level2. The
+// empty value is allowed for the boolean keywords, for which it means true.
+var collationKeywords = map[string][]string{
+	// Strength.
+	"ks": {"level1", "level2", "level3", "level4", "identic"},
+	// Alternate handling of whitespace and punctuation.
+	"ka": {"noignore", "shifted"},
+	// Backwards secondary weights.
+	"kb": {"", "true", "false"},
+	// Case level.
+	"kc": {"", "true", "false"},
+	// Case first.
+	"kf": {"upper", "lower", "false"},
+	// Numeric ordering.
+	"kn": {"", "true", "false"},
+}
+
+// ValidateLocale returns an error if s is not a valid locale identifier, or if
+// it has collation keywords with invalid values, like und-u-ks-level9.
+func ValidateLocale(s string) error {
+	tag, err := language.Parse(s)
+	if err != nil {
+		return err
+	}
+	if _, ok := tag.Extension('u'); !ok {
+		return nil
+	}
+	for key, values := range collationKeywords {
+		value := tag.TypeForKey(key)
+		if value == "" {
+			continue
+		}
+		valid := false
+		for _, v := range values {
+			valid = valid || value == v
+		}
+		if !valid {
+			return errors.Newf("invalid value %q for collation keyword %q", value, key)
+		}
+	}
+	return nil
+}
+
+// IsDeterministicCollation returns false if the collation of the given locale
+// considers strings that differ only by case, accents, or punctuation as
+// equal, like und-u-ks-level2 which ignores case. The locale must be valid.
+func IsDeterministicCollation(s string) bool {
+	tag, err := language.Parse(s)
+	if err != nil {
+		return true
+	}
+	switch tag.TypeForKey("ks") {
+	case "level1", "level2":
+		return false
+	}
+	return tag.TypeForKey("ka") != "shifted"
+}
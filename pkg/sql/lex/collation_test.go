@@ -0,0 +1,56 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package lex_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lex"
+)
+
+func TestCollationLocales(t *testing.T) {
+	testData := []struct {
+		locale        string
+		err           string
+		deterministic bool
+	}{
+		{`en-US`, ``, true},
+		{`en_us`, ``, true},
+		{`und-u-ks-level1`, ``, false},
+		{`und_u_ks_level2`, ``, false},
+		{`und-u-ks-level3`, ``, true},
+		{`en-u-ks-identic`, ``, true},
+		{`en-u-ka-shifted`, ``, false},
+		{`en-u-kn`, ``, true},
+		{`en-u-kn-true-kf-upper`, ``, true},
+		{`de-u-co-phonebk`, ``, true},
+		{`und-u-ks-level9`, `invalid value "level9" for collation keyword "ks"`, false},
+		{`en-u-kc-banana`, `invalid value "banana" for collation keyword "kc"`, false},
+		{`not a locale`, `language: tag is not well-formed`, false},
+	}
+	for _, d := range testData {
+		t.Run(d.locale, func(t *testing.T) {
+			err := lex.ValidateLocale(d.locale)
+			if d.err != "" {
+				if err == nil || err.Error() != d.err {
+					t.Fatalf("expected error %q, got %v", d.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res := lex.IsDeterministicCollation(d.locale); res != d.deterministic {
+				t.Fatalf("expected deterministic %t, got %t", d.deterministic, res)
+			}
+		})
+	}
+}
@@ -129,6 +129,12 @@ type Catalog interface {
 		ctx context.Context, name *tree.UnresolvedObjectName,
 	) (*types.T, error)
 
+	// ResolveAggregate is used to resolve the name of an aggregate defined with
+	// CREATE AGGREGATE. It returns nil if there is no such aggregate.
+	ResolveAggregate(
+		ctx context.Context, name *tree.UnresolvedObjectName,
+	) (*tree.FunctionDefinition, error)
+
 	// ResolveIndex is used to resolve index with a TableIndexName where name of
 	// table, schema, database could be missing. Index is returned together with
 	// name of the table/materialized view contains the index. Error is returned
@@ -172,4 +178,8 @@ type Catalog interface {
 
 	// RoleExists returns true if the role exists.
 	RoleExists(ctx context.Context, role username.SQLUsername) (bool, error)
+
+	// IsMemberOfRole returns true if the current user is the given role or is
+	// a direct or indirect member of it.
+	IsMemberOfRole(ctx context.Context, role username.SQLUsername) (bool, error)
 }
@@ -13,6 +13,7 @@ package cat
 import (
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 )
 
@@ -143,6 +144,27 @@ type Table interface {
 	// IsPartitionAllBy returns true if this is a PARTITION ALL BY table. This
 	// includes REGIONAL BY ROW tables.
 	IsPartitionAllBy() bool
+
+	// Owner returns the role that owns the table.
+	Owner() username.SQLUsername
+
+	// IsRowLevelSecurityEnabled returns true if the row-level security policies
+	// of the table are enforced.
+	IsRowLevelSecurityEnabled() bool
+
+	// PolicyCount returns the number of row-level security policies defined on
+	// the table.
+	PolicyCount() int
+
+	// Policy returns the ith row-level security policy, where i < PolicyCount.
+	Policy(i int) Policy
+
+	// TriggerCount returns the number of row-level triggers defined on the
+	// table.
+	TriggerCount() int
+
+	// Trigger returns the ith row-level trigger, where i < TriggerCount.
+	Trigger(i int) Trigger
 }
 
 // CheckConstraint contains the SQL text and the validity status for a check
@@ -157,6 +179,56 @@ type CheckConstraint struct {
 	Validated  bool
 }
 
+// Policy contains the definition of a row-level security policy on a table.
+// When row-level security is enabled on the table, a row is only accessible by
+// a statement if it satisfies the USING expression of at least one policy that
+// applies to the command of the statement and to the current user. For
+// example, this policy only allows the users to see their own rows:
+//
+//   CREATE POLICY p ON t FOR SELECT USING (owner = current_user)
+//
+type Policy struct {
+	Name    tree.Name
+	Command tree.PolicyCommand
+	// Roles is the list of roles to which the policy applies. The public role
+	// means that the policy applies to everyone.
+	Roles []username.SQLUsername
+	// UsingExpr is the SQL text of the USING expression.
+	UsingExpr string
+}
+
+// Trigger describes a row-level trigger on a table. The triggers are executed
+// after the mutations that fire them, once for each modified row, so the
+// optimizer only needs to know which mutations fire them: the mutations must
+// produce all the columns of the modified rows.
+type Trigger struct {
+	Name tree.Name
+	// Events are the kinds of modifications that fire the trigger.
+	Events tree.TriggerEvents
+}
+
+// FiresOn returns true if the trigger fires for the given kind of
+// modification.
+func (t *Trigger) FiresOn(event tree.TriggerEvent) bool {
+	for _, e := range t.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTriggersOn returns true if the table has row-level triggers that fire
+// for the given kind of modification.
+func HasTriggersOn(tab Table, event tree.TriggerEvent) bool {
+	for i, n := 0, tab.TriggerCount(); i < n; i++ {
+		if trigger := tab.Trigger(i); trigger.FiresOn(event) {
+			return true
+		}
+	}
+	return false
+}
+
 // TableStatistic is an interface to a table statistic. Each statistic is
 // associated with a set of columns.
 type TableStatistic interface {
@@ -555,6 +555,11 @@ func (b *Builder) tryBuildDeleteRange(del *memo.DeleteExpr) (_ execPlan, ok bool
 		return execPlan{}, false, nil
 	}
 
+	// Row-level triggers need the values of the deleted rows.
+	if cat.HasTriggersOn(tab, tree.TriggerEventDelete) {
+		return execPlan{}, false, nil
+	}
+
 	// We can use the fast path if we don't need to buffer the input to the
 	// delete operator (for foreign key checks/cascades).
 	if del.WithID != 0 {
@@ -911,6 +916,11 @@ func (b *Builder) canAutoCommit(rel memo.RelExpr) bool {
 
 	switch rel.Op() {
 	case opt.InsertOp, opt.UpsertOp, opt.UpdateOp, opt.DeleteOp:
+		// Row-level triggers are executed after the mutation, in the same
+		// transaction.
+		if b.firesRowTriggers(rel) {
+			return false
+		}
 		// Check that there aren't any more mutations in the input.
 		// TODO(radu): this can go away when all mutations are under top-level
 		// With ops.
@@ -931,6 +941,25 @@ func (b *Builder) canAutoCommit(rel memo.RelExpr) bool {
 	}
 }
 
+// firesRowTriggers returns true if the given mutation fires row-level
+// triggers defined on its target table.
+func (b *Builder) firesRowTriggers(mut memo.RelExpr) bool {
+	private := mut.Private().(*memo.MutationPrivate)
+	tab := b.mem.Metadata().Table(private.Table)
+	switch mut.Op() {
+	case opt.InsertOp:
+		return cat.HasTriggersOn(tab, tree.TriggerEventInsert)
+	case opt.UpsertOp:
+		return cat.HasTriggersOn(tab, tree.TriggerEventInsert) ||
+			cat.HasTriggersOn(tab, tree.TriggerEventUpdate)
+	case opt.UpdateOp:
+		return cat.HasTriggersOn(tab, tree.TriggerEventUpdate)
+	case opt.DeleteOp:
+		return cat.HasTriggersOn(tab, tree.TriggerEventDelete)
+	}
+	return false
+}
+
 // forUpdateLocking is the row-level locking mode used by mutations during their
 // initial row scan, when such locking is deemed desirable. The locking mode is
 // equivalent that used by a SELECT ... FOR UPDATE statement.
@@ -14,6 +14,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"math"
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
@@ -254,6 +255,11 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	case *memo.ZigzagJoinExpr:
 		ep, err = b.buildZigzagJoin(t)
 
+	case *memo.BarrierExpr:
+		// Barrier only constrains the optimizer, so the input is built in its
+		// place.
+		ep, err = b.buildRelational(t.Input)
+
 	case *memo.OrdinalityExpr:
 		ep, err = b.buildOrdinality(t)
 
@@ -660,6 +666,11 @@ func (b *Builder) scanParams(
 		Locking:            locking,
 		EstimatedRowCount:  rowCount,
 		LocalityOptimized:  scan.LocalityOptimized,
+		ChangesSince:       scan.Flags.ChangesSince,
+		SampleMethod:       scan.Flags.SampleMethod,
+		SampleProbability:  scan.Flags.SampleProbability,
+		SystemTimeStart:    scan.Flags.SystemTimeStart,
+		SystemTimeEnd:      scan.Flags.SystemTimeEnd,
 	}, outputMap, nil
 }
 
@@ -946,12 +957,14 @@ func (b *Builder) buildApplyJoin(join memo.RelExpr) (execPlan, error) {
 	// a column bound by the left side of this apply join to the column ordinal
 	// in the left side that contains the binding.
 	var leftBoundColMap opt.ColMap
+	leftBoundColOrds := make([]exec.NodeColumnOrdinal, 0, leftBoundCols.Len())
 	for col, ok := leftBoundCols.Next(0); ok; col, ok = leftBoundCols.Next(col + 1) {
 		v, ok := leftPlan.outputCols.Get(int(col))
 		if !ok {
 			return execPlan{}, fmt.Errorf("couldn't find binding column %d in left output columns", col)
 		}
 		leftBoundColMap.Set(int(col), v)
+		leftBoundColOrds = append(leftBoundColOrds, exec.NodeColumnOrdinal(v))
 	}
 
 	// Now, the cool part! We set up an ApplyJoinPlanRightSideFn which plans the
@@ -1048,6 +1061,8 @@ func (b *Builder) buildApplyJoin(join memo.RelExpr) (execPlan, error) {
 		b.presentationToResultColumns(rightRequiredProps.Presentation),
 		onExpr,
 		planRightSideFn,
+		leftBoundColOrds,
+		!rightProps.VolatilitySet.HasVolatile(),
 	)
 	if err != nil {
 		return execPlan{}, err
@@ -1157,6 +1172,10 @@ func (b *Builder) buildHashJoin(join memo.RelExpr) (execPlan, error) {
 
 	leftEqColsAreKey := leftExpr.Relational().FuncDeps.ColsAreStrictKey(leftEq.ToSet())
 	rightEqColsAreKey := rightExpr.Relational().FuncDeps.ColsAreStrictKey(rightEq.ToSet())
+	var rightEstimatedRowCount uint64
+	if rightStats := &rightExpr.Relational().Stats; rightStats.Available {
+		rightEstimatedRowCount = uint64(math.Ceil(rightStats.RowCount))
+	}
 
 	ep.root, err = b.factory.ConstructHashJoin(
 		joinType,
@@ -1164,6 +1183,7 @@ func (b *Builder) buildHashJoin(join memo.RelExpr) (execPlan, error) {
 		leftEqOrdinals, rightEqOrdinals,
 		leftEqColsAreKey, rightEqColsAreKey,
 		onExpr,
+		rightEstimatedRowCount,
 	)
 	if err != nil {
 		return execPlan{}, err
@@ -1332,7 +1352,7 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 			agg = aggDistinct.Input
 		}
 
-		name, _ := memo.FindAggregateOverload(agg)
+		name, overload := memo.FindAggregateOverload(agg)
 
 		// Accumulate variable arguments in argCols and constant arguments in
 		// constArgs. Constant arguments must follow variable arguments.
@@ -1360,6 +1380,8 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 			ArgCols:    argCols,
 			ConstArgs:  constArgs,
 			Filter:     filterOrd,
+
+			UserDefined: overload.UserDefinedAggregate,
 		}
 		ep.outputCols.Set(int(item.Col), len(groupingColIdx)+i)
 	}
@@ -2676,6 +2698,7 @@ var boundedStalenessAllowList = map[opt.Operator]struct{}{
 	opt.OffsetOp:           {},
 	opt.SortOp:             {},
 	opt.OrdinalityOp:       {},
+	opt.BarrierOp:          {},
 	opt.Max1RowOp:          {},
 	opt.ProjectSetOp:       {},
 	opt.WindowOp:           {},
@@ -392,12 +392,29 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 		if s.KVBytesRead.HasValue() {
 			e.ob.AddField("KV bytes read", humanize.IBytes(s.KVBytesRead.Value()))
 		}
+		if s.KVLeaseRedirects.HasValue() {
+			rangeIDs := make([]string, len(s.KVLeaseRedirectRangeIDs))
+			for i, rangeID := range s.KVLeaseRedirectRangeIDs {
+				rangeIDs[i] = fmt.Sprintf("r%d", rangeID)
+			}
+			e.ob.AddField("KV lease redirects", fmt.Sprintf("%s (%s)",
+				humanizeutil.Count(s.KVLeaseRedirects.Value()), strings.Join(rangeIDs, ", "),
+			))
+		}
+		if e.ob.flags.ShowSamples && !e.ob.flags.HideValues {
+			for _, row := range s.SampledRows {
+				e.ob.AddField("sampled row", row)
+			}
+		}
 		if s.MaxAllocatedMem.HasValue() {
 			e.ob.AddField("estimated max memory allocated", humanize.IBytes(s.MaxAllocatedMem.Value()))
 		}
 		if s.MaxAllocatedDisk.HasValue() {
 			e.ob.AddField("estimated max sql temp disk usage", humanize.IBytes(s.MaxAllocatedDisk.Value()))
 		}
+		if s.CPUTime.HasValue() {
+			e.ob.AddField("sql cpu time", string(humanizeutil.Duration(s.CPUTime.Value())))
+		}
 		if e.ob.flags.Verbose {
 			if s.StepCount.HasValue() {
 				e.ob.AddField("MVCC step count (ext/int)", fmt.Sprintf("%s/%s",
@@ -409,6 +426,9 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 					humanizeutil.Count(s.SeekCount.Value()), humanizeutil.Count(s.InternalSeekCount.Value()),
 				))
 			}
+			if s.ScanMaxMemUsage.HasValue() {
+				e.ob.AddField("scan max memory usage", humanize.IBytes(s.ScanMaxMemUsage.Value()))
+			}
 		}
 	}
 
@@ -493,6 +513,15 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 			ob.Attr("limit", a.Params.HardLimit)
 		}
 
+		if a.Params.SampleMethod != tree.NoTableSample {
+			ob.Attrf("table sample", "%s (%g%%)",
+				strings.ToLower(a.Params.SampleMethod.String()), a.Params.SampleProbability*100)
+		}
+
+		if !a.Params.SystemTimeEnd.IsEmpty() {
+			ob.Attrf("system time", "%s - %s", a.Params.SystemTimeStart, a.Params.SystemTimeEnd)
+		}
+
 		if a.Params.Parallelize {
 			ob.VAttr("parallel", "")
 		}
@@ -29,6 +29,9 @@ type Flags struct {
 	// This is used for EXPLAIN(SHAPE), which is used for the statement-bundle
 	// debug tool.
 	OnlyShape bool
+	// ShowSamples indicates that the rows sampled from the output of each
+	// operator are shown. Only used for EXPLAIN ANALYZE (SAMPLE).
+	ShowSamples bool
 
 	// Redaction control (for testing purposes).
 	Redact RedactFlags
@@ -74,6 +77,9 @@ func MakeFlags(options *tree.ExplainOptions) Flags {
 		f.Verbose = true
 		f.ShowTypes = true
 	}
+	if options.Flags[tree.ExplainFlagSample] {
+		f.ShowSamples = true
+	}
 	if options.Flags[tree.ExplainFlagShape] {
 		f.HideValues = true
 		f.OnlyShape = true
@@ -385,6 +385,13 @@ func (ob *OutputBuilder) AddContentionTime(contentionTime time.Duration) {
 	)
 }
 
+// AddCPUTime adds a top-level field for the cumulative CPU time spent by SQL
+// execution.
+func (ob *OutputBuilder) AddCPUTime(cpuTime time.Duration) {
+	ob.AddRedactableTopLevelField(
+		RedactVolatile, "sql cpu time", string(humanizeutil.Duration(cpuTime)))
+}
+
 // AddMaxMemUsage adds a top-level field for the memory used by the query.
 func (ob *OutputBuilder) AddMaxMemUsage(bytes int64) {
 	ob.AddRedactableTopLevelField(
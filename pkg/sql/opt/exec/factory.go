@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/optional"
 )
 
@@ -70,6 +71,21 @@ type ScanParams struct {
 	// to work correctly, the execution engine must create a local DistSQL plan
 	// for the main query (subqueries and postqueries need not be local).
 	LocalityOptimized bool
+
+	// If set, only the rows that have changed after this timestamp are
+	// returned.
+	ChangesSince hlc.Timestamp
+
+	// If set, only a random sample of the rows is returned, where each row (or
+	// block of rows) is returned with the probability SampleProbability.
+	SampleMethod      tree.TableSampleMethod
+	SampleProbability float64
+
+	// If SystemTimeEnd is set, the revisions of the rows that were visible
+	// between SystemTimeStart and SystemTimeEnd are returned instead of the
+	// rows visible to the transaction.
+	SystemTimeStart hlc.Timestamp
+	SystemTimeEnd   hlc.Timestamp
 }
 
 // OutputOrdering indicates the required output ordering on a Node that is being
@@ -152,6 +168,9 @@ type AggInfo struct {
 	// Filter is the index of the column, if any, which should be used as the
 	// FILTER condition for the aggregate. If there is no filter, Filter is -1.
 	Filter NodeColumnOrdinal
+
+	// UserDefined is set if the aggregate was defined with CREATE AGGREGATE.
+	UserDefined tree.UserDefinedAggregateOverload
 }
 
 // WindowInfo represents the information about a window function that must be
@@ -337,6 +356,24 @@ type ExecutionStats struct {
 	MaxAllocatedMem  optional.Uint
 	MaxAllocatedDisk optional.Uint
 
+	// CPUTime is the CPU time spent executing the operator. It is only set if
+	// the Go runtime supports tracking the CPU time of individual goroutines.
+	CPUTime optional.Duration
+
+	// ScanMaxMemUsage is the peak memory usage of the vectorized scans, including
+	// the KV fetcher buffers.
+	ScanMaxMemUsage optional.Uint
+
+	// KVLeaseRedirects is the number of KV requests that were redirected to a
+	// new leaseholder, and KVLeaseRedirectRangeIDs are the (sorted) IDs of the
+	// affected ranges.
+	KVLeaseRedirects        optional.Uint
+	KVLeaseRedirectRangeIDs []int64
+
+	// SampledRows contains a sample of the rows produced by this operator. It
+	// is only populated for EXPLAIN ANALYZE (SAMPLE).
+	SampledRows []string
+
 	// Nodes on which this operator was executed.
 	Nodes []string
 
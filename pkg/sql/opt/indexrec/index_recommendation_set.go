@@ -140,21 +140,24 @@ func (irs *IndexRecommendationSet) addIndexToRecommendationSet(
 	}
 }
 
-// Output returns a string slice of index recommendation output that will be
-// displayed below the statement plan in EXPLAIN.
-func (irs *IndexRecommendationSet) Output() []string {
-	indexRecCount := 0
-	for t := range irs.indexRecs {
-		indexRecCount += len(irs.indexRecs[t])
-	}
-	if indexRecCount == 0 {
-		return nil
-	}
-
-	outputRowCount := 2*len(irs.indexRecs) + 1
-	output := make([]string, 0, outputRowCount)
-	output = append(output, fmt.Sprintf("index recommendations: %d", indexRecCount))
+// Recommendation is a single index recommendation of an
+// IndexRecommendationSet.
+type Recommendation struct {
+	// Table is the table of the recommended index.
+	Table cat.Table
+
+	// Replacement is true if the recommended index replaces an existing index
+	// with the same explicit key columns, which must be dropped.
+	Replacement bool
+
+	// SQL is the statement that creates the recommended index, followed by the
+	// statement that drops the replaced index if Replacement is true.
+	SQL string
+}
 
+// Recommendations returns the index recommendations of the set, ordered by the
+// names of their tables.
+func (irs *IndexRecommendationSet) Recommendations() []Recommendation {
 	sortedTables := make([]cat.Table, 0, len(irs.indexRecs))
 	for t := range irs.indexRecs {
 		sortedTables = append(sortedTables, t)
@@ -163,22 +166,40 @@ func (irs *IndexRecommendationSet) Output() []string {
 		return sortedTables[i].Name() < sortedTables[j].Name()
 	})
 
-	indexRecOrd := 1
+	var recs []Recommendation
 	for _, t := range sortedTables {
 		indexes := irs.indexRecs[t]
-		for _, indexRec := range indexes {
-			recTypeStr := "index creation"
-			if indexRec.existingIndex != nil {
-				recTypeStr = "index replacement"
-			}
-			indexCols := indexRec.indexCols()
-			storing := indexRec.storingColumns()
+		for i := range indexes {
+			indexRec := &indexes[i]
+			recs = append(recs, Recommendation{
+				Table:       t,
+				Replacement: indexRec.existingIndex != nil,
+				SQL:         indexRec.sqlCommands(indexRec.indexCols(), indexRec.storingColumns()),
+			})
+		}
+	}
+	return recs
+}
+
+// Output returns a string slice of index recommendation output that will be
+// displayed below the statement plan in EXPLAIN.
+func (irs *IndexRecommendationSet) Output() []string {
+	recs := irs.Recommendations()
+	if len(recs) == 0 {
+		return nil
+	}
+
+	outputRowCount := 2*len(recs) + 1
+	output := make([]string, 0, outputRowCount)
+	output = append(output, fmt.Sprintf("index recommendations: %d", len(recs)))
 
-			indexRecType := fmt.Sprintf("%d. type: %s", indexRecOrd, recTypeStr)
-			indexRecSQL := indexRec.indexRecommendationString(indexCols, storing)
-			output = append(output, indexRecType, indexRecSQL)
-			indexRecOrd++
+	for i, rec := range recs {
+		recTypeStr, sqlStr := "index creation", "   SQL command: "
+		if rec.Replacement {
+			recTypeStr, sqlStr = "index replacement", "   SQL commands: "
 		}
+		indexRecType := fmt.Sprintf("%d. type: %s", i+1, recTypeStr)
+		output = append(output, indexRecType, sqlStr+rec.SQL)
 	}
 	return output
 }
@@ -248,7 +269,7 @@ func (ir *indexRecommendation) addStoredColOrds(scannedColOrds util.FastIntSet)
 }
 
 // indexCols returns the explicit key columns of the index, used in
-// indexRecommendationString.
+// sqlCommands.
 func (ir *indexRecommendation) indexCols() []tree.IndexElem {
 	indexCols := make([]tree.IndexElem, len(ir.index.cols))
 
@@ -272,7 +293,7 @@ func (ir *indexRecommendation) indexCols() []tree.IndexElem {
 }
 
 // storingColumns returns the stored columns of an index recommendation, used in
-// indexRecommendationString.
+// sqlCommands.
 func (ir *indexRecommendation) storingColumns() []tree.Name {
 	storingLen := ir.newStoredColOrds.Len()
 	if storingLen == 0 {
@@ -286,26 +307,20 @@ func (ir *indexRecommendation) storingColumns() []tree.Name {
 	return storingCols
 }
 
-// indexRecommendationString returns the string output for an index
-// recommendation, containing the SQL command(s) needed to follow this
+// sqlCommands returns the SQL command(s) needed to follow an index
 // recommendation.
-func (ir *indexRecommendation) indexRecommendationString(
-	indexCols []tree.IndexElem, storing []tree.Name,
-) string {
+func (ir *indexRecommendation) sqlCommands(indexCols []tree.IndexElem, storing []tree.Name) string {
 	var sb strings.Builder
 	tableName := tree.NewUnqualifiedTableName(ir.index.tab.Name())
 
 	var dropCmd tree.DropIndex
 	unique := false
 	if ir.existingIndex != nil {
-		sb.WriteString("   SQL commands: ")
 		indexName := tree.UnrestrictedName(ir.existingIndex.Name())
 		dropCmd.IndexList = []*tree.TableIndexName{{Table: *tableName, Index: indexName}}
 
 		// Maintain uniqueness if the existing index is unique.
 		unique = ir.existingIndex.IsUnique()
-	} else {
-		sb.WriteString("   SQL command: ")
 	}
 
 	createCmd := tree.CreateIndex{
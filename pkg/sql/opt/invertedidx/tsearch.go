@@ -0,0 +1,86 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package invertedidx
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+type tsqueryFilterPlanner struct {
+	tabID           opt.TableID
+	index           cat.Index
+	computedColumns map[opt.ColumnID]opt.ScalarExpr
+}
+
+var _ invertedFilterPlanner = &tsqueryFilterPlanner{}
+
+// extractInvertedFilterConditionFromLeaf is part of the invertedFilterPlanner
+// interface.
+func (t *tsqueryFilterPlanner) extractInvertedFilterConditionFromLeaf(
+	evalCtx *eval.Context, expr opt.ScalarExpr,
+) (
+	invertedExpr inverted.Expression,
+	remainingFilters opt.ScalarExpr,
+	_ *invertedexpr.PreFiltererStateForInvertedFilterer,
+) {
+	if m, ok := expr.(*memo.TSMatchesExpr); ok {
+		invertedExpr = t.extractTSMatchesCondition(m.Left, m.Right)
+	}
+
+	if invertedExpr == nil {
+		// An inverted expression could not be extracted.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+
+	// If the extracted inverted expression is not tight then remaining filters
+	// must be applied after the inverted index scan.
+	if !invertedExpr.IsTight() {
+		remainingFilters = expr
+	}
+
+	// We do not currently support pre-filtering for tsvector indexes, so the
+	// returned pre-filter state is nil.
+	return invertedExpr, remainingFilters, nil
+}
+
+// extractTSMatchesCondition extracts an InvertedExpression representing an
+// inverted filter over the planner's inverted index, based on the given left
+// and right arguments of the @@ operator. Returns an empty InvertedExpression
+// if no inverted filter could be extracted.
+func (t *tsqueryFilterPlanner) extractTSMatchesCondition(
+	left, right opt.ScalarExpr,
+) inverted.Expression {
+	var constantVal opt.ScalarExpr
+	if isIndexColumn(t.tabID, t.index, left, t.computedColumns) && memo.CanExtractConstDatum(right) {
+		constantVal = right
+	} else if isIndexColumn(t.tabID, t.index, right, t.computedColumns) && memo.CanExtractConstDatum(left) {
+		constantVal = left
+	} else {
+		return inverted.NonInvertedColExpression{}
+	}
+	q, ok := memo.ExtractConstDatum(constantVal).(*tree.DTSQuery)
+	if !ok {
+		return inverted.NonInvertedColExpression{}
+	}
+	invertedExpr, err := q.TSQuery.GetInvertedExpr()
+	if err != nil {
+		// The tsquery can match documents that aren't in the index, such as
+		// !'cat'.
+		return inverted.NonInvertedColExpression{}
+	}
+	return invertedExpr
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treewindow"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 )
@@ -368,6 +369,25 @@ type ScanFlags struct {
 	// ZigzagIndexes makes planner prefer a zigzag with particular indexes.
 	// ForceZigzag must also be true.
 	ZigzagIndexes util.FastIntSet
+
+	// ChangesSince, if set, restricts the scan to only the rows that have been
+	// changed after this timestamp. The scan must be over the primary index of
+	// a table with a single column family (ForceIndex must also be true).
+	ChangesSince hlc.Timestamp
+
+	// SampleMethod, if set, restricts the scan to a random sample of the rows,
+	// where each row (or block of rows, depending on the method) is returned
+	// with the probability SampleProbability.
+	SampleMethod      tree.TableSampleMethod
+	SampleProbability float64
+
+	// SystemTimeEnd, if set, makes the scan return the revisions of the rows
+	// that were visible at some point between SystemTimeStart and
+	// SystemTimeEnd, rather than the rows visible to the transaction. The scan
+	// must be over the primary index of a table with a single column family
+	// (ForceIndex must also be true).
+	SystemTimeStart hlc.Timestamp
+	SystemTimeEnd   hlc.Timestamp
 }
 
 // Empty returns true if there are no flags set.
@@ -422,6 +442,11 @@ const (
 	// PreferLookupJoinIntoRight reduces the cost of a lookup join where the
 	// lookup table is on the right side.
 	PreferLookupJoinIntoRight
+
+	// PreserveJoinOrder prevents the join from being reordered or commuted with
+	// other joins, without restricting the join execution method. It is set by
+	// the leading statement hint.
+	PreserveJoinOrder
 )
 
 const (
@@ -447,6 +472,10 @@ const (
 
 	// AllowOnlyMergeJoin has all "disallow" flags set except DisallowMergeJoin.
 	AllowOnlyMergeJoin = disallowAll ^ DisallowMergeJoin
+
+	// AllowOnlyHashJoin has all "disallow" flags set except
+	// DisallowHashJoinStoreLeft and DisallowHashJoinStoreRight.
+	AllowOnlyHashJoin = disallowAll ^ (DisallowHashJoinStoreLeft | DisallowHashJoinStoreRight)
 )
 
 var joinFlagStr = map[JoinFlags]string{
@@ -479,7 +508,8 @@ func (jf JoinFlags) String() string {
 	}
 
 	prefer := jf & (PreferLookupJoinIntoLeft | PreferLookupJoinIntoRight)
-	disallow := jf ^ prefer
+	preserveOrder := jf & PreserveJoinOrder
+	disallow := jf ^ prefer ^ preserveOrder
 
 	// Special cases with prettier results for common cases.
 	var b strings.Builder
@@ -492,6 +522,8 @@ func (jf JoinFlags) String() string {
 		b.WriteString("force inverted join (into right side)")
 	case AllowOnlyMergeJoin:
 		b.WriteString("force merge join")
+	case AllowOnlyHashJoin:
+		b.WriteString("force hash join")
 
 	default:
 		for disallow != 0 {
@@ -515,6 +547,13 @@ func (jf JoinFlags) String() string {
 		b.WriteString(joinFlagStr[flag])
 		prefer ^= flag
 	}
+
+	if preserveOrder != 0 {
+		if b.Len() > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString("preserve join order")
+	}
 	return b.String()
 }
 
@@ -658,6 +697,8 @@ func (s *ScanPrivate) IsUnfiltered(md *opt.Metadata) bool {
 	return (s.Constraint == nil || s.Constraint.IsUnconstrained()) &&
 		s.InvertedConstraint == nil &&
 		s.HardLimit == 0 &&
+		s.Flags.SampleMethod == tree.NoTableSample &&
+		s.Flags.SystemTimeEnd.IsEmpty() &&
 		s.PartialIndexPredicate(md) == nil
 }
 
@@ -460,6 +460,17 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 					}
 				}
 			}
+			if !private.Flags.ChangesSince.IsEmpty() {
+				b.WriteString(fmt.Sprintf(" changes-since=%s", private.Flags.ChangesSince))
+			}
+			if private.Flags.SampleMethod != tree.NoTableSample {
+				b.WriteString(fmt.Sprintf(" sample=%s(%g)",
+					strings.ToLower(private.Flags.SampleMethod.String()), private.Flags.SampleProbability))
+			}
+			if !private.Flags.SystemTimeEnd.IsEmpty() {
+				b.WriteString(fmt.Sprintf(" system-time=%s-%s",
+					private.Flags.SystemTimeStart, private.Flags.SystemTimeEnd))
+			}
 			tp.Child(b.String())
 		}
 		f.formatLocking(tp, private.Locking)
@@ -524,6 +524,14 @@ func (h *hasher) HashScanFlags(val ScanFlags) {
 			h.HashInt(i)
 		}
 	}
+	h.HashUint64(uint64(val.ChangesSince.WallTime))
+	h.HashUint64(uint64(val.ChangesSince.Logical))
+	h.HashInt(int(val.SampleMethod))
+	h.HashFloat64(val.SampleProbability)
+	h.HashUint64(uint64(val.SystemTimeStart.WallTime))
+	h.HashUint64(uint64(val.SystemTimeStart.Logical))
+	h.HashUint64(uint64(val.SystemTimeEnd.WallTime))
+	h.HashUint64(uint64(val.SystemTimeEnd.Logical))
 }
 
 func (h *hasher) HashJoinFlags(val JoinFlags) {
@@ -110,6 +110,15 @@ func (b *logicalPropsBuilder) buildScanProps(scan *ScanExpr, rel *props.Relation
 	// that def.HardLimit = 0 indicates there is no known limit.
 	if hardLimit == 1 {
 		rel.FuncDeps.MakeMax1Row(rel.OutputCols)
+	} else if !scan.Flags.SystemTimeEnd.IsEmpty() {
+		// A scan of the revision history returns multiple revisions of each row,
+		// which do not have to satisfy the constraints of the current schema, so
+		// only the constant columns of the constraint are known.
+		if scan.Constraint != nil {
+			rel.FuncDeps.AddConstants(scan.Constraint.ExtractConstCols(b.evalCtx))
+		}
+		rel.FuncDeps.MakeNotNull(rel.NotNullCols)
+		rel.FuncDeps.ProjectCols(rel.OutputCols)
 	} else {
 		// Initialize key FD's from the table schema, including constant columns from
 		// the constraint, minus any columns that are not projected by the Scan
@@ -1230,6 +1239,42 @@ func (b *logicalPropsBuilder) buildMax1RowProps(max1Row *Max1RowExpr, rel *props
 	}
 }
 
+func (b *logicalPropsBuilder) buildBarrierProps(barrier *BarrierExpr, rel *props.Relational) {
+	BuildSharedProps(barrier, &rel.Shared, b.evalCtx)
+
+	inputProps := barrier.Input.Relational()
+
+	// Output Columns
+	// --------------
+	// Output columns are inherited from input.
+	rel.OutputCols = inputProps.OutputCols
+
+	// Not Null Columns
+	// ----------------
+	// Not null columns are inherited from input.
+	rel.NotNullCols = inputProps.NotNullCols
+
+	// Outer Columns
+	// -------------
+	// Outer columns were already derived by BuildSharedProps.
+
+	// Functional Dependencies
+	// -----------------------
+	// Functional dependencies are inherited from input.
+	rel.FuncDeps.CopyFrom(&inputProps.FuncDeps)
+
+	// Cardinality
+	// -----------
+	// Barrier returns all the rows of its input.
+	rel.Cardinality = inputProps.Cardinality
+
+	// Statistics
+	// ----------
+	if !b.disableStats {
+		b.sb.buildBarrier(barrier, rel)
+	}
+}
+
 func (b *logicalPropsBuilder) buildOrdinalityProps(ord *OrdinalityExpr, rel *props.Relational) {
 	BuildSharedProps(ord, &rel.Shared, b.evalCtx)
 
@@ -1617,6 +1662,9 @@ func BuildSharedProps(e opt.Expr, shared *props.Shared, evalCtx *eval.Context) {
 	case *FunctionExpr:
 		shared.VolatilitySet.Add(t.Overload.Volatility)
 
+	case *UserDefinedAggExpr:
+		shared.VolatilitySet.Add(t.Overload.Volatility)
+
 	case *CastExpr, *AssignmentCastExpr:
 		from := e.Child(0).(opt.ScalarExpr).DataType()
 		to := e.Private().(*types.T)
@@ -17,14 +17,17 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/geo/geoindex"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/constraint"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
@@ -35,6 +38,12 @@ import (
 
 var statsAnnID = opt.NewTableAnnID()
 
+// invertedHistAnnID is the annotation of the histograms of the inverted index
+// keys of the JSON and array columns of a table that have no inverted index,
+// which are stored separately from the column statistics since they don't
+// describe the values of the columns.
+var invertedHistAnnID = opt.NewTableAnnID()
+
 const (
 	// This is the value used for inequality filters such as x < 1 in
 	// "Access Path Selection in a Relational Database Management System"
@@ -458,6 +467,9 @@ func (sb *statisticsBuilder) colStat(colSet opt.ColSet, e RelExpr) *props.Column
 	case opt.Max1RowOp:
 		return sb.colStatMax1Row(colSet, e.(*Max1RowExpr))
 
+	case opt.BarrierOp:
+		return sb.colStatBarrier(colSet, e.(*BarrierExpr))
+
 	case opt.OrdinalityOp:
 		return sb.colStatOrdinality(colSet, e.(*OrdinalityExpr))
 
@@ -629,6 +641,7 @@ func (sb *statisticsBuilder) makeTableStatistics(tabID opt.TableID) *props.Stati
 
 	// Make now and annotate the metadata table with it for next time.
 	stats = &props.Statistics{}
+	var invertedHists map[opt.ColumnID]*props.Histogram
 	if tab.StatisticCount() == 0 {
 		// No statistics.
 		stats.Available = false
@@ -668,7 +681,18 @@ func (sb *statisticsBuilder) makeTableStatistics(tabID opt.TableID) *props.Stati
 					// entries, and we need to create a new stat for it, and not apply a histogram
 					// to the source column.
 					invertedColOrds := invertedIndexCols[stat.ColumnOrdinal(0)]
-					if len(invertedColOrds) == 0 {
+					if len(invertedColOrds) == 0 && isInvertedHistogram(stat.Histogram()) {
+						// The column is not indexed, so the histogram of its inverted
+						// index keys is only used to estimate the selectivity of
+						// containment filters. See selectivityFromInvertedHistogram.
+						if _, ok := invertedHists[col]; !ok {
+							if invertedHists == nil {
+								invertedHists = make(map[opt.ColumnID]*props.Histogram)
+							}
+							invertedHists[col] = &props.Histogram{}
+							invertedHists[col].Init(sb.evalCtx, col, stat.Histogram())
+						}
+					} else if len(invertedColOrds) == 0 {
 						colStat.Histogram = &props.Histogram{}
 						colStat.Histogram.Init(sb.evalCtx, col, stat.Histogram())
 					} else {
@@ -711,9 +735,18 @@ func (sb *statisticsBuilder) makeTableStatistics(tabID opt.TableID) *props.Stati
 		}
 	}
 	sb.md.SetTableAnnotation(tabID, statsAnnID, stats)
+	if invertedHists != nil {
+		sb.md.SetTableAnnotation(tabID, invertedHistAnnID, invertedHists)
+	}
 	return stats
 }
 
+// isInvertedHistogram returns true if the given histogram buckets describe the
+// inverted index keys of a column rather than its values.
+func isInvertedHistogram(buckets []cat.HistogramBucket) bool {
+	return len(buckets) > 0 && buckets[0].UpperBound.ResolvedType().Family() == types.BytesFamily
+}
+
 func (sb *statisticsBuilder) colStatTable(
 	tabID opt.TableID, colSet opt.ColSet,
 ) *props.ColumnStatistic {
@@ -737,6 +770,10 @@ func (sb *statisticsBuilder) buildScan(scan *ScanExpr, relProps *props.Relationa
 
 	inputStats := sb.makeTableStatistics(scan.Table)
 	s.RowCount = inputStats.RowCount
+	if scan.Flags.SampleMethod != tree.NoTableSample {
+		// Only the sampled fraction of the rows is expected to be returned.
+		s.RowCount *= scan.Flags.SampleProbability
+	}
 	pred := scan.PartialIndexPredicate(sb.md)
 
 	// If the constraints and pred are nil, then this scan is an unconstrained
@@ -2236,6 +2273,38 @@ func (sb *statisticsBuilder) colStatMax1Row(
 	return colStat
 }
 
+// +---------+
+// | Barrier |
+// +---------+
+
+func (sb *statisticsBuilder) buildBarrier(barrier *BarrierExpr, relProps *props.Relational) {
+	s := &relProps.Stats
+	if zeroCardinality := s.Init(relProps); zeroCardinality {
+		// Short cut if cardinality is 0.
+		return
+	}
+	s.Available = sb.availabilityFromInput(barrier)
+
+	inputStats := &barrier.Input.Relational().Stats
+
+	s.RowCount = inputStats.RowCount
+	sb.finalizeFromCardinality(relProps)
+}
+
+func (sb *statisticsBuilder) colStatBarrier(
+	colSet opt.ColSet, barrier *BarrierExpr,
+) *props.ColumnStatistic {
+	s := &barrier.Relational().Stats
+
+	colStat := sb.copyColStatFromChild(colSet, barrier, s)
+
+	if colSet.Intersects(barrier.Relational().NotNullCols) {
+		colStat.NullCount = 0
+	}
+	sb.finalizeFromRowCountAndDistinctCounts(colStat, s)
+	return colStat
+}
+
 // +------------+
 // | Row Number |
 // +------------+
@@ -3067,6 +3136,15 @@ func (sb *statisticsBuilder) applyFiltersItem(
 		return 0, opt.ColSet{}, opt.ColSet{}
 	}
 
+	// Special case: The current conjunct is a JSON or Array Contains operator
+	// on a column with a histogram of the keys of its inverted index. The
+	// selectivity of the conjunct is estimated from the histogram, and it is
+	// counted as the number of unapplied conjuncts that has this selectivity.
+	if sel, ok := sb.selectivityFromInvertedHistogram(filter.Condition); ok {
+		return math.Log(max(sel.AsFloat(), epsilon)) / math.Log(unknownFilterSelectivity),
+			opt.ColSet{}, opt.ColSet{}
+	}
+
 	// Special case: The current conjunct is a JSON or Array Contains
 	// operator, or an equality operator with a JSON fetch value operator on
 	// the left (for example j->'a' = '1'), or a JSON exists operator. If so,
@@ -4354,6 +4432,74 @@ func (sb *statisticsBuilder) selectivityFromInvertedJoinCondition(
 	return props.MakeSelectivity(unknownInvertedJoinSelectivity)
 }
 
+// selectivityFromInvertedHistogram returns the selectivity of a containment
+// filter like j @> '{"a": 1}', where j is a JSON or array column without an
+// inverted index, estimated from the histogram of the keys that an inverted
+// index on j would have. ok is false if there is no such histogram, or if the
+// filter has another form.
+func (sb *statisticsBuilder) selectivityFromInvertedHistogram(
+	cond opt.ScalarExpr,
+) (selectivity props.Selectivity, ok bool) {
+	contains, ok := cond.(*ContainsExpr)
+	if !ok {
+		return props.OneSelectivity, false
+	}
+	v, ok := contains.Left.(*VariableExpr)
+	if !ok || !CanExtractConstDatum(contains.Right) {
+		return props.OneSelectivity, false
+	}
+	tabID := sb.md.ColumnMeta(v.Col).Table
+	if tabID == 0 {
+		return props.OneSelectivity, false
+	}
+	tabStats := sb.makeTableStatistics(tabID)
+	hists, _ := sb.md.TableAnnotation(tabID, invertedHistAnnID).(map[opt.ColumnID]*props.Histogram)
+	hist := hists[v.Col]
+	if hist == nil {
+		return props.OneSelectivity, false
+	}
+	invertedExpr, err := rowenc.EncodeContainingInvertedIndexSpans(
+		sb.evalCtx, ExtractConstDatum(contains.Right),
+	)
+	if err != nil || invertedExpr == nil {
+		return props.OneSelectivity, false
+	}
+	return props.MakeSelectivity(invertedExprFraction(invertedExpr, hist, tabStats.RowCount)), true
+}
+
+// invertedExprFraction returns the estimated fraction of the rows of a table
+// matched by the given inverted expression, using the histogram of the
+// inverted index keys of the table. Each row has at most one key in a span, so
+// the number of keys in the spans of a leaf of the expression is the number of
+// rows it matches. The leaves are assumed to be independent.
+func invertedExprFraction(
+	invertedExpr inverted.Expression, hist *props.Histogram, rowCount float64,
+) float64 {
+	spanExpr, ok := invertedExpr.(*inverted.SpanExpression)
+	if !ok {
+		return 1
+	}
+	var fraction float64
+	if len(spanExpr.FactoredUnionSpans) > 0 {
+		count := hist.InvertedFilter(spanExpr.FactoredUnionSpans).ValuesCount()
+		fraction = min(count/rowCount, 1)
+	}
+	var opFraction float64
+	switch spanExpr.Operator {
+	case inverted.SetUnion:
+		left := invertedExprFraction(spanExpr.Left, hist, rowCount)
+		right := invertedExprFraction(spanExpr.Right, hist, rowCount)
+		opFraction = left + right - left*right
+	case inverted.SetIntersection:
+		left := invertedExprFraction(spanExpr.Left, hist, rowCount)
+		right := invertedExprFraction(spanExpr.Right, hist, rowCount)
+		opFraction = left * right
+	default:
+		return fraction
+	}
+	return fraction + opFraction - fraction*opFraction
+}
+
 func (sb *statisticsBuilder) selectivityFromUnappliedConjuncts(
 	numUnappliedConjuncts float64,
 ) (selectivity props.Selectivity) {
@@ -16,6 +16,7 @@ import (
 	"math/bits"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -107,6 +108,12 @@ type Metadata struct {
 	// we want to verify the resolution of both names.
 	deps []mdDep
 
+	// roleMemberships stores the results of the checks of whether the current
+	// user is a member of a role that were made while building the query. They
+	// are used to apply the row-level security policies, so the query is stale
+	// if any of the results change.
+	roleMemberships []mdRoleMembership
+
 	// views stores the list of referenced views. This information is only
 	// needed for EXPLAIN (opt, env).
 	views []cat.View
@@ -131,6 +138,11 @@ type mdDep struct {
 	privileges privilegeBitmap
 }
 
+type mdRoleMembership struct {
+	role     username.SQLUsername
+	isMember bool
+}
+
 // MDDepName stores either the unresolved DataSourceName or the StableID from
 // the query that was used to resolve a data source.
 type MDDepName struct {
@@ -176,6 +188,11 @@ func (md *Metadata) Init() {
 		deps[i] = mdDep{}
 	}
 
+	roleMemberships := md.roleMemberships
+	for i := range roleMemberships {
+		roleMemberships[i] = mdRoleMembership{}
+	}
+
 	views := md.views
 	for i := range views {
 		views[i] = nil
@@ -189,6 +206,7 @@ func (md *Metadata) Init() {
 	md.tables = tables[:0]
 	md.sequences = sequences[:0]
 	md.deps = deps[:0]
+	md.roleMemberships = roleMemberships[:0]
 	md.views = views[:0]
 }
 
@@ -202,7 +220,8 @@ func (md *Metadata) Init() {
 // expression.
 func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 	if len(md.schemas) != 0 || len(md.cols) != 0 || len(md.tables) != 0 ||
-		len(md.sequences) != 0 || len(md.deps) != 0 || len(md.views) != 0 ||
+		len(md.sequences) != 0 || len(md.deps) != 0 || len(md.roleMemberships) != 0 ||
+		len(md.views) != 0 ||
 		len(md.userDefinedTypes) != 0 || len(md.userDefinedTypesSlice) != 0 {
 		panic(errors.AssertionFailedf("CopyFrom requires empty destination"))
 	}
@@ -232,6 +251,7 @@ func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 
 	md.sequences = append(md.sequences, from.sequences...)
 	md.deps = append(md.deps, from.deps...)
+	md.roleMemberships = append(md.roleMemberships, from.roleMemberships...)
 	md.views = append(md.views, from.views...)
 	md.currUniqueID = from.currUniqueID
 
@@ -270,6 +290,34 @@ func (md *Metadata) AddDependency(name MDDepName, ds cat.DataSource, priv privil
 	})
 }
 
+// AddRoleMembership tracks the result of the check of whether the current user
+// is a member of the given role. If the Memo using this metadata is cached,
+// then a call to CheckDependencies can detect if the result has changed (for
+// example, because the query is executed by a different user).
+func (md *Metadata) AddRoleMembership(role username.SQLUsername, isMember bool) {
+	for i := range md.roleMemberships {
+		if md.roleMemberships[i].role == role {
+			return
+		}
+	}
+	md.roleMemberships = append(md.roleMemberships, mdRoleMembership{
+		role:     role,
+		isMember: isMember,
+	})
+}
+
+// NumRoleMemberships returns the number of checks of whether the current user
+// is a member of a role tracked by AddRoleMembership.
+func (md *Metadata) NumRoleMemberships() int {
+	return len(md.roleMemberships)
+}
+
+// RoleMembership returns the role and the result of the ith check tracked by
+// AddRoleMembership.
+func (md *Metadata) RoleMembership(i int) (role username.SQLUsername, isMember bool) {
+	return md.roleMemberships[i].role, md.roleMemberships[i].isMember
+}
+
 // CheckDependencies resolves (again) each data source on which this metadata
 // depends, in order to check that all data source names resolve to the same
 // objects, and that the user still has sufficient privileges to access the
@@ -318,6 +366,16 @@ func (md *Metadata) CheckDependencies(
 			privs &= ^(1 << priv)
 		}
 	}
+	// Check that the current user is still a member of the same roles.
+	for i := range md.roleMemberships {
+		isMember, err := catalog.IsMemberOfRole(ctx, md.roleMemberships[i].role)
+		if err != nil {
+			return false, err
+		}
+		if isMember != md.roleMemberships[i].isMember {
+			return false, nil
+		}
+	}
 	// Check that all of the user defined types present have not changed.
 	for _, typ := range md.AllUserDefinedTypes() {
 		toCheck, err := catalog.ResolveTypeByOID(ctx, typ.Oid())
@@ -676,6 +734,12 @@ func (md *Metadata) AddView(v cat.View) {
 	md.views = append(md.views, v)
 }
 
+// AllSequences returns the metadata for all sequences. The result must not be
+// modified.
+func (md *Metadata) AllSequences() []cat.Sequence {
+	return md.sequences
+}
+
 // AllViews returns the metadata for all views. The result must not be
 // modified.
 func (md *Metadata) AllViews() []cat.View {
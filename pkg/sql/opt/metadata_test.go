@@ -16,6 +16,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
@@ -188,6 +189,32 @@ func TestMetadataSchemas(t *testing.T) {
 	}
 }
 
+func TestMetadataRoleMemberships(t *testing.T) {
+	ctx := context.Background()
+	testCat := testcat.New()
+	var md opt.Metadata
+
+	// The test catalog considers the current user a member of every role.
+	md.AddRoleMembership(username.MakeSQLUsernameFromPreNormalizedString("alice"), true)
+	if upToDate, err := md.CheckDependencies(ctx, testCat); err != nil || !upToDate {
+		t.Fatalf("expected dependencies to be up to date: %v", err)
+	}
+
+	md.AddRoleMembership(username.MakeSQLUsernameFromPreNormalizedString("bob"), false)
+	if upToDate, err := md.CheckDependencies(ctx, testCat); err != nil || upToDate {
+		t.Fatalf("expected role membership change to be detected: %v", err)
+	}
+
+	// Checks of a role are only tracked once.
+	md.AddRoleMembership(username.MakeSQLUsernameFromPreNormalizedString("alice"), false)
+	if n := md.NumRoleMemberships(); n != 2 {
+		t.Fatalf("expected 2 role memberships, got %d", n)
+	}
+	if role, isMember := md.RoleMembership(1); role.Normalized() != "bob" || isMember {
+		t.Fatalf("unexpected role membership %s: %t", role, isMember)
+	}
+}
+
 func TestMetadataColumns(t *testing.T) {
 	var md opt.Metadata
 
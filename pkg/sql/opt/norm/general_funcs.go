@@ -729,6 +729,26 @@ func (c *CustomFuncs) ExtractUnboundConditions(
 	return newFilters
 }
 
+// ExtractBoundLeakproofConditions returns a new list containing only those
+// expressions from the given list that are fully bound by the given columns
+// (see ExtractBoundConditions) and that are leak-proof, meaning that
+// evaluating them can't reveal anything about the rows they are evaluated on,
+// other than through their result. Expressions with subqueries are never
+// considered leak-proof.
+func (c *CustomFuncs) ExtractBoundLeakproofConditions(
+	filters memo.FiltersExpr, cols opt.ColSet,
+) memo.FiltersExpr {
+	newFilters := make(memo.FiltersExpr, 0, len(filters))
+	for i := range filters {
+		scalarProps := filters[i].ScalarProps()
+		if scalarProps.VolatilitySet.IsLeakProof() && !scalarProps.HasSubquery &&
+			c.IsBoundBy(&filters[i], cols) {
+			newFilters = append(newFilters, filters[i])
+		}
+	}
+	return newFilters
+}
+
 // ----------------------------------------------------------------------
 //
 // Project functions
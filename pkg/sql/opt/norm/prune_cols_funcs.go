@@ -15,6 +15,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -126,6 +127,17 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 		}
 	}
 
+	// Row-level triggers need the values of all the columns of the updated and
+	// deleted rows.
+	if (op == opt.UpdateOp && cat.HasTriggersOn(tabMeta.Table, tree.TriggerEventUpdate)) ||
+		(op == opt.DeleteOp && cat.HasTriggersOn(tabMeta.Table, tree.TriggerEventDelete)) {
+		for ord, col := range private.FetchCols {
+			if col != 0 {
+				cols.Add(tabMeta.MetaID.ColumnID(ord))
+			}
+		}
+	}
+
 	// Retain any FetchCols that are needed for ReturnCols. If a RETURN column
 	// is needed, then:
 	//   1. For Delete, the corresponding FETCH column is always needed, since
@@ -564,6 +576,10 @@ func DerivePruneCols(e memo.RelExpr) opt.ColSet {
 		ordering := e.Private().(*props.OrderingChoice).ColSet()
 		relProps.Rule.PruneCols = inputPruneCols.Difference(ordering)
 
+	case opt.BarrierOp:
+		// Any pruneable input columns can potentially be pruned.
+		relProps.Rule.PruneCols = DerivePruneCols(e.Child(0).(memo.RelExpr))
+
 	case opt.OrdinalityOp:
 		// Any pruneable input columns can potentially be pruned, as long as
 		// they're not used as an ordering column. The new row number column
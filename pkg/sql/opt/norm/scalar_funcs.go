@@ -12,6 +12,7 @@ package norm
 
 import (
 	"sort"
+	"strconv"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
@@ -369,3 +370,43 @@ func (c *CustomFuncs) SplitTupleEq(lhs, rhs *memo.TupleExpr) memo.FiltersExpr {
 	}
 	return res
 }
+
+// CanSplitJSONPath returns true if the given constant path of a #> or #>>
+// operator is not empty and only contains strings that are not integers, so
+// that each element of the path can be fetched with the -> operator instead.
+func (c *CustomFuncs) CanSplitJSONPath(path *memo.ConstExpr) bool {
+	arr, ok := path.Value.(*tree.DArray)
+	if !ok || arr.Len() == 0 {
+		return false
+	}
+	for _, d := range arr.Array {
+		s, ok := d.(*tree.DString)
+		if !ok {
+			return false
+		}
+		if _, err := strconv.Atoi(string(*s)); err == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// SplitFetchValPath returns a chain of FetchVal expressions that fetches each
+// element of the given constant path in turn. See CanSplitJSONPath.
+func (c *CustomFuncs) SplitFetchValPath(json opt.ScalarExpr, path *memo.ConstExpr) opt.ScalarExpr {
+	for _, d := range path.Value.(*tree.DArray).Array {
+		json = c.f.ConstructFetchVal(json, c.f.ConstructConstVal(d, types.String))
+	}
+	return json
+}
+
+// SplitFetchTextPath is similar to SplitFetchValPath, but the last element of
+// the path is fetched as text with a FetchText expression.
+func (c *CustomFuncs) SplitFetchTextPath(json opt.ScalarExpr, path *memo.ConstExpr) opt.ScalarExpr {
+	elems := path.Value.(*tree.DArray).Array
+	last := len(elems) - 1
+	for _, d := range elems[:last] {
+		json = c.f.ConstructFetchVal(json, c.f.ConstructConstVal(d, types.String))
+	}
+	return c.f.ConstructFetchText(json, c.f.ConstructConstVal(elems[last], types.String))
+}
@@ -146,6 +146,7 @@ var ComparisonOpReverseMap = map[Operator]treecmp.ComparisonOperatorSymbol{
 	OverlapsOp:       treecmp.Overlaps,
 	BBoxCoversOp:     treecmp.RegMatch,
 	BBoxIntersectsOp: treecmp.Overlaps,
+	TSMatchesOp:      treecmp.TSMatches,
 }
 
 // BinaryOpReverseMap maps from an optimizer operator type to a semantic tree
@@ -330,7 +331,7 @@ func AggregateIgnoresNulls(op Operator) bool {
 		return true
 
 	case ArrayAggOp, ConcatAggOp, ConstAggOp, CountRowsOp, FirstAggOp, JsonAggOp,
-		JsonbAggOp, JsonObjectAggOp, JsonbObjectAggOp:
+		JsonbAggOp, JsonObjectAggOp, JsonbObjectAggOp, UserDefinedAggOp:
 		return false
 
 	default:
@@ -355,7 +356,7 @@ func AggregateIsNullOnEmpty(op Operator) bool {
 		RegressionSXYOp, RegressionSYYOp:
 		return true
 
-	case CountOp, CountRowsOp, RegressionCountOp:
+	case CountOp, CountRowsOp, RegressionCountOp, UserDefinedAggOp:
 		return false
 
 	default:
@@ -388,6 +389,11 @@ func AggregateIsNeverNullOnNonNullInput(op Operator) bool {
 		// These aggregations return NULL if they are given a single not-NULL input.
 		return false
 
+	case UserDefinedAggOp:
+		// The state and final functions of user-defined aggregates can return
+		// NULL for any input.
+		return false
+
 	default:
 		panic(errors.AssertionFailedf("unhandled op %s", redact.Safe(op)))
 	}
@@ -435,7 +441,7 @@ func AggregatesCanMerge(inner, outer Operator) bool {
 		SqrDiffOp, STCollectOp, StdDevOp, StringAggOp, VarianceOp, StdDevPopOp,
 		VarPopOp, CovarPopOp, CovarSampOp, RegressionAvgXOp, RegressionAvgYOp,
 		RegressionInterceptOp, RegressionR2Op, RegressionSlopeOp, RegressionSXXOp,
-		RegressionSXYOp, RegressionSYYOp, RegressionCountOp:
+		RegressionSXYOp, RegressionSYYOp, RegressionCountOp, UserDefinedAggOp:
 		return false
 
 	default:
@@ -457,7 +463,7 @@ func AggregateIgnoresDuplicates(op Operator) bool {
 		VarPopOp, JsonObjectAggOp, JsonbObjectAggOp, STCollectOp, CovarPopOp,
 		CovarSampOp, RegressionAvgXOp, RegressionAvgYOp, RegressionInterceptOp,
 		RegressionR2Op, RegressionSlopeOp, RegressionSXXOp, RegressionSXYOp,
-		RegressionSYYOp, RegressionCountOp:
+		RegressionSYYOp, RegressionCountOp, UserDefinedAggOp:
 		return false
 
 	default:
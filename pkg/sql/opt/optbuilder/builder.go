@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/optgen/exprgen"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
@@ -69,6 +70,11 @@ type Builder struct {
 	// This is used when re-preparing invalidated queries.
 	KeepPlaceholders bool
 
+	// StatementHints is a control knob: it contains the hints given in the hint
+	// comments of the statement. The optimizer hints among them, like
+	// hash_join(a b), are honored when building the statement.
+	StatementHints []parser.StatementHint
+
 	// -- Results --
 	//
 	// These fields are set during the building process and can be used after
@@ -92,6 +98,9 @@ type Builder struct {
 	catalog    cat.Catalog
 	scopeAlloc []scope
 
+	// hints are the optimizer hints among StatementHints.
+	hints optimizerHints
+
 	// ctes stores CTEs which may need to be built at the top-level.
 	ctes cteSources
 
@@ -145,6 +154,14 @@ type Builder struct {
 	// (without ON CONFLICT) or false otherwise. All mutated tables will have an
 	// entry in the map.
 	areAllTableMutationsSimpleInserts map[cat.StableID]bool
+
+	// tableSample is the TABLESAMPLE clause of the table that is currently being
+	// built, if any. It is consumed by buildScan.
+	tableSample *tree.TableSample
+
+	// systemTime is the FOR SYSTEM_TIME clause of the table that is currently
+	// being built, if any. It is consumed by buildScan.
+	systemTime *tree.SystemTime
 }
 
 // New creates a new Builder structure initialized with the given
@@ -211,6 +228,8 @@ func (b *Builder) Build() (err error) {
 		return err
 	}
 
+	b.hints = buildOptimizerHints(b.StatementHints)
+
 	// Build the memo, and call SetRoot on the memo to indicate the root group
 	// and physical properties.
 	outScope := b.buildStmtAtRoot(b.stmt, nil /* desiredTypes */)
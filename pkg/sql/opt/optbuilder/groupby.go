@@ -385,7 +385,12 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 
 		// Construct the aggregate function from its name and arguments and store
 		// it in the corresponding scope column.
-		aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		if agg.def.Overload.UserDefinedAggregate != nil {
+			def := agg.def
+			aggCols[i].scalar = b.factory.ConstructUserDefinedAgg(args[0], &def)
+		} else {
+			aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		}
 
 		// Wrap the aggregate function with an AggDistinct operator if DISTINCT
 		// was specified in the query.
@@ -210,6 +210,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 			// UPSERT and INDEX ON CONFLICT DO UPDATE may modify rows if the
 			// DO NOTHING clause is not present.
 			b.checkPrivilege(depName, tab, privilege.UPDATE)
+			b.checkRowLevelSecurityForUpsert(tab)
 		}
 	}
 
@@ -52,8 +52,22 @@ func (b *Builder) buildJoin(
 
 	joinType := descpb.JoinTypeFromAstString(join.JoinType)
 	var flags memo.JoinFlags
+	commute := false
 	switch join.Hint {
 	case "":
+		// Hints in the join syntax, like INNER HASH JOIN, take precedence over
+		// the join method hints given in the hint comments of the statement.
+		flags, commute = b.hints.joinFlags(
+			hintTableNames(nil /* names */, join.Left), hintTableNames(nil /* names */, join.Right),
+		)
+		if _, isOn := join.Cond.(*tree.OnJoinCond); commute &&
+			(joinType != descpb.InnerJoin || isLateral || (!isOn && join.Cond != nil)) {
+			// Only inner joins with an ON condition can be commuted here.
+			panic(pgerror.New(pgcode.Syntax,
+				"lookup_join hint requires the table looked up into to be on the right side of the join",
+			))
+		}
+
 	case tree.AstHash:
 		telemetry.Inc(sqltelemetry.HashJoinHintUseCounter)
 		flags = memo.AllowOnlyHashJoinStoreRight
@@ -128,6 +142,9 @@ func (b *Builder) buildJoin(
 
 		left := leftScope.expr
 		right := rightScope.expr
+		if commute {
+			left, right = right, left
+		}
 		outScope.expr = b.constructJoin(
 			joinType, left, right, filters, &memo.JoinPrivate{Flags: flags}, isLateral,
 		)
@@ -276,6 +276,10 @@ func (mb *mutationBuilder) buildInputForUpdate(
 		indexFlags = source.IndexFlags
 		telemetry.Inc(sqltelemetry.IndexHintUseCounter)
 		telemetry.Inc(sqltelemetry.IndexHintUpdateUseCounter)
+		if indexFlags.ChangesSince != "" {
+			panic(pgerror.New(pgcode.FeatureNotSupported,
+				"CHANGES_SINCE cannot be used in UPDATE statements"))
+		}
 	}
 
 	// Fetch columns from different instance of the table metadata, so that it's
@@ -285,8 +289,9 @@ func (mb *mutationBuilder) buildInputForUpdate(
 	//
 	// NOTE: Include mutation columns, but be careful to never use them for any
 	//       reason other than as "fetch columns". See buildScan comment.
+	tabMeta := mb.b.addTable(mb.tab, &mb.alias)
 	mb.fetchScope = mb.b.buildScan(
-		mb.b.addTable(mb.tab, &mb.alias),
+		tabMeta,
 		tableOrdinals(mb.tab, columnKinds{
 			includeMutations: true,
 			includeSystem:    true,
@@ -296,6 +301,7 @@ func (mb *mutationBuilder) buildInputForUpdate(
 		noRowLocking,
 		inScope,
 	)
+	mb.b.addRowLevelSecurityFilter(tabMeta, tree.PolicyCommandUpdate, mb.fetchScope)
 
 	// Set list of columns that will be fetched by the input expression.
 	mb.setFetchColIDs(mb.fetchScope.cols)
@@ -390,6 +396,10 @@ func (mb *mutationBuilder) buildInputForDelete(
 		indexFlags = source.IndexFlags
 		telemetry.Inc(sqltelemetry.IndexHintUseCounter)
 		telemetry.Inc(sqltelemetry.IndexHintDeleteUseCounter)
+		if indexFlags.ChangesSince != "" {
+			panic(pgerror.New(pgcode.FeatureNotSupported,
+				"CHANGES_SINCE cannot be used in DELETE statements"))
+		}
 	}
 
 	// Fetch columns from different instance of the table metadata, so that it's
@@ -400,8 +410,9 @@ func (mb *mutationBuilder) buildInputForDelete(
 	// NOTE: Include mutation columns, but be careful to never use them for any
 	//       reason other than as "fetch columns". See buildScan comment.
 	// TODO(andyk): Why does execution engine need mutation columns for Delete?
+	tabMeta := mb.b.addTable(mb.tab, &mb.alias)
 	mb.fetchScope = mb.b.buildScan(
-		mb.b.addTable(mb.tab, &mb.alias),
+		tabMeta,
 		tableOrdinals(mb.tab, columnKinds{
 			includeMutations: true,
 			includeSystem:    true,
@@ -411,6 +422,7 @@ func (mb *mutationBuilder) buildInputForDelete(
 		noRowLocking,
 		inScope,
 	)
+	mb.b.addRowLevelSecurityFilter(tabMeta, tree.PolicyCommandDelete, mb.fetchScope)
 	mb.outScope = mb.fetchScope
 
 	// WHERE
@@ -0,0 +1,291 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// PlanHints returns the optimizer hints that steer the optimization of the
+// statement of the given optimized memo towards the plan of the memo. It is
+// the inverse of the building of the optimizer hints, as far as they can
+// express the plan:
+//
+//   - every scanned table gets an index hint for the index it scans, and the
+//     table looked up into by a lookup join gets one for the lookup index;
+//   - the inner joins executed as hash, merge and lookup joins get the
+//     corresponding join method hint;
+//   - the outermost tree of such inner joins gets a leading hint, which only
+//     applies if the tables of the tree are part of a single comma-separated
+//     FROM clause.
+//
+// ok is false if the plan can't be expressed by hints at all, because two
+// tables are referred to by the same name or by a name that can't be part of
+// a hint. Note that the hints don't necessarily reproduce the plan: the join
+// method hints only apply to the joins of the statement as written, or as
+// given by the leading hint, and the other operators of the plan aren't
+// hinted.
+func PlanHints(mem *memo.Memo) (_ []parser.StatementHint, ok bool) {
+	root, isRel := mem.RootExpr().(memo.RelExpr)
+	if !isRel {
+		return nil, false
+	}
+	b := planHintsBuilder{
+		md:     mem.Metadata(),
+		names:  make(map[tree.Name]opt.TableID),
+		tables: make(map[opt.Expr][]opt.TableID),
+		ok:     true,
+	}
+	b.walk(root)
+	if !b.ok {
+		return nil, false
+	}
+	hints := b.joins
+	if leading := b.findLeading(root); leading != nil {
+		leadingHint := parser.StatementHint{
+			Name: "leading",
+			Args: []string{b.formatLeading(leading.left), b.formatLeading(leading.right)},
+		}
+		hints = append([]parser.StatementHint{leadingHint}, hints...)
+	}
+	return append(hints, b.indexes...), true
+}
+
+// planHintsBuilder accumulates the hints of the plan of a memo.
+type planHintsBuilder struct {
+	md *opt.Metadata
+
+	// names maps the names by which the tables are referred to in the hints to
+	// the tables.
+	names map[tree.Name]opt.TableID
+
+	// tables caches the tables of the relational expressions of the plan.
+	tables map[opt.Expr][]opt.TableID
+
+	joins   []parser.StatementHint
+	indexes []parser.StatementHint
+
+	ok bool
+}
+
+// walk records the hints of the given expression and its children, and
+// returns the tables scanned by the expression.
+func (b *planHintsBuilder) walk(e opt.Expr) (tables []opt.TableID) {
+	switch t := e.(type) {
+	case *memo.ScanExpr:
+		b.addIndexHint(t.Table, t.Index)
+		tables = []opt.TableID{t.Table}
+
+	case *memo.PlaceholderScanExpr:
+		b.addIndexHint(t.Table, t.Index)
+		tables = []opt.TableID{t.Table}
+
+	case *memo.ZigzagJoinExpr:
+		// The two sides of a zigzag join scan different indexes of the same
+		// table, which can't be hinted.
+		b.addTableName(t.LeftTable)
+		tables = []opt.TableID{t.LeftTable}
+
+	case *memo.InnerJoinExpr:
+		left, right := b.walk(t.Left), b.walk(t.Right)
+		b.walk(&t.On)
+		b.addJoinHint("hash_join", left, right)
+		tables = append(append(tables, left...), right...)
+
+	case *memo.MergeJoinExpr:
+		left, right := b.walk(t.Left), b.walk(t.Right)
+		b.walk(&t.On)
+		if t.JoinType == opt.InnerJoinOp {
+			b.addJoinHint("merge_join", left, right)
+		}
+		tables = append(append(tables, left...), right...)
+
+	case *memo.LookupJoinExpr:
+		tables = b.walkChildren(e)
+		if containsTableID(tables, t.Table) {
+			// This is the lookup join of an index join or of a paired join into
+			// a table of the input.
+			break
+		}
+		b.addIndexHint(t.Table, t.Index)
+		if t.JoinType == opt.InnerJoinOp {
+			b.addJoinHint("lookup_join", tables, []opt.TableID{t.Table})
+		}
+		tables = append(tables, t.Table)
+
+	case *memo.InvertedJoinExpr:
+		tables = b.walkChildren(e)
+		if !containsTableID(tables, t.Table) {
+			b.addTableName(t.Table)
+			tables = append(tables, t.Table)
+		}
+
+	default:
+		tables = b.walkChildren(e)
+	}
+	b.tables[e] = tables
+	return tables
+}
+
+// walkChildren walks the children of the given expression, and returns the
+// tables scanned by the children.
+func (b *planHintsBuilder) walkChildren(e opt.Expr) (tables []opt.TableID) {
+	for i, n := 0, e.ChildCount(); i < n; i++ {
+		for _, t := range b.walk(e.Child(i)) {
+			if !containsTableID(tables, t) {
+				tables = append(tables, t)
+			}
+		}
+	}
+	return tables
+}
+
+// addTableName records the name by which the given table is referred to in
+// the hints. It makes the hints fail if the name can't be used in a hint or
+// if it refers to another table too.
+func (b *planHintsBuilder) addTableName(table opt.TableID) tree.Name {
+	name := b.md.TableMeta(table).Alias.ObjectName
+	if !isHintWord(string(name)) {
+		b.ok = false
+	} else if other, ok := b.names[name]; ok && other != table {
+		b.ok = false
+	}
+	b.names[name] = table
+	return name
+}
+
+// addIndexHint adds the index hint for the given index of the given table.
+func (b *planHintsBuilder) addIndexHint(table opt.TableID, index cat.IndexOrdinal) {
+	name := b.addTableName(table)
+	idxName := string(b.md.Table(table).Index(index).Name())
+	for i := range b.indexes {
+		if b.indexes[i].Args[0] == string(name) {
+			if b.indexes[i].Args[1] != idxName {
+				// The table is scanned through different indexes.
+				b.ok = false
+			}
+			return
+		}
+	}
+	if !isHintWord(idxName) {
+		b.ok = false
+		return
+	}
+	b.indexes = append(b.indexes, parser.StatementHint{
+		Name: "index",
+		Args: []string{string(name), idxName},
+	})
+}
+
+// addJoinHint adds the join method hint with the given name for the join of
+// the given tables.
+func (b *planHintsBuilder) addJoinHint(name string, left, right []opt.TableID) {
+	if len(left) == 0 || len(right) == 0 {
+		return
+	}
+	hint := parser.StatementHint{Name: name}
+	for _, t := range left {
+		hint.Args = append(hint.Args, string(b.md.TableMeta(t).Alias.ObjectName))
+	}
+	for _, t := range right {
+		hint.Args = append(hint.Args, string(b.md.TableMeta(t).Alias.ObjectName))
+	}
+	b.joins = append(b.joins, hint)
+}
+
+// findLeading returns the join tree of the outermost tree of inner joins of
+// the given expression, or nil if there is none.
+func (b *planHintsBuilder) findLeading(e opt.Expr) *leadingNode {
+	switch t := e.(type) {
+	case *memo.InnerJoinExpr, *memo.MergeJoinExpr, *memo.LookupJoinExpr:
+		if n := b.joinTree(t.(memo.RelExpr)); n != nil && !n.isTable() {
+			return n
+		}
+	}
+	for i, n := 0, e.ChildCount(); i < n; i++ {
+		if res := b.findLeading(e.Child(i)); res != nil {
+			return res
+		}
+	}
+	return nil
+}
+
+// joinTree returns the join tree of the given expression, in which the leaves
+// are the expressions that scan a single table. It returns nil if the
+// expression isn't a tree of inner joins.
+func (b *planHintsBuilder) joinTree(e memo.RelExpr) *leadingNode {
+	switch t := e.(type) {
+	case *memo.InnerJoinExpr:
+		return b.makeJoinNode(b.joinTree(t.Left), b.joinTree(t.Right))
+
+	case *memo.MergeJoinExpr:
+		if t.JoinType == opt.InnerJoinOp {
+			return b.makeJoinNode(b.joinTree(t.Left), b.joinTree(t.Right))
+		}
+
+	case *memo.LookupJoinExpr:
+		if t.JoinType == opt.InnerJoinOp && !containsTableID(b.tables[t.Input], t.Table) {
+			right := &leadingNode{table: b.md.TableMeta(t.Table).Alias.ObjectName}
+			return b.makeJoinNode(b.joinTree(t.Input), right)
+		}
+
+	case *memo.SelectExpr:
+		if len(b.tables[e]) > 1 {
+			return b.joinTree(t.Input)
+		}
+
+	case *memo.ProjectExpr:
+		if len(b.tables[e]) > 1 {
+			return b.joinTree(t.Input)
+		}
+	}
+	if tables := b.tables[e]; len(tables) == 1 {
+		return &leadingNode{table: b.md.TableMeta(tables[0]).Alias.ObjectName}
+	}
+	return nil
+}
+
+func (b *planHintsBuilder) makeJoinNode(left, right *leadingNode) *leadingNode {
+	if left == nil || right == nil {
+		return nil
+	}
+	return &leadingNode{left: left, right: right}
+}
+
+// formatLeading formats the given join tree as an argument of a leading hint.
+func (b *planHintsBuilder) formatLeading(n *leadingNode) string {
+	if n.isTable() {
+		return string(n.table)
+	}
+	return "(" + b.formatLeading(n.left) + " " + b.formatLeading(n.right) + ")"
+}
+
+func containsTableID(tables []opt.TableID, t opt.TableID) bool {
+	for i := range tables {
+		if tables[i] == t {
+			return true
+		}
+	}
+	return false
+}
+
+// isHintWord returns true if the given name is an unquoted word of a hint,
+// which can be an element of the list arguments of the leading hint.
+func isHintWord(name string) bool {
+	return name != "" && strings.Trim(name,
+		"abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_.-") == ""
+}
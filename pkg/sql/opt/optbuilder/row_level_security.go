@@ -0,0 +1,141 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// addRowLevelSecurityFilter filters the output of the given scan of a table
+// according to the row-level security policies of the table. A row is only
+// kept if it satisfies the USING expression of at least one policy that
+// applies to the given command and to the current user; if no policy applies,
+// no rows are kept.
+//
+// The filter is not added if row-level security isn't enabled on the table,
+// or if the current user is an admin or a member of the role that owns the
+// table.
+//
+// Since the filter is added during planning, it is honored by all execution
+// engines. The filter depends on the current user, so the role memberships
+// that were checked are recorded in the metadata, which makes the memo stale
+// if they change (see opt.Metadata.CheckDependencies).
+//
+// The filtered scan is wrapped in a Barrier, so that the other filters of the
+// statement are evaluated after the policies unless they are leak-proof. A
+// predicate of the statement that can error, such as 1/(balance-42) = 0,
+// would otherwise reveal that a row hidden by the policies exists.
+func (b *Builder) addRowLevelSecurityFilter(
+	tabMeta *opt.TableMeta, cmd tree.PolicyCommand, scanScope *scope,
+) {
+	tab := tabMeta.Table
+	if b.isExemptFromRowLevelSecurity(tab) {
+		return
+	}
+
+	// The policies are not part of the view definitions, so we don't want to
+	// track the view dependencies here.
+	if b.trackViewDeps {
+		b.trackViewDeps = false
+		defer func() {
+			b.trackViewDeps = true
+		}()
+	}
+
+	// Create a scope that can be used for building the scalar expressions.
+	tableScope := b.allocScope()
+	tableScope.appendOrdinaryColumnsFromTable(tabMeta, &tabMeta.Alias)
+
+	var filter opt.ScalarExpr
+	for i, n := 0, tab.PolicyCount(); i < n; i++ {
+		policy := tab.Policy(i)
+		if policy.Command != tree.PolicyCommandAll && policy.Command != cmd {
+			continue
+		}
+		if !b.policyAppliesToCurrentUser(&policy) {
+			continue
+		}
+		expr, err := parser.ParseExpr(policy.UsingExpr)
+		if err != nil {
+			panic(err)
+		}
+		texpr := tableScope.resolveAndRequireType(expr, types.Bool)
+		condition := b.buildScalar(texpr, tableScope, nil, nil, nil)
+		if filter == nil {
+			filter = condition
+		} else {
+			filter = b.factory.ConstructOr(filter, condition)
+		}
+	}
+	if filter == nil {
+		filter = memo.FalseSingleton
+	}
+	scanScope.expr = b.factory.ConstructBarrier(b.factory.ConstructSelect(
+		scanScope.expr,
+		memo.FiltersExpr{b.factory.ConstructFiltersItem(filter)},
+	))
+}
+
+// checkRowLevelSecurityForUpsert raises an error if the current user isn't
+// exempt from the row-level security policies of the given table. UPSERT and
+// INSERT ... ON CONFLICT DO UPDATE statements aren't allowed then, since they
+// read the conflicting rows and can update them without applying the
+// policies.
+func (b *Builder) checkRowLevelSecurityForUpsert(tab cat.Table) {
+	if b.isExemptFromRowLevelSecurity(tab) {
+		return
+	}
+	panic(pgerror.Newf(pgcode.FeatureNotSupported,
+		"UPSERT and INSERT ... ON CONFLICT DO UPDATE are not supported on table %s "+
+			"with row-level security enabled", tab.Name(),
+	))
+}
+
+// isExemptFromRowLevelSecurity returns true if the row-level security policies
+// of the given table don't apply to the current user, either because
+// row-level security isn't enabled on the table, or because the current user
+// is an admin or a member of the role that owns the table.
+func (b *Builder) isExemptFromRowLevelSecurity(tab cat.Table) bool {
+	if !tab.IsRowLevelSecurityEnabled() {
+		return true
+	}
+	return b.isMemberOfRole(username.AdminRoleName()) || b.isMemberOfRole(tab.Owner())
+}
+
+// policyAppliesToCurrentUser returns true if the current user is a member of
+// any of the roles to which the given policy applies.
+func (b *Builder) policyAppliesToCurrentUser(policy *cat.Policy) bool {
+	for _, role := range policy.Roles {
+		if role.IsPublicRole() || b.isMemberOfRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// isMemberOfRole returns true if the current user is the given role or a
+// member of it, and records the result in the metadata.
+func (b *Builder) isMemberOfRole(role username.SQLUsername) bool {
+	isMember, err := b.catalog.IsMemberOfRole(b.ctx, role)
+	if err != nil {
+		panic(err)
+	}
+	b.factory.Metadata().AddRoleMembership(role, isMember)
+	return isMember
+}
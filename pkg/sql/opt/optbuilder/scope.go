@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treewindow"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 )
@@ -1016,7 +1017,10 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 	case *tree.FuncExpr:
 		def, err := t.Func.Resolve(s.builder.semaCtx.SearchPath)
 		if err != nil {
-			panic(err)
+			if pgerror.GetPGCode(err) != pgcode.UndefinedFunction {
+				panic(err)
+			}
+			t, def = s.resolveUserDefinedAggregate(t, err)
 		}
 
 		if isGenerator(def) && s.replaceSRFs {
@@ -1156,6 +1160,42 @@ func isOrderedSetAggregate(def *tree.FunctionDefinition) (*tree.FunctionDefiniti
 // the variables referenced by the aggregate (or the current scope if the
 // aggregate references no variables). The aggOutScope.groupby.aggs slice is
 // used later by the Builder to build aggregations in the aggregation scope.
+// resolveUserDefinedAggregate resolves the name of f, which is not the name of
+// a built-in function, to a user-defined aggregate. It returns a copy of f
+// which references the definition of the aggregate, and the definition. If
+// there is no such aggregate, it panics with resolveErr, the error returned
+// when resolving the name of f as a built-in function.
+func (s *scope) resolveUserDefinedAggregate(
+	f *tree.FuncExpr, resolveErr error,
+) (*tree.FuncExpr, *tree.FunctionDefinition) {
+	un, ok := f.Func.FunctionReference.(*tree.UnresolvedName)
+	if !ok || un.Star {
+		panic(resolveErr)
+	}
+	name, err := un.ToUnresolvedObjectName(tree.NoAnnotation)
+	if err != nil {
+		panic(resolveErr)
+	}
+	def, err := s.builder.catalog.ResolveAggregate(s.builder.ctx, name)
+	if err != nil {
+		panic(err)
+	}
+	if def == nil {
+		panic(resolveErr)
+	}
+	if f.WindowDef != nil {
+		panic(unimplemented.New("user-defined window function",
+			"user-defined aggregates cannot be used as window functions"))
+	}
+	// The definition of the aggregate is not a data source, so the memo cannot
+	// detect that it is stale.
+	s.builder.DisableMemoReuse = true
+
+	fCopy := *f
+	fCopy.Func.FunctionReference = def
+	return &fCopy, def
+}
+
 func (s *scope) replaceAggregate(f *tree.FuncExpr, def *tree.FunctionDefinition) tree.Expr {
 	f, def = s.replaceCount(f, def)
 
@@ -1226,6 +1266,7 @@ func (s *scope) replaceAggregate(f *tree.FuncExpr, def *tree.FunctionDefinition)
 
 	private := memo.FunctionPrivate{
 		Name:       def.Name,
+		Typ:        f.ResolvedType(),
 		Properties: &def.FunctionProperties,
 		Overload:   f.ResolvedOverload(),
 	}
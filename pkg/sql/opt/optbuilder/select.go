@@ -11,6 +11,7 @@
 package optbuilder
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
@@ -23,10 +24,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/asof"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/errors"
 )
 
@@ -53,10 +56,18 @@ func (b *Builder) buildDataSource(
 			telemetry.Inc(sqltelemetry.IndexHintUseCounter)
 			telemetry.Inc(sqltelemetry.IndexHintSelectUseCounter)
 			indexFlags = source.IndexFlags
+		} else if flags := b.hints.indexHintFlags(source); flags != nil {
+			indexFlags = flags
 		}
 		if source.As.Alias != "" {
 			locking = locking.filter(source.As.Alias)
 		}
+		if source.TableSample != nil {
+			b.tableSample = source.TableSample
+		}
+		if source.SystemTime != nil {
+			b.systemTime = source.SystemTime
+		}
 
 		outScope = b.buildDataSource(source.Expr, indexFlags, locking, inScope)
 
@@ -77,6 +88,12 @@ func (b *Builder) buildDataSource(
 
 		// CTEs take precedence over other data sources.
 		if cte := inScope.resolveCTE(tn); cte != nil {
+			if b.tableSample != nil {
+				panic(errTableSampleNotTable)
+			}
+			if b.systemTime != nil {
+				panic(errSystemTimeNotTable)
+			}
 			locking.ignoreLockingForCTE()
 			outScope = inScope.push()
 			inCols := make(opt.ColList, len(cte.cols), len(cte.cols)+len(inScope.ordering))
@@ -113,7 +130,7 @@ func (b *Builder) buildDataSource(
 		switch t := ds.(type) {
 		case cat.Table:
 			tabMeta := b.addTable(t, &resName)
-			return b.buildScan(
+			outScope = b.buildScan(
 				tabMeta,
 				tableOrdinals(t, columnKinds{
 					includeMutations: false,
@@ -122,11 +139,25 @@ func (b *Builder) buildDataSource(
 				}),
 				indexFlags, locking, inScope,
 			)
+			b.addRowLevelSecurityFilter(tabMeta, tree.PolicyCommandSelect, outScope)
+			return outScope
 
 		case cat.Sequence:
+			if b.tableSample != nil {
+				panic(errTableSampleNotTable)
+			}
+			if b.systemTime != nil {
+				panic(errSystemTimeNotTable)
+			}
 			return b.buildSequenceSelect(t, &resName, inScope)
 
 		case cat.View:
+			if b.tableSample != nil {
+				panic(errTableSampleNotTable)
+			}
+			if b.systemTime != nil {
+				panic(errSystemTimeNotTable)
+			}
 			return b.buildView(t, &resName, locking, inScope)
 
 		default:
@@ -407,7 +438,9 @@ func (b *Builder) buildScanFromTableRef(
 
 	tn := tree.MakeUnqualifiedTableName(tab.Name())
 	tabMeta := b.addTable(tab, &tn)
-	return b.buildScan(tabMeta, ordinals, indexFlags, locking, inScope)
+	outScope = b.buildScan(tabMeta, ordinals, indexFlags, locking, inScope)
+	b.addRowLevelSecurityFilter(tabMeta, tree.PolicyCommandSelect, outScope)
+	return outScope
 }
 
 // addTable adds a table to the metadata and returns the TableMeta. The table
@@ -580,6 +613,44 @@ func (b *Builder) buildScan(
 				}
 			}
 		}
+		if indexFlags.ChangesSince != "" {
+			// Time-bound scans are only supported on the primary index, so we
+			// force it and disallow the plans that would read from the secondary
+			// indexes.
+			private.Flags.ChangesSince = b.evalChangesSince(tab, indexFlags.ChangesSince)
+			private.Flags.ForceIndex = true
+			private.Flags.Index = cat.PrimaryIndex
+			private.Flags.NoZigzagJoin = true
+		}
+	}
+	if b.tableSample != nil {
+		// The join readers look up the rows without sampling them, so zigzag
+		// joins are disallowed.
+		private.Flags.SampleMethod, private.Flags.SampleProbability = b.evalTableSample(tab, b.tableSample)
+		private.Flags.NoZigzagJoin = true
+		b.tableSample = nil
+	}
+	if b.systemTime != nil {
+		// The revision history is only read from the primary index; the
+		// secondary indexes cannot be used to find the rows, since the
+		// revisions of a row may not match the current index entries.
+		if private.Flags.ForceIndex && private.Flags.Index != cat.PrimaryIndex {
+			panic(pgerror.New(pgcode.FeatureNotSupported,
+				"FOR SYSTEM_TIME cannot be used with a secondary index"))
+		}
+		if locking.isSet() {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"%s is not allowed with FOR SYSTEM_TIME", locking.get().Strength))
+		}
+		if b.evalCtx.AsOfSystemTime != nil && b.evalCtx.AsOfSystemTime.BoundedStaleness {
+			panic(pgerror.New(pgcode.FeatureNotSupported,
+				"FOR SYSTEM_TIME cannot be used with bounded staleness reads"))
+		}
+		private.Flags.SystemTimeStart, private.Flags.SystemTimeEnd = b.evalSystemTime(tab, b.systemTime)
+		private.Flags.ForceIndex = true
+		private.Flags.Index = cat.PrimaryIndex
+		private.Flags.NoZigzagJoin = true
+		b.systemTime = nil
 	}
 	if locking.isSet() {
 		private.Locking = locking.get()
@@ -1130,6 +1201,11 @@ func (b *Builder) buildFromTables(
 			return b.buildFromWithLateral(tables, locking, inScope)
 		}
 	}
+	if b.hints.leading != nil {
+		if outScope = b.buildFromTablesWithLeading(tables, locking, inScope); outScope != nil {
+			return outScope
+		}
+	}
 	return b.buildFromTablesRightDeep(tables, locking, inScope)
 }
 
@@ -1153,7 +1229,8 @@ func (b *Builder) buildFromTables(
 func (b *Builder) buildFromTablesRightDeep(
 	tables tree.TableExprs, locking lockingSpec, inScope *scope,
 ) (outScope *scope) {
-	outScope = b.buildDataSource(tables[0], nil /* indexFlags */, locking, inScope)
+	first := tables[0]
+	outScope = b.buildDataSource(first, nil /* indexFlags */, locking, inScope)
 
 	// Recursively build table join.
 	tables = tables[1:]
@@ -1169,7 +1246,10 @@ func (b *Builder) buildFromTablesRightDeep(
 
 	left := outScope.expr
 	right := tableScope.expr
-	outScope.expr = b.factory.ConstructInnerJoin(left, right, memo.TrueFilter, memo.EmptyJoinPrivate)
+	outScope.expr = b.constructHintedInnerJoin(
+		left, right, hintTableNames(nil /* names */, first),
+		hintTableNames(nil /* names */, tables...), 0, /* flags */
+	)
 	return outScope
 }
 
@@ -1227,6 +1307,123 @@ func (b *Builder) buildFromWithLateral(
 	return outScope
 }
 
+// evalChangesSince evaluates the timestamp of the CHANGES_SINCE index flag
+// specified for a scan of the given table.
+func (b *Builder) evalChangesSince(tab cat.Table, changesSince string) hlc.Timestamp {
+	if !b.evalCtx.Settings.Version.IsActive(b.ctx, clusterversion.TimeBoundScans) {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"CHANGES_SINCE is not supported until the cluster is upgraded to version %s",
+			clusterversion.ByKey(clusterversion.TimeBoundScans)))
+	}
+	if tab.FamilyCount() > 1 {
+		// The rows are filtered at the KV level, so only the column families that
+		// changed would be returned.
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"CHANGES_SINCE is not supported on table %q with multiple column families",
+			tab.Name()))
+	}
+	ts, err := asof.DatumToHLC(b.evalCtx, b.evalCtx.GetStmtTimestamp(), tree.NewDString(changesSince))
+	if err != nil {
+		panic(pgerror.Wrap(err, pgcode.InvalidParameterValue, "CHANGES_SINCE"))
+	}
+	// The timestamp can be relative to the statement timestamp, so the memo
+	// cannot be reused.
+	b.DisableMemoReuse = true
+	return ts
+}
+
+var errSystemTimeNotTable = pgerror.New(pgcode.FeatureNotSupported,
+	"FOR SYSTEM_TIME clause can only be applied to tables")
+
+// evalSystemTime evaluates the bounds of the FOR SYSTEM_TIME clause specified
+// for a scan of the given table.
+func (b *Builder) evalSystemTime(
+	tab cat.Table, systemTime *tree.SystemTime,
+) (start, end hlc.Timestamp) {
+	if !b.evalCtx.Settings.Version.IsActive(b.ctx, clusterversion.SystemVersioning) {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"FOR SYSTEM_TIME is not supported until the cluster is upgraded to version %s",
+			clusterversion.ByKey(clusterversion.SystemVersioning)))
+	}
+	if tab.IsVirtualTable() {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"FOR SYSTEM_TIME is not supported on virtual table %q", tab.Name()))
+	}
+	if tab.FamilyCount() > 1 {
+		// The revisions of the column families are read independently, so they
+		// would not be assembled into the rows that were visible together.
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"FOR SYSTEM_TIME is not supported on table %q with multiple column families",
+			tab.Name()))
+	}
+	evalBound := func(expr tree.Expr) hlc.Timestamp {
+		texpr, err := tree.TypeCheck(b.ctx, expr, b.semaCtx, types.Any)
+		if err != nil {
+			panic(err)
+		}
+		if tree.ContainsVars(texpr) {
+			panic(pgerror.New(pgcode.InvalidParameterValue, "FOR SYSTEM_TIME bounds must be constants"))
+		}
+		d, err := eval.Expr(b.evalCtx, texpr)
+		if err != nil {
+			panic(err)
+		}
+		ts, err := asof.DatumToHLC(b.evalCtx, b.evalCtx.GetStmtTimestamp(), d)
+		if err != nil {
+			panic(pgerror.Wrap(err, pgcode.InvalidParameterValue, "FOR SYSTEM_TIME"))
+		}
+		return ts
+	}
+	start, end = evalBound(systemTime.Start), evalBound(systemTime.End)
+	if end.Less(start) {
+		panic(pgerror.Newf(pgcode.InvalidParameterValue,
+			"FOR SYSTEM_TIME start %s must not be after end %s", start, end))
+	}
+	// The bounds can be relative to the statement timestamp, so the memo
+	// cannot be reused.
+	b.DisableMemoReuse = true
+	return start, end
+}
+
+var errTableSampleNotTable = pgerror.New(pgcode.FeatureNotSupported,
+	"TABLESAMPLE clause can only be applied to tables")
+
+// evalTableSample evaluates the sampling percentage of the TABLESAMPLE clause
+// specified for a scan of the given table and returns the sampling method and
+// the probability with which the rows are sampled.
+func (b *Builder) evalTableSample(
+	tab cat.Table, tableSample *tree.TableSample,
+) (tree.TableSampleMethod, float64) {
+	if !b.evalCtx.Settings.Version.IsActive(b.ctx, clusterversion.TableSampleScans) {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"TABLESAMPLE is not supported until the cluster is upgraded to version %s",
+			clusterversion.ByKey(clusterversion.TableSampleScans)))
+	}
+	if tab.IsVirtualTable() {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"TABLESAMPLE is not supported on virtual table %q", tab.Name()))
+	}
+	texpr, err := tree.TypeCheckAndRequire(b.ctx, tableSample.Percent, b.semaCtx, types.Float, "TABLESAMPLE")
+	if err != nil {
+		panic(err)
+	}
+	if tree.ContainsVars(texpr) {
+		panic(pgerror.New(pgcode.InvalidParameterValue, "TABLESAMPLE percentage must be a constant"))
+	}
+	d, err := eval.Expr(b.evalCtx, texpr)
+	if err != nil {
+		panic(err)
+	}
+	if d == tree.DNull {
+		panic(pgerror.New(pgcode.InvalidParameterValue, "TABLESAMPLE percentage cannot be null"))
+	}
+	percent := float64(*d.(*tree.DFloat))
+	if !(percent >= 0 && percent <= 100) {
+		panic(pgerror.New(pgcode.InvalidParameterValue, "sample percentage must be between 0 and 100"))
+	}
+	return tableSample.Method, percent / 100
+}
+
 // validateAsOf ensures that any AS OF SYSTEM TIME timestamp is consistent with
 // that of the root statement.
 func (b *Builder) validateAsOf(asOfClause tree.AsOfClause) {
@@ -0,0 +1,338 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// optimizerHintFlags maps the names of the join method hints to the flags of
+// the joins they apply to.
+var optimizerHintFlags = map[string]memo.JoinFlags{
+	"hash_join":   memo.AllowOnlyHashJoin,
+	"merge_join":  memo.AllowOnlyMergeJoin,
+	"lookup_join": memo.AllowOnlyLookupJoinIntoRight,
+}
+
+// IsOptimizerHint returns true if the statement hint with the given name is
+// honored by the optimizer.
+func IsOptimizerHint(name string) bool {
+	if _, ok := optimizerHintFlags[name]; ok {
+		return true
+	}
+	return name == "leading" || name == "index"
+}
+
+// HasOptimizerHints returns true if any of the given statement hints is
+// honored by the optimizer.
+func HasOptimizerHints(hints []parser.StatementHint) bool {
+	for i := range hints {
+		if IsOptimizerHint(hints[i].Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// optimizerHints are the optimizer hints given in the hint comments of a
+// statement, for example:
+//
+//   SELECT /*+ hash_join(a b) leading((a b) c) index(c c_idx) */ ...
+//
+// The tables are referred to by their alias, or by their unqualified name if
+// they have no alias. Hints naming tables that are not part of the statement
+// are ignored.
+type optimizerHints struct {
+	// joins are the join method hints, like hash_join(a b), which apply to the
+	// joins of exactly the given tables.
+	joins []joinMethodHint
+
+	// leading is the join tree given by a leading hint, like leading((a b) c),
+	// which applies to FROM clauses that include all its tables. It is nil if
+	// there is no leading hint.
+	leading *leadingNode
+
+	// indexes maps tables to the index given by an index hint, like
+	// index(a a_idx), which is used like a@a_idx.
+	indexes map[tree.Name]tree.UnrestrictedName
+}
+
+// joinMethodHint is a hint like hash_join(a b).
+type joinMethodHint struct {
+	name   string
+	tables []tree.Name
+	flags  memo.JoinFlags
+}
+
+// leadingNode is a node of the join tree of a leading hint. It is either a
+// table, or the join of the left and right nodes in that order.
+type leadingNode struct {
+	table       tree.Name
+	left, right *leadingNode
+}
+
+// isTable returns true if the node is a table.
+func (n *leadingNode) isTable() bool {
+	return n.left == nil
+}
+
+// tables appends the tables of the node to the given list.
+func (n *leadingNode) tables(names []tree.Name) []tree.Name {
+	if n.isTable() {
+		return append(names, n.table)
+	}
+	return n.right.tables(n.left.tables(names))
+}
+
+// buildOptimizerHints returns the optimizer hints among the given statement
+// hints. It panics if a hint has invalid arguments.
+func buildOptimizerHints(hints []parser.StatementHint) optimizerHints {
+	var res optimizerHints
+	for _, hint := range hints {
+		switch hint.Name {
+		case "hash_join", "merge_join", "lookup_join":
+			if len(hint.Args) < 2 {
+				panic(pgerror.Newf(pgcode.Syntax,
+					"%s hint expects at least two tables, got %d", hint.Name, len(hint.Args)))
+			}
+			tables := make([]tree.Name, len(hint.Args))
+			for i, arg := range hint.Args {
+				if _, ok := parser.SplitStatementHintList(arg); ok {
+					panic(pgerror.Newf(pgcode.Syntax, "%s hint expects table names, got %s", hint.Name, arg))
+				}
+				tables[i] = tree.Name(arg)
+			}
+			res.joins = append(res.joins, joinMethodHint{
+				name:   hint.Name,
+				tables: tables,
+				flags:  optimizerHintFlags[hint.Name],
+			})
+
+		case "leading":
+			if res.leading != nil {
+				panic(pgerror.New(pgcode.Syntax, "leading hint given more than once"))
+			}
+			res.leading = buildLeadingNode(hint.Args)
+			if res.leading.isTable() {
+				panic(pgerror.New(pgcode.Syntax, "leading hint expects at least two tables"))
+			}
+			tables := res.leading.tables(nil /* names */)
+			for i := range tables {
+				for j := i + 1; j < len(tables); j++ {
+					if tables[i] == tables[j] {
+						panic(pgerror.Newf(pgcode.Syntax,
+							"table %s appears more than once in leading hint", tables[i]))
+					}
+				}
+			}
+
+		case "index":
+			if len(hint.Args) != 2 {
+				panic(pgerror.Newf(pgcode.Syntax,
+					"index hint expects a table and an index, got %d arguments", len(hint.Args)))
+			}
+			if res.indexes == nil {
+				res.indexes = make(map[tree.Name]tree.UnrestrictedName)
+			}
+			res.indexes[tree.Name(hint.Args[0])] = tree.UnrestrictedName(hint.Args[1])
+		}
+	}
+	return res
+}
+
+// buildLeadingNode returns the join tree of the given list of arguments of a
+// leading hint. The elements of a list are joined left-deep in the order of
+// the list, so leading(a b c) is the same as leading((a b) c).
+func buildLeadingNode(args []string) *leadingNode {
+	var res *leadingNode
+	for _, arg := range args {
+		n := &leadingNode{table: tree.Name(arg)}
+		if elems, ok := parser.SplitStatementHintList(arg); ok {
+			n = buildLeadingNode(elems)
+		}
+		if res == nil {
+			res = n
+		} else {
+			res = &leadingNode{left: res, right: n}
+		}
+	}
+	return res
+}
+
+// joinFlags returns the flags given by the join method hints for the join of
+// the tables of the left and right inputs. commute is true if the inputs must
+// be swapped to honor a lookup_join hint, because the table looked up into,
+// which is the last table of the hint, is part of the left input.
+func (h *optimizerHints) joinFlags(left, right []tree.Name) (_ memo.JoinFlags, commute bool) {
+	var flags memo.JoinFlags
+	for i := range h.joins {
+		hint := &h.joins[i]
+		if !sameTables(hint.tables, left, right) {
+			continue
+		}
+		flags = hint.flags
+		if hint.name == "lookup_join" {
+			commute = containsTable(left, hint.tables[len(hint.tables)-1])
+		}
+	}
+	return flags, commute
+}
+
+// sameTables returns true if the given list of tables contains exactly the
+// tables of the left and right lists.
+func sameTables(tables, left, right []tree.Name) bool {
+	if len(tables) != len(left)+len(right) {
+		return false
+	}
+	for _, t := range tables {
+		if !containsTable(left, t) && !containsTable(right, t) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsTable(tables []tree.Name, t tree.Name) bool {
+	for i := range tables {
+		if tables[i] == t {
+			return true
+		}
+	}
+	return false
+}
+
+// hintTableNames appends to names the names by which the tables of the given
+// table expressions are referred to in optimizer hints: their alias, or their
+// unqualified name if they have no alias.
+func hintTableNames(names []tree.Name, texprs ...tree.TableExpr) []tree.Name {
+	for _, texpr := range texprs {
+		switch t := texpr.(type) {
+		case *tree.AliasedTableExpr:
+			if t.As.Alias != "" {
+				names = append(names, t.As.Alias)
+			} else {
+				names = hintTableNames(names, t.Expr)
+			}
+		case *tree.TableName:
+			names = append(names, t.ObjectName)
+		case *tree.ParenTableExpr:
+			names = hintTableNames(names, t.Expr)
+		case *tree.JoinTableExpr:
+			names = hintTableNames(names, t.Left, t.Right)
+		}
+	}
+	return names
+}
+
+// indexHintFlags returns the index flags given by an index hint for the given
+// table expression, or nil if there is no such hint.
+func (h *optimizerHints) indexHintFlags(source *tree.AliasedTableExpr) *tree.IndexFlags {
+	if _, ok := source.Expr.(*tree.TableName); !ok || h.indexes == nil {
+		return nil
+	}
+	names := hintTableNames(nil /* names */, source)
+	if idx, ok := h.indexes[names[0]]; ok {
+		return &tree.IndexFlags{Index: idx}
+	}
+	return nil
+}
+
+// constructHintedInnerJoin constructs the inner join of the given inputs with
+// the flags given by the join method hints for their tables, combined with
+// the given flags.
+func (b *Builder) constructHintedInnerJoin(
+	left, right memo.RelExpr, leftTables, rightTables []tree.Name, flags memo.JoinFlags,
+) memo.RelExpr {
+	hintFlags, commute := b.hints.joinFlags(leftTables, rightTables)
+	if commute {
+		left, right = right, left
+	}
+	flags |= hintFlags
+	if flags.Empty() {
+		return b.factory.ConstructInnerJoin(left, right, memo.TrueFilter, memo.EmptyJoinPrivate)
+	}
+	return b.factory.ConstructInnerJoin(left, right, memo.TrueFilter, &memo.JoinPrivate{Flags: flags})
+}
+
+// buildFromTablesWithLeading builds the FROM tables with the tables of the
+// leading hint joined first, in the order of the hint. The result is joined
+// with the other FROM tables, which can be reordered. It returns nil if the
+// FROM tables don't include every table of the hint.
+//
+// See Builder.buildStmt for a description of the remaining input and
+// return values.
+func (b *Builder) buildFromTablesWithLeading(
+	tables tree.TableExprs, locking lockingSpec, inScope *scope,
+) (outScope *scope) {
+	leading := b.hints.leading
+	ords := make(map[tree.Name]int, len(tables))
+	for i := range tables {
+		if names := hintTableNames(nil /* names */, tables[i]); len(names) == 1 {
+			ords[names[0]] = i
+		}
+	}
+	for _, t := range leading.tables(nil /* names */) {
+		if _, ok := ords[t]; !ok {
+			return nil
+		}
+	}
+
+	scopes := make([]*scope, len(tables))
+	for i := range tables {
+		scopes[i] = b.buildDataSource(tables[i], nil /* indexFlags */, locking, inScope)
+	}
+	inLeading := make([]bool, len(tables))
+	var build func(n *leadingNode) (memo.RelExpr, []tree.Name)
+	build = func(n *leadingNode) (memo.RelExpr, []tree.Name) {
+		if n.isTable() {
+			ord := ords[n.table]
+			inLeading[ord] = true
+			return scopes[ord].expr, []tree.Name{n.table}
+		}
+		left, leftTables := build(n.left)
+		right, rightTables := build(n.right)
+		join := b.constructHintedInnerJoin(
+			left, right, leftTables, rightTables, memo.PreserveJoinOrder,
+		)
+		return join, append(leftTables, rightTables...)
+	}
+	expr, _ := build(leading)
+
+	// Join the other tables right-deep, like buildFromTablesRightDeep.
+	var rest memo.RelExpr
+	for i := len(tables) - 1; i >= 0; i-- {
+		if inLeading[i] {
+			continue
+		}
+		if rest == nil {
+			rest = scopes[i].expr
+		} else {
+			rest = b.factory.ConstructInnerJoin(scopes[i].expr, rest, memo.TrueFilter, memo.EmptyJoinPrivate)
+		}
+	}
+	if rest != nil {
+		expr = b.factory.ConstructInnerJoin(expr, rest, memo.TrueFilter, memo.EmptyJoinPrivate)
+	}
+
+	// The columns are in the order of the FROM tables.
+	outScope = scopes[0]
+	for _, s := range scopes[1:] {
+		// Check that the same table name is not used multiple times.
+		b.validateJoinTableNames(outScope, s)
+		outScope.appendColumnsFromScope(s)
+	}
+	outScope.expr = expr
+	return outScope
+}
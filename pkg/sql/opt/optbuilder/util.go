@@ -557,9 +557,14 @@ func (b *Builder) resolveTableForMutation(
 		alias = *outerAlias
 	}
 
-	// We can't mutate materialized views.
+	// We can't mutate materialized views, unless the view is being refreshed
+	// incrementally.
 	if tab.IsMaterializedView() {
-		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate materialized view %q", tab.Name()))
+		if !b.evalCtx.SessionData().AllowMaterializedViewMutations {
+			panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate materialized view %q", tab.Name()))
+		}
+		// The memo must not be reused by sessions that can't mutate the view.
+		b.DisableMemoReuse = true
 	}
 
 	return tab, depName, alias, columns
@@ -159,6 +159,11 @@ func init() {
 		buildChildReqOrdering: ordinalityBuildChildReqOrdering,
 		buildProvidedOrdering: ordinalityBuildProvided,
 	}
+	funcMap[opt.BarrierOp] = funcs{
+		canProvideOrdering:    barrierCanProvideOrdering,
+		buildChildReqOrdering: barrierBuildChildReqOrdering,
+		buildProvidedOrdering: barrierBuildProvided,
+	}
 	funcMap[opt.MergeJoinOp] = funcs{
 		canProvideOrdering:    mergeJoinCanProvideOrdering,
 		buildChildReqOrdering: mergeJoinBuildChildReqOrdering,
@@ -73,6 +73,10 @@ func ScanPrivateCanProvide(
 		if s.HardLimit.Reverse() {
 			direction = rev
 		}
+	} else if !s.Flags.SystemTimeEnd.IsEmpty() {
+		// The revision history of a table can only be read in the forward
+		// direction.
+		direction = fwd
 	} else if s.Flags.Direction != 0 {
 		direction = fwd
 		if s.Flags.Direction == tree.Descending {
@@ -99,6 +99,8 @@ func (t TableID) index() int {
 // Currently, the following annotations are in use:
 //   - WeakKeys: weak keys derived from the base table
 //   - Stats: statistics derived from the base table
+//   - InvertedHistograms: histograms of the inverted index keys of columns
+//     without an inverted index
 //
 // To add an additional annotation, increase the value of maxTableAnnIDCount and
 // add a call to NewTableAnnID.
@@ -111,7 +113,7 @@ var tableAnnIDCount TableAnnID
 // called. Calling more than this number of times results in a panic. Having
 // a maximum enables a static annotation array to be inlined into the metadata
 // table struct.
-const maxTableAnnIDCount = 2
+const maxTableAnnIDCount = 3
 
 // TableMeta stores information about one of the tables stored in the metadata.
 //
@@ -298,6 +298,11 @@ func (tc *Catalog) RoleExists(ctx context.Context, role username.SQLUsername) (b
 	return true, nil
 }
 
+// IsMemberOfRole is part of the cat.Catalog interface.
+func (tc *Catalog) IsMemberOfRole(ctx context.Context, role username.SQLUsername) (bool, error) {
+	return true, nil
+}
+
 func (tc *Catalog) resolveSchema(toResolve *cat.SchemaName) (cat.Schema, cat.SchemaName, error) {
 	if string(toResolve.CatalogName) != testDB {
 		return nil, cat.SchemaName{}, pgerror.Newf(pgcode.InvalidSchemaName,
@@ -832,6 +837,36 @@ func (tt *Table) IsPartitionAllBy() bool {
 	return false
 }
 
+// Owner is part of the cat.Table interface.
+func (tt *Table) Owner() username.SQLUsername {
+	return username.RootUserName()
+}
+
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (tt *Table) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (tt *Table) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (tt *Table) Policy(i int) cat.Policy {
+	panic(errors.AssertionFailedf("no policies"))
+}
+
+// TriggerCount is part of the cat.Table interface.
+func (tt *Table) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (tt *Table) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("no triggers"))
+}
+
 // FindOrdinal returns the ordinal of the column with the given name.
 func (tt *Table) FindOrdinal(name string) int {
 	for i, col := range tt.Columns {
@@ -64,6 +64,13 @@ func (tc *Catalog) ResolveType(
 	return typ, nil
 }
 
+// ResolveAggregate is part of the cat.Catalog interface.
+func (tc *Catalog) ResolveAggregate(
+	context.Context, *tree.UnresolvedObjectName,
+) (*tree.FunctionDefinition, error) {
+	return nil, nil
+}
+
 // ResolveTypeByOID is part of the cat.Catalog interface.
 func (tc *Catalog) ResolveTypeByOID(context.Context, oid.Oid) (*types.T, error) {
 	return nil, errors.Newf("ResolveTypeByOID not supported in the test catalog")
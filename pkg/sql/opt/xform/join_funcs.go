@@ -338,6 +338,19 @@ func (c *CustomFuncs) generateLookupJoinsImpl(
 	if joinPrivate.Flags.Has(memo.DisallowLookupJoinIntoRight) {
 		return
 	}
+	if !scanPrivate.Flags.ChangesSince.IsEmpty() {
+		// The lookups performed by the join readers are not time-bound.
+		return
+	}
+	if !scanPrivate.Flags.SystemTimeEnd.IsEmpty() {
+		// The lookups performed by the join readers don't read the revision
+		// history.
+		return
+	}
+	if scanPrivate.Flags.SampleMethod != tree.NoTableSample {
+		// The lookups performed by the join readers don't sample the rows.
+		return
+	}
 	md := c.e.mem.Metadata()
 	inputProps := input.Relational()
 
@@ -693,6 +706,19 @@ func (c *CustomFuncs) GenerateInvertedJoins(
 	if joinPrivate.Flags.Has(memo.DisallowInvertedJoinIntoRight) {
 		return
 	}
+	if !scanPrivate.Flags.ChangesSince.IsEmpty() {
+		// The lookups performed by the join readers are not time-bound.
+		return
+	}
+	if !scanPrivate.Flags.SystemTimeEnd.IsEmpty() {
+		// The lookups performed by the join readers don't read the revision
+		// history.
+		return
+	}
+	if scanPrivate.Flags.SampleMethod != tree.NoTableSample {
+		// The lookups performed by the join readers don't sample the rows.
+		return
+	}
 
 	inputCols := input.Relational().OutputCols
 	var pkCols opt.ColList
@@ -52,6 +52,11 @@ func (c *CustomFuncs) CanLimitFilteredScan(
 		// redundant Limit operators would be discarded.
 		return false
 	}
+	if scanPrivate.Flags.SampleMethod != tree.NoTableSample {
+		// The limit must be applied to the sampled rows, so it cannot be pushed
+		// into the scan.
+		return false
+	}
 
 	md := c.e.mem.Metadata()
 	if scanPrivate.Constraint == nil && scanPrivate.PartialIndexPredicate(md) == nil {
@@ -102,6 +107,10 @@ func (c *CustomFuncs) GenerateLimitedScans(
 	if scanPrivate.IsVirtualTable(c.e.mem.Metadata()) && !required.Any() {
 		return
 	}
+	if scanPrivate.Flags.SampleMethod != tree.NoTableSample {
+		// The limit must be applied to the sampled rows.
+		return
+	}
 	limitVal := int64(*limit.(*tree.DInt))
 
 	var pkCols opt.ColSet
@@ -211,6 +220,11 @@ func (c *CustomFuncs) SplitLimitedSelectIntoUnionScansOrSelects(
 	limit tree.Datum,
 	filters memo.FiltersExpr,
 ) (_ memo.RelExpr, ok bool) {
+	if sp.Flags.SampleMethod != tree.NoTableSample {
+		// The limited scans could return fewer rows than the original limit
+		// since the rows are sampled, so don't split sampled scans.
+		return nil, false
+	}
 
 	cons, ok := c.getKnownScanConstraint(sp)
 	if !ok {
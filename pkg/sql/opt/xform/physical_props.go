@@ -171,7 +171,7 @@ func BuildChildPhysicalProps(
 			}
 		}
 
-	case opt.OrdinalityOp, opt.ProjectOp, opt.ProjectSetOp:
+	case opt.BarrierOp, opt.OrdinalityOp, opt.ProjectOp, opt.ProjectSetOp:
 		childProps.LimitHint = parentProps.LimitHint
 
 	case opt.TopKOp:
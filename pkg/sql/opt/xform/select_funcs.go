@@ -841,6 +841,11 @@ func (c *CustomFuncs) partitionValuesFilters(
 func (c *CustomFuncs) GenerateInvertedIndexScans(
 	grp memo.RelExpr, scanPrivate *memo.ScanPrivate, filters memo.FiltersExpr,
 ) {
+	if scanPrivate.Flags.SampleMethod != tree.NoTableSample {
+		// An inverted index can contain multiple entries for the same row, so
+		// the rows wouldn't be sampled with the same probability.
+		return
+	}
 	var pkCols opt.ColSet
 	var sb indexScanBuilder
 	sb.Init(c, scanPrivate.Table)
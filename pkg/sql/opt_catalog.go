@@ -35,11 +35,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
@@ -325,6 +327,55 @@ func (oc *optCatalog) ResolveType(
 	return oc.planner.ResolveType(ctx, name)
 }
 
+// ResolveAggregate is part of the cat.Catalog interface.
+func (oc *optCatalog) ResolveAggregate(
+	ctx context.Context, name *tree.UnresolvedObjectName,
+) (*tree.FunctionDefinition, error) {
+	if !oc.planner.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.UserDefinedAggregates) {
+		return nil, nil
+	}
+	dbName := oc.planner.CurrentDatabase()
+	if name.HasExplicitCatalog() {
+		dbName = name.Catalog()
+	}
+	if dbName == "" {
+		return nil, nil
+	}
+	db, err := oc.planner.Descriptors().GetImmutableDatabaseByName(
+		ctx, oc.planner.Txn(), dbName, tree.DatabaseLookupFlags{},
+	)
+	if err != nil || db == nil {
+		return nil, err
+	}
+
+	// The aggregate is resolved in the first schema of the search path that
+	// defines an aggregate with the given name.
+	var def *tree.FunctionDefinition
+	lookup := func(scName string) error {
+		sc, err := oc.planner.Descriptors().GetImmutableSchemaByName(
+			ctx, oc.planner.Txn(), db, scName, tree.SchemaLookupFlags{},
+		)
+		if err != nil || sc == nil {
+			return err
+		}
+		if aggs := findUserDefinedAggregates(sc, name.Object()); len(aggs) > 0 {
+			def = builtins.MakeUserDefinedAggregateDefinition(name.Object(), aggs)
+			return iterutil.StopIteration()
+		}
+		return nil
+	}
+	if name.HasExplicitSchema() {
+		err = lookup(name.Schema())
+	} else {
+		searchPath := oc.planner.CurrentSearchPath()
+		err = searchPath.IterateSearchPath(lookup)
+	}
+	if err != nil && !iterutil.Done(err) {
+		return nil, err
+	}
+	return def, nil
+}
+
 func getDescFromCatalogObjectForPermissions(o cat.Object) (catalog.Descriptor, error) {
 	switch t := o.(type) {
 	case *optSchema:
@@ -443,6 +494,15 @@ func (oc *optCatalog) RoleExists(ctx context.Context, role username.SQLUsername)
 	return RoleExists(ctx, oc.planner.ExecCfg(), oc.planner.Txn(), role)
 }
 
+// IsMemberOfRole is part of the cat.Catalog interface.
+func (oc *optCatalog) IsMemberOfRole(
+	ctx context.Context, role username.SQLUsername,
+) (bool, error) {
+	return oc.planner.checkRolePredicate(ctx, oc.planner.User(), func(r username.SQLUsername) bool {
+		return r == role
+	})
+}
+
 // dataSourceForDesc returns a data source wrapper for the given descriptor.
 // The wrapper might come from the cache, or it may be created now.
 func (oc *optCatalog) dataSourceForDesc(
@@ -1242,6 +1302,51 @@ func (ot *optTable) IsPartitionAllBy() bool {
 	return ot.desc.IsPartitionAllBy()
 }
 
+// Owner is part of the cat.Table interface.
+func (ot *optTable) Owner() username.SQLUsername {
+	return ot.desc.GetPrivileges().Owner()
+}
+
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optTable) IsRowLevelSecurityEnabled() bool {
+	return ot.desc.IsRowLevelSecurityEnabled()
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optTable) PolicyCount() int {
+	return len(ot.desc.GetPolicies())
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optTable) Policy(i int) cat.Policy {
+	policy := &ot.desc.GetPolicies()[i]
+	roles := make([]username.SQLUsername, len(policy.Roles))
+	for j, r := range policy.Roles {
+		roles[j] = username.MakeSQLUsernameFromPreNormalizedString(r)
+	}
+	return cat.Policy{
+		Name:      tree.Name(policy.Name),
+		Command:   policyCommandFromProto(policy.Command),
+		Roles:     roles,
+		UsingExpr: policy.UsingExpr,
+	}
+}
+
+// TriggerCount is part of the cat.Table interface.
+func (ot *optTable) TriggerCount() int {
+	return len(ot.desc.GetTriggers())
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optTable) Trigger(i int) cat.Trigger {
+	trigger := &ot.desc.GetTriggers()[i]
+	events := make(tree.TriggerEvents, len(trigger.Events))
+	for j, e := range trigger.Events {
+		events[j] = triggerEventFromProto(e)
+	}
+	return cat.Trigger{Name: tree.Name(trigger.Name), Events: events}
+}
+
 // lookupColumnOrdinal returns the ordinal of the column with the given ID. A
 // cache makes the lookup O(1).
 func (ot *optTable) lookupColumnOrdinal(colID descpb.ColumnID) (int, error) {
@@ -2167,6 +2272,36 @@ func (ot *optVirtualTable) IsPartitionAllBy() bool {
 	return false
 }
 
+// Owner is part of the cat.Table interface.
+func (ot *optVirtualTable) Owner() username.SQLUsername {
+	return ot.desc.GetPrivileges().Owner()
+}
+
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optVirtualTable) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optVirtualTable) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optVirtualTable) Policy(i int) cat.Policy {
+	panic(errors.AssertionFailedf("no policies"))
+}
+
+// TriggerCount is part of the cat.Table interface.
+func (ot *optVirtualTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optVirtualTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("no triggers"))
+}
+
 // CollectTypes is part of the cat.DataSource interface.
 func (ot *optVirtualTable) CollectTypes(ord int) (descpb.IDs, error) {
 	col := ot.desc.AllColumns()[ord]
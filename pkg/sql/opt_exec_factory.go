@@ -41,6 +41,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -49,6 +50,9 @@ type execFactory struct {
 	// isExplain is true if this factory is used to build a statement inside
 	// EXPLAIN or EXPLAIN ANALYZE.
 	isExplain bool
+	// triggers contains the rows modified by the mutations of the plan that
+	// fire row-level triggers.
+	triggers []*rowTriggerEvents
 }
 
 var _ exec.Factory = &execFactory{}
@@ -122,6 +126,11 @@ func (ef *execFactory) ConstructScan(
 	scan.lockingStrength = descpb.ToScanLockingStrength(params.Locking.Strength)
 	scan.lockingWaitPolicy = descpb.ToScanLockingWaitPolicy(params.Locking.WaitPolicy)
 	scan.localityOptimized = params.LocalityOptimized
+	scan.changesSince = params.ChangesSince
+	scan.sampleMethod = params.SampleMethod
+	scan.sampleProbability = params.SampleProbability
+	scan.systemTimeStart = params.SystemTimeStart
+	scan.systemTimeEnd = params.SystemTimeEnd
 	if !ef.isExplain && !ef.planner.isInternalPlanner {
 		idxUsageKey := roachpb.IndexUsageKey{
 			TableID: roachpb.TableID(tabDesc.GetID()),
@@ -346,6 +355,7 @@ func (ef *execFactory) ConstructHashJoin(
 	leftEqCols, rightEqCols []exec.NodeColumnOrdinal,
 	leftEqColsAreKey, rightEqColsAreKey bool,
 	extraOnCond tree.TypedExpr,
+	rightEstimatedRowCount uint64,
 ) (exec.Node, error) {
 	p := ef.planner
 	leftSrc := asDataSource(left)
@@ -368,7 +378,9 @@ func (ef *execFactory) ConstructHashJoin(
 
 	pred.onCond = pred.iVarHelper.Rebind(extraOnCond)
 
-	return p.makeJoinNode(leftSrc, rightSrc, pred), nil
+	n := p.makeJoinNode(leftSrc, rightSrc, pred)
+	n.rightEstimatedRowCount = rightEstimatedRowCount
+	return n, nil
 }
 
 // ConstructApplyJoin is part of the exec.Factory interface.
@@ -378,11 +390,15 @@ func (ef *execFactory) ConstructApplyJoin(
 	rightColumns colinfo.ResultColumns,
 	onCond tree.TypedExpr,
 	planRightSideFn exec.ApplyJoinPlanRightSideFn,
+	leftBoundCols []exec.NodeColumnOrdinal,
+	cacheRightSide bool,
 ) (exec.Node, error) {
 	leftSrc := asDataSource(left)
 	pred := makePredicate(joinType, leftSrc.columns, rightColumns)
 	pred.onCond = pred.iVarHelper.Rebind(onCond)
-	return newApplyJoinNode(joinType, leftSrc, rightColumns, pred, planRightSideFn)
+	return newApplyJoinNode(
+		joinType, leftSrc, rightColumns, pred, planRightSideFn, leftBoundCols, cacheRightSide,
+	)
 }
 
 // ConstructMergeJoin is part of the exec.Factory interface.
@@ -495,6 +511,9 @@ func (ef *execFactory) addAggregations(n *groupNode, aggregations []exec.AggInfo
 			agg.Distinct,
 		)
 		f.filterRenderIdx = int(agg.Filter)
+		if agg.UserDefined != nil {
+			f.userDefined = agg.UserDefined.(*descpb.UserDefinedAggregate)
+		}
 
 		n.funcs = append(n.funcs, f)
 	}
@@ -1121,7 +1140,31 @@ func (ef *execFactory) ConstructPlan(
 	if spool, ok := root.(*spoolNode); ok {
 		root = spool.source
 	}
-	return constructPlan(ef.planner, root, subqueries, cascades, checks, rootRowCount)
+	plan, err := constructPlan(ef.planner, root, subqueries, cascades, checks, rootRowCount)
+	if err != nil {
+		return nil, err
+	}
+	plan.(*planComponents).triggers = ef.triggers
+	return plan, nil
+}
+
+// addRowTriggerEvents returns the rowTriggerEvents that buffer the rows
+// modified by a mutation of the given table, and registers them so that the
+// triggers are executed after the main query. It returns nil if no row-level
+// trigger fires for the mutation.
+func (ef *execFactory) addRowTriggerEvents(
+	desc catalog.TableDescriptor,
+	event descpb.TriggerDescriptor_Event,
+	oldCols, newCols []catalog.Column,
+) *rowTriggerEvents {
+	if ef.isExplain {
+		return nil
+	}
+	ev := newRowTriggerEvents(desc, event, oldCols, newCols)
+	if ev != nil {
+		ef.triggers = append(ef.triggers, ev)
+	}
+	return ev
 }
 
 // urlOutputter handles writing strings into an encoded URL for EXPLAIN (OPT,
@@ -1322,6 +1365,9 @@ func (ef *execFactory) ConstructInsert(
 			ti:         tableInserter{ri: ri},
 			checkOrds:  checkOrdSet,
 			insertCols: ri.InsertCols,
+			triggers: ef.addRowTriggerEvents(
+				tabDesc, descpb.TriggerDescriptor_INSERT, nil /* oldCols */, ri.InsertCols,
+			),
 		},
 	}
 
@@ -1520,6 +1566,9 @@ func (ef *execFactory) ConstructUpdate(
 			updateValues:   make(tree.Datums, len(ru.UpdateCols)),
 			updateColsIdx:  updateColsIdx,
 			numPassthrough: len(passthrough),
+			triggers: ef.addRowTriggerEvents(
+				tabDesc, descpb.TriggerDescriptor_UPDATE, ru.FetchCols, ru.FetchCols,
+			),
 		},
 	}
 
@@ -1573,6 +1622,11 @@ func (ef *execFactory) ConstructUpsert(
 ) (exec.Node, error) {
 	ctx := ef.planner.extendedEvalCtx.Ctx()
 
+	if cat.HasTriggersOn(table, tree.TriggerEventInsert) || cat.HasTriggersOn(table, tree.TriggerEventUpdate) {
+		return nil, unimplemented.New("upsert triggers",
+			"UPSERT and INSERT ... ON CONFLICT DO UPDATE are not supported on tables with INSERT or UPDATE triggers")
+	}
+
 	// Derive table and column descriptors.
 	rowsNeeded := !returnColOrdSet.Empty()
 	tabDesc := table.(*optTable).desc
@@ -1702,6 +1756,9 @@ func (ef *execFactory) ConstructDelete(
 		run: deleteRun{
 			td:                        tableDeleter{rd: rd, alloc: ef.planner.alloc},
 			partialIndexDelValsOffset: len(rd.FetchCols),
+			triggers: ef.addRowTriggerEvents(
+				tabDesc, descpb.TriggerDescriptor_DELETE, rd.FetchCols, nil, /* newCols */
+			),
 		},
 	}
 
@@ -12,6 +12,7 @@ package paramparse
 
 import (
 	"context"
+	"strconv"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/geo/geoindex"
@@ -142,6 +143,23 @@ func boolFromDatum(evalCtx *eval.Context, key string, datum tree.Datum) (bool, e
 	return bool(*s), nil
 }
 
+// parseSplitPolicy parses the value of the split_policy storage parameter,
+// which is either 'none' or 'prefix(N)', and returns N, or 0 for 'none'.
+func parseSplitPolicy(key string, str string) (uint32, error) {
+	str = strings.ToLower(strings.TrimSpace(str))
+	if str == "none" {
+		return 0, nil
+	}
+	if strings.HasPrefix(str, "prefix(") && strings.HasSuffix(str, ")") {
+		n, err := strconv.ParseUint(strings.TrimSpace(str[len("prefix("):len(str)-1]), 10, 32)
+		if err == nil && n > 0 {
+			return uint32(n), nil
+		}
+	}
+	return 0, pgerror.Newf(pgcode.InvalidParameterValue,
+		`invalid value for %s: %q, expected 'none' or 'prefix(N)' with N > 0`, key, str)
+}
+
 func intFromDatum(evalCtx *eval.Context, key string, datum tree.Datum) (int64, error) {
 	intDatum := datum
 	if stringVal, err := DatumAsString(evalCtx, key, datum); err == nil {
@@ -496,6 +514,63 @@ var tableParams = map[string]tableParam{
 			return nil
 		},
 	},
+	`system_versioning`: {
+		onSet: func(ctx context.Context, po *TableStorageParamObserver, semaCtx *tree.SemaContext,
+			evalCtx *eval.Context, key string, datum tree.Datum) error {
+			if po.tableDesc.Temporary {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"cannot enable system versioning on a temporary table")
+			}
+			systemVersioning, err := boolFromDatum(evalCtx, key, datum)
+			if err != nil {
+				return err
+			}
+			if !systemVersioning {
+				po.tableDesc.SystemVersioning = nil
+			} else if po.tableDesc.SystemVersioning == nil {
+				// The protected timestamp record is created once the storage
+				// parameters are applied.
+				po.tableDesc.SystemVersioning = &descpb.SystemVersioning{}
+			}
+			return nil
+		},
+		onReset: func(po *TableStorageParamObserver, evalCtx *eval.Context, key string) error {
+			po.tableDesc.SystemVersioning = nil
+			return nil
+		},
+	},
+	`split_policy`: {
+		onSet: func(ctx context.Context, po *TableStorageParamObserver, semaCtx *tree.SemaContext,
+			evalCtx *eval.Context, key string, datum tree.Datum) error {
+			if po.tableDesc.Temporary {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"cannot set a split policy on a temporary table")
+			}
+			str, err := DatumAsString(evalCtx, key, datum)
+			if err != nil {
+				return err
+			}
+			prefixColumns, err := parseSplitPolicy(key, str)
+			if err != nil {
+				return err
+			}
+			if prefixColumns == 0 {
+				po.tableDesc.SplitPolicy = nil
+				return nil
+			}
+			if po.tableDesc.SplitPolicy == nil {
+				// The job that maintains the splits is created once the storage
+				// parameters are applied.
+				po.tableDesc.SplitPolicy = &descpb.SplitPolicy{}
+			}
+			po.tableDesc.SplitPolicy.PrefixColumns = prefixColumns
+			return nil
+		},
+		onReset: func(po *TableStorageParamObserver, evalCtx *eval.Context, key string) error {
+			po.tableDesc.SplitPolicy = nil
+			return nil
+		},
+	},
 	catpb.AutoStatsEnabledTableSettingName: {
 		onSet:   autoStatsEnabledSettingFunc,
 		onReset: autoStatsTableSettingResetFunc,
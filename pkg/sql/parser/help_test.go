@@ -130,12 +130,21 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE INDEX blah ON bloh (x,y) STORING ??`, `CREATE INDEX`},
 		{`CREATE INDEX blah ON bloh (x) ??`, `CREATE INDEX`},
 
+		{`CREATE AGGREGATE ??`, `CREATE AGGREGATE`},
+		{`CREATE AGGREGATE a(INT) (SFUNC = ??`, `CREATE AGGREGATE`},
+
 		{`CREATE DATABASE IF ??`, `CREATE DATABASE`},
 		{`CREATE DATABASE IF NOT ??`, `CREATE DATABASE`},
 		{`CREATE DATABASE blih ??`, `CREATE DATABASE`},
 
 		{`CREATE EXTENSION ??`, `CREATE EXTENSION`},
 
+		{`CREATE POLICY ??`, `CREATE POLICY`},
+		{`CREATE POLICY p ON t ??`, `CREATE POLICY`},
+
+		{`CREATE TRIGGER ??`, `CREATE TRIGGER`},
+		{`CREATE TRIGGER tr AFTER INSERT ON t ??`, `CREATE TRIGGER`},
+
 		{`CREATE USER blih ??`, `CREATE ROLE`},
 		{`CREATE USER blih WITH ??`, `CREATE ROLE`},
 
@@ -207,6 +216,15 @@ func TestContextualHelp(t *testing.T) {
 
 		{`DROP SCHEMA ??`, `DROP SCHEMA`},
 
+		{`DROP POLICY ??`, `DROP POLICY`},
+		{`DROP POLICY IF ??`, `DROP POLICY`},
+
+		{`DROP TRIGGER ??`, `DROP TRIGGER`},
+		{`DROP TRIGGER IF ??`, `DROP TRIGGER`},
+
+		{`DROP AGGREGATE ??`, `DROP AGGREGATE`},
+		{`DROP AGGREGATE IF ??`, `DROP AGGREGATE`},
+
 		{`EXPLAIN (??`, `EXPLAIN`},
 		{`EXPLAIN SELECT 1 ??`, `SELECT`},
 		{`EXPLAIN INSERT INTO xx (SELECT 1) ??`, `INSERT`},
@@ -0,0 +1,252 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package parser
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// StatementHint is a hint given in a hint comment of a statement. A hint
+// comment is a comment starting with /*+ which contains a list of hints of
+// the form name(arg ...) separated by whitespace, for example:
+//
+//   SELECT /*+ statement_timeout('500ms') hash_join(a b) */ * FROM a, b
+//
+// The arguments are separated by whitespace or commas, and are either
+// single-quoted strings, unquoted words, or parenthesized lists of unquoted
+// words and nested lists, like the (a b) of leading((a b) c).
+type StatementHint struct {
+	// Name is the lowercase name of the hint.
+	Name string
+	// Args are the arguments of the hint, with the quotes of quoted arguments
+	// removed. A list argument is given as its elements separated by single
+	// spaces and enclosed in parentheses, see SplitStatementHintList.
+	Args []string
+}
+
+// parseStatementHints parses the contents of the hint comments of a statement.
+// A comment that cannot be parsed doesn't prevent the statement from running,
+// like any other comment: its hints are skipped, and the error of the first
+// such comment is returned alongside the hints of the other comments.
+func parseStatementHints(comments []string) (hints []StatementHint, retErr error) {
+	for _, comment := range comments {
+		commentHints, err := parseStatementHintComment(comment)
+		if err != nil {
+			if retErr == nil {
+				retErr = pgerror.Wrapf(err, pgcode.Syntax,
+					"invalid statement hint /*+%s*/", comment)
+			}
+			continue
+		}
+		hints = append(hints, commentHints...)
+	}
+	return hints, retErr
+}
+
+// parseStatementHintComment parses the contents of a single hint comment.
+func parseStatementHintComment(comment string) ([]StatementHint, error) {
+	var hints []StatementHint
+	h := hintParser{in: comment}
+	for {
+		h.skipSpace()
+		if h.eof() {
+			return hints, nil
+		}
+		hint, err := h.parseHint()
+		if err != nil {
+			return nil, err
+		}
+		hints = append(hints, hint)
+	}
+}
+
+// SplitStatementHintList returns the elements of an argument of a hint that
+// is a parenthesized list, like the (a b) of leading((a b) c). ok is false if
+// the argument is not a list.
+func SplitStatementHintList(arg string) (elems []string, ok bool) {
+	if len(arg) < 2 || arg[0] != '(' || arg[len(arg)-1] != ')' {
+		return nil, false
+	}
+	h := hintParser{in: arg[1 : len(arg)-1]}
+	for {
+		h.skipSpace()
+		if h.eof() {
+			return elems, true
+		}
+		elem, err := h.parseListElem()
+		if err != nil {
+			// This is a quoted argument that only looks like a list.
+			return nil, false
+		}
+		elems = append(elems, elem)
+	}
+}
+
+// hintParser parses the contents of a hint comment.
+type hintParser struct {
+	in  string
+	pos int
+}
+
+func (h *hintParser) eof() bool {
+	return h.pos >= len(h.in)
+}
+
+func (h *hintParser) peek() byte {
+	if h.eof() {
+		return 0
+	}
+	return h.in[h.pos]
+}
+
+// skipSpace skips whitespace.
+func (h *hintParser) skipSpace() {
+	for !h.eof() {
+		switch h.peek() {
+		case ' ', '\t', '\n', '\r', '\f':
+			h.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (h *hintParser) expect(ch byte) error {
+	h.skipSpace()
+	if h.peek() != ch {
+		return pgerror.Newf(pgcode.Syntax, "expected %q at position %d", ch, h.pos)
+	}
+	h.pos++
+	return nil
+}
+
+// parseHint parses name(arg ...).
+func (h *hintParser) parseHint() (StatementHint, error) {
+	name := h.parseWord()
+	if name == "" {
+		return StatementHint{}, pgerror.Newf(pgcode.Syntax,
+			"expected hint name at position %d", h.pos)
+	}
+	hint := StatementHint{Name: strings.ToLower(name)}
+	if err := h.expect('('); err != nil {
+		return StatementHint{}, err
+	}
+	h.skipSpace()
+	if h.peek() == ')' {
+		h.pos++
+		return hint, nil
+	}
+	for {
+		arg, err := h.parseArg()
+		if err != nil {
+			return StatementHint{}, err
+		}
+		hint.Args = append(hint.Args, arg)
+		h.skipSpace()
+		switch h.peek() {
+		case ',':
+			h.pos++
+		case ')':
+			h.pos++
+			return hint, nil
+		}
+	}
+}
+
+// parseWord parses an unquoted word made of letters, digits, and the
+// characters _ . and -.
+func (h *hintParser) parseWord() string {
+	start := h.pos
+	for !h.eof() {
+		ch := h.peek()
+		if (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') ||
+			(ch >= '0' && ch <= '9') || ch == '_' || ch == '.' || ch == '-' {
+			h.pos++
+			continue
+		}
+		break
+	}
+	return h.in[start:h.pos]
+}
+
+// parseArg parses a single-quoted string, in which a quote is escaped by
+// doubling it, an unquoted word, or a parenthesized list.
+func (h *hintParser) parseArg() (string, error) {
+	h.skipSpace()
+	if h.peek() == '(' {
+		return h.parseList()
+	}
+	if h.peek() != '\'' {
+		if word := h.parseWord(); word != "" {
+			return word, nil
+		}
+		return "", pgerror.Newf(pgcode.Syntax, "expected hint argument at position %d", h.pos)
+	}
+	h.pos++
+	var buf strings.Builder
+	for !h.eof() {
+		ch := h.in[h.pos]
+		h.pos++
+		if ch != '\'' {
+			buf.WriteByte(ch)
+			continue
+		}
+		if h.peek() != '\'' {
+			return buf.String(), nil
+		}
+		buf.WriteByte('\'')
+		h.pos++
+	}
+	return "", pgerror.New(pgcode.Syntax, "unterminated string")
+}
+
+// parseList parses a parenthesized list of unquoted words and nested lists,
+// separated by whitespace or commas. It returns the list with its elements
+// separated by single spaces.
+func (h *hintParser) parseList() (string, error) {
+	h.pos++
+	h.skipSpace()
+	if h.peek() == ')' {
+		return "", pgerror.Newf(pgcode.Syntax, "empty list at position %d", h.pos)
+	}
+	var elems []string
+	for {
+		h.skipSpace()
+		elem, err := h.parseListElem()
+		if err != nil {
+			return "", err
+		}
+		elems = append(elems, elem)
+		h.skipSpace()
+		switch h.peek() {
+		case ',':
+			h.pos++
+		case ')':
+			h.pos++
+			return "(" + strings.Join(elems, " ") + ")", nil
+		}
+	}
+}
+
+// parseListElem parses an element of a list, which is either an unquoted word
+// or a nested list.
+func (h *hintParser) parseListElem() (string, error) {
+	if h.peek() == '(' {
+		return h.parseList()
+	}
+	if word := h.parseWord(); word != "" {
+		return word, nil
+	}
+	return "", pgerror.Newf(pgcode.Syntax, "expected list element at position %d", h.pos)
+}
@@ -80,7 +80,7 @@ func (l *lexer) Lex(lval *sqlSymType) int {
 	*lval = l.tokens[l.lastPos]
 
 	switch lval.id {
-	case NOT, WITH, AS, GENERATED, NULLS, RESET, ROLE, USER, ON, TENANT, SET:
+	case NOT, WITH, AS, GENERATED, NULLS, RESET, ROLE, USER, ON, TENANT, SET, FOR:
 		nextToken := sqlSymType{}
 		if l.lastPos+1 < len(l.tokens) {
 			nextToken = l.tokens[l.lastPos+1]
@@ -154,6 +154,11 @@ func (l *lexer) Lex(lval *sqlSymType) int {
 			case ALL:
 				lval.id = TENANT_ALL
 			}
+		case FOR:
+			switch nextToken.id {
+			case SYSTEM_TIME:
+				lval.id = FOR_LA
+			}
 		case SET:
 			switch nextToken.id {
 			case TRACING:
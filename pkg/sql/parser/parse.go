@@ -61,6 +61,14 @@ type Statement struct {
 	// NumAnnotations indicates the number of annotations in the tree. It is equal
 	// to the maximum annotation index.
 	NumAnnotations tree.AnnotationIdx
+
+	// Hints are the hints given in the hint comments of the statement.
+	Hints []StatementHint
+
+	// HintsErr is set if some of the hint comments of the statement could not
+	// be parsed. The hints of these comments are not part of Hints; the
+	// executor only reports the error if reject_unknown_statement_hints is set.
+	HintsErr error
 }
 
 // Statements is a list of parsed statements.
@@ -136,6 +144,7 @@ func (p *Parser) parseOneWithInt(sql string, nakedIntType *types.T) (Statement,
 func (p *Parser) scanOneStmt() (sql string, tokens []sqlSymType, done bool) {
 	var lval sqlSymType
 	tokens = p.tokBuf[:0]
+	p.scanner.ResetHints()
 
 	// Scan the first token.
 	for {
@@ -176,6 +185,9 @@ func (p *Parser) parseWithDepth(depth int, sql string, nakedIntType *types.T) (S
 		if err != nil {
 			return nil, err
 		}
+		if comments := p.scanner.Hints(); len(comments) > 0 && stmt.AST != nil {
+			stmt.Hints, stmt.HintsErr = parseStatementHints(comments)
+		}
 		if stmt.AST != nil {
 			stmts = append(stmts, stmt)
 		}
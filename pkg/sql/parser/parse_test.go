@@ -413,7 +413,6 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`ALTER AGGREGATE a`, 74775, `alter aggregate`, ``},
 		{`ALTER FUNCTION a`, 17511, `alter function`, ``},
 
-		{`CREATE AGGREGATE a`, 74775, `create aggregate`, ``},
 		{`CREATE CAST a`, 0, `create cast`, ``},
 		{`CREATE CONSTRAINT TRIGGER a`, 28296, `create constraint`, ``},
 		{`CREATE CONVERSION a`, 0, `create conversion`, ``},
@@ -432,10 +431,9 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`CREATE SUBSCRIPTION a`, 0, `create subscription`, ``},
 		{`CREATE TABLESPACE a`, 54113, `create tablespace`, ``},
 		{`CREATE TEXT SEARCH a`, 7821, `create text`, ``},
-		{`CREATE TRIGGER a`, 28296, `create`, ``},
+		{`CREATE TRIGGER a AFTER UPDATE OF b ON t FOR EACH ROW AS ''`, 28296, `create trigger update of`, ``},
 
 		{`DROP ACCESS METHOD a`, 0, `drop access method`, ``},
-		{`DROP AGGREGATE a`, 74775, `drop aggregate`, ``},
 		{`DROP CAST a`, 0, `drop cast`, ``},
 		{`DROP COLLATION a`, 0, `drop collation`, ``},
 		{`DROP CONVERSION a`, 0, `drop conversion`, ``},
@@ -452,7 +450,6 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`DROP SERVER a`, 0, `drop server`, ``},
 		{`DROP SUBSCRIPTION a`, 0, `drop subscription`, ``},
 		{`DROP TEXT SEARCH a`, 7821, `drop text`, ``},
-		{`DROP TRIGGER a`, 28296, `drop`, ``},
 
 		{`DISCARD PLANS`, 0, `discard plans`, ``},
 		{`DISCARD SEQUENCES`, 0, `discard sequences`, ``},
@@ -561,8 +558,6 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`CREATE TABLE a(b PG_LSN)`, 0, `pg_lsn`, ``},
 		{`CREATE TABLE a(b POINT)`, 21286, `point`, ``},
 		{`CREATE TABLE a(b POLYGON)`, 21286, `polygon`, ``},
-		{`CREATE TABLE a(b TSQUERY)`, 7821, `tsquery`, ``},
-		{`CREATE TABLE a(b TSVECTOR)`, 7821, `tsvector`, ``},
 		{`CREATE TABLE a(b TXID_SNAPSHOT)`, 0, `txid_snapshot`, ``},
 		{`CREATE TABLE a(b XML)`, 43355, `xml`, ``},
 
@@ -716,6 +711,98 @@ func TestParseNumPlaceholders(t *testing.T) {
 	}
 }
 
+func TestParseStatementHints(t *testing.T) {
+	testData := []struct {
+		in  string
+		exp [][]parser.StatementHint
+		err string
+	}{
+		{in: `SELECT 1`, exp: [][]parser.StatementHint{nil}},
+		{in: `SELECT /* comment */ 1`, exp: [][]parser.StatementHint{nil}},
+		{in: `SELECT /*+ statement_timeout('500ms') */ 1`,
+			exp: [][]parser.StatementHint{{{Name: "statement_timeout", Args: []string{"500ms"}}}}},
+		{in: `/*+ STATEMENT_TIMEOUT(10) */ SELECT 1`,
+			exp: [][]parser.StatementHint{{{Name: "statement_timeout", Args: []string{"10"}}}}},
+		{in: `SELECT 1 /*+ a() b('x''y', z) */`,
+			exp: [][]parser.StatementHint{{{Name: "a"}, {Name: "b", Args: []string{"x'y", "z"}}}}},
+		{in: `SELECT /*+ a(1) */ 1; SELECT 2; /*+ b(2) */ SELECT 3`,
+			exp: [][]parser.StatementHint{
+				{{Name: "a", Args: []string{"1"}}}, nil, {{Name: "b", Args: []string{"2"}}},
+			}},
+		{in: `SELECT '/*+ a(1) */'`, exp: [][]parser.StatementHint{nil}},
+		{in: `SELECT /*+ a(b c) */ 1`,
+			exp: [][]parser.StatementHint{{{Name: "a", Args: []string{"b", "c"}}}}},
+		{in: `SELECT /*+ leading(( a,b ) (c (d e))) index(a a_idx) */ 1`,
+			exp: [][]parser.StatementHint{{
+				{Name: "leading", Args: []string{"(a b)", "(c (d e))"}},
+				{Name: "index", Args: []string{"a", "a_idx"}},
+			}}},
+
+		{in: `SELECT /*+ statement_timeout */ 1`, err: `invalid statement hint`},
+		{in: `SELECT /*+ a('x) */ 1`, err: `unterminated string`},
+		{in: `SELECT /*+ a(b */ 1`, err: `expected hint argument`},
+		{in: `SELECT /*+ a(() b) */ 1`, err: `empty list`},
+		{in: `SELECT /*+ a(('b' c)) */ 1`, err: `expected list element`},
+
+		// The hints of a comment that cannot be parsed are skipped.
+		{in: `SELECT /*+ a(1) */ /*+ b( */ 1`, err: `expected hint argument`,
+			exp: [][]parser.StatementHint{{{Name: "a", Args: []string{"1"}}}}},
+	}
+
+	var p parser.Parser
+	for _, d := range testData {
+		t.Run(d.in, func(t *testing.T) {
+			// Hint comments that cannot be parsed don't prevent the statement
+			// from being parsed.
+			stmts, err := p.Parse(d.in)
+			if err != nil {
+				t.Fatalf("expected success, but found %s", err)
+			}
+			if d.err != "" {
+				if !testutils.IsError(stmts[0].HintsErr, d.err) {
+					t.Fatalf("expected error %q, but found %v", d.err, stmts[0].HintsErr)
+				}
+				if d.exp == nil {
+					if stmts[0].Hints != nil {
+						t.Errorf("expected no hints, but found %v", stmts[0].Hints)
+					}
+					return
+				}
+			} else if stmts[0].HintsErr != nil {
+				t.Fatalf("expected success, but found %s", stmts[0].HintsErr)
+			}
+			var res [][]parser.StatementHint
+			for i := range stmts {
+				res = append(res, stmts[i].Hints)
+			}
+			if !reflect.DeepEqual(res, d.exp) {
+				t.Errorf("expected \n%v\n, but found %v", d.exp, res)
+			}
+		})
+	}
+}
+
+func TestSplitStatementHintList(t *testing.T) {
+	testData := []struct {
+		in  string
+		exp []string
+		ok  bool
+	}{
+		{in: `(a b)`, exp: []string{"a", "b"}, ok: true},
+		{in: `(a (b c))`, exp: []string{"a", "(b c)"}, ok: true},
+		{in: `a`},
+		{in: `(a 'b')`},
+	}
+	for _, d := range testData {
+		t.Run(d.in, func(t *testing.T) {
+			res, ok := parser.SplitStatementHintList(d.in)
+			if ok != d.ok || !reflect.DeepEqual(res, d.exp) {
+				t.Errorf("expected %v, %t, but found %v, %t", d.exp, d.ok, res, ok)
+			}
+		})
+	}
+}
+
 func TestParseOne(t *testing.T) {
 	_, err := parser.ParseOne("SELECT 1; SELECT 2")
 	if !testutils.IsError(err, "expected 1 statement") {
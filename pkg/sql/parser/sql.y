@@ -275,6 +275,24 @@ func (u *sqlSymUnion) roleSpec() tree.RoleSpec {
 func (u *sqlSymUnion) roleSpecList() tree.RoleSpecList {
     return u.val.(tree.RoleSpecList)
 }
+func (u *sqlSymUnion) policyCommand() tree.PolicyCommand {
+    return u.val.(tree.PolicyCommand)
+}
+func (u *sqlSymUnion) triggerActionTime() tree.TriggerActionTime {
+    return u.val.(tree.TriggerActionTime)
+}
+func (u *sqlSymUnion) triggerEvent() tree.TriggerEvent {
+    return u.val.(tree.TriggerEvent)
+}
+func (u *sqlSymUnion) triggerEvents() tree.TriggerEvents {
+    return u.val.(tree.TriggerEvents)
+}
+func (u *sqlSymUnion) aggregateOption() tree.AggregateOption {
+    return u.val.(tree.AggregateOption)
+}
+func (u *sqlSymUnion) aggregateOptions() tree.AggregateOptions {
+    return u.val.(tree.AggregateOptions)
+}
 func (u *sqlSymUnion) user() username.SQLUsername {
     return u.val.(username.SQLUsername)
 }
@@ -324,6 +342,15 @@ func (u *sqlSymUnion) tableNames() tree.TableNames {
 func (u *sqlSymUnion) indexFlags() *tree.IndexFlags {
     return u.val.(*tree.IndexFlags)
 }
+func (u *sqlSymUnion) tableSample() *tree.TableSample {
+    return u.val.(*tree.TableSample)
+}
+func (u *sqlSymUnion) tableSampleMethod() tree.TableSampleMethod {
+    return u.val.(tree.TableSampleMethod)
+}
+func (u *sqlSymUnion) systemTime() *tree.SystemTime {
+    return u.val.(*tree.SystemTime)
+}
 func (u *sqlSymUnion) arraySubscript() *tree.ArraySubscript {
     return u.val.(*tree.ArraySubscript)
 }
@@ -800,13 +827,13 @@ func (u *sqlSymUnion) asTenantClause() tree.TenantID {
 // Ordinary key words in alphabetical order.
 %token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADMIN AFTER AGGREGATE
 %token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC
-%token <str> ASENSITIVE ASYMMETRIC AT ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
+%token <str> ASENSITIVE ASYMMETRIC AT AT_AT ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
 
 %token <str> BACKUP BACKUPS BACKWARD BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
 %token <str> BUCKET_COUNT
 %token <str> BOOLEAN BOTH BOX2D BUNDLE BY
 
-%token <str> CACHE CANCEL CANCELQUERY CASCADE CASE CAST CBRT CHANGEFEED CHAR
+%token <str> CACHE CANCEL CANCELQUERY CASCADE CASE CAST CBRT CHANGEFEED CHANGES_SINCE CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CLOSE
 %token <str> CLUSTER COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
 %token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
@@ -818,16 +845,16 @@ func (u *sqlSymUnion) asTenantClause() tree.TenantID {
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEBUG_PAUSE_ON DEC DECIMAL DEFAULT DEFAULTS
 %token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DESC DESTINATION DETACHED
-%token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
+%token <str> DISABLE DISCARD DISTINCT DO DOMAIN DOUBLE DROP
 
-%token <str> ELSE ENCODING ENCRYPTED ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
+%token <str> EACH ELSE ENABLE ENCODING ENCRYPTED ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXECUTION EXPERIMENTAL
 %token <str> EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
 %token <str> EXPERIMENTAL_AUDIT EXPERIMENTAL_RELOCATE
 %token <str> EXPIRATION EXPLAIN EXPORT EXTENSION EXTRACT EXTRACT_DURATION
 
 %token <str> FAILURE FALSE FAMILY FETCH FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH
-%token <str> FILES FILTER
+%token <str> FILES FILTER FINALFUNC
 %token <str> FIRST FLOAT FLOAT4 FLOAT8 FLOORDIV FOLLOWING FOR FORCE FORCE_INDEX FORCE_ZIGZAG
 %token <str> FOREIGN FORWARD FREEZE FROM FULL FUNCTION FUNCTIONS
 
@@ -839,10 +866,10 @@ func (u *sqlSymUnion) asTenantClause() tree.TenantID {
 
 %token <str> IDENTITY
 %token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMPORT IN INCLUDE
-%token <str> INCLUDING INCREMENT INCREMENTAL INCREMENTAL_LOCATION
+%token <str> INCLUDING INCREMENT INCREMENTAL INCREMENTAL_LOCATION INCREMENTALLY
 %token <str> INET INET_CONTAINED_BY_OR_EQUALS
-%token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INJECT INITIALLY
-%token <str> INNER INSENSITIVE INSERT INT INTEGER
+%token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INITCOND INJECT INITIALLY
+%token <str> INNER INSENSITIVE INSERT INSTEAD INT INTEGER
 %token <str> INTERSECT INTERVAL INTO INTO_DB INVERTED IS ISERROR ISNULL ISOLATION
 
 %token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS
@@ -868,7 +895,7 @@ func (u *sqlSymUnion) asTenantClause() tree.TenantID {
 %token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
 
 %token <str> PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PHYSICAL PLACEMENT PLACING
-%token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLYGON POLYGONM POLYGONZ POLYGONZM
+%token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLICY POLYGON POLYGONM POLYGONZ POLYGONZM
 %token <str> POSITION PRECEDING PRECISION PREPARE PRESERVE PRIMARY PRIOR PRIORITY PRIVILEGES
 %token <str> PROCEDURAL PUBLIC PUBLICATION
 
@@ -880,16 +907,16 @@ func (u *sqlSymUnion) asTenantClause() tree.TenantID {
 %token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESUME RETURNING RETRY REVISION_HISTORY
 %token <str> REVOKE RIGHT ROLE ROLES ROLLBACK ROLLUP ROUTINES ROW ROWS RSHIFT RULE RUNNING
 
-%token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMAS SCRUB SEARCH SECOND SELECT SEQUENCE SEQUENCES
-%token <str> SERIALIZABLE SERVER SESSION SESSIONS SESSION_USER SET SETS SETTING SETTINGS
+%token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMAS SCRUB SEARCH SECOND SECURITY SELECT SEQUENCE SEQUENCES
+%token <str> SERIALIZABLE SERVER SESSION SESSIONS SESSION_USER SET SETS SETTING SETTINGS SFUNC
 %token <str> SHARE SHOW SIMILAR SIMPLE SKIP SKIP_LOCALITIES_CHECK SKIP_MISSING_FOREIGN_KEYS
 %token <str> SKIP_MISSING_SEQUENCES SKIP_MISSING_SEQUENCE_OWNERS SKIP_MISSING_VIEWS SMALLINT SMALLSERIAL SNAPSHOT SOME SPLIT SQL
 %token <str> SQLLOGIN
 
-%token <str> START STATE STATISTICS STATUS STDIN STREAM STRICT STRING STORAGE STORE STORED STORING SUBSTRING SUPER
-%token <str> SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENTS
+%token <str> START STATE STATEMENT STATISTICS STATUS STDIN STREAM STRICT STRING STORAGE STORE STORED STORING STYPE SUBSTRING SUPER
+%token <str> SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SYSTEM_TIME SQRT SUBSCRIPTION STATEMENTS
 
-%token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANTS TESTING_RELOCATE TEXT THEN
+%token <str> TABLE TABLES TABLESAMPLE TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANTS TESTING_RELOCATE TEXT THEN
 %token <str> TIES TIME TIMETZ TIMESTAMP TIMESTAMPTZ TO THROTTLING TRAILING TRACE
 %token <str> TRANSACTION TRANSACTIONS TRANSFER TREAT TRIGGER TRIM TRUE
 %token <str> TRUNCATE TRUSTED TYPE TYPES
@@ -926,8 +953,10 @@ func (u *sqlSymUnion) asTenantClause() tree.TenantID {
 // references.
 // - TENANT_ALL is used to differentiate `ALTER TENANT <id>` from
 // `ALTER TENANT ALL`.
+// - FOR_LA is used to differentiate `FOR SYSTEM_TIME` after a table name from
+// the locking clauses (`FOR UPDATE` etc).
 %token NOT_LA NULLS_LA WITH_LA AS_LA GENERATED_ALWAYS GENERATED_BY_DEFAULT RESET_ALL ROLE_ALL
-%token USER_ALL ON_LA TENANT_ALL SET_TRACING
+%token USER_ALL ON_LA TENANT_ALL SET_TRACING FOR_LA
 
 %union {
   id    int32
@@ -1047,6 +1076,9 @@ func (u *sqlSymUnion) asTenantClause() tree.TenantID {
 %type <tree.Statement> create_role_stmt
 %type <tree.Statement> create_schedule_for_backup_stmt
 %type <tree.Statement> create_schema_stmt
+%type <tree.Statement> create_policy_stmt
+%type <tree.Statement> create_trigger_stmt
+%type <tree.Statement> create_aggregate_stmt
 %type <tree.Statement> create_table_stmt
 %type <tree.Statement> create_table_as_stmt
 %type <tree.Statement> create_view_stmt
@@ -1092,6 +1124,9 @@ func (u *sqlSymUnion) asTenantClause() tree.TenantID {
 %type <tree.Statement> reset_stmt reset_session_stmt reset_csetting_stmt
 %type <tree.Statement> resume_stmt resume_jobs_stmt resume_schedules_stmt resume_all_jobs_stmt
 %type <tree.Statement> drop_schedule_stmt
+%type <tree.Statement> drop_policy_stmt
+%type <tree.Statement> drop_trigger_stmt
+%type <tree.Statement> drop_aggregate_stmt
 %type <tree.Statement> restore_stmt
 %type <tree.StringOrPlaceholderOptList> string_or_placeholder_opt_list
 %type <[]tree.StringOrPlaceholderOptList> list_of_string_or_placeholder_opt_list
@@ -1354,6 +1389,9 @@ func (u *sqlSymUnion) asTenantClause() tree.TenantID {
 %type <*tree.IndexFlags> opt_index_flags
 %type <*tree.IndexFlags> index_flags_param
 %type <*tree.IndexFlags> index_flags_param_list
+%type <*tree.TableSample> opt_tablesample_clause
+%type <*tree.SystemTime> opt_system_time_clause
+%type <tree.TableSampleMethod> tablesample_method
 %type <tree.Expr> a_expr b_expr c_expr d_expr typed_literal
 %type <tree.Expr> substr_from substr_for
 %type <tree.Expr> in_expr
@@ -1465,6 +1503,17 @@ func (u *sqlSymUnion) asTenantClause() tree.TenantID {
 %type <privilege.List> privileges
 %type <[]tree.KVOption> opt_role_options role_options
 %type <tree.AuditMode> audit_mode
+%type <tree.PolicyCommand> opt_policy_command
+%type <tree.RoleSpecList> opt_policy_roles
+%type <tree.Expr> opt_policy_using opt_policy_with_check
+%type <tree.TriggerActionTime> trigger_action_time
+%type <tree.TriggerEvent> trigger_event
+%type <tree.TriggerEvents> trigger_event_list
+%type <bool> trigger_for_each
+%type <tree.Expr> opt_trigger_when
+%type <tree.AggregateOption> aggregate_option
+%type <tree.AggregateOptions> aggregate_option_list
+%type <[]tree.ResolvableTypeReference> aggregate_arg_types
 
 %type <str> relocate_kw
 %type <tree.RelocateSubject> relocate_subject relocate_subject_nonlease
@@ -1536,7 +1585,7 @@ func (u *sqlSymUnion) asTenantClause() tree.TenantID {
 %left      '|'
 %left      '#'
 %left      '&'
-%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND SQRT CBRT
+%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND AT_AT SQRT CBRT
 %left      OPERATOR // if changing the last token before OPERATOR, change all instances of %prec <last token>
 %left      '+' '-'
 %left      '*' '/' FLOORDIV '%'
@@ -1653,6 +1702,7 @@ alter_ddl_stmt:
 //   ALTER TABLE ... CONFIGURE ZONE <zoneconfig>
 //   ALTER TABLE ... SET SCHEMA <newschemaname>
 //   ALTER TABLE ... SET LOCALITY [REGIONAL BY [TABLE IN <region> | ROW] | GLOBAL]
+//   ALTER TABLE ... {ENABLE | DISABLE} ROW LEVEL SECURITY
 //
 // Column qualifiers:
 //   [CONSTRAINT <constraintname>] {NULL | NOT NULL | UNIQUE | PRIMARY KEY | CHECK (<expr>) | DEFAULT <expr>}
@@ -2474,6 +2524,16 @@ alter_table_cmd:
   {
     $$.val = &tree.AlterTableSetAudit{Mode: $3.auditMode()}
   }
+  // ALTER TABLE <name> ENABLE ROW LEVEL SECURITY
+| ENABLE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableSetRowLevelSecurity{Enabled: true}
+  }
+  // ALTER TABLE <name> DISABLE ROW LEVEL SECURITY
+| DISABLE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableSetRowLevelSecurity{Enabled: false}
+  }
   // ALTER TABLE <name> PARTITION BY ...
 | partition_by_table
   {
@@ -2759,7 +2819,7 @@ alter_attribute_action:
 // %Help: REFRESH - recalculate a materialized view
 // %Category: Misc
 // %Text:
-// REFRESH MATERIALIZED VIEW [CONCURRENTLY] view_name [WITH [NO] DATA]
+// REFRESH MATERIALIZED VIEW [CONCURRENTLY] view_name [WITH [NO] DATA | INCREMENTALLY]
 refresh_stmt:
   REFRESH MATERIALIZED VIEW opt_concurrently view_name opt_clear_data
   {
@@ -2780,6 +2840,10 @@ opt_clear_data:
   {
     $$.val = tree.RefreshDataClear
   }
+| INCREMENTALLY
+  {
+    $$.val = tree.RefreshDataIncremental
+  }
 | /* EMPTY */
   {
     $$.val = tree.RefreshDataDefault
@@ -3762,7 +3826,8 @@ comment_text:
 // %Text:
 // CREATE DATABASE, CREATE TABLE, CREATE INDEX, CREATE TABLE AS,
 // CREATE USER, CREATE VIEW, CREATE SEQUENCE, CREATE STATISTICS,
-// CREATE ROLE, CREATE TYPE, CREATE EXTENSION
+// CREATE ROLE, CREATE TYPE, CREATE EXTENSION, CREATE POLICY,
+// CREATE TRIGGER, CREATE AGGREGATE
 create_stmt:
   create_role_stmt     // EXTEND WITH HELP: CREATE ROLE
 | create_ddl_stmt      // help texts in sub-rule
@@ -3793,9 +3858,255 @@ create_extension_stmt:
   }
 | CREATE EXTENSION error // SHOW HELP: CREATE EXTENSION
 
+// %Help: CREATE POLICY - define a new row-level security policy
+// %Category: Priv
+// %Text:
+// CREATE POLICY <name> ON <tablename>
+//   [FOR {ALL | SELECT | UPDATE | DELETE}]
+//   [TO {<rolename> | PUBLIC | CURRENT_USER | SESSION_USER} [, ...]]
+//   USING (<expr>)
+//
+// The policies are only enforced on the tables with row-level security
+// enabled (see ALTER TABLE ... ENABLE ROW LEVEL SECURITY).
+// %SeeAlso: DROP POLICY, ALTER TABLE
+create_policy_stmt:
+  CREATE POLICY name ON table_name opt_policy_command opt_policy_roles opt_policy_using opt_policy_with_check
+  {
+    $$.val = &tree.CreatePolicy{
+      Name: tree.Name($3),
+      Table: $5.unresolvedObjectName(),
+      Command: $6.policyCommand(),
+      Roles: $7.roleSpecList(),
+      Using: $8.expr(),
+      WithCheck: $9.expr(),
+    }
+  }
+| CREATE POLICY error // SHOW HELP: CREATE POLICY
+
+opt_policy_command:
+  FOR ALL
+  {
+    $$.val = tree.PolicyCommandAll
+  }
+| FOR SELECT
+  {
+    $$.val = tree.PolicyCommandSelect
+  }
+| FOR INSERT
+  {
+    $$.val = tree.PolicyCommandInsert
+  }
+| FOR UPDATE
+  {
+    $$.val = tree.PolicyCommandUpdate
+  }
+| FOR DELETE
+  {
+    $$.val = tree.PolicyCommandDelete
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.PolicyCommandAll
+  }
+
+opt_policy_roles:
+  TO role_spec_list
+  {
+    $$.val = $2.roleSpecList()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.RoleSpecList(nil)
+  }
+
+opt_policy_using:
+  USING '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+opt_policy_with_check:
+  WITH CHECK '(' a_expr ')'
+  {
+    $$.val = $4.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+// %Help: CREATE TRIGGER - define a new trigger
+// %Category: DDL
+// %Text:
+// CREATE TRIGGER <name> AFTER <event> [OR ...] ON <tablename>
+//   FOR EACH ROW [WHEN (<condition>)] AS <body>
+//
+// Events:
+//   INSERT, UPDATE, DELETE
+//
+// The body is a string containing one or more SQL statements separated by
+// semicolons. They are executed for each row modified by a statement, after
+// the statement ran. The values of the row before and after the modification
+// can be referred to in the body and in the condition as OLD.<column> and
+// NEW.<column>.
+// %SeeAlso: DROP TRIGGER
+create_trigger_stmt:
+  CREATE TRIGGER name trigger_action_time trigger_event_list ON table_name trigger_for_each opt_trigger_when AS SCONST
+  {
+    $$.val = &tree.CreateTrigger{
+      Name: tree.Name($3),
+      ActionTime: $4.triggerActionTime(),
+      Events: $5.triggerEvents(),
+      Table: $7.unresolvedObjectName(),
+      ForEachRow: $8.bool(),
+      When: $9.expr(),
+      Body: $11,
+    }
+  }
+| CREATE TRIGGER error // SHOW HELP: CREATE TRIGGER
+
+trigger_action_time:
+  BEFORE
+  {
+    $$.val = tree.TriggerActionTimeBefore
+  }
+| AFTER
+  {
+    $$.val = tree.TriggerActionTimeAfter
+  }
+| INSTEAD OF
+  {
+    $$.val = tree.TriggerActionTimeInsteadOf
+  }
+
+trigger_event_list:
+  trigger_event
+  {
+    $$.val = tree.TriggerEvents{$1.triggerEvent()}
+  }
+| trigger_event_list OR trigger_event
+  {
+    $$.val = append($1.triggerEvents(), $3.triggerEvent())
+  }
+
+trigger_event:
+  INSERT
+  {
+    $$.val = tree.TriggerEventInsert
+  }
+| UPDATE
+  {
+    $$.val = tree.TriggerEventUpdate
+  }
+| UPDATE OF name_list { return unimplementedWithIssueDetail(sqllex, 28296, "create trigger update of") }
+| DELETE
+  {
+    $$.val = tree.TriggerEventDelete
+  }
+| TRUNCATE
+  {
+    $$.val = tree.TriggerEventTruncate
+  }
+
+trigger_for_each:
+  FOR opt_each ROW
+  {
+    $$.val = true
+  }
+| FOR opt_each STATEMENT
+  {
+    $$.val = false
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
+opt_each:
+  EACH {}
+| /* EMPTY */ {}
+
+opt_trigger_when:
+  WHEN '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+// %Help: CREATE AGGREGATE - define a new aggregate function
+// %Category: DDL
+// %Text:
+// CREATE AGGREGATE <name> ( <argtype> ) (
+//   SFUNC = <state_func>,
+//   STYPE = <state_type>
+//   [, FINALFUNC = <final_func>]
+//   [, INITCOND = <initial_condition>]
+// )
+//
+// The state of the aggregate starts with the initial condition, or NULL, and
+// is updated for each input row with <state_func>(<state>, <input>). The
+// result of the aggregate is <final_func>(<state>), or the final state if
+// FINALFUNC is omitted. The state and final functions must be built-in
+// functions.
+// %SeeAlso: DROP AGGREGATE
+create_aggregate_stmt:
+  CREATE AGGREGATE db_object_name aggregate_arg_types '(' aggregate_option_list ')'
+  {
+    $$.val = &tree.CreateAggregate{
+      Name: $3.unresolvedObjectName(),
+      ArgTypes: $4.typeReferences(),
+      Options: $6.aggregateOptions(),
+    }
+  }
+| CREATE AGGREGATE error // SHOW HELP: CREATE AGGREGATE
+
+aggregate_arg_types:
+  '(' type_list ')'
+  {
+    $$.val = $2.typeReferences()
+  }
+| '(' ')'
+  {
+    $$.val = []tree.ResolvableTypeReference(nil)
+  }
+
+aggregate_option_list:
+  aggregate_option
+  {
+    $$.val = tree.AggregateOptions{$1.aggregateOption()}
+  }
+| aggregate_option_list ',' aggregate_option
+  {
+    $$.val = append($1.aggregateOptions(), $3.aggregateOption())
+  }
+
+aggregate_option:
+  SFUNC '=' db_object_name
+  {
+    $$.val = tree.AggregateOption{Kind: tree.AggregateOptionStateFunc, Func: $3.unresolvedObjectName().ToUnresolvedName()}
+  }
+| STYPE '=' typename
+  {
+    $$.val = tree.AggregateOption{Kind: tree.AggregateOptionStateType, Type: $3.typeReference()}
+  }
+| FINALFUNC '=' db_object_name
+  {
+    $$.val = tree.AggregateOption{Kind: tree.AggregateOptionFinalFunc, Func: $3.unresolvedObjectName().ToUnresolvedName()}
+  }
+| INITCOND '=' SCONST
+  {
+    $$.val = tree.AggregateOption{Kind: tree.AggregateOptionInitCond, Value: $3}
+  }
+
 create_unsupported:
   CREATE ACCESS METHOD error { return unimplemented(sqllex, "create access method") }
-| CREATE AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate") }
 | CREATE CAST error { return unimplemented(sqllex, "create cast") }
 | CREATE CONSTRAINT TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create constraint") }
 | CREATE CONVERSION error { return unimplemented(sqllex, "create conversion") }
@@ -3812,7 +4123,6 @@ create_unsupported:
 | CREATE SUBSCRIPTION error { return unimplemented(sqllex, "create subscription") }
 | CREATE TABLESPACE error { return unimplementedWithIssueDetail(sqllex, 54113, "create tablespace") }
 | CREATE TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "create text") }
-| CREATE TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create trigger") }
 
 opt_or_replace:
   OR REPLACE {}
@@ -3828,7 +4138,6 @@ opt_procedural:
 
 drop_unsupported:
   DROP ACCESS METHOD error { return unimplemented(sqllex, "drop access method") }
-| DROP AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "drop aggregate") }
 | DROP CAST error { return unimplemented(sqllex, "drop cast") }
 | DROP COLLATION error { return unimplemented(sqllex, "drop collation") }
 | DROP CONVERSION error { return unimplemented(sqllex, "drop conversion") }
@@ -3845,7 +4154,6 @@ drop_unsupported:
 | DROP SERVER error { return unimplemented(sqllex, "drop server") }
 | DROP SUBSCRIPTION error { return unimplemented(sqllex, "drop subscription") }
 | DROP TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text") }
-| DROP TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "drop") }
 
 create_ddl_stmt:
   create_database_stmt // EXTEND WITH HELP: CREATE DATABASE
@@ -3858,6 +4166,9 @@ create_ddl_stmt:
 | create_type_stmt     // EXTEND WITH HELP: CREATE TYPE
 | create_view_stmt     // EXTEND WITH HELP: CREATE VIEW
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
+| create_policy_stmt   // EXTEND WITH HELP: CREATE POLICY
+| create_trigger_stmt  // EXTEND WITH HELP: CREATE TRIGGER
+| create_aggregate_stmt // EXTEND WITH HELP: CREATE AGGREGATE
 
 // %Help: CREATE STATISTICS - create a new table statistic
 // %Category: Misc
@@ -4076,7 +4387,8 @@ discard_stmt:
 // %Category: Group
 // %Text:
 // DROP DATABASE, DROP INDEX, DROP TABLE, DROP VIEW, DROP SEQUENCE,
-// DROP USER, DROP ROLE, DROP TYPE
+// DROP USER, DROP ROLE, DROP TYPE, DROP POLICY, DROP TRIGGER,
+// DROP AGGREGATE
 drop_stmt:
   drop_ddl_stmt      // help texts in sub-rule
 | drop_role_stmt     // EXTEND WITH HELP: DROP ROLE
@@ -4092,6 +4404,9 @@ drop_ddl_stmt:
 | drop_sequence_stmt // EXTEND WITH HELP: DROP SEQUENCE
 | drop_schema_stmt   // EXTEND WITH HELP: DROP SCHEMA
 | drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
+| drop_policy_stmt   // EXTEND WITH HELP: DROP POLICY
+| drop_trigger_stmt  // EXTEND WITH HELP: DROP TRIGGER
+| drop_aggregate_stmt // EXTEND WITH HELP: DROP AGGREGATE
 
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
@@ -4264,6 +4579,79 @@ drop_schema_stmt:
   }
 | DROP SCHEMA error // SHOW HELP: DROP SCHEMA
 
+// %Help: DROP POLICY - remove a row-level security policy
+// %Category: Priv
+// %Text: DROP POLICY [IF EXISTS] <name> ON <tablename>
+// %SeeAlso: CREATE POLICY
+drop_policy_stmt:
+  DROP POLICY name ON table_name
+  {
+    $$.val = &tree.DropPolicy{
+      Name: tree.Name($3),
+      Table: $5.unresolvedObjectName(),
+      IfExists: false,
+    }
+  }
+| DROP POLICY IF EXISTS name ON table_name
+  {
+    $$.val = &tree.DropPolicy{
+      Name: tree.Name($5),
+      Table: $7.unresolvedObjectName(),
+      IfExists: true,
+    }
+  }
+| DROP POLICY error // SHOW HELP: DROP POLICY
+
+// %Help: DROP TRIGGER - remove a trigger
+// %Category: DDL
+// %Text: DROP TRIGGER [IF EXISTS] <name> ON <tablename> [CASCADE | RESTRICT]
+// %SeeAlso: CREATE TRIGGER
+drop_trigger_stmt:
+  DROP TRIGGER name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Name: tree.Name($3),
+      Table: $5.unresolvedObjectName(),
+      IfExists: false,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TRIGGER IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Name: tree.Name($5),
+      Table: $7.unresolvedObjectName(),
+      IfExists: true,
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TRIGGER error // SHOW HELP: DROP TRIGGER
+
+// %Help: DROP AGGREGATE - remove an aggregate function
+// %Category: DDL
+// %Text: DROP AGGREGATE [IF EXISTS] <name> ( <argtype> ) [CASCADE | RESTRICT]
+// %SeeAlso: CREATE AGGREGATE
+drop_aggregate_stmt:
+  DROP AGGREGATE db_object_name aggregate_arg_types opt_drop_behavior
+  {
+    $$.val = &tree.DropAggregate{
+      Name: $3.unresolvedObjectName(),
+      ArgTypes: $4.typeReferences(),
+      IfExists: false,
+      DropBehavior: $5.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE IF EXISTS db_object_name aggregate_arg_types opt_drop_behavior
+  {
+    $$.val = &tree.DropAggregate{
+      Name: $5.unresolvedObjectName(),
+      ArgTypes: $6.typeReferences(),
+      IfExists: true,
+      DropBehavior: $7.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE error // SHOW HELP: DROP AGGREGATE
+
 // %Help: DROP ROLE - remove a user
 // %Category: Priv
 // %Text: DROP ROLE [IF EXISTS] <user> [, ...]
@@ -5083,7 +5471,7 @@ set_exprs_internal:
 // %Text:
 // SET [SESSION] <var> { TO | = } <values...>
 // SET [SESSION] TIME ZONE <tz>
-// SET [SESSION] CHARACTERISTICS AS TRANSACTION ISOLATION LEVEL { SNAPSHOT | SERIALIZABLE }
+// SET [SESSION] CHARACTERISTICS AS TRANSACTION ISOLATION LEVEL { READ COMMITTED | SNAPSHOT | SERIALIZABLE }
 // SET [SESSION] TRACING { TO | = } { on | off | cluster | kv | results } [,...]
 //
 // %SeeAlso: SHOW SESSION, RESET, DISCARD, SHOW, SET CLUSTER SETTING, SET TRANSACTION, SET LOCAL
@@ -5140,7 +5528,7 @@ set_local_stmt:
 // SET [SESSION] TRANSACTION <txnparameters...>
 //
 // Transaction parameters:
-//    ISOLATION LEVEL { SNAPSHOT | SERIALIZABLE }
+//    ISOLATION LEVEL { READ COMMITTED | SNAPSHOT | SERIALIZABLE }
 //    PRIORITY { LOW | NORMAL | HIGH }
 //    AS OF SYSTEM TIME <expr>
 //    [NOT] DEFERRABLE
@@ -5281,11 +5669,11 @@ var_list:
 iso_level:
   READ UNCOMMITTED
   {
-    $$.val = tree.SerializableIsolation
+    $$.val = tree.ReadCommittedIsolation
   }
 | READ COMMITTED
   {
-    $$.val = tree.SerializableIsolation
+    $$.val = tree.ReadCommittedIsolation
   }
 | SNAPSHOT
   {
@@ -9341,7 +9729,7 @@ transaction_stmt:
 // START TRANSACTION [ <txnparameter> [[,] ...] ]
 //
 // Transaction parameters:
-//    ISOLATION LEVEL { SNAPSHOT | SERIALIZABLE }
+//    ISOLATION LEVEL { READ COMMITTED | SNAPSHOT | SERIALIZABLE }
 //    PRIORITY { LOW | NORMAL | HIGH }
 //
 // %SeeAlso: COMMIT, ROLLBACK, WEBDOCS/begin-transaction.html
@@ -10680,6 +11068,11 @@ index_flags_param:
     /* SKIP DOC */
      $$.val = &tree.IndexFlags{ZigzagIndexIDs: []tree.IndexID{tree.IndexID($4.int64())}}
   }
+|
+  CHANGES_SINCE '=' SCONST
+  {
+    $$.val = &tree.IndexFlags{ChangesSince: $3}
+  }
 
 index_flags_param_list:
   index_flags_param
@@ -10732,6 +11125,8 @@ opt_index_flags:
 //   <source> NATURAL [ <jointype> ] JOIN <source>
 //   <source> CROSS JOIN <source>
 //   <source> WITH ORDINALITY
+//   <tablename> [AS] <alias> TABLESAMPLE { BERNOULLI | SYSTEM } ( <percent> )
+//   <tablename> [AS] <alias> FOR SYSTEM_TIME BETWEEN <start> AND <end>
 //   '[' EXPLAIN ... ']'
 //   '[' SHOW ... ']'
 //
@@ -10742,6 +11137,7 @@ opt_index_flags:
 //   '{' NO_FULL_SCAN [, ...] '}'
 //   '{' IGNORE_FOREIGN_KEYS [, ...] '}'
 //   '{' FORCE_ZIGZAG = <idxname> [, ...]  '}'
+//   '{' CHANGES_SINCE = <timestamp> [, ...] '}'
 //
 // Join types:
 //   { INNER | { LEFT | RIGHT | FULL } [OUTER] } [ { HASH | MERGE | LOOKUP | INVERTED } ]
@@ -10758,14 +11154,16 @@ table_ref:
         As:         $4.aliasClause(),
     }
   }
-| relation_expr opt_index_flags opt_ordinality opt_alias_clause
+| relation_expr opt_index_flags opt_ordinality opt_alias_clause opt_system_time_clause opt_tablesample_clause
   {
     name := $1.unresolvedObjectName().ToTableName()
     $$.val = &tree.AliasedTableExpr{
-      Expr:       &name,
-      IndexFlags: $2.indexFlags(),
-      Ordinality: $3.bool(),
-      As:         $4.aliasClause(),
+      Expr:        &name,
+      IndexFlags:  $2.indexFlags(),
+      Ordinality:  $3.bool(),
+      As:          $4.aliasClause(),
+      SystemTime:  $5.systemTime(),
+      TableSample: $6.tableSample(),
     }
   }
 | select_with_parens opt_ordinality opt_alias_clause
@@ -10897,6 +11295,39 @@ opt_ordinality:
     $$.val = false
   }
 
+opt_tablesample_clause:
+  TABLESAMPLE tablesample_method '(' a_expr ')'
+  {
+    $$.val = &tree.TableSample{Method: $2.tableSampleMethod(), Percent: $4.expr()}
+  }
+| /* EMPTY */
+  {
+    $$.val = (*tree.TableSample)(nil)
+  }
+
+opt_system_time_clause:
+  FOR_LA SYSTEM_TIME BETWEEN b_expr AND b_expr
+  {
+    $$.val = &tree.SystemTime{Start: $4.expr(), End: $6.expr()}
+  }
+| /* EMPTY */
+  {
+    $$.val = (*tree.SystemTime)(nil)
+  }
+
+tablesample_method:
+  SYSTEM
+  {
+    $$.val = tree.TableSampleSystem
+  }
+| IDENT
+  {
+    if $1 != "bernoulli" {
+      return setErr(sqllex, errors.Newf("tablesample method %s does not exist", $1))
+    }
+    $$.val = tree.TableSampleBernoulli
+  }
+
 // It may seem silly to separate joined_table from table_ref, but there is
 // method in SQL's madness: if you don't do it this way you get reduce- reduce
 // conflicts, because it's not clear to the parser generator whether to expect
@@ -11944,6 +12375,10 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.Overlaps), Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr AT_AT a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.TSMatches), Left: $1.expr(), Right: $3.expr()}
+  }
 | a_expr INET_CONTAINS_OR_EQUALS a_expr
   {
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction("inet_contains_or_equals"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
@@ -13135,6 +13570,7 @@ all_op:
 | REGIMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.RegIMatch) }
 | NOT_REGIMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.NotRegIMatch) }
 | AND_AND { $$.val = treecmp.MakeComparisonOperator(treecmp.Overlaps) }
+| AT_AT { $$.val = treecmp.MakeComparisonOperator(treecmp.TSMatches) }
 | '~' { $$.val = tree.MakeUnaryOperator(tree.UnaryComplement) }
 | SQRT { $$.val = tree.MakeUnaryOperator(tree.UnarySqrt) }
 | CBRT { $$.val = tree.MakeUnaryOperator(tree.UnaryCbrt) }
@@ -14069,6 +14505,7 @@ unreserved_keyword:
 | CANCELQUERY
 | CASCADE
 | CHANGEFEED
+| CHANGES_SINCE
 | CLOSE
 | CLUSTER
 | COLUMNS
@@ -14112,10 +14549,13 @@ unreserved_keyword:
 | DELIMITER
 | DESTINATION
 | DETACHED
+| DISABLE
 | DISCARD
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
+| ENABLE
 | ENCODING
 | ENCRYPTED
 | ENCRYPTION_PASSPHRASE
@@ -14138,6 +14578,7 @@ unreserved_keyword:
 | FAILURE
 | FILES
 | FILTER
+| FINALFUNC
 | FIRST
 | FOLLOWING
 | FORCE
@@ -14173,10 +14614,13 @@ unreserved_keyword:
 | INCREMENT
 | INCREMENTAL
 | INCREMENTAL_LOCATION
+| INCREMENTALLY
 | INDEXES
 | INHERITS
+| INITCOND
 | INJECT
 | INSERT
+| INSTEAD
 | INTO_DB
 | INVERTED
 | ISOLATION
@@ -14283,6 +14727,7 @@ unreserved_keyword:
 | POINTM
 | POINTZ
 | POINTZM
+| POLICY
 | POLYGONM
 | POLYGONZ
 | POLYGONZM
@@ -14348,6 +14793,7 @@ unreserved_keyword:
 | SCRUB
 | SEARCH
 | SECOND
+| SECURITY
 | SERIALIZABLE
 | SEQUENCE
 | SEQUENCES
@@ -14356,6 +14802,7 @@ unreserved_keyword:
 | SESSIONS
 | SET
 | SETS
+| SFUNC
 | SHARE
 | SHOW
 | SIMPLE
@@ -14371,6 +14818,7 @@ unreserved_keyword:
 | SQLLOGIN
 | START
 | STATE
+| STATEMENT
 | STATEMENTS
 | STATISTICS
 | STDIN
@@ -14380,12 +14828,14 @@ unreserved_keyword:
 | STORING
 | STREAM
 | STRICT
+| STYPE
 | SUBSCRIPTION
 | SUPER
 | SURVIVE
 | SURVIVAL
 | SYNTAX
 | SYSTEM
+| SYSTEM_TIME
 | TABLES
 | TABLESPACE
 | TEMP
@@ -14534,6 +14984,7 @@ type_func_name_no_crdb_extra_keyword:
 | OVERLAPS
 | RIGHT
 | SIMILAR
+| TABLESAMPLE
 
 // CockroachDB-specific keywords that can be used in type/function
 // identifiers.
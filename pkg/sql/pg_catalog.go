@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
@@ -755,6 +756,10 @@ https://www.postgresql.org/docs/9.5/catalog-pg-collation.html`,
 		return forEachDatabaseDesc(ctx, p, dbContext, false /* requiresPrivileges */, func(db catalog.DatabaseDescriptor) error {
 			namespaceOid := h.NamespaceOid(db.GetID(), pgCatalogName)
 			add := func(collName string) error {
+				provider := collProviderICU
+				if collName == tree.DefaultCollationTag {
+					provider = collProviderDefault
+				}
 				return addRow(
 					h.CollationOid(collName),  // oid
 					tree.NewDString(collName), // collname
@@ -765,10 +770,10 @@ https://www.postgresql.org/docs/9.5/catalog-pg-collation.html`,
 					// required by LC_COLLATE and LC_CTYPE.
 					tree.DNull, // collcollate
 					tree.DNull, // collctype
-					// These columns were automatically created by pg_catalog_test's missing column generator.
-					tree.DNull, // collprovider
+					provider,   // collprovider
+					// This column was automatically created by pg_catalog_test's missing column generator.
 					tree.DNull, // collversion
-					tree.DNull, // collisdeterministic
+					tree.MakeDBool(tree.DBool(lex.IsDeterministicCollation(collName))), // collisdeterministic
 				)
 			}
 			if err := add(tree.DefaultCollationTag); err != nil {
@@ -780,11 +785,29 @@ https://www.postgresql.org/docs/9.5/catalog-pg-collation.html`,
 					return err
 				}
 			}
+			for _, collName := range nondeterministicCollations {
+				if err := add(collName); err != nil {
+					return err
+				}
+			}
 			return nil
 		})
 	},
 }
 
+var (
+	collProviderDefault = tree.NewDString("d")
+	collProviderICU     = tree.NewDString("i")
+)
+
+// nondeterministicCollations are listed in pg_collation in addition to the
+// supported locales. Any locale can be made case- or accent-insensitive with
+// the ks keyword of its Unicode extension, like en-u-ks-level2.
+var nondeterministicCollations = []string{
+	"und-u-ks-level1", // case- and accent-insensitive
+	"und-u-ks-level2", // case-insensitive
+}
+
 var (
 	conTypeCheck     = tree.NewDString("c")
 	conTypeFK        = tree.NewDString("f")
@@ -4079,6 +4102,8 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.TimestampFamily:   typCategoryDateTime,
 	types.TimestampTZFamily: typCategoryDateTime,
 	types.ArrayFamily:       typCategoryArray,
+	types.TSQueryFamily:     typCategoryUserDefined,
+	types.TSVectorFamily:    typCategoryUserDefined,
 	types.TupleFamily:       typCategoryPseudo,
 	types.OidFamily:         typCategoryNumeric,
 	types.UuidFamily:        typCategoryUserDefined,
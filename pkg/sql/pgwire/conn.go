@@ -493,15 +493,20 @@ func (c *conn) serveImpl(
 				// To support the 1PC txn fast path, we peek at the next command to
 				// see if it is a Sync. This is because in the extended protocol, an
 				// implicit transaction cannot commit until the Sync is seen. If there's
-				// an error while peeking (for example, there are no bytes in the
-				// buffer), the error is ignored since it will be handled on the next
-				// loop iteration.
-				followedBySync := false
-				if nextMsgType, err := c.rd.Peek(1); err == nil &&
-					pgwirebase.ClientMessageType(nextMsgType[0]) == pgwirebase.ClientMsgSync {
-					followedBySync = true
-				}
-				return false, isSimpleQuery, c.handleExecute(ctx, &c.readBuf, timeReceived, followedBySync)
+				// an error while peeking, the error is ignored since it will be
+				// handled on the next loop iteration.
+				//
+				// If the next message hasn't been received yet, we only wait for it
+				// for a short time. A client using pipeline mode can send a batch of
+				// Bind/Execute messages and only send the Sync later, so waiting until
+				// the next message arrives would delay the execution of the last
+				// statement of the batch until then. In that case, the statements of
+				// the batch are executed in the same implicit transaction, which is
+				// committed when the Sync is received.
+				nextMsgType, ok := c.peekNextMsgType(nextMsgAfterExecuteWait)
+				followedBySync := ok && nextMsgType == pgwirebase.ClientMsgSync
+				pipelined := ok && !followedBySync
+				return false, isSimpleQuery, c.handleExecute(ctx, &c.readBuf, timeReceived, followedBySync, pipelined)
 
 			case pgwirebase.ClientMsgParse:
 				return false, isSimpleQuery, c.handleParse(ctx, &c.readBuf, parser.NakedIntTypeFromDefaultIntSize(atomic.LoadInt32(atomicUnqualifiedIntSize)))
@@ -1151,12 +1156,44 @@ func (c *conn) handleBind(ctx context.Context, buf *pgwirebase.ReadBuffer) error
 		})
 }
 
+// nextMsgAfterExecuteWait is the maximum amount of time the connection waits
+// for the message following an Execute to arrive, when it hasn't been received
+// yet, in order to find out whether it is a Sync.
+const nextMsgAfterExecuteWait = time.Millisecond
+
+// peekNextMsgType returns the type of the next message sent by the client,
+// without consuming it. If the message hasn't been received yet, it waits at
+// most wait for it to arrive. ok is false if no message could be peeked at.
+func (c *conn) peekNextMsgType(wait time.Duration) (_ pgwirebase.ClientMessageType, ok bool) {
+	if c.rd.Buffered() == 0 {
+		rtc, isReadTimeoutConn := c.conn.(*readTimeoutConn)
+		if !isReadTimeoutConn {
+			return 0, false
+		}
+		rtc.maxReadDeadline = timeutil.Now().Add(wait)
+		defer func() { rtc.maxReadDeadline = time.Time{} }()
+	}
+	// An error returned by Peek is not retained by the reader, so the next read
+	// is attempted again.
+	b, err := c.rd.Peek(1)
+	if err != nil {
+		return 0, false
+	}
+	return pgwirebase.ClientMessageType(b[0]), true
+}
+
 // An error is returned iff the statement buffer has been closed. In that case,
 // the connection should be considered toast.
 func (c *conn) handleExecute(
-	ctx context.Context, buf *pgwirebase.ReadBuffer, timeReceived time.Time, followedBySync bool,
+	ctx context.Context,
+	buf *pgwirebase.ReadBuffer,
+	timeReceived time.Time,
+	followedBySync, pipelined bool,
 ) error {
 	telemetry.Inc(sqltelemetry.ExecuteRequestCounter)
+	if pipelined {
+		telemetry.Inc(sqltelemetry.PipelinedExecuteRequestCounter)
+	}
 	portalName, err := buf.GetString()
 	if err != nil {
 		return c.stmtBuf.Push(ctx, sql.SendError{Err: err})
@@ -1789,6 +1826,9 @@ type readTimeoutConn struct {
 	// the Read() returns that error. Future calls to Read() are allowed, in which
 	// case checkExitConds() will be called again.
 	checkExitConds func() error
+	// maxReadDeadline, if set, bounds the time Read() waits for data. Once it
+	// has passed, Read() returns the timeout error instead of retrying.
+	maxReadDeadline time.Time
 }
 
 // NewReadTimeoutConn wraps the given connection with a readTimeoutConn.
@@ -1813,13 +1853,19 @@ func (c *readTimeoutConn) Read(b []byte) (int, error) {
 		if err := c.checkExitConds(); err != nil {
 			return 0, err
 		}
-		if err := c.SetReadDeadline(timeutil.Now().Add(readTimeout)); err != nil {
+		deadline := timeutil.Now().Add(readTimeout)
+		bounded := !c.maxReadDeadline.IsZero() && c.maxReadDeadline.Before(deadline)
+		if bounded {
+			deadline = c.maxReadDeadline
+		}
+		if err := c.SetReadDeadline(deadline); err != nil {
 			return 0, err
 		}
 		n, err := c.Conn.Read(b)
 		if err != nil {
-			// Continue if the error is due to timing out.
-			if ne := (net.Error)(nil); errors.As(err, &ne) && ne.Timeout() {
+			// Continue if the error is due to timing out, unless the read was
+			// bounded by maxReadDeadline.
+			if ne := (net.Error)(nil); errors.As(err, &ne) && ne.Timeout() && !bounded {
 				continue
 			}
 		}
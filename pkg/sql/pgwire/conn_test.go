@@ -1161,6 +1161,40 @@ func TestReadTimeoutConnExits(t *testing.T) {
 	}
 }
 
+// TestReadTimeoutConnMaxReadDeadline asserts that a readTimeoutConn returns a
+// timeout error once its maxReadDeadline has passed, and that reads are
+// performed normally afterwards.
+func TestReadTimeoutConnMaxReadDeadline(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	// Cannot use net.Pipe because deadlines are not supported.
+	ln, err := net.Listen(util.TestAddr.Network(), util.TestAddr.String())
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ln.Close()) }()
+
+	c, err := net.Dial(ln.Addr().Network(), ln.Addr().String())
+	require.NoError(t, err)
+	defer c.Close()
+	serverConn, err := ln.Accept()
+	require.NoError(t, err)
+	defer serverConn.Close()
+
+	rtc := NewReadTimeoutConn(serverConn, func() error { return nil }).(*readTimeoutConn)
+	rtc.maxReadDeadline = timeutil.Now().Add(10 * time.Millisecond)
+	_, err = rtc.Read(make([]byte, 1))
+	var ne net.Error
+	require.True(t, errors.As(err, &ne) && ne.Timeout(), "expected timeout, got %v", err)
+
+	rtc.maxReadDeadline = time.Time{}
+	expectedRead := []byte("expectedRead")
+	_, err = c.Write(expectedRead)
+	require.NoError(t, err)
+	readBytes := make([]byte, len(expectedRead))
+	_, err = io.ReadFull(rtc, readBytes)
+	require.NoError(t, err)
+	require.Equal(t, expectedRead, readBytes)
+}
+
 func TestConnResultsBufferSize(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
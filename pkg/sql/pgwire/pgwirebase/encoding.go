@@ -500,6 +500,16 @@ func DecodeDatum(
 				return nil, err
 			}
 			return tree.ParseDJSON(string(b))
+		case oid.T_tsquery:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDTSQuery(string(b))
+		case oid.T_tsvector:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDTSVector(string(b))
 		}
 		if typ.Family() == types.ArrayFamily {
 			// Arrays come in in their string form, so we parse them as such and later
@@ -239,6 +239,12 @@ func writeTextDatumNotNull(
 	case *tree.DJSON:
 		b.writeLengthPrefixedString(v.JSON.String())
 
+	case *tree.DTSQuery:
+		b.writeLengthPrefixedString(v.TSQuery.String())
+
+	case *tree.DTSVector:
+		b.writeLengthPrefixedString(v.TSVector.String())
+
 	case *tree.DTuple:
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
@@ -742,6 +748,10 @@ func writeBinaryDatumNotNull(
 	case *tree.DJSON:
 		writeBinaryJSON(b, v.JSON)
 
+	case *tree.DTSQuery, *tree.DTSVector:
+		b.setError(unimplemented.NewWithIssueDetail(7821,
+			"binenc", "unsupported binary serialization of tsquery and tsvector"))
+
 	case *tree.DOid:
 		b.putInt32(4)
 		b.putInt32(int32(v.DInt))
@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
+	"github.com/cockroachdb/cockroach/pkg/sql/resultcache"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
@@ -307,6 +308,10 @@ type planTop struct {
 	mem     *memo.Memo
 	catalog *optCatalog
 
+	// resultCacheStmt describes the statement to the result cache. It is only
+	// set if the results of the statement can be cached.
+	resultCacheStmt *resultcache.Statement
+
 	// auditEvents becomes non-nil if any of the descriptors used by
 	// current statement is causing an auditing event. See exec_log.go.
 	auditEvents []auditEvent
@@ -314,6 +319,9 @@ type planTop struct {
 	// flags is populated during planning and execution.
 	flags planFlags
 
+	// baseline is the state of the plan regression guard for the statement.
+	baseline planBaselineState
+
 	// avoidBuffering, when set, causes the execution to avoid buffering
 	// results.
 	avoidBuffering bool
@@ -428,6 +436,12 @@ type planComponents struct {
 	// checkPlans contains all the plans for queries that are to be executed after
 	// the main query (for example, foreign key checks).
 	checkPlans []checkPlan
+
+
+	// triggers contains the rows modified by the main query (and cascades)
+	// that fire row-level triggers. The triggers are executed after the
+	// cascades and before the checks.
+	triggers []*rowTriggerEvents
 }
 
 type cascadeMetadata struct {
@@ -455,6 +469,9 @@ func (p *planComponents) close(ctx context.Context) {
 	for i := range p.checkPlans {
 		p.checkPlans[i].plan.Close(ctx)
 	}
+	for _, ev := range p.triggers {
+		ev.close(ctx)
+	}
 }
 
 // init resets planTop to point to a given statement; used at the start of the
@@ -615,6 +632,14 @@ const (
 
 	// planFlagContainsMutation is set if the plan has any mutations.
 	planFlagContainsMutation
+
+	// planFlagResultCacheHit is set if the results of the statement were
+	// returned from the result cache.
+	planFlagResultCacheHit
+
+	// planFlagResultCacheMiss is set if we looked for the results of the
+	// statement in the result cache but did not find them.
+	planFlagResultCacheMiss
 )
 
 func (pf planFlags) IsSet(flag planFlags) bool {
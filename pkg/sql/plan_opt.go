@@ -210,12 +210,29 @@ func (p *planner) makeOptimizerPlan(ctx context.Context) error {
 	p.curPlan.init(&p.stmt, &p.instrumentation)
 
 	opc := &p.optPlanningCtx
+	prevHints := p.initPlanBaseline()
 	opc.reset()
 
 	execMemo, err := opc.buildExecMemo(ctx)
+	if baseline := &p.curPlan.baseline; baseline.fallback {
+		p.stmt.Hints = prevHints
+		if err != nil {
+			// The hints of the baseline plan might not apply anymore, for example
+			// because an index they refer to was dropped.
+			log.VEventf(ctx, 1, "discarding baseline plan: %v", err)
+			p.extendedEvalCtx.planBaselines.discard(baseline.key)
+			baseline.fallback = false
+			opc.reset()
+			execMemo, err = opc.buildExecMemo(ctx)
+		}
+	}
 	if err != nil {
 		return err
 	}
+	if baseline := &p.curPlan.baseline; baseline.guarded && !baseline.fallback &&
+		p.extendedEvalCtx.planBaselines.needsBaseline(baseline.key) {
+		baseline.hints, _ = optbuilder.PlanHints(execMemo)
+	}
 
 	// Build the plan tree.
 	if mode := p.SessionData().ExperimentalDistSQLPlanningMode; mode != sessiondatapb.ExperimentalDistSQLPlanningOff {
@@ -347,6 +364,14 @@ func (opc *optPlanningCtx) reset() {
 			opc.useCache = false
 		}
 
+		if optbuilder.HasOptimizerHints(p.stmt.Hints) {
+			// The memo depends on the optimizer hints, which are not part of the
+			// key of the cache and can be overridden by the hints of an EXECUTE
+			// statement.
+			opc.allowMemoReuse = false
+			opc.useCache = false
+		}
+
 	default:
 		opc.allowMemoReuse = false
 		opc.useCache = false
@@ -398,6 +423,7 @@ func (opc *optPlanningCtx) buildReusableMemo(ctx context.Context) (_ *memo.Memo,
 	f := opc.optimizer.Factory()
 	bld := optbuilder.New(ctx, &p.semaCtx, p.EvalContext(), &opc.catalog, f, opc.p.stmt.AST)
 	bld.KeepPlaceholders = true
+	bld.StatementHints = opc.p.stmt.Hints
 	if err := bld.Build(); err != nil {
 		return nil, err
 	}
@@ -539,6 +565,7 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 	f := opc.optimizer.Factory()
 	f.FoldingControl().AllowStableFolds()
 	bld := optbuilder.New(ctx, &p.semaCtx, p.EvalContext(), &opc.catalog, f, opc.p.stmt.AST)
+	bld.StatementHints = opc.p.stmt.Hints
 	if err := bld.Build(); err != nil {
 		return nil, err
 	}
@@ -671,6 +698,9 @@ func (opc *optPlanningCtx) runExecBuilder(
 		planTop.mem = mem
 		planTop.catalog = &opc.catalog
 	}
+	if opc.p.canUseResultCache() {
+		planTop.resultCacheStmt = opc.p.makeResultCacheStatement(mem, result.main.planColumns())
+	}
 	return nil
 }
 
@@ -745,3 +775,77 @@ func (opc *optPlanningCtx) makeQueryIndexRecommendation() error {
 
 	return nil
 }
+
+// makeWhatIfIndexRecommendations builds the statement and finds its index
+// recommendations like makeQueryIndexRecommendation, for the index advisor. It
+// also returns the estimated costs of the optimal plans of the statement
+// without and with the recommended indexes. Placeholders are not assigned, so
+// the costs are those of a generic plan of the statement.
+func (opc *optPlanningCtx) makeWhatIfIndexRecommendations(
+	ctx context.Context,
+) (_ []whatIfIndexRecommendation, cost, whatIfCost float64, _ error) {
+	p := opc.p
+	opc.reset()
+	f := opc.optimizer.Factory()
+	bld := optbuilder.New(ctx, &p.semaCtx, p.EvalContext(), &opc.catalog, f, p.stmt.AST)
+	bld.KeepPlaceholders = true
+	if err := bld.Build(); err != nil {
+		return nil, 0, 0, err
+	}
+	savedMemo := opc.optimizer.DetachMemo()
+
+	// Fully normalize the memo to determine the index candidates.
+	f.FoldingControl().AllowStableFolds()
+	f.CopyAndReplace(
+		savedMemo.RootExpr().(memo.RelExpr),
+		savedMemo.RootProps(),
+		f.CopyWithoutAssigningPlaceholders,
+	)
+	opc.optimizer.NotifyOnMatchedRule(func(ruleName opt.RuleName) bool {
+		return ruleName.IsNormalize()
+	})
+	if _, err := opc.optimizer.Optimize(); err != nil {
+		return nil, 0, 0, err
+	}
+	indexCandidates := indexrec.FindIndexCandidateSet(f.Memo().RootExpr(), f.Metadata())
+	optTables, hypTables := indexrec.BuildOptAndHypTableMaps(indexCandidates)
+
+	// Optimize with the existing indexes only.
+	opc.optimizer.Init(f.EvalContext(), &opc.catalog)
+	f.CopyAndReplace(
+		savedMemo.RootExpr().(memo.RelExpr),
+		savedMemo.RootProps(),
+		f.CopyWithoutAssigningPlaceholders,
+	)
+	root, err := opc.optimizer.Optimize()
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	cost = float64(root.(memo.RelExpr).Cost())
+
+	// Optimize with the hypothetical indexes added to the tables.
+	opc.optimizer.Init(f.EvalContext(), &opc.catalog)
+	f.CopyAndReplace(
+		savedMemo.RootExpr().(memo.RelExpr),
+		savedMemo.RootProps(),
+		f.CopyWithoutAssigningPlaceholders,
+	)
+	opc.optimizer.Memo().Metadata().UpdateTableMeta(hypTables)
+	root, err = opc.optimizer.Optimize()
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	whatIfCost = float64(root.(memo.RelExpr).Cost())
+
+	indexRecommendations := indexrec.FindIndexRecommendationSet(f.Memo().RootExpr(), f.Metadata())
+	recs := indexRecommendations.Recommendations()
+	res := make([]whatIfIndexRecommendation, len(recs))
+	for i := range recs {
+		tn, err := opc.catalog.FullyQualifiedName(ctx, optTables[recs[i].Table.ID()])
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		res[i] = whatIfIndexRecommendation{Recommendation: recs[i], tableName: tn}
+	}
+	return res, cost, whatIfCost, nil
+}
@@ -0,0 +1,348 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+var planRegressionGuardEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.plan_regression_guard.enabled",
+	"if set, the plan of every statement fingerprint is captured as its baseline plan once "+
+		"it's been executed a few times, and the statements fall back to the baseline plan "+
+		"when a new plan chosen by the optimizer is slower than the baseline plan by more "+
+		"than sql.plan_regression_guard.regression_factor; disabling the setting discards "+
+		"the baseline plans",
+	false,
+).WithPublic()
+
+var planRegressionFactor = settings.RegisterFloatSetting(
+	settings.TenantWritable,
+	"sql.plan_regression_guard.regression_factor",
+	"the ratio of the mean run latency of a new plan of a statement fingerprint to that of "+
+		"its baseline plan above which the plan regression guard falls back to the baseline plan",
+	2,
+	func(v float64) error {
+		if v <= 1 {
+			return errors.Errorf("regression factor must be greater than 1, got %f", v)
+		}
+		return nil
+	},
+).WithPublic()
+
+// planBaselineMinExecutions is the number of executions of a plan after which
+// the mean run latency of the plan is considered significant, i.e. after which
+// the plan becomes the baseline plan of its fingerprint, or a new plan is
+// compared to the baseline plan.
+const planBaselineMinExecutions = 10
+
+// maxPlanBaselines is the maximum number of statement fingerprints tracked by
+// the planBaselines registry.
+const maxPlanBaselines = 1024
+
+// planBaselines is the node-wide registry of the plan regression guard. It
+// captures a known-good plan of every statement fingerprint, its baseline
+// plan, and falls back to it once the optimizer chooses a new plan that is
+// slower than the baseline plan by more than the regression factor.
+//
+// The baseline plan of a fingerprint is the first plan executed
+// planBaselineMinExecutions times. It's kept as the optimizer hints that
+// steer the optimizer towards the plan (see optbuilder.PlanHints), so a plan
+// that can't be expressed by hints never becomes a baseline plan. Once the
+// guard has fallen back to the baseline plan, the statements of the
+// fingerprint are planned with its hints until the baseline is evicted or the
+// guard is disabled, or until the hints fail to apply, for example because an
+// index they refer to was dropped.
+type planBaselines struct {
+	st *cluster.Settings
+	mu struct {
+		syncutil.Mutex
+		cache *cache.UnorderedCache
+	}
+}
+
+// planBaselineKey identifies a statement fingerprint. The current database is
+// part of the key because it determines the tables of the fingerprint.
+type planBaselineKey struct {
+	fingerprint string
+	database    string
+}
+
+// planStats are the statistics of the executions of a plan.
+type planStats struct {
+	gist        string
+	count       int64
+	meanLatency float64 // seconds
+}
+
+func (s *planStats) record(latency float64) {
+	s.count++
+	s.meanLatency += (latency - s.meanLatency) / float64(s.count)
+}
+
+type planBaseline struct {
+	baseline planStats
+	// hints are the optimizer hints of the baseline plan.
+	hints []parser.StatementHint
+	// current are the statistics of the last other plan of the fingerprint,
+	// once the baseline plan has become significant.
+	current planStats
+	// fallback is set once the guard has fallen back to the baseline plan.
+	fallback     bool
+	fallbacks    int64
+	lastFallback time.Time
+}
+
+// established returns true if the baseline plan is significant.
+func (b *planBaseline) established() bool {
+	return b.baseline.count >= planBaselineMinExecutions
+}
+
+// planRegression describes a plan that regressed compared to the baseline
+// plan of its fingerprint.
+type planRegression struct {
+	baseline, regressed planStats
+}
+
+func newPlanBaselines(st *cluster.Settings) *planBaselines {
+	b := &planBaselines{st: st}
+	b.mu.cache = cache.NewUnorderedCache(cache.Config{
+		Policy: cache.CacheLRU,
+		ShouldEvict: func(size int, _, _ interface{}) bool {
+			return size > maxPlanBaselines
+		},
+	})
+	planRegressionGuardEnabled.SetOnChange(&st.SV, func(context.Context) {
+		if !planRegressionGuardEnabled.Get(&st.SV) {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			b.mu.cache.Clear()
+		}
+	})
+	return b
+}
+
+// needsBaseline returns true if the next plan of the given fingerprint might
+// become its baseline plan.
+func (b *planBaselines) needsBaseline(key planBaselineKey) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v, ok := b.mu.cache.StealthyGet(key)
+	return !ok || !v.(*planBaseline).established()
+}
+
+// fallbackHints returns the hints of the baseline plan of the given
+// fingerprint if the guard has fallen back to it.
+func (b *planBaselines) fallbackHints(key planBaselineKey) ([]parser.StatementHint, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v, ok := b.mu.cache.Get(key)
+	if !ok || !v.(*planBaseline).fallback {
+		return nil, false
+	}
+	return v.(*planBaseline).hints, true
+}
+
+// discard removes the baseline plan of the given fingerprint.
+func (b *planBaselines) discard(key planBaselineKey) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.mu.cache.Del(key)
+}
+
+// record records an execution of the plan with the given gist of the given
+// fingerprint, and returns the regression that made the guard fall back to
+// the baseline plan, if any. hints are the optimizer hints of the plan; they
+// are only needed if needsBaseline returned true.
+func (b *planBaselines) record(
+	key planBaselineKey, gist string, hints []parser.StatementHint, latency float64, now time.Time,
+) *planRegression {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v, ok := b.mu.cache.Get(key)
+	if !ok {
+		if len(hints) == 0 {
+			return nil
+		}
+		e := &planBaseline{baseline: planStats{gist: gist}, hints: hints}
+		e.baseline.record(latency)
+		b.mu.cache.Add(key, e)
+		return nil
+	}
+	e := v.(*planBaseline)
+	switch {
+	case e.baseline.gist == gist:
+		e.baseline.record(latency)
+
+	case !e.established():
+		// The plan changed before the baseline plan became significant, so the
+		// new plan becomes the baseline plan.
+		if len(hints) > 0 {
+			e.baseline, e.hints = planStats{gist: gist}, hints
+			e.baseline.record(latency)
+		}
+
+	case e.fallback:
+		// The hints of the baseline plan didn't reproduce it. The executions of
+		// the other plans are ignored so that the guard doesn't fall back again.
+
+	default:
+		if e.current.gist != gist {
+			e.current = planStats{gist: gist}
+		}
+		e.current.record(latency)
+		if e.current.count >= planBaselineMinExecutions &&
+			e.current.meanLatency > e.baseline.meanLatency*planRegressionFactor.Get(&b.st.SV) {
+			e.fallback = true
+			e.fallbacks++
+			e.lastFallback = now
+			return &planRegression{baseline: e.baseline, regressed: e.current}
+		}
+	}
+	return nil
+}
+
+// planBaselineEntry describes the baseline plan of a statement fingerprint in
+// crdb_internal.node_plan_baselines.
+type planBaselineEntry struct {
+	key planBaselineKey
+	planBaseline
+}
+
+// entries returns a copy of the baseline plans, ordered by fingerprint and
+// database.
+func (b *planBaselines) entries() []planBaselineEntry {
+	b.mu.Lock()
+	res := make([]planBaselineEntry, 0, b.mu.cache.Len())
+	b.mu.cache.Do(func(e *cache.Entry) {
+		res = append(res, planBaselineEntry{
+			key:          e.Key.(planBaselineKey),
+			planBaseline: *e.Value.(*planBaseline),
+		})
+	})
+	b.mu.Unlock()
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].key.fingerprint != res[j].key.fingerprint {
+			return res[i].key.fingerprint < res[j].key.fingerprint
+		}
+		return res[i].key.database < res[j].key.database
+	})
+	return res
+}
+
+// planBaselineState is the state of the plan regression guard for the current
+// statement.
+type planBaselineState struct {
+	// guarded is set if the plan of the statement is checked against the
+	// baseline plan of its fingerprint.
+	guarded bool
+	key     planBaselineKey
+	// hints are the optimizer hints of the plan, if it might become the
+	// baseline plan of the fingerprint.
+	hints []parser.StatementHint
+	// fallback is set if the statement was planned with the hints of the
+	// baseline plan.
+	fallback bool
+}
+
+// initPlanBaseline initializes the state of the plan regression guard for the
+// current statement. If the guard has fallen back to the baseline plan of the
+// fingerprint of the statement, the hints of the baseline plan are added to
+// the hints of the statement. It returns the previous hints of the statement,
+// which are restored once the statement is planned.
+func (p *planner) initPlanBaseline() (prevHints []parser.StatementHint) {
+	prevHints = p.stmt.Hints
+	if p.extendedEvalCtx.planBaselines == nil || p.SessionData().Internal ||
+		p.stmt.StmtNoConstants == "" || !planRegressionGuardEnabled.Get(&p.execCfg.Settings.SV) {
+		return prevHints
+	}
+	switch p.stmt.AST.(type) {
+	case *tree.Select, *tree.ParenSelect, *tree.Insert, *tree.Update, *tree.Delete:
+	default:
+		return prevHints
+	}
+	if optbuilder.HasOptimizerHints(prevHints) {
+		// The hints of the statement take precedence over its baseline plan.
+		return prevHints
+	}
+	state := &p.curPlan.baseline
+	state.guarded = true
+	state.key = planBaselineKey{
+		fingerprint: p.stmt.StmtNoConstants,
+		database:    p.SessionData().Database,
+	}
+	if hints, ok := p.extendedEvalCtx.planBaselines.fallbackHints(state.key); ok {
+		state.fallback = true
+		p.stmt.Hints = append(append([]parser.StatementHint(nil), prevHints...), hints...)
+	}
+	return prevHints
+}
+
+// recordPlanBaseline records the execution of the current statement with the
+// plan regression guard, and emits a PlanRegression event if the guard falls
+// back to the baseline plan of the fingerprint.
+func (ex *connExecutor) recordPlanBaseline(
+	ctx context.Context, planner *planner, runLatency time.Duration,
+) {
+	state := &planner.curPlan.baseline
+	gist := planner.instrumentation.planGist.String()
+	if gist == "" {
+		return
+	}
+	regression := ex.server.planBaselines.record(
+		state.key, gist, state.hints, runLatency.Seconds(), timeutil.Now(),
+	)
+	if regression == nil {
+		return
+	}
+	telemetry.Inc(sqltelemetry.PlanRegressionFallbackCounter)
+	log.StructuredEvent(ctx, &eventpb.PlanRegression{
+		CommonSQLEventDetails: planner.getCommonSQLEventDetails(defaultRedactionOptions),
+		BaselinePlanGist:      regression.baseline.gist,
+		RegressedPlanGist:     regression.regressed.gist,
+		BaselineLatencyNanos:  int64(regression.baseline.meanLatency * float64(time.Second)),
+		RegressedLatencyNanos: int64(regression.regressed.meanLatency * float64(time.Second)),
+	})
+}
+
+// formatStatementHints formats the given hints like the contents of a hint
+// comment.
+func formatStatementHints(hints []parser.StatementHint) string {
+	var buf strings.Builder
+	for i, hint := range hints {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(hint.Name)
+		buf.WriteByte('(')
+		buf.WriteString(strings.Join(hint.Args, " "))
+		buf.WriteByte(')')
+	}
+	return buf.String()
+}
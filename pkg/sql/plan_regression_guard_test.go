@@ -0,0 +1,120 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanBaselines(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	planRegressionGuardEnabled.Override(ctx, &st.SV, true)
+	b := newPlanBaselines(st)
+
+	key := planBaselineKey{fingerprint: "SELECT * FROM t WHERE k = _", database: "defaultdb"}
+	hints := func(index string) []parser.StatementHint {
+		return []parser.StatementHint{{Name: "index", Args: []string{"t", index}}}
+	}
+	now := timeutil.Now()
+	recordN := func(n int, gist string, hints []parser.StatementHint, latency float64) *planRegression {
+		var res *planRegression
+		for i := 0; i < n; i++ {
+			if r := b.record(key, gist, hints, latency, now); r != nil {
+				res = r
+			}
+		}
+		return res
+	}
+
+	// A plan without hints never becomes the baseline plan.
+	require.True(t, b.needsBaseline(key))
+	require.Nil(t, recordN(1, "a", nil /* hints */, 1))
+	require.Empty(t, b.entries())
+
+	// The plan changes before the baseline plan becomes significant, so the new
+	// plan replaces it.
+	require.Nil(t, recordN(planBaselineMinExecutions-1, "a", hints("a_idx"), 1))
+	require.Nil(t, recordN(1, "b", hints("b_idx"), 1))
+	require.True(t, b.needsBaseline(key))
+	entries := b.entries()
+	require.Len(t, entries, 1)
+	require.Equal(t, "b", entries[0].baseline.gist)
+	require.Equal(t, "index(t b_idx)", formatStatementHints(entries[0].hints))
+
+	// The baseline plan becomes significant.
+	require.Nil(t, recordN(planBaselineMinExecutions-1, "b", hints("b_idx"), 1))
+	require.False(t, b.needsBaseline(key))
+
+	// A new plan that isn't slower than the baseline plan by more than the
+	// regression factor is tolerated.
+	require.Nil(t, recordN(planBaselineMinExecutions, "c", nil /* hints */, 1.5))
+	_, fallback := b.fallbackHints(key)
+	require.False(t, fallback)
+
+	// A slower plan makes the guard fall back to the baseline plan once it's
+	// been executed enough times.
+	require.Nil(t, recordN(planBaselineMinExecutions-1, "d", nil /* hints */, 3))
+	r := recordN(1, "d", nil /* hints */, 3)
+	require.NotNil(t, r)
+	require.Equal(t, "b", r.baseline.gist)
+	require.Equal(t, "d", r.regressed.gist)
+	require.InDelta(t, 3, r.regressed.meanLatency, 1e-9)
+	fallbackHints, fallback := b.fallbackHints(key)
+	require.True(t, fallback)
+	require.Equal(t, hints("b_idx"), fallbackHints)
+
+	// The guard doesn't fall back again.
+	require.Nil(t, recordN(planBaselineMinExecutions, "e", nil /* hints */, 10))
+	entries = b.entries()
+	require.Len(t, entries, 1)
+	require.Equal(t, int64(1), entries[0].fallbacks)
+
+	// Disabling the guard discards the baseline plans.
+	planRegressionGuardEnabled.Override(ctx, &st.SV, false)
+	require.Empty(t, b.entries())
+}
+
+func TestPlanRegressionGuardCapturesBaseline(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `SET CLUSTER SETTING sql.plan_regression_guard.enabled = true`)
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v INT, INDEX v_idx (v))`)
+	for i := 0; i < planBaselineMinExecutions; i++ {
+		sqlDB.Exec(t, `SELECT k FROM t WHERE v = $1`, i)
+	}
+	sqlDB.CheckQueryResults(t, `
+SELECT baseline_hints, baseline_executions, fallback
+  FROM crdb_internal.node_plan_baselines
+ WHERE fingerprint = 'SELECT k FROM t WHERE v = $1'`,
+		[][]string{{"index(t v_idx)", "10", "false"}},
+	)
+}
@@ -103,6 +103,8 @@ type extendedEvalContext struct {
 
 	indexUsageStats *idxusage.LocalIndexUsageStats
 
+	planBaselines *planBaselines
+
 	SchemaChangerState *SchemaChangerState
 
 	statementPreparer statementPreparer
@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
@@ -158,6 +159,10 @@ func RandDatumWithNullChance(rng *rand.Rand, typ *types.T, nullChance int) tree.
 			return nil
 		}
 		return &tree.DJSON{JSON: j}
+	case types.TSQueryFamily:
+		return tree.NewDTSQuery(tsearch.RandomTSQuery(rng))
+	case types.TSVectorFamily:
+		return tree.NewDTSVector(tsearch.RandomTSVector(rng))
 	case types.TupleFamily:
 		tuple := tree.DTuple{D: make(tree.Datums, len(typ.TupleContents()))}
 		for i := range typ.TupleContents() {
@@ -618,6 +623,36 @@ var (
 			}
 			return res
 		}(),
+		types.TSQueryFamily: func() []tree.Datum {
+			var res []tree.Datum
+			for _, s := range []string{
+				``,
+				`a`,
+				`a & !(b | c:*) <-> d`,
+			} {
+				d, err := tree.ParseDTSQuery(s)
+				if err != nil {
+					panic(err)
+				}
+				res = append(res, d)
+			}
+			return res
+		}(),
+		types.TSVectorFamily: func() []tree.Datum {
+			var res []tree.Datum
+			for _, s := range []string{
+				``,
+				`a`,
+				`a:1A b:2,3 c`,
+			} {
+				d, err := tree.ParseDTSVector(s)
+				if err != nil {
+					panic(err)
+				}
+				res = append(res, d)
+			}
+			return res
+		}(),
 		types.BitFamily: func() []tree.Datum {
 			var res []tree.Datum
 			for _, i := range []int64{
@@ -13,6 +13,7 @@ package sql
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
@@ -26,6 +27,8 @@ import (
 type refreshMaterializedViewNode struct {
 	n    *tree.RefreshMaterializedView
 	desc *tabledesc.Mutable
+	// shape is set if the view is refreshed incrementally.
+	shape *viewDeltaShape
 }
 
 func (p *planner) RefreshMaterializedView(
@@ -69,7 +72,21 @@ func (p *planner) RefreshMaterializedView(
 		)
 	}
 
-	return &refreshMaterializedViewNode{n: n, desc: desc}, nil
+	var shape *viewDeltaShape
+	if n.RefreshDataOption == tree.RefreshDataIncremental {
+		// The changes to the tables the view depends on are read from rangefeeds,
+		// which require the `kv.rangefeed.enabled` setting to be true.
+		if p.ExecCfg().Codec.ForSystemTenant() && !kvserver.RangefeedEnabled.Get(&p.ExecCfg().Settings.SV) {
+			return nil, pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"incremental refreshes of materialized views require the kv.rangefeed.enabled setting")
+		}
+		shape, err = p.analyzeViewDeltaShape(ctx, desc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &refreshMaterializedViewNode{n: n, desc: desc, shape: shape}, nil
 }
 
 func (n *refreshMaterializedViewNode) startExec(params runParams) error {
@@ -90,6 +107,13 @@ func (n *refreshMaterializedViewNode) startExec(params runParams) error {
 		)
 	}
 
+	if n.shape != nil {
+		telemetry.Inc(sqltelemetry.SchemaRefreshMaterializedViewIncrementally)
+		if done, err := n.refreshIncrementally(params); done || err != nil {
+			return err
+		}
+	}
+
 	// Prepare the new set of indexes by cloning all existing indexes on the view.
 	newPrimaryIndex := n.desc.GetPrimaryIndex().IndexDescDeepCopy()
 	newIndexes := make([]descpb.IndexDescriptor, len(n.desc.PublicNonPrimaryIndexes()))
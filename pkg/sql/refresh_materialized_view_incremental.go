@@ -0,0 +1,797 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// incrementalRefreshMaxChangedKeys is the maximum number of keys of a
+// materialized view that are recomputed by an incremental refresh. If more
+// keys changed since the last refresh, the view is fully refreshed instead.
+var incrementalRefreshMaxChangedKeys = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.materialized_view.incremental_refresh.max_changed_keys",
+	"the maximum number of changed keys for which REFRESH MATERIALIZED VIEW ... "+
+		"INCREMENTALLY recomputes the rows of the view; a full refresh is performed "+
+		"if more keys changed since the last refresh",
+	10000,
+	settings.PositiveInt,
+)
+
+// incrementalRefreshBatchSize is the number of keys of a materialized view
+// that are recomputed by each statement of an incremental refresh.
+const incrementalRefreshBatchSize = 100
+
+// viewDeltaSource is a table in the FROM clause of the query of a
+// materialized view that can be refreshed incrementally.
+type viewDeltaSource struct {
+	desc catalog.TableDescriptor
+	// alias is the name by which the table is referenced in the view query.
+	alias tree.Name
+	// keyCols are the columns of the table that correspond to the key columns
+	// of the view.
+	keyCols []catalog.Column
+}
+
+// viewDeltaShape describes how a materialized view is refreshed
+// incrementally.
+//
+// The query of the view is a selection, projection or aggregation of a table,
+// or of an inner equality join of two tables. Each row of the view is derived
+// from the rows of the tables that have the same values in some key columns,
+// and the view outputs these columns: for an aggregation, they are grouping
+// columns, and for a join, they are compared by the join condition. When rows
+// of the tables change, only the rows of the view with the old and new key
+// values of these rows have to be recomputed.
+type viewDeltaShape struct {
+	sources []viewDeltaSource
+	// keyOrdinals are the ordinals of the key columns in the visible columns of
+	// the view.
+	keyOrdinals []int
+}
+
+// errIncrementalRefreshUnsupported returns an error indicating that a
+// materialized view cannot be refreshed incrementally.
+func errIncrementalRefreshUnsupported(format string, args ...interface{}) error {
+	return pgerror.Newf(pgcode.FeatureNotSupported,
+		"materialized view cannot be refreshed incrementally: %s", fmt.Sprintf(format, args...))
+}
+
+// viewDeltaColumn identifies a column of a source of a view query.
+type viewDeltaColumn struct {
+	source int
+	id     descpb.ColumnID
+}
+
+// analyzeViewDeltaShape determines how the given materialized view can be
+// refreshed incrementally, or returns an error if it can't be.
+func (p *planner) analyzeViewDeltaShape(
+	ctx context.Context, viewDesc catalog.TableDescriptor,
+) (*viewDeltaShape, error) {
+	stmt, err := parser.ParseOne(viewDesc.GetViewQuery())
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok {
+		return nil, errIncrementalRefreshUnsupported("unsupported view query")
+	}
+	for {
+		if sel.With != nil {
+			return nil, errIncrementalRefreshUnsupported("WITH clauses are not supported")
+		}
+		if sel.Limit != nil {
+			return nil, errIncrementalRefreshUnsupported("LIMIT clauses are not supported")
+		}
+		paren, ok := sel.Select.(*tree.ParenSelect)
+		if !ok {
+			break
+		}
+		sel = paren.Select
+	}
+	clause, ok := sel.Select.(*tree.SelectClause)
+	if !ok {
+		return nil, errIncrementalRefreshUnsupported("only simple SELECT queries are supported")
+	}
+	if clause.Window != nil || clause.DistinctOn != nil || clause.From.AsOf.Expr != nil {
+		return nil, errIncrementalRefreshUnsupported(
+			"WINDOW, DISTINCT ON and AS OF SYSTEM TIME clauses are not supported",
+		)
+	}
+	if len(clause.From.Tables) != 1 {
+		return nil, errIncrementalRefreshUnsupported(
+			"the view must select from a single table or from a join of two tables",
+		)
+	}
+
+	shape := &viewDeltaShape{}
+	// equalities contains the pairs of columns compared by the join condition.
+	var equalities [][2]viewDeltaColumn
+	var usingCols tree.NameList
+	var exprs []tree.Expr
+	switch t := clause.From.Tables[0].(type) {
+	case *tree.AliasedTableExpr:
+		if err := p.addViewDeltaSource(ctx, viewDesc, shape, t); err != nil {
+			return nil, err
+		}
+
+	case *tree.JoinTableExpr:
+		if t.JoinType != "" && t.JoinType != tree.AstInner {
+			return nil, errIncrementalRefreshUnsupported("%s joins are not supported", t.JoinType)
+		}
+		for _, side := range []tree.TableExpr{t.Left, t.Right} {
+			ate, ok := side.(*tree.AliasedTableExpr)
+			if !ok {
+				return nil, errIncrementalRefreshUnsupported("only joins of two tables are supported")
+			}
+			if err := p.addViewDeltaSource(ctx, viewDesc, shape, ate); err != nil {
+				return nil, err
+			}
+		}
+		switch cond := t.Cond.(type) {
+		case *tree.OnJoinCond:
+			exprs = append(exprs, cond.Expr)
+			for _, conjunct := range splitViewDeltaConjuncts(cond.Expr, nil /* conjuncts */) {
+				cmp, ok := tree.StripParens(conjunct).(*tree.ComparisonExpr)
+				if !ok || cmp.Operator.Symbol != treecmp.EQ {
+					continue
+				}
+				left, lok, err := shape.resolveColumn(cmp.Left, usingCols)
+				if err != nil {
+					return nil, err
+				}
+				right, rok, err := shape.resolveColumn(cmp.Right, usingCols)
+				if err != nil {
+					return nil, err
+				}
+				if lok && rok && left.source != right.source {
+					equalities = append(equalities, [2]viewDeltaColumn{left, right})
+				}
+			}
+
+		case *tree.UsingJoinCond:
+			usingCols = cond.Cols
+			for _, name := range cond.Cols {
+				var pair [2]viewDeltaColumn
+				for i := range shape.sources {
+					col, err := shape.sources[i].desc.FindColumnWithName(name)
+					if err != nil {
+						return nil, err
+					}
+					pair[i] = viewDeltaColumn{source: i, id: col.GetID()}
+				}
+				equalities = append(equalities, pair)
+			}
+
+		default:
+			return nil, errIncrementalRefreshUnsupported("only ON and USING join conditions are supported")
+		}
+
+	default:
+		return nil, errIncrementalRefreshUnsupported(
+			"the view must select from a single table or from a join of two tables",
+		)
+	}
+
+	// Check that the expressions of the query are deterministic and only depend
+	// on the rows of the sources.
+	for i := range clause.Exprs {
+		exprs = append(exprs, clause.Exprs[i].Expr)
+	}
+	if clause.Where != nil {
+		exprs = append(exprs, clause.Where.Expr)
+	}
+	if clause.Having != nil {
+		exprs = append(exprs, clause.Having.Expr)
+	}
+	exprs = append(exprs, clause.GroupBy...)
+	checker := viewDeltaExprChecker{searchPath: p.SemaCtx().SearchPath}
+	for _, expr := range exprs {
+		tree.WalkExprConst(&checker, expr)
+		if checker.err != nil {
+			return nil, checker.err
+		}
+	}
+	aggregated := len(clause.GroupBy) > 0 || checker.hasAggregate
+	groupCols := make(map[viewDeltaColumn]struct{})
+	for _, expr := range clause.GroupBy {
+		col, ok, err := shape.resolveColumn(expr, usingCols)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			groupCols[col] = struct{}{}
+		}
+	}
+	isGroupCol := func(col viewDeltaColumn) bool {
+		_, ok := groupCols[col]
+		return ok
+	}
+
+	// Every selected column that is a grouping column (if the query is
+	// aggregated) and that is compared by the join condition (if the query is
+	// a join) is a key column.
+	for i := range clause.Exprs {
+		col, ok, err := shape.resolveColumn(clause.Exprs[i].Expr, usingCols)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		keyCols := []viewDeltaColumn{col}
+		if len(shape.sources) > 1 {
+			keyCols = nil
+			for _, eq := range equalities {
+				if eq[0] == col || eq[1] == col {
+					keyCols = []viewDeltaColumn{eq[0], eq[1]}
+					break
+				}
+			}
+			if keyCols == nil {
+				continue
+			}
+		}
+		if aggregated && !isGroupCol(keyCols[0]) && (len(keyCols) == 1 || !isGroupCol(keyCols[1])) {
+			continue
+		}
+		if shape.addKey(keyCols) {
+			shape.keyOrdinals = append(shape.keyOrdinals, i)
+		}
+	}
+	if len(shape.keyOrdinals) == 0 {
+		switch {
+		case len(shape.sources) > 1:
+			return nil, errIncrementalRefreshUnsupported(
+				"the view must select a column compared by the equality join condition",
+			)
+		case aggregated:
+			return nil, errIncrementalRefreshUnsupported("the view must select a grouping column")
+		default:
+			return nil, errIncrementalRefreshUnsupported("the view must select a column of the table")
+		}
+	}
+	return shape, nil
+}
+
+// addViewDeltaSource adds the table referenced by the given table expression
+// to the sources of the view query.
+func (p *planner) addViewDeltaSource(
+	ctx context.Context,
+	viewDesc catalog.TableDescriptor,
+	shape *viewDeltaShape,
+	ate *tree.AliasedTableExpr,
+) error {
+	if ate.Ordinality || ate.SystemTime != nil || ate.TableSample != nil || len(ate.As.Cols) > 0 {
+		return errIncrementalRefreshUnsupported(
+			"WITH ORDINALITY, FOR SYSTEM_TIME, TABLESAMPLE and column aliases are not supported",
+		)
+	}
+	src := viewDeltaSource{alias: ate.As.Alias}
+	switch t := ate.Expr.(type) {
+	case *tree.TableName:
+		// The tables the view depends on can't be renamed, so we can find the
+		// table among them.
+		for _, id := range viewDesc.GetDependsOn() {
+			desc, err := p.Descriptors().GetImmutableTableByID(
+				ctx, p.Txn(), id, tree.ObjectLookupFlagsWithRequired(),
+			)
+			if err != nil {
+				return err
+			}
+			if desc.GetName() != string(t.ObjectName) {
+				continue
+			}
+			if src.desc != nil {
+				return errIncrementalRefreshUnsupported("ambiguous table name %s", t.ObjectName)
+			}
+			src.desc = desc
+		}
+		if src.desc == nil {
+			return errIncrementalRefreshUnsupported("unknown table %s", t.ObjectName)
+		}
+		if src.alias == "" {
+			src.alias = t.ObjectName
+		}
+
+	case *tree.TableRef:
+		desc, err := p.Descriptors().GetImmutableTableByID(
+			ctx, p.Txn(), descpb.ID(t.TableID), tree.ObjectLookupFlagsWithRequired(),
+		)
+		if err != nil {
+			return err
+		}
+		src.desc = desc
+		if src.alias == "" {
+			src.alias = t.As.Alias
+		}
+
+	default:
+		return errIncrementalRefreshUnsupported("the view must select from tables")
+	}
+	if !src.desc.IsTable() || src.desc.IsVirtualTable() {
+		return errIncrementalRefreshUnsupported("%q is not a table", src.desc.GetName())
+	}
+	if len(src.desc.GetFamilies()) > 1 {
+		return errIncrementalRefreshUnsupported(
+			"table %q has multiple column families", src.desc.GetName(),
+		)
+	}
+	shape.sources = append(shape.sources, src)
+	return nil
+}
+
+// resolveColumn returns the column of a source referenced by the given
+// expression, if it is a column reference.
+func (s *viewDeltaShape) resolveColumn(
+	expr tree.Expr, usingCols tree.NameList,
+) (_ viewDeltaColumn, ok bool, _ error) {
+	expr = tree.StripParens(expr)
+	name, ok := expr.(*tree.UnresolvedName)
+	if !ok {
+		return viewDeltaColumn{}, false, nil
+	}
+	v, err := name.NormalizeVarName()
+	if err != nil {
+		return viewDeltaColumn{}, false, err
+	}
+	item, ok := v.(*tree.ColumnItem)
+	if !ok {
+		return viewDeltaColumn{}, false, nil
+	}
+	var res viewDeltaColumn
+	found := false
+	for i := range s.sources {
+		src := &s.sources[i]
+		if item.TableName != nil && tree.Name(item.TableName.Parts[0]) != src.alias {
+			continue
+		}
+		col, err := src.desc.FindColumnWithName(item.ColumnName)
+		if err != nil {
+			continue
+		}
+		if found {
+			// The column is shared by the tables of a join with a USING
+			// condition; it refers to the column of the left table.
+			if isUsingCol(usingCols, item.ColumnName) {
+				break
+			}
+			return viewDeltaColumn{}, false, errIncrementalRefreshUnsupported(
+				"ambiguous column reference %s", tree.ErrString(item),
+			)
+		}
+		if col.IsVirtual() {
+			return viewDeltaColumn{}, false, nil
+		}
+		res = viewDeltaColumn{source: i, id: col.GetID()}
+		found = true
+	}
+	return res, found, nil
+}
+
+// isUsingCol returns true if the given column is one of the columns of a USING
+// join condition.
+func isUsingCol(usingCols tree.NameList, name tree.Name) bool {
+	for _, col := range usingCols {
+		if col == name {
+			return true
+		}
+	}
+	return false
+}
+
+// addKey adds the given columns, one for each source, to the key columns of
+// the view. It returns false if one of the columns is already a key column.
+func (s *viewDeltaShape) addKey(cols []viewDeltaColumn) bool {
+	for _, col := range cols {
+		for _, keyCol := range s.sources[col.source].keyCols {
+			if keyCol.GetID() == col.id {
+				return false
+			}
+		}
+	}
+	for _, col := range cols {
+		src := &s.sources[col.source]
+		keyCol, err := src.desc.FindColumnWithID(col.id)
+		if err != nil {
+			panic(err)
+		}
+		src.keyCols = append(src.keyCols, keyCol)
+	}
+	return true
+}
+
+// splitViewDeltaConjuncts appends the conjuncts of the given expression to the
+// given slice.
+func splitViewDeltaConjuncts(expr tree.Expr, conjuncts []tree.Expr) []tree.Expr {
+	if and, ok := tree.StripParens(expr).(*tree.AndExpr); ok {
+		conjuncts = splitViewDeltaConjuncts(and.Left, conjuncts)
+		return splitViewDeltaConjuncts(and.Right, conjuncts)
+	}
+	return append(conjuncts, expr)
+}
+
+// viewDeltaExprChecker is a tree.Visitor that checks that the expressions of
+// a view query can be incrementally maintained: they can't contain subqueries,
+// window functions or functions that aren't immutable.
+type viewDeltaExprChecker struct {
+	searchPath   tree.SearchPath
+	hasAggregate bool
+	err          error
+}
+
+var _ tree.Visitor = &viewDeltaExprChecker{}
+
+// VisitPre implements the tree.Visitor interface.
+func (v *viewDeltaExprChecker) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if v.err != nil {
+		return false, expr
+	}
+	switch t := expr.(type) {
+	case *tree.Subquery:
+		v.err = errIncrementalRefreshUnsupported("subqueries are not supported")
+
+	case *tree.FuncExpr:
+		if t.WindowDef != nil {
+			v.err = errIncrementalRefreshUnsupported("window functions are not supported")
+			break
+		}
+		def, err := t.Func.Resolve(v.searchPath)
+		if err != nil {
+			v.err = err
+			break
+		}
+		if def.Class == tree.AggregateClass {
+			v.hasAggregate = true
+		}
+		_, overloads := builtins.GetBuiltinProperties(def.Name)
+		for i := range overloads {
+			if overloads[i].Volatility > volatility.Immutable {
+				v.err = errIncrementalRefreshUnsupported("%s() is not immutable", def.Name)
+				break
+			}
+		}
+	}
+	return v.err == nil, expr
+}
+
+// VisitPost implements the tree.Visitor interface.
+func (v *viewDeltaExprChecker) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
+// viewDeltaKey is a value of the key columns of a materialized view.
+type viewDeltaKey struct {
+	// values are the values of the key columns formatted as SQL expressions.
+	values  []string
+	hasNull bool
+}
+
+// viewDeltaKeys is the set of the keys of a materialized view whose rows have
+// to be recomputed.
+type viewDeltaKeys struct {
+	// types are the types of the key columns of the view.
+	types []*types.T
+	seen  map[string]struct{}
+	keys  []viewDeltaKey
+}
+
+// add adds the given key values, which are in the order of the key columns of
+// the view, to the set.
+func (k *viewDeltaKeys) add(datums tree.Datums) {
+	key := viewDeltaKey{values: make([]string, len(datums))}
+	for i, d := range datums {
+		if d == tree.DNull {
+			key.hasNull = true
+		}
+		key.values[i] = tree.AsStringWithFlags(d, tree.FmtParsable)
+		if d != tree.DNull && !d.ResolvedType().Identical(k.types[i]) {
+			// The key columns of the tables of a join can have different types.
+			key.values[i] = fmt.Sprintf("(%s)::%s", key.values[i], k.types[i].SQLString())
+		}
+	}
+	s := strings.Join(key.values, ", ")
+	if _, ok := k.seen[s]; ok {
+		return
+	}
+	k.seen[s] = struct{}{}
+	k.keys = append(k.keys, key)
+}
+
+// makeViewDeltaFilter returns a filter that selects the rows with the given
+// keys from the relation with the given alias.
+func makeViewDeltaFilter(alias string, keyCols []string, keys []viewDeltaKey) string {
+	cols := make([]string, len(keyCols))
+	for i := range keyCols {
+		cols[i] = alias + "." + keyCols[i]
+	}
+	var tuples, disjuncts []string
+	for _, key := range keys {
+		if !key.hasNull {
+			tuples = append(tuples, "("+strings.Join(key.values, ", ")+")")
+			continue
+		}
+		// NULL values can't be matched by IN.
+		conds := make([]string, len(cols))
+		for i := range cols {
+			conds[i] = fmt.Sprintf("%s IS NOT DISTINCT FROM %s", cols[i], key.values[i])
+		}
+		disjuncts = append(disjuncts, "("+strings.Join(conds, " AND ")+")")
+	}
+	if len(tuples) > 0 {
+		disjuncts = append(disjuncts, fmt.Sprintf(
+			"(%s) IN (%s)", strings.Join(cols, ", "), strings.Join(tuples, ", "),
+		))
+	}
+	return strings.Join(disjuncts, " OR ")
+}
+
+// viewDeltaDecoder decodes the values of the key columns of a source of a
+// view query from the KVs of its primary index.
+type viewDeltaDecoder struct {
+	tableID   descpb.ID
+	fetcher   row.Fetcher
+	kvFetcher row.SpanKVFetcher
+	alloc     tree.DatumAlloc
+}
+
+func (d *viewDeltaDecoder) init(
+	ctx context.Context, codec keys.SQLCodec, src *viewDeltaSource,
+) error {
+	d.tableID = src.desc.GetID()
+	colIDs := make([]descpb.ColumnID, len(src.keyCols))
+	for i, col := range src.keyCols {
+		colIDs[i] = col.GetID()
+	}
+	var spec descpb.IndexFetchSpec
+	if err := rowenc.InitIndexFetchSpec(
+		&spec, codec, src.desc, src.desc.GetPrimaryIndex(), colIDs,
+	); err != nil {
+		return err
+	}
+	return d.fetcher.Init(ctx, row.FetcherInitArgs{Alloc: &d.alloc, Spec: &spec})
+}
+
+// decode returns the values of the key columns of the row with the given KV.
+func (d *viewDeltaDecoder) decode(
+	ctx context.Context, key roachpb.Key, value roachpb.Value,
+) (tree.Datums, error) {
+	d.kvFetcher.KVs = append(d.kvFetcher.KVs[:0], roachpb.KeyValue{Key: key, Value: value})
+	if err := d.fetcher.StartScanFrom(ctx, &d.kvFetcher, false /* traceKV */); err != nil {
+		return nil, err
+	}
+	datums, err := d.fetcher.NextRowDecoded(ctx)
+	if err != nil || datums == nil {
+		return nil, err
+	}
+	return append(tree.Datums(nil), datums...), nil
+}
+
+// refreshIncrementally refreshes the materialized view by recomputing the rows
+// of the view whose keys changed since the last refresh. It returns false if
+// the view must be fully refreshed instead.
+func (n *refreshMaterializedViewNode) refreshIncrementally(params runParams) (bool, error) {
+	ctx, p := params.ctx, params.p
+	fallBack := func(format string, args ...interface{}) (bool, error) {
+		params.p.BufferClientNotice(ctx, pgnotice.Newf(
+			"performing a full refresh of the materialized view: %s", fmt.Sprintf(format, args...),
+		))
+		return false, nil
+	}
+
+	startTS := n.desc.GetMaterializedViewRefreshTime()
+	if startTS.IsEmpty() {
+		return fallBack("the data of the view is not up to date")
+	}
+	for i := range n.shape.sources {
+		// The spans of the tables and the encoding of their rows could have
+		// changed.
+		if src := n.shape.sources[i].desc; startTS.Less(src.GetModificationTime()) {
+			return fallBack("table %q was modified since the last refresh", src.GetName())
+		}
+	}
+	endTS := p.Txn().ReadTimestamp()
+	changed, reason, err := n.collectChangedKeys(ctx, p, startTS, endTS)
+	if err != nil || reason != "" {
+		if err != nil {
+			return false, err
+		}
+		return fallBack("%s", reason)
+	}
+
+	viewCols := n.desc.VisibleColumns()
+	colNames := make([]string, len(viewCols))
+	for i, col := range viewCols {
+		colNames[i] = tree.NameString(col.GetName())
+	}
+	keyCols := make([]string, len(n.shape.keyOrdinals))
+	for i, ord := range n.shape.keyOrdinals {
+		keyCols[i] = colNames[ord]
+	}
+	cols := strings.Join(colNames, ", ")
+
+	// The rows of the view are recomputed by internal statements which are
+	// allowed to modify the view.
+	sd := p.SessionData().Clone()
+	sd.AllowMaterializedViewMutations = true
+	ie := p.ExecCfg().InternalExecutorFactory(ctx, sd)
+	override := sessiondata.InternalExecutorOverride{User: username.RootUserName()}
+	for start := 0; start < len(changed.keys); start += incrementalRefreshBatchSize {
+		end := start + incrementalRefreshBatchSize
+		if end > len(changed.keys) {
+			end = len(changed.keys)
+		}
+		batch := changed.keys[start:end]
+		if _, err := ie.ExecEx(
+			ctx, "refresh-materialized-view-delete", p.Txn(), override,
+			fmt.Sprintf(`DELETE FROM [%d AS v] WHERE %s`,
+				n.desc.GetID(), makeViewDeltaFilter("v", keyCols, batch)),
+		); err != nil {
+			return false, err
+		}
+		if _, err := ie.ExecEx(
+			ctx, "refresh-materialized-view-insert", p.Txn(), override,
+			fmt.Sprintf(`INSERT INTO [%d AS v] (%s) SELECT * FROM (%s) AS q (%s) WHERE %s`,
+				n.desc.GetID(), cols, n.desc.GetViewQuery(), cols,
+				makeViewDeltaFilter("q", keyCols, batch)),
+		); err != nil {
+			return false, err
+		}
+	}
+	log.VEventf(ctx, 2, "incrementally refreshed %d keys of materialized view %d",
+		len(changed.keys), n.desc.GetID())
+
+	n.desc.MaterializedViewRefreshTime = endTS
+	return true, p.writeSchemaChange(
+		ctx, n.desc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+// collectChangedKeys returns the keys of the view whose rows have to be
+// recomputed because of the changes to the sources of the view between
+// startTS (exclusive) and endTS (inclusive). The changes are read from a
+// rangefeed with diffs started at startTS, which replays the history of the
+// rows of the sources. If the changes can't be read, or if too many keys
+// changed, a reason for performing a full refresh instead is returned.
+func (n *refreshMaterializedViewNode) collectChangedKeys(
+	ctx context.Context, p *planner, startTS, endTS hlc.Timestamp,
+) (_ *viewDeltaKeys, reason string, _ error) {
+	codec := p.ExecCfg().Codec
+	decoders := make([]viewDeltaDecoder, len(n.shape.sources))
+	defer func() {
+		for i := range decoders {
+			decoders[i].fetcher.Close(ctx)
+		}
+	}()
+	var spans []roachpb.Span
+	var tableIDs catalog.DescriptorIDSet
+	for i := range n.shape.sources {
+		src := &n.shape.sources[i]
+		if err := decoders[i].init(ctx, codec, src); err != nil {
+			return nil, "", err
+		}
+		if !tableIDs.Contains(src.desc.GetID()) {
+			tableIDs.Add(src.desc.GetID())
+			spans = append(spans, src.desc.PrimaryIndexSpan(codec))
+		}
+	}
+
+	// The values and the frontier updates of the rangefeed are delivered on the
+	// same goroutine, so a nil value is sent on eventCh once all the changes up
+	// to endTS were sent.
+	eventCh := make(chan *roachpb.RangeFeedValue, 64)
+	errCh := make(chan error, 1)
+	var sentDone bool
+	send := func(ctx context.Context, value *roachpb.RangeFeedValue) {
+		select {
+		case eventCh <- value:
+		case <-ctx.Done():
+		}
+	}
+	feed, err := p.ExecCfg().RangeFeedFactory.RangeFeed(
+		ctx,
+		fmt.Sprintf("refresh-materialized-view-%d", n.desc.GetID()),
+		spans,
+		startTS,
+		func(ctx context.Context, value *roachpb.RangeFeedValue) {
+			if endTS.Less(value.Value.Timestamp) {
+				return
+			}
+			send(ctx, value)
+		},
+		rangefeed.WithDiff(true),
+		rangefeed.WithOnFrontierAdvance(func(ctx context.Context, ts hlc.Timestamp) {
+			if !sentDone && endTS.LessEq(ts) {
+				sentDone = true
+				send(ctx, nil)
+			}
+		}),
+		rangefeed.WithOnInternalError(func(ctx context.Context, err error) {
+			errCh <- err
+		}),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	defer feed.Close()
+
+	keyTypes := make([]*types.T, len(n.shape.keyOrdinals))
+	viewCols := n.desc.VisibleColumns()
+	for i, ord := range n.shape.keyOrdinals {
+		keyTypes[i] = viewCols[ord].GetType()
+	}
+	changed := &viewDeltaKeys{types: keyTypes, seen: make(map[string]struct{})}
+	maxKeys := int(incrementalRefreshMaxChangedKeys.Get(&p.ExecCfg().Settings.SV))
+	for {
+		select {
+		case value := <-eventCh:
+			if value == nil {
+				return changed, "", nil
+			}
+			_, tableID, err := codec.DecodeTablePrefix(value.Key)
+			if err != nil {
+				return nil, "", err
+			}
+			for i := range decoders {
+				if decoders[i].tableID != descpb.ID(tableID) {
+					continue
+				}
+				// Both the rows before and after the change have to be
+				// recomputed.
+				for _, v := range []roachpb.Value{value.PrevValue, value.Value} {
+					if !v.IsPresent() {
+						continue
+					}
+					key, err := decoders[i].decode(ctx, value.Key, v)
+					if err != nil {
+						return nil, "", err
+					}
+					if key != nil {
+						changed.add(key)
+					}
+				}
+			}
+			if len(changed.keys) > maxKeys {
+				return nil, fmt.Sprintf("more than %d keys changed since the last refresh", maxKeys), nil
+			}
+
+		case err := <-errCh:
+			log.VEventf(ctx, 2, "rangefeed for materialized view %d failed: %v", n.desc.GetID(), err)
+			return nil, "the changes since the last refresh are no longer available", nil
+
+		case <-ctx.Done():
+			return nil, "", ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/resultcache"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// canUseResultCache returns whether the results of the current statement may
+// be looked up in the result cache. Only the SELECT statements of implicit
+// transactions of the sessions that set enable_result_cache can use the cache,
+// since the cached results can be stale.
+func (p *planner) canUseResultCache() bool {
+	if p.execCfg.ResultCache == nil || !p.execCfg.ResultCache.Enabled() {
+		return false
+	}
+	if !p.SessionData().EnableResultCache || !p.autoCommit {
+		return false
+	}
+	if _, ok := p.stmt.AST.(*tree.Select); !ok {
+		return false
+	}
+	if asOf := p.EvalContext().AsOfSystemTime; asOf != nil && asOf.BoundedStaleness {
+		return false
+	}
+	return true
+}
+
+// makeResultCacheStatement returns the description of the current statement
+// used by the result cache, or nil if the results of the statement cannot be
+// cached. The results can be cached if the statement doesn't have side effects
+// and if they only depend on the data of the tables the statement reads, the
+// placeholders, the user and its role memberships.
+func (p *planner) makeResultCacheStatement(
+	mem *memo.Memo, cols colinfo.ResultColumns,
+) *resultcache.Statement {
+	rel := mem.RootExpr().(memo.RelExpr).Relational()
+	if rel.CanMutate || rel.VolatilitySet.HasStable() || rel.VolatilitySet.HasVolatile() {
+		// The statement writes, locks rows, or uses functions whose results
+		// depend on the time or on the session.
+		return nil
+	}
+	md := mem.Metadata()
+	if len(md.AllSequences()) > 0 {
+		return nil
+	}
+
+	stmt := &resultcache.Statement{
+		SQL:          p.stmt.SQL,
+		User:         p.User(),
+		Placeholders: p.semaCtx.Placeholders.Values,
+		ResultTypes:  make([]*types.T, len(cols)),
+	}
+	for i := range cols {
+		stmt.ResultTypes[i] = cols[i].Typ
+	}
+	// The memo is only reused if the role memberships recorded in its metadata
+	// are still current (see opt.Metadata.CheckDependencies), so these are the
+	// memberships of the user at the time of the statement.
+	for i, n := 0, md.NumRoleMemberships(); i < n; i++ {
+		role, isMember := md.RoleMembership(i)
+		stmt.RoleMemberships = append(stmt.RoleMemberships, resultcache.RoleMembership{
+			Role: role, IsMember: isMember,
+		})
+	}
+	seen := make(map[descpb.ID]struct{})
+	for _, tabMeta := range md.AllTables() {
+		tab, ok := tabMeta.Table.(*optTable)
+		if !ok {
+			// Virtual tables are computed on the fly.
+			return nil
+		}
+		id := tab.desc.GetID()
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		stmt.Dependencies = append(stmt.Dependencies, resultcache.Dependency{
+			ID: id, Version: tab.desc.GetVersion(),
+		})
+		rowCount := int64(-1)
+		if tab.StatisticCount() > 0 {
+			rowCount = int64(tab.Statistic(0).RowCount())
+		}
+		stmt.Tables = append(stmt.Tables, resultcache.Table{ID: id, RowCount: rowCount})
+	}
+	for _, v := range md.AllViews() {
+		view, ok := v.(*optView)
+		if !ok {
+			return nil
+		}
+		stmt.Dependencies = append(stmt.Dependencies, resultcache.Dependency{
+			ID: view.desc.GetID(), Version: view.desc.GetVersion(),
+		})
+	}
+	for _, typ := range md.AllUserDefinedTypes() {
+		id, err := typedesc.UserDefinedTypeOIDToID(typ.Oid())
+		if err != nil {
+			return nil
+		}
+		stmt.Dependencies = append(stmt.Dependencies, resultcache.Dependency{
+			ID: id, Version: descpb.DescriptorVersion(typ.TypeMeta.Version),
+		})
+	}
+	return stmt
+}
+
+// execWithResultCache returns the results of the current statement from the
+// result cache if possible. Otherwise, it runs the statement with the DistSQL
+// engine and adds its results to the cache.
+func (ex *connExecutor) execWithResultCache(
+	ctx context.Context,
+	planner *planner,
+	res RestrictedCommandResult,
+	distribute DistributionType,
+	progressAtomic *uint64,
+) (topLevelQueryStats, error) {
+	c := ex.server.cfg.ResultCache
+	req, ok := c.NewRequest(ctx, planner.curPlan.resultCacheStmt, planner.Txn().ReadTimestamp())
+	if !ok {
+		return ex.execWithDistSQLEngine(ctx, planner, tree.Rows, res, distribute, progressAtomic)
+	}
+	if rows, ok := c.Get(&req, planner.Txn().ReadTimestamp()); ok {
+		planner.curPlan.flags.Set(planFlagResultCacheHit)
+		for _, row := range rows {
+			if err := res.AddRow(ctx, row); err != nil {
+				// See DistSQLReceiver.handleCommErr.
+				if errors.Is(err, ErrLimitedResultClosed) {
+					break
+				}
+				res.SetError(err)
+				if errors.Is(err, ErrLimitedResultNotSupported) {
+					break
+				}
+				return topLevelQueryStats{}, err
+			}
+		}
+		return topLevelQueryStats{}, nil
+	}
+
+	planner.curPlan.flags.Set(planFlagResultCacheMiss)
+	w := resultCacheWriter{RestrictedCommandResult: res}
+	stats, err := ex.execWithDistSQLEngine(ctx, planner, tree.Rows, &w, distribute, progressAtomic)
+	if err == nil && res.Err() == nil && !w.incomplete {
+		// The read timestamp might have been moved forward during the
+		// execution.
+		c.Add(&req, planner.Txn().ReadTimestamp(), &w.rec)
+	}
+	return stats, err
+}
+
+// resultCacheWriter is a RestrictedCommandResult that records the rows of the
+// results to add them to the result cache.
+type resultCacheWriter struct {
+	RestrictedCommandResult
+
+	rec resultcache.Recorder
+	// incomplete is set if not all the rows were sent to the client, for
+	// example because a portal was closed before fetching all its rows.
+	incomplete bool
+}
+
+var _ RestrictedCommandResult = &resultCacheWriter{}
+
+// AddRow is part of the RestrictedCommandResult interface.
+func (w *resultCacheWriter) AddRow(ctx context.Context, row tree.Datums) error {
+	if err := w.RestrictedCommandResult.AddRow(ctx, row); err != nil {
+		w.incomplete = true
+		return err
+	}
+	w.rec.AddRow(row)
+	return nil
+}
+
+// AddBatch is part of the RestrictedCommandResult interface.
+func (w *resultCacheWriter) AddBatch(context.Context, coldata.Batch) error {
+	return errors.AssertionFailedf("AddBatch is not supported by resultCacheWriter")
+}
+
+// SupportsAddBatch is part of the RestrictedCommandResult interface. The rows
+// are always materialized, so that they can be recorded.
+func (w *resultCacheWriter) SupportsAddBatch() bool {
+	return false
+}
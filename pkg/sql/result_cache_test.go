@@ -0,0 +1,78 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// TestResultCacheDimensionTableWrites verifies that a statement reading a
+// dimension table right after a write to it doesn't get the stale results from
+// the result cache, even though the rangefeed on the table might not have
+// seen the write yet.
+func TestResultCacheDimensionTableWrites(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	// The session variable must apply to all the statements.
+	db.SetMaxOpenConns(1)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	sqlDB.Exec(t, `SET CLUSTER SETTING kv.closed_timestamp.target_duration = '10ms'`)
+	sqlDB.Exec(t, `SET CLUSTER SETTING sql.result_cache.size = '1MiB'`)
+	sqlDB.Exec(t, `CREATE TABLE t (a INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `INSERT INTO t VALUES (0)`)
+	sqlDB.Exec(t, `CREATE STATISTICS s FROM t`)
+	sqlDB.Exec(t, `SET enable_result_cache = true`)
+
+	getHits := func() int {
+		var hits int
+		sqlDB.QueryRow(t,
+			`SELECT value FROM crdb_internal.node_metrics WHERE name = 'sql.result_cache.hits'`,
+		).Scan(&hits)
+		return hits
+	}
+	// Wait for the rangefeed on the table to be established. The results are
+	// only served from the cache once the rangefeed has caught up with the read
+	// timestamp, so we use a read in the past.
+	testutils.SucceedsSoon(t, func() error {
+		hits := getHits()
+		if _, err := db.Exec(`SELECT count(*) FROM t AS OF SYSTEM TIME '-1s'`); err != nil {
+			return err
+		}
+		if getHits() == hits {
+			return errors.New("the results are not served from the cache yet")
+		}
+		return nil
+	})
+
+	// The results of the reads right after the writes are cached, but they
+	// must not be served to the following reads until the rangefeed has seen
+	// the next write.
+	for i := 1; i <= 10; i++ {
+		sqlDB.Exec(t, `INSERT INTO t VALUES ($1)`, i)
+		sqlDB.CheckQueryResults(t, `SELECT count(*) FROM t`, [][]string{{strconv.Itoa(i + 1)}})
+	}
+}
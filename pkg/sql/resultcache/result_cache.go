@@ -0,0 +1,419 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package resultcache implements a per-node cache of the results of read-only
+// statements.
+package resultcache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/memsize"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// Size is the maximum memory used by the result cache of each node.
+var Size = settings.RegisterByteSizeSetting(
+	settings.TenantWritable,
+	"sql.result_cache.size",
+	"the maximum memory used by the per-node cache of query results, which is "+
+		"used by the sessions that set enable_result_cache; 0 disables the cache",
+	0,
+	settings.NonNegativeInt,
+).WithPublic()
+
+// MaxStaleness is the width of the read timestamp buckets of the cached
+// results, which bounds how stale the results served from the cache are.
+var MaxStaleness = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"sql.result_cache.max_staleness",
+	"the maximum staleness of the query results served from the result cache, "+
+		"unless all the tables they read are small dimension tables; 0 only "+
+		"caches the results of queries that read small dimension tables",
+	time.Second,
+	settings.NonNegativeDuration,
+).WithPublic()
+
+// DimensionTableMaxRows is the maximum number of rows of the tables whose
+// changes invalidate the cached results using a rangefeed.
+var DimensionTableMaxRows = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.result_cache.dimension_table_max_rows",
+	"the maximum number of rows, according to the table statistics, of the "+
+		"tables whose changes invalidate the cached query results through a "+
+		"rangefeed; the results of queries that only read such tables are "+
+		"cached until the tables change; 0 disables the invalidation",
+	10000,
+	settings.NonNegativeInt,
+).WithPublic()
+
+// maxEntrySize is the maximum memory used by one entry of the cache. We
+// disallow caching large results, which are expensive to copy and would evict
+// many other entries.
+const maxEntrySize = 1 << 20 // 1 MiB
+
+// maxWatchedTables is the maximum number of dimension tables watched with a
+// rangefeed by each cache.
+const maxWatchedTables = 128
+
+// Dependency is a descriptor on which the results of a statement depend.
+type Dependency struct {
+	ID      descpb.ID
+	Version descpb.DescriptorVersion
+}
+
+// RoleMembership is the result of the check of whether the user running a
+// statement is a member of a role.
+type RoleMembership struct {
+	Role     username.SQLUsername
+	IsMember bool
+}
+
+// Table is a table read by a statement.
+type Table struct {
+	ID descpb.ID
+	// RowCount is the number of rows of the table according to its most recent
+	// statistics, or -1 if the table has no statistics.
+	RowCount int64
+}
+
+// Statement describes a read-only statement whose results can be cached.
+type Statement struct {
+	// SQL is the text of the statement, including its constants.
+	SQL string
+	// User is the user running the statement. The results of a statement
+	// depend on its user when the tables have row-level security policies.
+	User username.SQLUsername
+	// Placeholders are the values of the placeholders of the statement.
+	Placeholders tree.QueryArguments
+	// ResultTypes are the types of the result columns of the statement, which
+	// depend on some session variables such as default_int_size.
+	ResultTypes []*types.T
+	// RoleMemberships are the results of the checks of whether the user is a
+	// member of a role that were made while planning the statement, which
+	// determine the row-level security policies that apply to it. Role
+	// memberships can change without changing the versions of the
+	// descriptors, so they are part of the key.
+	RoleMemberships []RoleMembership
+	// Dependencies are the tables, views and types used by the statement. The
+	// versions of the descriptors are part of the key, so schema changes make
+	// the cached results unreachable.
+	Dependencies []Dependency
+	// Tables are the tables read by the statement.
+	Tables []Table
+}
+
+// Request is used to look up and add the results of a statement to the cache.
+type Request struct {
+	key string
+	// tables, if set, are the dimension tables read by the statement, whose
+	// changes invalidate the cached results. Otherwise, the key contains the
+	// bucket of the read timestamp.
+	tables []descpb.ID
+}
+
+// Cache is a cache of the results of read-only statements, keyed by the
+// statement, the values of its placeholders, the versions of the descriptors
+// it depends on, and by the bucket of its read timestamp.
+//
+// The results of statements that only read small dimension tables are not
+// keyed by the read timestamp. Instead, a rangefeed is run on each of these
+// tables, and removes the results that depend on a table when it changes.
+// Such results are only returned to the statements reading at or below the
+// resolved timestamps of the rangefeeds, which lag behind the present time, so
+// they mostly benefit the statements reading in the past (e.g. using
+// follower_read_timestamp()).
+type Cache struct {
+	ambientCtx log.AmbientContext
+	settings   *cluster.Settings
+	codec      keys.SQLCodec
+	// rangeFeedFactory is used to watch the dimension tables. If nil, all the
+	// results are keyed by the bucket of their read timestamp.
+	rangeFeedFactory *rangefeed.Factory
+	clock            *hlc.Clock
+
+	mu struct {
+		syncutil.Mutex
+
+		// cache maps the keys of the requests to *entry objects.
+		cache *cache.UnorderedCache
+		// usedMem is the memory used by the entries of the cache.
+		usedMem int64
+		// maxMem is the value of the Size setting.
+		maxMem int64
+		// watchers contains the dimension tables that are being watched.
+		watchers map[descpb.ID]*tableWatcher
+	}
+}
+
+// entry is a cached result.
+type entry struct {
+	rows []tree.Datums
+	// readTS is the timestamp at which the statement was run.
+	readTS hlc.Timestamp
+	// tables is Request.tables.
+	tables []descpb.ID
+	// memUsage is the memory used the entry, including its key.
+	memUsage int64
+}
+
+const sizeOfEntry = int64(unsafe.Sizeof(entry{}))
+
+// New creates a result cache.
+func New(
+	ambientCtx log.AmbientContext,
+	st *cluster.Settings,
+	codec keys.SQLCodec,
+	rangeFeedFactory *rangefeed.Factory,
+	clock *hlc.Clock,
+) *Cache {
+	c := &Cache{
+		ambientCtx:       ambientCtx,
+		settings:         st,
+		codec:            codec,
+		rangeFeedFactory: rangeFeedFactory,
+		clock:            clock,
+	}
+	c.mu.cache = cache.NewUnorderedCache(cache.Config{
+		Policy: cache.CacheLRU,
+		ShouldEvict: func(_ int, _, _ interface{}) bool {
+			return c.mu.usedMem > c.mu.maxMem
+		},
+		OnEvicted: func(key, value interface{}) {
+			c.onEvictedLocked(key.(string), value.(*entry))
+		},
+	})
+	c.mu.maxMem = Size.Get(&st.SV)
+	c.mu.watchers = make(map[descpb.ID]*tableWatcher)
+	Size.SetOnChange(&st.SV, func(ctx context.Context) {
+		c.resize(Size.Get(&st.SV))
+	})
+	return c
+}
+
+// Enabled returns whether the cache is enabled by the sql.result_cache.size
+// setting.
+func (c *Cache) Enabled() bool {
+	return Size.Get(&c.settings.SV) > 0
+}
+
+// NewRequest returns the request used to look up and add the results of the
+// given statement, which is run at the given timestamp. It returns false if
+// the results of the statement cannot be cached.
+func (c *Cache) NewRequest(
+	ctx context.Context, stmt *Statement, readTS hlc.Timestamp,
+) (_ Request, ok bool) {
+	var r Request
+	if watched := c.watchTables(ctx, stmt.Tables); watched {
+		r.tables = make([]descpb.ID, len(stmt.Tables))
+		for i := range stmt.Tables {
+			r.tables[i] = stmt.Tables[i].ID
+		}
+	}
+
+	var buf strings.Builder
+	buf.WriteString(stmt.SQL)
+	buf.WriteString("\x00")
+	buf.WriteString(stmt.User.Normalized())
+	for _, p := range stmt.Placeholders {
+		buf.WriteString("\x00")
+		buf.WriteString(tree.AsStringWithFlags(p, tree.FmtCheckEquivalence))
+	}
+	buf.WriteString("\x00")
+	for _, t := range stmt.ResultTypes {
+		buf.WriteString(t.SQLString())
+		buf.WriteString(",")
+	}
+	buf.WriteString("\x00")
+	for _, m := range stmt.RoleMemberships {
+		buf.WriteString(m.Role.Normalized())
+		buf.WriteString("=")
+		buf.WriteString(strconv.FormatBool(m.IsMember))
+		buf.WriteString(",")
+	}
+	buf.WriteString("\x00")
+	for _, d := range stmt.Dependencies {
+		buf.WriteString(strconv.FormatUint(uint64(d.ID), 10))
+		buf.WriteString("@")
+		buf.WriteString(strconv.FormatUint(uint64(d.Version), 10))
+		buf.WriteString(",")
+	}
+	if r.tables == nil {
+		bucketWidth := MaxStaleness.Get(&c.settings.SV)
+		if bucketWidth <= 0 {
+			return Request{}, false
+		}
+		buf.WriteString("\x00")
+		buf.WriteString(strconv.FormatInt(readTS.WallTime/bucketWidth.Nanoseconds(), 10))
+	}
+	r.key = buf.String()
+	return r, true
+}
+
+// Get returns the cached results of the request, if any. The results must not
+// be modified.
+//
+// Results cached at a timestamp after the given read timestamp are not
+// returned, so that a statement doesn't see changes from its future. The
+// results that depend on dimension tables are only returned once the
+// rangefeeds on all these tables have caught up with the read timestamp, since
+// a change up to that timestamp might not have been seen yet.
+func (c *Cache) Get(r *Request, readTS hlc.Timestamp) (_ []tree.Datums, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.mu.cache.Get(r.key)
+	if !ok {
+		return nil, false
+	}
+	e := v.(*entry)
+	if readTS.Less(e.readTS) {
+		return nil, false
+	}
+	for _, id := range e.tables {
+		if w := c.mu.watchers[id]; w == nil || !w.coversLocked(readTS) {
+			return nil, false
+		}
+	}
+	return e.rows, true
+}
+
+// Recorder accumulates the results of a statement, to add them to the cache.
+type Recorder struct {
+	rows     []tree.Datums
+	memUsage int64
+	// tooLarge is set once the results are too large to be cached, in which
+	// case the rows are not recorded anymore.
+	tooLarge bool
+}
+
+// AddRow records a copy of the given row.
+func (r *Recorder) AddRow(row tree.Datums) {
+	if r.tooLarge {
+		return
+	}
+	r.memUsage += memsize.DatumsOverhead + int64(len(row))*memsize.DatumOverhead
+	for _, d := range row {
+		r.memUsage += int64(d.Size())
+	}
+	if r.memUsage > maxEntrySize {
+		r.tooLarge = true
+		r.rows = nil
+		return
+	}
+	r.rows = append(r.rows, append(tree.Datums(nil), row...))
+}
+
+// Add adds the results accumulated by the recorder, read at the given
+// timestamp, to the cache. The recorder must not be used once this method is
+// called.
+func (c *Cache) Add(r *Request, readTS hlc.Timestamp, rec *Recorder) {
+	if rec.tooLarge {
+		return
+	}
+	memUsage := sizeOfEntry + int64(len(r.key)) + memsize.RowsOverhead +
+		int64(len(r.tables))*int64(unsafe.Sizeof(descpb.ID(0))) + rec.memUsage
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if memUsage > c.mu.maxMem {
+		return
+	}
+	for _, id := range r.tables {
+		w := c.mu.watchers[id]
+		if w == nil || !w.isUpToDateLocked(readTS) {
+			// The table isn't watched anymore, or it changed after the results
+			// were read, in which case they're already stale.
+			return
+		}
+	}
+	c.mu.cache.Del(r.key)
+	c.mu.usedMem += memUsage
+	c.mu.cache.Add(r.key, &entry{
+		rows:     rec.rows,
+		readTS:   readTS,
+		tables:   r.tables,
+		memUsage: memUsage,
+	})
+	if _, ok := c.mu.cache.StealthyGet(r.key); !ok {
+		// The entry was evicted right away.
+		return
+	}
+	for _, id := range r.tables {
+		c.mu.watchers[id].entries[r.key] = struct{}{}
+	}
+}
+
+// Clear removes all the entries from the cache.
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mu.cache.Clear()
+}
+
+// onEvictedLocked is called when an entry is removed from the cache.
+func (c *Cache) onEvictedLocked(key string, e *entry) {
+	c.mu.usedMem -= e.memUsage
+	for _, id := range e.tables {
+		if w := c.mu.watchers[id]; w != nil {
+			delete(w.entries, key)
+		}
+	}
+}
+
+// resize updates the maximum memory used by the cache. If the cache is
+// disabled, all the entries are removed and the rangefeeds are stopped.
+func (c *Cache) resize(maxMem int64) {
+	var toClose []*tableWatcher
+	func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.mu.maxMem = maxMem
+		if maxMem > 0 {
+			// Evict the entries that don't fit anymore.
+			for c.mu.usedMem > c.mu.maxMem && c.mu.cache.Len() > 0 {
+				c.evictOldestLocked()
+			}
+			return
+		}
+		c.mu.cache.Clear()
+		for id, w := range c.mu.watchers {
+			toClose = append(toClose, w)
+			delete(c.mu.watchers, id)
+		}
+	}()
+	// Closing a rangefeed waits for its callbacks, which lock the cache.
+	for _, w := range toClose {
+		w.feed.Close()
+	}
+}
+
+// evictOldestLocked removes the least recently used entry from the cache.
+func (c *Cache) evictOldestLocked() {
+	var oldest *cache.Entry
+	c.mu.cache.Do(func(e *cache.Entry) {
+		oldest = e
+	})
+	c.mu.cache.DelEntry(oldest)
+}
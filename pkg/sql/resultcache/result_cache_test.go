@@ -0,0 +1,252 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package resultcache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCache(t *testing.T, size int64) *Cache {
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	Size.Override(ctx, &st.SV, size)
+	MaxStaleness.Override(ctx, &st.SV, time.Second)
+	return New(log.AmbientContext{}, st, keys.SystemSQLCodec, nil /* rangeFeedFactory */, nil /* clock */)
+}
+
+func testStmt(sql string, placeholders ...tree.TypedExpr) *Statement {
+	return &Statement{
+		SQL:          sql,
+		User:         username.RootUserName(),
+		Placeholders: placeholders,
+		ResultTypes:  []*types.T{types.Int},
+		Dependencies: []Dependency{{ID: 100, Version: 1}},
+		Tables:       []Table{{ID: 100, RowCount: 10}},
+	}
+}
+
+func testRows(vals ...int) []tree.Datums {
+	rows := make([]tree.Datums, len(vals))
+	for i, v := range vals {
+		rows[i] = tree.Datums{tree.NewDInt(tree.DInt(v))}
+	}
+	return rows
+}
+
+func testRecorder(vals ...int) *Recorder {
+	var rec Recorder
+	for _, row := range testRows(vals...) {
+		rec.AddRow(row)
+	}
+	return &rec
+}
+
+func ts(seconds float64) hlc.Timestamp {
+	return hlc.Timestamp{WallTime: int64(seconds * float64(time.Second))}
+}
+
+func TestResultCache(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	c := newTestCache(t, 1<<20)
+
+	get := func(stmt *Statement, readTS hlc.Timestamp) []tree.Datums {
+		t.Helper()
+		r, ok := c.NewRequest(ctx, stmt, readTS)
+		require.True(t, ok)
+		rows, _ := c.Get(&r, readTS)
+		return rows
+	}
+	add := func(stmt *Statement, readTS hlc.Timestamp, vals ...int) {
+		t.Helper()
+		r, ok := c.NewRequest(ctx, stmt, readTS)
+		require.True(t, ok)
+		c.Add(&r, readTS, testRecorder(vals...))
+	}
+
+	stmt := testStmt("SELECT a FROM t")
+	require.Nil(t, get(stmt, ts(10)))
+	add(stmt, ts(10.5), 1, 2)
+	require.Equal(t, testRows(1, 2), get(stmt, ts(10.7)))
+
+	// The results are not returned to statements reading at an earlier
+	// timestamp, or outside of the read timestamp bucket.
+	require.Nil(t, get(stmt, ts(10.2)))
+	require.Nil(t, get(stmt, ts(11)))
+
+	// The placeholders, the user, the role memberships, the result types and
+	// the versions of the descriptors are part of the key.
+	p1 := testStmt("SELECT a FROM t WHERE a = $1", tree.NewDInt(1))
+	add(p1, ts(10), 1)
+	require.Equal(t, testRows(1), get(testStmt("SELECT a FROM t WHERE a = $1", tree.NewDInt(1)), ts(10)))
+	require.Nil(t, get(testStmt("SELECT a FROM t WHERE a = $1", tree.NewDInt(2)), ts(10)))
+
+	other := testStmt("SELECT a FROM t")
+	other.User = username.TestUserName()
+	require.Nil(t, get(other, ts(10)))
+
+	other = testStmt("SELECT a FROM t")
+	other.RoleMemberships = []RoleMembership{{Role: username.AdminRoleName(), IsMember: false}}
+	require.Nil(t, get(other, ts(10.7)))
+	add(other, ts(10.5), 3)
+	require.Equal(t, testRows(3), get(other, ts(10.7)))
+	other.RoleMemberships[0].IsMember = true
+	require.Nil(t, get(other, ts(10.7)))
+	require.Equal(t, testRows(1, 2), get(stmt, ts(10.7)))
+
+	other = testStmt("SELECT a FROM t")
+	other.ResultTypes = []*types.T{types.Int4}
+	require.Nil(t, get(other, ts(10)))
+
+	other = testStmt("SELECT a FROM t")
+	other.Dependencies[0].Version = 2
+	require.Nil(t, get(other, ts(10)))
+
+	// Results are not cached when the bucket width is 0, unless they only read
+	// watched dimension tables.
+	MaxStaleness.Override(ctx, &c.settings.SV, 0)
+	_, ok := c.NewRequest(ctx, stmt, ts(10))
+	require.False(t, ok)
+	MaxStaleness.Override(ctx, &c.settings.SV, time.Second)
+
+	c.Clear()
+	require.Nil(t, get(stmt, ts(10)))
+}
+
+func TestResultCacheEviction(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+
+	stmt := func(i int) *Statement {
+		return testStmt("SELECT a FROM t WHERE a = $1", tree.NewDInt(tree.DInt(i)))
+	}
+	entrySize := func(c *Cache, i int) int64 {
+		r, ok := c.NewRequest(ctx, stmt(i), ts(1))
+		require.True(t, ok)
+		c.Add(&r, ts(1), testRecorder(i))
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.mu.usedMem
+	}
+	size := entrySize(newTestCache(t, 1<<20), 0)
+	c := newTestCache(t, 3*size)
+
+	for i := 0; i < 5; i++ {
+		r, ok := c.NewRequest(ctx, stmt(i), ts(1))
+		require.True(t, ok)
+		c.Add(&r, ts(1), testRecorder(i))
+		if i == 2 {
+			// Access the first entry, so that it is evicted after the following ones.
+			_, ok := c.Get(&Request{key: c.mustKey(t, stmt(0))}, ts(1))
+			require.True(t, ok)
+		}
+	}
+	var cached []int
+	for i := 0; i < 5; i++ {
+		if _, ok := c.Get(&Request{key: c.mustKey(t, stmt(i))}, ts(1)); ok {
+			cached = append(cached, i)
+		}
+	}
+	require.Equal(t, []int{0, 3, 4}, cached)
+	c.mu.Lock()
+	require.Equal(t, 3*size, c.mu.usedMem)
+	c.mu.Unlock()
+
+	// Shrinking the cache evicts the least recently used entries.
+	Size.Override(ctx, &c.settings.SV, 2*size)
+	_, ok := c.Get(&Request{key: c.mustKey(t, stmt(0))}, ts(1))
+	require.False(t, ok)
+
+	// Disabling the cache removes all the entries.
+	Size.Override(ctx, &c.settings.SV, 0)
+	c.mu.Lock()
+	require.Equal(t, 0, c.mu.cache.Len())
+	require.Equal(t, int64(0), c.mu.usedMem)
+	c.mu.Unlock()
+
+	// Entries larger than the cache are not added.
+	Size.Override(ctx, &c.settings.SV, size-1)
+	r, ok := c.NewRequest(ctx, stmt(0), ts(1))
+	require.True(t, ok)
+	c.Add(&r, ts(1), testRecorder(0))
+	_, ok = c.Get(&r, ts(1))
+	require.False(t, ok)
+}
+
+func TestResultCacheDimensionTables(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	c := newTestCache(t, 1<<20)
+
+	// Simulate an established rangefeed on the table.
+	w := &tableWatcher{startTS: ts(5), frontier: ts(6), entries: make(map[string]struct{})}
+	c.mu.watchers[100] = w
+	r := Request{key: c.mustKey(t, testStmt("SELECT a FROM t")), tables: []descpb.ID{100}}
+
+	// Results read before the rangefeed started are not cached.
+	c.Add(&r, ts(4), testRecorder(1))
+	_, ok := c.Get(&r, ts(4))
+	require.False(t, ok)
+
+	// The results are not returned until the frontier of the rangefeed catches
+	// up with the read timestamp, and they don't expire with the read
+	// timestamp bucket.
+	c.Add(&r, ts(10), testRecorder(1))
+	_, ok = c.Get(&r, ts(100))
+	require.False(t, ok)
+	c.onFrontierAdvance(w, ts(100))
+	rows, ok := c.Get(&r, ts(100))
+	require.True(t, ok)
+	require.Equal(t, testRows(1), rows)
+	require.Len(t, w.entries, 1)
+
+	// A write to the table that the rangefeed hasn't delivered yet must not be
+	// missed by a statement reading right after it.
+	_, ok = c.Get(&r, ts(102))
+	require.False(t, ok)
+
+	// Once the change is seen, it removes the results.
+	c.onTableChange(100, w, ts(101))
+	c.onFrontierAdvance(w, ts(102))
+	_, ok = c.Get(&r, ts(102))
+	require.False(t, ok)
+	require.Len(t, w.entries, 0)
+
+	// Results read before the latest change are not cached.
+	c.Add(&r, ts(100.5), testRecorder(1))
+	_, ok = c.Get(&r, ts(102))
+	require.False(t, ok)
+	c.Add(&r, ts(102), testRecorder(2))
+	rows, ok = c.Get(&r, ts(102))
+	require.True(t, ok)
+	require.Equal(t, testRows(2), rows)
+}
+
+// mustKey returns the key of the request for the given statement, keyed by
+// the read timestamp bucket.
+func (c *Cache) mustKey(t *testing.T, stmt *Statement) string {
+	r, ok := c.NewRequest(context.Background(), stmt, ts(1))
+	require.True(t, ok)
+	return r.key
+}
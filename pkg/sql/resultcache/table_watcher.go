@@ -0,0 +1,185 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package resultcache
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// tableWatcher runs a rangefeed on a dimension table, and removes the cached
+// results that depend on the table when it changes. Its fields are protected
+// by the mutex of the cache.
+type tableWatcher struct {
+	feed *rangefeed.RangeFeed
+	// startTS is the timestamp at which the rangefeed started. The changes
+	// before startTS aren't seen by the watcher.
+	startTS hlc.Timestamp
+	// frontier is the resolved timestamp of the rangefeed: all the changes to
+	// the table at or below it have been seen by the watcher.
+	frontier hlc.Timestamp
+	// lastChangeTS is the timestamp of the latest change to the table seen by
+	// the rangefeed.
+	lastChangeTS hlc.Timestamp
+	// entries contains the keys of the cached entries that depend on the table.
+	entries map[string]struct{}
+}
+
+// establishedLocked returns whether the frontier of the rangefeed advanced
+// past startTS, which means that the rangefeed is running on all the ranges of
+// the table.
+func (w *tableWatcher) establishedLocked() bool {
+	return w.startTS.Less(w.frontier)
+}
+
+// isUpToDateLocked returns whether the results of a statement reading the
+// table at the given timestamp can be cached until the watcher sees a change
+// to the table.
+func (w *tableWatcher) isUpToDateLocked(readTS hlc.Timestamp) bool {
+	return w.establishedLocked() && w.startTS.LessEq(readTS) && w.lastChangeTS.LessEq(readTS)
+}
+
+// coversLocked returns whether the watcher has seen all the changes to the
+// table up to the given timestamp, so that the cached results that depend on
+// the table are still valid at that timestamp.
+func (w *tableWatcher) coversLocked(readTS hlc.Timestamp) bool {
+	return readTS.LessEq(w.frontier)
+}
+
+// watchTables returns whether all the given tables are dimension tables that
+// are watched by an established rangefeed. It starts the rangefeeds of the
+// dimension tables that aren't watched yet, which are used by the following
+// statements.
+func (c *Cache) watchTables(ctx context.Context, tables []Table) bool {
+	maxRows := DimensionTableMaxRows.Get(&c.settings.SV)
+	if c.rangeFeedFactory == nil || maxRows == 0 || len(tables) == 0 {
+		return false
+	}
+	for i := range tables {
+		if tables[i].RowCount < 0 || tables[i].RowCount > maxRows {
+			return false
+		}
+	}
+
+	watched := true
+	var toWatch []descpb.ID
+	func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for i := range tables {
+			if w, ok := c.mu.watchers[tables[i].ID]; !ok {
+				toWatch = append(toWatch, tables[i].ID)
+				watched = false
+			} else if !w.establishedLocked() {
+				watched = false
+			}
+		}
+		if len(c.mu.watchers)+len(toWatch) > maxWatchedTables {
+			toWatch = nil
+		}
+	}()
+	for _, id := range toWatch {
+		c.startWatcher(ctx, id)
+	}
+	return watched
+}
+
+// startWatcher starts the rangefeed of a dimension table.
+func (c *Cache) startWatcher(ctx context.Context, id descpb.ID) {
+	// The rangefeed outlives the statement that started it.
+	feedCtx := c.ambientCtx.AnnotateCtx(context.Background())
+	w := &tableWatcher{
+		startTS: c.clock.Now(),
+		entries: make(map[string]struct{}),
+	}
+	prefix := c.codec.TablePrefix(uint32(id))
+	feed, err := c.rangeFeedFactory.RangeFeed(
+		feedCtx,
+		"result-cache",
+		[]roachpb.Span{{Key: prefix, EndKey: prefix.PrefixEnd()}},
+		w.startTS,
+		func(ctx context.Context, kv *roachpb.RangeFeedValue) {
+			c.onTableChange(id, w, kv.Value.Timestamp)
+		},
+		rangefeed.WithOnSSTable(func(ctx context.Context, sst *roachpb.RangeFeedSSTable) {
+			c.onTableChange(id, w, sst.WriteTS)
+		}),
+		rangefeed.WithOnFrontierAdvance(func(ctx context.Context, ts hlc.Timestamp) {
+			c.onFrontierAdvance(w, ts)
+		}),
+		rangefeed.WithOnInternalError(func(ctx context.Context, err error) {
+			// The rangefeed stopped, so we can't tell when the table changes
+			// anymore. The watcher is restarted by the next statement reading
+			// the table.
+			log.Warningf(ctx, "result cache rangefeed on table %d failed: %v", id, err)
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if c.mu.watchers[id] == w {
+				c.removeEntriesLocked(w)
+				delete(c.mu.watchers, id)
+			}
+		}),
+	)
+	if err != nil {
+		// The server is shutting down.
+		log.VEventf(ctx, 2, "could not start result cache rangefeed on table %d: %v", id, err)
+		return
+	}
+
+	added := func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if _, ok := c.mu.watchers[id]; ok || c.mu.maxMem == 0 {
+			// Another statement started a watcher for the table concurrently, or
+			// the cache was disabled.
+			return false
+		}
+		w.feed = feed
+		c.mu.watchers[id] = w
+		return true
+	}()
+	if !added {
+		feed.Close()
+	}
+}
+
+// onTableChange is called when the rangefeed of a watcher sees a change to its
+// table at the given timestamp.
+func (c *Cache) onTableChange(id descpb.ID, w *tableWatcher, ts hlc.Timestamp) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w.lastChangeTS.Forward(ts)
+	if c.mu.watchers[id] == w {
+		c.removeEntriesLocked(w)
+	}
+}
+
+// onFrontierAdvance is called when the resolved timestamp of the rangefeed of a
+// watcher advances.
+func (c *Cache) onFrontierAdvance(w *tableWatcher, ts hlc.Timestamp) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w.frontier.Forward(ts)
+}
+
+// removeEntriesLocked removes the cached entries that depend on the table of
+// the watcher.
+func (c *Cache) removeEntriesLocked(w *tableWatcher) {
+	for key := range w.entries {
+		// This removes the key from w.entries.
+		c.mu.cache.Del(key)
+	}
+}
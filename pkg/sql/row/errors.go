@@ -145,6 +145,30 @@ func NewUniquenessConstraintViolationError(
 	)
 }
 
+// NewValueChecksumError creates an error that represents a value whose
+// checksum doesn't match its contents, as detected by the fetcher when reading
+// the given key of the index described by spec.
+func NewValueChecksumError(spec *descpb.IndexFetchSpec, key roachpb.Key, cause error) error {
+	baseMsg := "value checksum mismatch on row"
+	colNames, values, err := decodeKeyValsUsingSpec(spec, key)
+	if err != nil {
+		err = errors.CombineErrors(cause, err)
+		return errors.WithDetailf(
+			pgerror.Wrapf(err, pgcode.DataCorrupted, "%s: got decoding error", baseMsg), "key: %s", key,
+		)
+	}
+	return errors.WithDetailf(
+		pgerror.Wrapf(cause, pgcode.DataCorrupted,
+			"%s (%s)=(%s) in %s@%s",
+			baseMsg,
+			strings.Join(colNames, ","),
+			strings.Join(values, ","),
+			spec.TableName,
+			spec.IndexName,
+		), "key: %s", key,
+	)
+}
+
 // decodeKeyValsUsingSpec decodes an index key and returns the key column names
 // and values.
 func decodeKeyValsUsingSpec(
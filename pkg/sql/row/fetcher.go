@@ -37,6 +37,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
@@ -171,6 +172,18 @@ type Fetcher struct {
 	// existing lock in order to perform a non-locking read on a key.
 	lockTimeout time.Duration
 
+	// minTimestamp, if set, restricts the fetcher to only the rows that have
+	// changed after this timestamp.
+	minTimestamp hlc.Timestamp
+	// historyStartTime and historyEndTime, if the latter is set, make the
+	// fetcher return the revisions of the rows that were visible between these
+	// timestamps instead of the rows visible to the transaction (see
+	// historyKVFetcher).
+	historyStartTime, historyEndTime hlc.Timestamp
+	// responseQuota, if set, is the node-wide quota pool that limits the number
+	// of bytes of KV responses in flight.
+	responseQuota *quotapool.IntPool
+
 	// traceKV indicates whether or not session tracing is enabled. It is set
 	// when beginning a new scan.
 	traceKV bool
@@ -236,9 +249,19 @@ type FetcherInitArgs struct {
 	LockStrength   descpb.ScanLockingStrength
 	LockWaitPolicy descpb.ScanLockingWaitPolicy
 	LockTimeout    time.Duration
-	Alloc          *tree.DatumAlloc
-	MemMonitor     *mon.BytesMonitor
-	Spec           *descpb.IndexFetchSpec
+	MinTimestamp   hlc.Timestamp
+	// HistoryStartTime and HistoryEndTime, if the latter is set, make the
+	// fetcher return the history of the rows between these timestamps. The
+	// spec must be for the primary index of a table with a single column
+	// family.
+	HistoryStartTime hlc.Timestamp
+	HistoryEndTime   hlc.Timestamp
+	Alloc            *tree.DatumAlloc
+	MemMonitor       *mon.BytesMonitor
+	// ResponseQuota, if set, is the node-wide quota pool that limits the number
+	// of bytes of KV responses in flight (see MakeAndRegisterKVResponseQuota).
+	ResponseQuota *quotapool.IntPool
+	Spec          *descpb.IndexFetchSpec
 }
 
 // Init sets up a Fetcher for a given table and index.
@@ -250,6 +273,10 @@ func (rf *Fetcher) Init(ctx context.Context, args FetcherInitArgs) error {
 	rf.lockStrength = args.LockStrength
 	rf.lockWaitPolicy = args.LockWaitPolicy
 	rf.lockTimeout = args.LockTimeout
+	rf.minTimestamp = args.MinTimestamp
+	rf.historyStartTime = args.HistoryStartTime
+	rf.historyEndTime = args.HistoryEndTime
+	rf.responseQuota = args.ResponseQuota
 	rf.alloc = args.Alloc
 
 	if args.MemMonitor != nil {
@@ -403,6 +430,11 @@ func (rf *Fetcher) StartScan(
 	if len(spans) == 0 {
 		return errors.AssertionFailedf("no spans")
 	}
+	if !rf.historyEndTime.IsEmpty() {
+		return rf.StartScanFrom(ctx, makeHistoryKVFetcher(
+			txn, spans, rf.historyStartTime, rf.historyEndTime, batchBytesLimit, rf.kvFetcherMemAcc,
+		), traceKV)
+	}
 
 	f, err := makeKVBatchFetcher(
 		ctx,
@@ -416,7 +448,9 @@ func (rf *Fetcher) StartScan(
 			lockStrength:               rf.lockStrength,
 			lockWaitPolicy:             rf.lockWaitPolicy,
 			lockTimeout:                rf.lockTimeout,
+			minTimestamp:               rf.minTimestamp,
 			acc:                        rf.kvFetcherMemAcc,
+			responseQuota:              rf.responseQuota,
 			forceProductionKVBatchSize: forceProductionKVBatchSize,
 			requestAdmissionHeader:     txn.AdmissionHeader(),
 			responseAdmissionQ:         txn.DB().SQLKVResponseAdmissionQ,
@@ -520,7 +554,9 @@ func (rf *Fetcher) StartInconsistentScan(
 			lockStrength:               rf.lockStrength,
 			lockWaitPolicy:             rf.lockWaitPolicy,
 			lockTimeout:                rf.lockTimeout,
+			minTimestamp:               rf.minTimestamp,
 			acc:                        rf.kvFetcherMemAcc,
+			responseQuota:              rf.responseQuota,
 			forceProductionKVBatchSize: forceProductionKVBatchSize,
 			requestAdmissionHeader:     txn.AdmissionHeader(),
 			responseAdmissionQ:         txn.DB().SQLKVResponseAdmissionQ,
@@ -23,8 +23,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/admission"
 	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
 	"github.com/cockroachdb/errors"
 )
 
@@ -143,6 +145,9 @@ type txnKVFetcher struct {
 	// wait while attempting to acquire a lock on a key or while blocking on an
 	// existing lock in order to perform a non-locking read on a key.
 	lockTimeout time.Duration
+	// minTimestamp, if set, restricts the fetcher to only return the keys that
+	// have changed after this timestamp.
+	minTimestamp hlc.Timestamp
 
 	// alreadyFetched indicates whether fetch() has already been executed at
 	// least once.
@@ -162,6 +167,10 @@ type txnKVFetcher struct {
 	spansAccountedFor         int64
 	batchResponseAccountedFor int64
 
+	// responseQuota, if set, is the node-wide quota pool limiting the number of
+	// bytes of KV responses in flight across all fetchers.
+	responseQuota *quotapool.IntPool
+
 	// If set, we will use the production value for kvBatchSize.
 	forceProductionKVBatchSize bool
 
@@ -245,7 +254,9 @@ type kvBatchFetcherArgs struct {
 	lockStrength               descpb.ScanLockingStrength
 	lockWaitPolicy             descpb.ScanLockingWaitPolicy
 	lockTimeout                time.Duration
+	minTimestamp               hlc.Timestamp
 	acc                        *mon.BoundAccount
+	responseQuota              *quotapool.IntPool
 	forceProductionKVBatchSize bool
 	requestAdmissionHeader     roachpb.AdmissionHeader
 	responseAdmissionQ         *admission.WorkQueue
@@ -331,7 +342,9 @@ func makeKVBatchFetcher(ctx context.Context, args kvBatchFetcherArgs) (txnKVFetc
 		lockStrength:               getKeyLockingStrength(args.lockStrength),
 		lockWaitPolicy:             GetWaitPolicy(args.lockWaitPolicy),
 		lockTimeout:                args.lockTimeout,
+		minTimestamp:               args.minTimestamp,
 		acc:                        args.acc,
+		responseQuota:              args.responseQuota,
 		forceProductionKVBatchSize: args.forceProductionKVBatchSize,
 		requestAdmissionHeader:     args.requestAdmissionHeader,
 		responseAdmissionQ:         args.responseAdmissionQ,
@@ -382,7 +395,7 @@ func (f *txnKVFetcher) fetch(ctx context.Context) error {
 	ba.Header.TargetBytes = int64(f.batchBytesLimit)
 	ba.Header.MaxSpanRequestKeys = int64(f.getBatchKeyLimit())
 	ba.AdmissionHeader = f.requestAdmissionHeader
-	ba.Requests = spansToRequests(f.spans.Spans, f.reverse, f.lockStrength)
+	ba.Requests = spansToRequests(f.spans.Spans, f.reverse, f.lockStrength, f.minTimestamp)
 
 	if log.ExpensiveLogEnabled(ctx, 2) {
 		log.VEventf(ctx, 2, "Scan %s", f.spans)
@@ -410,8 +423,24 @@ func (f *txnKVFetcher) fetch(ctx context.Context) error {
 		f.batchResponseAccountedFor = tokenFetchAllocation
 	}
 
+	var responseQuotaAlloc *quotapool.IntAlloc
+	if f.responseQuota != nil && f.batchBytesLimit != 0 {
+		// The unlimited fetches don't take part in the quota since we cannot
+		// know how large their responses will be. The quota is only held while
+		// the response is in flight: once it has been accounted for with acc,
+		// the quota is released. Holding onto it until the response is consumed
+		// could deadlock the fetchers that are consumed in turns (e.g. the two
+		// sides of a join).
+		alloc, err := f.responseQuota.Acquire(ctx, uint64(f.batchBytesLimit))
+		if err != nil {
+			return err
+		}
+		responseQuotaAlloc = alloc
+	}
+
 	br, err := f.sendFn(ctx, ba)
 	if err != nil {
+		releaseResponseQuota(responseQuotaAlloc)
 		return err
 	}
 	if br != nil {
@@ -440,10 +469,12 @@ func (f *txnKVFetcher) fetch(ctx context.Context) error {
 		// reasoning for why we never ratchet this account down past the maximum
 		// fetch size once it's exceeded.
 		if err := f.acc.Resize(ctx, f.batchResponseAccountedFor, returnedBytes); err != nil {
+			releaseResponseQuota(responseQuotaAlloc)
 			return err
 		}
 		f.batchResponseAccountedFor = returnedBytes
 	}
+	releaseResponseQuota(responseQuotaAlloc)
 	// Do admission control after we've accounted for the response bytes.
 	if br != nil && f.responseAdmissionQ != nil {
 		responseAdmission := admission.WorkInfo{
@@ -603,6 +634,14 @@ func (f *txnKVFetcher) nextBatch(ctx context.Context) (resp kvBatchFetcherRespon
 	return f.nextBatch(ctx)
 }
 
+// releaseResponseQuota returns the quota acquired for a batch response (if
+// any) to the node-wide pool.
+func releaseResponseQuota(alloc *quotapool.IntAlloc) {
+	if alloc != nil {
+		alloc.Release()
+	}
+}
+
 // close releases the resources of this txnKVFetcher.
 func (f *txnKVFetcher) close(ctx context.Context) {
 	f.responses = nil
@@ -617,16 +656,22 @@ func (f *txnKVFetcher) close(ctx context.Context) {
 // a span doesn't have the EndKey set, then a Get request is used for it;
 // otherwise, a Scan (or ReverseScan if reverse is true) request is used with
 // BATCH_RESPONSE format.
+//
+// If minTimestamp is set, then all requests only return the keys that have
+// changed after minTimestamp. Since GetRequests don't support that option,
+// single-key spans are turned into Scans (or ReverseScans) over that one key.
 func spansToRequests(
-	spans roachpb.Spans, reverse bool, keyLocking lock.Strength,
+	spans roachpb.Spans, reverse bool, keyLocking lock.Strength, minTimestamp hlc.Timestamp,
 ) []roachpb.RequestUnion {
 	reqs := make([]roachpb.RequestUnion, len(spans))
 	// Detect the number of gets vs scans, so we can batch allocate all of the
 	// requests precisely.
 	nGets := 0
-	for i := range spans {
-		if spans[i].EndKey == nil {
-			nGets++
+	if minTimestamp.IsEmpty() {
+		for i := range spans {
+			if spans[i].EndKey == nil {
+				nGets++
+			}
 		}
 	}
 	gets := make([]struct {
@@ -642,20 +687,25 @@ func spansToRequests(
 			union roachpb.RequestUnion_ReverseScan
 		}, len(spans)-nGets)
 		for i := range spans {
-			if spans[i].EndKey == nil {
-				// A span without an EndKey indicates that the caller is requesting a
-				// single key fetch, which can be served using a GetRequest.
-				gets[curGet].req.Key = spans[i].Key
-				gets[curGet].req.KeyLocking = keyLocking
-				gets[curGet].union.Get = &gets[curGet].req
-				reqs[i].Value = &gets[curGet].union
-				curGet++
-				continue
+			span := spans[i]
+			if span.EndKey == nil {
+				if minTimestamp.IsEmpty() {
+					// A span without an EndKey indicates that the caller is requesting a
+					// single key fetch, which can be served using a GetRequest.
+					gets[curGet].req.Key = span.Key
+					gets[curGet].req.KeyLocking = keyLocking
+					gets[curGet].union.Get = &gets[curGet].req
+					reqs[i].Value = &gets[curGet].union
+					curGet++
+					continue
+				}
+				span.EndKey = span.Key.Next()
 			}
 			curScan := i - curGet
-			scans[curScan].req.SetSpan(spans[i])
+			scans[curScan].req.SetSpan(span)
 			scans[curScan].req.ScanFormat = roachpb.BATCH_RESPONSE
 			scans[curScan].req.KeyLocking = keyLocking
+			scans[curScan].req.MinTimestamp = minTimestamp
 			scans[curScan].union.ReverseScan = &scans[curScan].req
 			reqs[i].Value = &scans[curScan].union
 		}
@@ -665,20 +715,25 @@ func spansToRequests(
 			union roachpb.RequestUnion_Scan
 		}, len(spans)-nGets)
 		for i := range spans {
-			if spans[i].EndKey == nil {
-				// A span without an EndKey indicates that the caller is requesting a
-				// single key fetch, which can be served using a GetRequest.
-				gets[curGet].req.Key = spans[i].Key
-				gets[curGet].req.KeyLocking = keyLocking
-				gets[curGet].union.Get = &gets[curGet].req
-				reqs[i].Value = &gets[curGet].union
-				curGet++
-				continue
+			span := spans[i]
+			if span.EndKey == nil {
+				if minTimestamp.IsEmpty() {
+					// A span without an EndKey indicates that the caller is requesting a
+					// single key fetch, which can be served using a GetRequest.
+					gets[curGet].req.Key = span.Key
+					gets[curGet].req.KeyLocking = keyLocking
+					gets[curGet].union.Get = &gets[curGet].req
+					reqs[i].Value = &gets[curGet].union
+					curGet++
+					continue
+				}
+				span.EndKey = span.Key.Next()
 			}
 			curScan := i - curGet
-			scans[curScan].req.SetSpan(spans[i])
+			scans[curScan].req.SetSpan(span)
 			scans[curScan].req.ScanFormat = roachpb.BATCH_RESPONSE
 			scans[curScan].req.KeyLocking = keyLocking
+			scans[curScan].req.MinTimestamp = minTimestamp
 			scans[curScan].union.Scan = &scans[curScan].req
 			reqs[i].Value = &scans[curScan].union
 		}
@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 )
 
@@ -70,7 +71,7 @@ func NewTxnKVStreamer(
 		log.VEventf(ctx, 2, "Scan %s", spans)
 	}
 	keyLocking := getKeyLockingStrength(lockStrength)
-	reqs := spansToRequests(spans, false /* reverse */, keyLocking)
+	reqs := spansToRequests(spans, false /* reverse */, keyLocking, hlc.Timestamp{} /* minTimestamp */)
 	if err := streamer.Enqueue(ctx, reqs, spanIDs); err != nil {
 		return nil, err
 	}
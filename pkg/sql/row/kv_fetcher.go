@@ -21,8 +21,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
 	"github.com/cockroachdb/errors"
 )
 
@@ -58,6 +60,12 @@ type KVFetcher struct {
 // the slice will not be increased by the fetcher.
 //
 // If spanIDs is non-nil, then it must be of the same length as spans.
+//
+// If minTimestamp is set, then only the keys that have changed after that
+// timestamp are returned.
+//
+// If responseQuota is set, then the fetches limited in their response size
+// acquire their limit from it before being sent.
 func NewKVFetcher(
 	ctx context.Context,
 	txn *kv.Txn,
@@ -70,7 +78,9 @@ func NewKVFetcher(
 	lockStrength descpb.ScanLockingStrength,
 	lockWaitPolicy descpb.ScanLockingWaitPolicy,
 	lockTimeout time.Duration,
+	minTimestamp hlc.Timestamp,
 	acc *mon.BoundAccount,
+	responseQuota *quotapool.IntPool,
 	forceProductionKVBatchSize bool,
 ) (*KVFetcher, error) {
 	var sendFn sendFunc
@@ -111,7 +121,9 @@ func NewKVFetcher(
 			lockStrength:               lockStrength,
 			lockWaitPolicy:             lockWaitPolicy,
 			lockTimeout:                lockTimeout,
+			minTimestamp:               minTimestamp,
 			acc:                        acc,
+			responseQuota:              responseQuota,
 			forceProductionKVBatchSize: forceProductionKVBatchSize,
 			requestAdmissionHeader:     txn.AdmissionHeader(),
 			responseAdmissionQ:         txn.DB().SQLKVResponseAdmissionQ,
@@ -153,6 +165,18 @@ func (f *KVFetcher) ResetBytesRead() int64 {
 	return atomic.SwapInt64(&f.atomics.bytesRead, 0)
 }
 
+// LowerAdmissionPriority makes all subsequent requests issued by this fetcher
+// (as well as the admission of their responses) use the given priority if it
+// is lower than the priority derived from the txn. It is a no-op for the
+// fetchers that use the Streamer API.
+func (f *KVFetcher) LowerAdmissionPriority(pri admissionpb.WorkPriority) {
+	if tf, ok := f.KVBatchFetcher.(*txnKVFetcher); ok {
+		if admissionpb.WorkPriority(tf.requestAdmissionHeader.Priority) > pri {
+			tf.requestAdmissionHeader.Priority = int32(pri)
+		}
+	}
+}
+
 // MVCCDecodingStrategy controls if and how the fetcher should decode MVCC
 // timestamps from returned KV's.
 type MVCCDecodingStrategy int
@@ -0,0 +1,203 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package row
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+)
+
+// historyKVFetcher is a KVBatchFetcher that returns the revisions of the keys
+// in a set of spans that were visible at some point between a start and an end
+// timestamp (inclusive): the revisions written between the two timestamps, and
+// the revision that was visible at the start timestamp. The revisions of each
+// key are returned from the newest to the oldest, and deletion tombstones are
+// omitted.
+//
+// The revisions are read by ExportRequests with the MVCCFilter_All filter,
+// which are evaluated at the end timestamp outside of the transaction, so the
+// writes of the transaction are not visible to the fetcher.
+type historyKVFetcher struct {
+	sender    kv.Sender
+	spans     roachpb.Spans
+	startTime hlc.Timestamp
+	endTime   hlc.Timestamp
+
+	batchBytesLimit        rowinfra.BytesLimit
+	requestAdmissionHeader roachpb.AdmissionHeader
+
+	acc *mon.BoundAccount
+	// batchAccountedFor is the number of bytes registered with acc for the
+	// last batch of KVs.
+	batchAccountedFor int64
+}
+
+var _ KVBatchFetcher = &historyKVFetcher{}
+
+// makeHistoryKVFetcher returns a historyKVFetcher that reads the history of
+// the given spans between startTime and endTime. The end timestamp is capped
+// to the read timestamp of the transaction.
+func makeHistoryKVFetcher(
+	txn *kv.Txn,
+	spans roachpb.Spans,
+	startTime, endTime hlc.Timestamp,
+	batchBytesLimit rowinfra.BytesLimit,
+	acc *mon.BoundAccount,
+) *historyKVFetcher {
+	if readTS := txn.ReadTimestamp(); readTS.Less(endTime) {
+		endTime = readTS
+	}
+	if batchBytesLimit == rowinfra.NoBytesLimit {
+		// The ExportRequests would return the whole history of the spans at
+		// once otherwise.
+		batchBytesLimit = rowinfra.GetDefaultBatchBytesLimit(false /* forceProductionValue */)
+	}
+	f := &historyKVFetcher{
+		sender:                 txn.DB().NonTransactionalSender(),
+		startTime:              startTime,
+		endTime:                endTime,
+		batchBytesLimit:        batchBytesLimit,
+		requestAdmissionHeader: txn.AdmissionHeader(),
+		acc:                    acc,
+	}
+	if startTime.LessEq(endTime) {
+		f.spans = spans
+	}
+	return f
+}
+
+// NewHistoryKVFetcher returns a KVFetcher that returns the revisions of the
+// keys in the given spans that were visible between startTime and endTime (see
+// historyKVFetcher). If acc is non-nil, the fetcher must be closed.
+func NewHistoryKVFetcher(
+	txn *kv.Txn,
+	spans roachpb.Spans,
+	startTime, endTime hlc.Timestamp,
+	batchBytesLimit rowinfra.BytesLimit,
+	acc *mon.BoundAccount,
+) *KVFetcher {
+	return newKVFetcher(makeHistoryKVFetcher(txn, spans, startTime, endTime, batchBytesLimit, acc))
+}
+
+// nextBatch implements the KVBatchFetcher interface.
+func (f *historyKVFetcher) nextBatch(ctx context.Context) (kvBatchFetcherResponse, error) {
+	for len(f.spans) > 0 {
+		span := f.spans[0]
+		if len(span.EndKey) == 0 {
+			// ExportRequests are range requests, so single-key spans are turned
+			// into spans over that one key.
+			span.EndKey = span.Key.Next()
+		}
+		var ba roachpb.BatchRequest
+		ba.Header.Timestamp = f.endTime
+		ba.Header.TargetBytes = int64(f.batchBytesLimit)
+		ba.AdmissionHeader = f.requestAdmissionHeader
+		ba.Add(&roachpb.ExportRequest{
+			RequestHeader: roachpb.RequestHeaderFromSpan(span),
+			MVCCFilter:    roachpb.MVCCFilter_All,
+			ReturnSST:     true,
+		})
+		br, pErr := f.sender.Send(ctx, ba)
+		if pErr != nil {
+			return kvBatchFetcherResponse{}, pErr.GoError()
+		}
+		resp := br.Responses[0].GetExport()
+		// The start time of the response is the GC threshold of the ranges,
+		// at or below which the revisions may have been garbage collected.
+		if f.startTime.LessEq(resp.StartTime) {
+			return kvBatchFetcherResponse{}, pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"cannot read the history of the rows since %s, which must be after the GC threshold %s",
+				f.startTime, resp.StartTime)
+		}
+		if resp.ResumeSpan != nil {
+			f.spans[0] = *resp.ResumeSpan
+		} else {
+			f.spans = f.spans[1:]
+		}
+
+		var kvs []roachpb.KeyValue
+		for i := range resp.Files {
+			var err error
+			if kvs, err = f.appendRevisions(kvs, resp.Files[i].SST); err != nil {
+				return kvBatchFetcherResponse{}, err
+			}
+		}
+		if len(kvs) == 0 {
+			continue
+		}
+		var memUsage int64
+		for i := range kvs {
+			memUsage += int64(len(kvs[i].Key) + len(kvs[i].Value.RawBytes))
+		}
+		if err := f.acc.Resize(ctx, f.batchAccountedFor, memUsage); err != nil {
+			return kvBatchFetcherResponse{}, err
+		}
+		f.batchAccountedFor = memUsage
+		return kvBatchFetcherResponse{moreKVs: true, kvs: kvs}, nil
+	}
+	return kvBatchFetcherResponse{moreKVs: false}, nil
+}
+
+// appendRevisions appends the revisions in the given SST that were visible
+// between the start and end times to kvs.
+func (f *historyKVFetcher) appendRevisions(
+	kvs []roachpb.KeyValue, sst []byte,
+) ([]roachpb.KeyValue, error) {
+	iter, err := storage.NewMemSSTIterator(sst, false /* verify */)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	for iter.SeekGE(storage.MVCCKey{}); ; {
+		if ok, err := iter.Valid(); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+		key := iter.UnsafeKey()
+		v, err := storage.DecodeMVCCValue(iter.UnsafeValue())
+		if err != nil {
+			return nil, err
+		}
+		if !v.IsTombstone() {
+			kv := roachpb.KeyValue{
+				Key: append(roachpb.Key(nil), key.Key...),
+				Value: roachpb.Value{
+					RawBytes:  append([]byte(nil), v.Value.RawBytes...),
+					Timestamp: key.Timestamp,
+				},
+			}
+			kvs = append(kvs, kv)
+		}
+		if key.Timestamp.LessEq(f.startTime) {
+			// This revision was visible at the start time, so the older ones
+			// were overwritten before it.
+			iter.NextKey()
+		} else {
+			iter.Next()
+		}
+	}
+	return kvs, nil
+}
+
+func (f *historyKVFetcher) close(ctx context.Context) {
+	f.spans = nil
+	f.acc.Shrink(ctx, f.batchAccountedFor)
+	f.batchAccountedFor = 0
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package row
+
+import (
+	"context"
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
+)
+
+var maxKVResponseBytesInFlight = settings.RegisterByteSizeSetting(
+	settings.TenantWritable,
+	"sql.distsql.max_kv_response_bytes_in_flight",
+	"maximum number of bytes of KV responses that the fetchers on a single "+
+		"node can have requested but not yet received at once; new KV requests "+
+		"are queued once the limit is reached (0 = no limit)",
+	0,
+	settings.NonNegativeInt,
+)
+
+// MakeAndRegisterKVResponseQuota makes the node-wide quota pool that limits
+// the number of bytes of KV responses that are in flight across all of the
+// fetchers and registers it with the setting on-change hook; it should be
+// called only once during server setup due to the side-effects of the
+// on-change registration.
+//
+// Only the fetches that are limited in their response size take part in the
+// quota: the fetcher acquires its batch bytes limit from the pool before
+// sending each BatchRequest and releases it once the response has been
+// received and registered with the memory account of the fetcher.
+func MakeAndRegisterKVResponseQuota(sv *settings.Values) *quotapool.IntPool {
+	getCapacity := func() uint64 {
+		capacity := maxKVResponseBytesInFlight.Get(sv)
+		if capacity == 0 {
+			return math.MaxInt64
+		}
+		return uint64(capacity)
+	}
+	p := quotapool.NewIntPool("kv-response-bytes-in-flight", getCapacity())
+	maxKVResponseBytesInFlight.SetOnChange(sv, func(ctx context.Context) {
+		p.UpdateCapacity(getCapacity())
+	})
+	return p
+}
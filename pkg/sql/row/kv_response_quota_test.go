@@ -0,0 +1,88 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package row
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestKVResponseQuota verifies that the fetchers acquire their batch bytes
+// limit from the node-wide quota pool before sending each BatchRequest, that
+// other fetches queue while the response is in flight, and that the quota is
+// released once the response has been received, so that the fetchers
+// consumed in turns (like the two sides of a join) can interleave even if the
+// capacity only allows for a single batch.
+func TestKVResponseQuota(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	quota := MakeAndRegisterKVResponseQuota(&st.SV)
+	require.Equal(t, uint64(math.MaxInt64), quota.Capacity())
+
+	const limit = 100
+	maxKVResponseBytesInFlight.Override(ctx, &st.SV, limit)
+	require.Equal(t, uint64(limit), quota.Capacity())
+
+	// duringSend, if set, is called by the fetchers while their BatchRequest
+	// is in flight.
+	var duringSend func()
+	makeFetcher := func() txnKVFetcher {
+		f, err := makeKVBatchFetcher(ctx, kvBatchFetcherArgs{
+			sendFn: func(context.Context, roachpb.BatchRequest) (*roachpb.BatchResponse, error) {
+				// The quota must have been acquired before sending the request.
+				require.Zero(t, quota.ApproximateQuota())
+				if duringSend != nil {
+					duringSend()
+				}
+				return &roachpb.BatchResponse{}, nil
+			},
+			spans:           roachpb.Spans{{Key: roachpb.Key("a"), EndKey: roachpb.Key("b")}},
+			batchBytesLimit: rowinfra.BytesLimit(limit),
+			responseQuota:   quota,
+		})
+		require.NoError(t, err)
+		return f
+	}
+
+	f1, f2 := makeFetcher(), makeFetcher()
+	defer f1.close(ctx)
+	defer f2.close(ctx)
+
+	// The second fetch cannot proceed while the first response is in flight.
+	duringSend = func() {
+		duringSend = nil
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		require.Error(t, f2.fetch(timeoutCtx))
+	}
+	require.NoError(t, f1.fetch(ctx))
+	require.Nil(t, duringSend)
+	require.Equal(t, uint64(limit), quota.ApproximateQuota())
+
+	// The fetchers can interleave while the responses are being consumed.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, f1.fetch(ctx))
+		require.NoError(t, f2.fetch(ctx))
+		require.Equal(t, uint64(limit), quota.ApproximateQuota())
+	}
+}
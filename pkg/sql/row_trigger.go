@@ -0,0 +1,304 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// maxTriggerDepth is the maximum number of nested trigger executions. It
+// protects against triggers that fire themselves, directly or not.
+const maxTriggerDepth = 32
+
+// rowTriggerEvents buffers the rows modified by a mutation on a table with
+// row-level triggers that fire for the mutation. The triggers are executed
+// after the mutation and its cascades (see runRowTriggers).
+//
+// Each buffered row contains the values of oldCols (the row before the
+// modification, for UPDATE and DELETE) followed by the values of newCols (the
+// row after the modification, for INSERT and UPDATE).
+type rowTriggerEvents struct {
+	desc  catalog.TableDescriptor
+	event descpb.TriggerDescriptor_Event
+	// triggers are the triggers that fire for the event, sorted by name.
+	triggers []descpb.TriggerDescriptor
+
+	oldCols []catalog.Column
+	newCols []catalog.Column
+	types   []*types.T
+
+	rows    rowContainerHelper
+	scratch tree.Datums
+}
+
+// newRowTriggerEvents returns the rowTriggerEvents for a mutation of the given
+// table, or nil if no trigger fires for the mutation.
+func newRowTriggerEvents(
+	desc catalog.TableDescriptor,
+	event descpb.TriggerDescriptor_Event,
+	oldCols, newCols []catalog.Column,
+) *rowTriggerEvents {
+	var triggers []descpb.TriggerDescriptor
+	for _, trigger := range desc.GetTriggers() {
+		for _, e := range trigger.Events {
+			if e == event {
+				triggers = append(triggers, trigger)
+				break
+			}
+		}
+	}
+	if len(triggers) == 0 {
+		return nil
+	}
+	// Like in Postgres, the triggers fire in alphabetical order.
+	sort.Slice(triggers, func(i, j int) bool {
+		return triggers[i].Name < triggers[j].Name
+	})
+	ev := &rowTriggerEvents{
+		desc:     desc,
+		event:    event,
+		triggers: triggers,
+		oldCols:  oldCols,
+		newCols:  newCols,
+		types:    make([]*types.T, 0, len(oldCols)+len(newCols)),
+		scratch:  make(tree.Datums, len(oldCols)+len(newCols)),
+	}
+	for _, col := range oldCols {
+		ev.types = append(ev.types, col.GetType())
+	}
+	for _, col := range newCols {
+		ev.types = append(ev.types, col.GetType())
+	}
+	return ev
+}
+
+// addRow buffers a modified row. oldValues must correspond to oldCols and
+// newValues to newCols.
+func (ev *rowTriggerEvents) addRow(params runParams, oldValues, newValues tree.Datums) error {
+	if ev.rows.rows == nil {
+		ev.rows.Init(ev.types, params.extendedEvalCtx, "row triggers")
+	}
+	copy(ev.scratch, oldValues[:len(ev.oldCols)])
+	copy(ev.scratch[len(ev.oldCols):], newValues[:len(ev.newCols)])
+	return ev.rows.AddRow(params.ctx, ev.scratch)
+}
+
+// close releases the resources of the buffered rows.
+func (ev *rowTriggerEvents) close(ctx context.Context) {
+	ev.rows.Close(ctx)
+}
+
+// compiledRowTrigger is a row-level trigger ready to be executed for the rows
+// buffered in rowTriggerEvents.
+type compiledRowTrigger struct {
+	name tree.Name
+	// when is the WHEN condition, nil if there is none. NEW and OLD references
+	// are replaced by ordinal references to the buffered rows.
+	when tree.TypedExpr
+	// stmts are the statements of the body. NEW and OLD references are
+	// replaced by placeholders; args[i] contains the ordinals in the buffered
+	// rows of the values of the placeholders of stmts[i].
+	stmts []string
+	args  [][]int
+}
+
+// triggerRowContainer is an IndexedVarContainer over a buffered row.
+type triggerRowContainer struct {
+	row   tree.Datums
+	types []*types.T
+}
+
+var _ eval.IndexedVarContainer = &triggerRowContainer{}
+
+// IndexedVarEval implements the eval.IndexedVarContainer interface.
+func (c *triggerRowContainer) IndexedVarEval(idx int, e tree.ExprEvaluator) (tree.Datum, error) {
+	return c.row[idx], nil
+}
+
+// IndexedVarResolvedType implements the tree.IndexedVarContainer interface.
+func (c *triggerRowContainer) IndexedVarResolvedType(idx int) *types.T {
+	return c.types[idx]
+}
+
+// IndexedVarNodeFormatter implements the tree.IndexedVarContainer interface.
+func (c *triggerRowContainer) IndexedVarNodeFormatter(idx int) tree.NodeFormatter {
+	return nil
+}
+
+// resolveRef returns the ordinal in the buffered rows of the value referred
+// to by a NEW or OLD reference. If the value isn't available for the event (OLD
+// for INSERT, NEW for DELETE), it returns -1 along with the type of the column.
+func (ev *rowTriggerEvents) resolveRef(ref schemaexpr.TriggerRowRef) (int, *types.T, error) {
+	cols, offset := ev.oldCols, 0
+	if ref.New {
+		cols, offset = ev.newCols, len(ev.oldCols)
+	}
+	for i, col := range cols {
+		if col.ColName() == ref.Column {
+			return offset + i, col.GetType(), nil
+		}
+	}
+	col, err := ev.desc.FindColumnWithName(ref.Column)
+	if err != nil {
+		return 0, nil, err
+	}
+	return -1, col.GetType(), nil
+}
+
+// compile prepares the triggers for execution.
+func (ev *rowTriggerEvents) compile(ctx context.Context, p *planner) ([]compiledRowTrigger, error) {
+	res := make([]compiledRowTrigger, len(ev.triggers))
+	container := &triggerRowContainer{types: ev.types}
+	for i := range ev.triggers {
+		trigger := &ev.triggers[i]
+		c := &res[i]
+		c.name = tree.Name(trigger.Name)
+
+		if trigger.WhenExpr != "" {
+			expr, err := parser.ParseExpr(trigger.WhenExpr)
+			if err != nil {
+				return nil, err
+			}
+			expr, err = schemaexpr.ReplaceTriggerRowRefsInExpr(expr,
+				func(ref schemaexpr.TriggerRowRef) (tree.Expr, error) {
+					ord, typ, err := ev.resolveRef(ref)
+					if err != nil {
+						return nil, err
+					}
+					if ord < 0 {
+						return &tree.CastExpr{Expr: tree.DNull, Type: typ, SyntaxMode: tree.CastShort}, nil
+					}
+					return tree.NewTypedOrdinalReference(ord, typ), nil
+				})
+			if err != nil {
+				return nil, err
+			}
+			semaCtx := p.semaCtx
+			semaCtx.IVarContainer = container
+			c.when, err = tree.TypeCheckAndRequire(ctx, expr, &semaCtx, types.Bool, "WHEN")
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		stmts, err := schemaexpr.ParseTriggerBody(trigger.Body)
+		if err != nil {
+			return nil, err
+		}
+		c.stmts = make([]string, len(stmts))
+		c.args = make([][]int, len(stmts))
+		for j, stmt := range stmts {
+			placeholders := make(map[int]tree.PlaceholderIdx)
+			newStmt, err := schemaexpr.ReplaceTriggerRowRefs(stmt.AST,
+				func(ref schemaexpr.TriggerRowRef) (tree.Expr, error) {
+					ord, typ, err := ev.resolveRef(ref)
+					if err != nil {
+						return nil, err
+					}
+					if ord < 0 {
+						return &tree.CastExpr{Expr: tree.DNull, Type: typ, SyntaxMode: tree.CastShort}, nil
+					}
+					idx, ok := placeholders[ord]
+					if !ok {
+						idx = tree.PlaceholderIdx(len(c.args[j]))
+						placeholders[ord] = idx
+						c.args[j] = append(c.args[j], ord)
+					}
+					// The cast determines the type of the placeholder, even if
+					// the value is NULL.
+					return &tree.CastExpr{
+						Expr: &tree.Placeholder{Idx: idx}, Type: typ, SyntaxMode: tree.CastShort,
+					}, nil
+				})
+			if err != nil {
+				return nil, err
+			}
+			c.stmts[j] = tree.Serialize(newStmt)
+		}
+	}
+	return res, nil
+}
+
+// runRowTriggers executes the row-level triggers fired by the rows buffered in
+// the given rowTriggerEvents. For each row, the triggers are executed in turn
+// by the internal executor, in the transaction of the planner and as the
+// current user, so any error aborts the statement that fired them.
+func (p *planner) runRowTriggers(ctx context.Context, ev *rowTriggerEvents) error {
+	if ev.rows.rows == nil || ev.rows.Len() == 0 {
+		return nil
+	}
+	depth := p.SessionData().TriggerDepth
+	if depth >= maxTriggerDepth {
+		return pgerror.Newf(pgcode.TriggeredActionException,
+			"trigger depth limit (%d) reached", maxTriggerDepth)
+	}
+	triggers, err := ev.compile(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	sd := p.SessionData().Clone()
+	sd.TriggerDepth = depth + 1
+	ie := p.ExecCfg().InternalExecutorFactory(ctx, sd)
+	override := sessiondata.InternalExecutorOverride{User: p.User()}
+	container := &triggerRowContainer{types: ev.types}
+
+	it := newRowContainerIterator(ctx, ev.rows, ev.types)
+	defer it.Close()
+	for {
+		row, err := it.Next()
+		if err != nil {
+			return err
+		}
+		if row == nil {
+			return nil
+		}
+		for i := range triggers {
+			trigger := &triggers[i]
+			if trigger.when != nil {
+				container.row = row
+				p.EvalContext().PushIVarContainer(container)
+				d, err := eval.Expr(p.EvalContext(), trigger.when)
+				p.EvalContext().PopIVarContainer()
+				if err != nil {
+					return errors.Wrapf(err, "trigger %s", trigger.name)
+				}
+				if d != tree.DBoolTrue {
+					continue
+				}
+			}
+			log.VEventf(ctx, 2, "executing trigger %s on table %s", trigger.name, ev.desc.GetName())
+			for j, stmt := range trigger.stmts {
+				args := make([]interface{}, len(trigger.args[j]))
+				for k, ord := range trigger.args[j] {
+					args[k] = row[ord]
+				}
+				if _, err := ie.ExecEx(ctx, "trigger", p.Txn(), override, stmt, args...); err != nil {
+					return errors.Wrapf(err, "trigger %s", trigger.name)
+				}
+			}
+		}
+	}
+}
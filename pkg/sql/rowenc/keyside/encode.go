@@ -176,6 +176,10 @@ func Encode(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, error) {
 		return append(b, []byte(*t)...), nil
 	case *tree.DJSON:
 		return nil, unimplemented.NewWithIssue(35706, "unable to encode JSON as a table key")
+	case *tree.DTSQuery:
+		return nil, unimplemented.NewWithIssue(7821, "unable to encode TSQuery as a table key")
+	case *tree.DTSVector:
+		return nil, unimplemented.NewWithIssue(7821, "unable to encode TSVector as a table key")
 	}
 	return nil, errors.Errorf("unable to encode table key: %T", val)
 }
@@ -11,11 +11,14 @@
 package valueside
 
 import (
+	"bytes"
+
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 )
@@ -71,10 +74,14 @@ func decodeArray(a *tree.DatumAlloc, arrayType *types.T, b []byte) (tree.Datum,
 		return nil, b, err
 	}
 	elementType := arrayType.ArrayContents()
-	result := tree.DArray{
-		Array:    make(tree.Datums, header.length),
+	// The elements are allocated via the DatumAlloc, but we have to cap the
+	// slice so that appending to the array doesn't overwrite the datums
+	// allocated later.
+	n := int(header.length)
+	result := a.NewDArray(tree.DArray{
+		Array:    a.NewDatums(n)[:n:n],
 		ParamTyp: elementType,
-	}
+	})
 	if err = result.MaybeSetCustomOid(arrayType); err != nil {
 		return nil, b, err
 	}
@@ -92,7 +99,64 @@ func decodeArray(a *tree.DatumAlloc, arrayType *types.T, b []byte) (tree.Datum,
 			result.Array[i] = val
 		}
 	}
-	return &result, b, nil
+	return result, b, nil
+}
+
+// CanMatchEncodedArrayElements returns whether the elements of the arrays with
+// the given element type can be compared for equality using their value
+// encodings, without decoding the arrays. This is the case when the encoding
+// is canonical, i.e. two elements are equal if and only if their encodings
+// are. Notably, it isn't the case for floats (0 and -0) nor for decimals
+// (trailing zeros).
+func CanMatchEncodedArrayElements(elemTyp *types.T) bool {
+	switch elemTyp.Family() {
+	case types.IntFamily, types.StringFamily, types.BytesFamily:
+		return true
+	}
+	return false
+}
+
+// ArrayElementMatcher determines whether value-encoded arrays contain an
+// element equal to the given one without decoding the arrays.
+type ArrayElementMatcher struct {
+	encodedElem []byte
+}
+
+// MakeArrayElementMatcher returns an ArrayElementMatcher for the given
+// element. The element must be non-NULL, and its type must be supported by
+// CanMatchEncodedArrayElements.
+func MakeArrayElementMatcher(elem tree.Datum) (ArrayElementMatcher, error) {
+	if elem == tree.DNull || !CanMatchEncodedArrayElements(elem.ResolvedType()) {
+		return ArrayElementMatcher{}, errors.AssertionFailedf("unsupported array element %s", elem)
+	}
+	encodedElem, err := encodeArrayElement(nil /* b */, elem)
+	if err != nil {
+		return ArrayElementMatcher{}, err
+	}
+	return ArrayElementMatcher{encodedElem: encodedElem}, nil
+}
+
+// Matches returns whether the array with the given value encoding contains
+// the element of the matcher. NULL elements never match.
+func (m ArrayElementMatcher) Matches(b []byte) (bool, error) {
+	header, b, err := decodeArrayHeader(b)
+	if err != nil {
+		return false, err
+	}
+	for i := uint64(0); i < header.length; i++ {
+		if header.isNull(i) {
+			continue
+		}
+		n, err := encoding.PeekValueLengthWithOffsetsAndType(b, 0 /* dataOffset */, header.elementType)
+		if err != nil {
+			return false, err
+		}
+		if bytes.Equal(b[:n], m.encodedElem) {
+			return true, nil
+		}
+		b = b[n:]
+	}
+	return false, nil
 }
 
 // arrayHeader is a parameter passing struct between
@@ -233,6 +297,8 @@ func DatumTypeToArrayElementEncodingType(t *types.T) (encoding.Type, error) {
 		return encoding.IPAddr, nil
 	case types.JsonFamily:
 		return encoding.JSON, nil
+	case types.TSQueryFamily, types.TSVectorFamily:
+		return encoding.Bytes, nil
 	case types.TupleFamily:
 		return encoding.Tuple, nil
 	default:
@@ -313,6 +379,10 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 			return nil, err
 		}
 		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
+	case *tree.DTSQuery:
+		return encoding.EncodeUntaggedBytesValue(b, tsearch.EncodeTSQuery(nil, t.TSQuery)), nil
+	case *tree.DTSVector:
+		return encoding.EncodeUntaggedBytesValue(b, tsearch.EncodeTSVector(nil, t.TSVector)), nil
 	case *tree.DTuple:
 		return encodeUntaggedTuple(t, b, encoding.NoColumnID, nil)
 	default:
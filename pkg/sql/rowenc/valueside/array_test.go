@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/stretchr/testify/require"
 )
 
 type arrayEncodingTest struct {
@@ -140,6 +141,46 @@ func TestArrayEncoding(t *testing.T) {
 	}
 }
 
+func TestArrayElementMatcher(t *testing.T) {
+	intArray := &tree.DArray{
+		ParamTyp: types.Int,
+		Array:    tree.Datums{tree.NewDInt(1), tree.DNull, tree.NewDInt(300)},
+		HasNulls: true,
+	}
+	stringArray := &tree.DArray{
+		ParamTyp: types.String,
+		Array:    tree.Datums{tree.NewDString("foo"), tree.NewDString("")},
+	}
+	emptyArray := &tree.DArray{ParamTyp: types.Int, Array: tree.Datums{}}
+	for _, tc := range []struct {
+		array    *tree.DArray
+		elem     tree.Datum
+		expected bool
+	}{
+		{array: intArray, elem: tree.NewDInt(1), expected: true},
+		{array: intArray, elem: tree.NewDInt(300), expected: true},
+		{array: intArray, elem: tree.NewDInt(2), expected: false},
+		{array: stringArray, elem: tree.NewDString("foo"), expected: true},
+		{array: stringArray, elem: tree.NewDString(""), expected: true},
+		{array: stringArray, elem: tree.NewDString("fo"), expected: false},
+		{array: emptyArray, elem: tree.NewDInt(1), expected: false},
+	} {
+		enc, err := encodeArray(tc.array, nil /* scratch */)
+		require.NoError(t, err)
+		m, err := MakeArrayElementMatcher(tc.elem)
+		require.NoError(t, err)
+		matches, err := m.Matches(enc)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, matches, "%s = ANY(%s)", tc.elem, tc.array)
+	}
+
+	// The elements without canonical encodings are not supported.
+	_, err := MakeArrayElementMatcher(tree.NewDFloat(0))
+	require.Error(t, err)
+	_, err = MakeArrayElementMatcher(tree.DNull)
+	require.Error(t, err)
+}
+
 func BenchmarkArrayEncoding(b *testing.B) {
 	ary := tree.DArray{ParamTyp: types.Int, Array: tree.Datums{}}
 	for i := 0; i < 10000; i++ {
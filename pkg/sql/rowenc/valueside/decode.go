@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
 )
 
@@ -70,7 +71,7 @@ func DecodeUntaggedDatum(
 		if err != nil {
 			return nil, b, err
 		}
-		d, err := a.NewDCollatedString(string(data), t.Locale())
+		d, err := a.NewDCollatedStringFromBytes(data, t.Locale())
 		return d, b, err
 	case types.BitFamily:
 		b, data, err := encoding.DecodeUntaggedBitArrayValue(buf)
@@ -187,6 +188,26 @@ func DecodeUntaggedDatum(
 			return nil, b, err
 		}
 		return a.NewDJSON(tree.DJSON{JSON: j}), b, nil
+	case types.TSQueryFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		q, err := tsearch.DecodeTSQuery(data)
+		if err != nil {
+			return nil, b, err
+		}
+		return tree.NewDTSQuery(q), b, nil
+	case types.TSVectorFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		v, err := tsearch.DecodeTSVector(data)
+		if err != nil {
+			return nil, b, err
+		}
+		return tree.NewDTSVector(v), b, nil
 	case types.OidFamily:
 		b, data, err := encoding.DecodeUntaggedIntValue(buf)
 		return a.NewDOid(tree.MakeDOid(tree.DInt(data), t)), b, err
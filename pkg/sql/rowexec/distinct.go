@@ -51,6 +51,15 @@ type distinct struct {
 	nullsAreDistinct bool
 	nullCount        uint32
 	errorOnDup       string
+	// groupOrdering, if set, determines which row of each group is output
+	// (see execinfrapb.DistinctSpec.GroupOrdering). In such case, bestRow is
+	// the first row of the current group seen so far, if haveBestRow is true.
+	groupOrdering []execinfrapb.Ordering_Column
+	haveBestRow   bool
+	bestRow       rowenc.EncDatumRow
+	// spareRow is swapped with bestRow whenever bestRow is output so that the
+	// output row remains valid until the next call to Next.
+	spareRow rowenc.EncDatumRow
 }
 
 // sortedDistinct is a specialized distinct that can be used when all of the
@@ -109,6 +118,12 @@ func newDistinct(
 	}
 	d.distinctCols.ordered = spec.OrderedColumns
 	d.distinctCols.nonOrdered = nonOrderedCols
+	if len(spec.GroupOrdering.Columns) > 0 {
+		if len(nonOrderedCols) > 0 || spec.NullsAreDistinct || spec.ErrorOnDup != "" {
+			return nil, errors.AssertionFailedf("unsupported group ordering for distinct processor")
+		}
+		d.groupOrdering = spec.GroupOrdering.Columns
+	}
 
 	var returnProcessor execinfra.RowSourcedProcessor = d
 	if len(nonOrderedCols) == 0 {
@@ -130,6 +145,10 @@ func newDistinct(
 	}
 	d.lastGroupKey = d.OutputHelper.RowAlloc.AllocRow(len(d.types))
 	d.haveLastGroupKey = false
+	if d.groupOrdering != nil {
+		d.bestRow = d.OutputHelper.RowAlloc.AllocRow(len(d.types))
+		d.spareRow = d.OutputHelper.RowAlloc.AllocRow(len(d.types))
+	}
 	// If we set up the arena when d is created, the pointer to the memAcc
 	// will be changed because the sortedDistinct case makes a copy of d.
 	// So we have to set up the account here.
@@ -296,11 +315,94 @@ func (d *distinct) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
 	return nil, d.DrainHelper()
 }
 
+// precedesBestRow returns whether the row precedes bestRow according to the
+// group ordering.
+func (d *distinct) precedesBestRow(row rowenc.EncDatumRow) (bool, error) {
+	for _, col := range d.groupOrdering {
+		cmp, err := row[col.ColIdx].Compare(
+			d.types[col.ColIdx], &d.datumAlloc, d.EvalCtx, &d.bestRow[col.ColIdx],
+		)
+		if err != nil || cmp != 0 {
+			if col.Direction == execinfrapb.Ordering_Column_DESC {
+				cmp = -cmp
+			}
+			return cmp < 0, err
+		}
+	}
+	return false, nil
+}
+
+// emitBestRow outputs bestRow.
+func (d *distinct) emitBestRow() rowenc.EncDatumRow {
+	row := d.bestRow
+	d.bestRow, d.spareRow = d.spareRow, d.bestRow
+	d.haveBestRow = false
+	return d.ProcessRowHelper(row)
+}
+
+// nextWithGroupOrdering is the Next of sortedDistinct when the group ordering
+// is set. The row of a group can only be output once a row of the next group
+// is seen.
+func (d *sortedDistinct) nextWithGroupOrdering() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+	for d.State == execinfra.StateRunning {
+		row, meta := d.input.Next()
+		if meta != nil {
+			if meta.Err != nil {
+				d.MoveToDraining(nil /* err */)
+			}
+			return nil, meta
+		}
+		if row == nil {
+			var outRow rowenc.EncDatumRow
+			if d.haveBestRow {
+				outRow = d.emitBestRow()
+			}
+			d.MoveToDraining(nil /* err */)
+			if outRow != nil {
+				return outRow, nil
+			}
+			break
+		}
+		matched, err := d.matchLastGroupKey(row)
+		if err != nil {
+			d.MoveToDraining(err)
+			break
+		}
+		if matched {
+			precedes, err := d.precedesBestRow(row)
+			if err != nil {
+				d.MoveToDraining(err)
+				break
+			}
+			if precedes {
+				copy(d.bestRow, row)
+			}
+			continue
+		}
+
+		d.haveLastGroupKey = true
+		copy(d.lastGroupKey, row)
+		var outRow rowenc.EncDatumRow
+		if d.haveBestRow {
+			outRow = d.emitBestRow()
+		}
+		d.haveBestRow = true
+		copy(d.bestRow, row)
+		if outRow != nil {
+			return outRow, nil
+		}
+	}
+	return nil, d.DrainHelper()
+}
+
 // Next is part of the RowSource interface.
 //
 // sortedDistinct is simpler than distinct. All it has to do is keep track
 // of the last row it saw, emitting if the new row is different.
 func (d *sortedDistinct) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+	if d.groupOrdering != nil {
+		return d.nextWithGroupOrdering()
+	}
 	for d.State == execinfra.StateRunning {
 		row, meta := d.input.Next()
 		if meta != nil {
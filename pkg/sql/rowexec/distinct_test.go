@@ -263,6 +263,33 @@ func TestDistinct(t *testing.T) {
 			},
 			error: "duplicate rows",
 		},
+
+		// Test the group ordering, where the first row of each group according
+		// to it is output.
+		{
+			spec: execinfrapb.DistinctSpec{
+				OrderedColumns:  []uint32{0},
+				DistinctColumns: []uint32{0},
+				GroupOrdering: execinfrapb.Ordering{Columns: []execinfrapb.Ordering_Column{
+					{ColIdx: 1, Direction: execinfrapb.Ordering_Column_DESC},
+					{ColIdx: 2, Direction: execinfrapb.Ordering_Column_ASC},
+				}},
+			},
+			input: rowenc.EncDatumRows{
+				{v[1], v[2], v[1]},
+				{v[1], v[4], v[3]},
+				{v[1], v[4], v[2]},
+				{v[1], v[3], v[4]},
+				{vNull, v[1], v[5]},
+				{vNull, v[2], v[6]},
+				{v[3], v[5], v[7]},
+			},
+			expected: rowenc.EncDatumRows{
+				{v[1], v[4], v[2]},
+				{vNull, v[2], v[6]},
+				{v[3], v[5], v[7]},
+			},
+		},
 	}
 
 	for _, c := range testCases {
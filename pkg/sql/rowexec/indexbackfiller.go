@@ -14,12 +14,15 @@ import (
 	"context"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/backfill"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
@@ -53,6 +56,19 @@ type indexBackfiller struct {
 	output execinfra.RowReceiver
 
 	filter backfill.MutationFilter
+
+	// builders are the IndexBackfillers of the workers that construct index
+	// entries concurrently, besides the embedded one, along with the copies of
+	// the table descriptor they use.
+	builders []indexEntryBuilder
+}
+
+// indexEntryBuilder constructs the index entries of the ranges assigned to one
+// of the workers of an indexBackfiller. The IndexBackfillers keep per-row
+// state, so every worker needs its own.
+type indexEntryBuilder struct {
+	*backfill.IndexBackfiller
+	desc catalog.TableDescriptor
 }
 
 var _ execinfra.Processor = &indexBackfiller{}
@@ -67,6 +83,15 @@ var backfillerMaxBufferSize = settings.RegisterByteSizeSetting(
 	"schemachanger.backfiller.max_buffer_size", "the maximum size of the BulkAdder buffer handling index backfills", 512<<20,
 )
 
+var backfillerRangeConcurrency = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"schemachanger.backfiller.range_concurrency",
+	"the maximum number of ranges whose index entries are constructed concurrently by each "+
+		"index backfill processor; the processors lower it while admission control queues work",
+	4,
+	settings.PositiveInt,
+).WithPublic()
+
 func newIndexBackfiller(
 	ctx context.Context,
 	flowCtx *execinfra.FlowCtx,
@@ -110,64 +135,190 @@ type indexEntryBatch struct {
 	indexEntries         []rowenc.IndexEntry
 	completedSpan        roachpb.Span
 	memUsedBuildingBatch int64
+	// builder is the IndexBackfiller whose bound account holds the memory used
+	// building the batch.
+	builder *backfill.IndexBackfiller
 }
 
 // constructIndexEntries is responsible for constructing the index entries of
-// all the spans assigned to the processor. It streams batches of constructed
-// index entries over the indexEntriesCh.
+// all the spans assigned to the processor. The spans are split at the range
+// boundaries, and the index entries of up to
+// schemachanger.backfiller.range_concurrency ranges are constructed
+// concurrently. It streams batches of constructed index entries over the
+// indexEntriesCh.
 func (ib *indexBackfiller) constructIndexEntries(
 	ctx context.Context, indexEntriesCh chan indexEntryBatch,
 ) error {
-	var memUsedBuildingBatch int64
-	var err error
-	var entries []rowenc.IndexEntry
-	for i := range ib.spec.Spans {
-		log.VEventf(ctx, 2, "index backfiller starting span %d of %d: %s",
-			i+1, len(ib.spec.Spans), ib.spec.Spans[i])
-		todo := ib.spec.Spans[i]
-		for todo.Key != nil {
-			startKey := todo.Key
-			readAsOf := ib.spec.ReadAsOf
-			if readAsOf.IsEmpty() { // old gateway
-				readAsOf = ib.spec.WriteAsOf
-			}
-			todo.Key, entries, memUsedBuildingBatch, err = ib.buildIndexEntryBatch(ctx, todo,
-				readAsOf)
-			if err != nil {
-				return err
-			}
-
-			// Identify the Span for which we have constructed index entries. This is
-			// used for reporting progress and updating the job details.
-			completedSpan := ib.spec.Spans[i]
-			if todo.Key != nil {
-				completedSpan.Key = startKey
-				completedSpan.EndKey = todo.Key
-			}
+	spans, err := splitSpansByRange(ctx, ib.flowCtx.Cfg.DistSender, ib.spec.Spans)
+	if err != nil {
+		return err
+	}
+	concurrency := int(backfillerRangeConcurrency.Get(&ib.flowCtx.Cfg.Settings.SV))
+	if concurrency > len(spans) {
+		concurrency = len(spans)
+	}
+	knobs := &ib.flowCtx.Cfg.TestingKnobs
+	if knobs.RunBeforeBackfillChunk != nil || knobs.SerializeIndexBackfillCreationAndIngestion != nil {
+		// The testing knobs that intercept the chunks expect them to be built one
+		// at a time.
+		concurrency = 1
+	}
+	builders := []indexEntryBuilder{{IndexBackfiller: &ib.IndexBackfiller, desc: ib.desc}}
+	for len(builders) < concurrency {
+		b, err := ib.makeIndexEntryBuilder(ctx)
+		if err != nil {
+			return err
+		}
+		builders = append(builders, b)
+	}
 
-			log.VEventf(ctx, 2, "index entries built for span %s", completedSpan)
-			indexBatch := indexEntryBatch{completedSpan: completedSpan, indexEntries: entries,
-				memUsedBuildingBatch: memUsedBuildingBatch}
-			// Send index entries to be ingested into storage.
-			select {
-			case indexEntriesCh <- indexBatch:
-			case <-ctx.Done():
-				return ctx.Err()
-			}
+	// The spans are handed out to the workers in order.
+	spanCh := make(chan roachpb.Span, len(spans))
+	for _, sp := range spans {
+		spanCh <- sp
+	}
+	close(spanCh)
 
-			knobs := ib.flowCtx.Cfg.TestingKnobs
-			// Block until the current index entry batch has been ingested. Ingested
-			// does not mean written to storage, unless we force a flush after every
-			// batch.
-			if knobs.SerializeIndexBackfillCreationAndIngestion != nil {
-				<-knobs.SerializeIndexBackfillCreationAndIngestion
-			}
+	throttle := makeBackfillThrottle(len(builders))
+	stopThrottle := make(chan struct{})
+	g := ctxgroup.WithContext(ctx)
+	if q := ib.flowCtx.Cfg.KVAdmissionQ; q != nil && len(builders) > 1 {
+		g.GoCtx(func(ctx context.Context) error {
+			throttle.run(ctx, q, backfillThrottleInterval, stopThrottle)
+			return nil
+		})
+	}
+	g.GoCtx(func(ctx context.Context) error {
+		defer close(stopThrottle)
+		workers := ctxgroup.WithContext(ctx)
+		for i := range builders {
+			worker, b := i, builders[i]
+			workers.GoCtx(func(ctx context.Context) error {
+				for sp := range spanCh {
+					if err := ib.constructSpanIndexEntries(
+						ctx, b, sp, indexEntriesCh, func(ctx context.Context) error {
+							return throttle.wait(ctx, worker)
+						},
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 		}
+		return workers.Wait()
+	})
+	return g.Wait()
+}
+
+// makeIndexEntryBuilder initializes an additional IndexBackfiller, with its
+// own copy of the table descriptor and its own memory monitor.
+func (ib *indexBackfiller) makeIndexEntryBuilder(ctx context.Context) (indexEntryBuilder, error) {
+	b := indexEntryBuilder{
+		IndexBackfiller: &backfill.IndexBackfiller{},
+		desc:            tabledesc.NewBuilder(ib.desc.TableDesc()).BuildImmutableTable(),
+	}
+	mon := execinfra.NewMonitor(ctx, ib.flowCtx.Cfg.BackfillerMonitor, "index-backfill-mon")
+	if err := b.InitForDistributedUse(ctx, ib.flowCtx, b.desc, mon); err != nil {
+		mon.Stop(ctx)
+		return indexEntryBuilder{}, err
+	}
+	ib.builders = append(ib.builders, b)
+	return b, nil
+}
+
+// constructSpanIndexEntries constructs the index entries of the given span,
+// which usually covers a single range. wait is called before every batch to
+// throttle the construction.
+func (ib *indexBackfiller) constructSpanIndexEntries(
+	ctx context.Context,
+	b indexEntryBuilder,
+	sp roachpb.Span,
+	indexEntriesCh chan indexEntryBatch,
+	wait func(context.Context) error,
+) error {
+	log.VEventf(ctx, 2, "index backfiller starting span %s", sp)
+	readAsOf := ib.spec.ReadAsOf
+	if readAsOf.IsEmpty() { // old gateway
+		readAsOf = ib.spec.WriteAsOf
 	}
+	todo := sp
+	for todo.Key != nil {
+		if err := wait(ctx); err != nil {
+			return err
+		}
+		startKey := todo.Key
+		var entries []rowenc.IndexEntry
+		var memUsedBuildingBatch int64
+		var err error
+		todo.Key, entries, memUsedBuildingBatch, err = ib.buildIndexEntryBatch(ctx, b, todo,
+			readAsOf)
+		if err != nil {
+			return err
+		}
+
+		// Identify the Span for which we have constructed index entries. This is
+		// used for reporting progress and updating the job details.
+		completedSpan := sp
+		if todo.Key != nil {
+			completedSpan.Key = startKey
+			completedSpan.EndKey = todo.Key
+		}
 
+		log.VEventf(ctx, 2, "index entries built for span %s", completedSpan)
+		indexBatch := indexEntryBatch{completedSpan: completedSpan, indexEntries: entries,
+			memUsedBuildingBatch: memUsedBuildingBatch, builder: b.IndexBackfiller}
+		// Send index entries to be ingested into storage.
+		select {
+		case indexEntriesCh <- indexBatch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		knobs := ib.flowCtx.Cfg.TestingKnobs
+		// Block until the current index entry batch has been ingested. Ingested
+		// does not mean written to storage, unless we force a flush after every
+		// batch.
+		if knobs.SerializeIndexBackfillCreationAndIngestion != nil {
+			<-knobs.SerializeIndexBackfillCreationAndIngestion
+		}
+	}
 	return nil
 }
 
+// splitSpansByRange splits the given spans at the boundaries of the ranges
+// they overlap, so that the ranges can be backfilled concurrently. The spans
+// are returned unchanged if there is no DistSender to look up the ranges.
+func splitSpansByRange(
+	ctx context.Context, ds *kvcoord.DistSender, spans []roachpb.Span,
+) ([]roachpb.Span, error) {
+	if ds == nil {
+		return spans, nil
+	}
+	ri := kvcoord.MakeRangeIterator(ds)
+	var res []roachpb.Span
+	for _, sp := range spans {
+		rSpan, err := keys.SpanAddr(sp)
+		if err != nil {
+			return nil, err
+		}
+		for ri.Seek(ctx, rSpan.Key, kvcoord.Ascending); ; ri.Next(ctx) {
+			if !ri.Valid() {
+				return nil, ri.Error()
+			}
+			piece, err := rSpan.Intersect(ri.Desc())
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, roachpb.Span{Key: piece.Key.AsRawKey(), EndKey: piece.EndKey.AsRawKey()})
+			if !ri.NeedAnother(rSpan) {
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
 // ingestIndexEntries adds the batches of built index entries to the buffering
 // adder and reports progress back to the coordinator node.
 func (ib *indexBackfiller) ingestIndexEntries(
@@ -265,7 +416,7 @@ func (ib *indexBackfiller) ingestIndexEntries(
 			// free the memory which was accounted when building the index entries of the
 			// current chunk.
 			indexBatch.indexEntries = nil
-			ib.ShrinkBoundAccount(ctx, indexBatch.memUsedBuildingBatch)
+			indexBatch.builder.ShrinkBoundAccount(ctx, indexBatch.memUsedBuildingBatch)
 
 			knobs := &ib.flowCtx.Cfg.TestingKnobs
 			if knobs.BulkAdderFlushesEveryBatch {
@@ -380,6 +531,15 @@ func (ib *indexBackfiller) Run(ctx context.Context) {
 	}
 }
 
+// Close releases the resources of the IndexBackfillers of the processor.
+func (ib *indexBackfiller) Close(ctx context.Context) {
+	for _, b := range ib.builders {
+		b.Close(ctx)
+	}
+	ib.builders = nil
+	ib.IndexBackfiller.Close(ctx)
+}
+
 func (ib *indexBackfiller) wrapDupError(ctx context.Context, orig error) error {
 	if orig == nil {
 		return nil
@@ -410,7 +570,7 @@ func (ib *indexBackfiller) getProgressReportInterval() time.Duration {
 
 // buildIndexEntryBatch constructs the index entries for a single indexBatch.
 func (ib *indexBackfiller) buildIndexEntryBatch(
-	tctx context.Context, sp roachpb.Span, readAsOf hlc.Timestamp,
+	tctx context.Context, b indexEntryBuilder, sp roachpb.Span, readAsOf hlc.Timestamp,
 ) (roachpb.Key, []rowenc.IndexEntry, int64, error) {
 	knobs := &ib.flowCtx.Cfg.TestingKnobs
 	var memUsedBuildingBatch int64
@@ -432,7 +592,7 @@ func (ib *indexBackfiller) buildIndexEntryBatch(
 
 		// TODO(knz): do KV tracing in DistSQL processors.
 		var err error
-		entries, key, memUsedBuildingBatch, err = ib.BuildIndexEntriesChunk(ctx, txn, ib.desc, sp,
+		entries, key, memUsedBuildingBatch, err = b.BuildIndexEntriesChunk(ctx, txn, b.desc, sp,
 			ib.spec.ChunkSize, false /*traceKV*/)
 		return err
 	}); err != nil {
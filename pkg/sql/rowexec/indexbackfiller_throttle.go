@@ -0,0 +1,117 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rowexec
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// backfillThrottleInterval is the interval at which the backfillThrottle of an
+// index backfill processor samples the admission control signal.
+const backfillThrottleInterval = time.Second
+
+// overloadSignal reports whether the resources of the node are exhausted. It
+// is implemented by admission.WorkQueue.
+type overloadSignal interface {
+	Overloaded() bool
+}
+
+// backfillThrottle limits the number of workers of an index backfill processor
+// that construct index entries at the same time. The limit starts at the
+// number of workers. It is halved every time admission control is found to be
+// queueing work on the node, and it's increased by one every time it's not,
+// so that the backfill backs off quickly when the node is overloaded and
+// recovers gradually.
+type backfillThrottle struct {
+	maxLimit int
+
+	mu struct {
+		syncutil.Mutex
+		limit int
+		// changed is closed when the limit changes.
+		changed chan struct{}
+	}
+}
+
+func makeBackfillThrottle(workers int) *backfillThrottle {
+	t := &backfillThrottle{maxLimit: workers}
+	t.mu.limit = workers
+	t.mu.changed = make(chan struct{})
+	return t
+}
+
+// wait blocks until the given worker is allowed to construct index entries,
+// that is until the limit is greater than the ordinal of the worker.
+func (t *backfillThrottle) wait(ctx context.Context, worker int) error {
+	for {
+		t.mu.Lock()
+		limit, changed := t.mu.limit, t.mu.changed
+		t.mu.Unlock()
+		if worker < limit {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// adjust lowers or raises the limit depending on whether admission control is
+// queueing work, and returns the new limit.
+func (t *backfillThrottle) adjust(overloaded bool) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	limit := t.mu.limit
+	if overloaded {
+		limit /= 2
+		if limit < 1 {
+			limit = 1
+		}
+	} else if limit < t.maxLimit {
+		limit++
+	}
+	if limit != t.mu.limit {
+		t.mu.limit = limit
+		close(t.mu.changed)
+		t.mu.changed = make(chan struct{})
+	}
+	return limit
+}
+
+// run samples the given signal at the given interval and adjusts the limit
+// accordingly, until stop is closed or the context is canceled.
+func (t *backfillThrottle) run(
+	ctx context.Context, signal overloadSignal, interval time.Duration, stop <-chan struct{},
+) {
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+	prev := t.maxLimit
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+			if limit := t.adjust(signal.Overloaded()); limit != prev {
+				log.VEventf(ctx, 2, "index backfill range concurrency throttled to %d of %d",
+					limit, t.maxLimit)
+				prev = limit
+			}
+		}
+	}
+}
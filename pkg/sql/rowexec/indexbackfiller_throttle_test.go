@@ -0,0 +1,70 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rowexec
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfillThrottle(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	throttle := makeBackfillThrottle(8)
+
+	// All the workers may run initially.
+	for i := 0; i < 8; i++ {
+		require.NoError(t, throttle.wait(ctx, i))
+	}
+
+	// The limit is halved while admission control queues work, down to one.
+	require.Equal(t, 4, throttle.adjust(true /* overloaded */))
+	require.Equal(t, 2, throttle.adjust(true /* overloaded */))
+	require.Equal(t, 1, throttle.adjust(true /* overloaded */))
+	require.Equal(t, 1, throttle.adjust(true /* overloaded */))
+	require.NoError(t, throttle.wait(ctx, 0))
+
+	// The throttled workers wait until the limit is raised again.
+	waitErr := make(chan error)
+	go func() {
+		waitErr <- throttle.wait(ctx, 2)
+	}()
+	require.Equal(t, 2, throttle.adjust(false /* overloaded */))
+	select {
+	case err := <-waitErr:
+		t.Fatalf("worker 2 unexpectedly unblocked: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	require.Equal(t, 3, throttle.adjust(false /* overloaded */))
+	require.NoError(t, <-waitErr)
+
+	// The limit is raised by one at a time, up to the number of workers.
+	for i := 4; i <= 8; i++ {
+		require.Equal(t, i, throttle.adjust(false /* overloaded */))
+	}
+	require.Equal(t, 8, throttle.adjust(false /* overloaded */))
+
+	// The throttled workers stop waiting once the context is canceled.
+	throttle.adjust(true /* overloaded */)
+	cancelCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		waitErr <- throttle.wait(cancelCtx, 7)
+	}()
+	cancel()
+	require.ErrorIs(t, <-waitErr, context.Canceled)
+}
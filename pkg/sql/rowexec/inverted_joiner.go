@@ -296,6 +296,7 @@ func newInvertedJoiner(
 			LockTimeout:    flowCtx.EvalCtx.SessionData().LockTimeout,
 			Alloc:          &ij.alloc,
 			MemMonitor:     flowCtx.EvalCtx.Mon,
+			ResponseQuota:  flowCtx.Cfg.KVResponseQuota,
 			Spec:           &spec.FetchSpec,
 		},
 	); err != nil {
@@ -392,6 +392,7 @@ func newJoinReader(
 			LockTimeout:    flowCtx.EvalCtx.SessionData().LockTimeout,
 			Alloc:          &jr.alloc,
 			MemMonitor:     flowCtx.EvalCtx.Mon,
+			ResponseQuota:  flowCtx.Cfg.KVResponseQuota,
 			Spec:           &spec.FetchSpec,
 		},
 	); err != nil {
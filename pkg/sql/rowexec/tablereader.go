@@ -12,6 +12,7 @@ package rowexec
 
 import (
 	"context"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -45,6 +46,11 @@ type tableReader struct {
 
 	scanStarted bool
 
+	// sampleRng, if set, is used to keep each row with the probability
+	// sampleProbability (BERNOULLI sampling).
+	sampleRng         *rand.Rand
+	sampleProbability float64
+
 	// See TableReaderSpec.MaxTimestampAgeNanos.
 	maxTimestampAge time.Duration
 
@@ -148,19 +154,40 @@ func newTableReader(
 	if err := fetcher.Init(
 		flowCtx.EvalCtx.Context,
 		row.FetcherInitArgs{
-			Reverse:        spec.Reverse,
-			LockStrength:   spec.LockingStrength,
-			LockWaitPolicy: spec.LockingWaitPolicy,
-			LockTimeout:    flowCtx.EvalCtx.SessionData().LockTimeout,
-			Alloc:          &tr.alloc,
-			MemMonitor:     flowCtx.EvalCtx.Mon,
-			Spec:           &spec.FetchSpec,
+			Reverse:          spec.Reverse,
+			LockStrength:     spec.LockingStrength,
+			LockWaitPolicy:   spec.LockingWaitPolicy,
+			LockTimeout:      flowCtx.EvalCtx.SessionData().LockTimeout,
+			MinTimestamp:     spec.MinTimestamp,
+			HistoryStartTime: spec.HistoryStartTime,
+			HistoryEndTime:   spec.HistoryEndTime,
+			Alloc:            &tr.alloc,
+			MemMonitor:       flowCtx.EvalCtx.Mon,
+			ResponseQuota:    flowCtx.Cfg.KVResponseQuota,
+			Spec:             &spec.FetchSpec,
 		},
 	); err != nil {
 		return nil, err
 	}
 
 	tr.Spans = spec.Spans
+	if len(spec.RemoteSpans) > 0 {
+		// The tableReader doesn't short-circuit the scan of the remote spans,
+		// so they are simply scanned after all other spans. The limit of the
+		// PostProcessSpec still guarantees that no extra rows are emitted.
+		tr.Spans = append(tr.Spans[:len(tr.Spans):len(tr.Spans)], spec.RemoteSpans...)
+	}
+	if sample := spec.Sample; sample != nil {
+		rng := rand.New(rand.NewSource(rand.Int63()))
+		switch sample.Method {
+		case execinfrapb.TableSampleSpec_BERNOULLI:
+			tr.sampleRng, tr.sampleProbability = rng, sample.Probability
+		case execinfrapb.TableSampleSpec_SYSTEM:
+			tr.Spans = execinfra.SampleSpans(rng, tr.Spans, sample.Probability)
+		default:
+			return nil, errors.AssertionFailedf("unexpected table sample method %s", sample.Method)
+		}
+	}
 	if !tr.ignoreMisplannedRanges {
 		// Make a copy of the spans so that we could get the misplanned ranges
 		// info.
@@ -254,6 +281,11 @@ func TestingSetScannedRowProgressFrequency(val int64) func() {
 // Next is part of the RowSource interface.
 func (tr *tableReader) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
 	for tr.State == execinfra.StateRunning {
+		if len(tr.Spans) == 0 {
+			// All spans have been skipped by the SYSTEM sampling.
+			tr.MoveToDraining(nil /* err */)
+			break
+		}
 		if !tr.scanStarted {
 			err := tr.startScan(tr.Ctx)
 			if err != nil {
@@ -281,6 +313,9 @@ func (tr *tableReader) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata
 		// case can avoid tracking of the stall time which gives a noticeable
 		// performance hit.
 		tr.rowsRead++
+		if tr.sampleRng != nil && tr.sampleRng.Float64() >= tr.sampleProbability {
+			continue
+		}
 		if outRow := tr.ProcessRowHelper(row); outRow != nil {
 			return outRow, nil
 		}
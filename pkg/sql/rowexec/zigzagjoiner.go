@@ -462,6 +462,7 @@ func (z *zigzagJoiner) setupInfo(
 			LockTimeout:    flowCtx.EvalCtx.SessionData().LockTimeout,
 			Alloc:          &info.alloc,
 			MemMonitor:     flowCtx.EvalCtx.Mon,
+			ResponseQuota:  flowCtx.Cfg.KVResponseQuota,
 			Spec:           &spec.FetchSpec,
 		},
 	); err != nil {
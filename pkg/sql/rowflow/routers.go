@@ -60,7 +60,7 @@ func makeRouter(
 
 	switch spec.Type {
 	case execinfrapb.OutputRouterSpec_BY_HASH:
-		return makeHashRouter(rb, spec.HashColumns)
+		return makeHashRouter(rb, spec)
 
 	case execinfrapb.OutputRouterSpec_MIRROR:
 		return makeMirrorRouter(rb)
@@ -518,6 +518,14 @@ type hashRouter struct {
 	hashCols []uint32
 	buffer   []byte
 	alloc    tree.DatumAlloc
+
+	// regionCol, if non-negative, is the index of the column containing the
+	// region of each row, and streamsByRegion contains the indices of the
+	// streams in each of the regions. If set, the rows are routed only among
+	// the streams in their region (see execinfrapb.OutputRouterSpec for
+	// details).
+	regionCol       int
+	streamsByRegion map[string][]int
 }
 
 // rangeRouter is a router that assumes the keyColumn'th column of incoming
@@ -592,14 +600,24 @@ func (mr *mirrorRouter) Push(
 
 var crc32Table = crc32.MakeTable(crc32.Castagnoli)
 
-func makeHashRouter(rb routerBase, hashCols []uint32) (router, error) {
+func makeHashRouter(rb routerBase, spec *execinfrapb.OutputRouterSpec) (router, error) {
 	if len(rb.outputs) < 2 {
 		return nil, errors.Errorf("need at least two streams for hash router")
 	}
-	if len(hashCols) == 0 {
+	if len(spec.HashColumns) == 0 {
 		return nil, errors.Errorf("no hash columns for BY_HASH router")
 	}
-	return &hashRouter{hashCols: hashCols, routerBase: rb}, nil
+	hr := &hashRouter{hashCols: spec.HashColumns, routerBase: rb, regionCol: -1}
+	if spec.RegionColumn != nil {
+		if len(spec.StreamRegions) != len(rb.outputs) {
+			return nil, errors.Errorf(
+				"%d stream regions for BY_HASH router with %d streams", len(spec.StreamRegions), len(rb.outputs),
+			)
+		}
+		hr.regionCol = int(*spec.RegionColumn)
+		hr.streamsByRegion = spec.StreamsByRegion()
+	}
+	return hr, nil
 }
 
 // Push is part of the RowReceiver interface.
@@ -664,7 +682,33 @@ func (hr *hashRouter) computeDestination(row rowenc.EncDatumRow) (int, error) {
 	// We use CRC32-C because it makes for a decent hash function and is faster
 	// than most hashing algorithms (on recent x86 platforms where it is hardware
 	// accelerated).
-	return int(crc32.Update(0, crc32Table, hr.buffer) % uint32(len(hr.outputs))), nil
+	streamIdx := int(crc32.Update(0, crc32Table, hr.buffer) % uint32(len(hr.outputs)))
+	if hr.streamsByRegion != nil {
+		return hr.routeByRegion(row, streamIdx)
+	}
+	return streamIdx, nil
+}
+
+// routeByRegion returns the index of the output stream in the region of the
+// row that corresponds to streamIdx computed by hashing. The rows with NULL
+// region as well as the rows from the regions without any streams are sent on
+// streamIdx.
+func (hr *hashRouter) routeByRegion(row rowenc.EncDatumRow, streamIdx int) (int, error) {
+	if hr.regionCol >= len(row) {
+		return -1, errors.Errorf("region column %d, row with only %d columns", hr.regionCol, len(row))
+	}
+	if err := row[hr.regionCol].EnsureDecoded(hr.types[hr.regionCol], &hr.alloc); err != nil {
+		return -1, err
+	}
+	region, ok := row[hr.regionCol].Datum.(*tree.DEnum)
+	if !ok {
+		// The region is NULL.
+		return streamIdx, nil
+	}
+	if regionStreams, ok := hr.streamsByRegion[string(region.PhysicalRep)]; ok {
+		return regionStreams[streamIdx%len(regionStreams)], nil
+	}
+	return streamIdx, nil
 }
 
 func makeRangeRouter(
@@ -445,6 +445,66 @@ func preimageAttack(
 	}
 }
 
+// TestHashRouterRoutesByRegion verifies that the hash router co-locating the
+// hash buckets by region routes the rows only among the streams in their
+// region.
+func TestHashRouterRoutesByRegion(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	regionType := types.MakeEnum(100500, 100501)
+	regionType.TypeMeta.EnumData = &types.EnumMetadata{
+		PhysicalRepresentations: [][]byte{{64}, {128}, {192}},
+		LogicalRepresentations:  []string{"east", "west", "north"},
+		IsMemberReadOnly:        []bool{false, false, false},
+	}
+	regionCol := uint32(1)
+	spec := &execinfrapb.OutputRouterSpec{
+		Type:          execinfrapb.OutputRouterSpec_BY_HASH,
+		HashColumns:   []uint32{0, 1},
+		RegionColumn:  &regionCol,
+		StreamRegions: [][]byte{{64}, {64}, {128}, nil},
+	}
+	typs := []*types.T{types.Int, regionType}
+	r, err := makeHashRouter(routerBase{types: typs, outputs: make([]routerOutput, 4)}, spec)
+	require.NoError(t, err)
+	hr := r.(*hashRouter)
+
+	// expectedStreams contains the streams that the rows from each region can
+	// be routed to. The rows from "north" (without any streams) and the rows
+	// with NULL region can be routed to any stream.
+	expectedStreams := map[string][]int{
+		"east":  {0, 1},
+		"west":  {2},
+		"north": {0, 1, 2, 3},
+		"NULL":  {0, 1, 2, 3},
+	}
+	seen := make(map[string]map[int]struct{})
+	for i := 0; i < 1000; i++ {
+		region := "NULL"
+		var regionDatum tree.Datum = tree.DNull
+		if i%4 != 3 {
+			region = regionType.TypeMeta.EnumData.LogicalRepresentations[i%4]
+			regionDatum, err = tree.MakeDEnumFromLogicalRepresentation(regionType, region)
+			require.NoError(t, err)
+		}
+		row := rowenc.EncDatumRow{
+			rowenc.DatumToEncDatum(types.Int, tree.NewDInt(tree.DInt(i))),
+			rowenc.DatumToEncDatum(regionType, regionDatum),
+		}
+		streamIdx, err := hr.computeDestination(row)
+		require.NoError(t, err)
+		require.Contains(t, expectedStreams[region], streamIdx, "row %d from region %s", i, region)
+		if seen[region] == nil {
+			seen[region] = make(map[int]struct{})
+		}
+		seen[region][streamIdx] = struct{}{}
+	}
+	// The rows should be hashed among all of the expected streams.
+	for region, streams := range expectedStreams {
+		require.Len(t, seen[region], len(streams), "region %s", region)
+	}
+}
+
 // Test that metadata records get forwarded by routers. Depending on the type
 // of the metadata, it might need to be forward to either one or all non-closed
 // streams (regardless of the type of the router).
@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/errors"
 )
 
@@ -100,6 +101,20 @@ type scanNode struct {
 	// order for this optimization to work, the DistSQL planner must create a
 	// local plan.
 	localityOptimized bool
+
+	// changesSince, if set, restricts the scan to only the rows that have
+	// changed after this timestamp.
+	changesSince hlc.Timestamp
+
+	// sampleMethod, if set, restricts the scan to a random sample of the rows
+	// that are returned with the probability sampleProbability.
+	sampleMethod      tree.TableSampleMethod
+	sampleProbability float64
+
+	// systemTimeStart and systemTimeEnd, if the latter is set, make the scan
+	// return the revisions of the rows that were visible between them (see
+	// FOR SYSTEM_TIME BETWEEN).
+	systemTimeStart, systemTimeEnd hlc.Timestamp
 }
 
 // scanColumnsConfig controls the "schema" of a scan node.
@@ -57,6 +57,9 @@ type Scanner struct {
 	in            string
 	pos           int
 	bytesPrealloc []byte
+	// hints are the contents of the hint comments (/*+ ... */) scanned since
+	// the last call to ResetHints.
+	hints []string
 }
 
 // In returns the input string.
@@ -73,6 +76,7 @@ func (s *Scanner) Pos() int {
 func (s *Scanner) Init(str string) {
 	s.in = str
 	s.pos = 0
+	s.hints = nil
 	// Preallocate some buffer space for identifiers etc.
 	s.bytesPrealloc = make([]byte, len(str))
 }
@@ -83,6 +87,17 @@ func (s *Scanner) Cleanup() {
 	s.bytesPrealloc = nil
 }
 
+// Hints returns the contents, without the /*+ and */ delimiters, of the hint
+// comments scanned since the last call to ResetHints.
+func (s *Scanner) Hints() []string {
+	return s.hints
+}
+
+// ResetHints forgets the hint comments scanned so far.
+func (s *Scanner) ResetHints() {
+	s.hints = nil
+}
+
 func (s *Scanner) allocBytes(length int) []byte {
 	if len(s.bytesPrealloc) >= length {
 		res := s.bytesPrealloc[:length:length]
@@ -366,6 +381,10 @@ func (s *Scanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.CONTAINS)
 			return
+		case '@': // @@
+			s.pos++
+			lval.SetID(lexbase.AT_AT)
+			return
 		}
 		return
 
@@ -495,6 +514,9 @@ func (s *Scanner) ScanComment(lval ScanSymType) (present, ok bool) {
 					s.pos++
 					depth--
 					if depth == 0 {
+						if s.in[start+2] == '+' {
+							s.hints = append(s.hints, s.in[start+3:s.pos-2])
+						}
 						return true, true
 					}
 					continue
@@ -228,6 +228,7 @@ func (s *schemaChangePlanNode) startExec(params runParams) error {
 	}
 	scs.state = after
 	scs.jobID = jobID
+	scs.numDDL++
 	return nil
 }
 
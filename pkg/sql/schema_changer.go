@@ -441,6 +441,10 @@ func (sc *SchemaChanger) maybeMakeAddTablePublic(
 			return nil
 		}
 		mut.State = descpb.DescriptorState_PUBLIC
+		if mut.MaterializedView() {
+			// The view was backfilled as of its creation time.
+			mut.MaterializedViewRefreshTime = mut.CreateAsOfTime
+		}
 		return descsCol.WriteDesc(ctx, true /* kvTrace */, mut, txn)
 	})
 }
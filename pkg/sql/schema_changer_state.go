@@ -26,4 +26,7 @@ type SchemaChangerState struct {
 	// the bare minimum of statement information we need for testing, but in the
 	// future we may want sql.Statement or something.
 	stmts []string
+	// numDDL is the number of DDL statements whose statement phase was run by
+	// the declarative schema changer.
+	numDDL int
 }
@@ -109,6 +109,9 @@ func alterTableAddColumn(
 	}
 
 	spec.colType.TypeT = b.ResolveTypeRef(d.Type)
+	if schemaexpr.MakeJSONComputedColumnIndexDef(d, spec.colType.Type, b.SessionData()) != nil {
+		panic(scerrors.NotImplementedErrorf(d, "automatically creates an index"))
+	}
 	if spec.colType.TypeT.Type.UserDefined() {
 		typeID, err := typedesc.UserDefinedTypeOIDToID(spec.colType.TypeT.Type.Oid())
 		if err != nil {
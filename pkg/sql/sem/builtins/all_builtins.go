@@ -39,6 +39,7 @@ func init() {
 	initGeneratorBuiltins()
 	initGeoBuiltins()
 	initTrigramBuiltins()
+	initTSearchBuiltins()
 	initPGBuiltins()
 	initMathBuiltins()
 	initOverlapsBuiltins()
@@ -0,0 +1,232 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+)
+
+func initTSearchBuiltins() {
+	for k, v := range tsearchBuiltins {
+		if _, exists := builtins[k]; exists {
+			panic("duplicate builtin: " + k)
+		}
+		v.props.Category = categoryFullTextSearch
+		builtins[k] = v
+	}
+}
+
+// tsConfigOverload returns the overloads of a text search function that takes
+// an optional text search configuration followed by a string. Only the
+// "simple" configuration is supported.
+func tsConfigOverload(
+	argName string,
+	retType *types.T,
+	fn func(config string, s string) (tree.Datum, error),
+	info string,
+) []tree.Overload {
+	return []tree.Overload{
+		{
+			Types:      tree.ArgTypes{{argName, types.String}},
+			ReturnType: tree.FixedReturnType(retType),
+			Fn: func(evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(tsearch.DefaultConfig, string(tree.MustBeDString(args[0])))
+			},
+			Info:       info + " It uses the simple text search configuration.",
+			Volatility: volatility.Immutable,
+		},
+		{
+			Types:      tree.ArgTypes{{"config", types.String}, {argName, types.String}},
+			ReturnType: tree.FixedReturnType(retType),
+			Fn: func(evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(string(tree.MustBeDString(args[0])), string(tree.MustBeDString(args[1])))
+			},
+			Info:       info + " Only the simple text search configuration is supported.",
+			Volatility: volatility.Immutable,
+		},
+	}
+}
+
+// tsRankOverload returns an overload of ts_rank. The weights and the
+// normalization arguments are optional.
+func tsRankOverload(withWeights bool, withNormalization bool) tree.Overload {
+	var argTypes tree.ArgTypes
+	if withWeights {
+		argTypes = append(argTypes, tree.ArgTypes{{"weights", types.FloatArray}}...)
+	}
+	argTypes = append(argTypes, tree.ArgTypes{{"vector", types.TSVector}, {"query", types.TSQuery}}...)
+	if withNormalization {
+		argTypes = append(argTypes, tree.ArgTypes{{"normalization", types.Int}}...)
+	}
+	return tree.Overload{
+		Types:      argTypes,
+		ReturnType: tree.FixedReturnType(types.Float4),
+		Fn: func(evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+			weights := tsearch.DefaultRankWeights
+			if withWeights {
+				arr := tree.MustBeDArray(args[0])
+				w := make([]float32, 0, arr.Len())
+				for _, d := range arr.Array {
+					if d == tree.DNull {
+						return nil, pgerror.New(pgcode.NullValueNotAllowed, "array of weight must not contain nulls")
+					}
+					w = append(w, float32(tree.MustBeDFloat(d)))
+				}
+				var err error
+				if weights, err = tsearch.ValidateRankWeights(w); err != nil {
+					return nil, err
+				}
+				args = args[1:]
+			}
+			v, q := tree.MustBeDTSVector(args[0]), tree.MustBeDTSQuery(args[1])
+			var normalization int
+			if withNormalization {
+				normalization = int(tree.MustBeDInt(args[2]))
+			}
+			rank := tsearch.Rank(weights, v.TSVector, q.TSQuery, normalization)
+			return tree.NewDFloat(tree.DFloat(rank)), nil
+		},
+		Info: "Ranks the vector by how well it matches the query. The optional weights are the " +
+			"weights of the D, C, B and A positions, and the optional normalization is a bit " +
+			"mask that specifies how the rank is normalized by the length of the document.",
+		Volatility: volatility.Immutable,
+	}
+}
+
+var tsearchBuiltins = map[string]builtinDefinition{
+	"to_tsvector": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tsConfigOverload("document", types.TSVector, func(config string, s string) (tree.Datum, error) {
+			v, err := tsearch.DocumentToTSVector(config, s)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDTSVector(v), nil
+		}, "Converts the document to a tsvector, which contains its normalized words and their positions.")...,
+	),
+	"to_tsquery": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tsConfigOverload("query", types.TSQuery, func(config string, s string) (tree.Datum, error) {
+			q, err := tsearch.ToTSQuery(config, s)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDTSQuery(q), nil
+		}, "Converts the query to a tsquery, normalizing its words. The query must be made of "+
+			"words combined with the & | ! and <-> operators.")...,
+	),
+	"plainto_tsquery": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tsConfigOverload("text", types.TSQuery, func(config string, s string) (tree.Datum, error) {
+			q, err := tsearch.PlainToTSQuery(config, s)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDTSQuery(q), nil
+		}, "Converts the text to a tsquery that matches the documents that contain all its "+
+			"normalized words. Punctuation in the text is ignored.")...,
+	),
+	"phraseto_tsquery": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tsConfigOverload("text", types.TSQuery, func(config string, s string) (tree.Datum, error) {
+			q, err := tsearch.PhraseToTSQuery(config, s)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDTSQuery(q), nil
+		}, "Converts the text to a tsquery that matches the documents that contain its "+
+			"normalized words in the same order. Punctuation in the text is ignored.")...,
+	),
+	"ts_match_vq": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"vector", types.TSVector}, {"query", types.TSQuery}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v, q := tree.MustBeDTSVector(args[0]), tree.MustBeDTSQuery(args[1])
+				return tree.MakeDBool(tree.DBool(tsearch.EvalTSQuery(q.TSQuery, v.TSVector))), nil
+			},
+			Info:       "Returns whether the vector matches the query. It is equivalent to vector @@ query.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_match_qv": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"query", types.TSQuery}, {"vector", types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				q, v := tree.MustBeDTSQuery(args[0]), tree.MustBeDTSVector(args[1])
+				return tree.MakeDBool(tree.DBool(tsearch.EvalTSQuery(q.TSQuery, v.TSVector))), nil
+			},
+			Info:       "Returns whether the vector matches the query. It is equivalent to query @@ vector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"ts_rank": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tsRankOverload(false /* withWeights */, false /* withNormalization */),
+		tsRankOverload(false /* withWeights */, true /* withNormalization */),
+		tsRankOverload(true /* withWeights */, false /* withNormalization */),
+		tsRankOverload(true /* withWeights */, true /* withNormalization */),
+	),
+	"setweight": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"vector", types.TSVector}, {"weight", types.String}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDTSVector(args[0])
+				w := string(tree.MustBeDString(args[1]))
+				if len(w) != 1 {
+					return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unrecognized weight: %q", w)
+				}
+				res, err := v.TSVector.SetWeight(w[0])
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(res), nil
+			},
+			Info:       "Sets the weight of all the positions of the vector, which must be one of A, B, C or D.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"strip": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"vector", types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDTSVector(tree.MustBeDTSVector(args[0]).TSVector.Strip()), nil
+			},
+			Info:       "Removes the positions and weights from the vector.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"numnode": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"query", types.TSQuery}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDInt(tree.DInt(tree.MustBeDTSQuery(args[0]).TSQuery.NumNodes())), nil
+			},
+			Info:       "Returns the number of lexemes and operators in the query.",
+			Volatility: volatility.Immutable,
+		},
+	),
+}
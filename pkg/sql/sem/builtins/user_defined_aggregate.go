@@ -0,0 +1,242 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+	"strings"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// LookupAggregateSupportFunction returns the properties and the overload of
+// the built-in normal function with the given name which accepts arguments of
+// the given types. It is used to find the state and final functions of the
+// user-defined aggregates.
+func LookupAggregateSupportFunction(
+	name string, argTypes []*types.T,
+) (*tree.FunctionProperties, *tree.Overload, error) {
+	props, overloads := GetBuiltinProperties(name)
+	if props != nil && props.Class == tree.NormalClass {
+		for i := range overloads {
+			o := &overloads[i]
+			if o.Fn == nil || !o.Types.Match(argTypes) {
+				continue
+			}
+			if _, ok := o.Types.(tree.HomogeneousType); ok && !sameTypes(argTypes) {
+				// HomogeneousType matches any types, but the function
+				// expects all its arguments to have the same type.
+				continue
+			}
+			return props, o, nil
+		}
+	}
+	typeNames := make([]string, len(argTypes))
+	for i, typ := range argTypes {
+		typeNames[i] = typ.SQLString()
+	}
+	return nil, nil, pgerror.Newf(pgcode.UndefinedFunction,
+		"function %s(%s) does not exist", name, strings.Join(typeNames, ", "))
+}
+
+// sameTypes returns whether all the given types are equivalent.
+func sameTypes(typs []*types.T) bool {
+	for _, typ := range typs {
+		if !typ.Equivalent(typs[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MakeUserDefinedAggregateDefinition returns the definition of the aggregate
+// function with the given name, with one overload for each of the given
+// user-defined aggregates.
+func MakeUserDefinedAggregateDefinition(
+	name string, aggs []*descpb.UserDefinedAggregate,
+) *tree.FunctionDefinition {
+	overloads := make([]tree.Overload, len(aggs))
+	for i := range aggs {
+		agg := aggs[i]
+		overloads[i] = tree.Overload{
+			Types:      tree.ArgTypes{{Name: "arg", Typ: agg.ArgType}},
+			ReturnType: tree.FixedReturnType(agg.ResultType),
+			Volatility: userDefinedAggregateVolatility(agg),
+			AggregateFunc: eval.AggregateOverload(
+				func(_ []*types.T, evalCtx *eval.Context, _ tree.Datums) eval.AggregateFunc {
+					return NewUserDefinedAggregate(agg, evalCtx)
+				},
+			),
+			UserDefinedAggregate: agg,
+			Info:                 "User-defined aggregate.",
+		}
+	}
+	return tree.NewFunctionDefinition(
+		name, &tree.FunctionProperties{Class: tree.AggregateClass, NullableArgs: true}, overloads,
+	)
+}
+
+// userDefinedAggregateVolatility returns the most volatile of the volatilities
+// of the state and the final functions of agg.
+func userDefinedAggregateVolatility(agg *descpb.UserDefinedAggregate) volatility.V {
+	_, stateFn, err := LookupAggregateSupportFunction(
+		agg.StateFunc, []*types.T{agg.StateType, agg.ArgType},
+	)
+	if err != nil {
+		return volatility.Volatile
+	}
+	v := stateFn.Volatility
+	if agg.FinalFunc != "" {
+		_, finalFn, err := LookupAggregateSupportFunction(agg.FinalFunc, []*types.T{agg.StateType})
+		if err != nil {
+			return volatility.Volatile
+		}
+		if finalFn.Volatility > v {
+			v = finalFn.Volatility
+		}
+	}
+	return v
+}
+
+// userDefinedAggregate implements the aggregates defined with CREATE
+// AGGREGATE. The state and the final functions follow the semantics of
+// Postgres for strict functions: a strict state function is not called with
+// NULL inputs, and if the initial condition is NULL, the first non-NULL input
+// becomes the state.
+type userDefinedAggregate struct {
+	singleDatumAggregateBase
+
+	evalCtx *eval.Context
+	agg     *descpb.UserDefinedAggregate
+
+	stateFn     eval.FnOverload
+	stateStrict bool
+	finalFn     eval.FnOverload
+	finalStrict bool
+
+	initState tree.Datum
+	state     tree.Datum
+	// noState is true if the state is to be replaced by the first non-NULL
+	// input.
+	noState bool
+	// err is set if the aggregate could not be initialized.
+	err error
+}
+
+var _ eval.AggregateFunc = &userDefinedAggregate{}
+
+const sizeOfUserDefinedAggregate = int64(unsafe.Sizeof(userDefinedAggregate{}))
+
+// NewUserDefinedAggregate returns the aggregate function for the given
+// user-defined aggregate.
+func NewUserDefinedAggregate(
+	agg *descpb.UserDefinedAggregate, evalCtx *eval.Context,
+) eval.AggregateFunc {
+	a := &userDefinedAggregate{
+		singleDatumAggregateBase: makeSingleDatumAggregateBase(evalCtx),
+		evalCtx:                  evalCtx,
+		agg:                      agg,
+		initState:                tree.DNull,
+	}
+	a.err = a.init()
+	a.state = a.initState
+	a.noState = a.stateStrict && agg.InitCond == nil
+	return a
+}
+
+func (a *userDefinedAggregate) init() error {
+	props, stateFn, err := LookupAggregateSupportFunction(
+		a.agg.StateFunc, []*types.T{a.agg.StateType, a.agg.ArgType},
+	)
+	if err != nil {
+		return err
+	}
+	a.stateFn = stateFn.Fn.(eval.FnOverload)
+	a.stateStrict = !props.NullableArgs
+	if a.agg.FinalFunc != "" {
+		props, finalFn, err := LookupAggregateSupportFunction(
+			a.agg.FinalFunc, []*types.T{a.agg.StateType},
+		)
+		if err != nil {
+			return err
+		}
+		a.finalFn = finalFn.Fn.(eval.FnOverload)
+		a.finalStrict = !props.NullableArgs
+	}
+	if a.agg.InitCond != nil {
+		a.initState, err = eval.PerformCast(a.evalCtx, tree.NewDString(*a.agg.InitCond), a.agg.StateType)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Add implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Add(ctx context.Context, datum tree.Datum, _ ...tree.Datum) error {
+	if a.err != nil {
+		return a.err
+	}
+	if a.stateStrict {
+		if datum == tree.DNull {
+			return nil
+		}
+		if a.noState {
+			a.state = datum
+			a.noState = false
+			return a.updateMemoryUsage(ctx, int64(a.state.Size()))
+		}
+		if a.state == tree.DNull {
+			return nil
+		}
+	}
+	state, err := a.stateFn(a.evalCtx, tree.Datums{a.state, datum})
+	if err != nil {
+		return err
+	}
+	a.state = state
+	return a.updateMemoryUsage(ctx, int64(a.state.Size()))
+}
+
+// Result implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Result() (tree.Datum, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	if a.finalFn == nil || (a.finalStrict && a.state == tree.DNull) {
+		return a.state, nil
+	}
+	return a.finalFn(a.evalCtx, tree.Datums{a.state})
+}
+
+// Reset implements eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Reset(ctx context.Context) {
+	a.state = a.initState
+	a.noState = a.stateStrict && a.agg.InitCond == nil
+	a.reset(ctx)
+}
+
+// Close is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Close(ctx context.Context) {
+	a.close(ctx)
+}
+
+// Size is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Size() int64 {
+	return sizeOfUserDefinedAggregate
+}
@@ -156,6 +156,9 @@ const (
 	CrdbInternalTenantUsageDetailsViewID
 	CrdbInternalPgCatalogTableIsImplementedTableID
 	CrdbInternalSuperRegions
+	CrdbInternalNodeVectorizedFallbacksTableID
+	CrdbInternalIndexRecommendationsTableID
+	CrdbInternalNodePlanBaselinesTableID
 	InformationSchemaID
 	InformationSchemaAdministrableRoleAuthorizationsID
 	InformationSchemaApplicableRolesID
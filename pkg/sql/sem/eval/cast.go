@@ -459,6 +459,10 @@ func performCastWithoutPrecisionTruncation(
 			s = t.String()
 		case *tree.DJSON:
 			s = t.JSON.String()
+		case *tree.DTSQuery:
+			s = t.TSQuery.String()
+		case *tree.DTSVector:
+			s = t.TSVector.String()
 		case *tree.DEnum:
 			s = t.LogicalRep
 		case *tree.DVoid:
@@ -522,6 +526,26 @@ func performCastWithoutPrecisionTruncation(
 			return d, nil
 		}
 
+	case types.TSQueryFamily:
+		switch t := d.(type) {
+		case *tree.DString:
+			return tree.ParseDTSQuery(string(*t))
+		case *tree.DCollatedString:
+			return tree.ParseDTSQuery(t.Contents)
+		case *tree.DTSQuery:
+			return d, nil
+		}
+
+	case types.TSVectorFamily:
+		switch t := d.(type) {
+		case *tree.DString:
+			return tree.ParseDTSVector(string(*t))
+		case *tree.DCollatedString:
+			return tree.ParseDTSVector(t.Contents)
+		case *tree.DTSVector:
+			return d, nil
+		}
+
 	case types.INetFamily:
 		switch t := d.(type) {
 		case *tree.DString:
@@ -65,6 +65,8 @@ type Context struct {
 	TxnState string
 	// TxnReadOnly specifies if the current transaction is read-only.
 	TxnReadOnly bool
+	// TxnIsoLevel is the isolation level of the current transaction.
+	TxnIsoLevel tree.IsolationLevel
 	// TxnImplicit specifies if the current transaction is implicit.
 	TxnImplicit bool
 	// TxnIsSingleStmt specifies the current implicit transaction consists of only
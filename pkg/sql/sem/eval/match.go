@@ -118,6 +118,17 @@ func matchLike(ctx *Context, left, right tree.Datum, caseInsensitive bool) (tree
 	return tree.MakeDBool(tree.DBool(matches)), err
 }
 
+// ConvertRegexp compiles the specified pattern of the ~ (or ~* if
+// caseInsensitive is true) operator as a regular expression.
+func ConvertRegexp(ctx *Context, pattern string, caseInsensitive bool) (*regexp.Regexp, error) {
+	key := regexpKey{s: pattern, caseInsensitive: caseInsensitive}
+	re, err := ctx.ReCache.GetRegexp(key)
+	if err != nil {
+		return nil, pgerror.Wrap(err, pgcode.InvalidRegularExpression, "invalid regular expression")
+	}
+	return re, nil
+}
+
 func matchRegexpWithKey(ctx *Context, str tree.Datum, key tree.RegexpCacheKey) (tree.Datum, error) {
 	re, err := ctx.ReCache.GetRegexp(key)
 	if err != nil {
@@ -56,26 +56,27 @@ type AlterTableCmd interface {
 	alterTableCmd()
 }
 
-func (*AlterTableAddColumn) alterTableCmd()          {}
-func (*AlterTableAddConstraint) alterTableCmd()      {}
-func (*AlterTableAlterColumnType) alterTableCmd()    {}
-func (*AlterTableAlterPrimaryKey) alterTableCmd()    {}
-func (*AlterTableDropColumn) alterTableCmd()         {}
-func (*AlterTableDropConstraint) alterTableCmd()     {}
-func (*AlterTableDropNotNull) alterTableCmd()        {}
-func (*AlterTableDropStored) alterTableCmd()         {}
-func (*AlterTableSetNotNull) alterTableCmd()         {}
-func (*AlterTableRenameColumn) alterTableCmd()       {}
-func (*AlterTableRenameConstraint) alterTableCmd()   {}
-func (*AlterTableSetAudit) alterTableCmd()           {}
-func (*AlterTableSetDefault) alterTableCmd()         {}
-func (*AlterTableSetOnUpdate) alterTableCmd()        {}
-func (*AlterTableSetVisible) alterTableCmd()         {}
-func (*AlterTableValidateConstraint) alterTableCmd() {}
-func (*AlterTablePartitionByTable) alterTableCmd()   {}
-func (*AlterTableInjectStats) alterTableCmd()        {}
-func (*AlterTableSetStorageParams) alterTableCmd()   {}
-func (*AlterTableResetStorageParams) alterTableCmd() {}
+func (*AlterTableAddColumn) alterTableCmd()           {}
+func (*AlterTableAddConstraint) alterTableCmd()       {}
+func (*AlterTableAlterColumnType) alterTableCmd()     {}
+func (*AlterTableAlterPrimaryKey) alterTableCmd()     {}
+func (*AlterTableDropColumn) alterTableCmd()          {}
+func (*AlterTableDropConstraint) alterTableCmd()      {}
+func (*AlterTableDropNotNull) alterTableCmd()         {}
+func (*AlterTableDropStored) alterTableCmd()          {}
+func (*AlterTableSetNotNull) alterTableCmd()          {}
+func (*AlterTableRenameColumn) alterTableCmd()        {}
+func (*AlterTableRenameConstraint) alterTableCmd()    {}
+func (*AlterTableSetAudit) alterTableCmd()            {}
+func (*AlterTableSetRowLevelSecurity) alterTableCmd() {}
+func (*AlterTableSetDefault) alterTableCmd()          {}
+func (*AlterTableSetOnUpdate) alterTableCmd()         {}
+func (*AlterTableSetVisible) alterTableCmd()          {}
+func (*AlterTableValidateConstraint) alterTableCmd()  {}
+func (*AlterTablePartitionByTable) alterTableCmd()    {}
+func (*AlterTableInjectStats) alterTableCmd()         {}
+func (*AlterTableSetStorageParams) alterTableCmd()    {}
+func (*AlterTableResetStorageParams) alterTableCmd()  {}
 
 var _ AlterTableCmd = &AlterTableAddColumn{}
 var _ AlterTableCmd = &AlterTableAddConstraint{}
@@ -88,6 +89,7 @@ var _ AlterTableCmd = &AlterTableSetNotNull{}
 var _ AlterTableCmd = &AlterTableRenameColumn{}
 var _ AlterTableCmd = &AlterTableRenameConstraint{}
 var _ AlterTableCmd = &AlterTableSetAudit{}
+var _ AlterTableCmd = &AlterTableSetRowLevelSecurity{}
 var _ AlterTableCmd = &AlterTableSetDefault{}
 var _ AlterTableCmd = &AlterTableSetOnUpdate{}
 var _ AlterTableCmd = &AlterTableSetVisible{}
@@ -589,6 +591,29 @@ func (node *AlterTableSetAudit) Format(ctx *FmtCtx) {
 	ctx.WriteString(node.Mode.String())
 }
 
+// AlterTableSetRowLevelSecurity represents an ALTER TABLE {ENABLE | DISABLE}
+// ROW LEVEL SECURITY statement.
+type AlterTableSetRowLevelSecurity struct {
+	Enabled bool
+}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableSetRowLevelSecurity) TelemetryName() string {
+	if node.Enabled {
+		return "enable_row_level_security"
+	}
+	return "disable_row_level_security"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableSetRowLevelSecurity) Format(ctx *FmtCtx) {
+	if node.Enabled {
+		ctx.WriteString(" ENABLE ROW LEVEL SECURITY")
+	} else {
+		ctx.WriteString(" DISABLE ROW LEVEL SECURITY")
+	}
+}
+
 // AlterTableInjectStats represents an ALTER TABLE INJECT STATISTICS statement.
 type AlterTableInjectStats struct {
 	Stats Expr
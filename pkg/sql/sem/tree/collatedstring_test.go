@@ -11,6 +11,7 @@
 package tree_test
 
 import (
+	"bytes"
 	"context"
 	"testing"
 
@@ -68,3 +69,53 @@ func TestCastToCollatedString(t *testing.T) {
 		})
 	}
 }
+
+// TestNewDCollatedStringFromBytes verifies that the batch-allocated collated
+// strings are identical to the ones created by NewDCollatedString and that
+// they don't alias the input nor each other.
+func TestNewDCollatedStringFromBytes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	var da tree.DatumAlloc
+	var env tree.CollationEnvironment
+	var results []*tree.DCollatedString
+	contents := []string{"", "a", "test", "Straße", "über", "naïve café"}
+	for _, locale := range []string{"en", "de", "en_US"} {
+		for _, c := range contents {
+			input := []byte(c)
+			d, err := da.NewDCollatedStringFromBytes(input, locale)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Modifying the input must not affect the result.
+			for i := range input {
+				input[i] = 'x'
+			}
+			expected, err := tree.NewDCollatedString(c, locale, &env)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.Contents != expected.Contents || d.Locale != expected.Locale ||
+				!bytes.Equal(d.Key, expected.Key) {
+				t.Fatalf("expected %v, got %v", expected, d)
+			}
+			results = append(results, d)
+		}
+	}
+	// Make sure that the earlier results haven't been clobbered by the
+	// later allocations.
+	var i int
+	for _, locale := range []string{"en", "de", "en_US"} {
+		for _, c := range contents {
+			expected, err := tree.NewDCollatedString(c, locale, &env)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := results[i]; d.Contents != c || !bytes.Equal(d.Key, expected.Key) {
+				t.Fatalf("expected %v, got %v", expected, d)
+			}
+			i++
+		}
+	}
+}
@@ -484,6 +484,8 @@ var (
 		types.Box2D,
 		types.Geography,
 		types.Geometry,
+		types.TSQuery,
+		types.TSVector,
 		types.Time,
 		types.TimeTZ,
 		types.Timestamp,
@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -31,7 +32,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/pretty"
 	"github.com/cockroachdb/errors"
-	"golang.org/x/text/language"
 )
 
 // CreateDatabase represents a CREATE DATABASE statement.
@@ -514,8 +514,7 @@ func NewColumnTableDef(
 			// To most behave like postgres, set the CollatedString type if a non-"default"
 			// collation is used.
 			if locale != DefaultCollationTag {
-				_, err := language.Parse(locale)
-				if err != nil {
+				if err := lex.ValidateLocale(locale); err != nil {
 					return nil, pgerror.Wrapf(err, pgcode.Syntax, "invalid locale %s", locale)
 				}
 				collatedTyp, err := processCollationOnType(name, d.Type, t)
@@ -2010,6 +2009,9 @@ const (
 	// RefreshDataClear refers to the WITH NO DATA option provided to the REFRESH
 	// MATERIALIZED VIEW statement.
 	RefreshDataClear
+	// RefreshDataIncremental refers to the INCREMENTALLY option provided to the
+	// REFRESH MATERIALIZED VIEW statement.
+	RefreshDataIncremental
 )
 
 // Format implements the NodeFormatter interface.
@@ -2024,6 +2026,8 @@ func (node *RefreshMaterializedView) Format(ctx *FmtCtx) {
 		ctx.WriteString(" WITH DATA")
 	case RefreshDataClear:
 		ctx.WriteString(" WITH NO DATA")
+	case RefreshDataIncremental:
+		ctx.WriteString(" INCREMENTALLY")
 	}
 }
 
@@ -2132,3 +2136,248 @@ func (node *CreateExtension) Format(ctx *FmtCtx) {
 	// users attempt to load.
 	ctx.WriteString(node.Name)
 }
+
+// PolicyCommand represents the kind of statements to which a row-level
+// security policy applies.
+type PolicyCommand int
+
+// PolicyCommand values.
+const (
+	PolicyCommandAll PolicyCommand = iota
+	PolicyCommandSelect
+	PolicyCommandInsert
+	PolicyCommandUpdate
+	PolicyCommandDelete
+)
+
+var policyCommandName = [...]string{
+	PolicyCommandAll:    "ALL",
+	PolicyCommandSelect: "SELECT",
+	PolicyCommandInsert: "INSERT",
+	PolicyCommandUpdate: "UPDATE",
+	PolicyCommandDelete: "DELETE",
+}
+
+func (c PolicyCommand) String() string {
+	return policyCommandName[c]
+}
+
+// CreatePolicy represents a CREATE POLICY statement.
+type CreatePolicy struct {
+	Name    Name
+	Table   *UnresolvedObjectName
+	Command PolicyCommand
+	// Roles, if empty, means that the policy applies to the public role.
+	Roles RoleSpecList
+	// Using and WithCheck are nil if the corresponding clauses are omitted.
+	Using     Expr
+	WithCheck Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreatePolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE POLICY ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.Table)
+	if node.Command != PolicyCommandAll {
+		ctx.WriteString(" FOR ")
+		ctx.WriteString(node.Command.String())
+	}
+	if len(node.Roles) > 0 {
+		ctx.WriteString(" TO ")
+		ctx.FormatNode(&node.Roles)
+	}
+	if node.Using != nil {
+		ctx.WriteString(" USING (")
+		ctx.FormatNode(node.Using)
+		ctx.WriteByte(')')
+	}
+	if node.WithCheck != nil {
+		ctx.WriteString(" WITH CHECK (")
+		ctx.FormatNode(node.WithCheck)
+		ctx.WriteByte(')')
+	}
+}
+
+// TriggerActionTime represents when a trigger fires relative to the
+// modification of a row.
+type TriggerActionTime int
+
+// TriggerActionTime values.
+const (
+	TriggerActionTimeBefore TriggerActionTime = iota
+	TriggerActionTimeAfter
+	TriggerActionTimeInsteadOf
+)
+
+var triggerActionTimeName = [...]string{
+	TriggerActionTimeBefore:    "BEFORE",
+	TriggerActionTimeAfter:     "AFTER",
+	TriggerActionTimeInsteadOf: "INSTEAD OF",
+}
+
+func (t TriggerActionTime) String() string {
+	return triggerActionTimeName[t]
+}
+
+// TriggerEvent represents the kind of modification that fires a trigger.
+type TriggerEvent int
+
+// TriggerEvent values.
+const (
+	TriggerEventInsert TriggerEvent = iota
+	TriggerEventUpdate
+	TriggerEventDelete
+	TriggerEventTruncate
+)
+
+var triggerEventName = [...]string{
+	TriggerEventInsert:   "INSERT",
+	TriggerEventUpdate:   "UPDATE",
+	TriggerEventDelete:   "DELETE",
+	TriggerEventTruncate: "TRUNCATE",
+}
+
+func (e TriggerEvent) String() string {
+	return triggerEventName[e]
+}
+
+// TriggerEvents is a list of trigger events.
+type TriggerEvents []TriggerEvent
+
+// Format implements the NodeFormatter interface.
+func (l *TriggerEvents) Format(ctx *FmtCtx) {
+	for i, e := range *l {
+		if i > 0 {
+			ctx.WriteString(" OR ")
+		}
+		ctx.WriteString(e.String())
+	}
+}
+
+// CreateTrigger represents a CREATE TRIGGER statement.
+type CreateTrigger struct {
+	Name       Name
+	ActionTime TriggerActionTime
+	Events     TriggerEvents
+	Table      *UnresolvedObjectName
+	ForEachRow bool
+	// When is nil if the WHEN clause is omitted.
+	When Expr
+	// Body contains the SQL statements executed by the trigger.
+	Body string
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TRIGGER ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte(' ')
+	ctx.WriteString(node.ActionTime.String())
+	ctx.WriteByte(' ')
+	ctx.FormatNode(&node.Events)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.Table)
+	if node.ForEachRow {
+		ctx.WriteString(" FOR EACH ROW")
+	} else {
+		ctx.WriteString(" FOR EACH STATEMENT")
+	}
+	if node.When != nil {
+		ctx.WriteString(" WHEN (")
+		ctx.FormatNode(node.When)
+		ctx.WriteByte(')')
+	}
+	ctx.WriteString(" AS ")
+	if ctx.flags.HasFlags(FmtHideConstants) {
+		ctx.WriteString("'_'")
+	} else {
+		lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, node.Body, ctx.flags.EncodeFlags())
+	}
+}
+
+// AggregateOptionKind identifies a parameter of a CREATE AGGREGATE statement.
+type AggregateOptionKind int
+
+// AggregateOptionKind values.
+const (
+	AggregateOptionStateFunc AggregateOptionKind = iota
+	AggregateOptionStateType
+	AggregateOptionFinalFunc
+	AggregateOptionInitCond
+)
+
+var aggregateOptionKindName = [...]string{
+	AggregateOptionStateFunc: "SFUNC",
+	AggregateOptionStateType: "STYPE",
+	AggregateOptionFinalFunc: "FINALFUNC",
+	AggregateOptionInitCond:  "INITCOND",
+}
+
+func (k AggregateOptionKind) String() string {
+	return aggregateOptionKindName[k]
+}
+
+// AggregateOption represents a parameter of a CREATE AGGREGATE statement,
+// written as <kind> = <value>. Only the field corresponding to the kind of
+// the option is set.
+type AggregateOption struct {
+	Kind AggregateOptionKind
+	// Func is set for SFUNC and FINALFUNC.
+	Func *UnresolvedName
+	// Type is set for STYPE.
+	Type ResolvableTypeReference
+	// Value is set for INITCOND.
+	Value string
+}
+
+// AggregateOptions is a list of aggregate options.
+type AggregateOptions []AggregateOption
+
+// Format implements the NodeFormatter interface.
+func (l *AggregateOptions) Format(ctx *FmtCtx) {
+	for i := range *l {
+		o := &(*l)[i]
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.WriteString(o.Kind.String())
+		ctx.WriteString(" = ")
+		switch o.Kind {
+		case AggregateOptionStateFunc, AggregateOptionFinalFunc:
+			ctx.FormatNode(o.Func)
+		case AggregateOptionStateType:
+			ctx.FormatTypeReference(o.Type)
+		case AggregateOptionInitCond:
+			if ctx.flags.HasFlags(FmtHideConstants) {
+				ctx.WriteString("'_'")
+			} else {
+				lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, o.Value, ctx.flags.EncodeFlags())
+			}
+		}
+	}
+}
+
+// CreateAggregate represents a CREATE AGGREGATE statement.
+type CreateAggregate struct {
+	Name     *UnresolvedObjectName
+	ArgTypes []ResolvableTypeReference
+	Options  AggregateOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateAggregate) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE AGGREGATE ")
+	ctx.FormatNode(node.Name)
+	ctx.WriteByte('(')
+	for i, typ := range node.ArgTypes {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatTypeReference(typ)
+	}
+	ctx.WriteString(") (")
+	ctx.FormatNode(&node.Options)
+	ctx.WriteByte(')')
+}
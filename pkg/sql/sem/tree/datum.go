@@ -43,6 +43,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
@@ -1396,7 +1397,8 @@ func (d *DCollatedString) CompareError(ctx CompareContext, other Datum) (int, er
 		return 1, nil
 	}
 	v, ok := ctx.UnwrapDatum(other).(*DCollatedString)
-	if !ok || !d.ResolvedType().Equivalent(other.ResolvedType()) {
+	// Comparing the locales directly avoids allocating the resolved types.
+	if !ok || !lex.LocaleNamesAreEqual(d.Locale, v.Locale) {
 		return 0, makeUnsupportedComparisonMessage(d, other)
 	}
 	res := bytes.Compare(d.Key, v.Key)
@@ -3555,6 +3557,248 @@ func (d *DBox2D) Size() uintptr {
 	return unsafe.Sizeof(*d) + unsafe.Sizeof(d.CartesianBoundingBox)
 }
 
+// DTSQuery is the Datum representation of the tsquery type, which is a text
+// search query.
+type DTSQuery struct {
+	tsearch.TSQuery
+}
+
+// NewDTSQuery returns a new Query Datum.
+func NewDTSQuery(q tsearch.TSQuery) *DTSQuery {
+	return &DTSQuery{TSQuery: q}
+}
+
+// ParseDTSQuery takes the textual representation of a tsquery and returns a
+// DTSQuery.
+func ParseDTSQuery(str string) (*DTSQuery, error) {
+	q, err := tsearch.ParseTSQuery(str)
+	if err != nil {
+		return nil, err
+	}
+	return NewDTSQuery(q), nil
+}
+
+// AsDTSQuery attempts to retrieve a *DTSQuery from an Expr, returning a
+// *DTSQuery and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DTSQuery wrapped by a *DOidWrapper is possible.
+func AsDTSQuery(e Expr) (*DTSQuery, bool) {
+	switch t := e.(type) {
+	case *DTSQuery:
+		return t, true
+	case *DOidWrapper:
+		return AsDTSQuery(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDTSQuery attempts to retrieve a *DTSQuery from an Expr, panicking
+// if the assertion fails.
+func MustBeDTSQuery(e Expr) *DTSQuery {
+	i, ok := AsDTSQuery(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DTSQuery, found %T", e))
+	}
+	return i
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DTSQuery) ResolvedType() *types.T {
+	return types.TSQuery
+}
+
+// Compare implements the Datum interface.
+func (d *DTSQuery) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DTSQuery) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DTSQuery)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	// tsquerys are compared by their textual representation, which is canonical.
+	return strings.Compare(d.TSQuery.String(), v.TSQuery.String()), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DTSQuery) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DTSQuery) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DTSQuery) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DTSQuery) IsMin(ctx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DTSQuery) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DTSQuery) Min(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DTSQuery) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DTSQuery) Format(ctx *FmtCtx) {
+	s := d.TSQuery.String()
+	if ctx.flags.HasFlags(fmtRawStrings) {
+		ctx.WriteString(s)
+	} else {
+		lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, s, ctx.flags.EncodeFlags())
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DTSQuery) Size() uintptr {
+	return unsafe.Sizeof(*d) + d.TSQuery.MemSize()
+}
+
+// DTSVector is the Datum representation of the tsvector type, which is a
+// document optimized for text search.
+type DTSVector struct {
+	tsearch.TSVector
+}
+
+// NewDTSVector returns a new Vector Datum.
+func NewDTSVector(v tsearch.TSVector) *DTSVector {
+	return &DTSVector{TSVector: v}
+}
+
+// ParseDTSVector takes the textual representation of a tsvector and returns a
+// DTSVector.
+func ParseDTSVector(str string) (*DTSVector, error) {
+	v, err := tsearch.ParseTSVector(str)
+	if err != nil {
+		return nil, err
+	}
+	return NewDTSVector(v), nil
+}
+
+// AsDTSVector attempts to retrieve a *DTSVector from an Expr, returning a
+// *DTSVector and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DTSVector wrapped by a *DOidWrapper is possible.
+func AsDTSVector(e Expr) (*DTSVector, bool) {
+	switch t := e.(type) {
+	case *DTSVector:
+		return t, true
+	case *DOidWrapper:
+		return AsDTSVector(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDTSVector attempts to retrieve a *DTSVector from an Expr, panicking
+// if the assertion fails.
+func MustBeDTSVector(e Expr) *DTSVector {
+	i, ok := AsDTSVector(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DTSVector, found %T", e))
+	}
+	return i
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DTSVector) ResolvedType() *types.T {
+	return types.TSVector
+}
+
+// Compare implements the Datum interface.
+func (d *DTSVector) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DTSVector) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DTSVector)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	// tsvectors are compared by their textual representation, which is canonical.
+	return strings.Compare(d.TSVector.String(), v.TSVector.String()), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DTSVector) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DTSVector) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DTSVector) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DTSVector) IsMin(ctx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DTSVector) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DTSVector) Min(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DTSVector) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DTSVector) Format(ctx *FmtCtx) {
+	s := d.TSVector.String()
+	if ctx.flags.HasFlags(fmtRawStrings) {
+		ctx.WriteString(s)
+	} else {
+		lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, s, ctx.flags.EncodeFlags())
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DTSVector) Size() uintptr {
+	return unsafe.Sizeof(*d) + d.TSVector.MemSize()
+}
+
 // DJSON is the JSON Datum.
 type DJSON struct{ json.JSON }
 
@@ -3676,7 +3920,8 @@ func AsJSON(
 	case *DTimestamp:
 		// This is RFC3339Nano, but without the TZ fields.
 		return json.FromString(t.UTC().Format("2006-01-02T15:04:05.999999999")), nil
-	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray, *DBox2D:
+	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray, *DBox2D,
+		*DTSQuery, *DTSVector:
 		return json.FromString(AsStringWithFlags(t, FmtBareStrings, FmtDataConversionConfig(dcc))), nil
 	case *DGeometry:
 		return json.FromSpatialObject(t.Geometry.SpatialObject(), geo.DefaultGeoJSONDecimalDigits)
@@ -5310,6 +5555,10 @@ func NewDefaultDatum(collationEnv *CollationEnvironment, t *types.T) (d Datum, e
 		return dNullJSON, nil
 	case types.TimeTZFamily:
 		return dZeroTimeTZ, nil
+	case types.TSQueryFamily:
+		return &DTSQuery{}, nil
+	case types.TSVectorFamily:
+		return &DTSVector{}, nil
 	case types.GeometryFamily, types.GeographyFamily, types.Box2DFamily:
 		// TODO(otan): force Geometry/Geography to not allow `NOT NULL` columns to
 		// make this impossible.
@@ -5418,6 +5667,8 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.GeometryFamily:       {unsafe.Sizeof(DGeometry{}), variableSize},
 	types.TimeFamily:           {unsafe.Sizeof(DTime(0)), fixedSize},
 	types.TimeTZFamily:         {unsafe.Sizeof(DTimeTZ{}), fixedSize},
+	types.TSQueryFamily:        {unsafe.Sizeof(DTSQuery{}), variableSize},
+	types.TSVectorFamily:       {unsafe.Sizeof(DTSVector{}), variableSize},
 	types.TimestampFamily:      {unsafe.Sizeof(DTimestamp{}), fixedSize},
 	types.TimestampTZFamily:    {unsafe.Sizeof(DTimestampTZ{}), fixedSize},
 	types.IntervalFamily:       {unsafe.Sizeof(DInterval{}), fixedSize},
@@ -11,8 +11,11 @@
 package tree
 
 import (
+	"unsafe"
+
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"golang.org/x/text/collate"
 )
 
 // DatumAlloc provides batch allocation of datum pointers, amortizing the cost
@@ -46,11 +49,16 @@ type DatumAlloc struct {
 	dipnetAlloc       []DIPAddr
 	djsonAlloc        []DJSON
 	dtupleAlloc       []DTuple
+	darrayAlloc       []DArray
 	doidAlloc         []DOid
 	dvoidAlloc        []DVoid
+	dcollatedAlloc    []DCollatedString
 	// stringAlloc is used by all datum types that are strings (DBytes, DString, DEncodedKey).
 	stringAlloc []string
 	env         CollationEnvironment
+	// collatedBytesAlloc is used for the contents and the collation keys of
+	// the DCollatedStrings created by NewDCollatedStringFromBytes.
+	collatedBytesAlloc []byte
 
 	// Allocations for geopb.SpatialObject.EWKB
 	ewkbAlloc               []byte
@@ -58,10 +66,11 @@ type DatumAlloc struct {
 	lastEWKBBeyondAllocSize bool
 }
 
-const defaultDatumAllocSize = 16  // Arbitrary, could be tuned.
-const datumAllocMultiplier = 4    // Arbitrary, could be tuned.
-const defaultEWKBAllocSize = 4096 // Arbitrary, could be tuned.
-const maxEWKBAllocSize = 16384    // Arbitrary, could be tuned.
+const defaultDatumAllocSize = 16    // Arbitrary, could be tuned.
+const datumAllocMultiplier = 4      // Arbitrary, could be tuned.
+const defaultEWKBAllocSize = 4096   // Arbitrary, could be tuned.
+const maxEWKBAllocSize = 16384      // Arbitrary, could be tuned.
+const collatedBytesAllocSize = 4096 // Arbitrary, could be tuned.
 
 // NewDatums allocates Datums of the specified size.
 func (a *DatumAlloc) NewDatums(num int) Datums {
@@ -136,6 +145,60 @@ func (a *DatumAlloc) NewDCollatedString(contents string, locale string) (*DColla
 	return NewDCollatedString(contents, locale, &a.env)
 }
 
+// NewDCollatedStringFromBytes is like NewDCollatedString, but it takes the
+// contents as a byte slice which is copied. Both the DCollatedString object
+// and the memory for its contents and collation key are batch allocated, so
+// the cost of decoding many collated strings is amortized.
+func (a *DatumAlloc) NewDCollatedStringFromBytes(
+	contents []byte, locale string,
+) (*DCollatedString, error) {
+	entry, err := a.env.getCacheEntry(locale)
+	if err != nil {
+		return nil, err
+	}
+	if a.env.buffer == nil {
+		a.env.buffer = &collate.Buffer{}
+	}
+	key := entry.collator.Key(a.env.buffer, contents)
+	b := a.newCollatedBytes(len(contents) + len(key))
+	copy(b, contents)
+	copy(b[len(contents):], key)
+	a.env.buffer.Reset()
+
+	if a.AllocSize == 0 {
+		a.AllocSize = defaultDatumAllocSize
+	}
+	buf := &a.dcollatedAlloc
+	if len(*buf) == 0 {
+		*buf = make([]DCollatedString, a.AllocSize)
+	}
+	r := &(*buf)[0]
+	*buf = (*buf)[1:]
+	contentsBytes := b[:len(contents):len(contents)]
+	// The memory of b is never modified after this point, so it is safe to
+	// reference it from a string.
+	*r = DCollatedString{
+		Contents: *(*string)(unsafe.Pointer(&contentsBytes)),
+		Locale:   entry.locale,
+		Key:      b[len(contents):len(b):len(b)],
+	}
+	return r, nil
+}
+
+// newCollatedBytes returns a byte slice of length n carved out of
+// collatedBytesAlloc. Large requests are allocated separately.
+func (a *DatumAlloc) newCollatedBytes(n int) []byte {
+	if n > collatedBytesAllocSize/4 {
+		return make([]byte, n)
+	}
+	if len(a.collatedBytesAlloc) < n {
+		a.collatedBytesAlloc = make([]byte, collatedBytesAllocSize)
+	}
+	b := a.collatedBytesAlloc[:n:n]
+	a.collatedBytesAlloc = a.collatedBytesAlloc[n:]
+	return b
+}
+
 // NewDName allocates a DName.
 func (a *DatumAlloc) NewDName(v DString) Datum {
 	return NewDNameFromDString(a.NewDString(v))
@@ -456,6 +519,21 @@ func (a *DatumAlloc) NewDTuple(v DTuple) *DTuple {
 	return r
 }
 
+// NewDArray allocates a DArray.
+func (a *DatumAlloc) NewDArray(v DArray) *DArray {
+	if a.AllocSize == 0 {
+		a.AllocSize = defaultDatumAllocSize
+	}
+	buf := &a.darrayAlloc
+	if len(*buf) == 0 {
+		*buf = make([]DArray, a.AllocSize)
+	}
+	r := &(*buf)[0]
+	*r = v
+	*buf = (*buf)[1:]
+	return r
+}
+
 // NewDOid allocates a DOid.
 func (a *DatumAlloc) NewDOid(v DOid) Datum {
 	if a.AllocSize == 0 {
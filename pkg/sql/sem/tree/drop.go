@@ -217,3 +217,79 @@ func (node *DropSchema) Format(ctx *FmtCtx) {
 		ctx.WriteString(node.DropBehavior.String())
 	}
 }
+
+// DropPolicy represents a DROP POLICY statement.
+type DropPolicy struct {
+	Name     Name
+	Table    *UnresolvedObjectName
+	IfExists bool
+}
+
+var _ Statement = &DropPolicy{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropPolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP POLICY ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.Table)
+}
+
+// DropTrigger represents a DROP TRIGGER statement.
+type DropTrigger struct {
+	Name         Name
+	Table        *UnresolvedObjectName
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropTrigger{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP TRIGGER ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.Table)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// DropAggregate represents a DROP AGGREGATE statement.
+type DropAggregate struct {
+	Name         *UnresolvedObjectName
+	ArgTypes     []ResolvableTypeReference
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropAggregate{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropAggregate) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP AGGREGATE ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(node.Name)
+	ctx.WriteByte('(')
+	for i, typ := range node.ArgTypes {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatTypeReference(typ)
+	}
+	ctx.WriteByte(')')
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
@@ -1442,6 +1442,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]cmpOpOverload{
 		makeEqFn(types.TimeTZ, types.TimeTZ, volatility.LeakProof),
 		makeEqFn(types.Timestamp, types.Timestamp, volatility.LeakProof),
 		makeEqFn(types.TimestampTZ, types.TimestampTZ, volatility.LeakProof),
+		makeEqFn(types.TSQuery, types.TSQuery, volatility.Immutable),
+		makeEqFn(types.TSVector, types.TSVector, volatility.Immutable),
 		makeEqFn(types.Uuid, types.Uuid, volatility.LeakProof),
 		makeEqFn(types.VarBit, types.VarBit, volatility.LeakProof),
 
@@ -1498,6 +1500,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]cmpOpOverload{
 		makeLtFn(types.TimeTZ, types.TimeTZ, volatility.LeakProof),
 		makeLtFn(types.Timestamp, types.Timestamp, volatility.LeakProof),
 		makeLtFn(types.TimestampTZ, types.TimestampTZ, volatility.LeakProof),
+		makeLtFn(types.TSQuery, types.TSQuery, volatility.Immutable),
+		makeLtFn(types.TSVector, types.TSVector, volatility.Immutable),
 		makeLtFn(types.Uuid, types.Uuid, volatility.LeakProof),
 		makeLtFn(types.VarBit, types.VarBit, volatility.LeakProof),
 
@@ -1554,6 +1558,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]cmpOpOverload{
 		makeLeFn(types.TimeTZ, types.TimeTZ, volatility.LeakProof),
 		makeLeFn(types.Timestamp, types.Timestamp, volatility.LeakProof),
 		makeLeFn(types.TimestampTZ, types.TimestampTZ, volatility.LeakProof),
+		makeLeFn(types.TSQuery, types.TSQuery, volatility.Immutable),
+		makeLeFn(types.TSVector, types.TSVector, volatility.Immutable),
 		makeLeFn(types.Uuid, types.Uuid, volatility.LeakProof),
 		makeLeFn(types.VarBit, types.VarBit, volatility.LeakProof),
 
@@ -1631,6 +1637,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]cmpOpOverload{
 		makeIsFn(types.TimeTZ, types.TimeTZ, volatility.LeakProof),
 		makeIsFn(types.Timestamp, types.Timestamp, volatility.LeakProof),
 		makeIsFn(types.TimestampTZ, types.TimestampTZ, volatility.LeakProof),
+		makeIsFn(types.TSQuery, types.TSQuery, volatility.Immutable),
+		makeIsFn(types.TSVector, types.TSVector, volatility.Immutable),
 		makeIsFn(types.Uuid, types.Uuid, volatility.LeakProof),
 		makeIsFn(types.VarBit, types.VarBit, volatility.LeakProof),
 
@@ -1686,6 +1694,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]cmpOpOverload{
 		makeEvalTupleIn(types.TimeTZ, volatility.LeakProof),
 		makeEvalTupleIn(types.Timestamp, volatility.LeakProof),
 		makeEvalTupleIn(types.TimestampTZ, volatility.LeakProof),
+		makeEvalTupleIn(types.TSQuery, volatility.Immutable),
+		makeEvalTupleIn(types.TSVector, volatility.Immutable),
 		makeEvalTupleIn(types.Uuid, volatility.LeakProof),
 		makeEvalTupleIn(types.VarBit, volatility.LeakProof),
 	},
@@ -1819,6 +1829,20 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]cmpOpOverload{
 			},
 		)...,
 	),
+	treecmp.TSMatches: {
+		&CmpOp{
+			LeftType:   types.TSVector,
+			RightType:  types.TSQuery,
+			EvalOp:     &TSMatchesVectorQueryOp{},
+			Volatility: volatility.Immutable,
+		},
+		&CmpOp{
+			LeftType:   types.TSQuery,
+			RightType:  types.TSVector,
+			EvalOp:     &TSMatchesQueryVectorOp{},
+			Volatility: volatility.Immutable,
+		},
+	},
 })
 
 func makeBox2DComparisonOperators(op func(lhs, rhs *geo.CartesianBoundingBox) bool) cmpOpOverload {
@@ -116,6 +116,7 @@ const (
 	ExplainFlagMemo
 	ExplainFlagShape
 	ExplainFlagViz
+	ExplainFlagSample
 	numExplainFlags = iota
 )
 
@@ -128,6 +129,7 @@ var explainFlagStrings = [...]string{
 	ExplainFlagMemo:    "MEMO",
 	ExplainFlagShape:   "SHAPE",
 	ExplainFlagViz:     "VIZ",
+	ExplainFlagSample:  "SAMPLE",
 }
 
 var explainFlagStringMap = func() map[string]ExplainFlag {
@@ -261,6 +263,10 @@ func MakeExplain(options []string, stmt Statement) (Statement, error) {
 		}
 	}
 
+	if opts.Flags[ExplainFlagSample] && (!analyze || opts.Mode != ExplainPlan) {
+		return nil, pgerror.Newf(pgcode.Syntax, "the SAMPLE flag can only be used with EXPLAIN ANALYZE (PLAN)")
+	}
+
 	if analyze {
 		if opts.Mode != ExplainDistSQL && opts.Mode != ExplainDebug && opts.Mode != ExplainPlan {
 			return nil, pgerror.Newf(pgcode.Syntax, "EXPLAIN ANALYZE cannot be used with %s", opts.Mode)
@@ -39,6 +39,8 @@ type SpecializedVectorizedBuiltin int
 // Keep this list alphabetized so that it is easy to manage.
 const (
 	_ SpecializedVectorizedBuiltin = iota
+	JSONExtractPath
+	JSONExtractPathText
 	SubstringStringIntInt
 )
 
@@ -83,6 +85,13 @@ type SQLFnOverload interface {
 	SQLFn()
 }
 
+// UserDefinedAggregateOverload is an opaque type used to box the
+// *descpb.UserDefinedAggregate describing an aggregate function defined with
+// CREATE AGGREGATE.
+type UserDefinedAggregateOverload interface {
+	UserDefinedAggregate()
+}
+
 // Overload is one of the overloads of a built-in function.
 // Each FunctionDefinition may contain one or more overloads.
 type Overload struct {
@@ -136,6 +145,10 @@ type Overload struct {
 	// OnTypeCheck is incremented every time this overload is type checked.
 	OnTypeCheck func()
 
+	// UserDefinedAggregate is set for the overloads of the aggregate functions
+	// defined with CREATE AGGREGATE. AggregateFunc is set as well.
+	UserDefinedAggregate UserDefinedAggregateOverload
+
 	// SpecializedVecBuiltin is used to let the vectorized engine
 	// know when an Overload has a specialized vectorized operator.
 	SpecializedVecBuiltin SpecializedVectorizedBuiltin
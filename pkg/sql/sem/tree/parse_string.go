@@ -64,6 +64,10 @@ func ParseAndRequireString(
 		d, err = ParseDGeometry(s)
 	case types.JsonFamily:
 		d, err = ParseDJSON(s)
+	case types.TSQueryFamily:
+		d, err = ParseDTSQuery(s)
+	case types.TSVectorFamily:
+		d, err = ParseDTSVector(s)
 	case types.OidFamily:
 		if t.Oid() != oid.T_oid && s == ZeroOidValue {
 			d = WrapAsZeroOid(t)
@@ -713,6 +713,12 @@ func (node *AliasedTableExpr) doc(p *PrettyCfg) pretty.Doc {
 			),
 		)
 	}
+	if node.SystemTime != nil {
+		d = pretty.ConcatSpace(d, p.Doc(node.SystemTime))
+	}
+	if node.TableSample != nil {
+		d = pretty.ConcatSpace(d, p.Doc(node.TableSample))
+	}
 	return d
 }
 
@@ -22,6 +22,7 @@ package tree
 import (
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
@@ -312,6 +313,10 @@ type IndexFlags struct {
 	ForceZigzag    bool
 	ZigzagIndexes  []UnrestrictedName
 	ZigzagIndexIDs []IndexID
+	// ChangesSince, if set, is the timestamp (in the same formats as accepted by
+	// AS OF SYSTEM TIME) after which the rows must have been changed in order to
+	// be returned by the scan.
+	ChangesSince string
 }
 
 // ForceIndex returns true if a forced index was specified, either using a name
@@ -384,6 +389,13 @@ func (ih *IndexFlags) CombineWith(other *IndexFlags) error {
 		result.ZigzagIndexIDs = append(result.ZigzagIndexIDs, other.ZigzagIndexIDs...)
 	}
 
+	if other.ChangesSince != "" {
+		if ih.ChangesSince != "" {
+			return errors.New("CHANGES_SINCE specified multiple times")
+		}
+		result.ChangesSince = other.ChangesSince
+	}
+
 	// We only set at the end to avoid a partially changed structure in one of the
 	// error cases above.
 	*ih = result
@@ -392,7 +404,9 @@ func (ih *IndexFlags) CombineWith(other *IndexFlags) error {
 
 // Check verifies if the flags are valid:
 //  - ascending/descending is not specified without an index;
-//  - no_index_join isn't specified with an index.
+//  - no_index_join isn't specified with an index;
+//  - changes_since isn't specified with an index, no_index_join or zigzag
+//    hints.
 func (ih *IndexFlags) Check() error {
 	if ih.NoIndexJoin && ih.ForceIndex() {
 		return errors.New("FORCE_INDEX cannot be specified in conjunction with NO_INDEX_JOIN")
@@ -414,6 +428,9 @@ func (ih *IndexFlags) Check() error {
 			return errors.New("FORCE_ZIGZAG index name cannot be empty string")
 		}
 	}
+	if ih.ChangesSince != "" && (ih.ForceIndex() || ih.NoIndexJoin || ih.zigzagForced()) {
+		return errors.New("CHANGES_SINCE cannot be specified in conjunction with FORCE_INDEX, NO_INDEX_JOIN or FORCE_ZIGZAG")
+	}
 
 	return nil
 }
@@ -422,7 +439,8 @@ func (ih *IndexFlags) Check() error {
 func (ih *IndexFlags) Format(ctx *FmtCtx) {
 	ctx.WriteByte('@')
 	if !ih.NoIndexJoin && !ih.NoZigzagJoin && !ih.NoFullScan && !ih.IgnoreForeignKeys &&
-		!ih.IgnoreUniqueWithoutIndexKeys && ih.Direction == 0 && !ih.zigzagForced() {
+		!ih.IgnoreUniqueWithoutIndexKeys && ih.Direction == 0 && !ih.zigzagForced() &&
+		ih.ChangesSince == "" {
 		if ih.Index != "" {
 			ctx.FormatNode(&ih.Index)
 		} else {
@@ -496,6 +514,16 @@ func (ih *IndexFlags) Format(ctx *FmtCtx) {
 				}
 			}
 		}
+
+		if ih.ChangesSince != "" {
+			sep()
+			ctx.WriteString("CHANGES_SINCE=")
+			if ctx.flags.HasFlags(FmtHideConstants) {
+				ctx.WriteString("'_'")
+			} else {
+				lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, ih.ChangesSince, ctx.flags.EncodeFlags())
+			}
+		}
 		ctx.WriteString("}")
 	}
 }
@@ -507,11 +535,13 @@ func (ih *IndexFlags) zigzagForced() bool {
 // AliasedTableExpr represents a table expression coupled with an optional
 // alias.
 type AliasedTableExpr struct {
-	Expr       TableExpr
-	IndexFlags *IndexFlags
-	Ordinality bool
-	Lateral    bool
-	As         AliasClause
+	Expr        TableExpr
+	IndexFlags  *IndexFlags
+	Ordinality  bool
+	Lateral     bool
+	As          AliasClause
+	SystemTime  *SystemTime
+	TableSample *TableSample
 }
 
 // Format implements the NodeFormatter interface.
@@ -530,6 +560,76 @@ func (node *AliasedTableExpr) Format(ctx *FmtCtx) {
 		ctx.WriteString(" AS ")
 		ctx.FormatNode(&node.As)
 	}
+	if node.SystemTime != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.SystemTime)
+	}
+	if node.TableSample != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.TableSample)
+	}
+}
+
+// TableSampleMethod is the method used to sample the rows of a table by the
+// TABLESAMPLE clause.
+type TableSampleMethod int
+
+const (
+	// NoTableSample indicates that the table isn't sampled.
+	NoTableSample TableSampleMethod = iota
+	// TableSampleBernoulli selects each row independently with the given
+	// probability.
+	TableSampleBernoulli
+	// TableSampleSystem selects blocks of rows with the given probability,
+	// which is cheaper than TableSampleBernoulli but gives a less random
+	// sample.
+	TableSampleSystem
+)
+
+var tableSampleMethodName = [...]string{
+	NoTableSample:        "",
+	TableSampleBernoulli: "BERNOULLI",
+	TableSampleSystem:    "SYSTEM",
+}
+
+func (m TableSampleMethod) String() string {
+	if m < 0 || m > TableSampleMethod(len(tableSampleMethodName)-1) {
+		return fmt.Sprintf("TableSampleMethod(%d)", m)
+	}
+	return tableSampleMethodName[m]
+}
+
+// TableSample represents a TABLESAMPLE clause, which returns a random subset
+// of the rows of a table, where Percent is the sampling percentage between 0
+// and 100.
+type TableSample struct {
+	Method  TableSampleMethod
+	Percent Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TableSample) Format(ctx *FmtCtx) {
+	ctx.WriteString("TABLESAMPLE ")
+	ctx.WriteString(node.Method.String())
+	ctx.WriteString(" (")
+	ctx.FormatNode(node.Percent)
+	ctx.WriteByte(')')
+}
+
+// SystemTime represents a FOR SYSTEM_TIME BETWEEN clause, which returns the
+// revisions of the rows of a table that were visible at some point between
+// the Start and End timestamps.
+type SystemTime struct {
+	Start Expr
+	End   Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *SystemTime) Format(ctx *FmtCtx) {
+	ctx.WriteString("FOR SYSTEM_TIME BETWEEN ")
+	ctx.FormatNode(node.Start)
+	ctx.WriteString(" AND ")
+	ctx.FormatNode(node.End)
 }
 
 // ParenTableExpr represents a parenthesized TableExpr.
@@ -81,6 +81,12 @@ func SampleDatum(t *types.T) Datum {
 		return j
 	case types.OidFamily:
 		return NewDOid(DInt(1009))
+	case types.TSQueryFamily:
+		q, _ := ParseDTSQuery(`'fat' & ( 'rat' | 'cat' )`)
+		return q
+	case types.TSVectorFamily:
+		v, _ := ParseDTSVector(`'cat':3 'fat':2 'rat':4`)
+		return v
 	case types.Box2DFamily:
 		b := geo.NewCartesianBoundingBox().AddPoint(1, 2).AddPoint(3, 4)
 		return NewDBox2D(*b)
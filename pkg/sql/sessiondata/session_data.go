@@ -168,6 +168,14 @@ type LocalUnmigratableSessionData struct {
 	// descpb.ID -> descpb.ID, but cannot be stored as such due to package
 	// dependencies. Temporary tables are not supported in session migrations.
 	DatabaseIDToTempSchemaID map[uint32]uint32
+	// AllowMaterializedViewMutations allows the statements of the session to
+	// modify the data of materialized views. It is only set by the internal
+	// queries that refresh materialized views incrementally.
+	AllowMaterializedViewMutations bool
+	// TriggerDepth is the number of row-level triggers that are being executed
+	// by the statements of the session. It is only set by the internal queries
+	// that execute the triggers, and is used to detect infinite recursion.
+	TriggerDepth int
 
 	///////////////////////////////////////////////////////////////////////////
 	// WARNING: consider whether a session parameter you're adding needs to  //
@@ -19,7 +19,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
 	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
@@ -405,6 +407,45 @@ func stmtTimeoutVarSet(ctx context.Context, m sessionDataMutator, s string) erro
 	return nil
 }
 
+// stmtTimeoutFromHints returns the timeout given in a statement_timeout hint
+// of a statement, like /*+ statement_timeout('500ms') */. ok is false if
+// there is no such hint. The timeout is parsed like the statement_timeout
+// session variable.
+func stmtTimeoutFromHints(
+	style duration.IntervalStyle, hints []parser.StatementHint,
+) (timeout time.Duration, ok bool, _ error) {
+	for _, hint := range hints {
+		switch hint.Name {
+		case "statement_timeout":
+			if len(hint.Args) != 1 {
+				return 0, false, pgerror.Newf(pgcode.Syntax,
+					"statement_timeout hint expects a single argument, got %d", len(hint.Args))
+			}
+			if ok {
+				return 0, false, pgerror.New(pgcode.Syntax, "statement_timeout hint given more than once")
+			}
+			var err error
+			if timeout, err = validateTimeoutVar(style, hint.Args[0], "statement_timeout"); err != nil {
+				return 0, false, err
+			}
+			ok = true
+		}
+	}
+	return timeout, ok, nil
+}
+
+// checkStatementHints returns an error if one of the given statement hints is
+// neither a statement_timeout hint nor an optimizer hint. It is used when
+// reject_unknown_statement_hints is set; otherwise unknown hints are ignored.
+func checkStatementHints(hints []parser.StatementHint) error {
+	for _, hint := range hints {
+		if hint.Name != "statement_timeout" && !optbuilder.IsOptimizerHint(hint.Name) {
+			return pgerror.Newf(pgcode.Syntax, "unknown statement hint %q", hint.Name)
+		}
+	}
+	return nil
+}
+
 func lockTimeoutVarSet(ctx context.Context, m sessionDataMutator, s string) error {
 	timeout, err := validateTimeoutVar(
 		m.data.GetIntervalStyle(),
@@ -49,7 +49,7 @@ func (p *planner) ShowHistogram(ctx context.Context, n *tree.ShowHistogram) (pla
 				"read-histogram",
 				p.txn,
 				sessiondata.InternalExecutorOverride{User: username.RootUserName()},
-				`SELECT histogram
+				`SELECT "tableID", histogram
 				 FROM system.table_statistics
 				 WHERE "statisticID" = $1`,
 				n.HistogramID,
@@ -60,16 +60,27 @@ func (p *planner) ShowHistogram(ctx context.Context, n *tree.ShowHistogram) (pla
 			if row == nil {
 				return nil, fmt.Errorf("histogram %d not found", n.HistogramID)
 			}
-			if len(row) != 1 {
-				return nil, errors.AssertionFailedf("expected 1 column from internal query")
+			if len(row) != 2 {
+				return nil, errors.AssertionFailedf("expected 2 columns from internal query")
 			}
-			if row[0] == tree.DNull {
+			if row[1] == tree.DNull {
 				// We found a statistic, but it has no histogram.
 				return nil, fmt.Errorf("histogram %d not found", n.HistogramID)
 			}
 
+			// The histogram contains values of the rows of the table.
+			tableDesc, err := p.Descriptors().GetImmutableTableByID(
+				ctx, p.txn, descpb.ID(*row[0].(*tree.DInt)), tree.ObjectLookupFlagsWithRequired(),
+			)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.checkRowLevelSecurityExemption(ctx, tableDesc, "SHOW HISTOGRAM"); err != nil {
+				return nil, err
+			}
+
 			histogram := &stats.HistogramData{}
-			histData := *row[0].(*tree.DBytes)
+			histData := *row[1].(*tree.DBytes)
 			if err := protoutil.Unmarshal([]byte(histData), histogram); err != nil {
 				return nil, err
 			}
@@ -66,6 +66,14 @@ func (p *planner) ShowTableStats(ctx context.Context, n *tree.ShowTableStats) (p
 	if err := p.CheckAnyPrivilege(ctx, desc); err != nil {
 		return nil, err
 	}
+	if n.UsingJSON {
+		// The histograms contain values of the rows of the table.
+		if err := p.checkRowLevelSecurityExemption(
+			ctx, desc, "SHOW STATISTICS USING JSON",
+		); err != nil {
+			return nil, err
+		}
+	}
 	avgSizeColVerActive := p.ExtendedEvalContext().ExecCfg.Settings.Version.IsActive(ctx, clusterversion.AlterSystemTableStatisticsAddAvgSizeCol)
 	columns := showTableStatsColumnsAvgSizeVer
 	if !avgSizeColVerActive {
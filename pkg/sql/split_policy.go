@@ -0,0 +1,150 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// handleSplitPolicyStorageParamChange validates the split_policy storage
+// parameter of the table and creates the job that maintains its splits if the
+// policy was just set.
+func handleSplitPolicyStorageParamChange(
+	params runParams, tableDesc *tabledesc.Mutable, before *descpb.SplitPolicy,
+) error {
+	after := tableDesc.SplitPolicy
+	if after == nil {
+		// The job of the previous policy, if any, notices that the policy was
+		// removed and exits.
+		return nil
+	}
+	if !params.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.TableSplitPolicy) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"split policies are not supported until the cluster is upgraded to version %s",
+			clusterversion.ByKey(clusterversion.TableSplitPolicy))
+	}
+	if n := tableDesc.GetPrimaryIndex().NumKeyColumns(); int(after.PrefixColumns) > n {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"split policy prefix(%d) exceeds the %d columns of the primary key of %s",
+			after.PrefixColumns, n, tableDesc.GetName())
+	}
+	if before != nil && before.JobID != jobspb.InvalidJobID {
+		after.JobID = before.JobID
+		return nil
+	}
+	return params.p.createSplitPolicyJob(params.ctx, tableDesc)
+}
+
+// createSplitPolicyJob creates the job that maintains the splits requested by
+// the split policy of the table.
+func (p *planner) createSplitPolicyJob(ctx context.Context, tableDesc *tabledesc.Mutable) error {
+	record := jobs.Record{
+		Description:   fmt.Sprintf("split policy for table %s", tableDesc.GetName()),
+		Username:      p.User(),
+		DescriptorIDs: descpb.IDs{tableDesc.GetID()},
+		Details:       jobspb.TableSplitPolicyDetails{TableID: tableDesc.GetID()},
+		Progress:      jobspb.TableSplitPolicyProgress{},
+	}
+	jobID := p.ExecCfg().JobRegistry.MakeJobID()
+	if _, err := p.ExecCfg().JobRegistry.CreateAdoptableJobWithTxn(ctx, record, jobID, p.txn); err != nil {
+		return err
+	}
+	tableDesc.SplitPolicy.JobID = jobID
+	return nil
+}
+
+// ErrSplitPolicyRemoved is returned by EnforceTableSplitPolicy if the split
+// policy maintained by the job was removed, either because the table was
+// dropped or because the storage parameter was reset.
+var ErrSplitPolicyRemoved = errors.New("split policy was removed")
+
+// EnforceTableSplitPolicy splits the primary index of the table at each
+// distinct value of the prefix of the primary key columns given by its split
+// policy, up to maxSplits splits. The splits don't expire, so the ranges that
+// were merged since the last enforcement are split again. It returns the number
+// of split points, or ErrSplitPolicyRemoved if the table no longer has a split
+// policy maintained by the given job.
+func EnforceTableSplitPolicy(
+	ctx context.Context, execCfg *ExecutorConfig, jobID jobspb.JobID, tableID descpb.ID, maxSplits int64,
+) (int, error) {
+	var tableDesc catalog.TableDescriptor
+	if err := DescsTxn(ctx, execCfg, func(ctx context.Context, txn *kv.Txn, col *descs.Collection) error {
+		desc, err := col.GetImmutableTableByID(ctx, txn, tableID, tree.ObjectLookupFlags{
+			CommonLookupFlags: tree.CommonLookupFlags{AvoidLeased: true, IncludeDropped: true},
+		})
+		if err != nil {
+			return err
+		}
+		tableDesc = desc
+		return nil
+	}); err != nil {
+		if errors.Is(err, catalog.ErrDescriptorNotFound) {
+			return 0, ErrSplitPolicyRemoved
+		}
+		return 0, err
+	}
+	policy := tableDesc.GetSplitPolicy()
+	if tableDesc.Dropped() || policy == nil || policy.JobID != jobID {
+		return 0, ErrSplitPolicyRemoved
+	}
+
+	index := tableDesc.GetPrimaryIndex()
+	cols := make([]string, policy.PrefixColumns)
+	for i := range cols {
+		cols[i] = tree.NameString(index.GetKeyColumnName(i))
+	}
+	it, err := execCfg.InternalExecutor.QueryIteratorEx(
+		ctx, "split-policy-boundaries", nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: username.RootUserName()},
+		fmt.Sprintf(`SELECT DISTINCT %[1]s FROM [%[2]d AS t]@[%[3]d] ORDER BY %[1]s LIMIT %[4]d`,
+			strings.Join(cols, ", "), tableID, index.GetID(), maxSplits),
+	)
+	if err != nil {
+		return 0, err
+	}
+	numSplits := 0
+	for {
+		ok, err := it.Next(ctx)
+		if err != nil {
+			return 0, errors.CombineErrors(err, it.Close())
+		}
+		if !ok {
+			break
+		}
+		key, err := getRowKey(execCfg.Codec, tableDesc, index, it.Cur())
+		if err != nil {
+			return 0, errors.CombineErrors(err, it.Close())
+		}
+		if err := execCfg.DB.AdminSplit(ctx, key, hlc.MaxTimestamp /* expirationTime */); err != nil {
+			return 0, errors.CombineErrors(err, it.Close())
+		}
+		numSplits++
+	}
+	return numSplits, it.Close()
+}
@@ -0,0 +1,111 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package splitpolicy implements the job that maintains the splits requested
+// by the split_policy storage parameter of a table.
+package splitpolicy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+var reevaluationInterval = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"sql.split_policy.reevaluation_interval",
+	"the frequency at which the splits requested by the split policies of the tables are re-established",
+	time.Minute,
+	settings.PositiveDuration,
+).WithPublic()
+
+var maxSplits = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.split_policy.max_splits",
+	"the maximum number of splits maintained by the split policy of a table",
+	1000,
+	settings.PositiveInt,
+).WithPublic()
+
+type resumer struct {
+	job *jobs.Job
+}
+
+var _ jobs.Resumer = (*resumer)(nil)
+
+// Resume implements the jobs.Resumer interface.
+func (r *resumer) Resume(ctx context.Context, execCtxI interface{}) error {
+	execCtx := execCtxI.(sql.JobExecContext)
+	execCfg := execCtx.ExecCfg()
+	details := r.job.Details().(jobspb.TableSplitPolicyDetails)
+
+	// The job runs for as long as the table has a split policy. It's always
+	// safe to wind the SQL pod down in between two evaluations of the policy,
+	// which we indicate through the job's idle status.
+	r.job.MarkIdle(true)
+	defer r.job.MarkIdle(false)
+
+	timer := timeutil.NewTimer()
+	defer timer.Stop()
+	for {
+		numSplits, err := sql.EnforceTableSplitPolicy(
+			ctx, execCfg, r.job.ID(), details.TableID, maxSplits.Get(execCfg.SV()),
+		)
+		if errors.Is(err, sql.ErrSplitPolicyRemoved) {
+			log.Infof(ctx, "split policy of table %d was removed; exiting", details.TableID)
+			return nil
+		}
+		if err != nil {
+			// The splits are re-established at the next evaluation, so a failed
+			// evaluation doesn't fail the job.
+			log.Warningf(ctx, "failed to enforce the split policy of table %d: %v", details.TableID, err)
+		} else if err := r.job.RunningStatus(ctx, nil /* txn */, func(
+			context.Context, jobspb.Details,
+		) (jobs.RunningStatus, error) {
+			return jobs.RunningStatus(fmt.Sprintf(
+				"maintaining %d splits as of %s", numSplits, timeutil.Now().Format(time.RFC3339),
+			)), nil
+		}); err != nil {
+			return err
+		}
+
+		timer.Reset(reevaluationInterval.Get(execCfg.SV()))
+		select {
+		case <-timer.C:
+			timer.Read = true
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// OnFailOrCancel implements the jobs.Resumer interface.
+func (r *resumer) OnFailOrCancel(context.Context, interface{}) error {
+	// The splits that were established don't expire, so they remain in place
+	// until they are removed with ALTER TABLE ... UNSPLIT. A canceled job is not
+	// recreated until the split policy is set again.
+	return nil
+}
+
+func init() {
+	jobs.RegisterConstructor(jobspb.TypeTableSplitPolicy,
+		func(job *jobs.Job, settings *cluster.Settings) jobs.Resumer {
+			return &resumer{job: job}
+		})
+}
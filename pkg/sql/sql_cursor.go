@@ -12,10 +12,12 @@ package sql
 
 import (
 	"context"
+	"math"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -48,7 +50,10 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 				return nil, pgerror.Newf(pgcode.NoActiveSQLTransaction, "DECLARE CURSOR can only be used in transaction blocks")
 			}
 
-			ie := p.ExecCfg().InternalExecutorFactory(ctx, p.SessionData())
+			ie, ok := p.ExecCfg().InternalExecutorFactory(ctx, p.SessionData()).(*InternalExecutor)
+			if !ok {
+				return nil, errors.AssertionFailedf("unexpected internal executor type")
+			}
 			cursorName := s.Name.String()
 			if cursor := p.sqlCursors.getCursor(cursorName); cursor != nil {
 				return nil, pgerror.Newf(pgcode.DuplicateCursor, "cursor %q already exists", cursorName)
@@ -88,13 +93,18 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 
 			statement := s.Select.String()
 			itCtx := context.Background()
-			rows, err := ie.QueryIterator(itCtx, "sql-cursor", p.txn, statement)
+			// The query is paused after producing its first row, and each FETCH
+			// resumes it until it has produced the requested rows, so the
+			// results are never buffered.
+			pager := execinfra.NewFlowPager()
+			rows, err := ie.queryIteratorPaged(itCtx, "sql-cursor", p.txn, pager, statement)
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to DECLARE CURSOR")
 			}
 			inputState := p.txn.GetLeafTxnInputState(ctx)
 			cursor := &sqlCursor{
 				InternalRows: rows,
+				pager:        pager,
 				readSeqNum:   inputState.ReadSeqNum,
 				txn:          p.txn,
 				statement:    statement,
@@ -169,7 +179,41 @@ func (f *fetchNode) startExec(params runParams) error {
 	// semantics of cursors, which demand that data written after the cursor
 	// was declared is not visible to the cursor.
 	f.origTxnSeqNum = state.ReadSeqNum
-	return f.cursor.txn.SetReadSeqNum(f.cursor.readSeqNum)
+	if err := f.cursor.txn.SetReadSeqNum(f.cursor.readSeqNum); err != nil {
+		return err
+	}
+	// Now that the reads are performed at the right sequence number, let the
+	// query of the cursor produce the rows needed by this statement.
+	f.cursor.pager.ResumeUntil(f.targetRow())
+	return nil
+}
+
+// targetRow returns the total number of rows that the query of the cursor
+// must have produced for this statement to complete.
+func (f *fetchNode) targetRow() int64 {
+	switch f.fetchType {
+	case tree.FetchAll:
+		return math.MaxInt64
+	case tree.FetchFirst:
+		return 1
+	case tree.FetchLast:
+		// Fetching the last row is not supported.
+		return 0
+	case tree.FetchAbsolute:
+		return f.offset
+	case tree.FetchRelative:
+		return addRows(f.cursor.curRow, f.offset)
+	default:
+		return addRows(f.cursor.curRow, f.n)
+	}
+}
+
+// addRows adds two non-negative row counts, saturating on overflow.
+func addRows(a, b int64) int64 {
+	if a > math.MaxInt64-b {
+		return math.MaxInt64
+	}
+	return a + b
 }
 
 func (f *fetchNode) Next(params runParams) (bool, error) {
@@ -256,9 +300,12 @@ type sqlCursor struct {
 	// readSeqNum is the sequence number of the transaction that the cursor was
 	// initialized with.
 	readSeqNum enginepb.TxnSeq
-	statement  string
-	created    time.Time
-	curRow     int64
+	// pager paginates the results of the query of the cursor, which is
+	// paused in between the FETCH statements.
+	pager     *execinfra.FlowPager
+	statement string
+	created   time.Time
+	curRow    int64
 }
 
 // Next implements the InternalRows interface.
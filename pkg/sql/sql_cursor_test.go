@@ -12,11 +12,15 @@ package sql
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/skip"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/stretchr/testify/require"
@@ -132,3 +136,57 @@ func TestPrepareCursors(t *testing.T) {
 		require.Equal(t, false, more)
 	})
 }
+
+// TestCursorPagination verifies that the query of a cursor is paused in
+// between the FETCH statements, so that it only produces the requested rows.
+func TestCursorPagination(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const testQuery = "SELECT x FROM t"
+	var numRowsPushed int64
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{
+		Knobs: base.TestingKnobs{
+			SQLExecutor: &ExecutorTestingKnobs{
+				DistSQLReceiverPushCallbackFactory: func(query string) func(rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+					if query != testQuery {
+						return nil
+					}
+					return func(row rowenc.EncDatumRow, meta *execinfrapb.ProducerMetadata) {
+						if row != nil {
+							atomic.AddInt64(&numRowsPushed, 1)
+						}
+					}
+				},
+			},
+		},
+	})
+	defer srv.Stopper().Stop(ctx)
+
+	// All statements must be issued on the same connection.
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+	sqlDB := sqlutils.MakeSQLRunner(conn)
+	sqlDB.Exec(t, "CREATE TABLE t (x INT PRIMARY KEY)")
+	sqlDB.Exec(t, "INSERT INTO t SELECT generate_series(1, 100)")
+	sqlDB.Exec(t, "SET distsql = always")
+	sqlDB.Exec(t, "BEGIN")
+	sqlDB.Exec(t, "DECLARE foo CURSOR FOR "+testQuery)
+	for _, tc := range []struct {
+		fetch    string
+		expected int64
+	}{
+		{fetch: "FETCH 3 foo", expected: 3},
+		{fetch: "MOVE 10 foo", expected: 13},
+		{fetch: "FETCH ABSOLUTE 20 foo", expected: 20},
+		{fetch: "FETCH RELATIVE 5 foo", expected: 25},
+	} {
+		sqlDB.Exec(t, tc.fetch)
+		require.Equal(t, tc.expected, atomic.LoadInt64(&numRowsPushed), tc.fetch)
+	}
+	sqlDB.CheckQueryResults(t, "FETCH ALL foo", sqlDB.QueryStr(t, "SELECT x FROM t WHERE x > 25"))
+	require.Equal(t, int64(100), atomic.LoadInt64(&numRowsPushed))
+	sqlDB.Exec(t, "COMMIT")
+}
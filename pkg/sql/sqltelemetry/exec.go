@@ -30,6 +30,18 @@ func VecModeCounter(mode string) telemetry.Counter {
 	return telemetry.GetCounter(fmt.Sprintf("sql.exec.vectorized-setting.%s", mode))
 }
 
+// VecFallbackCounter is to be incremented every time the vectorized engine
+// falls back to the row-by-row engine because of the given component. wrapped
+// indicates whether only the component was executed by the row-by-row engine
+// (after being wrapped into the vectorized flow) or the whole flow was.
+func VecFallbackCounter(wrapped bool, component string) telemetry.Counter {
+	kind := "flow"
+	if wrapped {
+		kind = "wrapped"
+	}
+	return telemetry.GetCounter(fmt.Sprintf("sql.exec.vectorized-fallback.%s.%s", kind, component))
+}
+
 // CascadesLimitReached is to be incremented whenever the limit of foreign key
 // cascade for a single query is exceeded.
 var CascadesLimitReached = telemetry.GetCounterOnce("sql.exec.cascade-limit-reached")
@@ -61,6 +61,11 @@ var DescribeRequestCounter = telemetry.GetCounterOnce("pgwire.command.describe")
 // is made.
 var ExecuteRequestCounter = telemetry.GetCounterOnce("pgwire.command.execute")
 
+// PipelinedExecuteRequestCounter is to be incremented every time a execute
+// request is observed to be followed by a message other than a sync, which is
+// the case when the client pipelines several statements in the same batch.
+var PipelinedExecuteRequestCounter = telemetry.GetCounterOnce("pgwire.command.execute.pipelined")
+
 // CloseRequestCounter is to be incremented every time a close request
 // is made.
 var CloseRequestCounter = telemetry.GetCounterOnce("pgwire.command.close")
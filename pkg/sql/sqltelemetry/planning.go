@@ -82,6 +82,10 @@ var IndexHintUpdateUseCounter = telemetry.GetCounterOnce("sql.plan.hints.index.u
 // index hint in a DELETE.
 var IndexHintDeleteUseCounter = telemetry.GetCounterOnce("sql.plan.hints.index.delete")
 
+// PlanRegressionFallbackCounter is to be incremented whenever a statement
+// fingerprint falls back to its baseline plan because a new plan regressed.
+var PlanRegressionFallbackCounter = telemetry.GetCounterOnce("sql.plan.regression-guard.fallback")
+
 // ExplainPlanUseCounter is to be incremented whenever vanilla EXPLAIN is run.
 var ExplainPlanUseCounter = telemetry.GetCounterOnce("sql.plan.explain")
 
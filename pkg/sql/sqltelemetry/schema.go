@@ -162,6 +162,10 @@ var CreateUnloggedTableCounter = telemetry.GetCounterOnce("sql.schema.create_unl
 // view is refreshed.
 var SchemaRefreshMaterializedView = telemetry.GetCounterOnce("sql.schema.refresh_materialized_view")
 
+// SchemaRefreshMaterializedViewIncrementally is to be incremented every time a
+// materialized view is refreshed with REFRESH ... INCREMENTALLY.
+var SchemaRefreshMaterializedViewIncrementally = telemetry.GetCounterOnce("sql.schema.refresh_materialized_view.incremental")
+
 // SchemaChangeErrorCounter is to be incremented for different types
 // of errors.
 func SchemaChangeErrorCounter(typ string) telemetry.Counter {
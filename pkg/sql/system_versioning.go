@@ -0,0 +1,77 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/systemversioning"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// handleSystemVersioningStorageParamChange protects or releases the revision
+// history of the table if the system_versioning storage parameter of the table
+// was changed from before.
+func handleSystemVersioningStorageParamChange(
+	params runParams, tableDesc *tabledesc.Mutable, before *descpb.SystemVersioning,
+) error {
+	switch after := tableDesc.SystemVersioning; {
+	case before == nil && after != nil:
+		return params.p.protectTableHistory(params.ctx, tableDesc)
+	case before != nil && after == nil:
+		return params.p.releaseTableHistory(params.ctx, before)
+	}
+	return nil
+}
+
+// protectTableHistory creates the protected timestamp record that keeps the
+// revision history of a table with system versioning enabled from the read
+// timestamp of the transaction onwards.
+func (p *planner) protectTableHistory(ctx context.Context, tableDesc *tabledesc.Mutable) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.SystemVersioning) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"system versioning is not supported until the cluster is upgraded to version %s",
+			clusterversion.ByKey(clusterversion.SystemVersioning))
+	}
+	ts := p.txn.ReadTimestamp()
+	recordID := uuid.MakeV4()
+	rec := systemversioning.MakeRecord(recordID, p.ExecCfg().Codec, tableDesc.GetID(), ts)
+	if err := p.ExecCfg().ProtectedTimestampProvider.Protect(ctx, p.txn, rec); err != nil {
+		return err
+	}
+	tableDesc.SystemVersioning.ProtectedTimestampRecordID = recordID.GetBytes()
+	tableDesc.SystemVersioning.StartTime = ts
+	return nil
+}
+
+// releaseTableHistory releases the protected timestamp record of a table on
+// which system versioning was disabled.
+func (p *planner) releaseTableHistory(
+	ctx context.Context, systemVersioning *descpb.SystemVersioning,
+) error {
+	recordID, err := uuid.FromBytes(systemVersioning.ProtectedTimestampRecordID)
+	if err != nil {
+		return err
+	}
+	err = p.ExecCfg().ProtectedTimestampProvider.Release(ctx, p.txn, recordID)
+	if errors.Is(err, protectedts.ErrNotExists) {
+		// The record was already removed by the reconciler.
+		return nil
+	}
+	return err
+}
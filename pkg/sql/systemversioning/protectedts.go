@@ -0,0 +1,74 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package systemversioning manages the protected timestamp records that keep
+// the revision history of the tables with system versioning enabled, which can
+// be read with the FOR SYSTEM_TIME clause.
+package systemversioning
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptreconcile"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// MetaType is the meta type of the protected timestamp records of the tables
+// with system versioning enabled. The meta of the records is the ID of the
+// table.
+const MetaType = "system_versioning"
+
+// MakeRecord returns a protected timestamp record that protects the revision
+// history of the given table after the given timestamp.
+func MakeRecord(
+	recordID uuid.UUID, codec keys.SQLCodec, tableID descpb.ID, tsToProtect hlc.Timestamp,
+) *ptpb.Record {
+	prefix := codec.TablePrefix(uint32(tableID))
+	return &ptpb.Record{
+		ID:              recordID.GetBytesMut(),
+		Timestamp:       tsToProtect,
+		Mode:            ptpb.PROTECT_AFTER,
+		MetaType:        MetaType,
+		Meta:            []byte(strconv.FormatInt(int64(tableID), 10)),
+		DeprecatedSpans: []roachpb.Span{{Key: prefix, EndKey: prefix.PrefixEnd()}},
+		Target:          ptpb.MakeSchemaObjectsTarget(descpb.IDs{tableID}),
+	}
+}
+
+// MakeStatusFunc returns the function used by the protected timestamp
+// reconciler to remove the records of the tables that were dropped, whose
+// history would otherwise be kept forever. The records of the tables on which
+// system versioning was disabled are normally released by the ALTER TABLE
+// statement, but they are also removed.
+func MakeStatusFunc(codec keys.SQLCodec) ptreconcile.StatusFunc {
+	return func(ctx context.Context, txn *kv.Txn, meta []byte) (shouldRemove bool, _ error) {
+		id, err := strconv.ParseInt(string(meta), 10, 64)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to interpret meta %q as a table ID", meta)
+		}
+		// The descriptor is read directly rather than through the descriptor
+		// collection, since the reconciler runs outside of any SQL session.
+		var desc descpb.Descriptor
+		if err := txn.GetProto(ctx, catalogkeys.MakeDescMetadataKey(codec, descpb.ID(id)), &desc); err != nil {
+			return false, err
+		}
+		table, _, _, _ := descpb.FromDescriptor(&desc)
+		return table == nil || table.Dropped() || table.SystemVersioning == nil, nil
+	}
+}
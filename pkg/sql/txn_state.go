@@ -17,6 +17,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
@@ -92,6 +94,10 @@ type txnState struct {
 	// The transaction's read only state.
 	readOnly bool
 
+	// The transaction's isolation level. It's either SerializableIsolation or
+	// ReadCommittedIsolation.
+	isolationLevel tree.IsolationLevel
+
 	// Set to true when the current transaction is using a historical timestamp
 	// through the use of AS OF SYSTEM TIME.
 	isHistorical bool
@@ -146,6 +152,7 @@ const (
 // priority: The transaction's priority. Pass roachpb.UnspecifiedUserPriority if the txn arg is
 //   not nil.
 // readOnly: The read-only character of the new txn.
+// isolationLevel: The isolation level of the new txn.
 // txn: If not nil, this txn will be used instead of creating a new txn. If so,
 //   all the other arguments need to correspond to the attributes of this txn
 //   (unless otherwise specified).
@@ -159,6 +166,7 @@ func (ts *txnState) resetForNewSQLTxn(
 	historicalTimestamp *hlc.Timestamp,
 	priority roachpb.UserPriority,
 	readOnly tree.ReadWriteMode,
+	isolationLevel tree.IsolationLevel,
 	txn *kv.Txn,
 	tranCtx transitionCtx,
 	qualityOfService sessiondatapb.QoSLevel,
@@ -167,6 +175,7 @@ func (ts *txnState) resetForNewSQLTxn(
 	ts.sqlTimestamp = sqlTimestamp
 	ts.isHistorical = false
 	ts.lastEpoch = 0
+	ts.isolationLevel = isolationLevel
 
 	// Create a context for this transaction. It will include a root span that
 	// will contain everything executed as part of the upcoming SQL txn, including
@@ -333,6 +342,20 @@ func (ts *txnState) setReadOnlyMode(mode tree.ReadWriteMode) error {
 	return nil
 }
 
+// setIsolationLevel sets the isolation level of the transaction. The level
+// can't change once the transaction has performed reads or writes.
+func (ts *txnState) setIsolationLevel(level tree.IsolationLevel) error {
+	if level == ts.isolationLevel {
+		return nil
+	}
+	if ts.mu.txn.Active() {
+		return pgerror.New(pgcode.ActiveSQLTransaction,
+			"SET TRANSACTION ISOLATION LEVEL must be called before any query")
+	}
+	ts.isolationLevel = level
+	return nil
+}
+
 // advanceCode is part of advanceInfo; it instructs the module managing the
 // statements buffer on what action to take.
 type advanceCode int
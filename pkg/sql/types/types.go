@@ -454,6 +454,24 @@ var (
 		},
 	}
 
+	// TSQuery is the type of a text search query.
+	TSQuery = &T{
+		InternalType: InternalType{
+			Family: TSQueryFamily,
+			Oid:    oid.T_tsquery,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// TSVector is the type of a document optimized for text search.
+	TSVector = &T{
+		InternalType: InternalType{
+			Family: TSVectorFamily,
+			Oid:    oid.T_tsvector,
+			Locale: &emptyLocale,
+		},
+	}
+
 	// Void is the type representing void.
 	Void = &T{
 		InternalType: InternalType{
@@ -502,6 +520,8 @@ var (
 		TimeTZ,
 		Jsonb,
 		VarBit,
+		TSQuery,
+		TSVector,
 	}
 
 	// Any is a special type used only during static analysis as a wildcard type
@@ -1400,6 +1420,8 @@ var familyNames = map[Family]string{
 	TimestampFamily:      "timestamp",
 	TimestampTZFamily:    "timestamptz",
 	TimeTZFamily:         "timetz",
+	TSQueryFamily:        "tsquery",
+	TSVectorFamily:       "tsvector",
 	TupleFamily:          "tuple",
 	UnknownFamily:        "unknown",
 	UuidFamily:           "uuid",
@@ -1719,6 +1741,10 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 			return "timestamp with time zone"
 		}
 		return fmt.Sprintf("timestamp(%d) with time zone", typmod)
+	case TSQueryFamily:
+		return "tsquery"
+	case TSVectorFamily:
+		return "tsvector"
 	case TupleFamily:
 		if t.UserDefined() {
 			// If we have a user-defined tuple type, use its user-defined name.
@@ -2771,8 +2797,10 @@ var unreservedTypeTokens = map[string]*T{
 	"smallserial": &Serial2Type,
 	"bigserial":   &Serial8Type,
 
-	"string": String,
-	"uuid":   Uuid,
+	"string":   String,
+	"tsquery":  TSQuery,
+	"tsvector": TSVector,
+	"uuid":     Uuid,
 }
 
 // The following map must include all types predefined in PostgreSQL
@@ -2791,8 +2819,6 @@ var postgresPredefinedTypeIssues = map[string]int{
 	"money":         41578,
 	"path":          21286,
 	"pg_lsn":        -1,
-	"tsquery":       7821,
-	"tsvector":      7821,
 	"txid_snapshot": -1,
 	"xml":           43355,
 }
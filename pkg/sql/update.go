@@ -119,6 +119,12 @@ type updateRun struct {
 	// columns of the target table being returned, that we must pass through
 	// from the input node.
 	numPassthrough int
+
+
+	// triggers buffers the old and new values of the updated rows for the
+	// row-level triggers of the table. It is nil if no trigger fires on
+	// UPDATE.
+	triggers *rowTriggerEvents
 }
 
 func (u *updateNode) startExec(params runParams) error {
@@ -316,6 +322,12 @@ func (u *updateNode) processSourceRow(params runParams, sourceVals tree.Datums)
 		return err
 	}
 
+	if u.run.triggers != nil {
+		if err := u.run.triggers.addRow(params, oldValues, newValues); err != nil {
+			return err
+		}
+	}
+
 	// If result rows need to be accumulated, do it.
 	if u.run.tu.rows != nil {
 		// The new values can include all columns,  so the values may contain
@@ -45,6 +45,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
@@ -443,16 +444,22 @@ var varGen = map[string]sessionVar{
 	`default_transaction_isolation`: {
 		Set: func(_ context.Context, m sessionDataMutator, s string) error {
 			switch strings.ToUpper(s) {
-			case `READ UNCOMMITTED`, `READ COMMITTED`, `SNAPSHOT`, `REPEATABLE READ`, `SERIALIZABLE`, `DEFAULT`:
-				// Do nothing. All transactions execute with serializable isolation.
+			case `READ UNCOMMITTED`, `READ COMMITTED`:
+				m.SetDefaultTransactionIsolationLevel(tree.ReadCommittedIsolation)
+			case `SNAPSHOT`, `REPEATABLE READ`, `SERIALIZABLE`, `DEFAULT`:
+				m.SetDefaultTransactionIsolationLevel(tree.SerializableIsolation)
 			default:
-				return newVarValueError(`default_transaction_isolation`, s, "serializable")
+				return newVarValueError(`default_transaction_isolation`, s, "serializable", "read committed")
 			}
 
 			return nil
 		},
 		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
-			return "serializable", nil
+			level := tree.IsolationLevel(evalCtx.SessionData().DefaultTxnIsolationLevel)
+			if level == tree.UnspecifiedIsolation {
+				level = tree.SerializableIsolation
+			}
+			return strings.ToLower(level.String()), nil
 		},
 		GlobalDefault: func(sv *settings.Values) string { return "default" },
 	},
@@ -1430,14 +1437,19 @@ var varGen = map[string]sessionVar{
 	// See https://github.com/postgres/postgres/blob/REL_10_STABLE/src/backend/utils/misc/guc.c#L3401-L3409
 	`transaction_isolation`: {
 		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
-			return "serializable", nil
+			level := evalCtx.TxnIsoLevel
+			if level == tree.UnspecifiedIsolation {
+				level = tree.SerializableIsolation
+			}
+			return strings.ToLower(level.String()), nil
 		},
-		RuntimeSet: func(_ context.Context, evalCtx *extendedEvalContext, local bool, s string) error {
-			_, ok := tree.IsolationLevelMap[s]
+		RuntimeSet: func(ctx context.Context, evalCtx *extendedEvalContext, local bool, s string) error {
+			level, ok := tree.IsolationLevelMap[s]
 			if !ok {
-				return newVarValueError(`transaction_isolation`, s, "serializable")
+				return newVarValueError(`transaction_isolation`, s, "serializable", "read committed")
 			}
-			return nil
+			return evalCtx.TxnModesSetter.setTransactionModes(
+				ctx, tree.TransactionModes{Isolation: level}, hlc.Timestamp{} /* asOfTs */)
 		},
 		GlobalDefault: func(_ *settings.Values) string { return "serializable" },
 	},
@@ -1978,7 +1990,7 @@ var varGen = map[string]sessionVar{
 			return evalCtx.QualityOfService().String(), nil
 		},
 		GlobalDefault: func(sv *settings.Values) string {
-			return sessiondatapb.Normal.String()
+			return sessiondatapb.QoSLevel(defaultTxnQualityOfService.Get(sv)).String()
 		},
 	},
 	`opt_split_scan_limit`: {
@@ -2132,6 +2144,135 @@ var varGen = map[string]sessionVar{
 			return strconv.FormatInt(0, 10)
 		},
 	},
+
+	// CockroachDB extension.
+	`disable_scan_parallelization`: {
+		GetStringVal: makePostgresBoolGetStringValFn(`disable_scan_parallelization`),
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar(`disable_scan_parallelization`, s)
+			if err != nil {
+				return err
+			}
+			m.SetDisableScanParallelization(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return formatBoolAsPostgresSetting(evalCtx.SessionData().DisableScanParallelization), nil
+		},
+		GlobalDefault: globalFalse,
+	},
+
+	// CockroachDB extension.
+	`scan_batch_bytes_limit`: {
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			limit, err := humanizeutil.ParseBytes(s)
+			if err != nil {
+				return err
+			}
+			if limit < 0 {
+				return errors.New("scan_batch_bytes_limit cannot be set to a negative value")
+			}
+			m.SetScanBatchBytesLimit(limit)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return string(humanizeutil.IBytes(evalCtx.SessionData().ScanBatchBytesLimit)), nil
+		},
+		GlobalDefault: func(sv *settings.Values) string {
+			return string(humanizeutil.IBytes(0))
+		},
+	},
+
+	// CockroachDB extension.
+	`verify_scan_checksums`: {
+		GetStringVal: makePostgresBoolGetStringValFn(`verify_scan_checksums`),
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar(`verify_scan_checksums`, s)
+			if err != nil {
+				return err
+			}
+			m.SetVerifyScanChecksums(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return formatBoolAsPostgresSetting(evalCtx.SessionData().VerifyScanChecksums), nil
+		},
+		GlobalDefault: globalFalse,
+	},
+
+	// CockroachDB extension.
+	`min_scan_batch_size`: {
+		GetStringVal: makeIntGetStringValFn(`min_scan_batch_size`),
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			b, err := strconv.ParseInt(s, 10, 32)
+			if err != nil {
+				return err
+			}
+			if b < 0 {
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"cannot set min_scan_batch_size to a negative value: %d", b)
+			}
+			m.SetMinScanBatchSize(int32(b))
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return strconv.FormatInt(int64(evalCtx.SessionData().MinScanBatchSize), 10), nil
+		},
+		GlobalDefault: func(sv *settings.Values) string {
+			return "0"
+		},
+	},
+
+	// CockroachDB extension.
+	`enable_result_cache`: {
+		GetStringVal: makePostgresBoolGetStringValFn(`enable_result_cache`),
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar(`enable_result_cache`, s)
+			if err != nil {
+				return err
+			}
+			m.SetEnableResultCache(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return formatBoolAsPostgresSetting(evalCtx.SessionData().EnableResultCache), nil
+		},
+		GlobalDefault: globalFalse,
+	},
+
+	// CockroachDB extension.
+	`enable_auto_index_json_computed_columns`: {
+		GetStringVal: makePostgresBoolGetStringValFn(`enable_auto_index_json_computed_columns`),
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar(`enable_auto_index_json_computed_columns`, s)
+			if err != nil {
+				return err
+			}
+			m.SetAutoIndexJSONComputedColumns(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return formatBoolAsPostgresSetting(evalCtx.SessionData().AutoIndexJSONComputedColumns), nil
+		},
+		GlobalDefault: globalFalse,
+	},
+
+	// CockroachDB extension.
+	`reject_unknown_statement_hints`: {
+		GetStringVal: makePostgresBoolGetStringValFn(`reject_unknown_statement_hints`),
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar(`reject_unknown_statement_hints`, s)
+			if err != nil {
+				return err
+			}
+			m.SetRejectUnknownStatementHints(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
+			return formatBoolAsPostgresSetting(evalCtx.SessionData().RejectUnknownStatementHints), nil
+		},
+		GlobalDefault: globalFalse,
+	},
 }
 
 const compatErrMsg = "this parameter is currently recognized only for compatibility and has no effect in CockroachDB."
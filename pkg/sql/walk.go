@@ -368,13 +368,16 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&commentOnSchemaNode{}):              "comment on schema",
 	reflect.TypeOf(&controlJobsNode{}):                  "control jobs",
 	reflect.TypeOf(&controlSchedulesNode{}):             "control schedules",
+	reflect.TypeOf(&createAggregateNode{}):              "create aggregate",
 	reflect.TypeOf(&createDatabaseNode{}):               "create database",
 	reflect.TypeOf(&createExtensionNode{}):              "create extension",
 	reflect.TypeOf(&createIndexNode{}):                  "create index",
+	reflect.TypeOf(&createPolicyNode{}):                 "create policy",
 	reflect.TypeOf(&createSequenceNode{}):               "create sequence",
 	reflect.TypeOf(&createSchemaNode{}):                 "create schema",
 	reflect.TypeOf(&createStatsNode{}):                  "create statistics",
 	reflect.TypeOf(&createTableNode{}):                  "create table",
+	reflect.TypeOf(&createTriggerNode{}):                "create trigger",
 	reflect.TypeOf(&createTypeNode{}):                   "create type",
 	reflect.TypeOf(&CreateRoleNode{}):                   "create user/role",
 	reflect.TypeOf(&createViewNode{}):                   "create view",
@@ -382,11 +385,14 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&deleteNode{}):                       "delete",
 	reflect.TypeOf(&deleteRangeNode{}):                  "delete range",
 	reflect.TypeOf(&distinctNode{}):                     "distinct",
+	reflect.TypeOf(&dropAggregateNode{}):                "drop aggregate",
 	reflect.TypeOf(&dropDatabaseNode{}):                 "drop database",
 	reflect.TypeOf(&dropIndexNode{}):                    "drop index",
+	reflect.TypeOf(&dropPolicyNode{}):                   "drop policy",
 	reflect.TypeOf(&dropSequenceNode{}):                 "drop sequence",
 	reflect.TypeOf(&dropSchemaNode{}):                   "drop schema",
 	reflect.TypeOf(&dropTableNode{}):                    "drop table",
+	reflect.TypeOf(&dropTriggerNode{}):                  "drop trigger",
 	reflect.TypeOf(&dropTypeNode{}):                     "drop type",
 	reflect.TypeOf(&DropRoleNode{}):                     "drop user/role",
 	reflect.TypeOf(&dropViewNode{}):                     "drop view",
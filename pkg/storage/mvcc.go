@@ -2340,6 +2340,7 @@ func mvccScanToBytes(
 		inconsistent:           opts.Inconsistent,
 		tombstones:             opts.Tombstones,
 		failOnMoreRecent:       opts.FailOnMoreRecent,
+		minTimestamp:           opts.MinTimestamp,
 		keyBuf:                 mvccScanner.keyBuf,
 	}
 
@@ -2487,6 +2488,13 @@ type MVCCScanOptions struct {
 	MaxIntents int64
 	// MemoryAccount is used for tracking memory allocations.
 	MemoryAccount *mon.BoundAccount
+	// MinTimestamp, if set, makes the scan return only the keys whose visible
+	// version is newer than MinTimestamp. Inline values are never returned in
+	// this mode, and it cannot be combined with Tombstones.
+	//
+	// Note that this option only filters the results of the scan - the older
+	// versions are still iterated over.
+	MinTimestamp hlc.Timestamp
 }
 
 func (opts *MVCCScanOptions) validate() error {
@@ -2496,6 +2504,9 @@ func (opts *MVCCScanOptions) validate() error {
 	if opts.Inconsistent && opts.FailOnMoreRecent {
 		return errors.Errorf("cannot allow inconsistent reads with fail on more recent option")
 	}
+	if !opts.MinTimestamp.IsEmpty() && opts.Tombstones {
+		return errors.Errorf("cannot return tombstones with a min timestamp")
+	}
 	return nil
 }
 
@@ -2536,6 +2547,9 @@ type MVCCScanResult struct {
 // RawBytes field is nil. Otherwise, the key-value pair will be omitted from the
 // result entirely.
 //
+// If MinTimestamp is set, the keys whose visible version is at or below
+// MinTimestamp are omitted from the result.
+//
 // When scanning inconsistently, any encountered intents will be placed in the
 // dedicated result parameter. By contrast, when scanning consistently, any
 // encountered intents will cause the scan to return a WriteIntentError with the
@@ -1197,6 +1197,87 @@ func TestMVCCScanWithKeyPrefix(t *testing.T) {
 	}
 }
 
+// TestMVCCScanMinTimestamp verifies that a scan with MinTimestamp only returns
+// the keys whose visible version is newer than the min timestamp.
+func TestMVCCScanMinTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	for _, engineImpl := range mvccEngineImpls {
+		t.Run(engineImpl.name, func(t *testing.T) {
+			engine := engineImpl.create()
+			defer engine.Close()
+
+			// testKey1 is written at T=1 and at T=3, testKey2 only at T=1, testKey3
+			// at T=1 and deleted at T=3, and testKey4 only at T=4.
+			for _, w := range []struct {
+				key   roachpb.Key
+				ts    int64
+				value *roachpb.Value
+			}{
+				{testKey1, 1, &value1},
+				{testKey1, 3, &value2},
+				{testKey2, 1, &value2},
+				{testKey3, 1, &value3},
+				{testKey3, 3, nil},
+				{testKey4, 4, &value4},
+			} {
+				var err error
+				if w.value == nil {
+					err = MVCCDelete(ctx, engine, nil, w.key, hlc.Timestamp{WallTime: w.ts}, hlc.ClockTimestamp{}, nil)
+				} else {
+					err = MVCCPut(ctx, engine, nil, w.key, hlc.Timestamp{WallTime: w.ts}, hlc.ClockTimestamp{}, *w.value, nil)
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for _, tc := range []struct {
+				readTS, minTS int64
+				expected      []roachpb.Key
+			}{
+				{readTS: 5, minTS: 0, expected: []roachpb.Key{testKey1, testKey2, testKey4}},
+				{readTS: 5, minTS: 1, expected: []roachpb.Key{testKey1, testKey4}},
+				{readTS: 5, minTS: 3, expected: []roachpb.Key{testKey4}},
+				{readTS: 5, minTS: 4, expected: nil},
+				// The versions are filtered as of the read timestamp.
+				{readTS: 2, minTS: 1, expected: nil},
+				{readTS: 3, minTS: 2, expected: []roachpb.Key{testKey1}},
+			} {
+				for _, reverse := range []bool{false, true} {
+					res, err := MVCCScan(ctx, engine, testKey1, testKey5,
+						hlc.Timestamp{WallTime: tc.readTS},
+						MVCCScanOptions{MinTimestamp: hlc.Timestamp{WallTime: tc.minTS}, Reverse: reverse})
+					if err != nil {
+						t.Fatal(err)
+					}
+					var keys []roachpb.Key
+					for _, kv := range res.KVs {
+						keys = append(keys, kv.Key)
+					}
+					if reverse {
+						for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+							keys[i], keys[j] = keys[j], keys[i]
+						}
+					}
+					if !reflect.DeepEqual(keys, tc.expected) {
+						t.Errorf("readTS=%d minTS=%d reverse=%t: expected %v, got %v",
+							tc.readTS, tc.minTS, reverse, tc.expected, keys)
+					}
+				}
+			}
+
+			if _, err := MVCCScan(ctx, engine, testKey1, testKey5, hlc.Timestamp{WallTime: 5},
+				MVCCScanOptions{MinTimestamp: hlc.Timestamp{WallTime: 1}, Tombstones: true},
+			); !testutils.IsError(err, "cannot return tombstones with a min timestamp") {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestMVCCScanInTxn(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -333,6 +333,9 @@ type pebbleMVCCScanner struct {
 	// Uncertainty related fields.
 	uncertainty      uncertainty.Interval
 	checkUncertainty bool
+	// If set, only the versions newer than minTimestamp are added to the
+	// results. See MVCCScanOptions.MinTimestamp.
+	minTimestamp hlc.Timestamp
 	// Metadata object for unmarshalling intents.
 	meta enginepb.MVCCMetadata
 	// Bools copied over from MVCC{Scan,Get}Options. See the comment on the
@@ -914,6 +917,12 @@ func (p *pebbleMVCCScanner) addAndAdvance(
 	if len(rawValue) == 0 && !p.tombstones {
 		return p.advanceKey()
 	}
+	// Don't include the versions that are not newer than minTimestamp, if set.
+	// Note that p.curUnsafeKey always refers to the version being added (inline
+	// values have an empty timestamp and are skipped too).
+	if !p.minTimestamp.IsEmpty() && p.curUnsafeKey.Timestamp.LessEq(p.minTimestamp) {
+		return p.advanceKey()
+	}
 
 	// Check if adding the key would exceed a limit.
 	if p.targetBytes > 0 && (p.results.bytes >= p.targetBytes || (p.targetBytesAvoidExcess &&
@@ -1933,6 +1933,10 @@ var charts = []sectionDescription{
 				Title:   "Scheduled",
 				Metrics: []string{"sql.distsql.flows.scheduled"},
 			},
+			{
+				Title:   "Shed",
+				Metrics: []string{"sql.distsql.flows.shed"},
+			},
 		},
 	},
 	{
@@ -2939,6 +2943,8 @@ var charts = []sectionDescription{
 					"jobs.auto_span_config_reconciliation.currently_running",
 					"jobs.auto_sql_stats_compaction.currently_running",
 					"jobs.stream_replication.currently_running",
+					"jobs.table_split_policy.currently_running",
+					"jobs.index_advisor.currently_running",
 				},
 			},
 			{
@@ -2950,6 +2956,7 @@ var charts = []sectionDescription{
 					"jobs.backup.currently_idle",
 					"jobs.changefeed.currently_idle",
 					"jobs.create_stats.currently_idle",
+					"jobs.index_advisor.currently_idle",
 					"jobs.import.currently_idle",
 					"jobs.migration.currently_idle",
 					"jobs.new_schema_change.currently_idle",
@@ -2958,6 +2965,7 @@ var charts = []sectionDescription{
 					"jobs.schema_change_gc.currently_idle",
 					"jobs.stream_ingestion.currently_idle",
 					"jobs.stream_replication.currently_idle",
+					"jobs.table_split_policy.currently_idle",
 					"jobs.typedesc_schema_change.currently_idle",
 				},
 			},
@@ -3135,6 +3143,28 @@ var charts = []sectionDescription{
 					"jobs.auto_sql_stats_compaction.resume_retry_error",
 				},
 			},
+			{
+				Title: "Table Split Policy",
+				Metrics: []string{
+					"jobs.table_split_policy.fail_or_cancel_completed",
+					"jobs.table_split_policy.fail_or_cancel_failed",
+					"jobs.table_split_policy.fail_or_cancel_retry_error",
+					"jobs.table_split_policy.resume_completed",
+					"jobs.table_split_policy.resume_failed",
+					"jobs.table_split_policy.resume_retry_error",
+				},
+			},
+			{
+				Title: "Index Advisor",
+				Metrics: []string{
+					"jobs.index_advisor.fail_or_cancel_completed",
+					"jobs.index_advisor.fail_or_cancel_failed",
+					"jobs.index_advisor.fail_or_cancel_retry_error",
+					"jobs.index_advisor.resume_completed",
+					"jobs.index_advisor.resume_failed",
+					"jobs.index_advisor.resume_retry_error",
+				},
+			},
 		},
 	},
 	{
@@ -73,6 +73,14 @@ const typeOptions = [
     value: JobType.ROW_LEVEL_TTL.toString(),
     label: "Time-to-live Deletions",
   },
+  {
+    value: JobType.TABLE_SPLIT_POLICY.toString(),
+    label: "Table Split Policies",
+  },
+  {
+    value: JobType.INDEX_ADVISOR.toString(),
+    label: "Index Advisor",
+  },
 ];
 
 export const typeSetting = new LocalSetting<AdminUIState, number>(
@@ -704,6 +704,13 @@ func (q *WorkQueue) AdmittedWorkDone(tenantID roachpb.TenantID) {
 	q.granter.returnGrant(1)
 }
 
+// Overloaded returns true if the WorkQueue has waiting requests, i.e. if the
+// resource it admits work for is exhausted. Background work that can run at a
+// lower rate, such as bulk operations, uses it to throttle itself.
+func (q *WorkQueue) Overloaded() bool {
+	return q.hasWaitingRequests()
+}
+
 func (q *WorkQueue) hasWaitingRequests() bool {
 	q.mu.Lock()
 	defer q.mu.Unlock()
@@ -247,79 +247,101 @@ func encodeMediumNumber(negative bool, e int, m []byte, encInto []byte) []byte {
 // DecodeDecimalAscending returns the remaining byte slice after decoding and the decoded
 // decimal from buf.
 func DecodeDecimalAscending(buf []byte, tmp []byte) ([]byte, apd.Decimal, error) {
-	return decodeDecimal(buf, tmp, false)
+	var dec apd.Decimal
+	buf, err := decodeIntoDecimal(&dec, buf, tmp, false)
+	return buf, dec, err
 }
 
 // DecodeDecimalDescending decodes decimals encoded with EncodeDecimalDescending.
 func DecodeDecimalDescending(buf []byte, tmp []byte) ([]byte, apd.Decimal, error) {
-	return decodeDecimal(buf, tmp, true)
+	var dec apd.Decimal
+	buf, err := decodeIntoDecimal(&dec, buf, tmp, true)
+	return buf, dec, err
+}
+
+// DecodeIntoDecimalAscending is like DecodeDecimalAscending, but it operates on
+// the passed-in *apd.Decimal instead of producing a new one. The coefficient
+// storage of dec is reused, so decoding into the same decimal repeatedly
+// doesn't allocate once the storage is large enough.
+func DecodeIntoDecimalAscending(dec *apd.Decimal, buf []byte, tmp []byte) ([]byte, error) {
+	return decodeIntoDecimal(dec, buf, tmp, false)
+}
+
+// DecodeIntoDecimalDescending is like DecodeDecimalDescending, but it operates
+// on the passed-in *apd.Decimal instead of producing a new one.
+func DecodeIntoDecimalDescending(dec *apd.Decimal, buf []byte, tmp []byte) ([]byte, error) {
+	return decodeIntoDecimal(dec, buf, tmp, true)
+}
+
+// resetDecimal sets dec to zero while keeping the storage of its coefficient.
+func resetDecimal(dec *apd.Decimal) {
+	dec.Form = apd.Finite
+	dec.Negative = false
+	dec.Exponent = 0
+	dec.Coeff.SetInt64(0)
 }
 
-func decodeDecimal(buf []byte, tmp []byte, invert bool) ([]byte, apd.Decimal, error) {
+func decodeIntoDecimal(dec *apd.Decimal, buf []byte, tmp []byte, invert bool) ([]byte, error) {
+	resetDecimal(dec)
 	// Handle the simplistic cases first.
 	switch buf[0] {
 	case decimalNaN, decimalNaNDesc:
-		return buf[1:], apd.Decimal{Form: apd.NaN}, nil
+		dec.Form = apd.NaN
+		return buf[1:], nil
 	case decimalInfinity:
-		return buf[1:], apd.Decimal{Form: apd.Infinite, Negative: invert}, nil
+		dec.Form = apd.Infinite
+		dec.Negative = invert
+		return buf[1:], nil
 	case decimalNegativeInfinity:
-		return buf[1:], apd.Decimal{Form: apd.Infinite, Negative: !invert}, nil
+		dec.Form = apd.Infinite
+		dec.Negative = !invert
+		return buf[1:], nil
 	case decimalZero:
-		return buf[1:], apd.Decimal{}, nil
+		return buf[1:], nil
 	}
 	tmp = tmp[len(tmp):cap(tmp)]
+	var (
+		e        int
+		m, r     []byte
+		tmp2     []byte
+		negative bool
+		err      error
+	)
 	switch {
 	case buf[0] == decimalNegLarge:
 		// Negative large.
-		e, m, r, tmp2, err := decodeLargeNumber(true, buf, tmp)
-		if err != nil {
-			return nil, apd.Decimal{}, err
-		}
-		d, err := makeDecimalFromMandE(!invert, e, m, tmp2)
-		return r, d, err
+		e, m, r, tmp2, err = decodeLargeNumber(true, buf, tmp)
+		negative = !invert
 	case buf[0] > decimalNegLarge && buf[0] <= decimalNegMedium:
 		// Negative medium.
-		e, m, r, tmp2, err := decodeMediumNumber(true, buf, tmp)
-		if err != nil {
-			return nil, apd.Decimal{}, err
-		}
-		d, err := makeDecimalFromMandE(!invert, e, m, tmp2)
-		return r, d, err
+		e, m, r, tmp2, err = decodeMediumNumber(true, buf, tmp)
+		negative = !invert
 	case buf[0] == decimalNegSmall:
 		// Negative small.
-		e, m, r, tmp2, err := decodeSmallNumber(true, buf, tmp)
-		if err != nil {
-			return nil, apd.Decimal{}, err
-		}
-		d, err := makeDecimalFromMandE(!invert, e, m, tmp2)
-		return r, d, err
+		e, m, r, tmp2, err = decodeSmallNumber(true, buf, tmp)
+		negative = !invert
 	case buf[0] == decimalPosLarge:
 		// Positive large.
-		e, m, r, tmp2, err := decodeLargeNumber(false, buf, tmp)
-		if err != nil {
-			return nil, apd.Decimal{}, err
-		}
-		d, err := makeDecimalFromMandE(invert, e, m, tmp2)
-		return r, d, err
+		e, m, r, tmp2, err = decodeLargeNumber(false, buf, tmp)
+		negative = invert
 	case buf[0] >= decimalPosMedium && buf[0] < decimalPosLarge:
 		// Positive medium.
-		e, m, r, tmp2, err := decodeMediumNumber(false, buf, tmp)
-		if err != nil {
-			return nil, apd.Decimal{}, err
-		}
-		d, err := makeDecimalFromMandE(invert, e, m, tmp2)
-		return r, d, err
+		e, m, r, tmp2, err = decodeMediumNumber(false, buf, tmp)
+		negative = invert
 	case buf[0] == decimalPosSmall:
 		// Positive small.
-		e, m, r, tmp2, err := decodeSmallNumber(false, buf, tmp)
-		if err != nil {
-			return nil, apd.Decimal{}, err
-		}
-		d, err := makeDecimalFromMandE(invert, e, m, tmp2)
-		return r, d, err
+		e, m, r, tmp2, err = decodeSmallNumber(false, buf, tmp)
+		negative = invert
 	default:
-		return nil, apd.Decimal{}, errors.Errorf("unknown prefix of the encoded byte slice: %q", buf)
+		return nil, errors.Errorf("unknown prefix of the encoded byte slice: %q", buf)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = makeDecimalFromMandE(dec, negative, e, m, tmp2); err != nil {
+		return nil, err
 	}
+	return r, nil
 }
 
 // getDecimalLen returns the length of an encoded decimal.
@@ -349,10 +371,10 @@ func getDecimalLen(buf []byte) (int, error) {
 }
 
 // makeDecimalFromMandE reconstructs the decimal from the mantissa M and
-// exponent E.
-func makeDecimalFromMandE(negative bool, e int, m []byte, tmp []byte) (apd.Decimal, error) {
+// exponent E, writing the result into dec.
+func makeDecimalFromMandE(dec *apd.Decimal, negative bool, e int, m []byte, tmp []byte) error {
 	if len(m) == 0 {
-		return apd.Decimal{}, errors.New("expected mantissa, got zero bytes")
+		return errors.New("expected mantissa, got zero bytes")
 	}
 	// ±dddd.
 	b := tmp[:0]
@@ -362,7 +384,7 @@ func makeDecimalFromMandE(negative bool, e int, m []byte, tmp []byte) (apd.Decim
 	for _, v := range m {
 		t := int(v) / 2
 		if t < 0 || t > 99 {
-			return apd.Decimal{}, errors.Errorf("base-100 encoded digit %d out of range [0,99]", t)
+			return errors.Errorf("base-100 encoded digit %d out of range [0,99]", t)
 		}
 		b = append(b, byte(t/10)+'0', byte(t%10)+'0')
 	}
@@ -371,20 +393,18 @@ func makeDecimalFromMandE(negative bool, e int, m []byte, tmp []byte) (apd.Decim
 	}
 
 	exp := 2*e - len(b)
-	dec := apd.Decimal{
-		Exponent: int32(exp),
-	}
+	dec.Exponent = int32(exp)
 
 	// We unsafely convert the []byte to a string to avoid the usual allocation
 	// when converting to a string.
 	s := *(*string)(unsafe.Pointer(&b))
 	_, ok := dec.Coeff.SetString(s, 10)
 	if !ok {
-		return apd.Decimal{}, errors.Errorf("could not set big.Int's string value: %q", s)
+		return errors.Errorf("could not set big.Int's string value: %q", s)
 	}
 	dec.Negative = negative
 
-	return dec, nil
+	return nil
 }
 
 // findDecimalTerminator finds the decimalTerminator in the given slice.
@@ -622,9 +642,10 @@ func DecodeNonsortingDecimal(buf []byte, tmp []byte) (apd.Decimal, error) {
 }
 
 // DecodeIntoNonsortingDecimal is like DecodeNonsortingDecimal, but it operates
-// on the passed-in *apd.Decimal instead of producing a new one.
+// on the passed-in *apd.Decimal instead of producing a new one. The
+// coefficient storage of dec is reused.
 func DecodeIntoNonsortingDecimal(dec *apd.Decimal, buf []byte, tmp []byte) error {
-	*dec = apd.Decimal{}
+	resetDecimal(dec)
 	switch buf[0] {
 	case decimalNaN:
 		dec.Form = apd.NaN
@@ -245,6 +245,9 @@ func TestEncodeDecimalRand(t *testing.T) {
 	for _, dir := range []Direction{Ascending, Descending} {
 		var prev *apd.Decimal
 		var prevEnc []byte
+		// into is reused across all trials to make sure that decoding into a
+		// decimal that already has a value doesn't leak any of that value.
+		var into apd.Decimal
 		const randomTrials = 100000
 		for i := 0; i < randomTrials; i++ {
 			cur := randDecimal(rng, -20, 20)
@@ -265,10 +268,16 @@ func TestEncodeDecimalRand(t *testing.T) {
 				enc = EncodeDecimalAscending(appendTo, cur)
 				enc = enc[len(appendTo):]
 				_, res, err = DecodeDecimalAscending(enc, tmp)
+				if err == nil {
+					_, err = DecodeIntoDecimalAscending(&into, enc, tmp)
+				}
 			} else {
 				enc = EncodeDecimalDescending(appendTo, cur)
 				enc = enc[len(appendTo):]
 				_, res, err = DecodeDecimalDescending(enc, tmp)
+				if err == nil {
+					_, err = DecodeIntoDecimalDescending(&into, enc, tmp)
+				}
 			}
 			if err != nil {
 				t.Fatal(err)
@@ -280,6 +289,9 @@ func TestEncodeDecimalRand(t *testing.T) {
 			if cur.Cmp(&res) != 0 {
 				t.Fatalf("unexpected mismatch for %v, got %v", cur, res)
 			}
+			if cur.Cmp(&into) != 0 {
+				t.Fatalf("unexpected mismatch when decoding into %v, got %v", cur, &into)
+			}
 
 			// Make sure lexicographical sorting is consistent.
 			if prev != nil {
@@ -615,6 +627,18 @@ func BenchmarkDecodeDecimalLarge(b *testing.B) {
 	}
 }
 
+func BenchmarkDecodeIntoDecimalLarge(b *testing.B) {
+	vals := makeEncodedVals(11, 40)
+	buf := make([]byte, 0, 100)
+	var dec apd.Decimal
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = DecodeIntoDecimalAscending(&dec, vals[i%len(vals)], buf)
+	}
+}
+
 func BenchmarkPeekLengthDecimal(b *testing.B) {
 	vals := makeEncodedVals(-20, 20)
 
@@ -650,3 +674,22 @@ func BenchmarkNonsortingDecodeDecimal(b *testing.B) {
 		_, _ = DecodeNonsortingDecimal(vals[i%len(vals)], buf)
 	}
 }
+
+func BenchmarkNonsortingDecodeIntoDecimal(b *testing.B) {
+	rng, _ := randutil.NewTestRand()
+
+	vals := make([][]byte, 10000)
+	for i := range vals {
+		d := randDecimal(rng, -20, 20)
+		vals[i] = EncodeNonsortingDecimal(nil, d)
+	}
+
+	buf := make([]byte, 0, 100)
+	var dec apd.Decimal
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = DecodeIntoNonsortingDecimal(&dec, vals[i%len(vals)], buf)
+	}
+}
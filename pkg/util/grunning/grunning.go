@@ -0,0 +1,29 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package grunning is a library that's able to retrieve on-CPU running time
+// for individual goroutines. It relies on using a patched Go runtime that
+// maintains this information, and is only enabled when built with the
+// grunning build tag. When disabled, Time always returns zero.
+package grunning
+
+import "time"
+
+// Time returns the time spent by the current goroutine in the running state.
+func Time() time.Duration {
+	return time.Duration(grunningnanos())
+}
+
+// Supported returns true iff per-goroutine running time is available in this
+// build. We use a patched Go runtime for all platforms officially supported
+// for CRDB when built using the grunning build tag.
+func Supported() bool {
+	return supported()
+}
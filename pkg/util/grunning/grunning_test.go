@@ -0,0 +1,35 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package grunning_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/grunning"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/stretchr/testify/require"
+)
+
+var sink uint64
+
+func TestEnabled(t *testing.T) {
+	if !grunning.Supported() {
+		require.Zero(t, grunning.Time())
+		return
+	}
+	start := grunning.Time()
+	// Spin for a while so that the goroutine accumulates some running time.
+	for deadline := timeutil.Now().Add(10 * time.Millisecond); timeutil.Now().Before(deadline); {
+		sink++
+	}
+	require.Greater(t, grunning.Time(), start)
+}
@@ -463,6 +463,18 @@ func (mm *BytesMonitor) AllocBytes() int64 {
 	return mm.mu.curAllocated
 }
 
+// Capacity returns the maximum number of bytes that the monitor can allocate
+// as far as it knows: the pre-reserved budget if the monitor doesn't have a
+// pool (as is the case for the root monitors), and the local limit otherwise.
+func (mm *BytesMonitor) Capacity() int64 {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	if mm.mu.curBudget.mon == nil && mm.reserved.used < mm.limit {
+		return mm.reserved.used
+	}
+	return mm.limit
+}
+
 // SetMetrics sets the metric objects for the monitor.
 func (mm *BytesMonitor) SetMetrics(curCount *metric.Gauge, maxHist *metric.Histogram) {
 	mm.mu.Lock()
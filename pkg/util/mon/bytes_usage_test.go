@@ -367,6 +367,27 @@ func TestMemoryAllocationEdgeCases(t *testing.T) {
 	m.Stop(ctx)
 }
 
+func TestBytesMonitorCapacity(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	root := NewMonitor("root", MemoryResource,
+		nil /* curCount */, nil /* maxHist */, 1 /* increment */, 1000 /* noteworthy */, st)
+	root.Start(ctx, nil, MakeStandaloneBudget(100))
+	defer root.Stop(ctx)
+	if c := root.Capacity(); c != 100 {
+		t.Fatalf("expected capacity 100, got %d", c)
+	}
+
+	limited := NewMonitorInheritWithLimit("limited", 10 /* limit */, root)
+	limited.Start(ctx, root, BoundAccount{})
+	defer limited.Stop(ctx)
+	if c := limited.Capacity(); c != 10 {
+		t.Fatalf("expected capacity 10, got %d", c)
+	}
+}
+
 func TestMultiSharedGauge(t *testing.T) {
 	ctx := context.Background()
 	resourceGauge := metric.NewGauge(metric.Metadata{})
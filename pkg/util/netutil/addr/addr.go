@@ -81,3 +81,39 @@ func (a addrSetter) Set(v string) error {
 	*a.port = port
 	return nil
 }
+
+type addrListSetter struct {
+	addrSetter
+	others *[]string
+}
+
+// NewAddrListSetter is like NewAddrSetter, except that the flag also
+// accepts a comma-separated list of addresses. The first address is
+// stored in the address/port configuration option pair, and the
+// following ones, which may omit their port number, in otherAddrs.
+func NewAddrListSetter(hostOption, portOption *string, otherAddrs *[]string) pflag.Value {
+	return &addrListSetter{addrSetter: addrSetter{addr: hostOption, port: portOption}, others: otherAddrs}
+}
+
+// String implements the pflag.Value interface.
+func (a addrListSetter) String() string {
+	return strings.Join(append([]string{a.addrSetter.String()}, *a.others...), ",")
+}
+
+// Type implements the pflag.Value interface.
+func (a addrListSetter) Type() string { return "<addr/host>[:<port>][,...]" }
+
+// Set implements the pflag.Value interface.
+func (a addrListSetter) Set(v string) error {
+	addrs := strings.Split(v, ",")
+	for _, other := range addrs[1:] {
+		if _, _, err := SplitHostPort(other, ""); err != nil {
+			return err
+		}
+	}
+	if err := a.addrSetter.Set(addrs[0]); err != nil {
+		return err
+	}
+	*a.others = addrs[1:]
+	return nil
+}
@@ -56,3 +56,30 @@ func TestSplitHostPort(t *testing.T) {
 		})
 	}
 }
+
+func TestAddrListSetter(t *testing.T) {
+	host, port := "localhost", "26257"
+	var others []string
+	v := addr.NewAddrListSetter(&host, &port, &others)
+
+	if err := v.Set("a:123,b,[::1]:456"); err != nil {
+		t.Fatal(err)
+	}
+	if host != "a" || port != "123" {
+		t.Errorf("expected a:123, got %s:%s", host, port)
+	}
+	if expected := "a:123,b,[::1]:456"; v.String() != expected {
+		t.Errorf("expected %q, got %q", expected, v.String())
+	}
+
+	if err := v.Set("c"); err != nil {
+		t.Fatal(err)
+	}
+	if host != "c" || port != "123" || len(others) != 0 {
+		t.Errorf("expected c:123 without other addresses, got %s:%s %v", host, port, others)
+	}
+
+	if err := v.Set("d,::1"); !testutils.IsError(err, "invalid address format") {
+		t.Errorf("expected invalid address error, got %v", err)
+	}
+}
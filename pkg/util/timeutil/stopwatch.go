@@ -13,6 +13,7 @@ package timeutil
 import (
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/util/grunning"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 )
 
@@ -32,9 +33,23 @@ type StopWatch struct {
 		// timeSource is the source of time used by the stop watch. It is always
 		// timeutil.Now except for tests.
 		timeSource func() time.Time
+		// cpuStopWatch, if set, additionally tracks the CPU time spent by the
+		// goroutine between Starts and Stops.
+		cpuStopWatch *cpuStopWatch
 	}
 }
 
+// cpuStopWatch tracks the CPU time of the goroutine that starts and stops the
+// stop watch. Note that the CPU time is only tracked when supported by the Go
+// runtime (see grunning.Supported()).
+type cpuStopWatch struct {
+	// startedAt is the CPU time of the goroutine when the stop watch was
+	// started.
+	startedAt time.Duration
+	// elapsed is the total CPU time measured between all Starts and Stops.
+	elapsed time.Duration
+}
+
 // NewStopWatch creates a new StopWatch.
 func NewStopWatch() *StopWatch {
 	return newStopWatch(Now)
@@ -46,6 +61,15 @@ func NewTestStopWatch(timeSource func() time.Time) *StopWatch {
 	return newStopWatch(timeSource)
 }
 
+// NewStopWatchWithCPU creates a new StopWatch that also tracks the CPU time
+// spent between Starts and Stops. Start and Stop must be called from the same
+// goroutine in order for the CPU time to be accurate.
+func NewStopWatchWithCPU() *StopWatch {
+	w := newStopWatch(Now)
+	w.mu.cpuStopWatch = &cpuStopWatch{}
+	return w
+}
+
 func newStopWatch(timeSource func() time.Time) *StopWatch {
 	w := &StopWatch{}
 	w.mu.timeSource = timeSource
@@ -59,6 +83,9 @@ func (w *StopWatch) Start() {
 	if !w.mu.started {
 		w.mu.started = true
 		w.mu.startedAt = w.mu.timeSource()
+		if w.mu.cpuStopWatch != nil {
+			w.mu.cpuStopWatch.startedAt = grunning.Time()
+		}
 	}
 }
 
@@ -71,6 +98,9 @@ func (w *StopWatch) Stop() {
 	if w.mu.started {
 		w.mu.started = false
 		w.mu.elapsed += w.mu.timeSource().Sub(w.mu.startedAt)
+		if w.mu.cpuStopWatch != nil {
+			w.mu.cpuStopWatch.elapsed += grunning.Time() - w.mu.cpuStopWatch.startedAt
+		}
 	}
 }
 
@@ -81,6 +111,18 @@ func (w *StopWatch) Elapsed() time.Duration {
 	return w.mu.elapsed
 }
 
+// ElapsedCPU returns the total CPU time measured by the stop watch so far. It
+// is zero if the stop watch doesn't track the CPU time or if the CPU time isn't
+// supported by the Go runtime.
+func (w *StopWatch) ElapsedCPU() time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.mu.cpuStopWatch == nil {
+		return 0
+	}
+	return w.mu.cpuStopWatch.elapsed
+}
+
 // TestTimeSource is a source of time that remembers when it was created (in
 // terms of the real time) and returns the time based on its creation time and
 // the number of "advances" it has had. It is used for testing only.
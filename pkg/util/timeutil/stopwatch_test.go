@@ -16,6 +16,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/util/grunning"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
@@ -120,3 +121,24 @@ func TestStopWatchConcurrentUsage(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+// TestStopWatchCPU makes sure that the stop watch only tracks the CPU time when
+// requested and supported.
+func TestStopWatchCPU(t *testing.T) {
+	w := timeutil.NewStopWatch()
+	w.Start()
+	w.Stop()
+	require.Zero(t, w.ElapsedCPU())
+
+	w = timeutil.NewStopWatchWithCPU()
+	w.Start()
+	for deadline := timeutil.Now().Add(10 * time.Millisecond); timeutil.Now().Before(deadline); {
+	}
+	w.Stop()
+	if grunning.Supported() {
+		require.Greater(t, w.ElapsedCPU(), time.Duration(0))
+		require.LessOrEqual(t, w.ElapsedCPU(), w.Elapsed())
+	} else {
+		require.Zero(t, w.ElapsedCPU())
+	}
+}
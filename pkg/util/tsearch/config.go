@@ -0,0 +1,158 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// DefaultConfig is the name of the text search configuration that is used
+// when none is specified.
+const DefaultConfig = "simple"
+
+// ValidateConfig returns an error if the given text search configuration
+// doesn't exist. The only supported configuration is simple, which splits the
+// text into words made of letters and digits and lowercases them, without
+// stemming or stop words.
+func ValidateConfig(config string) error {
+	switch strings.ToLower(config) {
+	case "simple", "pg_catalog.simple":
+		return nil
+	}
+	return pgerror.Newf(pgcode.UndefinedObject,
+		"text search configuration %q does not exist", config)
+}
+
+// tokenize splits the given text into the normalized lexemes of the simple
+// text search configuration.
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	res := words[:0]
+	for _, w := range words {
+		w = strings.ToLower(w)
+		if len(w) > maxTSLexemeLen {
+			// Like Postgres, ignore the words that are too long to be
+			// lexemes.
+			continue
+		}
+		res = append(res, w)
+	}
+	return res
+}
+
+// DocumentToTSVector converts the given document into a tsvector using the
+// given text search configuration. The position of each lexeme is the
+// position of the word in the document.
+func DocumentToTSVector(config string, document string) (TSVector, error) {
+	if err := ValidateConfig(config); err != nil {
+		return nil, err
+	}
+	words := tokenize(document)
+	v := make(TSVector, len(words))
+	for i, w := range words {
+		pos := i + 1
+		if pos > maxTSPosition {
+			pos = maxTSPosition
+		}
+		v[i] = tsTerm{lexeme: w, positions: []tsPosition{{position: uint16(pos)}}}
+	}
+	return normalizeTSVector(v), nil
+}
+
+// ToTSQuery parses the given tsquery text like ParseTSQuery, and normalizes
+// its lexemes using the given text search configuration. Lexemes that are
+// normalized into multiple lexemes are replaced by these lexemes combined with
+// followed by operators, and lexemes that are normalized into no lexemes are
+// removed from the query.
+func ToTSQuery(config string, input string) (TSQuery, error) {
+	if err := ValidateConfig(config); err != nil {
+		return TSQuery{}, err
+	}
+	q, err := ParseTSQuery(input)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	return TSQuery{root: normalizeTSNode(q.root)}, nil
+}
+
+// normalizeTSNode normalizes the lexemes of the given tsquery node, and
+// returns nil if the node doesn't contain lexemes anymore.
+func normalizeTSNode(n *tsNode) *tsNode {
+	if n == nil {
+		return nil
+	}
+	switch n.op {
+	case lexemeOp:
+		var res *tsNode
+		for _, w := range tokenize(n.lexeme) {
+			term := &tsNode{op: lexemeOp, lexeme: w, prefix: n.prefix, weights: n.weights}
+			if res == nil {
+				res = term
+			} else {
+				res = &tsNode{op: followedByOp, distance: 1, left: res, right: term}
+			}
+		}
+		return res
+	case notOp:
+		if n.left = normalizeTSNode(n.left); n.left == nil {
+			return nil
+		}
+		return n
+	default:
+		n.left, n.right = normalizeTSNode(n.left), normalizeTSNode(n.right)
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		return n
+	}
+}
+
+// PlainToTSQuery converts the given text into a tsquery that matches the
+// documents containing all of its words, using the given text search
+// configuration. Operators in the text are ignored.
+func PlainToTSQuery(config string, text string) (TSQuery, error) {
+	return wordsToTSQuery(config, text, andOp)
+}
+
+// PhraseToTSQuery converts the given text into a tsquery that matches the
+// documents containing its words in sequence, using the given text search
+// configuration. Operators in the text are ignored.
+func PhraseToTSQuery(config string, text string) (TSQuery, error) {
+	return wordsToTSQuery(config, text, followedByOp)
+}
+
+func wordsToTSQuery(config string, text string, op tsOperator) (TSQuery, error) {
+	if err := ValidateConfig(config); err != nil {
+		return TSQuery{}, err
+	}
+	var root *tsNode
+	for _, w := range tokenize(text) {
+		term := &tsNode{op: lexemeOp, lexeme: w}
+		if root == nil {
+			root = term
+		} else {
+			root = &tsNode{op: op, left: root, right: term}
+			if op == followedByOp {
+				root.distance = 1
+			}
+		}
+	}
+	return TSQuery{root: root}, nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/keysbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// EncodeTSVector appends the binary encoding of the tsvector to appendTo. Each
+// lexeme is encoded as its length followed by its bytes, followed by the
+// number of its positions and the positions, which are each encoded along
+// with their weight in the two low bits.
+func EncodeTSVector(appendTo []byte, v TSVector) []byte {
+	appendTo = encoding.EncodeUvarintAscending(appendTo, uint64(len(v)))
+	for i := range v {
+		appendTo = encoding.EncodeUvarintAscending(appendTo, uint64(len(v[i].lexeme)))
+		appendTo = append(appendTo, v[i].lexeme...)
+		appendTo = encoding.EncodeUvarintAscending(appendTo, uint64(len(v[i].positions)))
+		for _, p := range v[i].positions {
+			appendTo = encoding.EncodeUvarintAscending(appendTo, uint64(p.position)<<2|uint64(p.weight))
+		}
+	}
+	return appendTo
+}
+
+// DecodeTSVector decodes a tsvector encoded with EncodeTSVector.
+func DecodeTSVector(b []byte) (TSVector, error) {
+	b, n, err := encoding.DecodeUvarintAscending(b)
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(b)) {
+		return nil, errors.Errorf("invalid tsvector encoding: %d lexemes", n)
+	}
+	v := make(TSVector, n)
+	for i := range v {
+		var l uint64
+		if b, l, err = encoding.DecodeUvarintAscending(b); err != nil {
+			return nil, err
+		}
+		if l > uint64(len(b)) {
+			return nil, errors.Errorf("invalid tsvector encoding: lexeme of length %d", l)
+		}
+		v[i].lexeme, b = string(b[:l]), b[l:]
+		if b, l, err = encoding.DecodeUvarintAscending(b); err != nil {
+			return nil, err
+		}
+		if l > maxTSPositions {
+			return nil, errors.Errorf("invalid tsvector encoding: %d positions", l)
+		}
+		if l > 0 {
+			v[i].positions = make([]tsPosition, l)
+		}
+		for j := range v[i].positions {
+			var p uint64
+			if b, p, err = encoding.DecodeUvarintAscending(b); err != nil {
+				return nil, err
+			}
+			v[i].positions[j] = tsPosition{position: uint16(p >> 2), weight: tsWeight(p & 3)}
+		}
+	}
+	if len(b) > 0 {
+		return nil, errors.Errorf("invalid tsvector encoding: %d trailing bytes", len(b))
+	}
+	return v, nil
+}
+
+// EncodeTSQuery appends the encoding of the tsquery to appendTo. The tsquery is
+// encoded as its textual representation.
+func EncodeTSQuery(appendTo []byte, q TSQuery) []byte {
+	return append(appendTo, q.String()...)
+}
+
+// DecodeTSQuery decodes a tsquery encoded with EncodeTSQuery.
+func DecodeTSQuery(b []byte) (TSQuery, error) {
+	return ParseTSQuery(string(b))
+}
+
+// EncodeInvertedIndexKeys returns the inverted index keys of the tsvector,
+// which are made of inKey followed by one of the lexemes of the tsvector.
+// Positions and weights are not stored in the index.
+func EncodeInvertedIndexKeys(inKey []byte, v TSVector) ([][]byte, error) {
+	keys := make([][]byte, len(v))
+	for i := range v {
+		key := make([]byte, len(inKey), len(inKey)+len(v[i].lexeme)+3)
+		copy(key, inKey)
+		keys[i] = encoding.EncodeStringAscending(key, v[i].lexeme)
+	}
+	return keys, nil
+}
+
+// errQueryNotIndexable is returned by GetInvertedExpr for the tsqueries that
+// can't be evaluated with an inverted index.
+var errQueryNotIndexable = errors.New("tsquery matches documents that don't contain any of its lexemes")
+
+// GetInvertedExpr returns the inverted expression that finds the rows of an
+// inverted index on a tsvector column that may match the tsquery. It returns
+// an error if the tsquery can match documents that don't contain any of its
+// lexemes, such as !'cat', since these documents can't be found in the index.
+func (q TSQuery) GetInvertedExpr() (inverted.Expression, error) {
+	if q.root == nil {
+		// The empty tsquery matches nothing.
+		return &inverted.SpanExpression{Tight: true, Unique: true}, nil
+	}
+	expr := q.root.invertedExpr()
+	if _, ok := expr.(inverted.NonInvertedColExpression); ok {
+		return nil, errQueryNotIndexable
+	}
+	return expr, nil
+}
+
+func (n *tsNode) invertedExpr() inverted.Expression {
+	switch n.op {
+	case lexemeOp:
+		key := encoding.EncodeStringAscending(nil, n.lexeme)
+		var span inverted.Span
+		if n.prefix {
+			// Remove the terminator of the encoded lexeme so that the span
+			// contains all the lexemes that start with it.
+			key = key[:len(key)-2]
+			span = inverted.Span{Start: key, End: keysbase.PrefixEnd(key)}
+		} else {
+			span = inverted.MakeSingleValSpan(key)
+		}
+		// The index doesn't store the weights, so the matches of lexemes that
+		// are restricted to some weights must be rechecked.
+		return inverted.ExprForSpan(span, n.weights == 0 /* tight */)
+	case andOp:
+		return inverted.And(n.left.invertedExpr(), n.right.invertedExpr())
+	case orOp:
+		return inverted.Or(n.left.invertedExpr(), n.right.invertedExpr())
+	case followedByOp:
+		// The index doesn't store the positions, so the index can only find the
+		// documents that contain both operands.
+		expr := inverted.And(n.left.invertedExpr(), n.right.invertedExpr())
+		expr.SetNotTight()
+		return expr
+	default:
+		// The documents that match a negation may not contain any lexeme, so
+		// the index can't find them.
+		return inverted.NonInvertedColExpression{}
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import "sort"
+
+// EvalTSQuery returns true if the tsquery matches the tsvector, which is the
+// result of the tsvector @@ tsquery operator. The empty tsquery matches
+// nothing.
+func EvalTSQuery(q TSQuery, v TSVector) bool {
+	if q.root == nil {
+		return false
+	}
+	return q.root.matches(v)
+}
+
+// matches returns true if the tsquery node matches the tsvector.
+func (n *tsNode) matches(v TSVector) bool {
+	switch n.op {
+	case lexemeOp:
+		for _, term := range v.find(n.lexeme, n.prefix) {
+			if len(term.positions) == 0 {
+				// Lexemes without positions are considered to have weight D.
+				if n.weights.contains(weightD) {
+					return true
+				}
+				continue
+			}
+			for _, p := range term.positions {
+				if n.weights.contains(p.weight) {
+					return true
+				}
+			}
+		}
+		return false
+	case andOp:
+		return n.left.matches(v) && n.right.matches(v)
+	case orOp:
+		return n.left.matches(v) || n.right.matches(v)
+	case notOp:
+		return !n.left.matches(v)
+	case followedByOp:
+		s, ok := n.positions(v)
+		if !ok {
+			// Some lexemes have no positions, so the distance between them is
+			// unknown. Like Postgres, fall back to checking that both operands
+			// match.
+			return n.left.matches(v) && n.right.matches(v)
+		}
+		return s.negated || len(s.positions) > 0
+	}
+	return false
+}
+
+// positionSet is a set of positions in a document. If negated is true, the set
+// contains all the positions except the listed ones. The positions are sorted
+// and distinct.
+type positionSet struct {
+	positions []int
+	negated   bool
+	// width is the distance between the first and the last lexemes of the
+	// matched phrases, which end at the listed positions.
+	width int
+}
+
+// positions returns the set of positions at which the tsquery node matches the
+// tsvector. For followed by operators, the position of a match is the position
+// of the last lexeme of the phrase. It returns ok=false if some matching
+// lexemes of the tsvector have no positions.
+func (n *tsNode) positions(v TSVector) (_ positionSet, ok bool) {
+	switch n.op {
+	case lexemeOp:
+		var s positionSet
+		for _, term := range v.find(n.lexeme, n.prefix) {
+			if len(term.positions) == 0 {
+				return positionSet{}, false
+			}
+			for _, p := range term.positions {
+				if n.weights.contains(p.weight) {
+					s.positions = append(s.positions, int(p.position))
+				}
+			}
+		}
+		s.positions = sortAndDedup(s.positions)
+		return s, true
+	case notOp:
+		s, ok := n.left.positions(v)
+		s.negated = !s.negated
+		return s, ok
+	}
+
+	left, ok := n.left.positions(v)
+	if !ok {
+		return positionSet{}, false
+	}
+	right, ok := n.right.positions(v)
+	if !ok {
+		return positionSet{}, false
+	}
+	var s positionSet
+	switch n.op {
+	case andOp:
+		// Both operands must match at the same position.
+		s = intersectPositions(left, right)
+	case orOp:
+		s = unionPositions(left, right)
+	case followedByOp:
+		// The left operand must end at the given distance before the start of
+		// the phrase matched by the right operand.
+		left.positions = shiftPositions(left.positions, int(n.distance)+right.width)
+		s = intersectPositions(left, right)
+		s.width = left.width + int(n.distance) + right.width
+		return s, true
+	default:
+		return positionSet{}, false
+	}
+	s.width = left.width
+	if right.width > s.width {
+		s.width = right.width
+	}
+	return s, true
+}
+
+func intersectPositions(a, b positionSet) positionSet {
+	switch {
+	case !a.negated && !b.negated:
+		return positionSet{positions: intersect(a.positions, b.positions)}
+	case !a.negated && b.negated:
+		return positionSet{positions: subtract(a.positions, b.positions)}
+	case a.negated && !b.negated:
+		return positionSet{positions: subtract(b.positions, a.positions)}
+	default:
+		return positionSet{positions: union(a.positions, b.positions), negated: true}
+	}
+}
+
+func unionPositions(a, b positionSet) positionSet {
+	switch {
+	case !a.negated && !b.negated:
+		return positionSet{positions: union(a.positions, b.positions)}
+	case !a.negated && b.negated:
+		return positionSet{positions: subtract(b.positions, a.positions), negated: true}
+	case a.negated && !b.negated:
+		return positionSet{positions: subtract(a.positions, b.positions), negated: true}
+	default:
+		return positionSet{positions: intersect(a.positions, b.positions), negated: true}
+	}
+}
+
+func shiftPositions(positions []int, distance int) []int {
+	res := make([]int, len(positions))
+	for i, p := range positions {
+		res[i] = p + distance
+	}
+	return res
+}
+
+func sortAndDedup(positions []int) []int {
+	sort.Ints(positions)
+	res := positions[:0]
+	for i, p := range positions {
+		if i == 0 || p != positions[i-1] {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+func intersect(a, b []int) []int {
+	var res []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			res = append(res, a[i])
+			i++
+			j++
+		}
+	}
+	return res
+}
+
+func union(a, b []int) []int {
+	res := make([]int, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			res = append(res, a[i])
+			i++
+		case a[i] > b[j]:
+			res = append(res, b[j])
+			j++
+		default:
+			res = append(res, a[i])
+			i++
+			j++
+		}
+	}
+	res = append(res, a[i:]...)
+	return append(res, b[j:]...)
+}
+
+// subtract returns the positions of a that are not in b.
+func subtract(a, b []int) []int {
+	var res []int
+	j := 0
+	for _, p := range a {
+		for j < len(b) && b[j] < p {
+			j++
+		}
+		if j < len(b) && b[j] == p {
+			continue
+		}
+		res = append(res, p)
+	}
+	return res
+}
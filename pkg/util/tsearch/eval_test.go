@@ -0,0 +1,195 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalTSQuery(t *testing.T) {
+	const doc = `a:1 fat:2A cat:3 sat:4 on:5 mat:7B rat:12,14C`
+	testCases := []struct {
+		vector   string
+		query    string
+		expected bool
+	}{
+		{vector: doc, query: ``, expected: false},
+		{vector: ``, query: `cat`, expected: false},
+		{vector: ``, query: `!cat`, expected: true},
+		{vector: doc, query: `cat`, expected: true},
+		{vector: doc, query: `dog`, expected: false},
+		{vector: doc, query: `cat & rat`, expected: true},
+		{vector: doc, query: `cat & dog`, expected: false},
+		{vector: doc, query: `cat | dog`, expected: true},
+		{vector: doc, query: `!cat`, expected: false},
+		{vector: doc, query: `!dog`, expected: true},
+		{vector: doc, query: `cat & !dog`, expected: true},
+		{vector: doc, query: `ca:*`, expected: true},
+		{vector: doc, query: `c:* & m:*`, expected: true},
+		{vector: doc, query: `do:*`, expected: false},
+		{vector: doc, query: `fat:A`, expected: true},
+		{vector: doc, query: `fat:B`, expected: false},
+		{vector: doc, query: `rat:C`, expected: true},
+		{vector: doc, query: `rat:D`, expected: true},
+		{vector: doc, query: `rat:AB`, expected: false},
+		{vector: doc, query: `m:*B`, expected: true},
+
+		{vector: doc, query: `fat <-> cat`, expected: true},
+		{vector: doc, query: `cat <-> fat`, expected: false},
+		{vector: doc, query: `fat <2> sat`, expected: true},
+		{vector: doc, query: `fat <-> sat`, expected: false},
+		{vector: doc, query: `fat <-> cat <-> sat`, expected: true},
+		{vector: doc, query: `fat <-> (cat <-> sat)`, expected: true},
+		{vector: doc, query: `a <-> (cat <-> sat)`, expected: false},
+		{vector: doc, query: `a <2> (cat <-> sat)`, expected: true},
+		{vector: doc, query: `(a <-> fat) <-> (cat <-> sat)`, expected: true},
+		{vector: doc, query: `fat <-> (cat | dog)`, expected: true},
+		{vector: doc, query: `fat <-> !sat`, expected: true},
+		{vector: doc, query: `fat <-> !cat`, expected: false},
+		{vector: doc, query: `a <-> (cat & fat)`, expected: false},
+		{vector: doc, query: `rat <2> rat`, expected: true},
+		{vector: doc, query: `sat <0> sat`, expected: true},
+		{vector: doc, query: `fat:B <-> cat`, expected: false},
+
+		// Lexemes without positions fall back to checking that both operands
+		// match.
+		{vector: `fat cat`, query: `cat <-> fat`, expected: true},
+		{vector: `fat cat`, query: `cat <-> dog`, expected: false},
+		// Lexemes without positions have weight D.
+		{vector: `fat cat`, query: `cat:D`, expected: true},
+		{vector: `fat cat`, query: `cat:A`, expected: false},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s @@ %s", tc.vector, tc.query), func(t *testing.T) {
+			v, err := ParseTSVector(tc.vector)
+			require.NoError(t, err)
+			q, err := ParseTSQuery(tc.query)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, EvalTSQuery(q, v))
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	testCases := []struct {
+		vector        string
+		query         string
+		weights       []float32
+		normalization int
+		expected      float32
+	}{
+		{vector: `brown fox quick the`, query: ``, expected: 0},
+		{vector: `brown:3 fox:4 quick:2 the:1`, query: `dog`, expected: 0},
+		{vector: `brown:3 fox:4 quick:2 the:1`, query: `fox`, expected: 0.0607927},
+		{vector: `brown:3 fox:4A quick:2 the:1`, query: `fox`, expected: 0.607927},
+		{vector: `brown:3 fox:4 quick:2 the:1`, query: `fox | brown`, expected: 0.0607927},
+		{vector: `brown:3 fox:4 quick:2 the:1`, query: `fox & brown`, expected: 0.0991032},
+		{vector: `brown:3 fox:4 quick:2 the:1`, query: `fox & the`, expected: 0.0973585},
+		{vector: `brown:3 fox:4 quick:2 the:1`, query: `fox`, normalization: rankNormLength,
+			expected: 0.0151982},
+		{vector: `brown:3 fox:4 quick:2 the:1`, query: `fox`, normalization: rankNormUniq | rankNormRDivRPlus1,
+			expected: 0.0149710},
+		{vector: `brown:3 fox:4 quick:2 the:1`, query: `fox`, weights: []float32{1, 1, 1, 1},
+			expected: 0.607927},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s @@ %s", tc.vector, tc.query), func(t *testing.T) {
+			v, err := ParseTSVector(tc.vector)
+			require.NoError(t, err)
+			q, err := ParseTSQuery(tc.query)
+			require.NoError(t, err)
+			weights := tc.weights
+			if weights == nil {
+				weights = DefaultRankWeights
+			}
+			require.InDelta(t, tc.expected, Rank(weights, v, q, tc.normalization), 1e-6)
+		})
+	}
+}
+
+func TestValidateRankWeights(t *testing.T) {
+	w, err := ValidateRankWeights([]float32{-1, 0.5, -0.1, 1, 2})
+	require.NoError(t, err)
+	require.Equal(t, []float32{0.1, 0.5, 0.4, 1}, w)
+
+	_, err = ValidateRankWeights([]float32{0.1, 0.2, 0.3})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "array of weight is too short")
+
+	_, err = ValidateRankWeights([]float32{0.1, 0.2, 0.3, 1.5})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "weight out of range")
+}
+
+func TestGetInvertedExpr(t *testing.T) {
+	docs := []string{
+		`a:1 cat:2`,
+		`cats:1 dog:2A`,
+		`dog:1 cat:2`,
+		`bird`,
+	}
+	keys := make([][][]byte, len(docs))
+	for i, doc := range docs {
+		v, err := ParseTSVector(doc)
+		require.NoError(t, err)
+		keys[i], err = EncodeInvertedIndexKeys(nil, v)
+		require.NoError(t, err)
+		require.Len(t, keys[i], len(v))
+	}
+
+	testCases := []struct {
+		query string
+		// expected are the indexes of the documents found by the inverted
+		// expression, or nil if the query can't use the index.
+		expected []int
+		tight    bool
+	}{
+		{query: ``, expected: []int{}, tight: true},
+		{query: `cat`, expected: []int{0, 2}, tight: true},
+		{query: `cat:*`, expected: []int{0, 1, 2}, tight: true},
+		{query: `cat & dog`, expected: []int{2}, tight: true},
+		{query: `cat | bird`, expected: []int{0, 2, 3}, tight: true},
+		{query: `fish`, expected: []int{}, tight: true},
+		{query: `dog:A`, expected: []int{1, 2}, tight: false},
+		{query: `cat <-> dog`, expected: []int{2}, tight: false},
+		{query: `cat & !dog`, expected: []int{0, 2}, tight: false},
+		{query: `!dog`},
+		{query: `cat | !dog`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := ParseTSQuery(tc.query)
+			require.NoError(t, err)
+			expr, err := q.GetInvertedExpr()
+			if tc.expected == nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.tight, expr.IsTight())
+			spanExpr, ok := expr.(*inverted.SpanExpression)
+			require.True(t, ok)
+			found := []int{}
+			for i := range docs {
+				ok, err := spanExpr.ContainsKeys(keys[i])
+				require.NoError(t, err)
+				if ok {
+					found = append(found, i)
+				}
+			}
+			require.Equal(t, tc.expected, found)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// lexer splits the textual representation of a tsvector or a tsquery into
+// tokens.
+type lexer struct {
+	input string
+	pos   int
+	// kind is either "tsvector" or "tsquery". In a tsquery, unquoted lexemes
+	// also end at operators.
+	kind string
+}
+
+func (l *lexer) done() bool {
+	return l.pos >= len(l.input)
+}
+
+func (l *lexer) peek() byte {
+	return l.input[l.pos]
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+func isQueryOperator(c byte) bool {
+	switch c {
+	case '&', '|', '!', '(', ')', '<':
+		return true
+	}
+	return false
+}
+
+func (l *lexer) skipSpaces() {
+	for !l.done() && isSpace(l.peek()) {
+		l.pos++
+	}
+}
+
+func (l *lexer) syntaxError() error {
+	return pgerror.Newf(pgcode.Syntax, "syntax error in %s: %q", l.kind, l.input)
+}
+
+// lexeme returns the next lexeme, which is either quoted with single quotes or
+// ends at the first whitespace or colon (or operator, in a tsquery). Inside a
+// quoted lexeme, two consecutive quotes stand for one quote. In both cases, a
+// backslash escapes the next character.
+func (l *lexer) lexeme() (string, error) {
+	var buf strings.Builder
+	if l.peek() == '\'' {
+		l.pos++
+		for {
+			if l.done() {
+				return "", l.syntaxError()
+			}
+			c := l.peek()
+			if c == '\\' {
+				if l.pos+1 >= len(l.input) {
+					return "", l.syntaxError()
+				}
+				buf.WriteByte(l.input[l.pos+1])
+				l.pos += 2
+				continue
+			}
+			if c == '\'' {
+				if l.pos+1 < len(l.input) && l.input[l.pos+1] == '\'' {
+					buf.WriteByte('\'')
+					l.pos += 2
+					continue
+				}
+				l.pos++
+				break
+			}
+			buf.WriteByte(c)
+			l.pos++
+		}
+	} else {
+		for !l.done() {
+			c := l.peek()
+			if isSpace(c) || c == ':' || (l.kind == "tsquery" && isQueryOperator(c)) {
+				break
+			}
+			if c == '\'' {
+				return "", l.syntaxError()
+			}
+			if c == '\\' {
+				if l.pos+1 >= len(l.input) {
+					return "", l.syntaxError()
+				}
+				l.pos++
+				c = l.peek()
+			}
+			buf.WriteByte(c)
+			l.pos++
+		}
+	}
+	if buf.Len() == 0 {
+		return "", l.syntaxError()
+	}
+	if buf.Len() > maxTSLexemeLen {
+		return "", errLexemeTooLong(buf.Len())
+	}
+	return buf.String(), nil
+}
+
+// positions returns the comma-separated list of positions that follows a
+// lexeme in a tsvector.
+func (l *lexer) positions() ([]tsPosition, error) {
+	var positions []tsPosition
+	for {
+		start := l.pos
+		for !l.done() && l.peek() >= '0' && l.peek() <= '9' {
+			l.pos++
+		}
+		if start == l.pos {
+			return nil, l.syntaxError()
+		}
+		n, err := strconv.Atoi(l.input[start:l.pos])
+		if err != nil || n > maxTSPosition {
+			// Like Postgres, silently reduce large positions to the maximum.
+			n = maxTSPosition
+		}
+		if n == 0 {
+			return nil, pgerror.Newf(pgcode.Syntax, "wrong position info in tsvector: %q", l.input)
+		}
+		p := tsPosition{position: uint16(n)}
+		if !l.done() {
+			if w, ok := parseWeight(l.peek()); ok {
+				p.weight = w
+				l.pos++
+			} else if l.peek() == '*' {
+				// Postgres accepts but ignores stars after positions.
+				l.pos++
+			}
+		}
+		positions = append(positions, p)
+		if l.done() || l.peek() != ',' {
+			return positions, nil
+		}
+		l.pos++
+	}
+}
+
+func errLexemeTooLong(n int) error {
+	return pgerror.Newf(pgcode.ProgramLimitExceeded,
+		"word is too long (%d bytes, max %d bytes)", n, maxTSLexemeLen)
+}
+
+func errUnrecognizedWeight(c byte) error {
+	return pgerror.Newf(pgcode.InvalidParameterValue, "unrecognized weight: %q", c)
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// randomLexeme returns a random short lexeme made of lowercase letters, so that
+// random tsvectors and tsqueries often have lexemes in common.
+func randomLexeme(rng *rand.Rand) string {
+	b := make([]byte, 1+rng.Intn(3))
+	for i := range b {
+		b[i] = byte('a' + rng.Intn(6))
+	}
+	return string(b)
+}
+
+// RandomTSVector returns a random tsvector for testing.
+func RandomTSVector(rng *rand.Rand) TSVector {
+	var buf strings.Builder
+	for i, n := 0, rng.Intn(10); i < n; i++ {
+		buf.WriteString(randomLexeme(rng))
+		for j, m := 0, rng.Intn(4); j < m; j++ {
+			if j == 0 {
+				buf.WriteByte(':')
+			} else {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(strconv.Itoa(1 + rng.Intn(100)))
+			buf.WriteString(tsWeight(rng.Intn(4)).String())
+		}
+		buf.WriteByte(' ')
+	}
+	v, err := ParseTSVector(buf.String())
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// RandomTSQuery returns a random tsquery for testing.
+func RandomTSQuery(rng *rand.Rand) TSQuery {
+	var buf strings.Builder
+	writeRandomTSQuery(rng, &buf, 3 /* depth */)
+	q, err := ParseTSQuery(buf.String())
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+func writeRandomTSQuery(rng *rand.Rand, buf *strings.Builder, depth int) {
+	if depth == 0 || rng.Intn(3) == 0 {
+		buf.WriteString(randomLexeme(rng))
+		if rng.Intn(4) == 0 {
+			buf.WriteString(":*")
+		}
+		return
+	}
+	switch rng.Intn(4) {
+	case 0:
+		buf.WriteString("!(")
+		writeRandomTSQuery(rng, buf, depth-1)
+		buf.WriteString(")")
+		return
+	case 1:
+		buf.WriteString("(")
+		writeRandomTSQuery(rng, buf, depth-1)
+		buf.WriteString(") & (")
+	case 2:
+		buf.WriteString("(")
+		writeRandomTSQuery(rng, buf, depth-1)
+		buf.WriteString(") | (")
+	default:
+		buf.WriteString("(")
+		writeRandomTSQuery(rng, buf, depth-1)
+		buf.WriteString(") <-> (")
+	}
+	writeRandomTSQuery(rng, buf, depth-1)
+	buf.WriteString(")")
+}
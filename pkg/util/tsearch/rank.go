@@ -0,0 +1,202 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// DefaultRankWeights are the weights of the D, C, B and A positions used by
+// Rank when none are specified.
+var DefaultRankWeights = []float32{0.1, 0.2, 0.4, 1.0}
+
+// The following flags can be combined in the normalization argument of Rank to
+// normalize the rank of a document by its length.
+const (
+	// rankNormLogLength divides the rank by 1 + the logarithm of the length of
+	// the document.
+	rankNormLogLength = 1 << iota
+	// rankNormLength divides the rank by the length of the document.
+	rankNormLength
+	// rankNormExtDist divides the rank by the mean harmonic distance between
+	// extents. It is only used by ts_rank_cd, which isn't supported.
+	rankNormExtDist
+	// rankNormUniq divides the rank by the number of distinct lexemes in the
+	// document.
+	rankNormUniq
+	// rankNormLogUniq divides the rank by 1 + the logarithm of the number of
+	// distinct lexemes in the document.
+	rankNormLogUniq
+	// rankNormRDivRPlus1 divides the rank by itself + 1.
+	rankNormRDivRPlus1
+)
+
+// ValidateRankWeights returns an error if the given weights can't be used by
+// Rank. The weights are the weights of the D, C, B and A positions, and must
+// be at most 1. Like in Postgres, negative weights are replaced by the default
+// weights.
+func ValidateRankWeights(weights []float32) ([]float32, error) {
+	if len(weights) < len(DefaultRankWeights) {
+		return nil, pgerror.New(pgcode.ArraySubscript, "array of weight is too short")
+	}
+	res := make([]float32, len(DefaultRankWeights))
+	for i := range res {
+		res[i] = weights[i]
+		if res[i] < 0 {
+			res[i] = DefaultRankWeights[i]
+		}
+		if res[i] > 1 {
+			return nil, pgerror.New(pgcode.InvalidParameterValue, "weight out of range")
+		}
+	}
+	return res, nil
+}
+
+// Rank returns a measure of how well the tsvector matches the tsquery, which
+// is the result of the ts_rank function. It is computed like in Postgres: the
+// more frequent the lexemes of the query are in the document, the higher the
+// rank, and if the query requires several lexemes, the closer they are in the
+// document, the higher the rank. The weights must have been validated with
+// ValidateRankWeights, and normalization is a combination of the rankNorm
+// flags.
+func Rank(weights []float32, v TSVector, q TSQuery, normalization int) float32 {
+	if len(v) == 0 || q.root == nil {
+		return 0
+	}
+	var res float32
+	if q.root.op == andOp || q.root.op == followedByOp {
+		res = rankAnd(weights, v, q)
+	} else {
+		res = rankOr(weights, v, q)
+	}
+	if res < 0 {
+		res = 1e-20
+	}
+
+	if normalization&rankNormLogLength != 0 {
+		res /= float32(math.Log(float64(v.positionCount()+1)) / math.Log(2.0))
+	}
+	if normalization&rankNormLength != 0 {
+		if n := v.positionCount(); n > 0 {
+			res /= float32(n)
+		}
+	}
+	if normalization&rankNormUniq != 0 {
+		res /= float32(len(v))
+	}
+	if normalization&rankNormLogUniq != 0 {
+		res /= float32(math.Log(float64(len(v)+1)) / math.Log(2.0))
+	}
+	if normalization&rankNormRDivRPlus1 != 0 {
+		res /= res + 1
+	}
+	return res
+}
+
+// nullPositions are the positions used by the ranking for the lexemes that
+// have no positions.
+var nullPositions = []tsPosition{{position: 0, weight: weightD}}
+
+// rankOr is the rank of a document for a query whose lexemes don't all have
+// to match. Each occurrence of a lexeme of the query in the document increases
+// the rank, with decreasing returns.
+func rankOr(weights []float32, v TSVector, q TSQuery) float32 {
+	var res float32
+	lexemes := q.lexemes()
+	for _, n := range lexemes {
+		for _, term := range v.find(n.lexeme, n.prefix) {
+			positions := term.positions
+			if len(positions) == 0 {
+				positions = nullPositions
+			}
+			var resj float32
+			wjm := float32(-1)
+			jm := 0
+			for j, p := range positions {
+				w := weights[p.weight]
+				resj += w / float32((j+1)*(j+1))
+				if w > wjm {
+					wjm = w
+					jm = j
+				}
+			}
+			// The limit of sum(1/i^2) is pi^2/6.
+			res += float32(float64(wjm+resj-wjm/float32((jm+1)*(jm+1))) / 1.64493406685)
+		}
+	}
+	if len(lexemes) > 0 {
+		res /= float32(len(lexemes))
+	}
+	return res
+}
+
+// rankAnd is the rank of a document for a query whose lexemes must all match.
+// The closer the lexemes of the query are in the document, the higher the
+// rank. It returns a negative rank if there are no pairs of lexemes of the
+// query in the document.
+func rankAnd(weights []float32, v TSVector, q TSQuery) float32 {
+	lexemes := q.lexemes()
+	if len(lexemes) < 2 {
+		return rankOr(weights, v, q)
+	}
+	// A position used for the lexemes that have no positions, which makes them
+	// as far as possible from the other lexemes.
+	farPositions := []tsPosition{{position: maxTSPosition, weight: weightD}}
+	res := float32(-1)
+	positions := make([][]tsPosition, len(lexemes))
+	noPositions := make([]bool, len(lexemes))
+	for i, n := range lexemes {
+		for _, term := range v.find(n.lexeme, n.prefix) {
+			positions[i], noPositions[i] = term.positions, len(term.positions) == 0
+			if noPositions[i] {
+				positions[i] = farPositions
+			}
+			for k := 0; k < i; k++ {
+				if positions[k] == nil {
+					continue
+				}
+				for _, pi := range positions[i] {
+					for _, pk := range positions[k] {
+						dist := int(pi.position) - int(pk.position)
+						if dist < 0 {
+							dist = -dist
+						}
+						if dist == 0 {
+							if !noPositions[i] && !noPositions[k] {
+								continue
+							}
+							dist = maxTSPosition + 1
+						}
+						curw := float32(math.Sqrt(float64(weights[pi.weight] * weights[pk.weight] * wordDistance(dist))))
+						if res < 0 {
+							res = curw
+						} else {
+							res = 1 - (1-res)*(1-curw)
+						}
+					}
+				}
+			}
+		}
+	}
+	return res
+}
+
+// wordDistance returns the factor applied to the rank of two lexemes that are
+// at the given distance in a document.
+func wordDistance(dist int) float32 {
+	if dist > 100 {
+		return 1e-30
+	}
+	return float32(1.0 / (1.005 + 0.05*math.Exp(float64(dist)/1.5-2)))
+}
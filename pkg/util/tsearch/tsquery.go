@@ -0,0 +1,351 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// tsOperator is an operator of a tsquery.
+type tsOperator uint8
+
+const (
+	// lexemeOp is the "operator" of the leaves of a tsquery, which are lexemes.
+	lexemeOp tsOperator = iota
+	// andOp matches the documents that match both of its operands.
+	andOp
+	// orOp matches the documents that match either of its operands.
+	orOp
+	// notOp matches the documents that don't match its operand.
+	notOp
+	// followedByOp matches the documents where its right operand appears at a
+	// given distance after its left operand. It is written <N>, or <-> when the
+	// distance is 1.
+	followedByOp
+)
+
+// priority returns the priority of the operator, which is used to decide
+// where parentheses are needed when formatting a tsquery.
+func (o tsOperator) priority() int {
+	switch o {
+	case orOp:
+		return 1
+	case andOp:
+		return 2
+	case followedByOp:
+		return 3
+	case notOp:
+		return 4
+	}
+	return 5
+}
+
+// maxFollowedByDistance is the largest distance of a followed by operator.
+const maxFollowedByDistance = 1 << 14
+
+// tsNode is a node of a tsquery.
+type tsNode struct {
+	op tsOperator
+
+	// The following fields are set for lexemes.
+	lexeme string
+	// prefix is true if the lexeme matches all the lexemes that start with it.
+	// It is written with a :* suffix.
+	prefix bool
+	// weights restricts the positions that the lexeme matches. It is written
+	// with a suffix like :AB.
+	weights tsWeightMask
+
+	// distance is set for followedByOp.
+	distance uint16
+
+	// left is set for all the operators, right for the binary operators.
+	left, right *tsNode
+}
+
+// TSQuery is a text search query, which is a boolean combination of lexemes
+// that is matched against tsvectors. The zero value is the empty query, which
+// matches nothing.
+type TSQuery struct {
+	root *tsNode
+}
+
+// String returns the textual representation of the tsquery, which is the same
+// as in Postgres, for example 'fat' & ( 'rat' | 'cat':*A ).
+func (q TSQuery) String() string {
+	if q.root == nil {
+		return ""
+	}
+	var buf strings.Builder
+	q.root.format(&buf, 0 /* parentPriority */, false /* rightOfFollowedBy */)
+	return buf.String()
+}
+
+func (n *tsNode) format(buf *strings.Builder, parentPriority int, rightOfFollowedBy bool) {
+	switch n.op {
+	case lexemeOp:
+		writeLexeme(buf, n.lexeme)
+		if n.prefix || n.weights != 0 {
+			buf.WriteByte(':')
+			if n.prefix {
+				buf.WriteByte('*')
+			}
+			buf.WriteString(n.weights.String())
+		}
+	case notOp:
+		buf.WriteByte('!')
+		n.left.format(buf, n.op.priority(), false /* rightOfFollowedBy */)
+	default:
+		priority := n.op.priority()
+		// The followed by operator isn't associative, so parentheses are needed
+		// when it is the right operand of another followed by operator.
+		parens := priority < parentPriority || (n.op == followedByOp && rightOfFollowedBy)
+		if parens {
+			buf.WriteString("( ")
+		}
+		n.left.format(buf, priority, false /* rightOfFollowedBy */)
+		switch n.op {
+		case andOp:
+			buf.WriteString(" & ")
+		case orOp:
+			buf.WriteString(" | ")
+		case followedByOp:
+			if n.distance == 1 {
+				buf.WriteString(" <-> ")
+			} else {
+				buf.WriteString(" <")
+				buf.WriteString(strconv.Itoa(int(n.distance)))
+				buf.WriteString("> ")
+			}
+		}
+		n.right.format(buf, priority, n.op == followedByOp)
+		if parens {
+			buf.WriteString(" )")
+		}
+	}
+}
+
+// ParseTSQuery parses the textual representation of a tsquery. The input is
+// made of lexemes, which are written like in a tsvector and can have a :*
+// suffix for prefix matching and weight letters to restrict the matched
+// positions, combined with the operators & (and), | (or), ! (not) and <->
+// (followed by) or <N> (followed by at distance N), and parentheses. Unlike
+// to_tsquery, no normalization is applied to the lexemes.
+func ParseTSQuery(input string) (TSQuery, error) {
+	p := tsQueryParser{l: lexer{input: input, kind: "tsquery"}}
+	p.l.skipSpaces()
+	if p.l.done() {
+		return TSQuery{}, nil
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return TSQuery{}, err
+	}
+	p.l.skipSpaces()
+	if !p.l.done() {
+		return TSQuery{}, p.l.syntaxError()
+	}
+	return TSQuery{root: root}, nil
+}
+
+// tsQueryParser is a recursive descent parser for tsqueries. From the lowest
+// to the highest priority, the operators are |, &, <-> and !.
+type tsQueryParser struct {
+	l lexer
+}
+
+// consume skips the whitespace and returns true if the input continues with
+// the given token, in which case the token is skipped too.
+func (p *tsQueryParser) consume(token string) bool {
+	p.l.skipSpaces()
+	if strings.HasPrefix(p.l.input[p.l.pos:], token) {
+		p.l.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *tsQueryParser) parseOr() (*tsNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume("|") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &tsNode{op: orOp, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *tsQueryParser) parseAnd() (*tsNode, error) {
+	left, err := p.parseFollowedBy()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume("&") {
+		right, err := p.parseFollowedBy()
+		if err != nil {
+			return nil, err
+		}
+		left = &tsNode{op: andOp, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *tsQueryParser) parseFollowedBy() (*tsNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		distance, ok, err := p.followedByOperator()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &tsNode{op: followedByOp, distance: distance, left: left, right: right}
+	}
+}
+
+// followedByOperator consumes a followed by operator, if there is one, and
+// returns its distance.
+func (p *tsQueryParser) followedByOperator() (distance uint16, ok bool, _ error) {
+	if p.consume("<->") {
+		return 1, true, nil
+	}
+	if !p.consume("<") {
+		return 0, false, nil
+	}
+	start := p.l.pos
+	for !p.l.done() && p.l.peek() >= '0' && p.l.peek() <= '9' {
+		p.l.pos++
+	}
+	n, err := strconv.Atoi(p.l.input[start:p.l.pos])
+	if err != nil || p.l.done() || p.l.peek() != '>' {
+		return 0, false, p.l.syntaxError()
+	}
+	p.l.pos++
+	if n > maxFollowedByDistance {
+		return 0, false, pgerror.Newf(pgcode.InvalidParameterValue,
+			"distance in phrase operator must be an integer value between zero and %d inclusive",
+			maxFollowedByDistance)
+	}
+	return uint16(n), true, nil
+}
+
+func (p *tsQueryParser) parseNot() (*tsNode, error) {
+	if p.consume("!") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &tsNode{op: notOp, left: operand}, nil
+	}
+	return p.parseOperand()
+}
+
+func (p *tsQueryParser) parseOperand() (*tsNode, error) {
+	if p.consume("(") {
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, p.l.syntaxError()
+		}
+		return n, nil
+	}
+	p.l.skipSpaces()
+	if p.l.done() || isQueryOperator(p.l.peek()) {
+		return nil, p.l.syntaxError()
+	}
+	lexeme, err := p.l.lexeme()
+	if err != nil {
+		return nil, err
+	}
+	n := &tsNode{op: lexemeOp, lexeme: lexeme}
+	if !p.l.done() && p.l.peek() == ':' {
+		p.l.pos++
+		for !p.l.done() {
+			c := p.l.peek()
+			if c == '*' {
+				n.prefix = true
+			} else if w, ok := parseWeight(c); ok {
+				n.weights |= 1 << w
+			} else {
+				break
+			}
+			p.l.pos++
+		}
+	}
+	return n, nil
+}
+
+// NumNodes returns the number of lexemes and operators of the tsquery.
+func (q TSQuery) NumNodes() int {
+	var count func(n *tsNode) int
+	count = func(n *tsNode) int {
+		if n == nil {
+			return 0
+		}
+		return 1 + count(n.left) + count(n.right)
+	}
+	return count(q.root)
+}
+
+// MemSize returns the size of the tsquery in memory.
+func (q TSQuery) MemSize() uintptr {
+	var size func(n *tsNode) uintptr
+	size = func(n *tsNode) uintptr {
+		if n == nil {
+			return 0
+		}
+		return unsafe.Sizeof(*n) + uintptr(len(n.lexeme)) + size(n.left) + size(n.right)
+	}
+	return size(q.root)
+}
+
+// lexemes returns the distinct lexemes of the tsquery, in order.
+func (q TSQuery) lexemes() []*tsNode {
+	var res []*tsNode
+	seen := make(map[string]struct{})
+	var visit func(n *tsNode)
+	visit = func(n *tsNode) {
+		if n == nil {
+			return
+		}
+		if n.op == lexemeOp {
+			if _, ok := seen[n.lexeme]; !ok {
+				seen[n.lexeme] = struct{}{}
+				res = append(res, n)
+			}
+			return
+		}
+		visit(n.left)
+		visit(n.right)
+	}
+	visit(q.root)
+	return res
+}
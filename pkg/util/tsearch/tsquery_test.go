@@ -0,0 +1,135 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTSQuery(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		err      string
+	}{
+		{input: ``, expected: ``},
+		{input: `   `, expected: ``},
+		{input: `a`, expected: `'a'`},
+		{input: `'a b'`, expected: `'a b'`},
+		{input: `a & b`, expected: `'a' & 'b'`},
+		{input: `a&b|c`, expected: `'a' & 'b' | 'c'`},
+		{input: `a&(b|c)`, expected: `'a' & ( 'b' | 'c' )`},
+		{input: `(a|b)&(c|d)`, expected: `( 'a' | 'b' ) & ( 'c' | 'd' )`},
+		{input: `a | b & c`, expected: `'a' | 'b' & 'c'`},
+		{input: `!a`, expected: `!'a'`},
+		{input: `!!a`, expected: `!!'a'`},
+		{input: `!(a|b)`, expected: `!( 'a' | 'b' )`},
+		{input: `a <-> b`, expected: `'a' <-> 'b'`},
+		{input: `a <2> b`, expected: `'a' <2> 'b'`},
+		{input: `a <0> b`, expected: `'a' <0> 'b'`},
+		{input: `a <-> b <-> c`, expected: `'a' <-> 'b' <-> 'c'`},
+		{input: `a <-> (b <-> c)`, expected: `'a' <-> ( 'b' <-> 'c' )`},
+		{input: `a <-> b & c`, expected: `'a' <-> 'b' & 'c'`},
+		{input: `a <-> (b & c)`, expected: `'a' <-> ( 'b' & 'c' )`},
+		{input: `a:*`, expected: `'a':*`},
+		{input: `a:ab`, expected: `'a':AB`},
+		{input: `a:*Ba`, expected: `'a':*AB`},
+		{input: `a:*AB & b:c`, expected: `'a':*AB & 'b':C`},
+
+		{input: `&`, err: `syntax error in tsquery`},
+		{input: `a &`, err: `syntax error in tsquery`},
+		{input: `a b`, err: `syntax error in tsquery`},
+		{input: `(a`, err: `syntax error in tsquery`},
+		{input: `a)`, err: `syntax error in tsquery`},
+		{input: `a <> b`, err: `syntax error in tsquery`},
+		{input: `a <1 b`, err: `syntax error in tsquery`},
+		{input: `a <16385> b`, err: `distance in phrase operator must be an integer value between zero and 16384 inclusive`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			q, err := ParseTSQuery(tc.input)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, q.String())
+
+			// The textual representation must round trip.
+			q2, err := DecodeTSQuery(EncodeTSQuery(nil, q))
+			require.NoError(t, err)
+			require.Equal(t, q.String(), q2.String())
+		})
+	}
+}
+
+func TestNumNodes(t *testing.T) {
+	for input, expected := range map[string]int{
+		``:                  0,
+		`a`:                 1,
+		`a & b`:             3,
+		`!a | b <-> c:*`:    6,
+		`(a | b) & (c | d)`: 7,
+	} {
+		q, err := ParseTSQuery(input)
+		require.NoError(t, err)
+		require.Equal(t, expected, q.NumNodes(), input)
+	}
+}
+
+func TestToTSQuery(t *testing.T) {
+	testCases := []struct {
+		input   string
+		toTS    string
+		plain   string
+		phrase  string
+		toTSErr string
+	}{
+		{input: ``, toTS: ``, plain: ``, phrase: ``},
+		{input: `Fat`, toTS: `'fat'`, plain: `'fat'`, phrase: `'fat'`},
+		{input: `Fat & Rats`, toTS: `'fat' & 'rats'`, plain: `'fat' & 'rats'`, phrase: `'fat' <-> 'rats'`},
+		{input: `fat | !rat:*A`, toTS: `'fat' | !'rat':*A`, plain: `'fat' & 'rat' & 'a'`,
+			phrase: `'fat' <-> 'rat' <-> 'a'`},
+		{input: `'fat rats' & cat`, toTS: `'fat' <-> 'rats' & 'cat'`, plain: `'fat' & 'rats' & 'cat'`,
+			phrase: `'fat' <-> 'rats' <-> 'cat'`},
+		{input: `'-' & cat`, toTS: `'cat'`, plain: `'cat'`, phrase: `'cat'`},
+		{input: `!'-'`, toTS: ``, plain: ``, phrase: ``},
+		{input: `fat rats`, toTSErr: `syntax error in tsquery`, plain: `'fat' & 'rats'`,
+			phrase: `'fat' <-> 'rats'`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			q, err := ToTSQuery(DefaultConfig, tc.input)
+			if tc.toTSErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.toTSErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.toTS, q.String())
+			}
+
+			q, err = PlainToTSQuery(DefaultConfig, tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.plain, q.String())
+
+			q, err = PhraseToTSQuery(DefaultConfig, tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.phrase, q.String())
+		})
+	}
+
+	_, err := ToTSQuery("pg_catalog.english", "a")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `text search configuration "pg_catalog.english" does not exist`)
+}
@@ -0,0 +1,287 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// tsWeight is the weight of a lexeme position in a tsvector. Weights are used
+// to restrict the matches of a tsquery to some parts of a document, and to rank
+// the matches. D is the default weight.
+type tsWeight uint8
+
+const (
+	weightD tsWeight = iota
+	weightC
+	weightB
+	weightA
+)
+
+func (w tsWeight) String() string {
+	return string("DCBA"[w])
+}
+
+// tsWeightMask is a set of weights. It is used by tsquery lexemes to restrict
+// the positions they match. The empty mask matches all the weights.
+type tsWeightMask uint8
+
+func (m tsWeightMask) contains(w tsWeight) bool {
+	return m == 0 || m&(1<<w) != 0
+}
+
+func (m tsWeightMask) String() string {
+	var buf strings.Builder
+	for w := weightA; ; w-- {
+		if m&(1<<w) != 0 {
+			buf.WriteString(w.String())
+		}
+		if w == weightD {
+			break
+		}
+	}
+	return buf.String()
+}
+
+// parseWeight returns the weight corresponding to the given letter.
+func parseWeight(c byte) (tsWeight, bool) {
+	switch c {
+	case 'A', 'a':
+		return weightA, true
+	case 'B', 'b':
+		return weightB, true
+	case 'C', 'c':
+		return weightC, true
+	case 'D', 'd':
+		return weightD, true
+	}
+	return 0, false
+}
+
+const (
+	// maxTSPosition is the largest position of a lexeme in a tsvector. Larger
+	// positions are silently reduced to it, like in Postgres.
+	maxTSPosition = 1<<14 - 1
+	// maxTSPositions is the largest number of positions of a lexeme in a
+	// tsvector. Extra positions are silently dropped, like in Postgres.
+	maxTSPositions = 256
+	// maxTSLexemeLen is the largest length in bytes of a lexeme.
+	maxTSLexemeLen = 1<<11 - 1
+)
+
+// tsPosition is a position of a lexeme in a document, along with its weight.
+// Positions start at 1.
+type tsPosition struct {
+	position uint16
+	weight   tsWeight
+}
+
+// tsTerm is a lexeme of a tsvector, along with the positions at which it
+// appears in the document. The positions may be empty, for example if they
+// were removed with strip().
+type tsTerm struct {
+	lexeme    string
+	positions []tsPosition
+}
+
+// TSVector is the representation of a document optimized for text search: a
+// sorted list of distinct lexemes, along with their positions in the document.
+type TSVector []tsTerm
+
+// String returns the textual representation of the tsvector, which is the
+// same as in Postgres, for example 'a':1A 'cat':5 'fat':2B,4C.
+func (v TSVector) String() string {
+	var buf strings.Builder
+	for i := range v {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		writeLexeme(&buf, v[i].lexeme)
+		for j, p := range v[i].positions {
+			if j == 0 {
+				buf.WriteByte(':')
+			} else {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(strconv.Itoa(int(p.position)))
+			if p.weight != weightD {
+				buf.WriteString(p.weight.String())
+			}
+		}
+	}
+	return buf.String()
+}
+
+// writeLexeme writes the quoted representation of a lexeme.
+func writeLexeme(buf *strings.Builder, lexeme string) {
+	buf.WriteByte('\'')
+	for i := 0; i < len(lexeme); i++ {
+		c := lexeme[i]
+		if c == '\'' || c == '\\' {
+			buf.WriteByte(c)
+		}
+		buf.WriteByte(c)
+	}
+	buf.WriteByte('\'')
+}
+
+// ParseTSVector parses the textual representation of a tsvector. The input is
+// a list of lexemes separated by whitespace, each optionally followed by a
+// colon and a comma-separated list of positions, with an optional weight
+// letter after each position. Lexemes containing whitespace or special
+// characters can be quoted with single quotes. Unlike to_tsvector, no
+// normalization is applied to the lexemes.
+func ParseTSVector(input string) (TSVector, error) {
+	l := lexer{input: input, kind: "tsvector"}
+	var v TSVector
+	for {
+		l.skipSpaces()
+		if l.done() {
+			break
+		}
+		lexeme, err := l.lexeme()
+		if err != nil {
+			return nil, err
+		}
+		term := tsTerm{lexeme: lexeme}
+		if !l.done() && l.peek() == ':' {
+			l.pos++
+			if term.positions, err = l.positions(); err != nil {
+				return nil, err
+			}
+		}
+		if !l.done() && !isSpace(l.peek()) {
+			return nil, l.syntaxError()
+		}
+		v = append(v, term)
+	}
+	return normalizeTSVector(v), nil
+}
+
+// normalizeTSVector sorts the terms of a tsvector and merges the duplicate
+// lexemes. The positions of each lexeme are sorted and deduplicated, keeping
+// the highest weight of duplicate positions.
+func normalizeTSVector(v TSVector) TSVector {
+	sort.SliceStable(v, func(i, j int) bool {
+		return v[i].lexeme < v[j].lexeme
+	})
+	res := v[:0]
+	for i := range v {
+		if len(res) > 0 && res[len(res)-1].lexeme == v[i].lexeme {
+			last := &res[len(res)-1]
+			last.positions = append(last.positions, v[i].positions...)
+		} else {
+			res = append(res, v[i])
+		}
+	}
+	for i := range res {
+		res[i].positions = normalizePositions(res[i].positions)
+	}
+	return res
+}
+
+func normalizePositions(positions []tsPosition) []tsPosition {
+	if len(positions) == 0 {
+		return nil
+	}
+	sort.SliceStable(positions, func(i, j int) bool {
+		return positions[i].position < positions[j].position
+	})
+	res := positions[:1]
+	for _, p := range positions[1:] {
+		last := &res[len(res)-1]
+		if p.position == last.position {
+			if p.weight > last.weight {
+				last.weight = p.weight
+			}
+			continue
+		}
+		res = append(res, p)
+	}
+	if len(res) > maxTSPositions {
+		res = res[:maxTSPositions]
+	}
+	return res
+}
+
+// positionCount returns the number of positions of the lexemes of the tsvector,
+// counting lexemes with no positions as one position, like the length used by
+// Postgres to normalize the ranks.
+func (v TSVector) positionCount() int {
+	n := 0
+	for i := range v {
+		if len(v[i].positions) == 0 {
+			n++
+		} else {
+			n += len(v[i].positions)
+		}
+	}
+	return n
+}
+
+// find returns the terms of the tsvector that match the given lexeme, which
+// are the terms that start with the lexeme if prefix is true.
+func (v TSVector) find(lexeme string, prefix bool) []tsTerm {
+	i := sort.Search(len(v), func(i int) bool {
+		return v[i].lexeme >= lexeme
+	})
+	j := i
+	for j < len(v) {
+		if v[j].lexeme == lexeme || (prefix && strings.HasPrefix(v[j].lexeme, lexeme)) {
+			j++
+			continue
+		}
+		break
+	}
+	return v[i:j]
+}
+
+// Strip returns a copy of the tsvector without the positions and weights of
+// its lexemes.
+func (v TSVector) Strip() TSVector {
+	res := make(TSVector, len(v))
+	for i := range v {
+		res[i].lexeme = v[i].lexeme
+	}
+	return res
+}
+
+// SetWeight returns a copy of the tsvector where the weight of all the
+// positions is set to the given weight letter.
+func (v TSVector) SetWeight(weight byte) (TSVector, error) {
+	w, ok := parseWeight(weight)
+	if !ok {
+		return nil, errUnrecognizedWeight(weight)
+	}
+	res := make(TSVector, len(v))
+	for i := range v {
+		res[i].lexeme = v[i].lexeme
+		if len(v[i].positions) > 0 {
+			res[i].positions = make([]tsPosition, len(v[i].positions))
+			for j, p := range v[i].positions {
+				res[i].positions[j] = tsPosition{position: p.position, weight: w}
+			}
+		}
+	}
+	return res, nil
+}
+
+// MemSize returns the size of the tsvector in memory.
+func (v TSVector) MemSize() uintptr {
+	size := uintptr(cap(v)) * unsafe.Sizeof(tsTerm{})
+	for i := range v {
+		size += uintptr(len(v[i].lexeme)) + uintptr(cap(v[i].positions))*unsafe.Sizeof(tsPosition{})
+	}
+	return size
+}
@@ -0,0 +1,150 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTSVector(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		err      string
+	}{
+		{input: ``, expected: ``},
+		{input: `  `, expected: ``},
+		{input: `a`, expected: `'a'`},
+		{input: `fat cat sat on a mat`, expected: `'a' 'cat' 'fat' 'mat' 'on' 'sat'`},
+		{input: `b a b`, expected: `'a' 'b'`},
+		{input: `a:1 fat:2,4 cat:3`, expected: `'a':1 'cat':3 'fat':2,4`},
+		{input: `a:3,1,2,1`, expected: `'a':1,2,3`},
+		{input: `a:1A,2b,3C,4d`, expected: `'a':1A,2B,3C,4`},
+		{input: `a:1A a:1B a:2`, expected: `'a':1A,2`},
+		{input: `a b:2 b`, expected: `'a' 'b':2`},
+		{input: `a:20000`, expected: `'a':16383`},
+		{input: `a:1*`, expected: `'a':1`},
+		{input: `'a b' 'it''s'`, expected: `'a b' 'it''s'`},
+		{input: `'a\'b' a\ b`, expected: `'a b' 'a''b'`},
+		{input: `'back\\slash'`, expected: `'back\\slash'`},
+		{input: `'a':1`, expected: `'a':1`},
+		{input: "a\tb\nc", expected: `'a' 'b' 'c'`},
+		{input: `a&b`, expected: `'a&b'`},
+
+		{input: `''`, err: `syntax error in tsvector`},
+		{input: `'a`, err: `syntax error in tsvector`},
+		{input: `a'b`, err: `syntax error in tsvector`},
+		{input: `a:`, err: `syntax error in tsvector`},
+		{input: `a:1,`, err: `syntax error in tsvector`},
+		{input: `a:x`, err: `syntax error in tsvector`},
+		{input: `a:1x`, err: `syntax error in tsvector`},
+		{input: `a:0`, err: `wrong position info in tsvector`},
+		{input: `a\`, err: `syntax error in tsvector`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			v, err := ParseTSVector(tc.input)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, v.String())
+
+			// The textual representation must round trip.
+			v2, err := ParseTSVector(v.String())
+			require.NoError(t, err)
+			require.Equal(t, v, v2)
+		})
+	}
+}
+
+func TestParseTSVectorLongLexeme(t *testing.T) {
+	lexeme := make([]byte, maxTSLexemeLen+1)
+	for i := range lexeme {
+		lexeme[i] = 'a'
+	}
+	_, err := ParseTSVector(string(lexeme))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "word is too long")
+
+	v, err := ParseTSVector(string(lexeme[:maxTSLexemeLen]))
+	require.NoError(t, err)
+	require.Len(t, v, 1)
+}
+
+func TestTSVectorStripAndSetWeight(t *testing.T) {
+	v, err := ParseTSVector(`a:1A,3 b:2C c`)
+	require.NoError(t, err)
+
+	require.Equal(t, `'a' 'b' 'c'`, v.Strip().String())
+
+	w, err := v.SetWeight('b')
+	require.NoError(t, err)
+	require.Equal(t, `'a':1B,3B 'b':2B 'c'`, w.String())
+	w, err = v.SetWeight('D')
+	require.NoError(t, err)
+	require.Equal(t, `'a':1,3 'b':2 'c'`, w.String())
+	_, err = v.SetWeight('e')
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unrecognized weight")
+
+	// The original tsvector must not be modified.
+	require.Equal(t, `'a':1A,3 'b':2C 'c'`, v.String())
+}
+
+func TestDocumentToTSVector(t *testing.T) {
+	testCases := []struct {
+		document string
+		expected string
+	}{
+		{document: ``, expected: ``},
+		{document: `The Fat Rats`, expected: `'fat':2 'rats':3 'the':1`},
+		{document: `a fat cat sat on a mat - it ate a fat rat`,
+			expected: `'a':1,6,10 'ate':9 'cat':3 'fat':2,11 'it':8 'mat':7 'on':5 'rat':12 'sat':4`},
+		{document: `isn't it?`, expected: `'isn':1 'it':3 't':2`},
+		{document: `Ünïcode wörds 42`, expected: `'42':3 'wörds':2 'ünïcode':1`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.document, func(t *testing.T) {
+			v, err := DocumentToTSVector(DefaultConfig, tc.document)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, v.String())
+		})
+	}
+
+	_, err := DocumentToTSVector("english", "a")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `text search configuration "english" does not exist`)
+}
+
+func TestTSVectorEncoding(t *testing.T) {
+	for _, input := range []string{
+		``,
+		`a`,
+		`a:1A,2B,3C,4 b c:16383`,
+		`'it''s' 'a b':5`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			v, err := ParseTSVector(input)
+			require.NoError(t, err)
+			decoded, err := DecodeTSVector(EncodeTSVector(nil, v))
+			require.NoError(t, err)
+			require.Equal(t, v.String(), decoded.String())
+		})
+	}
+
+	_, err := DecodeTSVector([]byte{0x8a})
+	require.Error(t, err)
+}
@@ -27,6 +27,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/server/pgurl"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/log/logconfig"
@@ -199,7 +200,12 @@ func CmdHelper(
 			dbOverride = dbFlag.Value.String()
 		}
 
-		urls := args
+		var urls []string
+		for _, arg := range args {
+			// A URL can list multiple nodes, e.g. postgres://root@n1,n2:26258.
+			// The nodes without a port use the CockroachDB default port.
+			urls = append(urls, pgurl.ExpandMultiHostURL(arg, "26257")...)
+		}
 		if len(urls) == 0 {
 			crdbDefaultURL := fmt.Sprintf(`postgres://%s@localhost:26257?sslmode=disable`, *user)
 			if *secure {
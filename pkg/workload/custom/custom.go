@@ -0,0 +1,178 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package custom
+
+import (
+	"context"
+	gosql "database/sql"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/workload"
+	"github.com/cockroachdb/cockroach/pkg/workload/histogram"
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/pflag"
+	"golang.org/x/exp/rand"
+)
+
+type custom struct {
+	flags     workload.Flags
+	connFlags *workload.ConnFlags
+
+	specFile string
+	seed     uint64
+
+	spec *spec
+}
+
+func init() {
+	workload.Register(customMeta)
+}
+
+var customMeta = workload.Meta{
+	Name: `custom`,
+	Description: `Custom runs the workload declared in the YAML file specified ` +
+		`with --spec: its tables, the distributions of their values, and a weighted ` +
+		`mix of statements.`,
+	Version: `1.0.0`,
+	New: func() workload.Generator {
+		g := &custom{}
+		g.flags.FlagSet = pflag.NewFlagSet(`custom`, pflag.ContinueOnError)
+		g.flags.StringVar(&g.specFile, `spec`, ``, `YAML file declaring the tables and the statements of the workload`)
+		g.flags.Uint64Var(&g.seed, `seed`, 1, `Random number generator seed`)
+		g.connFlags = workload.NewConnFlags(&g.flags)
+		return g
+	},
+}
+
+// Meta implements the Generator interface.
+func (*custom) Meta() workload.Meta { return customMeta }
+
+// Flags implements the Flagser interface.
+func (g *custom) Flags() workload.Flags { return g.flags }
+
+// Hooks implements the Hookser interface.
+func (g *custom) Hooks() workload.Hooks {
+	return workload.Hooks{
+		Validate: func() error {
+			if g.specFile == "" {
+				return errors.Errorf("Missing required argument '--spec'")
+			}
+			s, err := readSpec(g.specFile)
+			if err != nil {
+				return err
+			}
+			g.spec = s
+			return nil
+		},
+	}
+}
+
+// Tables implements the Generator interface.
+func (g *custom) Tables() []workload.Table {
+	if g.spec == nil {
+		// The spec is read by the Validate hook.
+		return []workload.Table{}
+	}
+	tables := make([]workload.Table, len(g.spec.Tables))
+	for i := range g.spec.Tables {
+		t := &g.spec.Tables[i]
+		typs := make([]*types.T, len(t.Columns))
+		gens := make([]valueGen, len(t.Columns))
+		for j := range t.Columns {
+			typs[j] = sqlTypes[t.Columns[j].Type]
+			gens[j] = t.Columns[j].gen()
+		}
+		tableSeed := g.seed + uint64(i)<<32
+		tables[i] = workload.Table{
+			Name:   t.Name,
+			Schema: t.schema(),
+			InitialRows: workload.TypedTuples(t.Rows, typs, func(rowIdx int) []interface{} {
+				rng := rand.New(rand.NewSource(tableSeed + uint64(rowIdx)))
+				row := make([]interface{}, len(gens))
+				for j, gen := range gens {
+					row[j] = gen(rng, rowIdx)
+				}
+				return row
+			}),
+		}
+	}
+	return tables
+}
+
+// Ops implements the Opser interface.
+func (g *custom) Ops(
+	ctx context.Context, urls []string, reg *histogram.Registry,
+) (workload.QueryLoad, error) {
+	if len(g.spec.Statements) == 0 {
+		return workload.QueryLoad{}, errors.New("no statements specified in the spec")
+	}
+	sqlDatabase, err := workload.SanitizeUrls(g, g.connFlags.DBOverride, urls)
+	if err != nil {
+		return workload.QueryLoad{}, err
+	}
+	db, err := gosql.Open(`cockroach`, strings.Join(urls, ` `))
+	if err != nil {
+		return workload.QueryLoad{}, err
+	}
+	// Allow a maximum of concurrency+1 connections to the database.
+	db.SetMaxOpenConns(g.connFlags.Concurrency + 1)
+	db.SetMaxIdleConns(g.connFlags.Concurrency + 1)
+
+	stmts := make([]*gosql.Stmt, len(g.spec.Statements))
+	argGens := make([][]valueGen, len(g.spec.Statements))
+	var totalWeight int
+	for i := range g.spec.Statements {
+		st := &g.spec.Statements[i]
+		if stmts[i], err = db.Prepare(st.SQL); err != nil {
+			return workload.QueryLoad{}, errors.Wrapf(err, "preparing statement %s", st.Name)
+		}
+		for j := range st.Args {
+			argGens[i] = append(argGens[i], st.Args[j].gen())
+		}
+		totalWeight += st.Weight
+	}
+
+	ql := workload.QueryLoad{SQLDatabase: sqlDatabase}
+	for i := 0; i < g.connFlags.Concurrency; i++ {
+		rng := rand.New(rand.NewSource(g.seed + uint64(i)))
+		hists := reg.GetHandle()
+		var opIdx int
+		workerFn := func(ctx context.Context) error {
+			stmtIdx := pickStatement(g.spec.Statements, rng.Intn(totalWeight))
+			args := make([]interface{}, len(argGens[stmtIdx]))
+			for j, gen := range argGens[stmtIdx] {
+				args[j] = gen(rng, opIdx)
+			}
+			opIdx++
+			start := timeutil.Now()
+			_, err := stmts[stmtIdx].ExecContext(ctx, args...)
+			elapsed := timeutil.Since(start)
+			hists.Get(g.spec.Statements[stmtIdx].Name).Record(elapsed)
+			return err
+		}
+		ql.WorkerFns = append(ql.WorkerFns, workerFn)
+	}
+	return ql, nil
+}
+
+// pickStatement returns the index of the statement that the weight w,
+// in [0, sum of the weights), falls into.
+func pickStatement(stmts []statementSpec, w int) int {
+	for i := range stmts {
+		if w < stmts[i].Weight {
+			return i
+		}
+		w -= stmts[i].Weight
+	}
+	panic(errors.AssertionFailedf("weight out of range"))
+}
@@ -0,0 +1,329 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package custom
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+	"golang.org/x/exp/rand"
+	"gopkg.in/yaml.v2"
+)
+
+// spec is the declarative definition of a custom workload, as read from
+// the file passed to --spec. For example:
+//
+//   tables:
+//   - name: users
+//     rows: 1000
+//     primary_key: [id]
+//     columns:
+//     - {name: id, type: int, distribution: sequential}
+//     - {name: age, type: int, distribution: uniform, min: 18, max: 90}
+//     - {name: name, type: string, distribution: random, length: 12}
+//   statements:
+//   - name: lookup
+//     weight: 9
+//     sql: SELECT name FROM users WHERE id = $1
+//     args:
+//     - {type: int, distribution: zipf, min: 0, max: 999}
+//   - name: birthday
+//     weight: 1
+//     sql: UPDATE users SET age = age + 1 WHERE id = $1
+//     args:
+//     - {type: int, distribution: uniform, min: 0, max: 999}
+type spec struct {
+	Tables     []tableSpec     `yaml:"tables"`
+	Statements []statementSpec `yaml:"statements"`
+}
+
+// tableSpec declares a table and the distributions of the values of its
+// initial rows.
+type tableSpec struct {
+	Name       string       `yaml:"name"`
+	Rows       int          `yaml:"rows"`
+	PrimaryKey []string     `yaml:"primary_key"`
+	Columns    []columnSpec `yaml:"columns"`
+}
+
+// columnSpec declares a column of a table.
+type columnSpec struct {
+	Name      string `yaml:"name"`
+	valueSpec `yaml:",inline"`
+}
+
+// statementSpec declares a statement of the mix run by the workload.
+// Each operation runs one statement, chosen at random in proportion to
+// the weights of the statements.
+type statementSpec struct {
+	Name   string      `yaml:"name"`
+	Weight int         `yaml:"weight"`
+	SQL    string      `yaml:"sql"`
+	Args   []valueSpec `yaml:"args"`
+}
+
+// valueSpec declares the type and the distribution of the values of a
+// column or of a statement placeholder.
+type valueSpec struct {
+	// Type is one of int, float, string or bool.
+	Type string `yaml:"type"`
+	// Distribution is one of:
+	//  - sequential (int): min plus the index of the row, or of the
+	//    operation of the worker for statement arguments.
+	//  - uniform (int, float, bool): uniform in [min, max].
+	//  - zipf (int): zipfian in [min, max] with the given skew, the
+	//    smaller values being the most frequent.
+	//  - normal (int, float): normal with the given mean and stddev.
+	//  - random (string): random letters of the given length.
+	//  - choice (string): one of the given values, chosen uniformly.
+	Distribution string   `yaml:"distribution"`
+	Min          float64  `yaml:"min"`
+	Max          float64  `yaml:"max"`
+	Skew         float64  `yaml:"skew"`
+	Mean         float64  `yaml:"mean"`
+	StdDev       float64  `yaml:"stddev"`
+	Length       int      `yaml:"length"`
+	Values       []string `yaml:"values"`
+	// NullFraction is the fraction of the values that are NULL.
+	NullFraction float64 `yaml:"null_fraction"`
+}
+
+// defaultZipfSkew is the skew of the zipf distribution if unspecified.
+const defaultZipfSkew = 1.1
+
+var identRE = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// readSpec reads and validates the spec in the given file.
+func readSpec(path string) (*spec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := parseSpec(data)
+	return s, errors.Wrapf(err, "invalid spec %s", path)
+}
+
+// parseSpec parses and validates a spec.
+func parseSpec(data []byte) (*spec, error) {
+	var s spec
+	if err := yaml.UnmarshalStrict(data, &s); err != nil {
+		return nil, err
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (s *spec) validate() error {
+	if len(s.Tables) == 0 && len(s.Statements) == 0 {
+		return errors.New("no tables or statements specified")
+	}
+	tableNames := map[string]bool{}
+	for _, t := range s.Tables {
+		if !identRE.MatchString(t.Name) {
+			return errors.Newf("invalid table name %q", t.Name)
+		}
+		if tableNames[t.Name] {
+			return errors.Newf("duplicate table %s", t.Name)
+		}
+		tableNames[t.Name] = true
+		if t.Rows < 0 {
+			return errors.Newf("table %s: negative number of rows", t.Name)
+		}
+		if len(t.Columns) == 0 {
+			return errors.Newf("table %s: no columns specified", t.Name)
+		}
+		colNames := map[string]bool{}
+		for _, c := range t.Columns {
+			if !identRE.MatchString(c.Name) {
+				return errors.Newf("table %s: invalid column name %q", t.Name, c.Name)
+			}
+			if colNames[c.Name] {
+				return errors.Newf("table %s: duplicate column %s", t.Name, c.Name)
+			}
+			colNames[c.Name] = true
+			if err := c.validate(); err != nil {
+				return errors.Wrapf(err, "table %s: column %s", t.Name, c.Name)
+			}
+		}
+		for _, name := range t.PrimaryKey {
+			if !colNames[name] {
+				return errors.Newf("table %s: unknown primary key column %q", t.Name, name)
+			}
+		}
+	}
+	stmtNames := map[string]bool{}
+	for _, st := range s.Statements {
+		if st.Name == "" {
+			return errors.Newf("statement %q: no name specified", st.SQL)
+		}
+		if stmtNames[st.Name] {
+			return errors.Newf("duplicate statement %s", st.Name)
+		}
+		stmtNames[st.Name] = true
+		if st.SQL == "" {
+			return errors.Newf("statement %s: no sql specified", st.Name)
+		}
+		if st.Weight <= 0 {
+			return errors.Newf("statement %s: weight must be positive", st.Name)
+		}
+		for i, a := range st.Args {
+			if err := a.validate(); err != nil {
+				return errors.Wrapf(err, "statement %s: argument $%d", st.Name, i+1)
+			}
+		}
+	}
+	return nil
+}
+
+// sqlTypes maps the supported value types to their SQL type.
+var sqlTypes = map[string]*types.T{
+	"int":    types.Int,
+	"float":  types.Float,
+	"string": types.String,
+	"bool":   types.Bool,
+}
+
+// validDistributions maps each value type to its supported distributions.
+var validDistributions = map[string][]string{
+	"int":    {"sequential", "uniform", "zipf", "normal"},
+	"float":  {"uniform", "normal"},
+	"string": {"random", "choice"},
+	"bool":   {"uniform"},
+}
+
+func (v *valueSpec) validate() error {
+	dists, ok := validDistributions[v.Type]
+	if !ok {
+		return errors.Newf("unsupported type %q (possible values: int, float, string, bool)", v.Type)
+	}
+	found := false
+	for _, d := range dists {
+		found = found || d == v.Distribution
+	}
+	if !found {
+		return errors.Newf("unsupported distribution %q for type %s (possible values: %s)",
+			v.Distribution, v.Type, strings.Join(dists, ", "))
+	}
+	switch v.Distribution {
+	case "uniform", "zipf":
+		if v.Type != "bool" && v.Min > v.Max {
+			return errors.Newf("min (%v) is greater than max (%v)", v.Min, v.Max)
+		}
+		if v.Distribution == "zipf" && v.Skew != 0 && v.Skew <= 1 {
+			return errors.Newf("zipf skew must be greater than 1, got %v", v.Skew)
+		}
+	case "normal":
+		if v.StdDev < 0 {
+			return errors.Newf("negative stddev %v", v.StdDev)
+		}
+	case "random":
+		if v.Length <= 0 {
+			return errors.New("length must be positive")
+		}
+	case "choice":
+		if len(v.Values) == 0 {
+			return errors.New("no values specified")
+		}
+	}
+	if v.NullFraction < 0 || v.NullFraction > 1 {
+		return errors.Newf("null_fraction must be between 0 and 1, got %v", v.NullFraction)
+	}
+	return nil
+}
+
+// valueGen generates a value given a random number generator and the
+// index of the row or the operation.
+type valueGen func(rng *rand.Rand, idx int) interface{}
+
+// gen returns the generator of the values. The spec must be valid.
+func (v *valueSpec) gen() valueGen {
+	var g valueGen
+	switch v.Distribution {
+	case "sequential":
+		g = func(_ *rand.Rand, idx int) interface{} { return int(v.Min) + idx }
+	case "uniform":
+		switch v.Type {
+		case "int":
+			min, n := int(v.Min), int(v.Max)-int(v.Min)+1
+			g = func(rng *rand.Rand, _ int) interface{} { return min + rng.Intn(n) }
+		case "float":
+			g = func(rng *rand.Rand, _ int) interface{} { return v.Min + rng.Float64()*(v.Max-v.Min) }
+		case "bool":
+			g = func(rng *rand.Rand, _ int) interface{} { return rng.Intn(2) == 1 }
+		}
+	case "zipf":
+		skew := v.Skew
+		if skew == 0 {
+			skew = defaultZipfSkew
+		}
+		min, imax := int(v.Min), uint64(int(v.Max)-int(v.Min))
+		g = func(rng *rand.Rand, _ int) interface{} {
+			return min + int(rand.NewZipf(rng, skew, 1, imax).Uint64())
+		}
+	case "normal":
+		if v.Type == "int" {
+			g = func(rng *rand.Rand, _ int) interface{} {
+				return int(math.Round(rng.NormFloat64()*v.StdDev + v.Mean))
+			}
+		} else {
+			g = func(rng *rand.Rand, _ int) interface{} { return rng.NormFloat64()*v.StdDev + v.Mean }
+		}
+	case "random":
+		g = func(rng *rand.Rand, _ int) interface{} { return randString(rng, v.Length) }
+	case "choice":
+		g = func(rng *rand.Rand, _ int) interface{} { return v.Values[rng.Intn(len(v.Values))] }
+	default:
+		panic(errors.AssertionFailedf("unexpected distribution %q", v.Distribution))
+	}
+	if v.NullFraction == 0 {
+		return g
+	}
+	return func(rng *rand.Rand, idx int) interface{} {
+		if rng.Float64() < v.NullFraction {
+			return nil
+		}
+		return g(rng, idx)
+	}
+}
+
+func randString(rng *rand.Rand, length int) string {
+	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = letters[rng.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+// schema returns the schema of the table, in the format of
+// workload.Table.Schema.
+func (t *tableSpec) schema() string {
+	var buf strings.Builder
+	buf.WriteString("(\n")
+	for i, c := range t.Columns {
+		if i > 0 {
+			buf.WriteString(",\n")
+		}
+		fmt.Fprintf(&buf, "\t%s %s", c.Name, sqlTypes[c.Type].SQLString())
+	}
+	if len(t.PrimaryKey) > 0 {
+		fmt.Fprintf(&buf, ",\n\tPRIMARY KEY (%s)", strings.Join(t.PrimaryKey, ", "))
+	}
+	buf.WriteString("\n)")
+	return buf.String()
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package custom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/rand"
+)
+
+const testSpec = `
+tables:
+- name: users
+  rows: 100
+  primary_key: [id]
+  columns:
+  - {name: id, type: int, distribution: sequential, min: 1}
+  - {name: age, type: int, distribution: uniform, min: 18, max: 90}
+  - {name: score, type: float, distribution: normal, mean: 50, stddev: 10}
+  - {name: name, type: string, distribution: random, length: 8}
+  - {name: country, type: string, distribution: choice, values: [fr, us], null_fraction: 0.5}
+  - {name: active, type: bool, distribution: uniform}
+statements:
+- name: lookup
+  weight: 3
+  sql: SELECT name FROM users WHERE id = $1
+  args:
+  - {type: int, distribution: zipf, min: 1, max: 100}
+- name: update
+  weight: 1
+  sql: UPDATE users SET age = age + 1 WHERE id = $1
+  args:
+  - {type: int, distribution: uniform, min: 1, max: 100}
+`
+
+func TestParseSpec(t *testing.T) {
+	s, err := parseSpec([]byte(testSpec))
+	require.NoError(t, err)
+	require.Len(t, s.Tables, 1)
+	require.Len(t, s.Statements, 2)
+	require.Equal(t, `(
+	id INT8,
+	age INT8,
+	score FLOAT8,
+	name STRING,
+	country STRING,
+	active BOOL,
+	PRIMARY KEY (id)
+)`, s.Tables[0].schema())
+
+	for _, tc := range []struct {
+		spec string
+		err  string
+	}{
+		{``, `no tables or statements specified`},
+		{`tables: [{name: t, columns: [{name: a, type: int, distribution: uniform}]}]
+foo: bar`, `field foo not found`},
+		{`tables: [{name: t-1, columns: [{name: a, type: int, distribution: uniform}]}]`,
+			`invalid table name "t-1"`},
+		{`tables: [{name: t}]`, `table t: no columns specified`},
+		{`tables: [{name: t, columns: [{name: a, type: date, distribution: uniform}]}]`,
+			`table t: column a: unsupported type "date"`},
+		{`tables: [{name: t, columns: [{name: a, type: string, distribution: uniform}]}]`,
+			`table t: column a: unsupported distribution "uniform" for type string`},
+		{`tables: [{name: t, columns: [{name: a, type: int, distribution: uniform, min: 2, max: 1}]}]`,
+			`min \(2\) is greater than max \(1\)`},
+		{`tables: [{name: t, primary_key: [b], columns: [{name: a, type: int, distribution: uniform}]}]`,
+			`unknown primary key column "b"`},
+		{`statements: [{name: s, sql: SELECT 1}]`, `statement s: weight must be positive`},
+		{`statements: [{name: s, weight: 1, sql: SELECT $1, args: [{type: string, distribution: choice}]}]`,
+			`statement s: argument \$1: no values specified`},
+	} {
+		_, err := parseSpec([]byte(tc.spec))
+		require.Error(t, err, tc.spec)
+		require.Regexp(t, tc.err, err.Error())
+	}
+}
+
+func TestValueGen(t *testing.T) {
+	s, err := parseSpec([]byte(testSpec))
+	require.NoError(t, err)
+	cols := s.Tables[0].Columns
+
+	rng := rand.New(rand.NewSource(1))
+	var nulls int
+	for i := 0; i < 1000; i++ {
+		require.Equal(t, i+1, cols[0].gen()(rng, i))
+		age := cols[1].gen()(rng, i).(int)
+		require.True(t, age >= 18 && age <= 90, "%d", age)
+		require.Len(t, cols[3].gen()(rng, i), 8)
+		switch c := cols[4].gen()(rng, i); c {
+		case nil:
+			nulls++
+		default:
+			require.Contains(t, []string{"fr", "us"}, c)
+		}
+		id := s.Statements[0].Args[0].gen()(rng, i).(int)
+		require.True(t, id >= 1 && id <= 100, "%d", id)
+	}
+	require.True(t, nulls > 400 && nulls < 600, "%d", nulls)
+}
+
+func TestPickStatement(t *testing.T) {
+	stmts := []statementSpec{{Weight: 3}, {Weight: 1}, {Weight: 2}}
+	var picked []int
+	for w := 0; w < 6; w++ {
+		picked = append(picked, pickStatement(stmts, w))
+	}
+	require.Equal(t, []int{0, 0, 0, 1, 2, 2}, picked)
+}
@@ -21,12 +21,12 @@ import (
 
 // cockroachDriver is a wrapper around lib/pq which provides for round-robin
 // load balancing amongst a list of URLs. The name passed to Open() is a space
-// separated list of "postgres" URLs to connect to.
+// separated list of "postgres" URLs to connect to. If a node cannot be
+// reached, the connection is opened to the next URL in the list instead.
 //
 // Note that the round-robin load balancing can lead to imbalances in
-// connections across the cluster. This is currently only suitable for
-// simplistic setups where nodes in the cluster are stable and do not go up and
-// down.
+// connections across the cluster, in particular after a node has been
+// unavailable.
 type cockroachDriver struct {
 	idx uint32
 }
@@ -34,7 +34,14 @@ type cockroachDriver struct {
 func (d *cockroachDriver) Open(name string) (driver.Conn, error) {
 	urls := strings.Split(name, " ")
 	i := atomic.AddUint32(&d.idx, 1) - 1
-	return pq.Open(urls[i%uint32(len(urls))])
+	var err error
+	for j := uint32(0); j < uint32(len(urls)); j++ {
+		var conn driver.Conn
+		if conn, err = pq.Open(urls[(i+j)%uint32(len(urls))]); err == nil {
+			return conn, nil
+		}
+	}
+	return nil, err
 }
 
 func init() {